@@ -0,0 +1,282 @@
+package cleanup
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/subcommands"
+	"github.com/slack-go/slack"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/reaper"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// Command is the command for deleting cloud resources leaked by clusters that no longer exist
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	region         string
+	project        string
+	ttl            time.Duration
+	namePrefix     string
+	clusterIDTag   string
+	clusterNameTag string
+	dryRun         bool
+	output         string
+	interval       time.Duration
+	environments   string
+
+	subcommands.Command
+}
+
+// Name is the name of the cleanup command
+func (*Command) Name() string {
+	return "cleanup"
+}
+
+// Synopsis is a short summary of the cleanup command
+func (*Command) Synopsis() string {
+	return "Deletes cloud resources leaked by osde2e clusters that no longer exist, or orphaned osde2e clusters."
+}
+
+// Usage describes how the cleanup command is used
+func (*Command) Usage() string {
+	return "cleanup [-configs ...] [-custom-config ...] [-config-format ...] [-region r] [-project p] [-ttl 24h] [-name-prefix p] [-cluster-id-tag t] [-cluster-name-tag t] [-dry-run] [-output report.yaml] [-interval 1h] [-environments int,stage,prod] aws|gcp|clusters"
+}
+
+// SetFlags describes the arguments used by the cleanup command
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.configString, "configs", "", "A comma separated list of built in configs to use")
+	f.StringVar(&c.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&c.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.StringVar(&c.region, "region", "", "AWS region to clean up. Defaults to the configured cloud provider region")
+	f.StringVar(&c.project, "project", "", "GCP project to clean up")
+	f.DurationVar(&c.ttl, "ttl", 24*time.Hour, "How old a cluster's resources must be before they're deleted")
+	f.StringVar(&c.namePrefix, "name-prefix", "", "Prefix of the names of clusters created by osde2e. Defaults to the prefix of the cluster name template")
+	f.StringVar(&c.clusterIDTag, "cluster-id-tag", "", "Tag or label holding the ID of the cluster owning a resource. Defaults to the one set by OCM")
+	f.StringVar(&c.clusterNameTag, "cluster-name-tag", "", "Tag or label holding the name of the cluster owning a resource. Defaults to the one set by OCM")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report what would be deleted without deleting anything")
+	f.StringVar(&c.output, "output", "", "File to write the report to. Written to stdout if not set")
+	f.DurationVar(&c.interval, "interval", 0, "If set, keeps running and cleans up every interval, reloading the config when the custom config changes or on SIGHUP")
+	f.StringVar(&c.environments, "environments", "int,stage,prod", "Comma separated OCM environments whose clusters can own resources in the cloud account. A cluster's resources are only deleted if it exists in none of them. Each is checked with the token in OCM_TOKEN_<ENV>, or OCM_TOKEN")
+}
+
+// Execute deletes the resources of old clusters that no longer exist, or the orphaned clusters themselves, and
+// reports what was deleted
+func (c *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 || (f.Arg(0) != "aws" && f.Arg(0) != "gcp" && f.Arg(0) != "clusters") {
+		logging.Errorf("Unexpected arguments.")
+		log.Printf(c.Usage())
+		return subcommands.ExitFailure
+	}
+
+	if err := common.LoadConfigs(c.configString, c.customConfig, c.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if c.interval > 0 {
+		return c.cleanupPeriodically(f.Arg(0))
+	}
+
+	if err := c.cleanup(f.Arg(0)); err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// cleanupPeriodically cleans up every interval until the process is stopped. Reloaded configs are applied between
+// passes, and a failed pass is retried at the next interval.
+func (c *Command) cleanupPeriodically(target string) subcommands.ExitStatus {
+	reloaded, stop, err := common.WatchConfigs(c.configString, c.customConfig, c.configFormat)
+	if err != nil {
+		logging.Errorf("error watching config: %v", err)
+		return subcommands.ExitFailure
+	}
+	defer stop()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.cleanup(target); err != nil {
+			logging.Errorf("%v", err)
+		}
+
+		for waiting := true; waiting; {
+			select {
+			case cfg := <-reloaded:
+				config.Replace(cfg)
+			case <-ticker.C:
+				waiting = false
+			}
+		}
+	}
+}
+
+// cleanup makes one pass over the target and writes its report. It fails if anything couldn't be deleted.
+func (c *Command) cleanup(target string) error {
+	if target == "clusters" {
+		provider, err := providers.ClusterProvider()
+		if err != nil {
+			return fmt.Errorf("error getting cluster provider: %v", err)
+		}
+		return c.cleanupClusters(provider)
+	}
+
+	namePrefix := c.namePrefix
+	if namePrefix == "" {
+		namePrefix = strings.SplitN(config.Instance.Cluster.NameTemplate, "{{", 2)[0]
+		if namePrefix == "" {
+			return fmt.Errorf("the cluster name template has no fixed prefix, so -name-prefix must be set")
+		}
+	}
+
+	cloud, err := c.cloud(target, namePrefix)
+	if err != nil {
+		return err
+	}
+
+	exists, err := c.existsInEnvironments()
+	if err != nil {
+		return err
+	}
+
+	report, err := reaper.Reap(cloud, exists, reaper.Options{
+		TTL:        c.ttl,
+		NamePrefix: namePrefix,
+		DryRun:     c.dryRun,
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("error cleaning up %s: %v", cloud.Name(), err)
+	}
+
+	if err = c.write(report); err != nil {
+		return fmt.Errorf("error writing report: %v", err)
+	}
+
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d resources couldn't be deleted", len(report.Failed))
+	}
+	return nil
+}
+
+// cleanupClusters deletes the clusters created by osde2e that expired or failed, and posts a summary to Slack
+func (c *Command) cleanupClusters(provider spi.Provider) error {
+	lister, ok := provider.(spi.ClusterListProvider)
+	if !ok {
+		return fmt.Errorf("provider %s can't list the clusters created by osde2e", config.Instance.Provider)
+	}
+
+	report, err := reaper.ReapClusters(lister, provider.DeleteCluster, reaper.ClusterOptions{
+		ErrorAge: time.Duration(config.Instance.ClusterReaper.ErrorHours) * time.Hour,
+		DryRun:   c.dryRun,
+	}, time.Now())
+	if err != nil {
+		return fmt.Errorf("error cleaning up clusters: %v", err)
+	}
+
+	if err = c.write(report); err != nil {
+		return fmt.Errorf("error writing report: %v", err)
+	}
+
+	if webhook := config.Instance.ClusterReaper.SlackWebhook; webhook != "" {
+		msg := &slack.WebhookMessage{Text: "*osde2e cluster cleanup*\n" + report.Summary()}
+		if err = slack.PostWebhook(webhook, msg); err != nil {
+			logging.Warnf("error posting summary to slack: %v", err)
+		}
+	}
+
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d clusters couldn't be deleted", len(report.Failed))
+	}
+	return nil
+}
+
+// existsInEnvironments checks whether clusters exist in any of the environments. Every environment must be reachable,
+// so that a cluster of an environment that couldn't be checked is never taken for deleted.
+func (c *Command) existsInEnvironments() (reaper.ExistsFunc, error) {
+	envs := map[string]reaper.ExistsFunc{}
+	for _, env := range strings.Split(c.environments, ",") {
+		env = strings.TrimSpace(env)
+		if env == "" {
+			continue
+		}
+
+		token := os.Getenv("OCM_TOKEN_" + strings.ToUpper(env))
+		if token == "" {
+			token = config.Instance.OCM.Token
+		}
+
+		provider, err := providers.ClusterProviderForEnvironment(env, token)
+		if err != nil {
+			return nil, fmt.Errorf("error getting cluster provider for environment %s: %v", env, err)
+		}
+
+		existence, ok := provider.(spi.ClusterExistenceProvider)
+		if !ok {
+			return nil, fmt.Errorf("provider %s can't check whether clusters still exist", config.Instance.Provider)
+		}
+		envs[env] = existence.ExistingClusters
+	}
+
+	if len(envs) == 0 {
+		return nil, fmt.Errorf("-environments must list the OCM environments to check for clusters")
+	}
+	return reaper.ExistsInAny(envs), nil
+}
+
+// cloud returns the cloud account to clean up, with the tags OCM sets on cluster resources unless others were chosen
+func (c *Command) cloud(name, namePrefix string) (reaper.Cloud, error) {
+	switch name {
+	case "aws":
+		region := c.region
+		if region == "" {
+			region = state.Instance.CloudProvider.Region
+		}
+		return reaper.AWS(region, defaultString(c.clusterIDTag, "api.openshift.com/id"), defaultString(c.clusterNameTag, "api.openshift.com/name"), namePrefix), nil
+	case "gcp":
+		if c.project == "" {
+			return nil, fmt.Errorf("-project must be set to clean up gcp")
+		}
+		return reaper.GCP(c.project, defaultString(c.clusterIDTag, "api-openshift-com-id"), defaultString(c.clusterNameTag, "api-openshift-com-name")), nil
+	default:
+		return nil, fmt.Errorf("unknown cloud %s", name)
+	}
+}
+
+// write writes the report as YAML to the output file or stdout
+func (c *Command) write(report interface{}) error {
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	if c.output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(c.output, data, os.FileMode(0644))
+}
+
+func defaultString(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,130 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// Command is the command for looking up clusters
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	outputFormat string
+
+	subcommands.Command
+}
+
+// info is how a cluster is described by the info subcommand
+type info struct {
+	ID             string `json:"cluster-id"`
+	Name           string `json:"cluster-name"`
+	Region         string `json:"region"`
+	ConsoleURL     string `json:"console-url"`
+	APIURL         string `json:"api-url"`
+	ClusterPageURL string `json:"cluster-page-url"`
+}
+
+// Name is the name of the cluster command
+func (*Command) Name() string {
+	return "cluster"
+}
+
+// Synopsis is a short summary of the cluster command
+func (*Command) Synopsis() string {
+	return "Prints how to access a cluster."
+}
+
+// Usage describes how the cluster command is used
+func (*Command) Usage() string {
+	return "cluster info <cluster-id>"
+}
+
+// SetFlags describes the arguments used by the cluster command
+func (t *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use")
+	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.StringVar(&t.outputFormat, "output-format", "text", "Output format, either text or json")
+}
+
+// Execute looks up the cluster and prints its region and URLs
+func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 2 || f.Arg(0) != "info" {
+		logging.Errorf("Unexpected arguments.")
+		log.Printf(t.Usage())
+		return subcommands.ExitFailure
+	}
+
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	provider, err := providers.ClusterProvider()
+	if err != nil {
+		logging.Errorf("error getting cluster provider: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	cluster, err := provider.GetCluster(f.Arg(1))
+	if err != nil {
+		logging.Errorf("error getting cluster: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if err = t.print(clusterInfo(cluster)); err != nil {
+		logging.Errorf("error writing output: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+// print writes the cluster info to stdout in the selected output format
+func (t *Command) print(i info) error {
+	switch t.outputFormat {
+	case "json":
+		data, err := json.MarshalIndent(i, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(data))
+		return err
+	case "text":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "ID:\t%s\n", i.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", i.Name)
+		fmt.Fprintf(w, "Region:\t%s\n", i.Region)
+		fmt.Fprintf(w, "Console:\t%s\n", i.ConsoleURL)
+		fmt.Fprintf(w, "API:\t%s\n", i.APIURL)
+		fmt.Fprintf(w, "Cluster page:\t%s\n", i.ClusterPageURL)
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown output format %s", t.outputFormat)
+	}
+}
+
+func clusterInfo(cluster *spi.Cluster) info {
+	return info{
+		ID:             cluster.ID(),
+		Name:           cluster.Name(),
+		Region:         cluster.Region(),
+		ConsoleURL:     cluster.ConsoleURL(),
+		APIURL:         cluster.APIURL(),
+		ClusterPageURL: cluster.PageURL(),
+	}
+}
@@ -2,16 +2,22 @@ package common
 
 import (
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/scenario"
 	"github.com/openshift/osde2e/pkg/common/state"
 )
 
-// LoadConfigs loads config objects given the provided list of configs and a custom config
-func LoadConfigs(configString string, customConfig string) error {
+// LoadConfigs loads config objects given the provided list of configs and a custom config in the given format,
+// which is detected from its extension if empty
+func LoadConfigs(configString string, customConfig string, customConfigFormat string) error {
 	var configs []string
 	if configString != "" {
 		configs = strings.Split(configString, ",")
@@ -22,13 +28,55 @@ func LoadConfigs(configString string, customConfig string) error {
 	}
 
 	// Load config and initial state
-	if err := load.IntoObject(config.Instance, configs, customConfig); err != nil {
+	if err := load.IntoObject(config.Instance, configs, customConfig, customConfigFormat); err != nil {
 		return fmt.Errorf("error loading config: %v", err)
 	}
 
-	if err := load.IntoObject(state.Instance, configs, customConfig); err != nil {
+	if err := load.IntoObject(state.Instance, configs, customConfig, customConfigFormat); err != nil {
 		return fmt.Errorf("error loading initial state: %v", err)
 	}
 
+	if config.Instance.Scenarios.Repo != "" {
+		if err := loadScenarios(customConfig, customConfigFormat); err != nil {
+			return fmt.Errorf("error loading scenarios: %v", err)
+		}
+	}
+
+	// Report every invalid option up front instead of failing partway through the run.
+	if err := load.Validate(config.Instance); err != nil {
+		return fmt.Errorf("error validating config: %v", err)
+	}
+
+	if err := logging.Configure(config.Instance.LogLevel, config.Instance.LogFormat); err != nil {
+		return fmt.Errorf("error configuring logging: %v", err)
+	}
+
+	StartProfiling()
+	return nil
+}
+
+// loadScenarios layers scenarios from the configured Git repository on top of the composable configs.
+// The custom config and environment are reapplied afterwards so they keep precedence.
+func loadScenarios(customConfig, customConfigFormat string) error {
+	dir, err := ioutil.TempDir("", "osde2e-scenarios")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	files, commit, err := scenario.Fetch(config.Instance.Scenarios, dir)
+	if err != nil {
+		return err
+	}
+
+	if err = load.Overlay(config.Instance, files, customConfig, customConfigFormat); err != nil {
+		return err
+	}
+
+	if err = load.Overlay(state.Instance, files, customConfig, customConfigFormat); err != nil {
+		return err
+	}
+
+	metadata.Instance.SetScenarioCommit(commit)
 	return nil
 }
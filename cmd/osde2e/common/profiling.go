@@ -0,0 +1,29 @@
+package common
+
+import (
+	"sync"
+	"syscall"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/profiling"
+)
+
+var profilingOnce sync.Once
+
+// StartProfiling serves the runtime profiles if an address is configured and writes them to the report directory
+// whenever osde2e receives SIGUSR1, for as long as the command runs. It only starts them the first time it's called,
+// so reloading the config doesn't start them again.
+func StartProfiling() {
+	profilingOnce.Do(func() {
+		if addr := config.Instance.Profiling.Address; addr != "" {
+			if _, err := profiling.Serve(addr); err != nil {
+				logging.Warnf("Unable to serve profiles: %v", err)
+			}
+		}
+
+		if dir := config.Instance.ReportDir; dir != "" {
+			profiling.WriteOnSignal(dir, syscall.SIGUSR1)
+		}
+	})
+}
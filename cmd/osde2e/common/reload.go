@@ -0,0 +1,112 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/fsnotify.v1"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// reloadDelay is how long to wait for a burst of file events to settle, since editors often write a file in steps.
+const reloadDelay = 500 * time.Millisecond
+
+// WatchConfigs reloads the config from the same sources as LoadConfigs whenever the custom config changes or the
+// process receives SIGHUP. Every reloaded config that is valid is sent on the returned channel, to be applied with
+// config.Replace. Configs that fail to load are logged and dropped, keeping the current config. Watching stops when
+// the returned function is called.
+func WatchConfigs(configString string, customConfig string, customConfigFormat string) (<-chan *config.Config, func(), error) {
+	var configs []string
+	if configString != "" {
+		configs = strings.Split(configString, ",")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't watch config files: %v", err)
+	}
+
+	var path string
+	if customConfig != "" {
+		if path, err = filepath.Abs(customConfig); err != nil {
+			watcher.Close()
+			return nil, nil, err
+		}
+		// the directory is watched, since editors and ConfigMap mounts replace files rather than writing to them
+		if err = watcher.Add(filepath.Dir(path)); err != nil {
+			watcher.Close()
+			return nil, nil, fmt.Errorf("couldn't watch %s: %v", customConfig, err)
+		}
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	reloaded := make(chan *config.Config, 1)
+	done := make(chan struct{})
+	go func() {
+		var settle <-chan time.Time
+		for {
+			select {
+			case event := <-watcher.Events:
+				if filepath.Clean(event.Name) == path && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					settle = time.After(reloadDelay)
+				}
+			case err := <-watcher.Errors:
+				logging.Warnf("Error watching config files: %v", err)
+			case <-signals:
+				log.Printf("Received SIGHUP, reloading config.")
+				settle = time.After(0)
+			case <-settle:
+				settle = nil
+				c, err := reloadConfig(configs, customConfig, customConfigFormat)
+				if err != nil {
+					logging.Warnf("Keeping the current config, the reloaded config is invalid: %v", err)
+					continue
+				}
+
+				// only the latest config is kept if the previous one hasn't been applied yet
+				select {
+				case dropped := <-reloaded:
+					load.ForgetExtensions(dropped)
+				default:
+				}
+				reloaded <- c
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return reloaded, func() {
+		signal.Stop(signals)
+		close(done)
+		watcher.Close()
+	}, nil
+}
+
+// reloadConfig loads and validates a new config, including the config extensions of providers, without touching the
+// current one.
+func reloadConfig(configs []string, customConfig string, customConfigFormat string) (*config.Config, error) {
+	c := new(config.Config)
+	load.CloneExtensions(config.Instance, c)
+	if err := load.IntoObject(c, configs, customConfig, customConfigFormat); err != nil {
+		load.ForgetExtensions(c)
+		return nil, err
+	}
+	if err := load.Validate(c); err != nil {
+		load.ForgetExtensions(c)
+		return nil, err
+	}
+	log.Printf("Reloaded config.")
+	return c, nil
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWatchConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "osde2e-reload")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	customConfig := filepath.Join(dir, "custom.yaml")
+	if err = ioutil.WriteFile(customConfig, []byte("weather:\n  numberOfSamplesNecessary: 4\n"), 0644); err != nil {
+		t.Fatalf("failed to write custom config: %v", err)
+	}
+
+	reloaded, stop, err := WatchConfigs("", customConfig, "")
+	if err != nil {
+		t.Fatalf("failed to watch configs: %v", err)
+	}
+	defer stop()
+
+	expectReload := func(samples int) {
+		select {
+		case c := <-reloaded:
+			if c.Weather.NumberOfSamplesNecessary != samples {
+				t.Errorf("expected a config with %d samples, got %d", samples, c.Weather.NumberOfSamplesNecessary)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the config to be reloaded")
+		}
+	}
+
+	if err = ioutil.WriteFile(customConfig, []byte("weather:\n  numberOfSamplesNecessary: 5\n"), 0644); err != nil {
+		t.Fatalf("failed to update custom config: %v", err)
+	}
+	expectReload(5)
+
+	// invalid configs are dropped
+	if err = ioutil.WriteFile(customConfig, []byte("weather: ["), 0644); err != nil {
+		t.Fatalf("failed to update custom config: %v", err)
+	}
+	select {
+	case <-reloaded:
+		t.Errorf("expected an invalid config not to be reloaded")
+	case <-time.After(2 * reloadDelay):
+	}
+
+	if err = ioutil.WriteFile(customConfig, []byte("weather:\n  numberOfSamplesNecessary: 6\n"), 0644); err != nil {
+		t.Fatalf("failed to update custom config: %v", err)
+	}
+	expectReload(6)
+
+	if err = syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("failed to send SIGHUP: %v", err)
+	}
+	expectReload(6)
+}
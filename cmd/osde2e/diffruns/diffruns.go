@@ -0,0 +1,85 @@
+package diffruns
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/rundiff"
+)
+
+// Command is the command for comparing the results of two osde2e runs
+type Command struct {
+	outputFormat      string
+	minDurationChange float64
+
+	subcommands.Command
+}
+
+// Name is the name of the diff-runs command
+func (*Command) Name() string {
+	return "diff-runs"
+}
+
+// Synopsis is a short summary of the diff-runs command
+func (*Command) Synopsis() string {
+	return "Compares the specs, durations, and metrics of two osde2e runs."
+}
+
+// Usage describes how the diff-runs command is used
+func (*Command) Usage() string {
+	return "diff-runs [-output-format text|json] [-min-duration-change seconds] <before report dir> <after report dir>"
+}
+
+// SetFlags describes the arguments used by the diff-runs command
+func (t *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&t.outputFormat, "output-format", "text", "Output format of the diff, text or json")
+	f.Float64Var(&t.minDurationChange, "min-duration-change", 30, "Smallest change in a spec's duration, in seconds, that is reported")
+}
+
+// Execute loads both runs and writes what changed between them
+func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 2 {
+		logging.Errorf("Unexpected number of arguments.")
+		log.Printf(t.Usage())
+		return subcommands.ExitFailure
+	}
+
+	before, err := rundiff.Load(f.Arg(0))
+	if err != nil {
+		logging.Errorf("error loading run: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	after, err := rundiff.Load(f.Arg(1))
+	if err != nil {
+		logging.Errorf("error loading run: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	diff := rundiff.Compare(before, after, t.minDurationChange)
+
+	switch t.outputFormat {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(diff)
+	case "text":
+		err = diff.WriteText(os.Stdout)
+	default:
+		logging.Errorf("Unknown output format %s.", t.outputFormat)
+		return subcommands.ExitFailure
+	}
+
+	if err != nil {
+		logging.Errorf("error writing output: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
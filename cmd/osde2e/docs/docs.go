@@ -0,0 +1,117 @@
+package docs
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/configdocs"
+	"github.com/openshift/osde2e/pkg/common/load"
+
+	// the providers register their config options when imported
+	"github.com/openshift/osde2e/pkg/common/logging"
+	_ "github.com/openshift/osde2e/pkg/common/providers"
+)
+
+const (
+	// markdownFile and schemaFile are the files written to the output directory.
+	markdownFile = "config.md"
+	schemaFile   = "config.schema.json"
+)
+
+// Command is the command for generating documentation of osde2e
+type Command struct {
+	format    string
+	outputDir string
+
+	subcommands.Command
+}
+
+// Name is the name of the docs command
+func (*Command) Name() string {
+	return "docs"
+}
+
+// Synopsis is a short summary of the docs command
+func (*Command) Synopsis() string {
+	return "Generates a reference and JSON Schema of every config option."
+}
+
+// Usage describes how the docs command is used
+func (*Command) Usage() string {
+	return "docs [-format markdown|json-schema] [-output-dir dir] config"
+}
+
+// SetFlags describes the arguments used by the docs command
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.format, "format", "markdown", "Format written to stdout, markdown or json-schema")
+	f.StringVar(&c.outputDir, "output-dir", "", "Directory to write both "+markdownFile+" and "+schemaFile+" to instead of stdout")
+}
+
+// Execute writes the documentation of the config options
+func (c *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 || f.Arg(0) != "config" {
+		logging.Errorf("Only config options can be documented.")
+		log.Printf(c.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	options := load.Options(config.Instance)
+	if c.outputDir != "" {
+		if err := writeFiles(c.outputDir, options); err != nil {
+			logging.Errorf("error writing config docs: %v", err)
+			return subcommands.ExitFailure
+		}
+		log.Printf("Wrote %s and %s to %s", markdownFile, schemaFile, c.outputDir)
+		return subcommands.ExitSuccess
+	}
+
+	var err error
+	switch c.format {
+	case "markdown":
+		err = configdocs.Markdown(os.Stdout, options)
+	case "json-schema":
+		var schema []byte
+		if schema, err = configdocs.JSONSchema(options); err == nil {
+			_, err = os.Stdout.Write(append(schema, '\n'))
+		}
+	default:
+		logging.Errorf("Unknown format %s.", c.format)
+		return subcommands.ExitUsageError
+	}
+
+	if err != nil {
+		logging.Errorf("error writing config docs: %v", err)
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// writeFiles writes the Markdown reference and the JSON Schema to a directory.
+func writeFiles(dir string, options []load.Option) error {
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(dir, markdownFile))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = configdocs.Markdown(file, options); err != nil {
+		return err
+	}
+
+	schema, err := configdocs.JSONSchema(options)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, schemaFile), append(schema, '\n'), os.FileMode(0644))
+}
@@ -0,0 +1,102 @@
+package fleet
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/e2e"
+)
+
+// Command is the command for auditing existing clusters
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	version       string
+	cloudProvider string
+	region        string
+	properties    string
+	query         string
+	limit         int
+
+	subcommands.Command
+}
+
+// Name is the name of the fleet command
+func (*Command) Name() string {
+	return "fleet"
+}
+
+// Synopsis is a short summary of the fleet command
+func (*Command) Synopsis() string {
+	return "Runs read-only health, SLO, and drift checks against existing clusters and writes a fleet report."
+}
+
+// Usage describes how the fleet command is used
+func (*Command) Usage() string {
+	return "fleet [-configs config1,config2] [-custom-config osde2e-custom-config.yaml] [-version v] [-cloud-provider p] [-region r] [-property name=value,...] [-query q] [-limit n]"
+}
+
+// SetFlags describes the arguments used by the fleet command
+func (c *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.configString, "configs", "", "A comma separated list of built in configs to use")
+	f.StringVar(&c.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&c.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.StringVar(&c.version, "version", "", "Only audit clusters running this version")
+	f.StringVar(&c.cloudProvider, "cloud-provider", "", "Only audit clusters on this cloud provider")
+	f.StringVar(&c.region, "region", "", "Only audit clusters in this region")
+	f.StringVar(&c.properties, "property", "", "A comma separated list of name=value properties clusters must have")
+	f.StringVar(&c.query, "query", "", "Added to the cluster search as is, in the provider's search syntax")
+	f.IntVar(&c.limit, "limit", 20, "The most clusters to audit, newest first")
+}
+
+// Execute searches for the clusters to audit, checks each of them, and fails if any didn't pass
+func (c *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 0 || c.limit < 1 {
+		logging.Errorf("Unexpected arguments.")
+		log.Printf(c.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	if err := common.LoadConfigs(c.configString, c.customConfig, c.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	search := spi.ClusterSearch{
+		Version:       c.version,
+		CloudProvider: c.cloudProvider,
+		Region:        c.region,
+		Properties:    map[string]string{},
+		Query:         c.query,
+	}
+	if c.properties != "" {
+		for _, property := range strings.Split(c.properties, ",") {
+			parts := strings.SplitN(property, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				logging.Errorf("property '%s' should be in the format <property>=<value>", property)
+				return subcommands.ExitUsageError
+			}
+			search.Properties[parts[0]] = parts[1]
+		}
+	}
+
+	report, err := e2e.AuditFleet(search, c.limit)
+	if err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+
+	if report.Failed > 0 {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
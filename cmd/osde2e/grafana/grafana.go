@@ -0,0 +1,115 @@
+package grafana
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/grafana"
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// Command is the command for provisioning Grafana dashboards for the metrics osde2e pushes
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	output string
+
+	subcommands.Command
+}
+
+// Name is the name of the grafana command
+func (*Command) Name() string {
+	return "grafana"
+}
+
+// Synopsis is a short summary of the grafana command
+func (*Command) Synopsis() string {
+	return "Provisions Grafana dashboards for the metrics osde2e pushes, or exports them as JSON."
+}
+
+// Usage describes how the grafana command is used
+func (*Command) Usage() string {
+	return "grafana [-configs config1,config2] [-custom-config osde2e-custom-config.yaml] [-output dir]"
+}
+
+// SetFlags describes the arguments used by the grafana command
+func (g *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&g.configString, "configs", "", "A comma separated list of built in configs to use")
+	f.StringVar(&g.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&g.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.StringVar(&g.output, "output", "", "Directory to export the dashboards to as JSON, instead of provisioning them in GRAFANA_URL")
+}
+
+// Execute provisions the dashboards through the Grafana HTTP API, or exports them
+func (g *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 0 {
+		logging.Errorf("Unexpected number of arguments.")
+		log.Printf(g.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	if err := common.LoadConfigs(g.configString, g.customConfig, g.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	cfg := config.Instance
+	jobs := cfg.Grafana.Jobs
+	if len(jobs) == 0 && cfg.JobName != "" {
+		jobs = []string{cfg.JobName}
+	}
+	dashboards := grafana.Dashboards(grafana.Options{Jobs: jobs, Datasource: cfg.Grafana.Datasource})
+
+	if g.output != "" {
+		if err := export(g.output, dashboards); err != nil {
+			logging.Errorf("error exporting dashboards: %v", err)
+			return subcommands.ExitFailure
+		}
+		log.Printf("Exported %d dashboards to %s.", len(dashboards), g.output)
+		return subcommands.ExitSuccess
+	}
+
+	if cfg.Grafana.URL == "" {
+		logging.Errorf("GRAFANA_URL must be set to provision dashboards, or use -output to export them.")
+		return subcommands.ExitUsageError
+	}
+
+	urls, err := grafana.New(cfg.Grafana.URL, cfg.Grafana.Token).Provision(cfg.Grafana.Folder, dashboards)
+	for _, url := range urls {
+		log.Printf("Provisioned %s", url)
+	}
+	if err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// export writes each dashboard to a JSON file named after its UID, which can be imported into Grafana.
+func export(dir string, dashboards []grafana.Dashboard) error {
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return err
+	}
+
+	for _, dashboard := range dashboards {
+		data, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(filepath.Join(dir, dashboard.UID+".json"), data, os.FileMode(0644)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -10,9 +10,22 @@ import (
 	"syscall"
 
 	_ "github.com/openshift/osde2e"
+	"github.com/openshift/osde2e/cmd/osde2e/cleanup"
+	"github.com/openshift/osde2e/cmd/osde2e/cluster"
+	"github.com/openshift/osde2e/cmd/osde2e/diffruns"
+	"github.com/openshift/osde2e/cmd/osde2e/docs"
+	"github.com/openshift/osde2e/cmd/osde2e/fleet"
+	"github.com/openshift/osde2e/cmd/osde2e/grafana"
+	"github.com/openshift/osde2e/cmd/osde2e/matrix"
+	"github.com/openshift/osde2e/cmd/osde2e/plan"
 	"github.com/openshift/osde2e/cmd/osde2e/query"
+	"github.com/openshift/osde2e/cmd/osde2e/rerun"
+	"github.com/openshift/osde2e/cmd/osde2e/smoke"
 	"github.com/openshift/osde2e/cmd/osde2e/test"
+	"github.com/openshift/osde2e/cmd/osde2e/validateharness"
 	"github.com/openshift/osde2e/cmd/osde2e/weather"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	_ "github.com/openshift/osde2e/pkg/common/secrets"
 
 	"github.com/google/subcommands"
 )
@@ -22,7 +35,20 @@ func main() {
 	subcommands.Register(subcommands.FlagsCommand(), "")
 	subcommands.Register(subcommands.CommandsCommand(), "")
 	subcommands.Register(&test.Command{}, "")
+	subcommands.Register(&test.TUICommand{}, "")
+	subcommands.Register(&test.NodeCommand{}, "")
 	subcommands.Register(&query.Command{}, "")
+	subcommands.Register(&rerun.Command{}, "")
+	subcommands.Register(&smoke.Command{}, "")
+	subcommands.Register(&plan.Command{}, "")
+	subcommands.Register(&diffruns.Command{}, "")
+	subcommands.Register(&docs.Command{}, "")
+	subcommands.Register(&cluster.Command{}, "")
+	subcommands.Register(&cleanup.Command{}, "")
+	subcommands.Register(&matrix.Command{}, "")
+	subcommands.Register(&grafana.Command{}, "")
+	subcommands.Register(&validateharness.Command{}, "")
+	subcommands.Register(&fleet.Command{}, "")
 	subcommands.Register(&weather.ReportCommand{}, "")
 	subcommands.Register(&weather.ReportToSlackCommand{}, "")
 
@@ -48,7 +74,7 @@ func selfUpdate() {
 	var binary string
 	var err error
 	if binary, err = exec.LookPath("osde2e"); err != nil {
-		log.Printf("Couldn't find osde2e on the path.")
+		logging.Warnf("Couldn't find osde2e on the path.")
 		return
 	}
 
@@ -0,0 +1,107 @@
+package matrix
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/matrix"
+	"github.com/openshift/osde2e/pkg/e2e"
+)
+
+// Command is the command for running a matrix of versions and regions across OCM environments
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	parallel int
+	dryRun   bool
+
+	subcommands.Command
+}
+
+// Name is the name of the matrix command
+func (*Command) Name() string {
+	return "matrix"
+}
+
+// Synopsis is a short summary of the matrix command
+func (*Command) Synopsis() string {
+	return "Runs a matrix of versions and regions, spreading its clusters across OCM environments by their remaining quota."
+}
+
+// Usage describes how the matrix command is used
+func (*Command) Usage() string {
+	return "matrix [-configs config1,config2] [-custom-config osde2e-custom-config.yaml] [-parallel n] [-dry-run] matrix.yaml"
+}
+
+// SetFlags describes the arguments used by the matrix command
+func (m *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&m.configString, "configs", "", "A comma separated list of built in configs every entry is run with")
+	f.StringVar(&m.customConfig, "custom-config", "", "Custom config file every entry is run with")
+	f.StringVar(&m.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.IntVar(&m.parallel, "parallel", 0, "How many entries run at once. All of them run at once if not set")
+	f.BoolVar(&m.dryRun, "dry-run", false, "Print the schedule without running any entries")
+}
+
+// Execute queries the quota of the matrix's environments up front, schedules its entries within it, and runs them
+func (m *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		logging.Errorf("Unexpected number of arguments.")
+		log.Printf(m.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	if err := common.LoadConfigs(m.configString, m.customConfig, m.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	mat, err := matrix.Read(f.Arg(0))
+	if err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+
+	results := e2e.PlanMatrix(mat)
+	log.Printf("Scheduled %d of %d entries.", len(results.Schedule.Assignments), len(mat.Entries))
+	if m.dryRun {
+		data, err := yaml.Marshal(results)
+		if err != nil {
+			logging.Errorf("error marshaling matrix schedule: %v", err)
+			return subcommands.ExitFailure
+		}
+		os.Stdout.Write(data)
+		return subcommands.ExitSuccess
+	}
+
+	var configs []string
+	if m.configString != "" {
+		configs = strings.Split(m.configString, ",")
+	}
+	opts := e2e.MatrixOptions{
+		Configs:            configs,
+		CustomConfig:       m.customConfig,
+		CustomConfigFormat: m.configFormat,
+		ReportDir:          config.Instance.ReportDir,
+		Parallel:           m.parallel,
+	}
+	if err = e2e.RunMatrix(mat, results, opts); err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+
+	if !results.Passed() {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,79 @@
+package plan
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/e2e"
+)
+
+// Command is the command for reviewing the operations a run would make against its provider
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	output string
+
+	subcommands.Command
+}
+
+// Name is the name of the plan command
+func (*Command) Name() string {
+	return "plan"
+}
+
+// Synopsis is a short summary of the plan command
+func (*Command) Synopsis() string {
+	return "Writes the operations a run would make against its provider, to be reviewed and applied with test -apply-plan."
+}
+
+// Usage describes how the plan command is used
+func (*Command) Usage() string {
+	return "plan [-configs config1,config2] [-custom-config osde2e-custom-config.yaml] [-output plan.yaml]"
+}
+
+// SetFlags describes the arguments used by the plan command
+func (t *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use")
+	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.StringVar(&t.output, "output", "", "File to write the plan to. The plan is printed if not set")
+}
+
+// Execute plans the run described by the configs without making any changes
+func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	p, err := e2e.BuildPlan()
+	if err != nil {
+		logging.Errorf("error planning run: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if t.output != "" {
+		if err = p.Write(t.output); err != nil {
+			logging.Errorf("error writing plan: %v", err)
+			return subcommands.ExitFailure
+		}
+		log.Printf("Wrote a plan with %d operation(s) to %s.", len(p.Operations), t.output)
+		return subcommands.ExitSuccess
+	}
+
+	data, err := p.Marshal()
+	if err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+	os.Stdout.Write(data)
+	return subcommands.ExitSuccess
+}
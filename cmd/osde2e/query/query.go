@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/subcommands"
 	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/prometheus"
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 )
@@ -19,6 +20,7 @@ import (
 type Command struct {
 	configString string
 	customConfig string
+	configFormat string
 
 	outputFormat string
 
@@ -44,19 +46,20 @@ func (*Command) Usage() string {
 func (t *Command) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use")
 	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
 	f.StringVar(&t.outputFormat, "output-format", "json", "Custom config file for osde2e")
 }
 
 // Execute actually executes the tests
 func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
 	if f.NArg() == 0 {
-		log.Printf("Unexpected number of arguments.")
+		logging.Errorf("Unexpected number of arguments.")
 		log.Printf(t.Usage())
 		return subcommands.ExitFailure
 	}
 
-	if err := common.LoadConfigs(t.configString, t.customConfig); err != nil {
-		log.Printf("error loading initial state: %v", err)
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
 		return subcommands.ExitFailure
 	}
 
@@ -65,7 +68,7 @@ func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 	client, err := prometheus.CreateClient()
 
 	if err != nil {
-		log.Printf("unable to create Prometheus client: %v", err)
+		logging.Errorf("unable to create Prometheus client: %v", err)
 		return subcommands.ExitFailure
 	}
 
@@ -76,12 +79,12 @@ func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 	value, warnings, err := promAPI.Query(context, query, time.Now())
 
 	if err != nil {
-		log.Printf("error issuing query: %v", err)
+		logging.Errorf("error issuing query: %v", err)
 		return subcommands.ExitFailure
 	}
 
 	for _, warning := range warnings {
-		log.Printf("warning: %s", warning)
+		logging.Warnf("warning: %s", warning)
 	}
 
 	var data []byte
@@ -90,7 +93,7 @@ func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 		data, err = json.MarshalIndent(value, "", "  ")
 
 		if err != nil {
-			log.Printf("error marshaling results: %v", err)
+			logging.Errorf("error marshaling results: %v", err)
 			return subcommands.ExitFailure
 		}
 	case "prom":
@@ -100,7 +103,7 @@ func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 	_, err = os.Stdout.Write(data)
 
 	if err != nil {
-		log.Printf("error writing output: %v", err)
+		logging.Errorf("error writing output: %v", err)
 		return subcommands.ExitFailure
 	}
 
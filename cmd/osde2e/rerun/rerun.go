@@ -0,0 +1,127 @@
+package rerun
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/manifest"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/e2e"
+)
+
+// Command is the command for replaying a previous osde2e run from its manifest
+type Command struct {
+	subcommands.Command
+}
+
+// Name is the name of the rerun command
+func (*Command) Name() string {
+	return "rerun"
+}
+
+// Synopsis is a short summary of the rerun command
+func (*Command) Synopsis() string {
+	return "Replays an osde2e run using the inputs recorded in its reproducibility manifest."
+}
+
+// Usage describes how the rerun command is used
+func (*Command) Usage() string {
+	return "rerun <manifest>"
+}
+
+// SetFlags describes the arguments used by the rerun command
+func (t *Command) SetFlags(f *flag.FlagSet) {}
+
+// Execute loads the manifest and runs the tests with the recorded inputs
+func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		logging.Errorf("Unexpected number of arguments.")
+		log.Printf(t.Usage())
+		return subcommands.ExitFailure
+	}
+
+	m, err := manifest.Read(f.Arg(0))
+	if err != nil {
+		logging.Errorf("error loading manifest: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if m.BuildCommit != manifest.BuildCommit {
+		logging.Warnf("Manifest was produced by osde2e %s, but this is osde2e %s. Results may differ.", m.BuildCommit, manifest.BuildCommit)
+	}
+
+	tmpDir, err := ioutil.TempDir("", "osde2e-rerun")
+	if err != nil {
+		logging.Errorf("error creating temporary directory: %v", err)
+		return subcommands.ExitFailure
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configFile, stateFile, err := m.WriteInputs(tmpDir)
+	if err != nil {
+		logging.Errorf("error writing manifest inputs: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	// Secrets are never recorded in the manifest, so they are still expected to come from the environment. Every other
+	// option is loaded again from the manifest afterwards, so that the environment can't change what is replayed.
+	if err := load.IntoObject(config.Instance, nil, configFile, load.YAMLFormat); err != nil {
+		logging.Errorf("error loading config from manifest: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if err := load.IntoObject(state.Instance, nil, stateFile, load.YAMLFormat); err != nil {
+		logging.Errorf("error loading state from manifest: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	warnOverridden(config.Instance, m.RecordsConfig)
+	warnOverridden(state.Instance, m.RecordsState)
+
+	if err := load.File(config.Instance, configFile); err != nil {
+		logging.Errorf("error loading config from manifest: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if err := load.File(state.Instance, stateFile); err != nil {
+		logging.Errorf("error loading state from manifest: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	for _, image := range manifest.PinImages(m.HarnessImages) {
+		logging.Warnf("No digest was recorded for image %s, so its current version is used.", image)
+	}
+
+	if err := load.Validate(config.Instance); err != nil {
+		logging.Errorf("error validating config from manifest: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	common.StartProfiling()
+	log.Printf("Replaying run with config hash %s", m.ConfigHash)
+
+	if e2e.RunTests() {
+		return subcommands.ExitSuccess
+	}
+
+	return subcommands.ExitFailure
+}
+
+// warnOverridden warns about every option set in the environment that is ignored because the manifest records it.
+func warnOverridden(object interface{}, recorded func(key string) bool) {
+	for _, setting := range load.EffectiveConfig(object) {
+		if strings.HasPrefix(setting.Source, load.EnvSource) && recorded(setting.Key) {
+			logging.Warnf("%s is set by %s, but the value recorded in the manifest is used.", setting.Key, strings.TrimPrefix(setting.Source, load.EnvSource))
+		}
+	}
+}
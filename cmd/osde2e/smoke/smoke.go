@@ -0,0 +1,110 @@
+package smoke
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/e2e"
+
+	// import the suites with smoke checks
+	_ "github.com/openshift/osde2e/pkg/e2e/state"
+	_ "github.com/openshift/osde2e/pkg/e2e/verify"
+)
+
+// smokeConfig is the built in config selecting the smoke suite. It's loaded after the other configs so that they
+// can't select other suites.
+const smokeConfig = "smoke-suite"
+
+// Command is the command for quickly sanity-checking an existing cluster
+type Command struct {
+	configString string
+	customConfig string
+	configFormat string
+
+	timeout time.Duration
+
+	subcommands.Command
+}
+
+// Name is the name of the smoke command
+func (*Command) Name() string {
+	return "smoke"
+}
+
+// Synopsis is a short summary of the smoke command
+func (*Command) Synopsis() string {
+	return "Runs the fastest critical checks against an existing cluster."
+}
+
+// Usage describes how the smoke command is used
+func (*Command) Usage() string {
+	return "smoke [-configs config1,config2] [-custom-config osde2e-custom-config.yaml] [-timeout 5m] [cluster-id]"
+}
+
+// SetFlags describes the arguments used by the smoke command
+func (t *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use, ex. the environment of the cluster")
+	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.DurationVar(&t.timeout, "timeout", 5*time.Minute, "How long the checks may run before the cluster is considered unhealthy")
+}
+
+// Execute runs the smoke suite against the cluster, giving up once the timeout has passed
+func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() > 1 {
+		logging.Errorf("Unexpected number of arguments.")
+		log.Printf(t.Usage())
+		return subcommands.ExitFailure
+	}
+
+	configs := smokeConfig
+	if t.configString != "" {
+		configs = t.configString + "," + smokeConfig
+	}
+
+	if err := common.LoadConfigs(configs, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	// the cluster isn't the run's to destroy, even if DESTROY_CLUSTER is set
+	config.Instance.Cluster.DestroyAfterTest = false
+
+	if f.NArg() == 1 {
+		state.Instance.Cluster.ID = f.Arg(0)
+	}
+
+	if state.Instance.Cluster.ID == "" && config.Instance.Kubeconfig.Path == "" && len(state.Instance.Kubeconfig.Contents) == 0 {
+		logging.Errorf("Smoke checks need an existing cluster, either its ID or a kubeconfig (TEST_KUBECONFIG).")
+		return subcommands.ExitFailure
+	}
+
+	passed := make(chan bool, 1)
+	go func() {
+		passed <- e2e.RunTests()
+	}()
+
+	select {
+	case ok := <-passed:
+		if ok {
+			return subcommands.ExitSuccess
+		}
+	case <-time.After(t.timeout):
+		logging.Errorf("Smoke checks didn't finish within %v.", t.timeout)
+
+		// the run is cleaned up before exiting, so that its reports are still written
+		e2e.AbortRun(fmt.Errorf("smoke checks didn't finish within %v", t.timeout))
+		<-passed
+	}
+
+	return subcommands.ExitFailure
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"context"
+	"flag"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/e2e"
+)
+
+// NodeCommand is the command for running a node of a parallel test phase. It is started by the other test commands
+// when TEST_PARALLELISM is more than 1.
+type NodeCommand struct {
+	handoff string
+	node    int
+}
+
+// Name is the name of the test-node command
+func (*NodeCommand) Name() string {
+	return "test-node"
+}
+
+// Synopsis is a short summary of the test-node command
+func (*NodeCommand) Synopsis() string {
+	return "Runs a node of a parallel test phase. Started by the test commands, not meant to be run directly."
+}
+
+// Usage describes how the test-node command is used
+func (*NodeCommand) Usage() string {
+	return "test-node -handoff dir -node n"
+}
+
+// SetFlags describes the arguments used by the test-node command
+func (n *NodeCommand) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&n.handoff, "handoff", "", "Directory the test command handed the run off to its nodes in")
+	f.IntVar(&n.node, "node", 1, "Number of the node, starting from 1")
+}
+
+// Execute runs the node's share of the phase's specs
+func (n *NodeCommand) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if n.handoff == "" {
+		logging.Errorf("test-node needs -handoff.")
+		return subcommands.ExitUsageError
+	}
+
+	if e2e.RunNode(n.handoff, n.node) {
+		return subcommands.ExitSuccess
+	}
+	return subcommands.ExitFailure
+}
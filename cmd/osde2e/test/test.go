@@ -3,15 +3,24 @@ package test
 import (
 	"context"
 	"flag"
-	"log"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/google/subcommands"
 
 	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/e2e"
 
 	// import suites to be tested
 	_ "github.com/openshift/osde2e/pkg/e2e/addons"
+	_ "github.com/openshift/osde2e/pkg/e2e/faultinjection"
+	_ "github.com/openshift/osde2e/pkg/e2e/hibernation"
+	_ "github.com/openshift/osde2e/pkg/e2e/hostedcluster"
+	_ "github.com/openshift/osde2e/pkg/e2e/multicluster"
 	_ "github.com/openshift/osde2e/pkg/e2e/openshift"
 	_ "github.com/openshift/osde2e/pkg/e2e/operators"
 	_ "github.com/openshift/osde2e/pkg/e2e/osd"
@@ -26,6 +35,9 @@ import (
 type Command struct {
 	configString string
 	customConfig string
+	configFormat string
+	applyPlan    string
+	planOnly     bool
 
 	subcommands.Command
 }
@@ -42,25 +54,81 @@ func (*Command) Synopsis() string {
 
 // Usage describes how the test command is used
 func (*Command) Usage() string {
-	return "test [-configs config1,config2] [-customConfig osde2e-custom-config.yaml]"
+	return "test [-configs config1,config2] [-customConfig osde2e-custom-config.yaml] [-apply-plan plan.yaml] [-plan-only] [list]"
 }
 
 // SetFlags describes the arguments used by the test command
 func (t *Command) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use")
 	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.StringVar(&t.applyPlan, "apply-plan", "", "A plan written by osde2e plan. The run makes its operations instead of choosing its own")
+	f.BoolVar(&t.planOnly, "plan-only", false, "Print the config, versions, upgrade, and specs of the run without creating a cluster")
 }
 
-// Execute actually executes the tests
+// Execute actually executes the tests. With the list argument, it lists the suites and their labels instead.
 func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	if err := common.LoadConfigs(t.configString, t.customConfig); err != nil {
-		log.Printf("error loading initial state: %v", err)
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
 		return subcommands.ExitFailure
 	}
 
+	if f.Arg(0) == "list" {
+		if err := listSuites(); err != nil {
+			logging.Errorf("error listing suites: %v", err)
+			return subcommands.ExitFailure
+		}
+		return subcommands.ExitSuccess
+	} else if f.NArg() > 0 {
+		logging.Errorf("unknown argument %q", f.Arg(0))
+		return subcommands.ExitUsageError
+	}
+
+	if t.applyPlan != "" {
+		config.Instance.Tests.ApplyPlan = t.applyPlan
+	}
+
+	if t.planOnly {
+		if err := printExecutionPlan(); err != nil {
+			logging.Errorf("error planning run: %v", err)
+			return subcommands.ExitFailure
+		}
+		return subcommands.ExitSuccess
+	}
+
 	if e2e.RunTests() {
 		return subcommands.ExitSuccess
 	}
 
 	return subcommands.ExitFailure
 }
+
+// listSuites prints the suites with their labels, and whether they're selected to run.
+func listSuites() error {
+	suites, err := e2e.ListSuites()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SUITE\tLABELS\tSELECTED")
+	for _, suite := range suites {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", suite.Suite, strings.Join(suite.Labels, ","), suite.Selected)
+	}
+	return w.Flush()
+}
+
+// printExecutionPlan prints what the run would do, without creating a cluster.
+func printExecutionPlan() error {
+	p, err := e2e.BuildExecutionPlan()
+	if err != nil {
+		return err
+	}
+
+	data, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/subcommands"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/dashboard"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/e2e"
+)
+
+// TUICommand is the command for running end to end tests with a live dashboard in the terminal
+type TUICommand struct {
+	Command
+
+	logFile string
+}
+
+// Name is the name of the tui command
+func (*TUICommand) Name() string {
+	return "tui"
+}
+
+// Synopsis is a short summary of the tui command
+func (*TUICommand) Synopsis() string {
+	return "Runs end to end tests like test, showing their progress and the cluster's health live in the terminal."
+}
+
+// Usage describes how the tui command is used
+func (*TUICommand) Usage() string {
+	return "tui [-configs config1,config2] [-customConfig osde2e-custom-config.yaml] [-apply-plan plan.yaml] [-log-file osde2e.log]"
+}
+
+// SetFlags describes the arguments used by the tui command
+func (t *TUICommand) SetFlags(f *flag.FlagSet) {
+	t.Command.SetFlags(f)
+	f.StringVar(&t.logFile, "log-file", "osde2e.log", "File the run's log is written to instead of the terminal")
+}
+
+// Execute runs the tests, writing their log to the log file while the dashboard is shown
+func (t *TUICommand) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	// planning and listing suites have nothing to show on the dashboard
+	if t.planOnly {
+		logging.Errorf("tui doesn't support -plan-only, use test instead.")
+		return subcommands.ExitUsageError
+	} else if f.NArg() > 0 {
+		logging.Errorf("tui doesn't take arguments, use test %s instead.", f.Arg(0))
+		return subcommands.ExitUsageError
+	}
+
+	fd := int(os.Stdout.Fd())
+	if !terminal.IsTerminal(fd) {
+		logging.Errorf("tui needs a terminal, use test instead.")
+		return subcommands.ExitFailure
+	}
+
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
+		return subcommands.ExitFailure
+	}
+
+	if t.applyPlan != "" {
+		config.Instance.Tests.ApplyPlan = t.applyPlan
+	}
+
+	logFile, err := os.Create(t.logFile)
+	if err != nil {
+		logging.Errorf("error creating log file: %v", err)
+		return subcommands.ExitFailure
+	}
+	defer logFile.Close()
+
+	logPath, err := filepath.Abs(t.logFile)
+	if err != nil {
+		logPath = t.logFile
+	}
+
+	// everything the run writes, including Ginkgo's output, goes to the log file so the dashboard isn't drawn over
+	terminalOut, terminalErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = logFile, logFile
+	logging.SetOutput(logFile)
+	defer func() {
+		os.Stdout, os.Stderr = terminalOut, terminalErr
+		logging.SetOutput(terminalErr)
+	}()
+
+	width := func() int {
+		if w, _, err := terminal.GetSize(fd); err == nil {
+			return w
+		}
+		return 0
+	}
+
+	stop := dashboard.New(logPath, time.Now()).Show(terminalOut, width)
+	passed := e2e.RunTests()
+	stop()
+
+	if passed {
+		fmt.Fprintf(terminalOut, "\nThe run passed. Its log is in %s.\n", logPath)
+		return subcommands.ExitSuccess
+	}
+
+	fmt.Fprintf(terminalOut, "\nThe run failed. Its log is in %s.\n", logPath)
+	return subcommands.ExitFailure
+}
@@ -0,0 +1,120 @@
+package validateharness
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"github.com/openshift/osde2e/pkg/common/harness"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/providers/mock"
+)
+
+// Command is the command for checking that an addon test harness writes results osde2e can read
+type Command struct {
+	engine     string
+	kubeconfig string
+	resultsDir string
+	timeout    time.Duration
+
+	subcommands.Command
+}
+
+// Name is the name of the validate-harness command
+func (*Command) Name() string {
+	return "validate-harness"
+}
+
+// Synopsis is a short summary of the validate-harness command
+func (*Command) Synopsis() string {
+	return "Runs an addon test harness against a mock cluster and checks that its results follow the harness schema."
+}
+
+// Usage describes how the validate-harness command is used
+func (*Command) Usage() string {
+	return "validate-harness [-engine podman|docker] [-kubeconfig file] [-timeout 10m] <image> | validate-harness -results-dir dir"
+}
+
+// SetFlags describes the arguments used by the validate-harness command
+func (t *Command) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&t.engine, "engine", "podman", "Container engine used to run the harness")
+	f.StringVar(&t.kubeconfig, "kubeconfig", "", "Kubeconfig of a cluster to run the harness against instead of the mock cluster")
+	f.StringVar(&t.resultsDir, "results-dir", "", "Directory of results already written by a harness to check instead of running one")
+	f.DurationVar(&t.timeout, "timeout", 10*time.Minute, "How long the harness may run")
+}
+
+// Execute runs the harness, or reads the results it already wrote, and reports any problems with them
+func (t *Command) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if (t.resultsDir == "") != (f.NArg() == 1) || f.NArg() > 1 {
+		logging.Errorf("Unexpected number of arguments.")
+		log.Printf(t.Usage())
+		return subcommands.ExitFailure
+	}
+
+	var results map[string][]byte
+	var err error
+	if t.resultsDir != "" {
+		results, err = harness.ReadResults(t.resultsDir)
+	} else {
+		results, err = t.run(f.Arg(0))
+	}
+	if err != nil {
+		logging.Errorf("%v", err)
+		return subcommands.ExitFailure
+	}
+
+	problems := harness.Validate(results)
+	if len(problems) > 0 {
+		logging.Errorf("The harness's results don't follow the schema:")
+		for _, problem := range problems {
+			logging.Errorf("  %s", problem)
+		}
+		return subcommands.ExitFailure
+	}
+
+	log.Printf("The harness wrote %d result files following the schema.", len(results))
+	return subcommands.ExitSuccess
+}
+
+// run runs the harness image and returns its results. A harness failing its tests isn't a problem, as the mock
+// cluster can't be reached, as long as it reports the failures.
+func (t *Command) run(image string) (map[string][]byte, error) {
+	var kubeconfig []byte
+	var err error
+	if t.kubeconfig != "" {
+		kubeconfig, err = ioutil.ReadFile(t.kubeconfig)
+	} else {
+		kubeconfig, err = mockKubeconfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Running harness %s with %s...", image, t.engine)
+	results, output, err := harness.RunLocal(t.engine, image, kubeconfig, t.timeout)
+	log.Printf("Harness output:\n%s", output)
+	if results == nil {
+		return nil, err
+	}
+	if err != nil {
+		logging.Warnf("Harness exited with an error, checking its results anyway: %v", err)
+	}
+	return results, nil
+}
+
+// mockKubeconfig returns the kubeconfig of a cluster from the mock provider.
+func mockKubeconfig() ([]byte, error) {
+	provider, err := mock.New("prod")
+	if err != nil {
+		return nil, err
+	}
+	clusterID, err := provider.LaunchCluster()
+	if err != nil {
+		return nil, err
+	}
+	return provider.ClusterKubeconfig(clusterID)
+}
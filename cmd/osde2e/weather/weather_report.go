@@ -8,6 +8,7 @@ import (
 	"github.com/google/subcommands"
 
 	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/weather"
 )
 
@@ -15,6 +16,7 @@ import (
 type ReportCommand struct {
 	configString string
 	customConfig string
+	configFormat string
 	output       string
 	outputType   string
 
@@ -40,19 +42,20 @@ func (*ReportCommand) Usage() string {
 func (t *ReportCommand) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use")
 	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
 	f.StringVar(&t.output, "output", "-", "Where to output the report. Use '-' for standard out")
 	f.StringVar(&t.outputType, "outputType", "json", "What format to output the report in. Defaults to json.")
 }
 
 // Execute actually generates the weather report
 func (t *ReportCommand) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	if err := common.LoadConfigs(t.configString, t.customConfig); err != nil {
-		log.Printf("error loading initial state: %v", err)
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
 		return subcommands.ExitFailure
 	}
 
 	if f.NArg() != 0 {
-		log.Printf("Unexpected number of arguments.")
+		logging.Errorf("Unexpected number of arguments.")
 		log.Printf(t.Usage())
 		return subcommands.ExitFailure
 	}
@@ -60,7 +63,7 @@ func (t *ReportCommand) Execute(_ context.Context, f *flag.FlagSet, _ ...interfa
 	err := weather.GenerateWeatherReportForOSD(t.output, t.outputType)
 
 	if err != nil {
-		log.Printf("error while generating report: %v", err)
+		logging.Errorf("error while generating report: %v", err)
 		return subcommands.ExitFailure
 	}
 
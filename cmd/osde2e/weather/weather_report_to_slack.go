@@ -4,10 +4,13 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/google/subcommands"
 
 	"github.com/openshift/osde2e/cmd/osde2e/common"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/weather"
 )
 
@@ -15,6 +18,8 @@ import (
 type ReportToSlackCommand struct {
 	configString string
 	customConfig string
+	configFormat string
+	interval     time.Duration
 
 	subcommands.Command
 }
@@ -26,39 +31,75 @@ func (*ReportToSlackCommand) Name() string {
 
 // Synopsis is a short summary of the weather-report command
 func (*ReportToSlackCommand) Synopsis() string {
-	return "Produces a report based on osde2e test runs and sends it to a Slack webhook."
+	return "Produces a report based on osde2e test runs and sends it to a Slack webhook, once or every -interval."
 }
 
 // Usage describes how the weather-report-to-slack command is used
 func (*ReportToSlackCommand) Usage() string {
-	return "weather-report-to-slack"
+	return "weather-report-to-slack [-interval 24h]"
 }
 
 // SetFlags describes the arguments used by the weather-report command
 func (t *ReportToSlackCommand) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&t.configString, "configs", "", "A comma separated list of built in configs to use")
 	f.StringVar(&t.customConfig, "custom-config", "", "Custom config file for osde2e")
+	f.StringVar(&t.configFormat, "config-format", "", "Format of the custom config file: yaml, json, or toml. Detected from its extension if not set")
+	f.DurationVar(&t.interval, "interval", 0, "If set, keeps running and sends a report every interval, reloading the config when the custom config changes or on SIGHUP")
 }
 
 // Execute actually generates the weather report
 func (t *ReportToSlackCommand) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	if err := common.LoadConfigs(t.configString, t.customConfig); err != nil {
-		log.Printf("error loading initial state: %v", err)
+	if err := common.LoadConfigs(t.configString, t.customConfig, t.configFormat); err != nil {
+		logging.Errorf("error loading initial state: %v", err)
 		return subcommands.ExitFailure
 	}
 
 	if f.NArg() != 0 {
-		log.Printf("Unexpected number of arguments.")
+		logging.Errorf("Unexpected number of arguments.")
 		log.Printf(t.Usage())
 		return subcommands.ExitFailure
 	}
 
+	if t.interval > 0 {
+		return t.sendPeriodically()
+	}
+
 	err := weather.SendReportToSlack()
 
 	if err != nil {
-		log.Printf("error while sending report to slack: %v", err)
+		logging.Errorf("error while sending report to slack: %v", err)
 		return subcommands.ExitFailure
 	}
 
 	return subcommands.ExitSuccess
 }
+
+// sendPeriodically sends a report every interval until the process is stopped. Reloaded configs are applied between
+// reports, so a report is never generated with a mix of configs.
+func (t *ReportToSlackCommand) sendPeriodically() subcommands.ExitStatus {
+	reloaded, stop, err := common.WatchConfigs(t.configString, t.customConfig, t.configFormat)
+	if err != nil {
+		logging.Errorf("error watching config: %v", err)
+		return subcommands.ExitFailure
+	}
+	defer stop()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := weather.SendReportToSlack(); err != nil {
+			// a failed report is retried at the next interval instead of stopping the reports
+			logging.Errorf("error while sending report to slack: %v", err)
+		}
+
+		for waiting := true; waiting; {
+			select {
+			case c := <-reloaded:
+				config.Replace(c)
+			case <-ticker.C:
+				waiting = false
+			}
+		}
+	}
+}
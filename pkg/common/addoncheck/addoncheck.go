@@ -0,0 +1,236 @@
+// Package addoncheck checks that installed addons are ready using declarations of the operators, deployments, CRDs,
+// and routes each addon is expected to create. Covering a new addon only takes a new declaration.
+package addoncheck
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/markbates/pkger"
+	"gopkg.in/yaml.v2"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/phase"
+)
+
+// expectationsDir holds the maintained addon declarations, named after the addon ID.
+const expectationsDir = "/assets/addons/readiness"
+
+var (
+	csvResource        = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "clusterserviceversions"}
+	deploymentResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	crdResource        = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	routeResource      = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+)
+
+// Expectations declare what an addon creates once it is installed.
+type Expectations struct {
+	// CSVs are the operators the addon installs. A CSV matches if its name starts with the declared name, so
+	// declarations don't need to change with every operator version.
+	CSVs []Object `yaml:"csvs"`
+
+	// Deployments must have all of their replicas available.
+	Deployments []Object `yaml:"deployments"`
+
+	// CRDs are the names of CustomResourceDefinitions that must be established.
+	CRDs []string `yaml:"crds"`
+
+	// Routes must be admitted by a router.
+	Routes []Object `yaml:"routes"`
+}
+
+// Object names a namespaced object.
+type Object struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+}
+
+func (o Object) String() string {
+	if o.Namespace == "" {
+		return o.Name
+	}
+	return o.Namespace + "/" + o.Name
+}
+
+// Load loads the declaration of an addon from dir, or the maintained declaration if dir doesn't have one. It returns
+// nil if the addon has no declaration.
+func Load(addonID, dir string) (*Expectations, error) {
+	data, err := read(addonID, dir)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	e := &Expectations{}
+	if err = yaml.UnmarshalStrict(data, e); err != nil {
+		return nil, fmt.Errorf("error parsing the readiness declaration of addon %s: %v", addonID, err)
+	}
+	return e, nil
+}
+
+func read(addonID, dir string) ([]byte, error) {
+	if dir != "" {
+		path := filepath.Join(dir, addonID+".yaml")
+		if _, err := os.Stat(path); err == nil {
+			return ioutil.ReadFile(path)
+		}
+	}
+
+	file, err := pkger.Open(filepath.Join(expectationsDir, addonID+".yaml"))
+	if err != nil {
+		return nil, nil
+	}
+	defer file.Close()
+	return ioutil.ReadAll(file)
+}
+
+// listFunc lists the objects of a resource in a namespace, or cluster-wide if namespace is empty.
+type listFunc func(resource schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error)
+
+// Check returns what isn't ready yet of the objects an addon is expected to create.
+func Check(client dynamic.Interface, e *Expectations) []string {
+	return check(func(resource schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+		list, err := client.Resource(resource).Namespace(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}, e)
+}
+
+func check(list listFunc, e *Expectations) []string {
+	var problems []string
+	checkObjects := func(kind string, resource schema.GroupVersionResource, objects []Object, prefix bool, ready func(unstructured.Unstructured) (bool, string)) {
+		for _, object := range objects {
+			items, err := list(resource, object.Namespace)
+			if err != nil && !kerror.IsNotFound(err) {
+				problems = append(problems, fmt.Sprintf("%s %s: %v", kind, object, err))
+				continue
+			}
+
+			found := false
+			for _, item := range items {
+				if item.GetName() == object.Name || (prefix && strings.HasPrefix(item.GetName(), object.Name)) {
+					found = true
+					if ok, reason := ready(item); !ok {
+						problems = append(problems, fmt.Sprintf("%s %s: %s", kind, Object{object.Namespace, item.GetName()}, reason))
+					}
+				}
+			}
+			if !found {
+				problems = append(problems, fmt.Sprintf("%s %s doesn't exist", kind, object))
+			}
+		}
+	}
+
+	crds := make([]Object, len(e.CRDs))
+	for i, name := range e.CRDs {
+		crds[i] = Object{Name: name}
+	}
+
+	checkObjects("CSV", csvResource, e.CSVs, true, csvReady)
+	checkObjects("deployment", deploymentResource, e.Deployments, false, deploymentReady)
+	checkObjects("CRD", crdResource, crds, false, crdEstablished)
+	checkObjects("route", routeResource, e.Routes, false, routeAdmitted)
+	return problems
+}
+
+// Wait waits for the addons that have a declaration to be ready. Addons without one aren't checked.
+func Wait(client dynamic.Interface, addonIDs []string, dir string, timeout time.Duration) error {
+	expectations := map[string]*Expectations{}
+	for _, id := range addonIDs {
+		e, err := Load(id, dir)
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			logging.Warnf("Addon %s has no readiness declaration, so it isn't checked", id)
+			continue
+		}
+		expectations[id] = e
+	}
+	if len(expectations) == 0 {
+		return nil
+	}
+
+	var problems []string
+	err := phase.Poll(15*time.Second, timeout, func() (bool, error) {
+		problems = nil
+		for id, e := range expectations {
+			for _, problem := range Check(client, e) {
+				problems = append(problems, id+": "+problem)
+			}
+		}
+		sort.Strings(problems)
+
+		if len(problems) > 0 {
+			logging.Infof("Waiting for addons to be ready: %s", strings.Join(problems, "; "))
+		}
+		return len(problems) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("addons weren't ready: %s: %v", strings.Join(problems, "; "), err)
+	}
+	return nil
+}
+
+// csvReady returns true if the operator of a CSV was installed.
+func csvReady(csv unstructured.Unstructured) (bool, string) {
+	if csvPhase, _, _ := unstructured.NestedString(csv.Object, "status", "phase"); csvPhase != "Succeeded" {
+		return false, fmt.Sprintf("phase is %q rather than Succeeded", csvPhase)
+	}
+	return true, ""
+}
+
+// deploymentReady returns true if all of the desired replicas of a deployment are up to date and available.
+func deploymentReady(deployment unstructured.Unstructured) (bool, string) {
+	desired, found, _ := unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+	if !found {
+		desired = 1
+	}
+	updated, _, _ := unstructured.NestedInt64(deployment.Object, "status", "updatedReplicas")
+	available, _, _ := unstructured.NestedInt64(deployment.Object, "status", "availableReplicas")
+	if updated < desired || available < desired {
+		return false, fmt.Sprintf("%d of %d replicas are available", available, desired)
+	}
+	return true, ""
+}
+
+// crdEstablished returns true once a CRD is served.
+func crdEstablished(crd unstructured.Unstructured) (bool, string) {
+	if status, ok := crd.Object["status"].(map[string]interface{}); ok && conditionTrue(status, "Established") {
+		return true, ""
+	}
+	return false, "isn't established"
+}
+
+// routeAdmitted returns true once a router has admitted a route.
+func routeAdmitted(route unstructured.Unstructured) (bool, string) {
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	for _, ingress := range ingresses {
+		if i, ok := ingress.(map[string]interface{}); ok && conditionTrue(i, "Admitted") {
+			return true, ""
+		}
+	}
+	return false, "isn't admitted"
+}
+
+// conditionTrue returns true if a status has a condition of the given type that is true.
+func conditionTrue(status map[string]interface{}, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(status, "conditions")
+	for _, condition := range conditions {
+		if c, ok := condition.(map[string]interface{}); ok && c["type"] == conditionType && c["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
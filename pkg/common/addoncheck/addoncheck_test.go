@@ -0,0 +1,106 @@
+package addoncheck
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func object(name string, fields map[string]interface{}) unstructured.Unstructured {
+	o := unstructured.Unstructured{Object: fields}
+	o.SetName(name)
+	return o
+}
+
+func TestCheck(t *testing.T) {
+	objects := map[schema.GroupVersionResource][]unstructured.Unstructured{
+		csvResource: {
+			object("addon-operator.v1.2.3", map[string]interface{}{"status": map[string]interface{}{"phase": "Succeeded"}}),
+			object("other-operator.v0.1.0", map[string]interface{}{"status": map[string]interface{}{"phase": "Installing"}}),
+		},
+		deploymentResource: {
+			object("addon-operator", map[string]interface{}{
+				"spec":   map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{"updatedReplicas": int64(2), "availableReplicas": int64(1)},
+			}),
+		},
+		crdResource: {
+			object("addons.example.com", map[string]interface{}{"status": map[string]interface{}{
+				"conditions": []interface{}{map[string]interface{}{"type": "Established", "status": "True"}},
+			}}),
+		},
+		routeResource: {
+			object("console", map[string]interface{}{"status": map[string]interface{}{
+				"ingress": []interface{}{map[string]interface{}{
+					"conditions": []interface{}{map[string]interface{}{"type": "Admitted", "status": "True"}},
+				}},
+			}}),
+		},
+	}
+	list := func(resource schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+		return objects[resource], nil
+	}
+
+	ready := &Expectations{
+		CSVs:   []Object{{"addon", "addon-operator."}},
+		CRDs:   []string{"addons.example.com"},
+		Routes: []Object{{"addon", "console"}},
+	}
+	if problems := check(list, ready); len(problems) != 0 {
+		t.Errorf("expected the addon to be ready, got %q", problems)
+	}
+
+	notReady := &Expectations{
+		CSVs:        []Object{{"addon", "other-operator."}},
+		Deployments: []Object{{"addon", "addon-operator"}},
+		CRDs:        []string{"missing.example.com"},
+	}
+	expected := []string{
+		`CSV addon/other-operator.v0.1.0: phase is "Installing" rather than Succeeded`,
+		"deployment addon/addon-operator: 1 of 2 replicas are available",
+		"CRD missing.example.com doesn't exist",
+	}
+	if problems := check(list, notReady); !reflect.DeepEqual(problems, expected) {
+		t.Errorf("expected %q, got %q", expected, problems)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addoncheck")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "my-addon.yaml"), []byte("deployments:\n- namespace: my-addon\n  name: my-operator\n"), 0644); err != nil {
+		t.Fatalf("failed to write declaration: %v", err)
+	}
+
+	e, err := Load("my-addon", dir)
+	if err != nil {
+		t.Fatalf("failed to load declaration: %v", err)
+	}
+	if expected := (&Expectations{Deployments: []Object{{"my-addon", "my-operator"}}}); !reflect.DeepEqual(e, expected) {
+		t.Errorf("expected %+v, got %+v", expected, e)
+	}
+
+	if e, err = Load("submariner", dir); err != nil || e == nil || len(e.CRDs) == 0 {
+		t.Errorf("expected the maintained declaration to be loaded, got %+v (%v)", e, err)
+	}
+
+	if e, err = Load("unknown", dir); err != nil || e != nil {
+		t.Errorf("expected no declaration for an unknown addon, got %+v (%v)", e, err)
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "typo.yaml"), []byte("deployment:\n- name: my-operator\n"), 0644); err != nil {
+		t.Fatalf("failed to write declaration: %v", err)
+	}
+	if _, err = Load("typo", dir); err == nil {
+		t.Errorf("expected unknown fields to be rejected")
+	}
+}
@@ -0,0 +1,231 @@
+// Package alert pages the people gating releases when a version keeps failing a suite, so that genuine gate breaks
+// are noticed without watching dashboards.
+//
+// The number of consecutive failures of each version and suite is kept in a store shared by the runs. Streaks are only
+// written over the version that was read, so concurrent runs can't lose each other's results. Once it reaches
+// the threshold, every further failure triggers an event with a dedup key for the version and suite, so they add to a
+// single incident. The incident is resolved by the next run that passes the suite.
+package alert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// maxLinks is how many of the latest failed runs of a streak are kept and linked from events.
+const maxLinks = 5
+
+// writeAttempts is how many times a result is recorded before giving up on writes by other runs.
+const writeAttempts = 5
+
+// ErrConflict is returned by stores when a streak was written by another run since it was read.
+var ErrConflict = errors.New("the streak was written by another run")
+
+// Store holds the streaks of failures by name.
+type Store interface {
+	// Read returns the streak data for a name and its version, and false if there is none.
+	Read(name string) (data []byte, version string, ok bool, err error)
+
+	// Write stores the streak data for a name if it's still at the version read, or doesn't exist if the version is
+	// empty. Otherwise ErrConflict is returned.
+	Write(name string, data []byte, version string) error
+}
+
+// S3Store stores streaks as objects under an S3 URL.
+type S3Store struct {
+	URL string
+}
+
+// Read returns the streak object for a name, versioned by its ETag.
+func (s S3Store) Read(name string) ([]byte, string, bool, error) {
+	data, etag, err := aws.ReadFromS3WithETag(s.key(name))
+	if aws.IsS3NotFound(err) {
+		return nil, "", false, nil
+	}
+	return data, etag, err == nil, err
+}
+
+// Write stores the streak object for a name if its ETag still matches.
+func (s S3Store) Write(name string, data []byte, version string) error {
+	err := aws.WriteToS3IfMatch(s.key(name), data, version)
+	if aws.IsS3PreconditionFailed(err) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (s S3Store) key(name string) string {
+	return strings.TrimSuffix(s.URL, "/") + "/" + name + ".json"
+}
+
+// Pager notifies people of gate regressions.
+type Pager interface {
+	// Trigger opens an incident, or adds to the open incident with the same dedup key.
+	Trigger(event Event) error
+
+	// Resolve resolves the open incident with the dedup key, if there is one.
+	Resolve(dedupKey string) error
+}
+
+// Event describes a version that keeps failing a suite.
+type Event struct {
+	DedupKey string
+	Summary  string
+	Source   string
+	Version  string
+	Suite    string
+	Details  map[string]string
+
+	// Links are the URLs of the latest failed runs.
+	Links []string
+}
+
+// Result is whether a run of a version passed a suite.
+type Result struct {
+	Version string
+	Suite   string
+	Passed  bool
+
+	// URL links to the run's results, if it has any.
+	URL string
+}
+
+// Streak is the consecutive failures of a version and suite.
+type Streak struct {
+	Failures    int       `json:"failures"`
+	LastFailure time.Time `json:"lastFailure,omitempty"`
+	Paged       bool      `json:"paged"`
+
+	// Runs are the URLs of the latest failed runs.
+	Runs []string `json:"runs,omitempty"`
+}
+
+// Tracker counts consecutive failures and pages once there are threshold of them.
+type Tracker struct {
+	store     Store
+	pager     Pager
+	threshold int
+	source    string
+	now       func() time.Time
+}
+
+// New creates a tracker paging through pager once a version fails a suite threshold times in a row. Source names
+// what is failing in events, such as the job.
+func New(store Store, pager Pager, threshold int, source string) *Tracker {
+	return &Tracker{store: store, pager: pager, threshold: threshold, source: source, now: time.Now}
+}
+
+// Record updates the streak of the result's version and suite, and triggers or resolves its incident. If another run
+// updates the streak at the same time, the result is recorded again over its update.
+func (t *Tracker) Record(result Result) error {
+	name := streakName(result.Version, result.Suite)
+	for attempt := 1; ; attempt++ {
+		err := t.record(name, result)
+		if err != ErrConflict {
+			return err
+		}
+		if attempt == writeAttempts {
+			return fmt.Errorf("error writing the failures of %s: %v", name, err)
+		}
+	}
+}
+
+// record updates a streak with a result. ErrConflict is returned if the streak was written since it was read.
+func (t *Tracker) record(name string, result Result) error {
+	streak, version, err := t.read(name)
+	if err != nil {
+		return fmt.Errorf("error reading the failures of %s: %v", name, err)
+	}
+
+	dedupKey := DedupKey(result.Version, result.Suite)
+	if result.Passed {
+		if streak.Failures == 0 {
+			return nil
+		}
+		// resolving again is harmless, so the incident is resolved before the streak is reset in case resolving fails
+		if streak.Paged {
+			if err = t.pager.Resolve(dedupKey); err != nil {
+				return err
+			}
+			logging.Infof("Resolved the incident for %s failing %s.", result.Version, result.Suite)
+		}
+		return t.write(name, Streak{}, version)
+	}
+
+	streak.Failures++
+	streak.LastFailure = t.now().UTC()
+	if result.URL != "" {
+		streak.Runs = append(streak.Runs, result.URL)
+		if len(streak.Runs) > maxLinks {
+			streak.Runs = streak.Runs[len(streak.Runs)-maxLinks:]
+		}
+	}
+
+	// the failure is counted before paging, so a run that lost the write doesn't page, and if paging fails the next
+	// failure pages again
+	page := streak.Failures >= t.threshold
+	if page {
+		streak.Paged = true
+	}
+	if err = t.write(name, streak, version); err != nil || !page {
+		return err
+	}
+
+	err = t.pager.Trigger(Event{
+		DedupKey: dedupKey,
+		Summary:  fmt.Sprintf("%s has failed %s %d times in a row", result.Version, result.Suite, streak.Failures),
+		Source:   t.source,
+		Version:  result.Version,
+		Suite:    result.Suite,
+		Details: map[string]string{
+			"consecutive failures": fmt.Sprint(streak.Failures),
+			"last failure":         streak.LastFailure.Format(time.RFC3339),
+		},
+		Links: streak.Runs,
+	})
+	if err == nil {
+		logging.Infof("Paged for %s failing %s %d times in a row.", result.Version, result.Suite, streak.Failures)
+	}
+	return err
+}
+
+// DedupKey identifies the incident of a version failing a suite.
+func DedupKey(version, suite string) string {
+	return fmt.Sprintf("osde2e/%s/%s", version, suite)
+}
+
+func (t *Tracker) read(name string) (Streak, string, error) {
+	var streak Streak
+	data, version, ok, err := t.store.Read(name)
+	if err != nil || !ok {
+		return streak, "", err
+	}
+	err = json.Unmarshal(data, &streak)
+	return streak, version, err
+}
+
+func (t *Tracker) write(name string, streak Streak, version string) error {
+	data, err := json.Marshal(streak)
+	if err != nil {
+		return err
+	}
+	if err = t.store.Write(name, data, version); err != nil && err != ErrConflict {
+		return fmt.Errorf("error writing the failures of %s: %v", name, err)
+	}
+	return err
+}
+
+// unsafeChars are characters left out of the names of streaks.
+var unsafeChars = regexp.MustCompile(`[^a-z0-9.]+`)
+
+// streakName names the streak of a version and suite so it can be used in an object key.
+func streakName(version, suite string) string {
+	return version + "/" + strings.Trim(unsafeChars.ReplaceAllString(strings.ToLower(suite), "-"), "-")
+}
@@ -0,0 +1,153 @@
+package alert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type memoryStore map[string][]byte
+
+// Read versions streaks by their contents, which change with every write.
+func (s memoryStore) Read(name string) ([]byte, string, bool, error) {
+	data, ok := s[name]
+	return data, string(data), ok, nil
+}
+
+func (s memoryStore) Write(name string, data []byte, version string) error {
+	if string(s[name]) != version {
+		return ErrConflict
+	}
+	s[name] = data
+	return nil
+}
+
+// racingStore records a failure from another run between the first read and write of a streak.
+type racingStore struct {
+	memoryStore
+	raced bool
+}
+
+func (s *racingStore) Write(name string, data []byte, version string) error {
+	if !s.raced {
+		s.raced = true
+		other := testTracker(s.memoryStore, &fakePager{})
+		if err := other.Record(Result{Version: "4.5.1", Suite: "[Suite: e2e]", URL: "https://prow.example.com/other"}); err != nil {
+			return err
+		}
+	}
+	return s.memoryStore.Write(name, data, version)
+}
+
+type fakePager struct {
+	triggered []Event
+	resolved  []string
+	err       error
+}
+
+func (p *fakePager) Trigger(event Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.triggered = append(p.triggered, event)
+	return nil
+}
+
+func (p *fakePager) Resolve(dedupKey string) error {
+	p.resolved = append(p.resolved, dedupKey)
+	return nil
+}
+
+func testTracker(store Store, pager Pager) *Tracker {
+	tracker := New(store, pager, 3, "osde2e-prod-aws-e2e-default")
+	tracker.now = func() time.Time { return time.Unix(1600000000, 0) }
+	return tracker
+}
+
+func TestRecord(t *testing.T) {
+	store, pager := memoryStore{}, &fakePager{}
+	tracker := testTracker(store, pager)
+
+	failure := Result{Version: "4.5.1", Suite: "[Suite: e2e]", Passed: false}
+	for i := 1; i <= 4; i++ {
+		failure.URL = fmt.Sprintf("https://prow.example.com/%d", i)
+		if err := tracker.Record(failure); err != nil {
+			t.Fatalf("failed to record failure %d: %v", i, err)
+		}
+		if expected := i - 2; expected > 0 && len(pager.triggered) != expected {
+			t.Errorf("expected %d events after %d failures, got %d", expected, i, len(pager.triggered))
+		} else if expected <= 0 && len(pager.triggered) != 0 {
+			t.Errorf("expected no events after %d failures, got %v", i, pager.triggered)
+		}
+	}
+
+	event := pager.triggered[1]
+	if event.DedupKey != "osde2e/4.5.1/[Suite: e2e]" || event.Summary != "4.5.1 has failed [Suite: e2e] 4 times in a row" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if len(event.Links) != 4 || event.Links[3] != "https://prow.example.com/4" {
+		t.Errorf("expected the event to link to the failed runs, got %v", event.Links)
+	}
+
+	// other versions and suites have their own streaks
+	if err := tracker.Record(Result{Version: "4.5.2", Suite: "[Suite: e2e]"}); err != nil || len(pager.triggered) != 2 {
+		t.Errorf("expected another version's failure not to page, got %v: %v", pager.triggered, err)
+	}
+	if _, ok := store["4.5.1/suite-e2e"]; !ok {
+		t.Errorf("expected the streak to be stored by version and suite, got %v", store)
+	}
+
+	if err := tracker.Record(Result{Version: "4.5.1", Suite: "[Suite: e2e]", Passed: true}); err != nil {
+		t.Fatalf("failed to record pass: %v", err)
+	}
+	if len(pager.resolved) != 1 || pager.resolved[0] != "osde2e/4.5.1/[Suite: e2e]" {
+		t.Errorf("expected the incident to be resolved, got %v", pager.resolved)
+	}
+
+	// the streak starts over after a pass
+	failure.URL = ""
+	if err := tracker.Record(failure); err != nil || len(pager.triggered) != 2 {
+		t.Errorf("expected a new streak not to page, got %v: %v", pager.triggered, err)
+	}
+}
+
+func TestRecordPagingFailure(t *testing.T) {
+	store, pager := memoryStore{}, &fakePager{err: fmt.Errorf("PagerDuty is down")}
+	tracker := New(store, pager, 1, "osde2e")
+
+	failure := Result{Version: "4.5.1", Suite: "[Suite: e2e]"}
+	if err := tracker.Record(failure); err == nil {
+		t.Errorf("expected the paging error to be returned")
+	}
+
+	pager.err = nil
+	if err := tracker.Record(failure); err != nil || len(pager.triggered) != 1 {
+		t.Errorf("expected the next failure to page, got %v: %v", pager.triggered, err)
+	}
+	if pager.triggered[0].Summary != "4.5.1 has failed [Suite: e2e] 2 times in a row" {
+		t.Errorf("expected failures to be counted while paging failed, got %s", pager.triggered[0].Summary)
+	}
+
+	// passes only resolve incidents that were opened
+	if err := tracker.Record(Result{Version: "4.5.2", Suite: "[Suite: e2e]", Passed: true}); err != nil || len(pager.resolved) != 0 {
+		t.Errorf("expected nothing to be resolved, got %v: %v", pager.resolved, err)
+	}
+}
+
+func TestRecordConcurrentFailure(t *testing.T) {
+	store, pager := &racingStore{memoryStore: memoryStore{}}, &fakePager{}
+	tracker := testTracker(store, pager)
+
+	failure := Result{Version: "4.5.1", Suite: "[Suite: e2e]", URL: "https://prow.example.com/1"}
+	if err := tracker.Record(failure); err != nil {
+		t.Fatalf("failed to record failure: %v", err)
+	}
+
+	streak, _, err := tracker.read("4.5.1/suite-e2e")
+	if err != nil {
+		t.Fatalf("failed to read streak: %v", err)
+	}
+	if streak.Failures != 2 || len(streak.Runs) != 2 {
+		t.Errorf("expected both runs' failures to be counted, got %+v", streak)
+	}
+}
@@ -0,0 +1,129 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+const (
+	// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+	requestTimeout = 30 * time.Second
+)
+
+// PagerDuty sends events to a PagerDuty service through the Events API v2.
+type PagerDuty struct {
+	url        string
+	routingKey string
+	client     *http.Client
+	retry      backoff.Backoff
+}
+
+// pagerDutyEvent is the body of an Events API v2 request.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+// NewPagerDuty creates a pager for the PagerDuty service with the routing key, sending events to eventsURL.
+func NewPagerDuty(eventsURL, routingKey string) *PagerDuty {
+	retry := backoff.Exponential(5*time.Second, time.Minute)
+	retry.MaxAttempts = 5
+
+	return &PagerDuty{
+		url:        eventsURL,
+		routingKey: routingKey,
+		client:     &http.Client{Timeout: requestTimeout},
+		retry:      retry,
+	}
+}
+
+// Trigger opens an incident, or adds to the open incident with the same dedup key.
+func (p *PagerDuty) Trigger(event Event) error {
+	body := pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "trigger",
+		DedupKey:    event.DedupKey,
+		Payload: &pagerDutyPayload{
+			Summary:       event.Summary,
+			Source:        event.Source,
+			Severity:      "critical",
+			Component:     event.Version,
+			Group:         event.Suite,
+			CustomDetails: event.Details,
+		},
+	}
+	for _, link := range event.Links {
+		body.Links = append(body.Links, pagerDutyLink{Href: link, Text: "Failed run"})
+	}
+	return p.send(body)
+}
+
+// Resolve resolves the open incident with the dedup key, if there is one.
+func (p *PagerDuty) Resolve(dedupKey string) error {
+	return p.send(pagerDutyEvent{RoutingKey: p.routingKey, EventAction: "resolve", DedupKey: dedupKey})
+}
+
+// send sends an event. Server errors and rate limiting are retried.
+func (p *PagerDuty) send(event pagerDutyEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	err = p.retry.Retry(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(data))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		err = fmt.Errorf("PagerDuty returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			return backoff.Permanent(err)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't send %s event %s to PagerDuty: %v", event.EventAction, event.DedupKey, err)
+	}
+	return nil
+}
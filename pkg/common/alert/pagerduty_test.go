@@ -0,0 +1,77 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func TestPagerDuty(t *testing.T) {
+	var events []pagerDutyEvent
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		var event pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pager := NewPagerDuty(server.URL, "routing-key")
+	pager.retry = backoff.Constant(time.Millisecond, 3)
+
+	err := pager.Trigger(Event{
+		DedupKey: DedupKey("4.5.1", "[Suite: e2e]"),
+		Summary:  "4.5.1 has failed [Suite: e2e] 3 times in a row",
+		Source:   "osde2e-prod-aws-e2e-default",
+		Version:  "4.5.1",
+		Suite:    "[Suite: e2e]",
+		Links:    []string{"https://prow.example.com/1"},
+	})
+	if err != nil {
+		t.Fatalf("failed to trigger: %v", err)
+	}
+	if err = pager.Resolve(DedupKey("4.5.1", "[Suite: e2e]")); err != nil {
+		t.Fatalf("failed to resolve: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected rate limited events to be retried, got %v", events)
+	}
+	trigger, resolve := events[0], events[1]
+	if trigger.RoutingKey != "routing-key" || trigger.EventAction != "trigger" || trigger.DedupKey != "osde2e/4.5.1/[Suite: e2e]" {
+		t.Errorf("unexpected trigger event: %+v", trigger)
+	}
+	if trigger.Payload == nil || trigger.Payload.Severity != "critical" || trigger.Payload.Component != "4.5.1" || len(trigger.Links) != 1 {
+		t.Errorf("unexpected trigger payload: %+v", trigger.Payload)
+	}
+	if resolve.EventAction != "resolve" || resolve.DedupKey != trigger.DedupKey || resolve.Payload != nil {
+		t.Errorf("unexpected resolve event: %+v", resolve)
+	}
+}
+
+func TestPagerDutyErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	pager := NewPagerDuty(server.URL, "invalid")
+	pager.retry = backoff.Constant(time.Millisecond, 3)
+	if err := pager.Resolve("osde2e/4.5.1/[Suite: e2e]"); err == nil || requests != 1 {
+		t.Errorf("expected invalid events not to be retried, got %d requests: %v", requests, err)
+	}
+}
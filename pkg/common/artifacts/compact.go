@@ -0,0 +1,208 @@
+package artifacts
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift/osde2e/pkg/common/manifest"
+)
+
+// IndexFile is the name of the index of the compacted artifacts written to the report directory.
+const IndexFile string = "index.json"
+
+// Index lists the artifacts in the report directory and where the contents of each are stored once compacted.
+type Index struct {
+	Files []IndexEntry `json:"files"`
+
+	// OriginalSize and StoredSize are the total sizes, in bytes, of the artifacts before and after compaction.
+	OriginalSize int64 `json:"originalSize"`
+	StoredSize   int64 `json:"storedSize"`
+}
+
+// IndexEntry describes an artifact. Paths are relative to the report directory.
+type IndexEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+
+	// StoredAs is where the contents of the artifact can be found.
+	StoredAs string `json:"storedAs"`
+
+	// DuplicateOf is the artifact with identical contents that was kept instead of this one.
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+
+	// Compressed is true when the contents were gzipped.
+	Compressed bool `json:"compressed,omitempty"`
+}
+
+// CompactReportDir removes artifacts that are identical to another one and gzips text artifacts of at least
+// threshold bytes, then writes an index of the artifacts. Results, metadata, and files that are already compressed
+// are left as they are.
+func CompactReportDir(reportDir string, threshold int64) (*Index, error) {
+	files, err := compactableFiles(reportDir)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &Index{Files: []IndexEntry{}}
+	stored := map[string]IndexEntry{}
+	for _, file := range files {
+		path := filepath.Join(reportDir, file)
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		sum, err := sha256File(path)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing artifact %s: %v", file, err)
+		}
+
+		entry := IndexEntry{Path: filepath.ToSlash(file), Size: info.Size(), SHA256: sum, StoredAs: filepath.ToSlash(file)}
+		index.OriginalSize += entry.Size
+
+		if original, ok := stored[sum]; ok {
+			if err = os.Remove(path); err != nil {
+				return nil, fmt.Errorf("error removing duplicate artifact %s: %v", file, err)
+			}
+			entry.DuplicateOf, entry.StoredAs, entry.Compressed = original.Path, original.StoredAs, original.Compressed
+			index.Files = append(index.Files, entry)
+			continue
+		}
+
+		storedSize := entry.Size
+		if entry.Size >= threshold && !strings.HasSuffix(file, ".gz") && isText(path) {
+			if storedSize, err = gzipFile(path); err != nil {
+				return nil, fmt.Errorf("error compressing artifact %s: %v", file, err)
+			}
+			entry.StoredAs, entry.Compressed = entry.StoredAs+".gz", true
+		}
+		index.StoredSize += storedSize
+
+		stored[sum] = entry
+		index.Files = append(index.Files, entry)
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(filepath.Join(reportDir, IndexFile), data, os.FileMode(0644)); err != nil {
+		return nil, fmt.Errorf("error writing artifact index: %v", err)
+	}
+	return index, removeEmptyDirs(reportDir)
+}
+
+// compactableFiles lists the files in the report directory that may be compacted, relative to the directory.
+func compactableFiles(reportDir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(reportDir, path)
+		if err != nil {
+			return err
+		}
+
+		if !isKept(name) {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error collecting artifacts from %s: %v", reportDir, err)
+	}
+
+	// the first of identical files in path order is kept
+	sort.Strings(files)
+	return files, nil
+}
+
+// isKept returns true for files that are read by other tools and must be left as they are.
+func isKept(name string) bool {
+	base := filepath.Base(name)
+	if strings.HasPrefix(base, "junit") && strings.HasSuffix(base, ".xml") {
+		return true
+	}
+	return strings.HasSuffix(base, "metadata.json") || (filepath.Dir(name) == "." && (name == IndexFile || name == manifest.ManifestFile)) || name == EncryptedBundleFile
+}
+
+// isText detects whether a file holds text from its first bytes.
+func isText(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return false
+	}
+	return strings.HasPrefix(http.DetectContentType(head[:n]), "text/")
+}
+
+// gzipFile replaces a file with a gzipped copy and returns the size of the copy.
+func gzipFile(path string) (int64, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	compressed := path + ".gz"
+	out, err := os.OpenFile(compressed, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	zw := gzip.NewWriter(out)
+	zw.Name = filepath.Base(path)
+	if _, err = io.Copy(zw, in); err != nil {
+		os.Remove(compressed)
+		return 0, err
+	}
+	if err = zw.Close(); err != nil {
+		os.Remove(compressed)
+		return 0, err
+	}
+
+	info, err := out.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if err = out.Close(); err != nil {
+		return 0, err
+	}
+
+	// only remove the original once it has been compressed
+	in.Close()
+	return info.Size(), os.Remove(path)
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
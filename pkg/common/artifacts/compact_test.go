@@ -0,0 +1,106 @@
+package artifacts
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompactReportDir(t *testing.T) {
+	reportDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(reportDir)
+
+	largeLog := strings.Repeat("I1015 12:00:00.000000 1 controller.go:42] synced\n", 100)
+	files := map[string]string{
+		"install/junit_abc.xml":                  largeLog,
+		"install/must-gather/node-a/kubelet.log": largeLog,
+		"install/must-gather/node-b/kubelet.log": largeLog,
+		"install/must-gather/small.log":          "ok\n",
+		"install/must-gather/dup/small.log":      "ok\n",
+		"install/binary.bin":                     string(make([]byte, 4096)),
+		"custom-prow-metadata.json":              "{}",
+	}
+	for name, contents := range files {
+		path := filepath.Join(reportDir, name)
+		if err = os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err = ioutil.WriteFile(path, []byte(contents), os.FileMode(0644)); err != nil {
+			t.Fatalf("failed to write artifact: %v", err)
+		}
+	}
+
+	index, err := CompactReportDir(reportDir, 1024)
+	if err != nil {
+		t.Fatalf("failed to compact report dir: %v", err)
+	}
+
+	entries := map[string]IndexEntry{}
+	for _, entry := range index.Files {
+		entries[entry.Path] = entry
+	}
+	if len(entries) != 5 {
+		t.Errorf("expected 5 artifacts in the index, got %+v", index.Files)
+	}
+
+	// results, metadata and binaries are left alone
+	for _, name := range []string{"install/junit_abc.xml", "custom-prow-metadata.json", "install/binary.bin"} {
+		if _, err = os.Stat(filepath.Join(reportDir, name)); err != nil {
+			t.Errorf("expected %s to be kept: %v", name, err)
+		}
+	}
+	if entry := entries["install/binary.bin"]; entry.Compressed || entry.StoredAs != "install/binary.bin" {
+		t.Errorf("expected binary artifact to be stored as is, got %+v", entry)
+	}
+
+	// large text is compressed once and duplicates point to it
+	kept := entries["install/must-gather/node-a/kubelet.log"]
+	if !kept.Compressed || kept.StoredAs != "install/must-gather/node-a/kubelet.log.gz" || kept.Size != int64(len(largeLog)) {
+		t.Errorf("expected large log to be compressed, got %+v", kept)
+	}
+	dup := entries["install/must-gather/node-b/kubelet.log"]
+	if dup.DuplicateOf != kept.Path || dup.StoredAs != kept.StoredAs || !dup.Compressed || dup.SHA256 != kept.SHA256 {
+		t.Errorf("expected log to be a duplicate of %s, got %+v", kept.Path, dup)
+	}
+	if _, err = os.Stat(filepath.Join(reportDir, "install/must-gather/node-b")); !os.IsNotExist(err) {
+		t.Errorf("expected the directory of the duplicate to be removed: %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(reportDir, kept.StoredAs))
+	if err != nil {
+		t.Fatalf("failed to open compressed artifact: %v", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("failed to read compressed artifact: %v", err)
+	}
+	if data, err := ioutil.ReadAll(zr); err != nil || string(data) != largeLog {
+		t.Errorf("compressed artifact doesn't match the original: %v", err)
+	}
+
+	// small text isn't compressed, but is deduplicated
+	if entry := entries["install/must-gather/small.log"]; entry.DuplicateOf != "install/must-gather/dup/small.log" || entry.Compressed {
+		t.Errorf("expected small log to be an uncompressed duplicate, got %+v", entry)
+	}
+
+	if index.OriginalSize != int64(2*len(largeLog)+2*3+4096) || index.StoredSize >= index.OriginalSize {
+		t.Errorf("unexpected sizes, original %d, stored %d", index.OriginalSize, index.StoredSize)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(reportDir, IndexFile))
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	var written Index
+	if err = json.Unmarshal(data, &written); err != nil || len(written.Files) != len(index.Files) {
+		t.Errorf("unexpected index written %s: %v", data, err)
+	}
+}
@@ -0,0 +1,189 @@
+// Package artifacts manages the files osde2e leaves in the report directory.
+package artifacts
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// EncryptedBundleFile is the name of the encrypted bundle of artifacts written to the report directory.
+const EncryptedBundleFile string = "artifacts.tar.gz.gpg"
+
+// LoadRecipients reads the OpenPGP public keys artifacts are encrypted for. The keyring may be armored or binary.
+func LoadRecipients(keyring string) (openpgp.EntityList, error) {
+	data, err := ioutil.ReadFile(keyring)
+	if err != nil {
+		return nil, fmt.Errorf("error reading artifact encryption keyring: %v", err)
+	}
+
+	var recipients openpgp.EntityList
+	if block, err := armor.Decode(bytes.NewReader(data)); err == nil {
+		recipients, err = openpgp.ReadKeyRing(block.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing artifact encryption keyring: %v", err)
+		}
+	} else if recipients, err = openpgp.ReadKeyRing(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("error parsing artifact encryption keyring: %v", err)
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("artifact encryption keyring %s has no keys", keyring)
+	}
+	return recipients, nil
+}
+
+// KeyIDs returns the IDs of the recipients' primary keys, as displayed by gpg.
+func KeyIDs(recipients openpgp.EntityList) []string {
+	ids := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		ids = append(ids, recipient.PrimaryKey.KeyIdString())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// EncryptReportDir replaces the contents of the report directory with a bundle encrypted for the recipients.
+// Metadata files stay in plaintext so runs can still be identified and the keys used can be found.
+func EncryptReportDir(reportDir string, recipients openpgp.EntityList) error {
+	files, err := bundledFiles(reportDir)
+	if err != nil {
+		return err
+	}
+
+	bundleFile := filepath.Join(reportDir, EncryptedBundleFile)
+	if err = writeEncryptedBundle(bundleFile, reportDir, files, recipients); err != nil {
+		os.Remove(bundleFile)
+		return fmt.Errorf("error writing encrypted artifact bundle: %v", err)
+	}
+
+	// only remove plaintext once it has been encrypted
+	for _, file := range files {
+		if err = os.Remove(filepath.Join(reportDir, file)); err != nil {
+			return fmt.Errorf("error removing plaintext artifact %s: %v", file, err)
+		}
+	}
+	return removeEmptyDirs(reportDir)
+}
+
+// bundledFiles lists the files in the report directory that are encrypted, relative to the directory.
+func bundledFiles(reportDir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(reportDir, path)
+		if err != nil {
+			return err
+		}
+
+		if !isPlaintext(name) {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error collecting artifacts from %s: %v", reportDir, err)
+	}
+	return files, nil
+}
+
+// isPlaintext returns true for top-level files that are left unencrypted.
+func isPlaintext(name string) bool {
+	return name == EncryptedBundleFile || (filepath.Dir(name) == "." && strings.HasSuffix(name, "metadata.json"))
+}
+
+// writeEncryptedBundle writes a gzipped tarball of the files, encrypted for the recipients.
+func writeEncryptedBundle(bundleFile, reportDir string, files []string, recipients openpgp.EntityList) error {
+	out, err := os.OpenFile(bundleFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	plaintext, err := openpgp.Encrypt(out, recipients, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(plaintext)
+	tw := tar.NewWriter(zw)
+	for _, file := range files {
+		if err = addToTar(tw, reportDir, file); err != nil {
+			return err
+		}
+	}
+
+	// each layer must be closed for the bundle to be complete
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	if err = plaintext.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func addToTar(tw *tar.Writer, reportDir, file string) error {
+	f, err := os.Open(filepath.Join(reportDir, file))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(file)
+
+	if err = tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// removeEmptyDirs removes directories left empty once their files were encrypted.
+func removeEmptyDirs(reportDir string) error {
+	var dirs []string
+	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() && path != reportDir {
+			dirs = append(dirs, path)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// remove the deepest directories first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := ioutil.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err = os.Remove(dirs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
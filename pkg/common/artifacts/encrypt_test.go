@@ -0,0 +1,169 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// writeKeyring writes the armored public key of a new entity into dir and returns the entity and keyring path.
+func writeKeyring(t *testing.T, dir string) (*openpgp.Entity, string) {
+	entity, err := openpgp.NewEntity("osde2e", "test", "osde2e@example.com", &packet.Config{RSABits: 1024})
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	// keys made by gpg list their preferred algorithms, otherwise openpgp falls back to RIPEMD160, which isn't available
+	for _, identity := range entity.Identities {
+		identity.SelfSignature.PreferredHash = []uint8{8}
+		identity.SelfSignature.PreferredSymmetric = []uint8{uint8(packet.CipherAES256)}
+		if err = identity.SelfSignature.SignUserId(identity.UserId.Id, entity.PrimaryKey, entity.PrivateKey, nil); err != nil {
+			t.Fatalf("failed to sign identity: %v", err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("failed to armor key: %v", err)
+	}
+	if err = entity.Serialize(w); err != nil {
+		t.Fatalf("failed to serialize key: %v", err)
+	}
+	w.Close()
+
+	keyring := filepath.Join(dir, "keyring.asc")
+	if err = ioutil.WriteFile(keyring, buf.Bytes(), os.FileMode(0644)); err != nil {
+		t.Fatalf("failed to write keyring: %v", err)
+	}
+	return entity, keyring
+}
+
+func TestEncryptReportDir(t *testing.T) {
+	keyDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(keyDir)
+
+	reportDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(reportDir)
+
+	entity, keyring := writeKeyring(t, keyDir)
+	recipients, err := LoadRecipients(keyring)
+	if err != nil {
+		t.Fatalf("failed to load recipients: %v", err)
+	}
+
+	if ids := KeyIDs(recipients); len(ids) != 1 || ids[0] != entity.PrimaryKey.KeyIdString() {
+		t.Errorf("unexpected key IDs %v", ids)
+	}
+
+	if err = os.MkdirAll(filepath.Join(reportDir, "install", "containerLogs"), os.FileMode(0755)); err != nil {
+		t.Fatalf("failed to create phase directory: %v", err)
+	}
+
+	files := map[string]string{
+		"metadata.json":                    "{}",
+		"install/junit_abc.xml":            "<testsuite></testsuite>",
+		"install/containerLogs/router.log": "customer.example.com",
+		"cluster-state.json.gz":            "state",
+	}
+	for file, contents := range files {
+		if err = ioutil.WriteFile(filepath.Join(reportDir, file), []byte(contents), os.FileMode(0644)); err != nil {
+			t.Fatalf("failed to write %s: %v", file, err)
+		}
+	}
+
+	if err = EncryptReportDir(reportDir, recipients); err != nil {
+		t.Fatalf("failed to encrypt report dir: %v", err)
+	}
+
+	// only the metadata and the bundle should be left in plaintext
+	entries, err := ioutil.ReadDir(reportDir)
+	if err != nil {
+		t.Fatalf("failed to read report dir: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name() != EncryptedBundleFile || entries[1].Name() != "metadata.json" {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("unexpected files left in report dir %v", names)
+	}
+
+	bundle, err := os.Open(filepath.Join(reportDir, EncryptedBundleFile))
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+	defer bundle.Close()
+
+	md, err := openpgp.ReadMessage(bundle, openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt bundle: %v", err)
+	}
+
+	zr, err := gzip.NewReader(md.UnverifiedBody)
+	if err != nil {
+		t.Fatalf("failed to decompress bundle: %v", err)
+	}
+
+	bundled := map[string]string{}
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("failed to read bundle: %v", err)
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("failed to read %s from bundle: %v", header.Name, err)
+		}
+		bundled[header.Name] = string(data)
+	}
+
+	for file, contents := range files {
+		if file == "metadata.json" {
+			continue
+		}
+		if bundled[file] != contents {
+			t.Errorf("expected %s in the bundle to be %q, got %q", file, contents, bundled[file])
+		}
+	}
+
+	if _, ok := bundled["metadata.json"]; ok || len(bundled) != len(files)-1 {
+		t.Errorf("unexpected files in bundle %v", bundled)
+	}
+}
+
+func TestLoadRecipientsEmpty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyring := filepath.Join(dir, "keyring.gpg")
+	if err = ioutil.WriteFile(keyring, nil, os.FileMode(0644)); err != nil {
+		t.Fatalf("failed to write keyring: %v", err)
+	}
+
+	if _, err = LoadRecipients(keyring); err == nil {
+		t.Errorf("expected an empty keyring to be rejected")
+	}
+}
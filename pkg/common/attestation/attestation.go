@@ -0,0 +1,310 @@
+// Package attestation signs the results of an osde2e run so that consumers can verify they are authentic.
+//
+// Results are described by an in-toto statement whose subjects are the JUnit, metadata, and encrypted artifact
+// files in the report directory. The statement is signed with the private key in a configured file and wrapped in a DSSE envelope.
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/artifacts"
+	"github.com/openshift/osde2e/pkg/common/manifest"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// AttestationFile is the name of the signed attestation written to the report directory.
+	AttestationFile string = "attestation.json"
+
+	// StatementType is the in-toto statement type.
+	StatementType = "https://in-toto.io/Statement/v0.1"
+
+	// PredicateType identifies the osde2e test result predicate.
+	PredicateType = "https://github.com/openshift/osde2e/attestation/test-results/v1"
+
+	// PayloadType is the DSSE payload type of in-toto statements.
+	PayloadType = "application/vnd.in-toto+json"
+)
+
+// Statement is an in-toto statement about a set of result files.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Subject is a file covered by a statement.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate describes the run that produced the subjects.
+type Predicate struct {
+	Passed         bool      `json:"passed"`
+	BuildCommit    string    `json:"buildCommit"`
+	ClusterID      string    `json:"clusterID,omitempty"`
+	ClusterVersion string    `json:"clusterVersion,omitempty"`
+	UpgradeVersion string    `json:"upgradeVersion,omitempty"`
+	Finished       time.Time `json:"finished"`
+}
+
+// Envelope is a DSSE envelope holding a signed statement.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a single signature over an envelope payload.
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Generate builds a statement covering the results in the report directory.
+func Generate(reportDir string, passed bool) (*Statement, error) {
+	subjects, err := collectSubjects(reportDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			Passed:         passed,
+			BuildCommit:    manifest.BuildCommit,
+			ClusterID:      state.Instance.Cluster.ID,
+			ClusterVersion: state.Instance.Cluster.Version,
+			UpgradeVersion: state.Instance.Upgrade.ReleaseName,
+			Finished:       time.Now().UTC(),
+		},
+	}, nil
+}
+
+// Write generates and signs a statement for the report directory using the PEM encoded PKCS8 key in keyFile.
+func Write(reportDir, keyFile string, passed bool) error {
+	statement, err := Generate(reportDir, passed)
+	if err != nil {
+		return fmt.Errorf("error generating attestation: %v", err)
+	}
+
+	signer, keyID, err := loadSigner(keyFile)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := Sign(statement, signer, keyID)
+	if err != nil {
+		return fmt.Errorf("error signing attestation: %v", err)
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(reportDir, AttestationFile), data, os.FileMode(0644))
+}
+
+// Sign wraps a statement in a signed DSSE envelope.
+func Sign(statement *Statement, signer crypto.Signer, keyID string) (*Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, opts := digestFor(signer.Public(), pae(PayloadType, payload))
+	sig, err := signer.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{
+				KeyID: keyID,
+				Sig:   base64.StdEncoding.EncodeToString(sig),
+			},
+		},
+	}, nil
+}
+
+// Verify checks that an envelope was signed by the given public key and returns the statement it contains.
+func Verify(envelope *Envelope, pub crypto.PublicKey) (*Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("unexpected payload type %s", envelope.PayloadType)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding payload: %v", err)
+	}
+
+	digest, _ := digestFor(pub, pae(envelope.PayloadType, payload))
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+
+		if verifySignature(pub, digest, sig) {
+			statement := &Statement{}
+			if err = json.Unmarshal(payload, statement); err != nil {
+				return nil, fmt.Errorf("error parsing statement: %v", err)
+			}
+			return statement, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no valid signature found")
+}
+
+// VerifySubjects confirms the files in reportDir match the digests recorded in a statement.
+func VerifySubjects(statement *Statement, reportDir string) error {
+	for _, subject := range statement.Subject {
+		digest, err := sha256File(filepath.Join(reportDir, subject.Name))
+		if err != nil {
+			return err
+		}
+
+		if digest != subject.Digest["sha256"] {
+			return fmt.Errorf("%s has been modified", subject.Name)
+		}
+	}
+	return nil
+}
+
+// collectSubjects finds the JUnit and metadata files in the report directory.
+func collectSubjects(reportDir string) ([]Subject, error) {
+	var subjects []Subject
+	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !isResult(info.Name()) {
+			return err
+		}
+
+		name, err := filepath.Rel(reportDir, path)
+		if err != nil {
+			return err
+		}
+
+		digest, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+
+		subjects = append(subjects, Subject{
+			Name:   filepath.ToSlash(name),
+			Digest: map[string]string{"sha256": digest},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error collecting results from %s: %v", reportDir, err)
+	}
+
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].Name < subjects[j].Name
+	})
+	return subjects, nil
+}
+
+// isResult returns true for files that make up the results bundle.
+func isResult(name string) bool {
+	if strings.HasPrefix(name, "junit") && strings.HasSuffix(name, ".xml") {
+		return true
+	}
+	return strings.HasSuffix(name, "metadata.json") || name == manifest.ManifestFile || name == artifacts.EncryptedBundleFile
+}
+
+func sha256File(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// loadSigner reads a PEM encoded PKCS8 private key. The key ID is the SHA256 of the public key.
+func loadSigner(keyFile string) (crypto.Signer, string, error) {
+	data, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading attestation key: %v", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, "", fmt.Errorf("attestation key %s is not PEM encoded", keyFile)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing attestation key: %v", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, "", fmt.Errorf("attestation key type %T can't be used for signing", key)
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, "", err
+	}
+	return signer, fmt.Sprintf("sha256:%x", sha256.Sum256(pub)), nil
+}
+
+// pae is the DSSE pre-authentication encoding of a payload.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// digestFor returns what should be signed for a key type. Ed25519 signs the message directly.
+func digestFor(pub crypto.PublicKey, message []byte) ([]byte, crypto.SignerOpts) {
+	if _, ok := pub.(ed25519.PublicKey); ok {
+		return message, crypto.Hash(0)
+	}
+	digest := sha256.Sum256(message)
+	return digest[:], crypto.SHA256
+}
+
+func verifySignature(pub crypto.PublicKey, digest, sig []byte) bool {
+	switch key := pub.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, digest, sig)
+	case *ecdsa.PublicKey:
+		var esig struct {
+			R, S *big.Int
+		}
+		if _, err := asn1.Unmarshal(sig, &esig); err != nil {
+			return false
+		}
+		return ecdsa.Verify(key, digest, esig.R, esig.S)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig) == nil
+	}
+	return false
+}
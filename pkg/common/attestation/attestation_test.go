@@ -0,0 +1,145 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeKey writes a PEM encoded PKCS8 private key into dir and returns its path.
+func writeKey(t *testing.T, dir string, key crypto.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	keyFile := filepath.Join(dir, "key.pem")
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), os.FileMode(0600)); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return keyFile
+}
+
+func TestWriteAndVerify(t *testing.T) {
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ed25519 key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ecdsa key: %v", err)
+	}
+
+	keys := map[string]struct {
+		private crypto.PrivateKey
+		public  crypto.PublicKey
+	}{
+		"ed25519": {edKey, edPub},
+		"ecdsa":   {ecKey, &ecKey.PublicKey},
+	}
+
+	for name, key := range keys {
+		reportDir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatalf("failed to create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(reportDir)
+
+		keyDir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatalf("failed to create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(keyDir)
+
+		if err = os.Mkdir(filepath.Join(reportDir, "install"), os.FileMode(0755)); err != nil {
+			t.Fatalf("failed to create phase directory: %v", err)
+		}
+
+		results := map[string]string{
+			"install/junit_abc.xml": "<testsuite></testsuite>",
+			"metadata.json":         "{}",
+			"cluster-log.txt":       "not part of the results",
+		}
+		for file, contents := range results {
+			if err = ioutil.WriteFile(filepath.Join(reportDir, file), []byte(contents), os.FileMode(0644)); err != nil {
+				t.Fatalf("failed to write %s: %v", file, err)
+			}
+		}
+
+		if err = Write(reportDir, writeKey(t, keyDir, key.private), true); err != nil {
+			t.Fatalf("%s: failed to write attestation: %v", name, err)
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(reportDir, AttestationFile))
+		if err != nil {
+			t.Fatalf("%s: failed to read attestation: %v", name, err)
+		}
+
+		envelope := &Envelope{}
+		if err = json.Unmarshal(data, envelope); err != nil {
+			t.Fatalf("%s: failed to parse attestation: %v", name, err)
+		}
+
+		statement, err := Verify(envelope, key.public)
+		if err != nil {
+			t.Fatalf("%s: failed to verify attestation: %v", name, err)
+		}
+
+		if !statement.Predicate.Passed {
+			t.Errorf("%s: expected the attestation to record a passing run", name)
+		}
+
+		if len(statement.Subject) != 2 || statement.Subject[0].Name != "install/junit_abc.xml" || statement.Subject[1].Name != "metadata.json" {
+			t.Errorf("%s: unexpected subjects %v", name, statement.Subject)
+		}
+
+		if err = VerifySubjects(statement, reportDir); err != nil {
+			t.Errorf("%s: subjects should match the report dir: %v", name, err)
+		}
+
+		// tampered results should be detected
+		if err = ioutil.WriteFile(filepath.Join(reportDir, "install/junit_abc.xml"), []byte("<testsuite failures=\"0\"></testsuite>"), os.FileMode(0644)); err != nil {
+			t.Fatalf("failed to modify results: %v", err)
+		}
+		if err = VerifySubjects(statement, reportDir); err == nil {
+			t.Errorf("%s: expected modified results to fail verification", name)
+		}
+
+		// a tampered statement should be detected
+		envelope.Payload = envelope.Payload[1:]
+		if _, err = Verify(envelope, key.public); err == nil {
+			t.Errorf("%s: expected a modified payload to fail verification", name)
+		}
+	}
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	envelope, err := Sign(&Statement{Type: StatementType}, key, "")
+	if err != nil {
+		t.Fatalf("failed to sign statement: %v", err)
+	}
+
+	if _, err = Verify(envelope, otherPub); err == nil {
+		t.Errorf("expected verification with a different key to fail")
+	}
+}
@@ -0,0 +1,528 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Kinds of resources that can be found by their tags.
+const (
+	ResourceLoadBalancer        = "elb"
+	ResourceNetworkLoadBalancer = "elbv2"
+	ResourceVolume              = "ebs-volume"
+	ResourceBucket              = "s3-bucket"
+	ResourceRole                = "iam-role"
+	ResourceVPC                 = "vpc"
+	ResourceInstance            = "ec2-instance"
+	ResourceNATGateway          = "nat-gateway"
+	ResourceElasticIP           = "elastic-ip"
+)
+
+// ResourceKinds are the kinds of resources found by TaggedResources, in the order they must be deleted. Instances are
+// terminated before their volumes are deleted, NAT gateways are deleted before their elastic IPs are released, and
+// everything is deleted before the VPC it's in.
+var ResourceKinds = []string{
+	ResourceInstance,
+	ResourceLoadBalancer,
+	ResourceNetworkLoadBalancer,
+	ResourceNATGateway,
+	ResourceElasticIP,
+	ResourceVolume,
+	ResourceBucket,
+	ResourceRole,
+	ResourceVPC,
+}
+
+const (
+	elbAPIVersion   = "2012-06-01"
+	elbv2APIVersion = "2015-12-01"
+	iamAPIVersion   = "2010-05-08"
+
+	// iamRegion is the region IAM requests are signed for, as IAM is global.
+	iamRegion = "us-east-1"
+
+	// describeTagsBatch is how many load balancers the ELB APIs describe the tags of at once.
+	describeTagsBatch = 20
+)
+
+// elbEndpoint returns the Elastic Load Balancing endpoint for a region, which serves both ELB API versions.
+var elbEndpoint = func(region string) string {
+	return fmt.Sprintf("https://elasticloadbalancing.%s.amazonaws.com/", region)
+}
+
+// iamEndpoint is the IAM endpoint.
+var iamEndpoint = "https://iam.amazonaws.com/"
+
+// TaggedResource is a resource found by its tags.
+type TaggedResource struct {
+	Kind string
+
+	// ID is the ID of an EC2 resource, the allocation ID of an elastic IP, the ARN of an ELBv2 load balancer, or the name
+	// of other resources.
+	ID string
+
+	Tags map[string]string
+
+	// Created is when the resource was created. VPCs and elastic IPs don't record it, so it's zero for them.
+	Created time.Time
+}
+
+// resourceTags are the tags of a resource as the Query APIs return them.
+type resourceTags []struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+func (t resourceTags) toMap() map[string]string {
+	tags := make(map[string]string, len(t))
+	for _, tag := range t {
+		tags[tag.Key] = tag.Value
+	}
+	return tags
+}
+
+// TaggedResources returns the VPCs, instances, NAT gateways, elastic IPs, load balancers, and EBS volumes in a region
+// that have the tag key, along with the IAM roles and S3 buckets in the region that have it. IAM roles and S3 buckets
+// can't be filtered by tag, so only those whose name starts with namePrefix are checked.
+func TaggedResources(region, tagKey, namePrefix string) ([]TaggedResource, error) {
+	sess, err := AWSSession.getSession()
+	if err != nil {
+		return nil, err
+	}
+	creds := sess.Config.Credentials
+
+	var resources []TaggedResource
+	for _, find := range []struct {
+		kind string
+		fn   func() ([]TaggedResource, error)
+	}{
+		{ResourceVPC, func() ([]TaggedResource, error) { return taggedVPCs(creds, region, tagKey) }},
+		{ResourceInstance, func() ([]TaggedResource, error) { return instances(creds, region, tagged(tagKey)) }},
+		{ResourceNATGateway, func() ([]TaggedResource, error) { return natGateways(creds, region, tagged(tagKey)) }},
+		{ResourceElasticIP, func() ([]TaggedResource, error) { return taggedAddresses(creds, region, tagKey) }},
+		{ResourceVolume, func() ([]TaggedResource, error) { return taggedVolumes(creds, region, tagKey) }},
+		{ResourceLoadBalancer, func() ([]TaggedResource, error) { return taggedLoadBalancers(creds, region, tagKey) }},
+		{ResourceNetworkLoadBalancer, func() ([]TaggedResource, error) { return taggedNetworkLoadBalancers(creds, region, tagKey) }},
+		{ResourceRole, func() ([]TaggedResource, error) { return taggedRoles(creds, tagKey, namePrefix) }},
+		{ResourceBucket, func() ([]TaggedResource, error) { return taggedBuckets(sess, region, tagKey, namePrefix) }},
+	} {
+		found, err := find.fn()
+		if err != nil {
+			return nil, fmt.Errorf("error finding tagged %s resources in %s: %v", find.kind, region, err)
+		}
+		resources = append(resources, found...)
+	}
+	return resources, nil
+}
+
+// DeleteResource deletes a resource found by TaggedResources. Instances and NAT gateways are waited on until they're
+// gone, VPCs are emptied of the resources that depend on them first, IAM roles are removed from their instance profiles
+// and their policies are detached first, and S3 buckets are emptied first.
+func DeleteResource(region string, r TaggedResource) error {
+	sess, err := AWSSession.getSession()
+	if err != nil {
+		return err
+	}
+	creds := sess.Config.Credentials
+
+	switch r.Kind {
+	case ResourceVPC:
+		err = deleteVPC(creds, region, r.ID)
+	case ResourceInstance:
+		err = terminateInstances(creds, region, []string{r.ID})
+	case ResourceNATGateway:
+		err = deleteNATGateway(creds, region, r.ID)
+	case ResourceElasticIP:
+		err = sendEC2(creds, region, url.Values{"Action": {"ReleaseAddress"}, "AllocationId": {r.ID}}, nil)
+	case ResourceVolume:
+		err = sendEC2(creds, region, url.Values{"Action": {"DeleteVolume"}, "VolumeId": {r.ID}}, nil)
+	case ResourceLoadBalancer:
+		err = sendELB(creds, region, elbAPIVersion, url.Values{"Action": {"DeleteLoadBalancer"}, "LoadBalancerName": {r.ID}}, nil)
+	case ResourceNetworkLoadBalancer:
+		err = sendELB(creds, region, elbv2APIVersion, url.Values{"Action": {"DeleteLoadBalancer"}, "LoadBalancerArn": {r.ID}}, nil)
+	case ResourceRole:
+		err = deleteRole(creds, r.ID)
+	case ResourceBucket:
+		err = deleteBucket(sess, region, r.ID)
+	default:
+		err = fmt.Errorf("unknown kind of resource")
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting %s %s: %v", r.Kind, r.ID, err)
+	}
+	return nil
+}
+
+func taggedVPCs(creds *credentials.Credentials, region, tagKey string) ([]TaggedResource, error) {
+	var resources []TaggedResource
+	nextToken := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", "DescribeVpcs")
+		params.Set("Filter.1.Name", "tag-key")
+		params.Set("Filter.1.Value.1", tagKey)
+		if nextToken != "" {
+			params.Set("NextToken", nextToken)
+		}
+
+		resp := struct {
+			VPCs []struct {
+				ID   string       `xml:"vpcId"`
+				Tags resourceTags `xml:"tagSet>item"`
+			} `xml:"vpcSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, vpc := range resp.VPCs {
+			resources = append(resources, TaggedResource{Kind: ResourceVPC, ID: vpc.ID, Tags: vpc.Tags.toMap()})
+		}
+
+		if resp.NextToken == "" {
+			return resources, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+func taggedVolumes(creds *credentials.Credentials, region, tagKey string) ([]TaggedResource, error) {
+	var resources []TaggedResource
+	nextToken := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", "DescribeVolumes")
+		params.Set("Filter.1.Name", "tag-key")
+		params.Set("Filter.1.Value.1", tagKey)
+		if nextToken != "" {
+			params.Set("NextToken", nextToken)
+		}
+
+		resp := struct {
+			Volumes []struct {
+				ID      string       `xml:"volumeId"`
+				Created time.Time    `xml:"createTime"`
+				Tags    resourceTags `xml:"tagSet>item"`
+			} `xml:"volumeSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, volume := range resp.Volumes {
+			resources = append(resources, TaggedResource{Kind: ResourceVolume, ID: volume.ID, Tags: volume.Tags.toMap(), Created: volume.Created})
+		}
+
+		if resp.NextToken == "" {
+			return resources, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// sendELB sends a request to one of the Elastic Load Balancing APIs.
+func sendELB(creds *credentials.Credentials, region, version string, params url.Values, out interface{}) error {
+	params.Set("Version", version)
+	return sendQuery(creds, "elasticloadbalancing", region, elbEndpoint(region), params, out)
+}
+
+// elbTagDescriptions are the tags of load balancers as both ELB APIs describe them.
+type elbTagDescriptions struct {
+	Descriptions []struct {
+		Name string `xml:"LoadBalancerName"`
+		ARN  string `xml:"ResourceArn"`
+		Tags []struct {
+			Key   string `xml:"Key"`
+			Value string `xml:"Value"`
+		} `xml:"Tags>member"`
+	} `xml:"DescribeTagsResult>TagDescriptions>member"`
+}
+
+// taggedLoadBalancers returns the classic load balancers with the tag key. Classic load balancers can't be filtered by
+// tag, so the tags of every load balancer are described.
+func taggedLoadBalancers(creds *credentials.Credentials, region, tagKey string) ([]TaggedResource, error) {
+	created := map[string]time.Time{}
+	var names []string
+	marker := ""
+	for {
+		params := url.Values{"Action": {"DescribeLoadBalancers"}}
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		resp := struct {
+			LoadBalancers []struct {
+				Name    string    `xml:"LoadBalancerName"`
+				Created time.Time `xml:"CreatedTime"`
+			} `xml:"DescribeLoadBalancersResult>LoadBalancerDescriptions>member"`
+			NextMarker string `xml:"DescribeLoadBalancersResult>NextMarker"`
+		}{}
+		if err := sendELB(creds, region, elbAPIVersion, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, lb := range resp.LoadBalancers {
+			names = append(names, lb.Name)
+			created[lb.Name] = lb.Created
+		}
+
+		if resp.NextMarker == "" {
+			break
+		}
+		marker = resp.NextMarker
+	}
+
+	var resources []TaggedResource
+	for start := 0; start < len(names); start += describeTagsBatch {
+		params := url.Values{"Action": {"DescribeTags"}}
+		for i, name := range names[start:min(start+describeTagsBatch, len(names))] {
+			params.Set(fmt.Sprintf("LoadBalancerNames.member.%d", i+1), name)
+		}
+
+		var resp elbTagDescriptions
+		if err := sendELB(creds, region, elbAPIVersion, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, description := range resp.Descriptions {
+			tags := map[string]string{}
+			for _, tag := range description.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			if _, ok := tags[tagKey]; ok {
+				resources = append(resources, TaggedResource{Kind: ResourceLoadBalancer, ID: description.Name, Tags: tags, Created: created[description.Name]})
+			}
+		}
+	}
+	return resources, nil
+}
+
+// taggedNetworkLoadBalancers returns the application and network load balancers with the tag key.
+func taggedNetworkLoadBalancers(creds *credentials.Credentials, region, tagKey string) ([]TaggedResource, error) {
+	created := map[string]time.Time{}
+	var arns []string
+	marker := ""
+	for {
+		params := url.Values{"Action": {"DescribeLoadBalancers"}}
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		resp := struct {
+			LoadBalancers []struct {
+				ARN     string    `xml:"LoadBalancerArn"`
+				Created time.Time `xml:"CreatedTime"`
+			} `xml:"DescribeLoadBalancersResult>LoadBalancers>member"`
+			NextMarker string `xml:"DescribeLoadBalancersResult>NextMarker"`
+		}{}
+		if err := sendELB(creds, region, elbv2APIVersion, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, lb := range resp.LoadBalancers {
+			arns = append(arns, lb.ARN)
+			created[lb.ARN] = lb.Created
+		}
+
+		if resp.NextMarker == "" {
+			break
+		}
+		marker = resp.NextMarker
+	}
+
+	var resources []TaggedResource
+	for start := 0; start < len(arns); start += describeTagsBatch {
+		params := url.Values{"Action": {"DescribeTags"}}
+		for i, arn := range arns[start:min(start+describeTagsBatch, len(arns))] {
+			params.Set(fmt.Sprintf("ResourceArns.member.%d", i+1), arn)
+		}
+
+		var resp elbTagDescriptions
+		if err := sendELB(creds, region, elbv2APIVersion, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, description := range resp.Descriptions {
+			tags := map[string]string{}
+			for _, tag := range description.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			if _, ok := tags[tagKey]; ok {
+				resources = append(resources, TaggedResource{Kind: ResourceNetworkLoadBalancer, ID: description.ARN, Tags: tags, Created: created[description.ARN]})
+			}
+		}
+	}
+	return resources, nil
+}
+
+// sendIAM sends a request to the IAM API.
+func sendIAM(creds *credentials.Credentials, params url.Values, out interface{}) error {
+	params.Set("Version", iamAPIVersion)
+	return sendQuery(creds, "iam", iamRegion, iamEndpoint, params, out)
+}
+
+// taggedRoles returns the IAM roles whose name starts with namePrefix that have the tag key.
+func taggedRoles(creds *credentials.Credentials, tagKey, namePrefix string) ([]TaggedResource, error) {
+	var resources []TaggedResource
+	marker := ""
+	for {
+		params := url.Values{"Action": {"ListRoles"}}
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+
+		resp := struct {
+			Roles []struct {
+				Name    string    `xml:"RoleName"`
+				Created time.Time `xml:"CreateDate"`
+			} `xml:"ListRolesResult>Roles>member"`
+			IsTruncated bool   `xml:"ListRolesResult>IsTruncated"`
+			Marker      string `xml:"ListRolesResult>Marker"`
+		}{}
+		if err := sendIAM(creds, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, role := range resp.Roles {
+			if !strings.HasPrefix(role.Name, namePrefix) {
+				continue
+			}
+
+			tagsResp := struct {
+				Tags []struct {
+					Key   string `xml:"Key"`
+					Value string `xml:"Value"`
+				} `xml:"ListRoleTagsResult>Tags>member"`
+			}{}
+			if err := sendIAM(creds, url.Values{"Action": {"ListRoleTags"}, "RoleName": {role.Name}}, &tagsResp); err != nil {
+				return nil, err
+			}
+
+			tags := map[string]string{}
+			for _, tag := range tagsResp.Tags {
+				tags[tag.Key] = tag.Value
+			}
+			if _, ok := tags[tagKey]; ok {
+				resources = append(resources, TaggedResource{Kind: ResourceRole, ID: role.Name, Tags: tags, Created: role.Created})
+			}
+		}
+
+		if !resp.IsTruncated {
+			return resources, nil
+		}
+		marker = resp.Marker
+	}
+}
+
+// deleteRole removes a role from its instance profiles, removes its policies, and deletes it.
+func deleteRole(creds *credentials.Credentials, name string) error {
+	profiles := struct {
+		Names []string `xml:"ListInstanceProfilesForRoleResult>InstanceProfiles>member>InstanceProfileName"`
+	}{}
+	if err := sendIAM(creds, url.Values{"Action": {"ListInstanceProfilesForRole"}, "RoleName": {name}}, &profiles); err != nil {
+		return err
+	}
+	for _, profile := range profiles.Names {
+		params := url.Values{"Action": {"RemoveRoleFromInstanceProfile"}, "RoleName": {name}, "InstanceProfileName": {profile}}
+		if err := sendIAM(creds, params, nil); err != nil {
+			return err
+		}
+	}
+
+	attached := struct {
+		ARNs []string `xml:"ListAttachedRolePoliciesResult>AttachedPolicies>member>PolicyArn"`
+	}{}
+	if err := sendIAM(creds, url.Values{"Action": {"ListAttachedRolePolicies"}, "RoleName": {name}}, &attached); err != nil {
+		return err
+	}
+	for _, arn := range attached.ARNs {
+		if err := sendIAM(creds, url.Values{"Action": {"DetachRolePolicy"}, "RoleName": {name}, "PolicyArn": {arn}}, nil); err != nil {
+			return err
+		}
+	}
+
+	inline := struct {
+		Names []string `xml:"ListRolePoliciesResult>PolicyNames>member"`
+	}{}
+	if err := sendIAM(creds, url.Values{"Action": {"ListRolePolicies"}, "RoleName": {name}}, &inline); err != nil {
+		return err
+	}
+	for _, policy := range inline.Names {
+		if err := sendIAM(creds, url.Values{"Action": {"DeleteRolePolicy"}, "RoleName": {name}, "PolicyName": {policy}}, nil); err != nil {
+			return err
+		}
+	}
+
+	return sendIAM(creds, url.Values{"Action": {"DeleteRole"}, "RoleName": {name}}, nil)
+}
+
+// taggedBuckets returns the S3 buckets in a region whose name starts with namePrefix that have the tag key.
+func taggedBuckets(sess *session.Session, region, tagKey, namePrefix string) ([]TaggedResource, error) {
+	client := s3.New(sess, aws.NewConfig().WithRegion(region))
+	list, err := client.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []TaggedResource
+	for _, bucket := range list.Buckets {
+		name := aws.StringValue(bucket.Name)
+		if !strings.HasPrefix(name, namePrefix) {
+			continue
+		}
+
+		bucketRegion, err := s3manager.GetBucketRegionWithClient(context.Background(), client, name)
+		if err != nil {
+			return nil, err
+		}
+		if bucketRegion != region {
+			continue
+		}
+
+		tagging, err := client.GetBucketTagging(&s3.GetBucketTaggingInput{Bucket: aws.String(name)})
+		if err != nil {
+			// buckets without tags return NoSuchTagSet
+			continue
+		}
+
+		tags := map[string]string{}
+		for _, tag := range tagging.TagSet {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		if _, ok := tags[tagKey]; ok {
+			resources = append(resources, TaggedResource{Kind: ResourceBucket, ID: name, Tags: tags, Created: aws.TimeValue(bucket.CreationDate)})
+		}
+	}
+	return resources, nil
+}
+
+// deleteBucket deletes the objects in a bucket and then the bucket.
+func deleteBucket(sess *session.Session, region, name string) error {
+	client := s3.New(sess, aws.NewConfig().WithRegion(region))
+	objects := s3manager.NewDeleteListIterator(client, &s3.ListObjectsInput{Bucket: aws.String(name)})
+	if err := s3manager.NewBatchDeleteWithClient(client).Delete(context.Background(), objects); err != nil {
+		return err
+	}
+
+	_, err := client.DeleteBucket(&s3.DeleteBucketInput{Bucket: aws.String(name)})
+	return err
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,176 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+// fakeELB points ELB requests at handler until the returned function is called.
+func fakeELB(handler http.HandlerFunc) func() {
+	server := httptest.NewServer(handler)
+	endpoint := elbEndpoint
+	elbEndpoint = func(string) string { return server.URL }
+	return func() {
+		elbEndpoint = endpoint
+		server.Close()
+	}
+}
+
+// fakeIAM points IAM requests at handler until the returned function is called.
+func fakeIAM(handler http.HandlerFunc) func() {
+	server := httptest.NewServer(handler)
+	endpoint := iamEndpoint
+	iamEndpoint = server.URL
+	return func() {
+		iamEndpoint = endpoint
+		server.Close()
+	}
+}
+
+func TestTaggedVolumes(t *testing.T) {
+	defer fakeEC2(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		if r.Form.Get("Action") != "DescribeVolumes" || r.Form.Get("Filter.1.Name") != "tag-key" || r.Form.Get("Filter.1.Value.1") != "api.openshift.com/id" {
+			t.Errorf("unexpected request: %v", r.Form)
+		}
+		fmt.Fprint(w, `<DescribeVolumesResponse><volumeSet>
+			<item><volumeId>vol-a</volumeId><createTime>2020-06-01T12:00:00.000Z</createTime><tagSet>
+				<item><key>api.openshift.com/id</key><value>abc</value></item>
+			</tagSet></item>
+		</volumeSet></DescribeVolumesResponse>`)
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	resources, err := taggedVolumes(creds, "us-east-1", "api.openshift.com/id")
+	if err != nil {
+		t.Fatalf("failed to describe volumes: %v", err)
+	}
+
+	expected := []TaggedResource{{
+		Kind:    ResourceVolume,
+		ID:      "vol-a",
+		Tags:    map[string]string{"api.openshift.com/id": "abc"},
+		Created: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected %+v, got %+v", expected, resources)
+	}
+}
+
+func TestTaggedLoadBalancers(t *testing.T) {
+	defer fakeELB(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		if r.Form.Get("Version") != elbAPIVersion {
+			t.Errorf("expected the classic ELB API, got %s", r.Form.Get("Version"))
+		}
+
+		switch r.Form.Get("Action") {
+		case "DescribeLoadBalancers":
+			fmt.Fprint(w, `<DescribeLoadBalancersResponse><DescribeLoadBalancersResult><LoadBalancerDescriptions>
+				<member><LoadBalancerName>osd-router</LoadBalancerName><CreatedTime>2020-06-01T12:00:00Z</CreatedTime></member>
+				<member><LoadBalancerName>other</LoadBalancerName><CreatedTime>2020-06-01T12:00:00Z</CreatedTime></member>
+			</LoadBalancerDescriptions></DescribeLoadBalancersResult></DescribeLoadBalancersResponse>`)
+		case "DescribeTags":
+			if r.Form.Get("LoadBalancerNames.member.1") != "osd-router" || r.Form.Get("LoadBalancerNames.member.2") != "other" {
+				t.Errorf("unexpected load balancers: %v", r.Form)
+			}
+			fmt.Fprint(w, `<DescribeTagsResponse><DescribeTagsResult><TagDescriptions>
+				<member><LoadBalancerName>osd-router</LoadBalancerName><Tags><member><Key>api.openshift.com/id</Key><Value>abc</Value></member></Tags></member>
+				<member><LoadBalancerName>other</LoadBalancerName><Tags><member><Key>team</Key><Value>sre</Value></member></Tags></member>
+			</TagDescriptions></DescribeTagsResult></DescribeTagsResponse>`)
+		default:
+			t.Errorf("unexpected request: %v", r.Form)
+		}
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	resources, err := taggedLoadBalancers(creds, "us-east-1", "api.openshift.com/id")
+	if err != nil {
+		t.Fatalf("failed to describe load balancers: %v", err)
+	}
+
+	if len(resources) != 1 || resources[0].ID != "osd-router" || resources[0].Created.IsZero() {
+		t.Errorf("expected only the tagged load balancer, got %+v", resources)
+	}
+}
+
+func TestTaggedRoles(t *testing.T) {
+	var actions []string
+	defer fakeIAM(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/us-east-1/iam/aws4_request") {
+			t.Errorf("request wasn't signed for IAM: %s", r.Header.Get("Authorization"))
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		actions = append(actions, r.Form.Get("Action"))
+
+		switch r.Form.Get("Action") {
+		case "ListRoles":
+			fmt.Fprint(w, `<ListRolesResponse><ListRolesResult><IsTruncated>false</IsTruncated><Roles>
+				<member><RoleName>ci-cluster-abc-master-role</RoleName><CreateDate>2020-06-01T12:00:00Z</CreateDate></member>
+				<member><RoleName>OrganizationAccountAccessRole</RoleName><CreateDate>2019-01-01T00:00:00Z</CreateDate></member>
+			</Roles></ListRolesResult></ListRolesResponse>`)
+		case "ListRoleTags":
+			if r.Form.Get("RoleName") != "ci-cluster-abc-master-role" {
+				t.Errorf("expected only roles with the prefix to be checked, got %s", r.Form.Get("RoleName"))
+			}
+			fmt.Fprint(w, `<ListRoleTagsResponse><ListRoleTagsResult><Tags>
+				<member><Key>api.openshift.com/id</Key><Value>abc</Value></member>
+			</Tags></ListRoleTagsResult></ListRoleTagsResponse>`)
+		case "ListInstanceProfilesForRole":
+			fmt.Fprint(w, `<ListInstanceProfilesForRoleResponse><ListInstanceProfilesForRoleResult><InstanceProfiles>
+				<member><InstanceProfileName>ci-cluster-abc-master-profile</InstanceProfileName></member>
+			</InstanceProfiles></ListInstanceProfilesForRoleResult></ListInstanceProfilesForRoleResponse>`)
+		case "ListAttachedRolePolicies":
+			fmt.Fprint(w, `<ListAttachedRolePoliciesResponse><ListAttachedRolePoliciesResult><AttachedPolicies>
+				<member><PolicyArn>arn:aws:iam::aws:policy/ReadOnlyAccess</PolicyArn></member>
+			</AttachedPolicies></ListAttachedRolePoliciesResult></ListAttachedRolePoliciesResponse>`)
+		case "ListRolePolicies":
+			fmt.Fprint(w, `<ListRolePoliciesResponse><ListRolePoliciesResult><PolicyNames>
+				<member>master-policy</member>
+			</PolicyNames></ListRolePoliciesResult></ListRolePoliciesResponse>`)
+		case "DeleteRole":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `<ErrorResponse><Error><Code>DeleteConflict</Code><Message>still in use</Message></Error></ErrorResponse>`)
+		default:
+			fmt.Fprint(w, `<Response/>`)
+		}
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	roles, err := taggedRoles(creds, "api.openshift.com/id", "ci-cluster-")
+	if err != nil {
+		t.Fatalf("failed to list roles: %v", err)
+	}
+	if len(roles) != 1 || roles[0].ID != "ci-cluster-abc-master-role" {
+		t.Errorf("expected only the tagged role, got %+v", roles)
+	}
+
+	actions = nil
+	err = deleteRole(creds, "ci-cluster-abc-master-role")
+	if err == nil || err.Error() != "DeleteConflict: still in use" {
+		t.Errorf("expected the IAM error to be returned, got %v", err)
+	}
+
+	expected := []string{
+		"ListInstanceProfilesForRole", "RemoveRoleFromInstanceProfile",
+		"ListAttachedRolePolicies", "DetachRolePolicy",
+		"ListRolePolicies", "DeleteRolePolicy",
+		"DeleteRole",
+	}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("expected the role to be detached from everything before it's deleted, got %v", actions)
+	}
+}
@@ -0,0 +1,352 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// ec2APIVersion is the version of the EC2 Query API that is called. The EC2 SDK client isn't vendored, so the
+// few EC2 calls osde2e makes are signed and sent directly.
+const ec2APIVersion = "2016-11-15"
+
+// ec2Endpoint returns the EC2 endpoint for a region.
+var ec2Endpoint = func(region string) string {
+	return fmt.Sprintf("https://ec2.%s.amazonaws.com/", region)
+}
+
+// ClusterInstancesInZone returns the IDs of the running instances in a zone tagged as belonging to the cluster
+// with the given infrastructure name.
+func ClusterInstancesInZone(region, infraName, zone string) ([]string, error) {
+	session, err := AWSSession.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := clusterInstancesInZone(session.Config.Credentials, region, infraName, zone)
+	if err != nil {
+		return nil, fmt.Errorf("error describing instances in %s: %v", zone, err)
+	}
+	return ids, nil
+}
+
+func clusterInstancesInZone(creds *credentials.Credentials, region, infraName, zone string) ([]string, error) {
+	var ids []string
+	nextToken := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", "DescribeInstances")
+		params.Set("Filter.1.Name", "tag-key")
+		params.Set("Filter.1.Value.1", "kubernetes.io/cluster/"+infraName)
+		params.Set("Filter.2.Name", "availability-zone")
+		params.Set("Filter.2.Value.1", zone)
+		params.Set("Filter.3.Name", "instance-state-name")
+		params.Set("Filter.3.Value.1", "running")
+		if nextToken != "" {
+			params.Set("NextToken", nextToken)
+		}
+
+		resp := struct {
+			Reservations []struct {
+				Instances []struct {
+					ID string `xml:"instanceId"`
+				} `xml:"instancesSet>item"`
+			} `xml:"reservationSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, reservation := range resp.Reservations {
+			for _, instance := range reservation.Instances {
+				ids = append(ids, instance.ID)
+			}
+		}
+
+		if resp.NextToken == "" {
+			return ids, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// Instance is a running EC2 instance and the security groups attached to it.
+type Instance struct {
+	ID             string
+	Name           string
+	SecurityGroups []string
+}
+
+// ClusterInstances returns the running instances tagged as belonging to the cluster with the given infrastructure
+// name, in the account of the access key. Clusters installed in a customer's account, such as CCS clusters, can't be
+// seen with osde2e's own credentials.
+func ClusterInstances(accessKeyID, secretAccessKey, region, infraName string) ([]Instance, error) {
+	creds := credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
+	instances, err := clusterInstances(creds, region, infraName)
+	if err != nil {
+		return nil, fmt.Errorf("error describing instances in %s: %v", region, err)
+	}
+	return instances, nil
+}
+
+func clusterInstances(creds *credentials.Credentials, region, infraName string) ([]Instance, error) {
+	var instances []Instance
+	nextToken := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", "DescribeInstances")
+		params.Set("Filter.1.Name", "tag-key")
+		params.Set("Filter.1.Value.1", "kubernetes.io/cluster/"+infraName)
+		params.Set("Filter.2.Name", "instance-state-name")
+		params.Set("Filter.2.Value.1", "running")
+		if nextToken != "" {
+			params.Set("NextToken", nextToken)
+		}
+
+		resp := struct {
+			Reservations []struct {
+				Instances []struct {
+					ID     string `xml:"instanceId"`
+					Groups []struct {
+						ID string `xml:"groupId"`
+					} `xml:"groupSet>item"`
+					Tags []struct {
+						Key   string `xml:"key"`
+						Value string `xml:"value"`
+					} `xml:"tagSet>item"`
+				} `xml:"instancesSet>item"`
+			} `xml:"reservationSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, reservation := range resp.Reservations {
+			for _, item := range reservation.Instances {
+				instance := Instance{ID: item.ID}
+				for _, group := range item.Groups {
+					instance.SecurityGroups = append(instance.SecurityGroups, group.ID)
+				}
+				for _, tag := range item.Tags {
+					if tag.Key == "Name" {
+						instance.Name = tag.Value
+					}
+				}
+				instances = append(instances, instance)
+			}
+		}
+
+		if resp.NextToken == "" {
+			return instances, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
+
+// StopInstances stops EC2 instances without terminating them.
+func StopInstances(region string, ids []string) error {
+	if err := AWSSession.callEC2(region, instanceParams("StopInstances", ids), nil); err != nil {
+		return fmt.Errorf("error stopping instances %v: %v", ids, err)
+	}
+	return nil
+}
+
+// StartInstances starts stopped EC2 instances.
+func StartInstances(region string, ids []string) error {
+	if err := AWSSession.callEC2(region, instanceParams("StartInstances", ids), nil); err != nil {
+		return fmt.Errorf("error starting instances %v: %v", ids, err)
+	}
+	return nil
+}
+
+func instanceParams(action string, ids []string) url.Values {
+	params := url.Values{}
+	params.Set("Action", action)
+	for i, id := range ids {
+		params.Set(fmt.Sprintf("InstanceId.%d", i+1), id)
+	}
+	return params
+}
+
+// callEC2 sends an EC2 Query API request using the global AWS session.
+func (a *awsSession) callEC2(region string, params url.Values, out interface{}) error {
+	session, err := a.getSession()
+	if err != nil {
+		return err
+	}
+	return sendEC2(session.Config.Credentials, region, params, out)
+}
+
+// sendEC2 sends a signed EC2 Query API request and decodes the XML response into out, if it isn't nil.
+func sendEC2(creds *credentials.Credentials, region string, params url.Values, out interface{}) error {
+	params.Set("Version", ec2APIVersion)
+	return sendQuery(creds, "ec2", region, ec2Endpoint(region), params, out)
+}
+
+// sendQuery sends a signed request to an AWS Query API, such as EC2, ELB, or IAM, and decodes the XML response into
+// out, if it isn't nil. params must include the API version.
+func sendQuery(creds *credentials.Credentials, service, region, endpoint string, params url.Values, out interface{}) error {
+	body := []byte(params.Encode())
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	if _, err = v4.NewSigner(creds).Sign(req, bytes.NewReader(body), service, region, time.Now()); err != nil {
+		return fmt.Errorf("error signing request: %v", err)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// EC2 nests errors in an Errors element, the other Query APIs don't
+		apiErr := struct {
+			Errors []queryError `xml:"Errors>Error"`
+			Error  *queryError  `xml:"Error"`
+		}{}
+		if xml.Unmarshal(data, &apiErr) == nil {
+			if len(apiErr.Errors) > 0 {
+				return apiErr.Errors[0]
+			}
+			if apiErr.Error != nil {
+				return *apiErr.Error
+			}
+		}
+		return fmt.Errorf("%s returned %s: %s", strings.ToUpper(service), resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	return xml.Unmarshal(data, out)
+}
+
+// queryError is an error returned by an AWS Query API.
+type queryError struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e queryError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// pvNameTag is the tag the Kubernetes volume provisioners put the name of the persistent volume in.
+const pvNameTag = "kubernetes.io/created-for/pv/name"
+
+// Volume is an EBS volume.
+type Volume struct {
+	ID     string
+	State  string
+	PVName string
+}
+
+// ClusterVolumes returns the EBS volumes that are tagged as belonging to the cluster with the given
+// infrastructure name or that have one of the given IDs.
+func ClusterVolumes(region, infraName string, ids []string) ([]Volume, error) {
+	session, err := AWSSession.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := clusterVolumes(session.Config.Credentials, region, infraName, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error describing volumes in %s: %v", region, err)
+	}
+	return volumes, nil
+}
+
+func clusterVolumes(creds *credentials.Credentials, region, infraName string, ids []string) ([]Volume, error) {
+	volumes, err := describeVolumes(creds, region, "tag-key", []string{"kubernetes.io/cluster/" + infraName})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return volumes, nil
+	}
+
+	// volumes provisioned outside of the cluster's tags are looked up by ID
+	byID, err := describeVolumes(creds, region, "volume-id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[string]bool{}
+	for _, volume := range volumes {
+		found[volume.ID] = true
+	}
+	for _, volume := range byID {
+		if !found[volume.ID] {
+			volumes = append(volumes, volume)
+		}
+	}
+	return volumes, nil
+}
+
+func describeVolumes(creds *credentials.Credentials, region, filter string, values []string) ([]Volume, error) {
+	var volumes []Volume
+	nextToken := ""
+	for {
+		params := url.Values{}
+		params.Set("Action", "DescribeVolumes")
+		params.Set("Filter.1.Name", filter)
+		for i, value := range values {
+			params.Set(fmt.Sprintf("Filter.1.Value.%d", i+1), value)
+		}
+		if nextToken != "" {
+			params.Set("NextToken", nextToken)
+		}
+
+		resp := struct {
+			Volumes []struct {
+				ID    string `xml:"volumeId"`
+				State string `xml:"status"`
+				Tags  []struct {
+					Key   string `xml:"key"`
+					Value string `xml:"value"`
+				} `xml:"tagSet>item"`
+			} `xml:"volumeSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, v := range resp.Volumes {
+			volume := Volume{ID: v.ID, State: v.State}
+			for _, tag := range v.Tags {
+				if tag.Key == pvNameTag {
+					volume.PVName = tag.Value
+				}
+			}
+			volumes = append(volumes, volume)
+		}
+
+		if resp.NextToken == "" {
+			return volumes, nil
+		}
+		nextToken = resp.NextToken
+	}
+}
@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+const describeInstancesPage = `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item><instanceId>i-%[1]s1</instanceId></item>
+        <item><instanceId>i-%[1]s2</instanceId></item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+  %[2]s
+</DescribeInstancesResponse>`
+
+// fakeEC2 points EC2 requests at handler until the returned function is called.
+func fakeEC2(handler http.HandlerFunc) func() {
+	server := httptest.NewServer(handler)
+	endpoint := ec2Endpoint
+	ec2Endpoint = func(string) string { return server.URL }
+	return func() {
+		ec2Endpoint = endpoint
+		server.Close()
+	}
+}
+
+func TestClusterInstancesInZone(t *testing.T) {
+	defer fakeEC2(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/us-east-1/ec2/aws4_request") {
+			t.Errorf("request wasn't signed for EC2: %s", r.Header.Get("Authorization"))
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		if r.Form.Get("Action") != "DescribeInstances" || r.Form.Get("Filter.1.Value.1") != "kubernetes.io/cluster/infra-abc" || r.Form.Get("Filter.2.Value.1") != "us-east-1c" {
+			t.Errorf("unexpected request: %v", r.Form)
+		}
+
+		if r.Form.Get("NextToken") == "" {
+			fmt.Fprintf(w, describeInstancesPage, "a", "<nextToken>page2</nextToken>")
+			return
+		}
+		fmt.Fprintf(w, describeInstancesPage, "b", "")
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	ids, err := clusterInstancesInZone(creds, "us-east-1", "infra-abc", "us-east-1c")
+	if err != nil {
+		t.Fatalf("failed to describe instances: %v", err)
+	}
+
+	if expected := []string{"i-a1", "i-a2", "i-b1", "i-b2"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected instances %v, got %v", expected, ids)
+	}
+}
+
+func TestClusterInstances(t *testing.T) {
+	defer fakeEC2(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		if r.Form.Get("Action") != "DescribeInstances" || r.Form.Get("Filter.1.Value.1") != "kubernetes.io/cluster/infra-abc" || r.Form.Get("Filter.2.Value.1") != "running" {
+			t.Errorf("unexpected request: %v", r.Form)
+		}
+
+		fmt.Fprint(w, `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-a1</instanceId>
+          <groupSet>
+            <item><groupId>sg-worker</groupId><groupName>infra-abc-worker-sg</groupName></item>
+            <item><groupId>sg-1</groupId><groupName>extra</groupName></item>
+          </groupSet>
+          <tagSet>
+            <item><key>kubernetes.io/cluster/infra-abc</key><value>owned</value></item>
+            <item><key>Name</key><value>infra-abc-worker-us-east-1a-x7k2p</value></item>
+          </tagSet>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`)
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	instances, err := clusterInstances(creds, "us-east-1", "infra-abc")
+	if err != nil {
+		t.Fatalf("failed to describe instances: %v", err)
+	}
+
+	expected := []Instance{{ID: "i-a1", Name: "infra-abc-worker-us-east-1a-x7k2p", SecurityGroups: []string{"sg-worker", "sg-1"}}}
+	if !reflect.DeepEqual(instances, expected) {
+		t.Errorf("expected instances %v, got %v", expected, instances)
+	}
+}
+
+func TestEC2Error(t *testing.T) {
+	defer fakeEC2(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<Response><Errors><Error><Code>IncorrectInstanceState</Code><Message>not running</Message></Error></Errors></Response>`))
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	err := sendEC2(creds, "us-east-1", instanceParams("StopInstances", []string{"i-a1"}), nil)
+	if err == nil || !strings.Contains(err.Error(), "IncorrectInstanceState: not running") {
+		t.Errorf("expected the EC2 error to be returned, got %v", err)
+	}
+}
+
+func TestClusterVolumes(t *testing.T) {
+	defer fakeEC2(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		if r.Form.Get("Action") != "DescribeVolumes" {
+			t.Errorf("unexpected request: %v", r.Form)
+		}
+
+		switch r.Form.Get("Filter.1.Name") {
+		case "tag-key":
+			fmt.Fprint(w, `<DescribeVolumesResponse><volumeSet>
+				<item><volumeId>vol-a</volumeId><status>available</status><tagSet>
+					<item><key>kubernetes.io/cluster/infra-abc</key><value>owned</value></item>
+					<item><key>kubernetes.io/created-for/pv/name</key><value>pvc-a</value></item>
+				</tagSet></item>
+			</volumeSet></DescribeVolumesResponse>`)
+		case "volume-id":
+			if r.Form.Get("Filter.1.Value.1") != "vol-a" || r.Form.Get("Filter.1.Value.2") != "vol-b" {
+				t.Errorf("unexpected volume IDs: %v", r.Form)
+			}
+			fmt.Fprint(w, `<DescribeVolumesResponse><volumeSet>
+				<item><volumeId>vol-a</volumeId><status>available</status></item>
+				<item><volumeId>vol-b</volumeId><status>in-use</status></item>
+			</volumeSet></DescribeVolumesResponse>`)
+		default:
+			t.Errorf("unexpected filter: %v", r.Form)
+		}
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	volumes, err := clusterVolumes(creds, "us-east-1", "infra-abc", []string{"vol-a", "vol-b"})
+	if err != nil {
+		t.Fatalf("failed to describe volumes: %v", err)
+	}
+
+	expected := []Volume{{ID: "vol-a", State: "available", PVName: "pvc-a"}, {ID: "vol-b", State: "in-use"}}
+	if !reflect.DeepEqual(volumes, expected) {
+		t.Errorf("expected volumes %+v, got %+v", expected, volumes)
+	}
+}
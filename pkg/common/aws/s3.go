@@ -3,10 +3,12 @@ package aws
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
@@ -67,6 +69,107 @@ func WriteToS3(outputKey string, data []byte) error {
 	return err
 }
 
+// ReadFromS3WithETag reads a key from S3 along with its ETag, so it can be overwritten with WriteToS3IfMatch.
+func ReadFromS3WithETag(inputKey string) ([]byte, string, error) {
+	bucket, key, err := ParseS3URL(inputKey)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("error trying to parse S3 URL: %v", err)
+	}
+
+	session, err := AWSSession.getSession()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	out, err := s3.New(session).GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return nil, "", err
+	}
+	defer out.Body.Close()
+
+	data, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, aws.StringValue(out.ETag), nil
+}
+
+// WriteToS3IfMatch writes the given byte array to S3 only if the key still has the ETag, or only if the key doesn't
+// exist when the ETag is empty. IsS3PreconditionFailed is true for the error returned if it was written in between.
+func WriteToS3IfMatch(outputKey string, data []byte, etag string) error {
+	bucket, key, err := ParseS3URL(outputKey)
+
+	if err != nil {
+		return fmt.Errorf("error trying to parse S3 URL: %v", err)
+	}
+
+	session, err := AWSSession.getSession()
+
+	if err != nil {
+		return err
+	}
+
+	// the vendored SDK predates conditional writes, so the headers are set on the request directly
+	req, _ := s3.New(session).PutObjectRequest(&s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+
+	if etag == "" {
+		req.HTTPRequest.Header.Set("If-None-Match", "*")
+	} else {
+		req.HTTPRequest.Header.Set("If-Match", etag)
+	}
+
+	return req.Send()
+}
+
+// DeleteFromS3 deletes a key from S3.
+func DeleteFromS3(inputKey string) error {
+	bucket, key, err := ParseS3URL(inputKey)
+
+	if err != nil {
+		return fmt.Errorf("error trying to parse S3 URL: %v", err)
+	}
+
+	session, err := AWSSession.getSession()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s3.New(session).DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+// IsS3NotFound returns true if an S3 error is because the key doesn't exist.
+func IsS3NotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound"
+	}
+	return false
+}
+
+// IsS3PreconditionFailed returns true if a conditional S3 write failed because the key was written in between.
+func IsS3PreconditionFailed(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == "PreconditionFailed" || aerr.Code() == "ConditionalRequestConflict"
+	}
+	return false
+}
+
 // CreateS3URL creates an S3 URL from a bucket and a key string.
 func CreateS3URL(bucket string, keys ...string) string {
 	strippedBucket := strings.Trim(bucket, "/")
@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// secretsManagerEndpoint returns the Secrets Manager endpoint for a region. The Secrets Manager SDK client isn't
+// vendored, so secrets are retrieved with signed requests like EC2 calls.
+var secretsManagerEndpoint = func(region string) string {
+	return fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", region)
+}
+
+// SecretValue returns the current value of a Secrets Manager secret. The secret may be named by its ARN, in which
+// case it is retrieved from the ARN's region, or by its name, in which case the session's region is used.
+func SecretValue(secretID string) (string, error) {
+	session, err := AWSSession.getSession()
+	if err != nil {
+		return "", err
+	}
+
+	region := ""
+	if session.Config.Region != nil {
+		region = *session.Config.Region
+	}
+	if parsed, err := arn.Parse(secretID); err == nil {
+		region = parsed.Region
+	}
+	if region == "" {
+		return "", fmt.Errorf("no region for secret %s, set AWS_REGION or use the secret's ARN", secretID)
+	}
+
+	value, err := secretValue(session.Config.Credentials, region, secretID)
+	if err != nil {
+		return "", fmt.Errorf("error getting secret %s: %v", secretID, err)
+	}
+	return value, nil
+}
+
+func secretValue(creds *credentials.Credentials, region, secretID string) (string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, secretsManagerEndpoint(region), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	if _, err = v4.NewSigner(creds).Sign(req, bytes.NewReader(body), "secretsmanager", region, time.Now()); err != nil {
+		return "", fmt.Errorf("error signing request: %v", err)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Type != "" {
+			return "", fmt.Errorf("%s: %s", apiErr.Type, apiErr.Message)
+		}
+		return "", fmt.Errorf("Secrets Manager returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	secret := struct {
+		SecretString *string `json:"SecretString"`
+	}{}
+	if err = json.Unmarshal(data, &secret); err != nil {
+		return "", err
+	}
+	if secret.SecretString == nil {
+		return "", fmt.Errorf("binary secrets aren't supported")
+	}
+	return *secret.SecretString, nil
+}
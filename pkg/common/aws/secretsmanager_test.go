@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+func TestSecretValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "/us-east-1/secretsmanager/aws4_request") {
+			t.Errorf("request wasn't signed for Secrets Manager: %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			t.Errorf("unexpected target %s", r.Header.Get("X-Amz-Target"))
+		}
+
+		req := struct{ SecretId string }{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		switch req.SecretId {
+		case "osde2e/ocm":
+			fmt.Fprint(w, `{"Name":"osde2e/ocm","SecretString":"{\"token\":\"abc\"}"}`)
+		case "osde2e/binary":
+			fmt.Fprint(w, `{"Name":"osde2e/binary","SecretBinary":"YWJj"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`)
+		}
+	}))
+	defer server.Close()
+
+	endpoint := secretsManagerEndpoint
+	secretsManagerEndpoint = func(string) string { return server.URL }
+	defer func() { secretsManagerEndpoint = endpoint }()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	if value, err := secretValue(creds, "us-east-1", "osde2e/ocm"); err != nil || value != `{"token":"abc"}` {
+		t.Errorf("unexpected secret %q: %v", value, err)
+	}
+
+	if _, err := secretValue(creds, "us-east-1", "osde2e/binary"); err == nil {
+		t.Errorf("expected binary secrets to be rejected")
+	}
+
+	if _, err := secretValue(creds, "us-east-1", "osde2e/missing"); err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Errorf("expected a missing secret to fail, got %v", err)
+	}
+}
@@ -0,0 +1,423 @@
+package aws
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+var (
+	// dependencyPoll is how often deletions that finish in the background are checked on.
+	dependencyPoll = 10 * time.Second
+
+	// dependencyTimeout is how long deletions that finish in the background are waited on.
+	dependencyTimeout = 10 * time.Minute
+)
+
+// ec2Filter is a filter of an EC2 Describe request.
+type ec2Filter struct {
+	name   string
+	values []string
+}
+
+// tagged filters EC2 resources by a tag key.
+func tagged(tagKey string) ec2Filter {
+	return ec2Filter{"tag-key", []string{tagKey}}
+}
+
+// describeEC2 sends an EC2 Describe request with the filters for every page of results. page decodes a page and
+// returns the token of the next one, or an empty string if it was the last.
+func describeEC2(creds *credentials.Credentials, region, action string, filters []ec2Filter, page func(params url.Values) (string, error)) error {
+	nextToken := ""
+	for {
+		params := url.Values{"Action": {action}}
+		for i, filter := range filters {
+			params.Set(fmt.Sprintf("Filter.%d.Name", i+1), filter.name)
+			for j, value := range filter.values {
+				params.Set(fmt.Sprintf("Filter.%d.Value.%d", i+1, j+1), value)
+			}
+		}
+		if nextToken != "" {
+			params.Set("NextToken", nextToken)
+		}
+
+		var err error
+		if nextToken, err = page(params); err != nil || nextToken == "" {
+			return err
+		}
+	}
+}
+
+// instances returns the instances matching the filters that aren't terminated.
+func instances(creds *credentials.Credentials, region string, filters ...ec2Filter) ([]TaggedResource, error) {
+	filters = append(filters, ec2Filter{"instance-state-name", []string{"pending", "running", "stopping", "stopped"}})
+
+	var resources []TaggedResource
+	err := describeEC2(creds, region, "DescribeInstances", filters, func(params url.Values) (string, error) {
+		resp := struct {
+			Reservations []struct {
+				Instances []struct {
+					ID       string       `xml:"instanceId"`
+					Launched time.Time    `xml:"launchTime"`
+					Tags     resourceTags `xml:"tagSet>item"`
+				} `xml:"instancesSet>item"`
+			} `xml:"reservationSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return "", err
+		}
+
+		for _, reservation := range resp.Reservations {
+			for _, instance := range reservation.Instances {
+				resources = append(resources, TaggedResource{Kind: ResourceInstance, ID: instance.ID, Tags: instance.Tags.toMap(), Created: instance.Launched})
+			}
+		}
+		return resp.NextToken, nil
+	})
+	return resources, err
+}
+
+// natGateways returns the NAT gateways matching the filters that aren't deleted.
+func natGateways(creds *credentials.Credentials, region string, filters ...ec2Filter) ([]TaggedResource, error) {
+	filters = append(filters, ec2Filter{"state", []string{"pending", "available", "deleting", "failed"}})
+
+	var resources []TaggedResource
+	err := describeEC2(creds, region, "DescribeNatGateways", filters, func(params url.Values) (string, error) {
+		resp := struct {
+			NATGateways []struct {
+				ID      string       `xml:"natGatewayId"`
+				Created time.Time    `xml:"createTime"`
+				Tags    resourceTags `xml:"tagSet>item"`
+			} `xml:"natGatewaySet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return "", err
+		}
+
+		for _, gateway := range resp.NATGateways {
+			resources = append(resources, TaggedResource{Kind: ResourceNATGateway, ID: gateway.ID, Tags: gateway.Tags.toMap(), Created: gateway.Created})
+		}
+		return resp.NextToken, nil
+	})
+	return resources, err
+}
+
+// taggedAddresses returns the elastic IPs with the tag key. Addresses don't record when they were allocated.
+func taggedAddresses(creds *credentials.Credentials, region, tagKey string) ([]TaggedResource, error) {
+	params := url.Values{"Action": {"DescribeAddresses"}, "Filter.1.Name": {"tag-key"}, "Filter.1.Value.1": {tagKey}}
+	resp := struct {
+		Addresses []struct {
+			AllocationID string       `xml:"allocationId"`
+			Tags         resourceTags `xml:"tagSet>item"`
+		} `xml:"addressesSet>item"`
+	}{}
+	if err := sendEC2(creds, region, params, &resp); err != nil {
+		return nil, err
+	}
+
+	var resources []TaggedResource
+	for _, address := range resp.Addresses {
+		resources = append(resources, TaggedResource{Kind: ResourceElasticIP, ID: address.AllocationID, Tags: address.Tags.toMap()})
+	}
+	return resources, nil
+}
+
+// terminateInstances terminates instances and waits until they are terminated, so their volumes and network
+// interfaces are released.
+func terminateInstances(creds *credentials.Credentials, region string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	params := url.Values{"Action": {"TerminateInstances"}}
+	for i, id := range ids {
+		params.Set(fmt.Sprintf("InstanceId.%d", i+1), id)
+	}
+	if err := sendEC2(creds, region, params, nil); err != nil {
+		return err
+	}
+
+	return waitForDependencies(fmt.Sprintf("instances %v to terminate", ids), func() (bool, error) {
+		remaining, err := instances(creds, region, ec2Filter{"instance-id", ids})
+		return len(remaining) == 0, err
+	})
+}
+
+// deleteNATGateway deletes a NAT gateway and waits until it's deleted, so its elastic IP and network interface are
+// released.
+func deleteNATGateway(creds *credentials.Credentials, region, id string) error {
+	if err := sendEC2(creds, region, url.Values{"Action": {"DeleteNatGateway"}, "NatGatewayId": {id}}, nil); err != nil {
+		return err
+	}
+
+	return waitForDependencies(fmt.Sprintf("NAT gateway %s to be deleted", id), func() (bool, error) {
+		remaining, err := natGateways(creds, region, ec2Filter{"nat-gateway-id", []string{id}})
+		return len(remaining) == 0, err
+	})
+}
+
+// waitForDependencies polls until done returns true or dependencyTimeout passes.
+func waitForDependencies(what string, done func() (bool, error)) error {
+	deadline := time.Now().Add(dependencyTimeout)
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s", what)
+		}
+		time.Sleep(dependencyPoll)
+	}
+}
+
+// deleteVPC deletes what keeps a VPC from being deleted and then the VPC: its instances, NAT gateways, endpoints,
+// unattached network interfaces, internet gateways, subnets, route tables, and security groups. Elastic IPs aren't
+// part of a VPC, so tagged ones are deleted as resources of their own.
+func deleteVPC(creds *credentials.Credentials, region, id string) error {
+	inVPC := ec2Filter{"vpc-id", []string{id}}
+
+	running, err := instances(creds, region, inVPC)
+	if err != nil {
+		return fmt.Errorf("error finding instances: %v", err)
+	}
+	ids := make([]string, 0, len(running))
+	for _, instance := range running {
+		ids = append(ids, instance.ID)
+	}
+	if err = terminateInstances(creds, region, ids); err != nil {
+		return fmt.Errorf("error terminating instances: %v", err)
+	}
+
+	gateways, err := natGateways(creds, region, inVPC)
+	if err != nil {
+		return fmt.Errorf("error finding NAT gateways: %v", err)
+	}
+	for _, gateway := range gateways {
+		if err = deleteNATGateway(creds, region, gateway.ID); err != nil {
+			return fmt.Errorf("error deleting NAT gateway %s: %v", gateway.ID, err)
+		}
+	}
+
+	for _, dependent := range []struct {
+		kind, describe, list, delete, idParam string
+		filters                               []ec2Filter
+	}{
+		{"endpoint", "DescribeVpcEndpoints", "vpcEndpointSet>item>vpcEndpointId", "DeleteVpcEndpoints", "VpcEndpointId.1", []ec2Filter{inVPC}},
+		{"network interface", "DescribeNetworkInterfaces", "networkInterfaceSet>item>networkInterfaceId", "DeleteNetworkInterface", "NetworkInterfaceId", []ec2Filter{inVPC, {"status", []string{"available"}}}},
+		{"internet gateway", "DescribeInternetGateways", "internetGatewaySet>item>internetGatewayId", "DeleteInternetGateway", "InternetGatewayId", []ec2Filter{{"attachment.vpc-id", []string{id}}}},
+		{"subnet", "DescribeSubnets", "subnetSet>item>subnetId", "DeleteSubnet", "SubnetId", []ec2Filter{inVPC}},
+	} {
+		ids, err := describeIDs(creds, region, dependent.describe, dependent.list, dependent.filters)
+		if err != nil {
+			return fmt.Errorf("error finding %ss: %v", dependent.kind, err)
+		}
+		for _, dependentID := range ids {
+			if dependent.kind == "internet gateway" {
+				params := url.Values{"Action": {"DetachInternetGateway"}, "InternetGatewayId": {dependentID}, "VpcId": {id}}
+				if err = sendEC2(creds, region, params, nil); err != nil {
+					return fmt.Errorf("error detaching internet gateway %s: %v", dependentID, err)
+				}
+			}
+			if err = sendEC2(creds, region, url.Values{"Action": {dependent.delete}, dependent.idParam: {dependentID}}, nil); err != nil {
+				return fmt.Errorf("error deleting %s %s: %v", dependent.kind, dependentID, err)
+			}
+		}
+	}
+
+	if err = deleteRouteTables(creds, region, inVPC); err != nil {
+		return err
+	}
+
+	if err = deleteSecurityGroups(creds, region, inVPC); err != nil {
+		return err
+	}
+
+	return sendEC2(creds, region, url.Values{"Action": {"DeleteVpc"}, "VpcId": {id}}, nil)
+}
+
+// describeIDs returns the IDs at the path, such as subnetSet>item>subnetId, of every page of an EC2 Describe response.
+func describeIDs(creds *credentials.Credentials, region, action, path string, filters []ec2Filter) ([]string, error) {
+	var ids []string
+	err := describeEC2(creds, region, action, filters, func(params url.Values) (string, error) {
+		page := &idList{path: strings.Split(path, ">")}
+		if err := sendEC2(creds, region, params, page); err != nil {
+			return "", err
+		}
+		ids = append(ids, page.ids...)
+		return page.nextToken, nil
+	})
+	return ids, err
+}
+
+// idList decodes the values at a path of an EC2 response, and its next token.
+type idList struct {
+	path      []string
+	ids       []string
+	nextToken string
+}
+
+// UnmarshalXML collects the text of the elements at the path below the response element.
+func (l *idList) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	var stack []string
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			switch {
+			case text == "":
+			case strings.Join(stack, ">") == strings.Join(l.path, ">"):
+				l.ids = append(l.ids, text)
+			case len(stack) == 1 && stack[0] == "nextToken":
+				l.nextToken = text
+			}
+		}
+	}
+}
+
+// deleteRouteTables deletes the route tables matching the filter, except the main route table, which is deleted with
+// its VPC.
+func deleteRouteTables(creds *credentials.Credentials, region string, filter ec2Filter) error {
+	var ids []string
+	err := describeEC2(creds, region, "DescribeRouteTables", []ec2Filter{filter}, func(params url.Values) (string, error) {
+		resp := struct {
+			RouteTables []struct {
+				ID   string `xml:"routeTableId"`
+				Main []bool `xml:"associationSet>item>main"`
+			} `xml:"routeTableSet>item"`
+			NextToken string `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return "", err
+		}
+
+		for _, table := range resp.RouteTables {
+			main := false
+			for _, association := range table.Main {
+				main = main || association
+			}
+			if !main {
+				ids = append(ids, table.ID)
+			}
+		}
+		return resp.NextToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error finding route tables: %v", err)
+	}
+
+	for _, id := range ids {
+		if err = sendEC2(creds, region, url.Values{"Action": {"DeleteRouteTable"}, "RouteTableId": {id}}, nil); err != nil {
+			return fmt.Errorf("error deleting route table %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// securityGroupPermissions are the rules of a security group as EC2 describes them.
+type securityGroupPermissions []struct {
+	Protocol string   `xml:"ipProtocol"`
+	FromPort string   `xml:"fromPort"`
+	ToPort   string   `xml:"toPort"`
+	Groups   []string `xml:"groups>item>groupId"`
+}
+
+// deleteSecurityGroups deletes the security groups matching the filter, except the default security group, which is
+// deleted with its VPC. Cluster security groups refer to each other, so rules referring to other groups are revoked
+// before any group is deleted.
+func deleteSecurityGroups(creds *credentials.Credentials, region string, filter ec2Filter) error {
+	type group struct {
+		ID      string                   `xml:"groupId"`
+		Name    string                   `xml:"groupName"`
+		Ingress securityGroupPermissions `xml:"ipPermissions>item"`
+		Egress  securityGroupPermissions `xml:"ipPermissionsEgress>item"`
+	}
+
+	var groups []group
+	err := describeEC2(creds, region, "DescribeSecurityGroups", []ec2Filter{filter}, func(params url.Values) (string, error) {
+		resp := struct {
+			Groups    []group `xml:"securityGroupInfo>item"`
+			NextToken string  `xml:"nextToken"`
+		}{}
+		if err := sendEC2(creds, region, params, &resp); err != nil {
+			return "", err
+		}
+		groups = append(groups, resp.Groups...)
+		return resp.NextToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error finding security groups: %v", err)
+	}
+
+	for _, g := range groups {
+		if err = revokeGroupRules(creds, region, "RevokeSecurityGroupIngress", g.ID, g.Ingress); err != nil {
+			return err
+		}
+		if err = revokeGroupRules(creds, region, "RevokeSecurityGroupEgress", g.ID, g.Egress); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range groups {
+		if g.Name == "default" {
+			continue
+		}
+		if err = sendEC2(creds, region, url.Values{"Action": {"DeleteSecurityGroup"}, "GroupId": {g.ID}}, nil); err != nil {
+			return fmt.Errorf("error deleting security group %s: %v", g.ID, err)
+		}
+	}
+	return nil
+}
+
+// revokeGroupRules revokes the rules of a security group that refer to other security groups.
+func revokeGroupRules(creds *credentials.Credentials, region, action, id string, permissions securityGroupPermissions) error {
+	params := url.Values{"Action": {action}, "GroupId": {id}}
+	n := 0
+	for _, permission := range permissions {
+		if len(permission.Groups) == 0 {
+			continue
+		}
+		n++
+		prefix := "IpPermissions." + strconv.Itoa(n) + "."
+		params.Set(prefix+"IpProtocol", permission.Protocol)
+		if permission.FromPort != "" {
+			params.Set(prefix+"FromPort", permission.FromPort)
+		}
+		if permission.ToPort != "" {
+			params.Set(prefix+"ToPort", permission.ToPort)
+		}
+		for i, groupID := range permission.Groups {
+			params.Set(fmt.Sprintf("%sGroups.%d.GroupId", prefix, i+1), groupID)
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	if err := sendEC2(creds, region, params, nil); err != nil {
+		return fmt.Errorf("error revoking rules of security group %s: %v", id, err)
+	}
+	return nil
+}
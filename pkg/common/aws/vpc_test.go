@@ -0,0 +1,116 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+func TestDeleteVPC(t *testing.T) {
+	defer func(poll, timeout time.Duration) {
+		dependencyPoll, dependencyTimeout = poll, timeout
+	}(dependencyPoll, dependencyTimeout)
+	dependencyPoll, dependencyTimeout = time.Millisecond, time.Second
+
+	var actions []string
+	terminated, natDeleted := false, false
+	defer fakeEC2(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse request: %v", err)
+		}
+		action := r.Form.Get("Action")
+		actions = append(actions, action)
+
+		switch action {
+		case "DescribeInstances":
+			if terminated {
+				fmt.Fprint(w, `<DescribeInstancesResponse><reservationSet/></DescribeInstancesResponse>`)
+			} else {
+				fmt.Fprint(w, `<DescribeInstancesResponse><reservationSet><item><instancesSet>
+					<item><instanceId>i-a</instanceId></item>
+				</instancesSet></item></reservationSet></DescribeInstancesResponse>`)
+			}
+		case "TerminateInstances":
+			if r.Form.Get("InstanceId.1") != "i-a" {
+				t.Errorf("unexpected instances terminated: %v", r.Form)
+			}
+			terminated = true
+		case "DescribeNatGateways":
+			if natDeleted {
+				fmt.Fprint(w, `<DescribeNatGatewaysResponse><natGatewaySet/></DescribeNatGatewaysResponse>`)
+			} else {
+				fmt.Fprint(w, `<DescribeNatGatewaysResponse><natGatewaySet>
+					<item><natGatewayId>nat-a</natGatewayId></item>
+				</natGatewaySet></DescribeNatGatewaysResponse>`)
+			}
+		case "DeleteNatGateway":
+			natDeleted = true
+		case "DescribeVpcEndpoints":
+			fmt.Fprint(w, `<DescribeVpcEndpointsResponse><vpcEndpointSet><item><vpcEndpointId>vpce-a</vpcEndpointId></item></vpcEndpointSet></DescribeVpcEndpointsResponse>`)
+		case "DescribeNetworkInterfaces":
+			fmt.Fprint(w, `<DescribeNetworkInterfacesResponse><networkInterfaceSet/></DescribeNetworkInterfacesResponse>`)
+		case "DescribeInternetGateways":
+			fmt.Fprint(w, `<DescribeInternetGatewaysResponse><internetGatewaySet><item><internetGatewayId>igw-a</internetGatewayId></item></internetGatewaySet></DescribeInternetGatewaysResponse>`)
+		case "DescribeSubnets":
+			fmt.Fprint(w, `<DescribeSubnetsResponse><subnetSet>
+				<item><subnetId>subnet-a</subnetId></item><item><subnetId>subnet-b</subnetId></item>
+			</subnetSet><nextToken></nextToken></DescribeSubnetsResponse>`)
+		case "DescribeRouteTables":
+			fmt.Fprint(w, `<DescribeRouteTablesResponse><routeTableSet>
+				<item><routeTableId>rtb-main</routeTableId><associationSet><item><main>true</main></item></associationSet></item>
+				<item><routeTableId>rtb-a</routeTableId><associationSet/></item>
+			</routeTableSet></DescribeRouteTablesResponse>`)
+		case "DescribeSecurityGroups":
+			fmt.Fprint(w, `<DescribeSecurityGroupsResponse><securityGroupInfo>
+				<item><groupId>sg-default</groupId><groupName>default</groupName></item>
+				<item><groupId>sg-a</groupId><groupName>worker</groupName><ipPermissions>
+					<item><ipProtocol>tcp</ipProtocol><fromPort>10250</fromPort><toPort>10250</toPort><groups><item><groupId>sg-b</groupId></item></groups></item>
+					<item><ipProtocol>tcp</ipProtocol><fromPort>22</fromPort><toPort>22</toPort><ipRanges><item><cidrIp>10.0.0.0/16</cidrIp></item></ipRanges></item>
+				</ipPermissions></item>
+				<item><groupId>sg-b</groupId><groupName>master</groupName></item>
+			</securityGroupInfo></DescribeSecurityGroupsResponse>`)
+		case "RevokeSecurityGroupIngress":
+			expected := map[string]string{"GroupId": "sg-a", "IpPermissions.1.IpProtocol": "tcp", "IpPermissions.1.FromPort": "10250", "IpPermissions.1.Groups.1.GroupId": "sg-b"}
+			for key, value := range expected {
+				if r.Form.Get(key) != value {
+					t.Errorf("expected %s to be %s, got %v", key, value, r.Form)
+				}
+			}
+			if r.Form.Get("IpPermissions.2.IpProtocol") != "" {
+				t.Errorf("expected only rules referring to groups to be revoked, got %v", r.Form)
+			}
+		case "DeleteSecurityGroup":
+			if r.Form.Get("GroupId") == "sg-default" {
+				t.Errorf("the default security group can't be deleted")
+			}
+		case "DeleteRouteTable":
+			if r.Form.Get("RouteTableId") != "rtb-a" {
+				t.Errorf("only route tables that aren't main can be deleted, got %v", r.Form)
+			}
+		}
+	})()
+
+	creds := credentials.NewStaticCredentials("id", "secret", "")
+	if err := deleteVPC(creds, "us-east-1", "vpc-a"); err != nil {
+		t.Fatalf("failed to delete VPC: %v", err)
+	}
+
+	expected := []string{
+		"DescribeInstances", "TerminateInstances", "DescribeInstances",
+		"DescribeNatGateways", "DeleteNatGateway", "DescribeNatGateways",
+		"DescribeVpcEndpoints", "DeleteVpcEndpoints",
+		"DescribeNetworkInterfaces",
+		"DescribeInternetGateways", "DetachInternetGateway", "DeleteInternetGateway",
+		"DescribeSubnets", "DeleteSubnet", "DeleteSubnet",
+		"DescribeRouteTables", "DeleteRouteTable",
+		"DescribeSecurityGroups", "RevokeSecurityGroupIngress", "DeleteSecurityGroup", "DeleteSecurityGroup",
+		"DeleteVpc",
+	}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("expected requests %v, got %v", expected, actions)
+	}
+}
@@ -0,0 +1,181 @@
+// Package backoff retries operations with exponential backoff and jitter within a budget.
+//
+// It is used for retries throughout osde2e and can be used by test harnesses as well.
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff describes how an operation is retried.
+type Backoff struct {
+	// Initial is the delay after the first failed attempt.
+	Initial time.Duration
+
+	// Max caps the delay between attempts. Zero means the delay is not capped.
+	Max time.Duration
+
+	// Multiplier scales the delay after each failed attempt. Values below 1 keep the delay constant.
+	Multiplier float64
+
+	// Jitter randomly shortens each delay by up to this fraction of it, between 0 and 1.
+	Jitter float64
+
+	// MaxAttempts is the number of attempts made before giving up. Zero means attempts are not limited.
+	MaxAttempts int
+
+	// MaxElapsed is how long to keep retrying for. Zero means time is not limited.
+	MaxElapsed time.Duration
+
+	// Retryable returns whether an error should be retried. All errors are retried when it isn't set.
+	Retryable func(err error) bool
+
+	// OnRetry is called after each failed attempt that will be retried.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// Exponential returns a Backoff that doubles the delay after each attempt, starting at initial and
+// capped at max, with 20% jitter.
+func Exponential(initial, max time.Duration) Backoff {
+	return Backoff{
+		Initial:    initial,
+		Max:        max,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+}
+
+// Constant returns a Backoff that makes up to attempts attempts with interval between them.
+func Constant(interval time.Duration, attempts int) Backoff {
+	return Backoff{
+		Initial:     interval,
+		Multiplier:  1,
+		MaxAttempts: attempts,
+	}
+}
+
+// permanentError is an error that should not be retried.
+type permanentError struct {
+	err error
+}
+
+func (p *permanentError) Error() string {
+	return p.err.Error()
+}
+
+// Permanent wraps an error so that Retry returns it without further attempts.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// delayedError is an error that should be retried after a given delay.
+type delayedError struct {
+	err   error
+	delay time.Duration
+}
+
+func (d *delayedError) Error() string {
+	return d.err.Error()
+}
+
+// RetryAfter wraps an error so that the next attempt is made after delay rather than the backoff's delay, such as
+// when a server says when to retry. The error is still subject to Retryable and MaxAttempts.
+func RetryAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &delayedError{err, delay}
+}
+
+// Delay returns the delay after the given failed attempt, starting at 1, without jitter.
+func (b Backoff) Delay(attempt int) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	return time.Duration(delay)
+}
+
+// Retry calls fn until it succeeds, returns an error that isn't retryable, or the budget is used up.
+// The error from the last attempt is returned. Retrying stops early if ctx is done.
+func (b Backoff) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	start := time.Now()
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil {
+			return cancelled(ctx, err)
+		}
+
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+
+		if permanent, ok := err.(*permanentError); ok {
+			return permanent.err
+		}
+
+		after := time.Duration(-1)
+		if delayed, ok := err.(*delayedError); ok {
+			err, after = delayed.err, delayed.delay
+		}
+
+		if b.Retryable != nil && !b.Retryable(err) {
+			return err
+		}
+
+		if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+			return err
+		}
+
+		delay := b.jitter(b.Delay(attempt))
+		if after >= 0 {
+			delay = after
+		}
+		if b.MaxElapsed > 0 && time.Since(start)+delay > b.MaxElapsed {
+			return err
+		}
+
+		if b.OnRetry != nil {
+			b.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return cancelled(ctx, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// jitter randomly shortens a delay by up to the Backoff's jitter fraction.
+func (b Backoff) jitter(delay time.Duration) time.Duration {
+	if b.Jitter <= 0 {
+		return delay
+	}
+
+	jitter := math.Min(b.Jitter, 1)
+	return time.Duration(float64(delay) * (1 - jitter*rand.Float64()))
+}
+
+// cancelled describes why retrying stopped early, including the last error if there was one.
+func cancelled(ctx context.Context, err error) error {
+	if err == nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("%v, last error: %w", ctx.Err(), err)
+}
@@ -0,0 +1,156 @@
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	b := Exponential(time.Second, 5*time.Second)
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, delay := range expected {
+		if actual := b.Delay(i + 1); actual != delay {
+			t.Errorf("attempt %d: expected delay %s, got %s", i+1, delay, actual)
+		}
+	}
+
+	if actual := Constant(time.Second, 3).Delay(10); actual != time.Second {
+		t.Errorf("constant backoff should not grow, got %s", actual)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	b := Exponential(time.Second, 0)
+	for i := 0; i < 100; i++ {
+		if delay := b.jitter(time.Second); delay > time.Second || delay < 800*time.Millisecond {
+			t.Fatalf("jittered delay %s is outside of the expected range", delay)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	failure := fmt.Errorf("failure")
+
+	tests := []struct {
+		Name     string
+		Backoff  Backoff
+		Failures int
+		Err      error
+		Attempts int
+		Success  bool
+	}{
+		{
+			Name:     "immediate success",
+			Backoff:  Constant(time.Millisecond, 3),
+			Attempts: 1,
+			Success:  true,
+		},
+		{
+			Name:     "eventual success",
+			Backoff:  Constant(time.Millisecond, 3),
+			Failures: 2,
+			Err:      failure,
+			Attempts: 3,
+			Success:  true,
+		},
+		{
+			Name:     "attempts used up",
+			Backoff:  Constant(time.Millisecond, 3),
+			Failures: 5,
+			Err:      failure,
+			Attempts: 3,
+		},
+		{
+			Name:     "permanent error",
+			Backoff:  Constant(time.Millisecond, 3),
+			Failures: 5,
+			Err:      Permanent(failure),
+			Attempts: 1,
+		},
+		{
+			Name:     "delay set by the error",
+			Backoff:  Constant(time.Hour, 3),
+			Failures: 2,
+			Err:      RetryAfter(failure, time.Millisecond),
+			Attempts: 3,
+			Success:  true,
+		},
+		{
+			Name:     "delayed attempts used up",
+			Backoff:  Constant(time.Hour, 3),
+			Failures: 5,
+			Err:      RetryAfter(failure, time.Millisecond),
+			Attempts: 3,
+		},
+		{
+			Name: "not retryable",
+			Backoff: Backoff{
+				Initial:   time.Millisecond,
+				Retryable: func(err error) bool { return err != failure },
+			},
+			Failures: 5,
+			Err:      failure,
+			Attempts: 1,
+		},
+		{
+			Name: "elapsed time used up",
+			Backoff: Backoff{
+				Initial:    100 * time.Millisecond,
+				MaxElapsed: 150 * time.Millisecond,
+			},
+			Failures: 5,
+			Err:      failure,
+			Attempts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		attempts := 0
+		err := test.Backoff.Retry(context.Background(), func(ctx context.Context) error {
+			attempts++
+			if attempts <= test.Failures {
+				return test.Err
+			}
+			return nil
+		})
+
+		if attempts != test.Attempts {
+			t.Errorf("%s: expected %d attempts, got %d", test.Name, test.Attempts, attempts)
+		}
+
+		if (err == nil) != test.Success {
+			t.Errorf("%s: unexpected result: %v", test.Name, err)
+		}
+
+		if err != nil && err != failure {
+			t.Errorf("%s: expected the last error to be returned, got %v", test.Name, err)
+		}
+	}
+}
+
+func TestRetryCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	b := Constant(time.Hour, 0)
+	b.OnRetry = func(attempt int, err error, delay time.Duration) {
+		cancel()
+	}
+
+	start := time.Now()
+	err := b.Retry(ctx, func(ctx context.Context) error {
+		attempts++
+		return fmt.Errorf("failure")
+	})
+
+	if err == nil || attempts != 1 {
+		t.Errorf("expected retrying to stop after cancellation, got %d attempts: %v", attempts, err)
+	}
+
+	if time.Since(start) > time.Minute {
+		t.Errorf("cancellation should interrupt the delay between attempts")
+	}
+}
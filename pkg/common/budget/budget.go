@@ -0,0 +1,193 @@
+// Package budget enforces limits on the resources a run may use, protecting shared accounts from runaway runs.
+package budget
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// Limits are the most a run may use. Zero values are unlimited.
+type Limits struct {
+	// MaxClusters is the number of clusters a run may create.
+	MaxClusters int
+
+	// MaxNodeHours is the total time the run's clusters' nodes may run, in hours.
+	MaxNodeHours float64
+
+	// MaxDuration is how long the run may take.
+	MaxDuration time.Duration
+}
+
+// Enabled returns true if any limit is set.
+func (l Limits) Enabled() bool {
+	return l.MaxClusters > 0 || l.MaxNodeHours > 0 || l.MaxDuration > 0
+}
+
+// ExceededError is returned when a run goes over its budget.
+type ExceededError struct {
+	// Limit is the limit that was exceeded.
+	Limit string
+
+	// Message describes how the limit was exceeded.
+	Message string
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("run budget exceeded: %s", e.Message)
+}
+
+// Usage is what a run has used so far.
+type Usage struct {
+	Clusters  int
+	NodeHours float64
+	Elapsed   time.Duration
+}
+
+// NodeCounter returns the number of nodes the run's clusters have.
+type NodeCounter func() (int, error)
+
+// Tracker accounts for what a run uses and checks it against the limits.
+type Tracker struct {
+	limits  Limits
+	started time.Time
+
+	mutex      sync.Mutex
+	clusters   int
+	launched   time.Time
+	nodeHours  float64
+	nodes      int
+	lastSample time.Time
+
+	watching bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// New creates a tracker for a run that started at the given time.
+func New(limits Limits, started time.Time) *Tracker {
+	return &Tracker{
+		limits:  limits,
+		started: started,
+	}
+}
+
+// ReserveCluster accounts for a cluster about to be created. It fails if the run may not create any more clusters.
+func (t *Tracker) ReserveCluster(now time.Time) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.limits.MaxClusters > 0 && t.clusters >= t.limits.MaxClusters {
+		return &ExceededError{"clusters", fmt.Sprintf("the run may create at most %d clusters", t.limits.MaxClusters)}
+	}
+
+	t.clusters++
+	if t.launched.IsZero() {
+		t.launched = now
+	}
+	return nil
+}
+
+// RecordNodes accounts for the nodes running since the last sample. Nodes can't be counted until a cluster is
+// reachable, so the first sample is assumed to have been running since the first cluster was launched.
+func (t *Tracker) RecordNodes(nodes int, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	since := t.lastSample
+	if since.IsZero() {
+		since = t.launched
+		if since.IsZero() {
+			since = now
+		}
+		t.nodes = nodes
+	}
+
+	// nodes are accounted at the count of the previous sample so scaling up is charged from when it's seen
+	t.nodeHours += float64(t.nodes) * now.Sub(since).Hours()
+	t.nodes = nodes
+	t.lastSample = now
+}
+
+// Usage returns what the run has used by the given time.
+func (t *Tracker) Usage(now time.Time) Usage {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return Usage{
+		Clusters:  t.clusters,
+		NodeHours: t.nodeHours,
+		Elapsed:   now.Sub(t.started),
+	}
+}
+
+// Check returns an ExceededError if the run has gone over its budget.
+func (t *Tracker) Check(now time.Time) error {
+	usage := t.Usage(now)
+
+	if t.limits.MaxDuration > 0 && usage.Elapsed > t.limits.MaxDuration {
+		return &ExceededError{"duration", fmt.Sprintf("the run has taken %s, more than the limit of %s", usage.Elapsed.Round(time.Second), t.limits.MaxDuration)}
+	}
+
+	if t.limits.MaxNodeHours > 0 && usage.NodeHours > t.limits.MaxNodeHours {
+		return &ExceededError{"node-hours", fmt.Sprintf("the run has used %.1f node-hours, more than the limit of %.1f", usage.NodeHours, t.limits.MaxNodeHours)}
+	}
+	return nil
+}
+
+// Watch counts nodes and checks the budget every interval until stopped. Exceeded is called once if the budget is exceeded.
+// A stopped tracker can be watched again, and keeps accounting for what was used before.
+func (t *Tracker) Watch(interval time.Duration, count NodeCounter, exceeded func(error)) {
+	t.mutex.Lock()
+	if t.watching {
+		t.mutex.Unlock()
+		return
+	}
+	t.watching = true
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+	stop, done := t.stop, t.done
+	t.mutex.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				if count != nil {
+					if nodes, err := count(); err == nil {
+						t.RecordNodes(nodes, now)
+					} else if t.Usage(now).Clusters > 0 {
+						logging.Warnf("Unable to count nodes for the run budget: %v", err)
+					}
+				}
+
+				if err := t.Check(now); err != nil {
+					exceeded(err)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops watching the budget.
+func (t *Tracker) Stop() {
+	t.mutex.Lock()
+	watching, stop, done := t.watching, t.stop, t.done
+	t.watching = false
+	t.mutex.Unlock()
+
+	if watching {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,111 @@
+package budget
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestReserveCluster(t *testing.T) {
+	now := time.Now()
+	tracker := New(Limits{MaxClusters: 1}, now)
+
+	if err := tracker.ReserveCluster(now); err != nil {
+		t.Fatalf("the first cluster should be within budget: %v", err)
+	}
+
+	err := tracker.ReserveCluster(now)
+	if exceeded, ok := err.(*ExceededError); !ok || exceeded.Limit != "clusters" {
+		t.Errorf("expected the second cluster to exceed the budget, got %v", err)
+	}
+}
+
+func TestNodeHours(t *testing.T) {
+	start := time.Now()
+	tracker := New(Limits{MaxNodeHours: 20}, start)
+
+	if err := tracker.ReserveCluster(start); err != nil {
+		t.Fatalf("failed to reserve cluster: %v", err)
+	}
+
+	// the first sample is charged from when the cluster was launched
+	tracker.RecordNodes(6, start.Add(time.Hour))
+	tracker.RecordNodes(12, start.Add(2*time.Hour))
+	tracker.RecordNodes(12, start.Add(3*time.Hour))
+
+	if usage := tracker.Usage(start.Add(3 * time.Hour)); math.Abs(usage.NodeHours-24) > 0.001 {
+		t.Errorf("expected 24 node-hours, got %f", usage.NodeHours)
+	}
+
+	err := tracker.Check(start.Add(3 * time.Hour))
+	if exceeded, ok := err.(*ExceededError); !ok || exceeded.Limit != "node-hours" {
+		t.Errorf("expected the node-hours limit to be exceeded, got %v", err)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	start := time.Now()
+	tracker := New(Limits{MaxDuration: time.Hour}, start)
+
+	if err := tracker.Check(start.Add(59 * time.Minute)); err != nil {
+		t.Errorf("run should be within its duration: %v", err)
+	}
+
+	err := tracker.Check(start.Add(61 * time.Minute))
+	if exceeded, ok := err.(*ExceededError); !ok || exceeded.Limit != "duration" {
+		t.Errorf("expected the duration limit to be exceeded, got %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tracker := New(Limits{MaxDuration: time.Millisecond}, time.Now())
+
+	exceeded := make(chan error, 1)
+	tracker.Watch(5*time.Millisecond, func() (int, error) { return 3, nil }, func(err error) {
+		exceeded <- err
+	})
+	defer tracker.Stop()
+
+	select {
+	case err := <-exceeded:
+		if _, ok := err.(*ExceededError); !ok {
+			t.Errorf("expected an ExceededError, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected the watcher to report the exceeded budget")
+	}
+
+	// stopping a tracker that was never watched shouldn't block
+	New(Limits{}, time.Now()).Stop()
+}
+
+func TestWatchAgain(t *testing.T) {
+	tracker := New(Limits{MaxClusters: 2}, time.Now())
+	if err := tracker.ReserveCluster(time.Now()); err != nil {
+		t.Fatalf("failed to reserve cluster: %v", err)
+	}
+
+	tracker.Watch(time.Hour, nil, func(error) {})
+	tracker.Stop()
+	tracker.Stop()
+
+	counted := make(chan struct{}, 1)
+	tracker.Watch(5*time.Millisecond, func() (int, error) {
+		select {
+		case counted <- struct{}{}:
+		default:
+		}
+		return 3, nil
+	}, func(error) {})
+	defer tracker.Stop()
+
+	select {
+	case <-counted:
+	case <-time.After(time.Second):
+		t.Errorf("expected a stopped tracker to be watched again")
+	}
+
+	if usage := tracker.Usage(time.Now()); usage.Clusters != 1 {
+		t.Errorf("expected usage to be kept across watches, got %d clusters", usage.Clusters)
+	}
+}
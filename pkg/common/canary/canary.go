@@ -0,0 +1,237 @@
+// Package canary continuously probes a cluster in the background so that outages between and during
+// test phases are recorded.
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+const (
+	// TimelineFile is the name of the availability timeline written to the report directory.
+	TimelineFile = "canary-timeline.json"
+
+	// probeTimeout bounds each probe so a hung request doesn't delay the next sample.
+	probeTimeout = 10 * time.Second
+)
+
+// Probe checks a single aspect of the cluster's availability.
+type Probe func(ctx context.Context) error
+
+// SetupFunc returns the probes to run. It is called before each sample until it succeeds, which
+// allows probing to begin before the cluster is available.
+type SetupFunc func() (map[string]Probe, error)
+
+// Sample is the result of running a probe once.
+type Sample struct {
+	Probe   string        `json:"probe"`
+	Phase   string        `json:"phase,omitempty"`
+	Time    time.Time     `json:"time"`
+	Latency time.Duration `json:"latency"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// Outage is a period in which a probe failed every time it ran.
+type Outage struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Phase string    `json:"phase,omitempty"`
+	Error string    `json:"error"`
+}
+
+// ProbeSummary describes the availability of a single probe over the run.
+type ProbeSummary struct {
+	Samples      int      `json:"samples"`
+	Failures     int      `json:"failures"`
+	Availability float64  `json:"availability"`
+	Outages      []Outage `json:"outages"`
+}
+
+// Timeline is the availability of the cluster over the run.
+type Timeline struct {
+	Interval string                  `json:"interval"`
+	Probes   map[string]ProbeSummary `json:"probes"`
+	Samples  []Sample                `json:"samples"`
+}
+
+// Prober runs probes at an interval until it is stopped.
+type Prober struct {
+	interval time.Duration
+	setup    SetupFunc
+	probes   map[string]Probe
+
+	mutex   sync.Mutex
+	phase   string
+	samples []Sample
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	done     chan struct{}
+}
+
+// New creates a Prober that samples every interval using the probes returned by setup.
+func New(interval time.Duration, setup SetupFunc) *Prober {
+	return &Prober{
+		interval: interval,
+		setup:    setup,
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start begins probing in the background.
+func (p *Prober) Start() {
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			p.sample()
+
+			select {
+			case <-p.stopCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// SetPhase labels subsequent samples with the phase of the run.
+func (p *Prober) SetPhase(phase string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.phase = phase
+}
+
+// Stop stops probing and waits for the current sample to finish. It returns false if the Prober was already stopped.
+func (p *Prober) Stop() bool {
+	stopped := false
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+		<-p.done
+		stopped = true
+	})
+	return stopped
+}
+
+// sample runs every probe once.
+func (p *Prober) sample() {
+	if p.probes == nil {
+		probes, err := p.setup()
+		if err != nil {
+			return
+		}
+		p.probes = probes
+	}
+
+	p.mutex.Lock()
+	phase := p.phase
+	p.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	results := make([]Sample, len(p.probes))
+	i := 0
+	for name, probe := range p.probes {
+		wg.Add(1)
+		go func(i int, name string, probe Probe) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+			defer cancel()
+
+			start := time.Now()
+			result := Sample{Probe: name, Phase: phase, Time: start}
+			if err := probe(ctx); err != nil {
+				result.Error = err.Error()
+			}
+			result.Latency = time.Since(start)
+			results[i] = result
+		}(i, name, probe)
+		i++
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Error != "" {
+			logging.Warnf("Canary probe %s failed: %s", result.Probe, result.Error)
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.samples = append(p.samples, results...)
+}
+
+// Timeline summarizes the samples collected so far.
+func (p *Prober) Timeline() *Timeline {
+	p.mutex.Lock()
+	samples := append([]Sample{}, p.samples...)
+	p.mutex.Unlock()
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	timeline := &Timeline{
+		Interval: p.interval.String(),
+		Probes:   map[string]ProbeSummary{},
+		Samples:  samples,
+	}
+
+	// an outage lasts from the first failed sample until the next successful one
+	open := map[string]*Outage{}
+	for _, s := range samples {
+		summary := timeline.Probes[s.Probe]
+		summary.Samples++
+
+		if s.Error != "" {
+			summary.Failures++
+			if open[s.Probe] == nil {
+				open[s.Probe] = &Outage{Start: s.Time, Phase: s.Phase, Error: s.Error}
+			}
+			open[s.Probe].End = s.Time.Add(s.Latency)
+		} else if outage := open[s.Probe]; outage != nil {
+			outage.End = s.Time
+			summary.Outages = append(summary.Outages, *outage)
+			delete(open, s.Probe)
+		}
+		timeline.Probes[s.Probe] = summary
+	}
+
+	for name, outage := range open {
+		summary := timeline.Probes[name]
+		summary.Outages = append(summary.Outages, *outage)
+		timeline.Probes[name] = summary
+	}
+
+	for name, summary := range timeline.Probes {
+		summary.Availability = float64(summary.Samples-summary.Failures) / float64(summary.Samples)
+		timeline.Probes[name] = summary
+	}
+	return timeline
+}
+
+// Write writes the availability timeline to the report directory.
+func (p *Prober) Write(reportDir string) error {
+	data, err := json.MarshalIndent(p.Timeline(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding canary timeline: %v", err)
+	}
+
+	if err = os.MkdirAll(reportDir, os.FileMode(0755)); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(reportDir, TimelineFile), data, os.FileMode(0644))
+}
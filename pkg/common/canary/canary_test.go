@@ -0,0 +1,116 @@
+package canary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProber(t *testing.T) {
+	var mutex sync.Mutex
+	failing := false
+	setupCalls := 0
+
+	prober := New(5*time.Millisecond, func() (map[string]Probe, error) {
+		setupCalls++
+		if setupCalls < 3 {
+			return nil, fmt.Errorf("cluster not available yet")
+		}
+
+		return map[string]Probe{
+			"api": func(ctx context.Context) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				if failing {
+					return fmt.Errorf("connection refused")
+				}
+				return nil
+			},
+		}, nil
+	})
+
+	prober.SetPhase("install")
+	prober.Start()
+	time.Sleep(50 * time.Millisecond)
+
+	mutex.Lock()
+	failing = true
+	mutex.Unlock()
+	prober.SetPhase("upgrading")
+	time.Sleep(50 * time.Millisecond)
+
+	mutex.Lock()
+	failing = false
+	mutex.Unlock()
+	time.Sleep(50 * time.Millisecond)
+
+	if !prober.Stop() {
+		t.Fatalf("first stop should report that probing stopped")
+	}
+	if prober.Stop() {
+		t.Errorf("stopping twice should be a no-op")
+	}
+
+	summary := prober.Timeline().Probes["api"]
+	if summary.Samples == 0 || summary.Failures == 0 || summary.Failures == summary.Samples {
+		t.Fatalf("expected both successful and failed samples, got %+v", summary)
+	}
+
+	if len(summary.Outages) != 1 {
+		t.Fatalf("expected a single outage, got %v", summary.Outages)
+	}
+
+	outage := summary.Outages[0]
+	if outage.Phase != "upgrading" || outage.Error != "connection refused" || !outage.End.After(outage.Start) {
+		t.Errorf("unexpected outage %+v", outage)
+	}
+
+	if summary.Availability <= 0 || summary.Availability >= 1 {
+		t.Errorf("unexpected availability %f", summary.Availability)
+	}
+}
+
+func TestTimelineOngoingOutage(t *testing.T) {
+	start := time.Now()
+	prober := New(time.Second, nil)
+	prober.samples = []Sample{
+		{Probe: "dns-api", Time: start},
+		{Probe: "dns-api", Time: start.Add(time.Second), Error: "no such host"},
+		{Probe: "dns-api", Time: start.Add(2 * time.Second), Error: "no such host"},
+	}
+
+	summary := prober.Timeline().Probes["dns-api"]
+	if len(summary.Outages) != 1 || !summary.Outages[0].Start.Equal(start.Add(time.Second)) {
+		t.Errorf("expected an outage that hasn't ended, got %v", summary.Outages)
+	}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = prober.Write(dir); err != nil {
+		t.Fatalf("failed to write timeline: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, TimelineFile))
+	if err != nil {
+		t.Fatalf("failed to read timeline: %v", err)
+	}
+
+	timeline := &Timeline{}
+	if err = json.Unmarshal(data, timeline); err != nil {
+		t.Fatalf("failed to parse timeline: %v", err)
+	}
+
+	if len(timeline.Samples) != 3 || timeline.Interval != "1s" {
+		t.Errorf("unexpected timeline %+v", timeline)
+	}
+}
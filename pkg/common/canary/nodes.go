@@ -0,0 +1,95 @@
+package canary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+const (
+	// desiredNodesRefresh is how often the desired number of compute nodes is retrieved from the provider.
+	desiredNodesRefresh = time.Minute
+
+	workerRoleLabel = "node-role.kubernetes.io/worker"
+	infraRoleLabel  = "node-role.kubernetes.io/infra"
+	masterRoleLabel = "node-role.kubernetes.io/master"
+)
+
+// DesiredNodesFunc returns how many compute nodes the cluster should have.
+type DesiredNodesFunc func() (int, error)
+
+// NodeDriftProbe returns a probe of the cluster in the global state that fails while the number of Ready compute
+// nodes differs from the desired number. When the cluster is autoscaling, only having fewer nodes than desired is
+// drift. It fails until a kubeconfig is available.
+func NodeDriftProbe(desired DesiredNodesFunc, autoscaling bool) (Probe, error) {
+	restConfig, err := clusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return nodeDriftProbe(kube, desired, autoscaling), nil
+}
+
+// nodeDriftProbe compares Ready compute nodes to the desired number. Probes are never run concurrently with
+// themselves, so the desired number is cached without locking.
+func nodeDriftProbe(kube kubernetes.Interface, desired DesiredNodesFunc, autoscaling bool) Probe {
+	var wanted int
+	var refreshed time.Time
+
+	return func(ctx context.Context) error {
+		if time.Since(refreshed) > desiredNodesRefresh {
+			num, err := desired()
+			if err != nil {
+				// not knowing the desired number isn't drift, the last known number is used if there is one
+				logging.Warnf("Unable to get the desired number of compute nodes: %v", err)
+			} else {
+				wanted, refreshed = num, time.Now()
+			}
+		}
+		if refreshed.IsZero() || wanted == 0 {
+			return nil
+		}
+
+		nodes, err := kube.CoreV1().Nodes().List(metav1.ListOptions{LabelSelector: workerRoleLabel})
+		if err != nil {
+			return err
+		}
+
+		ready := readyComputeNodes(nodes.Items)
+		if ready < wanted || (ready > wanted && !autoscaling) {
+			return fmt.Errorf("%d of %d desired compute nodes are ready", ready, wanted)
+		}
+		return nil
+	}
+}
+
+// readyComputeNodes counts the Ready workers that aren't also infra or master nodes.
+func readyComputeNodes(nodes []kubev1.Node) int {
+	ready := 0
+	for _, node := range nodes {
+		if _, ok := node.Labels[infraRoleLabel]; ok {
+			continue
+		}
+		if _, ok := node.Labels[masterRoleLabel]; ok {
+			continue
+		}
+
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == kubev1.NodeReady && condition.Status == kubev1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+	}
+	return ready
+}
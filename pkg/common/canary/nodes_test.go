@@ -0,0 +1,63 @@
+package canary
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func node(name string, ready bool, roles ...string) *kubev1.Node {
+	labels := map[string]string{}
+	for _, role := range roles {
+		labels["node-role.kubernetes.io/"+role] = ""
+	}
+
+	status := kubev1.ConditionFalse
+	if ready {
+		status = kubev1.ConditionTrue
+	}
+	return &kubev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: kubev1.NodeStatus{
+			Conditions: []kubev1.NodeCondition{{Type: kubev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func TestNodeDriftProbe(t *testing.T) {
+	kube := fake.NewSimpleClientset(
+		node("master-0", true, "master"),
+		node("infra-0", true, "infra", "worker"),
+		node("worker-0", true, "worker"),
+		node("worker-1", false, "worker"),
+		node("worker-2", true, "worker"),
+	)
+
+	tests := []struct {
+		description string
+		desired     int
+		err         error
+		autoscaling bool
+		drifted     bool
+	}{
+		{description: "matching", desired: 2},
+		{description: "too few nodes", desired: 3, drifted: true},
+		{description: "too many nodes", desired: 1, drifted: true},
+		{description: "too many nodes while autoscaling", desired: 1, autoscaling: true},
+		{description: "desired number unknown", err: fmt.Errorf("status 503")},
+	}
+
+	for _, test := range tests {
+		probe := nodeDriftProbe(kube, func() (int, error) {
+			return test.desired, test.err
+		}, test.autoscaling)
+
+		if err := probe(context.Background()); (err != nil) != test.drifted {
+			t.Errorf("%s: expected drift to be %t, got %v", test.description, test.drifted, err)
+		}
+	}
+}
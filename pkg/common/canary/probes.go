@@ -0,0 +1,109 @@
+package canary
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	routev1 "github.com/openshift/client-go/route/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// ClusterProbes returns probes for the cluster in the global state: an API request, DNS lookups of the
+// API and application domains, and a request to the console route. It fails until a kubeconfig is available.
+func ClusterProbes() (map[string]Probe, error) {
+	restConfig, err := clusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL, err := url.Parse(restConfig.Host)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing API server URL: %v", err)
+	}
+
+	probes := map[string]Probe{
+		"api": func(ctx context.Context) error {
+			_, err := kube.Discovery().RESTClient().Get().AbsPath("/readyz").Context(ctx).DoRaw()
+			return err
+		},
+		"dns-api": lookupProbe(apiURL.Hostname()),
+	}
+
+	// the console route is found once, if it can't be found the remaining probes are skipped
+	routes, err := routev1.NewForConfig(restConfig)
+	if err != nil {
+		return probes, nil
+	}
+
+	console, err := routes.RouteV1().Routes("openshift-console").Get("console", metav1.GetOptions{})
+	if err != nil {
+		return probes, nil
+	}
+
+	probes["dns-apps"] = lookupProbe(console.Spec.Host)
+	probes["route-console"] = routeProbe("https://" + console.Spec.Host)
+	return probes, nil
+}
+
+// clusterConfig returns the config of the cluster in the global state, with requests bounded by the probe timeout.
+func clusterConfig() (*rest.Config, error) {
+	if len(state.Instance.Kubeconfig.Contents) == 0 {
+		return nil, fmt.Errorf("no kubeconfig is available yet")
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(state.Instance.Kubeconfig.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("error generating restconfig: %v", err)
+	}
+	restConfig.Timeout = probeTimeout
+	return restConfig, nil
+}
+
+// lookupProbe resolves a hostname.
+func lookupProbe(host string) Probe {
+	return func(ctx context.Context) error {
+		_, err := net.DefaultResolver.LookupHost(ctx, host)
+		return err
+	}
+}
+
+// routeProbe requests a URL and fails on server errors. Certificates are checked elsewhere so they aren't verified.
+func routeProbe(address string) Probe {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	return func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodGet, address, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%s returned %s", address, resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// alertGateInterval is the time between checks of the alerts firing on a cluster.
+const alertGateInterval = 30 * time.Second
+
+// WaitForNoCriticalAlerts blocks until no critical alerts are firing on the cluster, other than the allowed ones, so
+// tests don't start while the cluster is still converging. It returns an error naming the alerts still firing if they
+// don't clear in time. Providers that can't report alerts aren't waited on, and neither are clusters whose alerts
+// couldn't be read at all, since that says nothing about whether they are healthy.
+func WaitForNoCriticalAlerts(provider spi.Provider, clusterID string) error {
+	cfg := config.Instance
+	if cfg.Tests.AlertGateTimeout == 0 || cfg.Tests.SkipClusterHealthChecks {
+		return nil
+	}
+	logger := logging.WithField(logging.ClusterIDField, clusterID)
+
+	alertsProvider, ok := provider.(spi.AlertsProvider)
+	if !ok {
+		logger.Infof("Not waiting for critical alerts to clear, provider %s can't report alerts.", cfg.Provider)
+		return nil
+	}
+
+	logger.Infof("Waiting %v minutes for critical alerts to clear...", cfg.Tests.AlertGateTimeout)
+	started := time.Now()
+	var (
+		firing []string
+		read   bool
+	)
+	err := phase.Poll(alertGateInterval, time.Duration(cfg.Tests.AlertGateTimeout)*time.Minute, func() (bool, error) {
+		alerts, err := alertsProvider.FiringAlerts(clusterID)
+		if err != nil {
+			// alerts may not be reported for a while after install
+			logger.Warnf("Unable to get the alerts firing on the cluster: %v", err)
+			return false, nil
+		}
+		read = true
+
+		firing = blockingAlerts(alerts, cfg.Tests.AlertGateAllow)
+		if len(firing) > 0 {
+			logger.Infof("Critical alerts are firing: %s", strings.Join(firing, ", "))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		if len(firing) > 0 {
+			return fmt.Errorf("critical alerts were still firing: %s: %v", strings.Join(firing, ", "), err)
+		}
+		if !read && err == wait.ErrWaitTimeout {
+			logger.Warnf("Not waiting for critical alerts to clear, the alerts firing on the cluster couldn't be read for %v minutes.", cfg.Tests.AlertGateTimeout)
+			return nil
+		}
+		return fmt.Errorf("couldn't check the alerts firing on the cluster: %v", err)
+	}
+
+	logger.Infof("No critical alerts are firing after %v.", time.Since(started).Round(time.Second))
+	return nil
+}
+
+// blockingAlerts returns the sorted names of the critical alerts that aren't allowed.
+func blockingAlerts(alerts []spi.Alert, allowed []string) []string {
+	allow := map[string]bool{}
+	for _, name := range allowed {
+		allow[name] = true
+	}
+
+	var blocking []string
+	for _, alert := range alerts {
+		if alert.Severity == "critical" && !allow[alert.Name] {
+			blocking = append(blocking, alert.Name)
+		}
+	}
+	sort.Strings(blocking)
+	return blocking
+}
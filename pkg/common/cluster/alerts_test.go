@@ -0,0 +1,27 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestBlockingAlerts(t *testing.T) {
+	alerts := []spi.Alert{
+		{Name: "Watchdog", Severity: "none"},
+		{Name: "KubeAPIDown", Severity: "critical"},
+		{Name: "ClusterOperatorDegraded", Severity: "critical"},
+		{Name: "KubePodNotReady", Severity: "warning"},
+		{Name: "etcdInsufficientMembers", Severity: "critical"},
+	}
+
+	blocking := blockingAlerts(alerts, []string{"ClusterOperatorDegraded"})
+	if expected := []string{"KubeAPIDown", "etcdInsufficientMembers"}; !reflect.DeepEqual(blocking, expected) {
+		t.Errorf("expected blocking alerts %v, got %v", expected, blocking)
+	}
+
+	if blocking = blockingAlerts(alerts[:1], nil); len(blocking) != 0 {
+		t.Errorf("expected no blocking alerts, got %v", blocking)
+	}
+}
@@ -1,19 +1,20 @@
 package cluster
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/Masterminds/semver"
-	"github.com/hashicorp/go-multierror"
 	osconfig "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/openshift/osde2e/pkg/common/triage"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -54,15 +55,24 @@ func WaitForClusterReady(provider spi.Provider, clusterID string) error {
 	cfg := config.Instance
 	state := state.Instance
 
-	log.Printf("Waiting %v minutes for cluster '%s' to be ready...\n", cfg.Cluster.InstallTimeout, clusterID)
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Waiting %v minutes for cluster '%s' to be ready...", cfg.Cluster.InstallTimeout, clusterID)
 	cleanRuns := 0
 	errRuns := 0
 
+	skipped := map[string]bool{}
+	if skippedChecks := healthchecks.Skipped(cfg.Tests.HealthCheckSkip); len(skippedChecks) > 0 {
+		logging.Infof("Skipping health checks: %v", skippedChecks)
+		metadata.Instance.SetSkippedHealthChecks(skippedChecks)
+		for _, name := range skippedChecks {
+			skipped[name] = true
+		}
+	}
+
 	clusterStarted := time.Now()
 	var readinessStarted time.Time
 	ocmReady := false
 	if !cfg.Tests.SkipClusterHealthChecks {
-		return wait.PollImmediate(30*time.Second, time.Duration(cfg.Cluster.InstallTimeout)*time.Minute, func() (bool, error) {
+		err := phase.Poll(30*time.Second, time.Duration(cfg.Cluster.InstallTimeout)*time.Minute, func() (bool, error) {
 			cluster, err := provider.GetCluster(clusterID)
 			state.Cluster.State = cluster.State()
 			if err == nil && cluster.State() == spi.ClusterStateReady {
@@ -75,9 +85,9 @@ func WaitForClusterReady(provider spi.Provider, clusterID string) error {
 
 					readinessStarted = time.Now()
 				}
-				if success, err := pollClusterHealth(provider, clusterID); success {
+				if success, err := pollClusterHealth(provider, clusterID, skipped); success {
 					cleanRuns++
-					log.Printf("Clean run %d/%d...", cleanRuns, config.Instance.Cluster.CleanCheckRuns)
+					logging.Infof("Clean run %d/%d...", cleanRuns, config.Instance.Cluster.CleanCheckRuns)
 					errRuns = 0
 					if cleanRuns == config.Instance.Cluster.CleanCheckRuns {
 						if metadata.Instance.TimeToClusterReady == 0 {
@@ -92,7 +102,7 @@ func WaitForClusterReady(provider spi.Provider, clusterID string) error {
 				} else {
 					if err != nil {
 						errRuns++
-						log.Printf("Error in PollClusterHealth: %v", err)
+						logging.Errorf("Error in PollClusterHealth: %v", err)
 						if errRuns >= errorWindow {
 							return false, fmt.Errorf("PollClusterHealth has returned an error %d times in a row. Failing osde2e", errorWindow)
 						}
@@ -103,66 +113,101 @@ func WaitForClusterReady(provider spi.Provider, clusterID string) error {
 			} else if err != nil {
 				return false, fmt.Errorf("Encountered error waiting for cluster: %v", err)
 			} else if cluster.State() == spi.ClusterStateError {
-				return false, fmt.Errorf("the installation of cluster '%s' has errored", clusterID)
+				return false, &triage.ProvisionError{ClusterID: clusterID, Err: fmt.Errorf("the installation of cluster '%s' has errored", clusterID)}
 			} else {
-				log.Printf("Cluster is not ready, current status '%s'.", cluster.State())
+				logging.Warnf("Cluster is not ready, current status '%s'.", cluster.State())
 			}
 			return false, nil
 		})
+		return readinessError(clusterID, ocmReady, err)
 	}
 	return nil
 }
 
-// PollClusterHealth looks at CVO data to determine if a cluster is alive/healthy or not
-func pollClusterHealth(provider spi.Provider, clusterID string) (status bool, err error) {
-	log.Print("Polling Cluster Health...\n")
+// readinessError types the error of a cluster that didn't become ready, as failing to provision if the provider
+// never reported it ready and as unhealthy otherwise.
+func readinessError(clusterID string, providerReady bool, err error) error {
+	var provisionErr *triage.ProvisionError
+	if err == nil || errors.As(err, &provisionErr) {
+		return err
+	}
+	if !providerReady {
+		return &triage.ProvisionError{ClusterID: clusterID, Err: err}
+	}
+	return &triage.HealthcheckError{ClusterID: clusterID, Err: err}
+}
+
+// PollClusterHealth looks at CVO data to determine if a cluster is alive/healthy or not. Skipped health checks aren't run.
+func pollClusterHealth(provider spi.Provider, clusterID string, skipped map[string]bool) (status bool, err error) {
+	logging.Infof("Polling Cluster Health...")
 	restConfig, err := getRestConfig(provider, clusterID)
 	if err != nil {
-		log.Printf("Error generating Rest Config: %v\n", err)
+		logging.Errorf("Error generating Rest Config: %v", err)
 		return false, nil
 	}
 
 	kubeClient, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		log.Printf("Error generating Kube Clientset: %v\n", err)
+		logging.Errorf("Error generating Kube Clientset: %v", err)
 		return false, nil
 	}
 
 	oscfg, err := osconfig.NewForConfig(restConfig)
 	if err != nil {
-		log.Printf("Error generating OpenShift Clientset: %v\n", err)
+		logging.Errorf("Error generating OpenShift Clientset: %v", err)
 		return false, nil
 	}
 
-	clusterHealthy := true
+	return len(runHealthChecks(kubeClient, oscfg, skipped)) == 0, nil
+}
 
-	var healthErr *multierror.Error
-	if check, err := healthchecks.CheckCVOReadiness(oscfg.ConfigV1()); !check || err != nil {
-		multierror.Append(healthErr, err)
-		clusterHealthy = false
+// CheckHealth runs the health checks against the cluster of a kubeconfig without waiting for them to pass, and
+// returns the checks that failed and why. Skipped health checks aren't run.
+func CheckHealth(kubeconfig []byte, skipped map[string]bool) ([]string, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error generating rest config: %v", err)
 	}
 
-	if check, err := healthchecks.CheckNodeHealth(kubeClient.CoreV1()); !check || err != nil {
-		multierror.Append(healthErr, err)
-		clusterHealthy = false
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Kube Clientset: %v", err)
 	}
 
-	if check, err := healthchecks.CheckOperatorReadiness(oscfg.ConfigV1()); !check || err != nil {
-		multierror.Append(healthErr, err)
-		clusterHealthy = false
+	oscfg, err := osconfig.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error generating OpenShift Clientset: %v", err)
 	}
+	return runHealthChecks(kubeClient, oscfg, skipped), nil
+}
 
-	if check, err := healthchecks.CheckPodHealth(kubeClient.CoreV1()); !check || err != nil {
-		multierror.Append(healthErr, err)
-		clusterHealthy = false
+// runHealthChecks runs the health checks that aren't skipped and returns the ones that failed, with their errors.
+func runHealthChecks(kubeClient kubernetes.Interface, oscfg osconfig.Interface, skipped map[string]bool) []string {
+	maxSkew := time.Duration(config.Instance.Tests.MaxClockSkew * float64(time.Second))
+	checks := []struct {
+		name  string
+		check func() (bool, error)
+	}{
+		{healthchecks.CVOCheck, func() (bool, error) { return healthchecks.CheckCVOReadiness(oscfg.ConfigV1()) }},
+		{healthchecks.NodesCheck, func() (bool, error) { return healthchecks.CheckNodeHealth(kubeClient.CoreV1()) }},
+		{healthchecks.OperatorsCheck, func() (bool, error) { return healthchecks.CheckOperatorReadiness(oscfg.ConfigV1()) }},
+		{healthchecks.PodsCheck, func() (bool, error) { return healthchecks.CheckPodHealth(kubeClient.CoreV1()) }},
+		{healthchecks.CertsCheck, func() (bool, error) { return healthchecks.CheckCerts(kubeClient.CoreV1()) }},
+		{healthchecks.ClockCheck, func() (bool, error) { return healthchecks.CheckClockSkew(kubeClient.CoordinationV1(), maxSkew) }},
 	}
 
-	if check, err := healthchecks.CheckCerts(kubeClient.CoreV1()); !check || err != nil {
-		multierror.Append(healthErr, err)
-		clusterHealthy = false
+	var failed []string
+	for _, c := range checks {
+		if skipped[c.name] {
+			continue
+		}
+		if ok, err := c.check(); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", c.name, err))
+		} else if !ok {
+			failed = append(failed, c.name)
+		}
 	}
-
-	return clusterHealthy, healthErr.ErrorOrNil()
+	return failed
 }
 
 func getRestConfig(provider spi.Provider, clusterID string) (*rest.Config, error) {
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/dns"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// dnsCheckInterval is the time between lookups of names that haven't propagated.
+const dnsCheckInterval = 15 * time.Second
+
+// WaitForDNSPropagation blocks until the names of the cluster's API server and routes resolve to the same addresses on
+// every configured DNS resolver, so route tests don't flake on resolvers that haven't seen the records yet. How long
+// each name took is recorded in the metadata.
+func WaitForDNSPropagation(provider spi.Provider, clusterID string) error {
+	cfg := config.Instance
+	if cfg.Tests.DNSPropagationTimeout == 0 || cfg.Tests.SkipClusterHealthChecks {
+		return nil
+	}
+	logger := logging.WithField(logging.ClusterIDField, clusterID)
+
+	cluster, err := provider.GetCluster(clusterID)
+	if err != nil {
+		return fmt.Errorf("couldn't get cluster: %v", err)
+	}
+	if cluster.APIURL() == "" {
+		logger.Infof("Not waiting for DNS propagation, the cluster has no API URL.")
+		return nil
+	}
+
+	hosts, err := dns.ClusterHosts(cluster.APIURL())
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Waiting %v minutes for DNS to propagate to %v...", cfg.Tests.DNSPropagationTimeout, cfg.Tests.DNSResolvers)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Tests.DNSPropagationTimeout)*time.Minute)
+	defer cancel()
+	propagations, err := dns.WaitForPropagation(ctx, dns.Servers(cfg.Tests.DNSResolvers), hosts, dnsCheckInterval)
+
+	seconds := map[string]float64{}
+	mismatches := map[string][]string{}
+	for _, propagation := range propagations {
+		if len(propagation.Mismatched) > 0 {
+			logger.Warnf("%s resolved everywhere after %.0fs, but not to the same addresses: %s", propagation.Host.Hostname, propagation.Seconds, strings.Join(propagation.Mismatched, ", "))
+			mismatches[propagation.Host.Name] = propagation.Mismatched
+		} else {
+			logger.Infof("%s resolved to %v everywhere after %.0fs.", propagation.Host.Hostname, propagation.Addresses, propagation.Seconds)
+		}
+		seconds[propagation.Host.Name] = propagation.Seconds
+	}
+	metadata.Instance.SetDNSPropagation(seconds, mismatches)
+	return err
+}
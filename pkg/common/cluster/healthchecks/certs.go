@@ -2,9 +2,9 @@ package healthchecks
 
 import (
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -36,7 +36,7 @@ func CheckCerts(secretClient v1.CoreV1Interface) (bool, error) {
 		return false, fmt.Errorf("error trying to find issued certificate(s): %v", err)
 	}
 	if len(secrets.Items) < 1 {
-		log.Printf("Certificate(s) not yet issued.")
+		logging.Warnf("Certificate(s) not yet issued.")
 		return false, nil
 	}
 
@@ -45,7 +45,7 @@ func CheckCerts(secretClient v1.CoreV1Interface) (bool, error) {
 		metadata.Instance.SetTimeToCertificateIssued(time.Since(certCheck.startTime).Seconds())
 	}
 
-	log.Printf("Certificate(s) has been found.")
+	logging.Infof("Certificate(s) has been found.")
 
 	return true, nil
 }
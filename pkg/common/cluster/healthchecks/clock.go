@@ -0,0 +1,54 @@
+package healthchecks
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// nodeLeaseNamespace holds the leases kubelets renew to show they're alive.
+const nodeLeaseNamespace = "kube-node-lease"
+
+// now is the reference clock nodes are compared against.
+var now = time.Now
+
+// CheckClockSkew compares the clock of each node to osde2e's clock, using the renew time that the kubelet sets on its
+// node's lease from the node's clock. A lease renewed in the future means the node's clock is ahead. Since leases are
+// only renewed every few seconds, a node's clock is considered behind only once its lease is older than its duration
+// by more than maxSkew; leases that are merely stale fail the nodes check.
+func CheckClockSkew(leaseClient v1.CoordinationV1Interface, maxSkew time.Duration) (bool, error) {
+	logging.Infof("Checking that node clocks aren't skewed...")
+
+	leases, err := leaseClient.Leases(nodeLeaseNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("error getting node leases: %v", err)
+	}
+
+	success := true
+	reference := now()
+	for _, lease := range leases.Items {
+		if lease.Spec.RenewTime == nil {
+			continue
+		}
+
+		skew := lease.Spec.RenewTime.Sub(reference)
+		var duration time.Duration
+		if lease.Spec.LeaseDurationSeconds != nil {
+			duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		}
+
+		if skew > maxSkew {
+			logging.Warnf("Node (%v) clock is %v ahead.", lease.Name, skew.Round(time.Millisecond))
+			success = false
+		} else if -skew > duration+maxSkew {
+			logging.Warnf("Node (%v) clock is at least %v behind.", lease.Name, (-skew - duration).Round(time.Millisecond))
+			success = false
+		}
+	}
+
+	return success, nil
+}
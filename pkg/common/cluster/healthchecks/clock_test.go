@@ -0,0 +1,66 @@
+package healthchecks
+
+import (
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+func nodeLease(name string, renewTime time.Time) *coordinationv1.Lease {
+	duration := int32(40)
+	renewed := metav1.NewMicroTime(renewTime)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nodeLeaseNamespace},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &name,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renewed,
+		},
+	}
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	reference := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+	now = func() time.Time { return reference }
+
+	var tests = []struct {
+		description string
+		expected    bool
+		objs        []runtime.Object
+	}{
+		{"no leases", true, nil},
+		{"recently renewed", true, []runtime.Object{
+			nodeLease("node-a", reference.Add(-8*time.Second)),
+			nodeLease("node-b", reference.Add(500*time.Millisecond)),
+		}},
+		{"clock ahead", false, []runtime.Object{
+			nodeLease("node-a", reference.Add(-8*time.Second)),
+			nodeLease("node-b", reference.Add(5*time.Second)),
+		}},
+		{"stale lease", true, []runtime.Object{
+			nodeLease("node-a", reference.Add(-30*time.Second)),
+		}},
+		{"clock behind", false, []runtime.Object{
+			nodeLease("node-a", reference.Add(-3*time.Minute)),
+		}},
+	}
+
+	for _, test := range tests {
+		kubeClient := kubernetes.NewSimpleClientset(test.objs...)
+		state, err := CheckClockSkew(kubeClient.CoordinationV1(), 2*time.Second)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			return
+		}
+
+		if state != test.expected {
+			t.Errorf("%v: Expected value doesn't match returned value (%v, %v)", test.description, test.expected, state)
+		}
+	}
+}
@@ -1,11 +1,11 @@
 package healthchecks
 
 import (
-	"log"
-
 	v1 "github.com/openshift/api/config/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
 )
 
 // GetClusterVersionObject wlil get the cluster version object for the cluster.
@@ -17,7 +17,7 @@ func GetClusterVersionObject(configClient configclient.ConfigV1Interface) (*v1.C
 // CheckCVOReadiness attempts to look at the state of the ClusterVersionOperator and returns true if things are healthy.
 func CheckCVOReadiness(configClient configclient.ConfigV1Interface) (bool, error) {
 	success := true
-	log.Print("Checking that CVO says the cluster is healthy...")
+	logging.Infof("Checking that CVO says the cluster is healthy...")
 
 	cvInfo, err := GetClusterVersionObject(configClient)
 	if err != nil {
@@ -26,7 +26,7 @@ func CheckCVOReadiness(configClient configclient.ConfigV1Interface) (bool, error
 
 	for _, v := range cvInfo.Status.Conditions {
 		if (v.Type != "Available" && v.Status != "False") && v.Type != "Upgradeable" && v.Type != "RetrievedUpdates" {
-			log.Printf("CVO State not complete: %v: %v %v", v.Type, v.Status, v.Message)
+			logging.Warnf("CVO State not complete: %v: %v %v", v.Type, v.Status, v.Message)
 			success = false
 		}
 	}
@@ -2,18 +2,18 @@ package healthchecks
 
 import (
 	"fmt"
-	"log"
 	"strings"
 
 	configclient "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // CheckOperatorReadiness attempts to look at the state of all operator and returns true if things are healthy.
 func CheckOperatorReadiness(configClient configclient.ConfigV1Interface) (bool, error) {
 	success := true
-	log.Print("Checking that all Operators are running or completed...")
+	logging.Infof("Checking that all Operators are running or completed...")
 
 	listOpts := metav1.ListOptions{}
 	list, err := configClient.ClusterOperators().List(listOpts)
@@ -22,7 +22,7 @@ func CheckOperatorReadiness(configClient configclient.ConfigV1Interface) (bool,
 	}
 
 	if len(list.Items) == 0 {
-		log.Printf("No operators found...?")
+		logging.Warnf("No operators found...?")
 		return false, nil
 	}
 
@@ -39,7 +39,7 @@ func CheckOperatorReadiness(configClient configclient.ConfigV1Interface) (bool,
 		if _, ok := operatorSkipList[co.GetName()]; !ok {
 			for _, cos := range co.Status.Conditions {
 				if (cos.Type != "Available" && cos.Status != "False") && cos.Type != "Upgradeable" {
-					log.Printf("Operator %v type %v is %v: %v", co.ObjectMeta.Name, cos.Type, cos.Status, cos.Message)
+					logging.Warnf("Operator %v type %v is %v: %v", co.ObjectMeta.Name, cos.Type, cos.Status, cos.Message)
 					success = false
 				}
 			}
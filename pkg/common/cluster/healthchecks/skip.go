@@ -0,0 +1,37 @@
+package healthchecks
+
+import "github.com/openshift/osde2e/pkg/common/logging"
+
+// Names of the health checks that can be skipped.
+const (
+	CVOCheck       = "cvo"
+	NodesCheck     = "nodes"
+	OperatorsCheck = "operators"
+	PodsCheck      = "pods"
+	CertsCheck     = "certs"
+	ClockCheck     = "clock"
+)
+
+// Names are all the health checks that can be skipped.
+var Names = []string{CVOCheck, NodesCheck, OperatorsCheck, PodsCheck, CertsCheck, ClockCheck}
+
+// Skipped returns the health checks in skip that exist. Names that don't match a health check are logged and ignored.
+func Skipped(skip []string) []string {
+	known := map[string]bool{}
+	for _, name := range Names {
+		known[name] = true
+	}
+
+	skipped := []string{}
+	for _, name := range skip {
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			logging.Warnf("Ignoring unknown health check '%s', the health checks are %v", name, Names)
+			continue
+		}
+		skipped = append(skipped, name)
+	}
+	return skipped
+}
@@ -0,0 +1,25 @@
+package healthchecks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkipped(t *testing.T) {
+	var tests = []struct {
+		description string
+		skip        []string
+		expected    []string
+	}{
+		{"nothing skipped", nil, []string{}},
+		{"known checks", []string{PodsCheck, CertsCheck}, []string{PodsCheck, CertsCheck}},
+		{"unknown checks ignored", []string{"pv", NodesCheck, ""}, []string{NodesCheck}},
+	}
+
+	for _, test := range tests {
+		skipped := Skipped(test.skip)
+		if !reflect.DeepEqual(skipped, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.description, test.expected, skipped)
+		}
+	}
+}
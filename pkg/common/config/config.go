@@ -1,10 +1,6 @@
 // Package config provides the configuration for tests run as part of the osde2e suite.
 package config
 
-import (
-	"time"
-)
-
 // Instance is the configuration used for end to end testing.
 var Instance = new(Config)
 
@@ -26,10 +22,46 @@ type Config struct {
 
 	Prometheus PrometheusConfig `yaml:"prometheus"`
 
+	Grafana GrafanaConfig `yaml:"grafana"`
+
+	Pushgateway PushgatewayConfig `yaml:"pushgateway"`
+
 	Weather WeatherConfig `yaml:"weather"`
 
+	Scenarios ScenarioConfig `yaml:"scenarios"`
+
+	Budget BudgetConfig `yaml:"budget"`
+
+	ReleaseController ReleaseControllerConfig `yaml:"releaseController"`
+
+	RunIndex RunIndexConfig `yaml:"runIndex"`
+
+	GateAlert GateAlertConfig `yaml:"gateAlert"`
+
+	ClusterReaper ClusterReaperConfig `yaml:"clusterReaper"`
+
+	FaultInjection FaultInjectionConfig `yaml:"faultInjection"`
+
+	EnvironmentLock EnvironmentLockConfig `yaml:"environmentLock"`
+
+	Hibernation HibernationConfig `yaml:"hibernation"`
+
+	Hooks HooksConfig `yaml:"hooks"`
+
+	Profiling ProfilingConfig `yaml:"profiling"`
+
+	Multicluster MulticlusterConfig `yaml:"multicluster"`
+
+	IdentityFederation IdentityFederationConfig `yaml:"identityFederation"`
+
+	CustomDomain CustomDomainConfig `yaml:"customDomain"`
+
+	ResourceBudget ResourceBudgetConfig `yaml:"resourceBudget"`
+
+	Fleet FleetConfig `yaml:"fleet"`
+
 	// Provider is what provider to use to create/delete clusters.
-	Provider string `json:"provider" env:"PROVIDER" sect:"tests" default:"ocm" yaml:"provider"`
+	Provider string `json:"provider" env:"PROVIDER" sect:"tests" default:"ocm" yaml:"provider" validate:"oneof=ocm rosa mock"`
 
 	// JobName lets you name the current e2e job run
 	JobName string `json:"job_name" env:"JOB_NAME" sect:"tests" yaml:"jobName"`
@@ -40,7 +72,7 @@ type Config struct {
 	// BaseJobURL is the root location for all job artifacts
 	// For example, https://storage.googleapis.com/origin-ci-test/logs/osde2e-prod-gcp-e2e-next/61/build-log.txt would be
 	// https://storage.googleapis.com/origin-ci-test/logs -- This is also our default
-	BaseJobURL string `jon:"baseJobURL" env:"BASE_JOB_URL" sect:"test" yaml:"baseJobURL" default:"https://storage.googleapis.com/origin-ci-test/logs"`
+	BaseJobURL string `jon:"baseJobURL" env:"BASE_JOB_URL" sect:"test" yaml:"baseJobURL" default:"https://storage.googleapis.com/origin-ci-test/logs" validate:"url"`
 
 	// ReportDir is the location JUnit XML results are written.
 	ReportDir string `json:"report_dir,omitempty" env:"REPORT_DIR" sect:"tests" default:"__TMP_DIR__" yaml:"reportDir"`
@@ -56,6 +88,12 @@ type Config struct {
 
 	// MustGather will run a Must-Gather process upon completion of the tests.
 	MustGather bool `json:"must_gather,omitempty" env:"MUST_GATHER" sect:"tests" default:"true" yaml:"mustGather"`
+
+	// LogLevel is the minimum level of the messages osde2e logs: debug, info, warn, or error.
+	LogLevel string `env:"LOG_LEVEL" sect:"tests" default:"info" yaml:"logLevel" validate:"oneof=debug info warn error"`
+
+	// LogFormat is the format osde2e logs messages in: text, or json for log stores.
+	LogFormat string `env:"LOG_FORMAT" sect:"tests" default:"text" yaml:"logFormat" validate:"oneof=text json"`
 }
 
 // KubeConfig stores information required to talk to the Kube API
@@ -64,19 +102,226 @@ type KubeConfig struct {
 	Path string `env:"TEST_KUBECONFIG" sect:"cluster" yaml:"path"`
 }
 
+// BudgetConfig limits what a run may use. When a limit is exceeded the run is aborted and its cluster is cleaned up.
+type BudgetConfig struct {
+	// MaxClusters is the number of clusters a run may create. If 0, there is no limit.
+	MaxClusters int `env:"BUDGET_MAX_CLUSTERS" sect:"budget" default:"0" yaml:"maxClusters"`
+
+	// MaxNodeHours is the total number of hours the nodes of the run's clusters may run. If 0, there is no limit.
+	MaxNodeHours int `env:"BUDGET_MAX_NODE_HOURS" sect:"budget" default:"0" yaml:"maxNodeHours"`
+
+	// MaxRunDuration is the number of minutes a run may take. If 0, there is no limit.
+	MaxRunDuration int `env:"BUDGET_MAX_RUN_DURATION" sect:"budget" default:"0" yaml:"maxRunDuration" validate:"range=0:"`
+}
+
+// ReleaseControllerConfig configures posting verdicts to the OpenShift release-controller.
+type ReleaseControllerConfig struct {
+	// URL is the address of the release-controller. If empty, verdicts aren't posted.
+	URL string `env:"RELEASE_CONTROLLER_URL" sect:"releaseController" yaml:"url" validate:"url"`
+
+	// Token authenticates osde2e to the release-controller.
+	Token string `env:"RELEASE_CONTROLLER_TOKEN" sect:"releaseController" yaml:"token" secret:"true"`
+
+	// Stream is the release stream of the tested release. If empty, it is derived from the release tag.
+	Stream string `env:"RELEASE_CONTROLLER_STREAM" sect:"releaseController" yaml:"stream"`
+
+	// Verification is the name verdicts are posted under in the release's acceptance workflow.
+	Verification string `env:"RELEASE_CONTROLLER_VERIFICATION" sect:"releaseController" default:"osd-e2e" yaml:"verification"`
+}
+
+// RunIndexConfig configures publishing a summary of each run to a central index.
+type RunIndexConfig struct {
+	// URL is the HTTP endpoint records are POSTed to, or the S3 URL they're stored under. If empty, runs aren't published.
+	URL string `env:"RUN_INDEX_URL" sect:"runIndex" yaml:"url" validate:"url"`
+
+	// Token authenticates osde2e to an HTTP run index.
+	Token string `env:"RUN_INDEX_TOKEN" sect:"runIndex" yaml:"token" secret:"true"`
+}
+
+// GateAlertConfig configures paging when release-gating runs of a version keep failing a suite.
+type GateAlertConfig struct {
+	// RoutingKey is the integration key of the PagerDuty service that is paged. If empty, no one is paged.
+	RoutingKey string `env:"GATE_ALERT_ROUTING_KEY" sect:"gateAlert" yaml:"routingKey" secret:"true"`
+
+	// StateURL is the S3 URL the consecutive failures of each version and suite are kept under.
+	StateURL string `env:"GATE_ALERT_STATE_URL" sect:"gateAlert" yaml:"stateURL" validate:"url"`
+
+	// Failures is how many runs of a version in a row must fail a suite before it pages.
+	Failures int `env:"GATE_ALERT_FAILURES" sect:"gateAlert" default:"3" yaml:"failures" validate:"range=1:"`
+
+	// EventsURL is the PagerDuty Events API v2 endpoint events are sent to.
+	EventsURL string `env:"GATE_ALERT_EVENTS_URL" sect:"gateAlert" default:"https://events.pagerduty.com/v2/enqueue" yaml:"eventsURL" validate:"url"`
+}
+
+// ClusterReaperConfig configures deleting clusters orphaned by killed runs.
+type ClusterReaperConfig struct {
+	// ErrorHours is how many hours after it was created a cluster in the error state is deleted.
+	ErrorHours int `env:"CLUSTER_REAPER_ERROR_HOURS" sect:"clusterReaper" default:"6" yaml:"errorHours" validate:"range=1:"`
+
+	// SlackWebhook is the webhook a summary of each pass is posted to. If empty, summaries are only logged.
+	SlackWebhook string `env:"CLUSTER_REAPER_SLACK_WEBHOOK" sect:"clusterReaper" yaml:"slackWebhook" secret:"true"`
+}
+
+// EnvironmentLockConfig makes runs against some environments take a lock so that only one runs at a time.
+type EnvironmentLockConfig struct {
+	// URL is the S3 URL locks are stored under. Environments aren't locked if this is empty.
+	URL string `env:"ENVIRONMENT_LOCK_URL" sect:"environmentLock" yaml:"url" validate:"url"`
+
+	// Environments is a comma-delimited list of the environments runs must lock before testing against them.
+	Environments []string `env:"ENVIRONMENT_LOCK_ENVIRONMENTS" sect:"environmentLock" default:"prod" yaml:"environments"`
+
+	// Timeout is how long (in minutes) to wait for another run to release the lock.
+	Timeout int `env:"ENVIRONMENT_LOCK_TIMEOUT" sect:"environmentLock" default:"120" yaml:"timeout"`
+
+	// TTL is how long (in minutes) a lock is kept without being renewed, such as after a run dies.
+	TTL int `env:"ENVIRONMENT_LOCK_TTL" sect:"environmentLock" default:"15" yaml:"ttl"`
+}
+
+// FaultInjectionConfig changes how faults are injected by the fault injection suites.
+type FaultInjectionConfig struct {
+	// Zone is the availability zone taken down by the AZ failure suite. If empty, the last zone with nodes is used.
+	Zone string `env:"AZ_FAILURE_ZONE" sect:"faultInjection" yaml:"zone"`
+
+	// Duration is how long (in minutes) the availability zone is kept down.
+	Duration int `env:"AZ_FAILURE_DURATION" sect:"faultInjection" default:"10" yaml:"duration"`
+
+	// RecoveryTimeout is how long (in minutes) to wait for the cluster to recover once the zone is restored.
+	RecoveryTimeout int `env:"AZ_FAILURE_RECOVERY_TIMEOUT" sect:"faultInjection" default:"30" yaml:"recoveryTimeout"`
+
+	// MachineReplacementSLO is how long (in minutes) a machine health check may take to replace a worker whose kubelet
+	// was stopped, until the replacement's node is ready.
+	MachineReplacementSLO int `env:"MHC_REPLACEMENT_SLO" sect:"faultInjection" default:"30" yaml:"machineReplacementSLO"`
+
+	// MachineReplacementTimeout is how long (in minutes) to wait for the worker to be replaced. The kubelet is started
+	// again after this if the worker hasn't been replaced.
+	MachineReplacementTimeout int `env:"MHC_REPLACEMENT_TIMEOUT" sect:"faultInjection" default:"60" yaml:"machineReplacementTimeout"`
+}
+
+// HibernationConfig sets the SLOs checked by the hibernation suite.
+type HibernationConfig struct {
+	// HibernateSLO is how long (in minutes) a cluster may take to hibernate.
+	HibernateSLO int `env:"HIBERNATION_HIBERNATE_SLO" sect:"hibernation" default:"15" yaml:"hibernateSLO"`
+
+	// ResumeSLO is how long (in minutes) a hibernating cluster may take to be reported ready again.
+	ResumeSLO int `env:"HIBERNATION_RESUME_SLO" sect:"hibernation" default:"20" yaml:"resumeSLO"`
+
+	// HealthySLO is how long (in minutes) a resumed cluster may take to pass its health checks.
+	HealthySLO int `env:"HIBERNATION_HEALTHY_SLO" sect:"hibernation" default:"15" yaml:"healthySLO"`
+
+	// Timeout is how long (in minutes) to wait for each transition before giving up. It should be longer than the SLOs
+	// so that the duration of a slow transition is still measured.
+	Timeout int `env:"HIBERNATION_TIMEOUT" sect:"hibernation" default:"60" yaml:"timeout"`
+}
+
+// HooksConfig configures the webhooks invoked at points of the run.
+type HooksConfig struct {
+	// Webhooks is a comma-delimited list of URLs the run context is posted to at every hook point.
+	Webhooks []string `env:"HOOK_WEBHOOKS" sect:"hooks" yaml:"webhooks"`
+}
+
+// MulticlusterConfig configures the suite testing connectivity between clusters.
+type MulticlusterConfig struct {
+	// PeerClusterID is an existing cluster to connect to the cluster under test. If empty, a peer is created and deleted by the suite.
+	PeerClusterID string `env:"MULTICLUSTER_PEER_CLUSTER_ID" sect:"multicluster" yaml:"peerClusterID"`
+
+	// PeerClusterSpec is the cluster spec of the peer created by the suite. Its network must not overlap with the cluster under test.
+	PeerClusterSpec string `env:"MULTICLUSTER_PEER_CLUSTER_SPEC" sect:"multicluster" default:"submariner-peer" yaml:"peerClusterSpec"`
+
+	// Addon is the addon that connects the clusters with Submariner.
+	Addon string `env:"MULTICLUSTER_ADDON" sect:"multicluster" default:"submariner" yaml:"addon"`
+
+	// Requests is the number of requests made across the clusters to measure their latency.
+	Requests int `env:"MULTICLUSTER_REQUESTS" sect:"multicluster" default:"50" yaml:"requests"`
+
+	// Timeout is how long (in minutes) to wait for the peer to be ready and for the clusters to be connected.
+	Timeout int `env:"MULTICLUSTER_TIMEOUT" sect:"multicluster" default:"150" yaml:"timeout"`
+}
+
+// IdentityFederationConfig configures the external OpenID Connect identity provider used by the identity federation
+// suite. The suite is skipped if no issuer is set.
+type IdentityFederationConfig struct {
+	// Issuer is the URL of the OpenID Connect issuer.
+	Issuer string `env:"OIDC_ISSUER" sect:"identityFederation" yaml:"issuer" validate:"url"`
+
+	// ClientID is the client registered with the issuer for the cluster's OAuth server.
+	ClientID string `env:"OIDC_CLIENT_ID" sect:"identityFederation" yaml:"clientID"`
+
+	// ClientSecret is the secret of the client.
+	ClientSecret string `env:"OIDC_CLIENT_SECRET" sect:"identityFederation" yaml:"clientSecret" secret:"true"`
+
+	// ExtraScopes is a comma-delimited list of scopes requested in addition to openid, such as the scope needed for the groups claim.
+	ExtraScopes []string `env:"OIDC_EXTRA_SCOPES" sect:"identityFederation" yaml:"extraScopes"`
+
+	// GroupsClaim is the claim listing the groups of a user.
+	GroupsClaim string `env:"OIDC_GROUPS_CLAIM" sect:"identityFederation" default:"groups" yaml:"groupsClaim"`
+
+	// Username and Password are the credentials of a user of the issuer. The issuer must support the password grant.
+	Username string `env:"OIDC_USERNAME" sect:"identityFederation" yaml:"username"`
+	Password string `env:"OIDC_PASSWORD" sect:"identityFederation" yaml:"password" secret:"true"`
+
+	// Group is a group in the user's groups claim. Its members are given access to a project to check RBAC mapping.
+	Group string `env:"OIDC_GROUP" sect:"identityFederation" yaml:"group"`
+
+	// Timeout is how long (in minutes) to wait for the cluster's OAuth server to accept logins from the identity provider.
+	Timeout int `env:"OIDC_TIMEOUT" sect:"identityFederation" default:"15" yaml:"timeout" validate:"range=1:"`
+}
+
+// CustomDomainConfig configures the custom domain suite.
+type CustomDomainConfig struct {
+	// Domain is the custom apps domain. Routes are requested through the domain's endpoint, so it doesn't need to resolve. A random subdomain of example.com is used if empty.
+	Domain string `env:"CUSTOM_DOMAIN" sect:"customDomain" yaml:"domain"`
+
+	// Timeout is how long (in minutes) to wait for the custom domain to be ready, and again for a renewed certificate to be served.
+	Timeout int `env:"CUSTOM_DOMAIN_TIMEOUT" sect:"customDomain" default:"20" yaml:"timeout" validate:"range=1:"`
+}
+
+// ResourceBudgetConfig caps the resources requested by the namespaces tests run in, so a runaway test harness can't
+// starve the cluster and invalidate the results of other suites. There is no budget if neither CPU nor Memory is set.
+type ResourceBudgetConfig struct {
+	// CPU is the total CPU the pods of a run's test namespaces may request, as a Kubernetes quantity, ex. "8".
+	CPU string `env:"RESOURCE_BUDGET_CPU" sect:"resourceBudget" yaml:"cpu"`
+
+	// Memory is the total memory the pods of a run's test namespaces may request, as a Kubernetes quantity, ex. "16Gi".
+	Memory string `env:"RESOURCE_BUDGET_MEMORY" sect:"resourceBudget" yaml:"memory"`
+
+	// DefaultCPURequest and DefaultMemoryRequest are requested by containers that don't request CPU or memory
+	// themselves, since containers without requests can't be created in namespaces with a budget.
+	DefaultCPURequest    string `env:"RESOURCE_BUDGET_DEFAULT_CPU_REQUEST" sect:"resourceBudget" default:"100m" yaml:"defaultCPURequest"`
+	DefaultMemoryRequest string `env:"RESOURCE_BUDGET_DEFAULT_MEMORY_REQUEST" sect:"resourceBudget" default:"128Mi" yaml:"defaultMemoryRequest"`
+}
+
+// ProfilingConfig exposes the runtime profiles of osde2e.
+type ProfilingConfig struct {
+	// Address is the address the pprof and expvar endpoints are served on while osde2e runs, ex. "localhost:6060". They aren't served if this is empty.
+	Address string `env:"PROFILING_ADDRESS" sect:"profiling" yaml:"address"`
+}
+
+// ScenarioConfig describes where run scenarios are defined outside of osde2e.
+type ScenarioConfig struct {
+	// Repo is a Git repository containing scenario definitions. Scenarios are disabled if this is empty.
+	Repo string `env:"SCENARIO_REPO" sect:"scenarios" yaml:"repo"`
+
+	// Ref is the tag or commit of the scenario repository to use. It must be set if Repo is, so that runs are
+	// repeatable.
+	Ref string `env:"SCENARIO_REF" sect:"scenarios" yaml:"ref"`
+
+	// Dir is the directory within the scenario repository containing scenario definitions.
+	Dir string `env:"SCENARIO_DIR" sect:"scenarios" default:"scenarios" yaml:"dir"`
+
+	// Names is a comma-delimited list of scenarios to load, in order. Each is read from <Dir>/<name>.yaml.
+	Names []string `env:"SCENARIOS" sect:"scenarios" yaml:"names"`
+}
+
 // OCMConfig contains connect info for the OCM API
 type OCMConfig struct {
 	// Token is used to authenticate with OCM.
-	Token string `json:"ocm_token" env:"OCM_TOKEN" sect:"required" yaml:"token"`
+	Token string `json:"ocm_token" env:"OCM_TOKEN" sect:"required" yaml:"token" secret:"true"`
 
 	// Env is the OpenShift Dedicated environment used to provision clusters.
 	Env string `env:"OSD_ENV" sect:"environment" default:"prod" yaml:"env"`
 
 	// Debug shows debug level messages when enabled.
 	Debug bool `env:"DEBUG_OSD" sect:"environment" default:"false" yaml:"debug"`
-
-	// NumRetries is the number of times to retry each OCM call.
-	NumRetries int `env:"NUM_RETRIES" sect:"ocm" default:"3" yaml:"numRetries"`
 }
 
 // UpgradeConfig stores information required to perform OSDe2e upgrade testing
@@ -92,6 +337,23 @@ type UpgradeConfig struct {
 
 	// ReleaseStream used to retrieve latest release images. If set, it will be used to perform an upgrade.
 	ReleaseStream string `env:"UPGRADE_RELEASE_STREAM" sect:"upgrade" yaml:"releaseStream"`
+
+	// SeedProfile seeds the cluster with namespaces and objects before upgrading so etcd holds a production-like
+	// amount of data. It is the name of a profile in /assets/upgrade/seed or the path to a profile file.
+	SeedProfile string `env:"UPGRADE_SEED_PROFILE" sect:"upgrade" yaml:"seedProfile"`
+
+	// HostedVersion is the version the hosted cluster upgrade suite upgrades the control plane and node pools to.
+	HostedVersion string `env:"HCP_UPGRADE_VERSION" sect:"upgrade" yaml:"hostedVersion"`
+
+	// HostedTimeout is how long (in minutes) the hosted cluster upgrade suite waits for each upgrade.
+	HostedTimeout int `env:"HCP_UPGRADE_TIMEOUT" sect:"upgrade" default:"90" yaml:"hostedTimeout"`
+
+	// AcknowledgeVersionGates acknowledges the version gates, such as API removals, that an upgrade requires.
+	AcknowledgeVersionGates bool `env:"UPGRADE_ACKNOWLEDGE_VERSION_GATES" sect:"upgrade" default:"false" yaml:"acknowledgeVersionGates"`
+
+	// VersionGateWait is how long (in minutes) to wait for version gates to be acknowledged by someone else when
+	// osde2e doesn't acknowledge them. If 0, upgrades with unacknowledged gates fail right away.
+	VersionGateWait int `env:"UPGRADE_VERSION_GATE_WAIT" sect:"upgrade" default:"0" yaml:"versionGateWait"`
 }
 
 // ClusterConfig contains config information pertaining to an OSD cluster
@@ -99,9 +361,20 @@ type ClusterConfig struct {
 	// MultiAZ deploys a cluster across multiple availability zones.
 	MultiAZ bool `env:"MULTI_AZ" sect:"cluster" default:"false" yaml:"multiAZ"`
 
+	// SecurityGroups are the IDs of additional security groups attached to the compute nodes of CCS clusters at
+	// install. A spec checks that they're attached to every compute node.
+	SecurityGroups []string `env:"CLUSTER_SECURITY_GROUPS" sect:"cluster" yaml:"securityGroups"`
+
 	// DestroyClusterAfterTest set to true if you want to the cluster to be explicitly deleted after the test.
 	DestroyAfterTest bool `env:"DESTROY_CLUSTER" sect:"cluster" default:"false" yaml:"destroyAfterTest"`
 
+	// NameTemplate is the Go template cluster names are generated from when CLUSTER_NAME isn't set. It can refer to
+	// .Job, .JobID, .Purpose, .Version, .Date, and .Suffix. The name is also the prefix of the cluster's DNS domain.
+	NameTemplate string `env:"CLUSTER_NAME_TEMPLATE" sect:"cluster" default:"ci-cluster-{{.Version}}-{{.Suffix}}" yaml:"nameTemplate"`
+
+	// Purpose describes what clusters are for, so it can be included in their names, ex. "upgrade" or "addons".
+	Purpose string `env:"CLUSTER_PURPOSE" sect:"cluster" default:"ci" yaml:"purpose"`
+
 	// ExpiryInMinutes is how long before a cluster expires and is deleted by OSD.
 	ExpiryInMinutes int64 `env:"CLUSTER_EXPIRY_IN_MINUTES" sect:"cluster" default:"210" yaml:"expiryInMinutes"`
 
@@ -109,7 +382,35 @@ type ClusterConfig struct {
 	AfterTestWait int64 `env:"AFTER_TEST_CLUSTER_WAIT" sect:"environment" default:"60" yaml:"afterTestWait"`
 
 	// InstallTimeout is how long to wait before failing a cluster launch.
-	InstallTimeout int64 `env:"CLUSTER_UP_TIMEOUT" sect:"environment" default:"135" yaml:"installTimeout"`
+	InstallTimeout int64 `env:"CLUSTER_UP_TIMEOUT" sect:"environment" default:"135" yaml:"installTimeout" validate:"range=0:"`
+
+	// DeprovisionTimeout is how many minutes to wait for a cluster to be deleted. If 0, deletion is not waited on.
+	DeprovisionTimeout int64 `env:"CLUSTER_DOWN_TIMEOUT" sect:"environment" default:"0" yaml:"deprovisionTimeout" validate:"range=0:"`
+
+	// AuditStorage fails the run if persistent volumes weren't reclaimed, and reports the ones still bound.
+	AuditStorage bool `env:"CLUSTER_AUDIT_STORAGE" sect:"cluster" default:"false" yaml:"auditStorage"`
+
+	// AuditVolumes fails the run if the cluster's EBS volumes still exist once it is deleted. The deletion is waited on
+	// even if DeprovisionTimeout is 0.
+	AuditVolumes bool `env:"CLUSTER_AUDIT_VOLUMES" sect:"cluster" default:"false" yaml:"auditVolumes"`
+
+	// CheckDrift compares the cluster's compute nodes, network, identity providers, and addons with what the provider
+	// believes was requested at the start and end of the run, and reports any drift.
+	CheckDrift bool `env:"CLUSTER_CHECK_DRIFT" sect:"cluster" default:"true" yaml:"checkDrift"`
+
+	// Adopt searches OCM for a ready cluster with the chosen version, cloud provider, and region and uses it instead
+	// of creating a cluster. A cluster is created if none match. Adopted clusters are never destroyed.
+	Adopt bool `env:"CLUSTER_ADOPT" sect:"cluster" default:"false" yaml:"adopt"`
+
+	// AdoptProperties is a comma-delimited list of <property>=<value> entries an adopted cluster must have.
+	AdoptProperties []string `env:"CLUSTER_ADOPT_PROPERTIES" sect:"cluster" yaml:"adoptProperties"`
+
+	// AdoptSearch is added to the OCM search for a cluster to adopt, ex. "multi_az = 'true'".
+	AdoptSearch string `env:"CLUSTER_ADOPT_SEARCH" sect:"cluster" yaml:"adoptSearch"`
+
+	// AdoptTestTimeout is how long (in minutes) the tests of a run that adopted a cluster may take. Claims older than
+	// it plus InstallTimeout were left by runs that died without releasing them and are ignored.
+	AdoptTestTimeout int64 `env:"CLUSTER_ADOPT_TEST_TIMEOUT" sect:"cluster" default:"480" yaml:"adoptTestTimeout" validate:"range=1:"`
 
 	// UseLatestVersionForInstall will select the latest cluster image set available for a fresh install.
 	UseLatestVersionForInstall bool `env:"USE_LATEST_VERSION_FOR_INSTALL" sect:"version" default:"false" yaml:"useLatestVersionForInstall"`
@@ -132,6 +433,12 @@ type ClusterConfig struct {
 	// MinorTarget is the minor version to target. If specified, it is used in version selection.
 	MinorTarget int64 `env:"MINOR_TARGET" sect:"version" yaml:"minorTarget"`
 
+	// AutoscalerMaxNodes configures the cluster autoscaler with the maximum number of nodes in the cluster. If 0, the autoscaler isn't configured.
+	AutoscalerMaxNodes int `env:"CLUSTER_AUTOSCALER_MAX_NODES" sect:"cluster" default:"0" yaml:"autoscalerMaxNodes"`
+
+	// AutoscalerScaleDownUtilization is the node utilization, between 0 and 1, below which the cluster autoscaler may remove a node.
+	AutoscalerScaleDownUtilization string `env:"CLUSTER_AUTOSCALER_SCALE_DOWN_UTILIZATION" sect:"cluster" yaml:"autoscalerScaleDownUtilization"`
+
 	// CleanCheckRuns lets us set the number of osd-verify checks we want to run before deeming a cluster "healthy"
 	CleanCheckRuns int `env:"CLEAN_CHECK_RUNS" sect:"environment" default:"20" yaml:"cleanCheckRuns"`
 }
@@ -142,6 +449,24 @@ type AddonConfig struct {
 	IDs []string `env:"ADDON_IDS" sect:"addons" yaml:"ids"`
 	// TestHarnesses is an array of container images that will test the addon
 	TestHarnesses []string `env:"ADDON_TEST_HARNESSES" sect:"addons" yaml:"testHarnesses"`
+	// TestHarnessProfile is the RBAC profile test harnesses run with: read-only, namespace-admin, or cluster-admin
+	TestHarnessProfile string `env:"ADDON_TEST_HARNESS_PROFILE" sect:"addons" default:"cluster-admin" yaml:"testHarnessProfile"`
+	// TestHarnessProfiles overrides the RBAC profile for individual test harnesses using entries of the form <image>=<profile>
+	TestHarnessProfiles []string `env:"ADDON_TEST_HARNESS_PROFILES" sect:"addons" yaml:"testHarnessProfiles"`
+	// TestHarnessGroups assigns test harnesses to groups using entries of the form <image>=<group>. Harnesses without a group are in the default group
+	TestHarnessGroups []string `env:"ADDON_TEST_HARNESS_GROUPS" sect:"addons" yaml:"testHarnessGroups"`
+	// TestHarnessConcurrency is how many harnesses of a group run at a time, using entries of the form <group>=<number>. Groups run one harness at a time by default
+	TestHarnessConcurrency []string `env:"ADDON_TEST_HARNESS_CONCURRENCY" sect:"addons" yaml:"testHarnessConcurrency"`
+	// TestHarnessGroupOrder lists the groups that run first, in order. The other groups follow in the order their first harness is listed
+	TestHarnessGroupOrder []string `env:"ADDON_TEST_HARNESS_GROUP_ORDER" sect:"addons" yaml:"testHarnessGroupOrder"`
+	// TestHarnessRoleARN is an AWS IAM role test harnesses assume using a projected ServiceAccount token
+	TestHarnessRoleARN string `env:"ADDON_TEST_HARNESS_ROLE_ARN" sect:"addons" yaml:"testHarnessRoleARN"`
+	// TestHarnessTokenAudience is the audience of the token test harnesses exchange for AWS credentials
+	TestHarnessTokenAudience string `env:"ADDON_TEST_HARNESS_TOKEN_AUDIENCE" sect:"addons" default:"sts.amazonaws.com" yaml:"testHarnessTokenAudience"`
+	// ReadinessDir is a directory of <addon ID>.yaml declarations of what addons create, used instead of the maintained declarations
+	ReadinessDir string `env:"ADDON_READINESS_DIR" sect:"addons" yaml:"readinessDir"`
+	// ReadinessTimeout is how long (in minutes) to wait for installed addons to be ready
+	ReadinessTimeout int `env:"ADDON_READINESS_TIMEOUT" sect:"addons" default:"30" yaml:"readinessTimeout" validate:"range=1:"`
 }
 
 // ScaleConfig options for scale testing
@@ -149,13 +474,38 @@ type ScaleConfig struct {
 	WorkloadsRepository string `env:"WORKLOADS_REPO" sect:"scale" default:"https://github.com/openshift-scale/workloads" yaml:"workloadsRepository"`
 
 	WorkloadsRepositoryBranch string `env:"WORKLOADS_REPO_BRANCH" sect:"scale" default:"master" yaml:"workloadsRepositoryBranch"`
+
+	// ImagePullImages is a comma-delimited list of large images the image pull suite pulls on every node at once.
+	ImagePullImages []string `env:"SCALE_IMAGE_PULL_IMAGES" sect:"scale" default:"registry.redhat.io/rhel8/support-tools,registry.redhat.io/ubi8/python-38,registry.redhat.io/openshift4/ose-cli" yaml:"imagePullImages"`
+
+	// ImagePullTimeout is how long (in minutes) the image pull suite waits for the images to be pulled.
+	ImagePullTimeout int `env:"SCALE_IMAGE_PULL_TIMEOUT" sect:"scale" default:"30" yaml:"imagePullTimeout" validate:"range=1:"`
+
+	// CreateRate is how many objects per second load tests create. If 0, objects are created as fast as possible.
+	CreateRate int `env:"SCALE_CREATE_RATE" sect:"scale" default:"0" yaml:"createRate" validate:"range=0:"`
+
+	// CreateBurst is how many objects load tests may create at once when they are being rate limited.
+	CreateBurst int `env:"SCALE_CREATE_BURST" sect:"scale" default:"1" yaml:"createBurst" validate:"range=1:"`
+
+	// NetworkImage is the image the network performance suite runs iperf3 and netperf from.
+	NetworkImage string `env:"SCALE_NETWORK_IMAGE" sect:"scale" default:"quay.io/cloud-bulldozer/netperf:latest" yaml:"networkImage"`
+
+	// NetworkDuration is how long (in seconds) each network throughput and latency measurement runs.
+	NetworkDuration int `env:"SCALE_NETWORK_DURATION" sect:"scale" default:"30" yaml:"networkDuration" validate:"range=1:"`
+
+	// NetworkBaselines is a YAML file of the expected network throughput and latency of each instance type. The
+	// maintained baselines are used by default.
+	NetworkBaselines string `env:"SCALE_NETWORK_BASELINES" sect:"scale" yaml:"networkBaselines"`
+
+	// NetworkTolerance is how far (in percent) network measurements may fall short of their baselines.
+	NetworkTolerance int `env:"SCALE_NETWORK_TOLERANCE" sect:"scale" default:"20" yaml:"networkTolerance" validate:"range=0:100"`
 }
 
 // TestConfig changes the behavior of how and what tests are run.
 type TestConfig struct {
 	// PollingTimeout is how long (in mimutes) to wait for an object to be created
 	// before failing the test.
-	PollingTimeout int64 `env:"POLLING_TIMEOUT" sect:"tests" default:"30" yaml:"pollingTimeout"`
+	PollingTimeout int64 `env:"POLLING_TIMEOUT" sect:"tests" default:"30" yaml:"pollingTimeout" validate:"range=0:"`
 
 	// GinkgoSkip is a regex passed to Ginkgo that skips any test suites matching the regex. ex. "Operator"
 	GinkgoSkip string `env:"GINKGO_SKIP" sect:"tests" yaml:"ginkgoSkip"`
@@ -163,9 +513,51 @@ type TestConfig struct {
 	// GinkgoFocus is a regex passed to Ginkgo that focus on any test suites matching the regex. ex. "Operator"
 	GinkgoFocus string `env:"GINKGO_FOCUS" sect:"tests" yaml:"focus"`
 
+	// Parallelism is the number of Ginkgo nodes the specs of each phase are spread across. Specs marked [Serial] run
+	// while no other spec is running. If 1, specs run one at a time in the osde2e process.
+	Parallelism int `env:"TEST_PARALLELISM" sect:"tests" default:"1" yaml:"parallelism" validate:"range=1:"`
+
+	// RetryCount is how many times the specs that failed in a phase are run again. Specs that pass when run again are
+	// reported as flaky instead of failed.
+	RetryCount int `env:"TEST_RETRY_COUNT" sect:"tests" default:"0" yaml:"retryCount" validate:"range=0:"`
+
 	// TestsToRun is a list of files which should be executed as part of a test suite
 	TestsToRun []string `env:"TESTS_TO_RUN" sect:"tests" yaml:"testsToRun"`
 
+	// FocusLabels is an expression over the labels of suites, ex. "informing && !storage". When set, the suites it
+	// matches are run instead of TestsToRun. Suites are labeled with their name, their class, and the labels their
+	// packages declare.
+	FocusLabels string `env:"FOCUS_LABELS" sect:"tests" yaml:"focusLabels"`
+
+	// InformingSuites is a comma-delimited list of the suites that are informing. Their failures are reported
+	// without failing the run. All other suites are blocking.
+	InformingSuites []string `env:"INFORMING_SUITES" sect:"tests" default:"[Suite: informing]" yaml:"informingSuites"`
+
+	// FailOnInforming fails the run when informing suites fail, for jobs that only run informing suites.
+	FailOnInforming bool `env:"FAIL_ON_INFORMING" sect:"tests" default:"false" yaml:"failOnInforming"`
+
+	// BlockingTimeout is the number of minutes the blocking suites may run for in each phase before the rest of them
+	// are skipped. If 0, there is no limit.
+	BlockingTimeout int `env:"BLOCKING_TIMEOUT" sect:"tests" default:"0" yaml:"blockingTimeout" validate:"range=0:"`
+
+	// InformingTimeout is the number of minutes the informing suites may run for in each phase before the rest of them
+	// are skipped. If 0, there is no limit.
+	InformingTimeout int `env:"INFORMING_TIMEOUT" sect:"tests" default:"0" yaml:"informingTimeout" validate:"range=0:"`
+
+	// DurationBudget is a YAML file mapping spec names to the number of seconds allotted to them. Specs taking longer
+	// than DurationBudgetFactor times their allotted time fail.
+	DurationBudget string `env:"DURATION_BUDGET" sect:"tests" yaml:"durationBudget"`
+
+	// DurationBudgetFactor is how many times its allotted time a spec in the duration budget may take, ex. 1.5.
+	DurationBudgetFactor float64 `env:"DURATION_BUDGET_FACTOR" sect:"tests" default:"1.5" yaml:"durationBudgetFactor" validate:"range=1:"`
+
+	// ChangedComponents is a comma-delimited list of components or images that changed, such as those in a payload diff.
+	// When set, only the suites impacted by them are run.
+	ChangedComponents []string `env:"CHANGED_COMPONENTS" sect:"tests" yaml:"changedComponents"`
+
+	// ImpactMapping is a YAML file mapping components to the suites they impact. The maintained mapping is used by default.
+	ImpactMapping string `env:"IMPACT_MAPPING" sect:"tests" yaml:"impactMapping"`
+
 	// SuppressSkipNotifications suppresses the notifications of skipped tests
 	SuppressSkipNotifications bool `env:"SUPPRESS_SKIP_NOTIFICATIONS" sect:"tests" default:"true" yaml:"suppressSkipNotifications"`
 
@@ -173,19 +565,99 @@ type TestConfig struct {
 	CleanRuns int `env:"CLEAN_RUNS" sect:"tests" yaml:"cleanRuns"`
 
 	// OperatorSkip is a comma-delimited list of operator names to ignore health checks from. ex. "insights,telemetry"
-	OperatorSkip string `env:"OPERATOR_SKIP" sect:"tests" default:"insights" yaml:"ginkgoFocus"`
+	OperatorSkip string `env:"OPERATOR_SKIP" sect:"tests" default:"insights" yaml:"operatorSkip" deprecatedYAML:"ginkgoFocus"`
+
+	// PhaseTimeout is the number of minutes each test phase may run before OCM calls made during it are cancelled. If 0, there is no deadline.
+	PhaseTimeout int `env:"PHASE_TIMEOUT" sect:"tests" default:"0" yaml:"phaseTimeout" validate:"range=0:"`
 
 	// SkipClusterHealthChecks skips the cluster health checks. Useful when developing against a running cluster.
 	SkipClusterHealthChecks bool `env:"SKIP_CLUSTER_HEALTH_CHECKS" sect:"tests" default:"false" yaml:"skipClusterHealthChecks"`
 
+	// HealthCheckSkip is a list of health checks to skip when waiting for the cluster to be healthy. ex. "pods,certs"
+	HealthCheckSkip []string `env:"HEALTHCHECK_SKIP" sect:"tests" yaml:"healthCheckSkip"`
+
+	// MaxClockSkew is how many seconds the clocks of nodes may differ from the reference clock, both osde2e's clock in
+	// the clock health check and the NTP time chrony tracks on each node.
+	MaxClockSkew float64 `env:"MAX_CLOCK_SKEW" sect:"tests" default:"2" yaml:"maxClockSkew" validate:"range=0:"`
+
+	// AlertGateTimeout is the number of minutes to wait after install for the cluster to have no critical alerts
+	// firing before tests start. If 0, alerts aren't waited on.
+	AlertGateTimeout int `env:"ALERT_GATE_TIMEOUT" sect:"tests" default:"15" yaml:"alertGateTimeout" validate:"range=0:"`
+
+	// AlertGateAllow are the names of critical alerts that don't hold up tests, ex. "ClusterOperatorDegraded".
+	AlertGateAllow []string `env:"ALERT_GATE_ALLOW" sect:"tests" yaml:"alertGateAllow"`
+
+	// DNSPropagationTimeout is the number of minutes to wait after install for the names of the cluster's API server and
+	// routes to resolve to the same addresses on every DNS resolver. If 0, propagation isn't waited for.
+	DNSPropagationTimeout int `env:"DNS_PROPAGATION_TIMEOUT" sect:"tests" default:"15" yaml:"dnsPropagationTimeout" validate:"range=0:"`
+
+	// DNSResolvers are the addresses of the public DNS resolvers propagation is checked on, on port 53 unless another
+	// port is given.
+	DNSResolvers []string `env:"DNS_RESOLVERS" sect:"tests" default:"8.8.8.8,1.1.1.1,9.9.9.9" yaml:"dnsResolvers"`
+
 	// UploadMetrics tells osde2e whether to try to upload to the S3 metrics bucket.
 	UploadMetrics bool `env:"UPLOAD_METRICS" sect:"metrics" default:"false" yaml:"uploadMetrics"`
 
 	// MetricsBucket is the bucket that metrics data will be uploaded to.
 	MetricsBucket string `env:"METRICS_BUCKET" sect:"metrics" default:"osde2e-metrics" yaml:"metricsBucket"`
 
+	// CanaryInterval is the number of seconds between canary probes of the cluster's availability. Probing runs for
+	// the whole run and records an availability timeline. Zero disables it.
+	CanaryInterval int `env:"CANARY_INTERVAL" sect:"tests" default:"15" yaml:"canaryInterval" validate:"range=0:"`
+
+	// CanaryNodeDrift adds a canary probe that fails while the number of Ready compute nodes differs from the number
+	// the provider says the cluster should have.
+	CanaryNodeDrift bool `env:"CANARY_NODE_DRIFT" sect:"tests" default:"true" yaml:"canaryNodeDrift"`
+
+	// FleetBaseline is a YAML snapshot of a typical fleet cluster. Clusters are compared against it to find anomalies.
+	FleetBaseline string `env:"FLEET_BASELINE" sect:"tests" yaml:"fleetBaseline"`
+
+	// KubeExpectations is a YAML file of the expected Kubernetes version, feature gates, and admission plugins of each OCP
+	// minor version. The maintained expectations are used by default.
+	KubeExpectations string `env:"KUBE_EXPECTATIONS" sect:"tests" yaml:"kubeExpectations"`
+
+	// AlertExpectations is a YAML file of the alerts that are expected to fire in each phase of each OCP minor
+	// version. The maintained expectations are used by default.
+	AlertExpectations string `env:"ALERT_EXPECTATIONS" sect:"tests" yaml:"alertExpectations"`
+
+	// PhaseRetries is how many times the run is retried on a new cluster when an install or upgrade fails because
+	// of the infrastructure rather than what is being tested. Only runs that create their cluster are retried.
+	PhaseRetries int `env:"PHASE_RETRIES" sect:"tests" default:"0" yaml:"phaseRetries"`
+
+	// NodeReservations is a YAML file of the expected kubelet resource reservations for each node role and instance
+	// type. The maintained reservations are used by default.
+	NodeReservations string `env:"NODE_RESERVATIONS" sect:"tests" yaml:"nodeReservations"`
+
 	// ServiceAccount defines what user the tests should run as. By default, osde2e uses system:admin
 	ServiceAccount string `env:"SERVICE_ACCOUNT" sect:"tests" yaml:"serviceAccount"`
+
+	// DisableHarnessHardening stops osde2e from applying a restrictive securityContext and egress NetworkPolicy to runner pods.
+	DisableHarnessHardening bool `env:"DISABLE_HARNESS_HARDENING" sect:"tests" default:"false" yaml:"disableHarnessHardening"`
+
+	// AttestationKey is the path to a file holding a PEM encoded PKCS8 private key used to sign an attestation of the results. Ed25519, ECDSA, and RSA keys are supported.
+	AttestationKey string `env:"ATTESTATION_KEY" sect:"tests" yaml:"attestationKey"`
+
+	// ArtifactEncryptionKeyring is a file of OpenPGP public keys. When set, artifacts other than the run metadata are
+	// replaced by a bundle encrypted for those keys before they're uploaded.
+	ArtifactEncryptionKeyring string `env:"ARTIFACT_ENCRYPTION_KEYRING" sect:"tests" yaml:"artifactEncryptionKeyring"`
+
+	// CompactArtifacts removes duplicate artifacts and compresses large text artifacts after the run, and writes an
+	// index of them.
+	CompactArtifacts bool `env:"COMPACT_ARTIFACTS" sect:"tests" default:"false" yaml:"compactArtifacts"`
+
+	// ArtifactCompressionThreshold is the size (in KiB) from which text artifacts are compressed when compacting them.
+	ArtifactCompressionThreshold int64 `env:"ARTIFACT_COMPRESSION_THRESHOLD" sect:"tests" default:"1024" yaml:"artifactCompressionThreshold"`
+
+	// HarnessEgressCIDRs is a comma-delimited list of CIDRs, such as artifact endpoints, that hardened runner pods may reach.
+	HarnessEgressCIDRs []string `env:"HARNESS_EGRESS_CIDRS" sect:"tests" yaml:"harnessEgressCIDRs"`
+
+	// ProgressEndpoint is where progress events are sent for CI frontends: a file, a udp://host:port address, or an
+	// http(s) URL.
+	ProgressEndpoint string `env:"PROGRESS_ENDPOINT" sect:"tests" yaml:"progressEndpoint"`
+
+	// ApplyPlan is a plan written by osde2e plan. When set, the run makes the operations of the plan against the
+	// provider instead of choosing its own.
+	ApplyPlan string `env:"APPLY_PLAN" sect:"tests" yaml:"applyPlan"`
 }
 
 // PrometheusConfig contains configs for connecting to a Prometheus instance for querying.
@@ -194,19 +666,87 @@ type PrometheusConfig struct {
 	Address string `env:"PROMETHEUS_ADDRESS" sect:"weather" yaml:"address"`
 
 	// BearerToken is the token needed for communicating with Prometheus.
-	BearerToken string `env:"PROMETHEUS_BEARER_TOKEN" sect:"weather" yaml:"bearerToken"`
+	BearerToken string `env:"PROMETHEUS_BEARER_TOKEN" sect:"weather" yaml:"bearerToken" secret:"true"`
+
+	// CABundle is a PEM file of certificate authorities Prometheus' certificate is verified with, in addition to the
+	// system's.
+	CABundle string `env:"PROMETHEUS_CA_BUNDLE" sect:"weather" yaml:"caBundle"`
+
+	// ClientCert and ClientKey are the PEM files of a certificate and key to authenticate to Prometheus with.
+	ClientCert string `env:"PROMETHEUS_CLIENT_CERT" sect:"weather" yaml:"clientCert"`
+	ClientKey  string `env:"PROMETHEUS_CLIENT_KEY" sect:"weather" yaml:"clientKey" secret:"true"`
+
+	// InsecureSkipVerify connects to Prometheus without verifying its certificate.
+	InsecureSkipVerify bool `env:"PROMETHEUS_INSECURE_SKIP_VERIFY" sect:"weather" default:"false" yaml:"insecureSkipVerify"`
+
+	// ThanosAddress is the address of a Thanos querier to query instead of Prometheus, for metrics kept in long-term
+	// storage after the Prometheus that scraped them, or the cluster it ran on, is gone.
+	ThanosAddress string `env:"THANOS_ADDRESS" sect:"weather" yaml:"thanosAddress" validate:"url"`
+
+	// ThanosTenant is the tenant whose metrics are queried from a multi-tenant Thanos.
+	ThanosTenant string `env:"THANOS_TENANT" sect:"weather" yaml:"thanosTenant"`
+
+	// ThanosTenantHeader is the header the tenant is sent in.
+	ThanosTenantHeader string `env:"THANOS_TENANT_HEADER" sect:"weather" default:"THANOS-TENANT" yaml:"thanosTenantHeader"`
+
+	// ThanosMaxSourceResolution is the coarsest resolution of downsampled data Thanos may answer with, such as 5m, 1h,
+	// or auto. Downsampled data makes queries over long windows cheaper. Only raw data is used if empty.
+	ThanosMaxSourceResolution string `env:"THANOS_MAX_SOURCE_RESOLUTION" sect:"weather" yaml:"thanosMaxSourceResolution"`
+}
+
+// GrafanaConfig configures the Grafana dashboards provisioned for the metrics osde2e pushes.
+type GrafanaConfig struct {
+	// URL is the address of the Grafana to provision dashboards in.
+	URL string `env:"GRAFANA_URL" sect:"grafana" yaml:"url" validate:"url"`
+
+	// Token is a Grafana API key allowed to edit dashboards.
+	Token string `env:"GRAFANA_TOKEN" sect:"grafana" yaml:"token" secret:"true"`
+
+	// Folder is the folder the dashboards are provisioned in. It is created if it doesn't exist.
+	Folder string `env:"GRAFANA_FOLDER" sect:"grafana" default:"osde2e" yaml:"folder"`
+
+	// Datasource is the name of the Prometheus datasource the pushed metrics are queried from.
+	Datasource string `env:"GRAFANA_DATASOURCE" sect:"grafana" default:"Prometheus" yaml:"datasource"`
+
+	// Jobs are regular expressions matching the jobs the dashboards show. Defaults to JOB_NAME, or every job if
+	// neither is set.
+	Jobs []string `env:"GRAFANA_JOBS" sect:"grafana" yaml:"jobs"`
+}
+
+// PushgatewayConfig configures pushing run-level metrics to a Prometheus Pushgateway.
+type PushgatewayConfig struct {
+	// URL is the address of the Pushgateway metrics are pushed to at the end of each phase. If empty, metrics aren't
+	// pushed.
+	URL string `env:"PUSHGATEWAY_URL" sect:"pushgateway" yaml:"url" validate:"url"`
+
+	// Token is sent as a bearer token to Pushgateways behind an authenticating proxy.
+	Token string `env:"PUSHGATEWAY_TOKEN" sect:"pushgateway" yaml:"token" secret:"true"`
+
+	// Job is the job label metrics are grouped under. Defaults to JOB_NAME, or osde2e if neither is set.
+	Job string `env:"PUSHGATEWAY_JOB" sect:"pushgateway" yaml:"job"`
+}
+
+// FleetConfig configures auditing existing clusters with the fleet command.
+type FleetConfig struct {
+	// SLOQueries are Prometheus queries, as name=query, that return results when a cluster misses an SLO.
+	// {{.ClusterID}} in a query is replaced with the ID of the cluster audited. Queries are sent to the configured
+	// Prometheus or Thanos querier. Queries containing commas must be set in a config file.
+	SLOQueries []string `env:"FLEET_SLO_QUERIES" sect:"fleet" yaml:"sloQueries"`
+
+	// Parallel is how many clusters are audited at once.
+	Parallel int `env:"FLEET_PARALLEL" sect:"fleet" default:"4" yaml:"parallel" validate:"range=1:"`
 }
 
 // WeatherConfig describes various config options for weather reports.
 type WeatherConfig struct {
 	// StartOfTimeWindowInHours is how many hours to look back through results.
-	StartOfTimeWindowInHours time.Duration `env:"START_OF_TIME_WINDOW_IN_HOURS" sect:"weather" default:"24" yaml:"startOfTimeWindowInHours"`
+	StartOfTimeWindowInHours int `env:"START_OF_TIME_WINDOW_IN_HOURS" sect:"weather" default:"24" yaml:"startOfTimeWindowInHours"`
 
 	// NumberOfSamplesNecessary is how many samples are necessary for generating a report.
 	NumberOfSamplesNecessary int `env:"NUMBER_OF_SAMPLES_NECESSARY" sect:"weather" default:"3" yaml:"numberOfSamplesNecessary"`
 
 	// SlackWebhook is the webhook to use to post the weather report to slack.
-	SlackWebhook string `env:"SLACK_WEBHOOK" sect:"weather" yaml:"slackWebhook"`
+	SlackWebhook string `env:"SLACK_WEBHOOK" sect:"weather" yaml:"slackWebhook" secret:"true"`
 
 	// JobWhitelist is a list of job regexes to consider in the weather report.
 	JobWhitelist []string `env:"JOB_WHITELIST" sect:"weather" default:"osde2e-.*-aws-e2e-.*" yaml:"jobWhitelist"`
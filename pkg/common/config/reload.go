@@ -0,0 +1,40 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/openshift/osde2e/pkg/common/load"
+)
+
+// ChangeCallback is called after Instance is replaced, with the previous and current config.
+type ChangeCallback func(previous, current Config)
+
+var (
+	changeMutex     sync.Mutex
+	changeCallbacks []ChangeCallback
+)
+
+// OnChange registers a callback for subsystems that hold on to state derived from the config, such as clients, so
+// they can update it when the config is reloaded.
+func OnChange(callback ChangeCallback) {
+	changeMutex.Lock()
+	defer changeMutex.Unlock()
+	changeCallbacks = append(changeCallbacks, callback)
+}
+
+// Replace swaps the contents of Instance for a reloaded config in one step, then calls the change callbacks. The
+// config is copied into Instance rather than replacing the pointer, since config extensions are registered for it.
+// The extensions cloned for the reloaded config, if any, are copied into those of Instance at the same time.
+// Long-running commands should call Replace between units of work, so that no work sees a mix of both configs.
+func Replace(reloaded *Config) {
+	changeMutex.Lock()
+	previous := *Instance
+	*Instance = *reloaded
+	load.MoveExtensions(reloaded, Instance)
+	callbacks := append([]ChangeCallback{}, changeCallbacks...)
+	changeMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback(previous, *Instance)
+	}
+}
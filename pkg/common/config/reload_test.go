@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestReplace(t *testing.T) {
+	defer func(c Config, callbacks []ChangeCallback) {
+		*Instance, changeCallbacks = c, callbacks
+	}(*Instance, changeCallbacks)
+
+	instance := Instance
+	Instance.Weather.NumberOfSamplesNecessary = 3
+
+	var previous, current Config
+	OnChange(func(p, c Config) {
+		previous, current = p, c
+	})
+
+	reloaded := new(Config)
+	reloaded.Weather.NumberOfSamplesNecessary = 5
+	Replace(reloaded)
+
+	if Instance != instance {
+		t.Errorf("expected Instance to be updated in place, so config extensions stay registered")
+	}
+	if Instance.Weather.NumberOfSamplesNecessary != 5 {
+		t.Errorf("expected the reloaded config, got %d samples", Instance.Weather.NumberOfSamplesNecessary)
+	}
+	if previous.Weather.NumberOfSamplesNecessary != 3 || current.Weather.NumberOfSamplesNecessary != 5 {
+		t.Errorf("expected callbacks to get the previous and current config, got %d and %d samples",
+			previous.Weather.NumberOfSamplesNecessary, current.Weather.NumberOfSamplesNecessary)
+	}
+}
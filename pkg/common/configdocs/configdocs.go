@@ -0,0 +1,287 @@
+// Package configdocs documents config options from the struct tags that declare them, as a Markdown reference and
+// a JSON Schema of the YAML config, so the documentation can't drift from the options.
+package configdocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/load"
+)
+
+// schemaVersion is the JSON Schema draft the schema is written in.
+const schemaVersion = "http://json-schema.org/draft-07/schema#"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Markdown writes a table of every option, grouped by documentation section in the order sections are declared.
+func Markdown(w io.Writer, options []load.Option) error {
+	var sections []string
+	bySection := map[string][]load.Option{}
+	for _, option := range options {
+		section := sectionOf(option)
+		if _, ok := bySection[section]; !ok {
+			sections = append(sections, section)
+		}
+		bySection[section] = append(bySection[section], option)
+	}
+
+	fmt.Fprintln(w, "# Config options")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "<!-- Generated by `osde2e docs config`. Do not edit. -->")
+	for _, section := range sections {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s\n\n", section)
+		fmt.Fprintln(w, "| Option | Environment variable | Type | Default | Notes |")
+		fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+		for _, option := range bySection[section] {
+			fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n",
+				option.Key, code(option.Env), typeName(option.Type), code(defaultValue(option)), notes(option))
+		}
+	}
+	return nil
+}
+
+// sectionOf returns the documentation section of an option, which defaults to its top-level YAML key.
+func sectionOf(option load.Option) string {
+	if option.Section != "" {
+		return option.Section
+	}
+	return strings.Split(option.Key, ".")[0]
+}
+
+// code formats a value as inline code, escaping pipes so they don't end the table cell.
+func code(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + strings.Replace(value, "|", `\|`, -1) + "`"
+}
+
+// defaultValue describes the default of an option, including defaults generated when the option is loaded.
+func defaultValue(option load.Option) string {
+	switch {
+	case !option.HasDefault:
+		return ""
+	case option.Default == "__TMP_DIR__":
+		return "a temporary directory"
+	case strings.HasPrefix(option.Default, "__RND_"):
+		return "a random string"
+	}
+	return option.Default
+}
+
+// notes describes the rules, secrecy, and deprecated environment variables of an option.
+func notes(option load.Option) string {
+	var notes []string
+	for _, rule := range option.Rules {
+		notes = append(notes, code(rule))
+	}
+	if option.Secret {
+		notes = append(notes, "secret, may be a reference to a secrets backend")
+	}
+	if len(option.DeprecatedEnv) > 0 {
+		notes = append(notes, "deprecated: "+code(strings.Join(option.DeprecatedEnv, ", ")))
+	}
+	return strings.Join(notes, "; ")
+}
+
+// typeName describes the type of an option.
+func typeName(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Slice, reflect.Array:
+		return "list of " + typeName(t.Elem())
+	case reflect.Map:
+		return "map of " + typeName(t.Elem())
+	case reflect.Struct:
+		return "object"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// JSONSchema returns a JSON Schema of YAML configs setting the options, for validation and completion in editors.
+func JSONSchema(options []load.Option) ([]byte, error) {
+	root := objectSchema()
+	root["$schema"] = schemaVersion
+	root["title"] = "osde2e config"
+	root["properties"].(map[string]interface{})[load.ProfilesKey] = map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "string"},
+		"description": "Profiles merged before this config, in order.",
+	}
+
+	for _, option := range options {
+		keys := strings.Split(option.Key, ".")
+		parent := root
+		for _, key := range keys[:len(keys)-1] {
+			properties := parent["properties"].(map[string]interface{})
+			child, ok := properties[key].(map[string]interface{})
+			if !ok {
+				child = objectSchema()
+				properties[key] = child
+			}
+			parent = child
+		}
+		parent["properties"].(map[string]interface{})[keys[len(keys)-1]] = optionSchema(option)
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// objectSchema returns the schema of an object whose properties are added later.
+func objectSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// optionSchema returns the schema of an option, including its default, rules, and environment variable.
+func optionSchema(option load.Option) map[string]interface{} {
+	schema := typeSchema(option.Type)
+
+	var description []string
+	if option.Env != "" {
+		description = append(description, fmt.Sprintf("Set by the %s environment variable.", option.Env))
+	}
+	if option.Secret {
+		description = append(description, "Secret, may be a reference to a secrets backend.")
+	}
+	if len(description) > 0 {
+		schema["description"] = strings.Join(description, " ")
+	}
+
+	if value, ok := typedDefault(option); ok {
+		schema["default"] = value
+	}
+
+	target := schema
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		// rules of list options apply to every item
+		target = items
+	}
+	for _, rule := range option.Rules {
+		applyRule(target, rule)
+	}
+	return schema
+}
+
+// typeSchema returns the schema of a Go type as it is written in YAML.
+func typeSchema(t reflect.Type) map[string]interface{} {
+	if t == durationType {
+		// durations can be written as strings, ex. 45m, or as nanoseconds
+		return map[string]interface{}{"type": []string{"string", "integer"}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		schema := objectSchema()
+		properties := schema["properties"].(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if f.PkgPath != "" || key == "-" {
+				continue
+			}
+			if key == "" {
+				key = f.Name
+			}
+			properties[key] = typeSchema(f.Type)
+		}
+		return schema
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// typedDefault converts the default of an option to its type. Defaults generated when the option is loaded, such
+// as temporary directories, aren't included.
+func typedDefault(option load.Option) (interface{}, bool) {
+	if !option.HasDefault || option.Default == "__TMP_DIR__" || strings.HasPrefix(option.Default, "__RND_") {
+		return nil, false
+	}
+	if option.Type == durationType {
+		return option.Default, true
+	}
+
+	var value interface{}
+	var err error
+	switch option.Type.Kind() {
+	case reflect.Bool:
+		value, err = strconv.ParseBool(option.Default)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err = strconv.ParseInt(option.Default, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		value, err = strconv.ParseFloat(option.Default, 64)
+	case reflect.Slice:
+		items := []string{}
+		if option.Default != "" {
+			items = strings.Split(option.Default, ",")
+		}
+		value = items
+	case reflect.Map:
+		pairs := map[string]string{}
+		for _, pair := range strings.Split(option.Default, ",") {
+			if kv := strings.SplitN(pair, "=", 2); len(kv) == 2 {
+				pairs[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+		value = pairs
+	default:
+		value = option.Default
+	}
+	return value, err == nil
+}
+
+// applyRule adds the schema keywords equivalent to a validation rule.
+func applyRule(schema map[string]interface{}, rule string) {
+	name, arg := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		name, arg = rule[:i], rule[i+1:]
+	}
+
+	switch name {
+	case "oneof":
+		schema["enum"] = strings.Fields(arg)
+	case "range":
+		bounds := strings.SplitN(arg, ":", 2)
+		for i, keyword := range []string{"minimum", "maximum"} {
+			if i >= len(bounds) || bounds[i] == "" {
+				continue
+			}
+			if bound, err := strconv.ParseFloat(bounds[i], 64); err == nil {
+				schema[keyword] = bound
+			}
+		}
+	case "url":
+		schema["format"] = "uri"
+	}
+}
@@ -0,0 +1,107 @@
+package configdocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/load"
+)
+
+type docsTestConfig struct {
+	Provider string `env:"DOCS_TEST_PROVIDER" sect:"tests" default:"ocm" yaml:"provider" validate:"oneof=ocm mock"`
+	Cluster  struct {
+		Nodes   int           `env:"DOCS_TEST_NODES" sect:"cluster" default:"3" yaml:"nodes" validate:"range=1:10"`
+		Timeout time.Duration `env:"DOCS_TEST_TIMEOUT" sect:"cluster" default:"45m" yaml:"timeout"`
+		Labels  []string      `env:"DOCS_TEST_LABELS" sect:"cluster" default:"a,b" yaml:"labels"`
+	} `yaml:"cluster"`
+	Token     string `env:"DOCS_TEST_TOKEN" sect:"tests" yaml:"token" secret:"true" deprecatedEnv:"DOCS_TEST_OLD_TOKEN"`
+	ReportDir string `env:"DOCS_TEST_REPORT_DIR" sect:"tests" default:"__TMP_DIR__" yaml:"reportDir"`
+}
+
+type docsTestExtension struct {
+	Retries int `env:"DOCS_TEST_RETRIES" default:"5" yaml:"retries"`
+}
+
+func docsTestOptions() []load.Option {
+	cfg := &docsTestConfig{}
+	load.RegisterExtension(cfg, "cluster", &docsTestExtension{})
+	return load.Options(cfg)
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Markdown(&buf, docsTestOptions()); err != nil {
+		t.Fatalf("failed to write Markdown: %v", err)
+	}
+	doc := buf.String()
+
+	for _, expected := range []string{
+		"## tests\n",
+		"| `provider` | `DOCS_TEST_PROVIDER` | string | `ocm` | `oneof=ocm mock` |",
+		"| `cluster.timeout` | `DOCS_TEST_TIMEOUT` | duration | `45m` |  |",
+		"| `cluster.labels` | `DOCS_TEST_LABELS` | list of string | `a,b` |  |",
+		"| `token` | `DOCS_TEST_TOKEN` | string |  | secret, may be a reference to a secrets backend; deprecated: `DOCS_TEST_OLD_TOKEN` |",
+		"| `reportDir` | `DOCS_TEST_REPORT_DIR` | string | `a temporary directory` |  |",
+		"| `cluster.retries` | `DOCS_TEST_RETRIES` | int | `5` |  |",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected Markdown to contain %q, got:\n%s", expected, doc)
+		}
+	}
+
+	if strings.Index(doc, "## tests") > strings.Index(doc, "## cluster") {
+		t.Errorf("expected sections in the order they're declared, got:\n%s", doc)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema(docsTestOptions())
+	if err != nil {
+		t.Fatalf("failed to generate schema: %v", err)
+	}
+
+	schema := map[string]interface{}{}
+	if err = json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	property := func(path ...string) map[string]interface{} {
+		current := schema
+		for _, key := range path {
+			current, _ = current["properties"].(map[string]interface{})[key].(map[string]interface{})
+			if current == nil {
+				t.Fatalf("schema has no property %s", strings.Join(path, "."))
+			}
+		}
+		return current
+	}
+
+	if provider := property("provider"); !reflect.DeepEqual(provider["enum"], []interface{}{"ocm", "mock"}) || provider["default"] != "ocm" {
+		t.Errorf("unexpected provider schema %v", provider)
+	}
+
+	nodes := property("cluster", "nodes")
+	if nodes["type"] != "integer" || nodes["default"] != 3.0 || nodes["minimum"] != 1.0 || nodes["maximum"] != 10.0 {
+		t.Errorf("unexpected nodes schema %v", nodes)
+	}
+
+	if labels := property("cluster", "labels"); labels["type"] != "array" || !reflect.DeepEqual(labels["default"], []interface{}{"a", "b"}) {
+		t.Errorf("unexpected labels schema %v", labels)
+	}
+
+	if retries := property("cluster", "retries"); retries["description"] != "Set by the DOCS_TEST_RETRIES environment variable." {
+		t.Errorf("expected extension options to be merged into their section, got %v", retries)
+	}
+
+	if reportDir := property("reportDir"); reportDir["default"] != nil {
+		t.Errorf("expected generated defaults to be left out, got %v", reportDir["default"])
+	}
+
+	if profiles := property(load.ProfilesKey); profiles["type"] != "array" {
+		t.Errorf("expected the profiles key to be described, got %v", profiles)
+	}
+}
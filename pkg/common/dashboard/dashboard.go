@@ -0,0 +1,291 @@
+// Package dashboard shows the progress of a run and the health of its cluster live in a terminal.
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	configclient "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/progress"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// maxEvents is the number of recent events shown.
+	maxEvents = 10
+
+	// barWidth is the width of the progress bars of test phases.
+	barWidth = 30
+
+	// redrawInterval is how often the dashboard is redrawn.
+	redrawInterval = time.Second
+
+	// healthInterval is how often the cluster's health is checked.
+	healthInterval = 30 * time.Second
+
+	// clearScreen moves the cursor home and clears the terminal.
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// phase is the progress of a phase of the run.
+type phase struct {
+	name  string
+	state string
+	event progress.Event
+}
+
+// Dashboard tracks the progress of a run from its progress events.
+type Dashboard struct {
+	mutex sync.Mutex
+
+	logFile string
+	started time.Time
+	cluster string
+
+	phases      []*phase
+	spec        string
+	specStarted time.Time
+	events      []string
+
+	health        *Health
+	healthErr     error
+	healthChecked time.Time
+}
+
+// New creates a dashboard for a run started at a time, whose log is written to logFile.
+func New(logFile string, started time.Time) *Dashboard {
+	return &Dashboard{logFile: logFile, started: started}
+}
+
+// Observe updates the dashboard with a progress event.
+func (d *Dashboard) Observe(event progress.Event) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	p := d.phase(event.Phase)
+	switch event.Type {
+	case progress.PhaseStarted:
+		p.state = "running"
+		p.event = event
+		d.addEvent(event.Time, "%s started", event.Phase)
+	case progress.SpecStarted:
+		d.spec, d.specStarted = event.Spec, event.Time
+	case progress.SpecCompleted:
+		p.event = event
+		d.spec = ""
+		if event.State != progress.Passed {
+			d.addEvent(event.Time, "%s %s: %s", event.Phase, event.State, event.Spec)
+		}
+	case progress.PhaseEnded:
+		p.state = event.State
+		if event.Total > 0 {
+			p.event = event
+		}
+		d.spec = ""
+		d.addEvent(event.Time, "%s %s", event.Phase, event.State)
+	}
+}
+
+// SetCluster describes the cluster under test.
+func (d *Dashboard) SetCluster(description string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.cluster = description
+}
+
+// SetHealth records the result of checking the cluster's health.
+func (d *Dashboard) SetHealth(health *Health, err error, checked time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.health, d.healthErr, d.healthChecked = health, err, checked
+}
+
+// phase returns the progress of a phase, adding it if it hasn't been seen.
+func (d *Dashboard) phase(name string) *phase {
+	for _, p := range d.phases {
+		if p.name == name {
+			return p
+		}
+	}
+	p := &phase{name: name}
+	d.phases = append(d.phases, p)
+	return p
+}
+
+// addEvent adds a line to the recent events, dropping the oldest once there are too many.
+func (d *Dashboard) addEvent(at time.Time, format string, args ...interface{}) {
+	d.events = append(d.events, at.Format("15:04:05")+" "+fmt.Sprintf(format, args...))
+	if len(d.events) > maxEvents {
+		d.events = d.events[len(d.events)-maxEvents:]
+	}
+}
+
+// Render draws the dashboard as text no wider than width.
+func (d *Dashboard) Render(width int, now time.Time) string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	cluster := d.cluster
+	if cluster == "" {
+		cluster = "no cluster yet"
+	}
+	add("osde2e - %s - running for %s", cluster, now.Sub(d.started).Round(time.Second))
+	add("Log: %s", d.logFile)
+	add("")
+
+	add("Phases")
+	if len(d.phases) == 0 {
+		add("  waiting for the first phase")
+	}
+	for _, p := range d.phases {
+		line := fmt.Sprintf("  %-10s %-8s", p.name, p.state)
+		if e := p.event; e.Total > 0 {
+			filled := barWidth * e.Completed / e.Total
+			line += fmt.Sprintf(" [%s%s] %d/%d  %d passed  %d failed  %d skipped",
+				strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled), e.Completed, e.Total, e.Passed, e.Failed, e.Skipped)
+		}
+		add("%s", strings.TrimRight(line, " "))
+	}
+	add("")
+
+	add("Current spec")
+	if d.spec == "" {
+		add("  none")
+	} else {
+		add("  %s (%s)", d.spec, now.Sub(d.specStarted).Round(time.Second))
+	}
+	add("")
+
+	switch {
+	case d.healthChecked.IsZero():
+		add("Cluster health")
+		add("  not checked yet")
+	case d.healthErr != nil:
+		add("Cluster health (checked %s ago)", now.Sub(d.healthChecked).Round(time.Second))
+		add("  %v", d.healthErr)
+	default:
+		h := d.health
+		add("Cluster health (checked %s ago)", now.Sub(d.healthChecked).Round(time.Second))
+		add("  Nodes: %d/%d ready  Operators: %d/%d available  Unhealthy openshift pods: %d",
+			h.ReadyNodes, h.Nodes, h.AvailableOperators, h.Operators, h.UnhealthyPods)
+		if len(h.DegradedOperators) > 0 {
+			add("  Degraded: %s", strings.Join(h.DegradedOperators, ", "))
+		}
+	}
+	add("")
+
+	add("Recent events")
+	if len(d.events) == 0 {
+		add("  none")
+	}
+	for _, event := range d.events {
+		add("  %s", event)
+	}
+
+	for i, line := range lines {
+		if runes := []rune(line); width > 0 && len(runes) > width {
+			lines[i] = string(runes[:width])
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Show subscribes the dashboard to progress events and redraws it on out until the returned function is called, which
+// draws it a last time. width returns the width of the terminal. The cluster under test and its health are read from
+// the state.
+func (d *Dashboard) Show(out io.Writer, width func() int) func() {
+	unsubscribe := progress.Subscribe(d.Observe)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		redraw := time.NewTicker(redrawInterval)
+		defer redraw.Stop()
+
+		checkingHealth := false
+		healthDone := make(chan struct{}, 1)
+		for {
+			d.SetCluster(describeCluster())
+
+			if !checkingHealth && time.Since(d.lastHealthCheck()) >= healthInterval && len(state.Instance.Kubeconfig.Contents) > 0 {
+				checkingHealth = true
+				go func(kubeconfig []byte) {
+					health, err := checkHealth(kubeconfig)
+					d.SetHealth(health, err, time.Now())
+					healthDone <- struct{}{}
+				}(state.Instance.Kubeconfig.Contents)
+			}
+
+			fmt.Fprint(out, clearScreen+d.Render(width(), time.Now()))
+
+			select {
+			case <-done:
+				return
+			case <-healthDone:
+				checkingHealth = false
+			case <-redraw.C:
+			}
+		}
+	}()
+
+	return func() {
+		unsubscribe()
+		close(done)
+		<-stopped
+		fmt.Fprint(out, clearScreen+d.Render(width(), time.Now()))
+	}
+}
+
+// lastHealthCheck is when the cluster's health was last checked.
+func (d *Dashboard) lastHealthCheck() time.Time {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.healthChecked
+}
+
+// describeCluster describes the cluster under test from the state.
+func describeCluster() string {
+	cluster := state.Instance.Cluster
+	if cluster.ID == "" {
+		return ""
+	}
+
+	description := cluster.Name + " (" + cluster.ID + ")"
+	if cluster.Version != "" {
+		description += " " + cluster.Version
+	}
+	return description
+}
+
+// checkHealth checks the health of the cluster a kubeconfig is for.
+func checkHealth(kubeconfig []byte) (*Health, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error generating rest config: %v", err)
+	}
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kube client: %v", err)
+	}
+
+	configClient, err := configclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating config client: %v", err)
+	}
+
+	return CheckHealth(kube, configClient)
+}
@@ -0,0 +1,110 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	fakeConfig "github.com/openshift/client-go/config/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift/osde2e/pkg/common/progress"
+)
+
+func TestRender(t *testing.T) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return started.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	d := New("/tmp/osde2e.log", started)
+	d.SetCluster("ci-cluster-abc (abc) openshift-v4.5.1")
+	d.Observe(progress.Event{Time: at(1), Type: progress.PhaseStarted, Phase: "install", Total: 4})
+	d.Observe(progress.Event{Time: at(2), Type: progress.SpecCompleted, Phase: "install", Spec: "Pods should be healthy", State: progress.Passed, Total: 4, Completed: 1, Passed: 1})
+	d.Observe(progress.Event{Time: at(3), Type: progress.SpecCompleted, Phase: "install", Spec: "Routes should be reachable", State: progress.Failed, Total: 4, Completed: 2, Passed: 1, Failed: 1})
+	d.Observe(progress.Event{Time: at(4), Type: progress.SpecStarted, Phase: "install", Spec: "Nodes should be ready"})
+	d.SetHealth(&Health{Nodes: 6, ReadyNodes: 5, Operators: 30, AvailableOperators: 29, DegradedOperators: []string{"monitoring"}}, nil, at(4))
+
+	expected := `osde2e - ci-cluster-abc (abc) openshift-v4.5.1 - running for 5m0s
+Log: /tmp/osde2e.log
+
+Phases
+  install    running  [###############---------------] 2/4  1 passed  1 failed  0 skipped
+
+Current spec
+  Nodes should be ready (1m0s)
+
+Cluster health (checked 1m0s ago)
+  Nodes: 5/6 ready  Operators: 29/30 available  Unhealthy openshift pods: 0
+  Degraded: monitoring
+
+Recent events
+  12:01:00 install started
+  12:03:00 install failed: Routes should be reachable
+`
+	if rendered := d.Render(0, at(5)); rendered != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, rendered)
+	}
+
+	d.Observe(progress.Event{Time: at(6), Type: progress.PhaseEnded, Phase: "install", State: progress.Failed, Total: 4, Completed: 2, Passed: 1, Failed: 1})
+	d.SetHealth(nil, fmt.Errorf("error getting node list: connection refused"), at(6))
+	for i := 0; i < maxEvents; i++ {
+		d.Observe(progress.Event{Time: at(7), Type: progress.PhaseStarted, Phase: "teardown"})
+	}
+
+	rendered := d.Render(40, at(8))
+	if !strings.Contains(rendered, "Current spec\n  none\n") || !strings.Contains(rendered, "  error getting node list: connection re\n") {
+		t.Errorf("expected the spec to be cleared and lines to be cut to the width, got:\n%s", rendered)
+	}
+	if strings.Contains(rendered, "install started") || strings.Count(rendered, "teardown started") != maxEvents {
+		t.Errorf("expected only the most recent events, got:\n%s", rendered)
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+	pod := func(namespace, name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	operator := func(name string, available, degraded configv1.ConditionStatus) *configv1.ClusterOperator {
+		return &configv1.ClusterOperator{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: configv1.ClusterOperatorStatus{Conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: available},
+				{Type: configv1.OperatorDegraded, Status: degraded},
+			}},
+		}
+	}
+
+	kube := fake.NewSimpleClientset(
+		node("master-0", corev1.ConditionTrue),
+		node("worker-0", corev1.ConditionFalse),
+		pod("openshift-monitoring", "prometheus-0", corev1.PodRunning),
+		pod("openshift-monitoring", "alertmanager-0", corev1.PodPending),
+		pod("openshift-etcd", "installer-1", corev1.PodSucceeded),
+		pod("osde2e-abc", "test", corev1.PodFailed),
+	)
+	configClient := fakeConfig.NewSimpleClientset(
+		operator("dns", configv1.ConditionTrue, configv1.ConditionFalse),
+		operator("monitoring", configv1.ConditionFalse, configv1.ConditionTrue),
+	)
+
+	health, err := CheckHealth(kube, configClient.ConfigV1())
+	if err != nil {
+		t.Fatalf("failed to check health: %v", err)
+	}
+
+	if health.Nodes != 2 || health.ReadyNodes != 1 || health.Operators != 2 || health.AvailableOperators != 1 ||
+		len(health.DegradedOperators) != 1 || health.DegradedOperators[0] != "monitoring" || health.UnhealthyPods != 1 {
+		t.Errorf("unexpected health: %+v", health)
+	}
+}
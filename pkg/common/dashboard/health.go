@@ -0,0 +1,81 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configclient "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Health summarizes the health of a cluster.
+type Health struct {
+	Nodes      int
+	ReadyNodes int
+
+	Operators          int
+	AvailableOperators int
+
+	// DegradedOperators are the names of the cluster operators that are degraded.
+	DegradedOperators []string
+
+	// UnhealthyPods is the number of pods in openshift namespaces that are neither running nor completed.
+	UnhealthyPods int
+}
+
+// CheckHealth summarizes the health of the cluster's nodes, cluster operators, and openshift pods.
+func CheckHealth(kube kubernetes.Interface, configClient configclient.ConfigV1Interface) (*Health, error) {
+	health := &Health{}
+
+	nodes, err := kube.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting node list: %v", err)
+	}
+	for _, node := range nodes.Items {
+		health.Nodes++
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				health.ReadyNodes++
+			}
+		}
+	}
+
+	operators, err := configClient.ClusterOperators().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster operator list: %v", err)
+	}
+	for _, co := range operators.Items {
+		health.Operators++
+		for _, condition := range co.Status.Conditions {
+			if condition.Status != configv1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case configv1.OperatorAvailable:
+				health.AvailableOperators++
+			case configv1.OperatorDegraded:
+				health.DegradedOperators = append(health.DegradedOperators, co.Name)
+			}
+		}
+	}
+	sort.Strings(health.DegradedOperators)
+
+	pods, err := kube.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting pod list: %v", err)
+	}
+	for _, pod := range pods.Items {
+		if !strings.HasPrefix(pod.Namespace, "openshift-") {
+			continue
+		}
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodSucceeded {
+			health.UnhealthyPods++
+		}
+	}
+
+	return health, nil
+}
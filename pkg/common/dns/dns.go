@@ -0,0 +1,198 @@
+// Package dns waits for the names of a cluster to propagate to public resolvers, so specs reaching its API server and
+// routes don't fail while resolvers still don't know them.
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// appsCheckLabel is the label of the name looked up under the apps domain. Routes are served from a wildcard
+	// record, so any name under it resolves once the record has propagated.
+	appsCheckLabel = "osde2e-dns-check"
+
+	// lookupTimeout is how long a server has to answer a lookup.
+	lookupTimeout = 10 * time.Second
+)
+
+// Resolver looks up the addresses of hosts. *net.Resolver is a Resolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Server is a resolver and the name it is reported by.
+type Server struct {
+	Name     string
+	Resolver Resolver
+}
+
+// Servers returns servers querying the DNS servers at the addresses, on port 53 unless another port is given.
+func Servers(addresses []string) []Server {
+	var servers []Server
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, "53")
+		}
+		servers = append(servers, Server{Name: address, Resolver: newResolver(address)})
+	}
+	return servers
+}
+
+// newResolver returns a resolver sending every query to the DNS server at address rather than the system's.
+func newResolver(address string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// Host is a name of a cluster that must resolve before tests start.
+type Host struct {
+	// Name is what the host is reported as, such as api or apps.
+	Name string
+
+	// Hostname is the name looked up.
+	Hostname string
+}
+
+// ClusterHosts returns the hosts of the cluster's API server and of its routes, found from the URL of its API server.
+func ClusterHosts(apiURL string) ([]Host, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse API URL %q: %v", apiURL, err)
+	}
+
+	apiHost := u.Hostname()
+	if !strings.HasPrefix(apiHost, "api.") {
+		return nil, fmt.Errorf("API URL %q isn't under the cluster's domain", apiURL)
+	}
+	domain := strings.TrimPrefix(apiHost, "api.")
+
+	return []Host{
+		{Name: "api", Hostname: apiHost},
+		{Name: "apps", Hostname: appsCheckLabel + ".apps." + domain},
+	}, nil
+}
+
+// Propagation is how long a host took to resolve on every server. Servers may still answer with different addresses,
+// such as when a record has several addresses and each server returns some of them, so what each server answered is
+// kept in Mismatched when they differ.
+type Propagation struct {
+	Host       Host
+	Seconds    float64
+	Addresses  []string
+	Mismatched []string
+}
+
+// WaitForPropagation looks up the hosts on every server each interval until each of them resolves on all of the
+// servers, or ctx is done. It returns how long each host that propagated took, and an error describing the answers
+// for the hosts that didn't.
+func WaitForPropagation(ctx context.Context, servers []Server, hosts []Host, interval time.Duration) ([]Propagation, error) {
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no DNS servers to check propagation on")
+	}
+
+	started := time.Now()
+	pending := append([]Host{}, hosts...)
+	answers := map[string][]string{}
+	var propagations []Propagation
+	for {
+		var remaining []Host
+		for _, host := range pending {
+			result := lookup(ctx, servers, host.Hostname)
+			if result.resolved {
+				propagation := Propagation{Host: host, Seconds: time.Since(started).Seconds(), Addresses: result.addresses}
+				if !result.consistent {
+					propagation.Mismatched = result.answers
+				}
+				propagations = append(propagations, propagation)
+				continue
+			}
+			answers[host.Hostname] = result.answers
+			remaining = append(remaining, host)
+		}
+		if pending = remaining; len(pending) == 0 {
+			return propagations, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var msgs []string
+			for _, host := range pending {
+				msgs = append(msgs, fmt.Sprintf("%s (%s)", host.Hostname, strings.Join(answers[host.Hostname], ", ")))
+			}
+			return propagations, fmt.Errorf("DNS hasn't propagated for %s", strings.Join(msgs, "; "))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// lookupResult is what the servers answered for a host.
+type lookupResult struct {
+	// addresses are the addresses the first server returned.
+	addresses []string
+
+	// resolved is true if every server returned addresses, and consistent if they all returned the same ones.
+	resolved   bool
+	consistent bool
+
+	// answers describe what each server answered.
+	answers []string
+}
+
+// lookup resolves the host on every server.
+func lookup(ctx context.Context, servers []Server, host string) lookupResult {
+	result := lookupResult{
+		resolved:   true,
+		consistent: true,
+		answers:    make([]string, 0, len(servers)),
+	}
+	for _, server := range servers {
+		lookupCtx, cancel := context.WithTimeout(ctx, lookupTimeout)
+		addresses, err := server.Resolver.LookupHost(lookupCtx, host)
+		cancel()
+		if err != nil || len(addresses) == 0 {
+			result.resolved = false
+			if err != nil {
+				result.answers = append(result.answers, fmt.Sprintf("%s failed: %v", server.Name, err))
+			} else {
+				result.answers = append(result.answers, fmt.Sprintf("%s returned no addresses", server.Name))
+			}
+			continue
+		}
+
+		sort.Strings(addresses)
+		result.answers = append(result.answers, fmt.Sprintf("%s returned %v", server.Name, addresses))
+		if result.addresses == nil {
+			result.addresses = addresses
+		} else if !equal(result.addresses, addresses) {
+			result.consistent = false
+		}
+	}
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
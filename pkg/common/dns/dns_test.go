@@ -0,0 +1,132 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers with the addresses of each host, which can change between lookups.
+type fakeResolver struct {
+	mutex     sync.Mutex
+	addresses map[string][]string
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if addresses, ok := r.addresses[host]; ok {
+		return append([]string{}, addresses...), nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (r *fakeResolver) set(host string, addresses ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.addresses[host] = addresses
+}
+
+func TestClusterHosts(t *testing.T) {
+	hosts, err := ClusterHosts("https://api.osde2e-abc.x1y2.p1.openshiftapps.com:6443")
+	if err != nil {
+		t.Fatalf("failed to find the hosts: %v", err)
+	}
+
+	expected := []Host{
+		{Name: "api", Hostname: "api.osde2e-abc.x1y2.p1.openshiftapps.com"},
+		{Name: "apps", Hostname: "osde2e-dns-check.apps.osde2e-abc.x1y2.p1.openshiftapps.com"},
+	}
+	if len(hosts) != len(expected) || hosts[0] != expected[0] || hosts[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, hosts)
+	}
+
+	if _, err = ClusterHosts("https://10.0.0.1:6443"); err == nil {
+		t.Errorf("expected an API URL outside the cluster's domain to be an error")
+	}
+}
+
+func TestWaitForPropagation(t *testing.T) {
+	google := &fakeResolver{addresses: map[string][]string{"api.example.com": {"10.0.0.2", "10.0.0.1"}}}
+	cloudflare := &fakeResolver{addresses: map[string][]string{"api.example.com": {"10.0.0.1", "10.0.0.2"}}}
+	servers := []Server{{Name: "google", Resolver: google}, {Name: "cloudflare", Resolver: cloudflare}}
+	hosts := []Host{{Name: "api", Hostname: "api.example.com"}, {Name: "apps", Hostname: "x.apps.example.com"}}
+
+	// the apps record reaches one resolver, then the other
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		google.set("x.apps.example.com", "10.0.1.1")
+		time.Sleep(20 * time.Millisecond)
+		cloudflare.set("x.apps.example.com", "10.0.1.1")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	propagations, err := WaitForPropagation(ctx, servers, hosts, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed waiting for propagation: %v", err)
+	}
+
+	if len(propagations) != 2 {
+		t.Fatalf("expected both hosts to propagate, got %v", propagations)
+	}
+	if api := propagations[0]; api.Host.Name != "api" || strings.Join(api.Addresses, ",") != "10.0.0.1,10.0.0.2" {
+		t.Errorf("expected api to resolve to the sorted addresses first, got %v", api)
+	}
+	if apps := propagations[1]; apps.Host.Name != "apps" || apps.Seconds < 0.04 || propagations[0].Seconds > apps.Seconds {
+		t.Errorf("expected apps to propagate once both resolvers answered, got %v", apps)
+	}
+}
+
+func TestWaitForPropagationMismatch(t *testing.T) {
+	google := &fakeResolver{addresses: map[string][]string{"api.example.com": {"10.0.0.1"}}}
+	cloudflare := &fakeResolver{addresses: map[string][]string{"api.example.com": {"10.0.0.9"}}}
+	servers := []Server{{Name: "google", Resolver: google}, {Name: "cloudflare", Resolver: cloudflare}}
+
+	// resolvers that answer differently have still propagated the name
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	propagations, err := WaitForPropagation(ctx, servers, []Host{{Name: "api", Hostname: "api.example.com"}}, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected resolvers that disagree not to block propagation: %v", err)
+	}
+
+	if len(propagations) != 1 {
+		t.Fatalf("expected the host to propagate, got %v", propagations)
+	}
+	mismatched := strings.Join(propagations[0].Mismatched, ", ")
+	if !strings.Contains(mismatched, "google returned [10.0.0.1]") || !strings.Contains(mismatched, "cloudflare returned [10.0.0.9]") {
+		t.Errorf("expected the mismatch to show each answer, got %v", propagations[0].Mismatched)
+	}
+}
+
+func TestWaitForPropagationTimeout(t *testing.T) {
+	google := &fakeResolver{addresses: map[string][]string{"api.example.com": {"10.0.0.1"}}}
+	cloudflare := &fakeResolver{addresses: map[string][]string{"api.example.com": {}}}
+	quad9 := &fakeResolver{addresses: map[string][]string{}}
+	servers := []Server{{Name: "google", Resolver: google}, {Name: "cloudflare", Resolver: cloudflare}, {Name: "quad9", Resolver: quad9}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := WaitForPropagation(ctx, servers, []Host{{Name: "api", Hostname: "api.example.com"}}, 5*time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected resolvers that don't answer to be an error")
+	}
+	if !strings.Contains(err.Error(), "google returned [10.0.0.1]") || !strings.Contains(err.Error(), "cloudflare returned no addresses") || !strings.Contains(err.Error(), "quad9 failed: no such host") {
+		t.Errorf("expected the error to show each answer, got %v", err)
+	}
+
+	if _, err = WaitForPropagation(ctx, nil, nil, time.Millisecond); err == nil {
+		t.Errorf("expected no servers to be an error")
+	}
+}
+
+func TestServers(t *testing.T) {
+	servers := Servers([]string{"8.8.8.8", " ", "[2606:4700:4700::1111]:5353"})
+	if len(servers) != 2 || servers[0].Name != "8.8.8.8:53" || servers[1].Name != "[2606:4700:4700::1111]:5353" {
+		t.Errorf("expected servers on the default and given ports, got %v", servers)
+	}
+}
@@ -0,0 +1,269 @@
+// Package envlock makes sure only one osde2e run at a time tests against an environment.
+//
+// The lock is a lease stored in S3 that its holder renews while it runs. S3 has no compare-and-swap, so a lease
+// is only considered held once it has been written and read back unchanged after a settle period. This makes it
+// unlikely, though not impossible, for two runs racing for a free lock to both get it.
+package envlock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+const (
+	// defaultSettle is how long a written lease must survive before it is considered held.
+	defaultSettle = 10 * time.Second
+
+	// defaultPoll is how often a held lock is checked while waiting for it.
+	defaultPoll = 30 * time.Second
+)
+
+// Store holds leases by name.
+type Store interface {
+	// Read returns the lease data for a name, and false if there is none.
+	Read(name string) ([]byte, bool, error)
+
+	// Write stores the lease data for a name.
+	Write(name string, data []byte) error
+
+	// Delete removes the lease for a name.
+	Delete(name string) error
+}
+
+// S3Store stores leases as objects under an S3 URL.
+type S3Store struct {
+	URL string
+}
+
+// Read returns the lease object for a name.
+func (s S3Store) Read(name string) ([]byte, bool, error) {
+	data, err := aws.ReadFromS3(s.key(name))
+	if aws.IsS3NotFound(err) {
+		return nil, false, nil
+	}
+	return data, err == nil, err
+}
+
+// Write stores the lease object for a name.
+func (s S3Store) Write(name string, data []byte) error {
+	return aws.WriteToS3(s.key(name), data)
+}
+
+// Delete removes the lease object for a name.
+func (s S3Store) Delete(name string) error {
+	return aws.DeleteFromS3(s.key(name))
+}
+
+func (s S3Store) key(name string) string {
+	return strings.TrimSuffix(s.URL, "/") + "/" + name + ".lock"
+}
+
+// Lease records who holds a lock and until when.
+type Lease struct {
+	Holder   string    `json:"holder"`
+	Job      string    `json:"job,omitempty"`
+	Acquired time.Time `json:"acquired"`
+	Expires  time.Time `json:"expires"`
+}
+
+// Lock is a named lock held by a single run.
+type Lock struct {
+	store  Store
+	name   string
+	holder string
+	job    string
+	ttl    time.Duration
+	settle time.Duration
+	poll   time.Duration
+
+	mutex    sync.Mutex
+	acquired time.Time
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// New creates a lock called name for holder. Leases expire after ttl unless they are renewed, so a run that dies
+// can't keep the lock. Job describes the holder to others waiting for the lock.
+func New(store Store, name, holder, job string, ttl time.Duration) *Lock {
+	return &Lock{
+		store:  store,
+		name:   name,
+		holder: holder,
+		job:    job,
+		ttl:    ttl,
+		settle: defaultSettle,
+		poll:   defaultPoll,
+	}
+}
+
+// Acquire waits up to timeout for the lock to be free and takes it.
+func (l *Lock) Acquire(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := l.read()
+		if err != nil {
+			return fmt.Errorf("error reading lock %s: %v", l.name, err)
+		}
+
+		if current == nil || current.Holder == l.holder || time.Now().After(current.Expires) {
+			acquired, err := l.take()
+			if err != nil {
+				return err
+			}
+			if acquired {
+				logging.Infof("Acquired lock %s.", l.name)
+				return nil
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for lock %s, held by %s until %s", timeout, l.name, describe(current), current.Expires.Format(time.RFC3339))
+		}
+		logging.Infof("Waiting for lock %s, held by %s until %s.", l.name, describe(current), current.Expires.Format(time.RFC3339))
+		time.Sleep(l.poll)
+	}
+}
+
+// take writes a lease for the holder and checks that it wasn't overwritten by another run.
+func (l *Lock) take() (bool, error) {
+	if err := l.write(); err != nil {
+		return false, err
+	}
+
+	time.Sleep(l.settle)
+
+	current, err := l.read()
+	if err != nil {
+		return false, fmt.Errorf("error reading lock %s: %v", l.name, err)
+	}
+	if current == nil || current.Holder != l.holder {
+		l.mutex.Lock()
+		l.acquired = time.Time{}
+		l.mutex.Unlock()
+		return false, nil
+	}
+	return true, nil
+}
+
+// Hold renews the lease until the lock is released. Lost is called if another run takes the lock or the lease
+// can't be renewed before it expires.
+func (l *Lock) Hold(lost func(error)) {
+	l.mutex.Lock()
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	stop, done := l.stop, l.done
+	l.mutex.Unlock()
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+
+		renewed := time.Now()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				current, err := l.read()
+				if err == nil && current != nil && current.Holder != l.holder {
+					lost(fmt.Errorf("lock %s was taken by %s", l.name, describe(current)))
+					return
+				}
+
+				if err == nil {
+					err = l.write()
+				}
+				if err == nil {
+					renewed = time.Now()
+				} else if time.Since(renewed) > l.ttl {
+					lost(fmt.Errorf("couldn't renew lock %s before it expired: %v", l.name, err))
+					return
+				} else {
+					logging.Warnf("Unable to renew lock %s: %v", l.name, err)
+				}
+			}
+		}
+	}()
+}
+
+// Release stops renewing the lease and removes it if it is still held.
+func (l *Lock) Release() error {
+	l.mutex.Lock()
+	stop, done := l.stop, l.done
+	l.stop = nil
+	l.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+
+	current, err := l.read()
+	if err != nil {
+		return fmt.Errorf("error reading lock %s: %v", l.name, err)
+	}
+	if current == nil || current.Holder != l.holder {
+		return nil
+	}
+
+	if err = l.store.Delete(l.name); err != nil {
+		return fmt.Errorf("error releasing lock %s: %v", l.name, err)
+	}
+	logging.Infof("Released lock %s.", l.name)
+	return nil
+}
+
+func (l *Lock) read() (*Lease, error) {
+	data, found, err := l.store.Read(l.name)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	lease := &Lease{}
+	if err = json.Unmarshal(data, lease); err != nil {
+		return nil, fmt.Errorf("error parsing lease: %v", err)
+	}
+	return lease, nil
+}
+
+func (l *Lock) write() error {
+	now := time.Now()
+
+	l.mutex.Lock()
+	if l.acquired.IsZero() {
+		l.acquired = now
+	}
+	acquired := l.acquired
+	l.mutex.Unlock()
+
+	data, err := json.Marshal(&Lease{
+		Holder:   l.holder,
+		Job:      l.job,
+		Acquired: acquired,
+		Expires:  now.Add(l.ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = l.store.Write(l.name, data); err != nil {
+		return fmt.Errorf("error writing lock %s: %v", l.name, err)
+	}
+	return nil
+}
+
+func describe(lease *Lease) string {
+	if lease.Job != "" {
+		return fmt.Sprintf("%s (%s)", lease.Holder, lease.Job)
+	}
+	return lease.Holder
+}
@@ -0,0 +1,112 @@
+package envlock
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore keeps leases in memory.
+type memoryStore struct {
+	mutex  sync.Mutex
+	leases map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{leases: map[string][]byte{}}
+}
+
+func (m *memoryStore) Read(name string) ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	data, ok := m.leases[name]
+	return data, ok, nil
+}
+
+func (m *memoryStore) Write(name string, data []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.leases[name] = data
+	return nil
+}
+
+func (m *memoryStore) Delete(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.leases, name)
+	return nil
+}
+
+func testLock(store Store, holder string, ttl time.Duration) *Lock {
+	l := New(store, "prod", holder, "job-"+holder, ttl)
+	l.settle = time.Millisecond
+	l.poll = 5 * time.Millisecond
+	return l
+}
+
+func TestAcquireAndRelease(t *testing.T) {
+	store := newMemoryStore()
+
+	first := testLock(store, "first", time.Hour)
+	if err := first.Acquire(time.Second); err != nil {
+		t.Fatalf("failed to acquire free lock: %v", err)
+	}
+
+	second := testLock(store, "second", time.Hour)
+	err := second.Acquire(20 * time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "first (job-first)") {
+		t.Errorf("expected a held lock to time out naming its holder, got %v", err)
+	}
+
+	if err = first.Release(); err != nil {
+		t.Fatalf("failed to release lock: %v", err)
+	}
+	if err = second.Acquire(time.Second); err != nil {
+		t.Errorf("failed to acquire released lock: %v", err)
+	}
+
+	// releasing a lock held by someone else leaves it alone
+	if err = first.Release(); err != nil {
+		t.Errorf("failed to release lock: %v", err)
+	}
+	if _, found, _ := store.Read("prod"); !found {
+		t.Errorf("expected the lock of another holder to be kept")
+	}
+}
+
+func TestAcquireExpired(t *testing.T) {
+	store := newMemoryStore()
+	data, _ := json.Marshal(&Lease{Holder: "dead", Expires: time.Now().Add(-time.Minute)})
+	store.Write("prod", data)
+
+	if err := testLock(store, "alive", time.Hour).Acquire(time.Second); err != nil {
+		t.Errorf("expected an expired lease to be taken over: %v", err)
+	}
+}
+
+func TestHoldDetectsTakeover(t *testing.T) {
+	store := newMemoryStore()
+
+	l := testLock(store, "first", 30*time.Millisecond)
+	if err := l.Acquire(time.Second); err != nil {
+		t.Fatalf("failed to acquire lock: %v", err)
+	}
+
+	lost := make(chan error, 1)
+	l.Hold(func(err error) { lost <- err })
+	defer l.Release()
+
+	data, _ := json.Marshal(&Lease{Holder: "other", Expires: time.Now().Add(time.Hour)})
+	store.Write("prod", data)
+
+	select {
+	case err := <-lost:
+		if !strings.Contains(err.Error(), "taken by other") {
+			t.Errorf("unexpected reason for losing the lock: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("expected the lock to be reported lost")
+	}
+}
@@ -22,6 +22,9 @@ const (
 	// NoHiveLogs when no logs from Hive were collected after a cluster provisioning event
 	NoHiveLogs EventType = "NoHiveLogs"
 
+	// DeprovisionFailed when the cluster failed to delete or did not finish deleting in time
+	DeprovisionFailed EventType = "DeprovisionFailed"
+
 	// ------ Addon installation events
 
 	// InstallAddonsSuccessful when the addons installed successfully
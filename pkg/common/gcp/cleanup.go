@@ -0,0 +1,239 @@
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of resources that can be found by their labels.
+const (
+	ResourceForwardingRule = "forwarding-rule"
+	ResourceDisk           = "disk"
+	ResourceBucket         = "gcs-bucket"
+)
+
+// ResourceKinds are the kinds of resources found by LabeledResources, in the order they must be deleted.
+var ResourceKinds = []string{
+	ResourceForwardingRule,
+	ResourceDisk,
+	ResourceBucket,
+}
+
+var (
+	// computeURL is the URL of the Compute Engine API.
+	computeURL = "https://compute.googleapis.com/compute/v1/"
+
+	// storageURL is the URL of the Cloud Storage JSON API.
+	storageURL = "https://storage.googleapis.com/storage/v1/"
+)
+
+// LabeledResource is a resource found by its labels.
+type LabeledResource struct {
+	Kind string
+
+	// ID is the path of a Compute Engine resource in its project, ex. zones/us-east1-b/disks/name, or the name of a
+	// bucket.
+	ID string
+
+	Labels  map[string]string
+	Created time.Time
+}
+
+// LabeledResources returns the forwarding rules, disks, and buckets of a project that have the label key. Networks
+// and service accounts can't be labeled, so they aren't found.
+func LabeledResources(project, labelKey string) ([]LabeledResource, error) {
+	token, err := AccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []LabeledResource
+	for _, kind := range []struct {
+		name     string
+		resource string
+	}{
+		{ResourceForwardingRule, "forwardingRules"},
+		{ResourceDisk, "disks"},
+	} {
+		found, err := labeledComputeResources(token, project, kind.name, kind.resource, labelKey)
+		if err != nil {
+			return nil, fmt.Errorf("error finding labeled %s resources in %s: %v", kind.name, project, err)
+		}
+		resources = append(resources, found...)
+	}
+
+	buckets, err := labeledBuckets(token, project, labelKey)
+	if err != nil {
+		return nil, fmt.Errorf("error finding labeled %s resources in %s: %v", ResourceBucket, project, err)
+	}
+	return append(resources, buckets...), nil
+}
+
+// DeleteResource deletes a resource found by LabeledResources. Buckets are emptied first. Compute Engine resources are
+// deleted asynchronously, so they may still exist for a short while afterwards.
+func DeleteResource(project string, r LabeledResource) error {
+	token, err := AccessToken()
+	if err != nil {
+		return err
+	}
+
+	switch r.Kind {
+	case ResourceForwardingRule, ResourceDisk:
+		_, err = Request(http.MethodDelete, computeURL+"projects/"+project+"/"+r.ID, "Bearer "+token, nil)
+	case ResourceBucket:
+		err = deleteBucket(token, r.ID)
+	default:
+		err = fmt.Errorf("unknown kind of resource")
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting %s %s: %v", r.Kind, r.ID, err)
+	}
+	return nil
+}
+
+// labeledComputeResources lists the Compute Engine resources of a type in every zone or region of a project that have
+// the label key.
+func labeledComputeResources(token, project, kind, resource, labelKey string) ([]LabeledResource, error) {
+	var resources []LabeledResource
+	pageToken := ""
+	for {
+		query := url.Values{"filter": {fmt.Sprintf("labels.%s:*", labelKey)}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		data, err := Request(http.MethodGet, computeURL+"projects/"+project+"/aggregated/"+resource+"?"+query.Encode(), "Bearer "+token, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// items are keyed by scope, ex. zones/us-east1-b or regions/us-east1
+		list := struct {
+			Items map[string]map[string]json.RawMessage `json:"items"`
+			Next  string                                `json:"nextPageToken"`
+		}{}
+		if err = json.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+
+		scopes := make([]string, 0, len(list.Items))
+		for scope := range list.Items {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+
+		for _, scope := range scopes {
+			raw, ok := list.Items[scope][resource]
+			if !ok {
+				continue
+			}
+
+			var items []struct {
+				Name    string            `json:"name"`
+				Created time.Time         `json:"creationTimestamp"`
+				Labels  map[string]string `json:"labels"`
+			}
+			if err = json.Unmarshal(raw, &items); err != nil {
+				return nil, err
+			}
+
+			for _, item := range items {
+				if _, ok := item.Labels[labelKey]; ok {
+					resources = append(resources, LabeledResource{Kind: kind, ID: scope + "/" + resource + "/" + item.Name, Labels: item.Labels, Created: item.Created})
+				}
+			}
+		}
+
+		if list.Next == "" {
+			return resources, nil
+		}
+		pageToken = list.Next
+	}
+}
+
+// labeledBuckets lists the buckets of a project that have the label key.
+func labeledBuckets(token, project, labelKey string) ([]LabeledResource, error) {
+	var resources []LabeledResource
+	pageToken := ""
+	for {
+		query := url.Values{"project": {project}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		data, err := Request(http.MethodGet, storageURL+"b?"+query.Encode(), "Bearer "+token, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		list := struct {
+			Items []struct {
+				Name    string            `json:"name"`
+				Created time.Time         `json:"timeCreated"`
+				Labels  map[string]string `json:"labels"`
+			} `json:"items"`
+			Next string `json:"nextPageToken"`
+		}{}
+		if err = json.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+
+		for _, bucket := range list.Items {
+			if _, ok := bucket.Labels[labelKey]; ok {
+				resources = append(resources, LabeledResource{Kind: ResourceBucket, ID: bucket.Name, Labels: bucket.Labels, Created: bucket.Created})
+			}
+		}
+
+		if list.Next == "" {
+			return resources, nil
+		}
+		pageToken = list.Next
+	}
+}
+
+// deleteBucket deletes the objects in a bucket and then the bucket.
+func deleteBucket(token, bucket string) error {
+	bucketURL := storageURL + "b/" + url.PathEscape(bucket)
+	pageToken := ""
+	for {
+		query := url.Values{}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		data, err := Request(http.MethodGet, bucketURL+"/o?"+query.Encode(), "Bearer "+token, nil)
+		if err != nil {
+			return err
+		}
+
+		list := struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			Next string `json:"nextPageToken"`
+		}{}
+		if err = json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+
+		for _, object := range list.Items {
+			if _, err = Request(http.MethodDelete, bucketURL+"/o/"+url.PathEscape(object.Name), "Bearer "+token, nil); err != nil && !strings.HasPrefix(err.Error(), "404") {
+				return err
+			}
+		}
+
+		if list.Next == "" {
+			break
+		}
+		pageToken = list.Next
+	}
+
+	_, err := Request(http.MethodDelete, bucketURL, "Bearer "+token, nil)
+	return err
+}
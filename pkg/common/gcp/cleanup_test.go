@@ -0,0 +1,106 @@
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeGCP points GCP requests at handler, authenticated with a fixed token, until the returned function is called.
+func fakeGCP(t *testing.T, handler http.HandlerFunc) func() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gcp-token" {
+			t.Errorf("expected the access token to be sent, got %q", r.Header.Get("Authorization"))
+		}
+		handler(w, r)
+	}))
+
+	compute, storage, token := computeURL, storageURL, os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	computeURL, storageURL = server.URL+"/compute/v1/", server.URL+"/storage/v1/"
+	os.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "gcp-token")
+	return func() {
+		computeURL, storageURL = compute, storage
+		os.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", token)
+		server.Close()
+	}
+}
+
+func TestLabeledResources(t *testing.T) {
+	defer fakeGCP(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/compute/v1/projects/p/aggregated/forwardingRules":
+			fmt.Fprint(w, `{"items":{"regions/us-east1":{"warning":{"code":"NO_RESULTS_ON_PAGE"}}}}`)
+		case "/compute/v1/projects/p/aggregated/disks":
+			if filter := r.URL.Query().Get("filter"); filter != "labels.api-openshift-com-id:*" {
+				t.Errorf("unexpected filter %s", filter)
+			}
+			if r.URL.Query().Get("pageToken") == "" {
+				fmt.Fprint(w, `{"items":{"zones/us-east1-b":{"disks":[
+					{"name":"ci-cluster-abc-master-0","creationTimestamp":"2020-06-01T05:00:00.000-07:00","labels":{"api-openshift-com-id":"abc"}}
+				]}},"nextPageToken":"page2"}`)
+				return
+			}
+			fmt.Fprint(w, `{"items":{"zones/us-east1-c":{"disks":[
+				{"name":"ci-cluster-abc-master-1","creationTimestamp":"2020-06-01T05:00:00.000-07:00","labels":{"api-openshift-com-id":"abc"}}
+			]}}}`)
+		case "/storage/v1/b":
+			fmt.Fprint(w, `{"items":[
+				{"name":"ci-cluster-abc-image-registry","timeCreated":"2020-06-01T12:00:00.000Z","labels":{"api-openshift-com-id":"abc"}},
+				{"name":"unrelated","timeCreated":"2020-06-01T12:00:00.000Z"}
+			]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})()
+
+	resources, err := LabeledResources("p", "api-openshift-com-id")
+	if err != nil {
+		t.Fatalf("failed to find labeled resources: %v", err)
+	}
+
+	var ids []string
+	for _, r := range resources {
+		ids = append(ids, r.Kind+" "+r.ID)
+		if !r.Created.Equal(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)) {
+			t.Errorf("unexpected creation time of %s: %v", r.ID, r.Created)
+		}
+	}
+
+	expected := []string{
+		"disk zones/us-east1-b/disks/ci-cluster-abc-master-0",
+		"disk zones/us-east1-c/disks/ci-cluster-abc-master-1",
+		"gcs-bucket ci-cluster-abc-image-registry",
+	}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestDeleteBucket(t *testing.T) {
+	var deleted []string
+	defer fakeGCP(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/registry/o":
+			fmt.Fprint(w, `{"items":[{"name":"docker/registry/v2/blob"}]}`)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.RawPath)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})()
+
+	if err := DeleteResource("p", LabeledResource{Kind: ResourceBucket, ID: "registry"}); err != nil {
+		t.Fatalf("failed to delete bucket: %v", err)
+	}
+
+	expected := []string{"/storage/v1/b/registry/o/docker%2Fregistry%2Fv2%2Fblob", ""}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected the objects to be deleted before the bucket, got %q", deleted)
+	}
+}
@@ -0,0 +1,66 @@
+// Package gcp calls GCP REST APIs as the user or service account osde2e runs as.
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// MetadataTokenURL is where the access token of the instance's service account is read from on GCP.
+var MetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// AccessToken returns the access token set by GOOGLE_OAUTH_ACCESS_TOKEN, ex. from `gcloud auth print-access-token`,
+// or the token of the service account of the instance or pod osde2e runs on.
+func AccessToken() (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	data, err := Request(http.MethodGet, MetadataTokenURL, "", map[string]string{"Metadata-Flavor": "Google"})
+	if err != nil {
+		return "", fmt.Errorf("GOOGLE_OAUTH_ACCESS_TOKEN must be set outside of GCP: %v", err)
+	}
+
+	token := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err = json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("error parsing access token: %v", err)
+	}
+	return token.AccessToken, nil
+}
+
+// Request sends a request without a body and returns the body of a successful response.
+func Request(method, url, authorization string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
@@ -0,0 +1,158 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+const (
+	// foldersPath lists and creates folders.
+	foldersPath = "/api/folders"
+
+	// dashboardsPath creates and updates dashboards.
+	dashboardsPath = "/api/dashboards/db"
+
+	requestTimeout = 30 * time.Second
+)
+
+// Client provisions dashboards through the Grafana HTTP API.
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	retry   backoff.Backoff
+}
+
+// New creates a client for the Grafana at baseURL, authenticating with an API key allowed to edit dashboards.
+func New(baseURL, token string) *Client {
+	retry := backoff.Exponential(5*time.Second, time.Minute)
+	retry.MaxAttempts = 5
+
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: requestTimeout},
+		retry:   retry,
+	}
+}
+
+// folder is a Grafana folder.
+type folder struct {
+	ID    int    `json:"id"`
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+}
+
+// dashboardRequest creates or replaces a dashboard in a folder.
+type dashboardRequest struct {
+	Dashboard Dashboard `json:"dashboard"`
+	FolderID  int       `json:"folderId"`
+	Overwrite bool      `json:"overwrite"`
+	Message   string    `json:"message"`
+}
+
+// dashboardResponse is where a provisioned dashboard can be found.
+type dashboardResponse struct {
+	URL string `json:"url"`
+}
+
+// Provision creates or replaces the dashboards in the folder with the given title, creating it if it doesn't exist.
+// It returns the URLs of the dashboards. Dashboards are matched by UID, so provisioning again updates them.
+func (c *Client) Provision(folderTitle string, dashboards []Dashboard) ([]string, error) {
+	folderID, err := c.ensureFolder(folderTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, dashboard := range dashboards {
+		// the ID is assigned by Grafana, dashboards are found by their UID
+		req := dashboardRequest{Dashboard: dashboard, FolderID: folderID, Overwrite: true, Message: "Provisioned by osde2e"}
+		var resp dashboardResponse
+		if err = c.do(http.MethodPost, dashboardsPath, req, &resp); err != nil {
+			return urls, fmt.Errorf("couldn't provision dashboard %s: %v", dashboard.Title, err)
+		}
+		urls = append(urls, c.baseURL+resp.URL)
+	}
+	return urls, nil
+}
+
+// ensureFolder returns the ID of the folder with the title, creating it if it doesn't exist. The General folder has
+// ID 0.
+func (c *Client) ensureFolder(title string) (int, error) {
+	if title == "" || strings.EqualFold(title, "General") {
+		return 0, nil
+	}
+
+	var folders []folder
+	if err := c.do(http.MethodGet, foldersPath, nil, &folders); err != nil {
+		return 0, fmt.Errorf("couldn't list folders: %v", err)
+	}
+	for _, f := range folders {
+		if f.Title == title {
+			return f.ID, nil
+		}
+	}
+
+	var created folder
+	if err := c.do(http.MethodPost, foldersPath, folder{Title: title}, &created); err != nil {
+		return 0, fmt.Errorf("couldn't create folder %s: %v", title, err)
+	}
+	return created.ID, nil
+}
+
+// do sends a request with a JSON body, if any, and decodes the JSON response into out. Server errors are retried.
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	return c.retry.Retry(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequest(method, c.baseURL+path, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Accept", "application/json")
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		data, _ := ioutil.ReadAll(resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err = fmt.Errorf("Grafana returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+			if resp.StatusCode < http.StatusInternalServerError {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+
+		if out != nil {
+			if err = json.Unmarshal(data, out); err != nil {
+				return backoff.Permanent(fmt.Errorf("couldn't decode Grafana response: %v", err))
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,307 @@
+// Package grafana provisions Grafana dashboards for the metrics osde2e pushes, so the results of a team's jobs can be
+// followed without building dashboards by hand.
+package grafana
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ResultsUID is the UID of the dashboard of test results.
+	ResultsUID = "osde2e-results"
+
+	// ClustersUID is the UID of the dashboard of cluster installs, upgrades, and failures.
+	ClustersUID = "osde2e-clusters"
+
+	// schemaVersion is the version of the Grafana dashboard JSON model the dashboards are written in.
+	schemaVersion = 22
+
+	// panelWidth is the width of a panel, half of Grafana's 24 column grid.
+	panelWidth = 12
+
+	// panelHeight is the height of a panel in grid rows.
+	panelHeight = 8
+
+	// selectors restrict queries to the runs selected by the template variables.
+	selectors = `job=~"$job", environment=~"$environment", cloud_provider=~"$cloud_provider"`
+)
+
+// Options are how the dashboards are built.
+type Options struct {
+	// Jobs are regular expressions matching the job label of the runs the dashboards show. Every job is shown if
+	// empty.
+	Jobs []string
+
+	// Datasource is the name of the Prometheus datasource the metrics are queried from.
+	Datasource string
+}
+
+// Dashboard is a Grafana dashboard in its JSON model.
+type Dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Tags          []string   `json:"tags"`
+	Timezone      string     `json:"timezone"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the time range a dashboard shows by default.
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the variables of a dashboard.
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a template variable whose values are the values of a label.
+type Variable struct {
+	Name       string          `json:"name"`
+	Label      string          `json:"label"`
+	Type       string          `json:"type"`
+	Datasource string          `json:"datasource"`
+	Query      string          `json:"query"`
+	Regex      string          `json:"regex,omitempty"`
+	Refresh    int             `json:"refresh"`
+	Multi      bool            `json:"multi"`
+	IncludeAll bool            `json:"includeAll"`
+	AllValue   string          `json:"allValue,omitempty"`
+	Current    VariableCurrent `json:"current"`
+	Sort       int             `json:"sort"`
+}
+
+// VariableCurrent is the selected value of a variable.
+type VariableCurrent struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+// Panel is a graph or table panel of a dashboard.
+type Panel struct {
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Type        string     `json:"type"`
+	Datasource  string     `json:"datasource"`
+	GridPos     GridPos    `json:"gridPos"`
+	Targets     []Target   `json:"targets"`
+	Stack       bool       `json:"stack,omitempty"`
+	Bars        bool       `json:"bars,omitempty"`
+	Lines       bool       `json:"lines"`
+	YAxes       []YAxis    `json:"yaxes,omitempty"`
+	Transform   string     `json:"transform,omitempty"`
+	Legend      *Legend    `json:"legend,omitempty"`
+	Sort        *TableSort `json:"sort,omitempty"`
+}
+
+// GridPos is where a panel is on the dashboard's grid.
+type GridPos struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Target is a Prometheus query of a panel.
+type Target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+	Instant      bool   `json:"instant,omitempty"`
+	Format       string `json:"format,omitempty"`
+}
+
+// YAxis is an axis of a graph panel.
+type YAxis struct {
+	Format  string `json:"format"`
+	Show    bool   `json:"show"`
+	Min     *int   `json:"min,omitempty"`
+	Max     *int   `json:"max,omitempty"`
+	LogBase int    `json:"logBase"`
+}
+
+// Legend is how the series of a graph panel are listed.
+type Legend struct {
+	Show         bool `json:"show"`
+	AlignAsTable bool `json:"alignAsTable"`
+	RightSide    bool `json:"rightSide"`
+	Values       bool `json:"values"`
+	Current      bool `json:"current"`
+}
+
+// TableSort is the column a table panel is sorted by.
+type TableSort struct {
+	Col  int  `json:"col"`
+	Desc bool `json:"desc"`
+}
+
+// Dashboards returns the dashboards of the metrics osde2e pushes: the results of the tests, and how long clusters
+// took to install and upgrade and why they failed.
+func Dashboards(opts Options) []Dashboard {
+	return []Dashboard{resultsDashboard(opts), clustersDashboard(opts)}
+}
+
+func resultsDashboard(opts Options) Dashboard {
+	b := newBuilder(opts)
+	b.graph("Pass rate",
+		"Share of the specs that passed, of those that passed or failed.",
+		"percentunit", false,
+		target(`count by (job) (cicd_jUnitResult{`+selectors+`, result="passed"}) / count by (job) (cicd_jUnitResult{`+selectors+`, result=~"passed|failed"})`, "{{job}}"))
+	b.graph("Results",
+		"Number of specs by result.",
+		"short", true,
+		target(`count by (result) (cicd_jUnitResult{`+selectors+`})`, "{{result}}"))
+	b.table("Failing specs",
+		"Specs that failed the most in the time range.",
+		target(`topk(25, count by (job, phase, testname) (count_over_time(cicd_jUnitResult{`+selectors+`, result="failed"}[$__range])))`, ""))
+	b.table("Flaky specs",
+		"Specs that passed when retried the most in the time range.",
+		target(`topk(25, count by (job, phase, testname) (count_over_time(cicd_jUnitResult{`+selectors+`, result="flaky"}[$__range])))`, ""))
+	b.table("Slowest specs",
+		"Longest duration of each spec in the time range, in seconds.",
+		target(`topk(25, max by (testname) (max_over_time(cicd_jUnitResult{`+selectors+`}[$__range])))`, ""))
+	b.graph("Pass rate by install version",
+		"Pass rate reported in the metadata of each run.",
+		"percentunit", false,
+		target(`avg by (install_version) (cicd_metadata{`+selectors+`, metadata_name="install-phase-pass-rate"})`, "{{install_version}}"))
+	return b.dashboard(ResultsUID, "osde2e / Results")
+}
+
+func clustersDashboard(opts Options) Dashboard {
+	b := newBuilder(opts)
+	b.graph("Time to cluster ready",
+		"Time from the cluster being installed until it was healthy.",
+		"s", false,
+		target(`avg by (job) (cicd_metadata{`+selectors+`, metadata_name="time-to-cluster-ready"})`, "{{job}}"))
+	b.graph("Time to upgraded cluster",
+		"Time from starting the upgrade until it completed.",
+		"s", false,
+		target(`avg by (job) (cicd_metadata{`+selectors+`, metadata_name="time-to-upgraded-cluster"})`, "{{job}}"))
+	b.graph("Failures by category",
+		"Install and upgrade failures by their classification.",
+		"short", true,
+		target(`count by (category) (cicd_failure_classification{`+selectors+`})`, "{{category}}"))
+	b.graph("Events",
+		"Events recorded by runs, such as install or upgrade failures.",
+		"short", true,
+		target(`sum by (event) (cicd_event{`+selectors+`})`, "{{event}}"))
+	b.table("Runs by version",
+		"Number of runs of each install and upgrade version in the time range.",
+		target(`count by (install_version, upgrade_version) (count by (install_version, upgrade_version, cluster_id) (count_over_time(cicd_metadata{`+selectors+`}[$__range])))`, ""))
+	b.graph("Etcd database size",
+		"Size of the etcd database before and after upgrades, in bytes.",
+		"bytes", false,
+		target(`avg by (metadata_name) (cicd_metadata{`+selectors+`, metadata_name=~"etcd-db-size-.*"})`, "{{metadata_name}}"))
+	return b.dashboard(ClustersUID, "osde2e / Clusters")
+}
+
+// builder lays out the panels of a dashboard two to a row.
+type builder struct {
+	opts   Options
+	panels []Panel
+}
+
+func newBuilder(opts Options) *builder {
+	return &builder{opts: opts}
+}
+
+func (b *builder) add(p Panel) {
+	i := len(b.panels)
+	p.ID = i + 1
+	p.Datasource = b.opts.Datasource
+	p.GridPos = GridPos{X: (i % 2) * panelWidth, Y: (i / 2) * panelHeight, W: panelWidth, H: panelHeight}
+	b.panels = append(b.panels, p)
+}
+
+func (b *builder) graph(title, description, unit string, stacked bool, t Target) {
+	zero := 0
+	p := Panel{
+		Title:       title,
+		Description: description,
+		Type:        "graph",
+		Targets:     []Target{t},
+		Lines:       !stacked,
+		Bars:        stacked,
+		Stack:       stacked,
+		YAxes:       []YAxis{{Format: unit, Show: true, Min: &zero, LogBase: 1}, {Format: "short", LogBase: 1}},
+		Legend:      &Legend{Show: true},
+	}
+	if unit == "percentunit" {
+		one := 1
+		p.YAxes[0].Max = &one
+	}
+	b.add(p)
+}
+
+func (b *builder) table(title, description string, t Target) {
+	t.Instant, t.Format = true, "table"
+	b.add(Panel{
+		Title:       title,
+		Description: description,
+		Type:        "table",
+		Targets:     []Target{t},
+		Transform:   "table",
+		Sort:        &TableSort{Col: 0, Desc: true},
+	})
+}
+
+func (b *builder) dashboard(uid, title string) Dashboard {
+	return Dashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{"osde2e"},
+		Timezone:      "utc",
+		SchemaVersion: schemaVersion,
+		Refresh:       "1h",
+		Time:          TimeRange{From: "now-7d", To: "now"},
+		Templating: Templating{List: []Variable{
+			b.variable("job", "Job", b.opts.Jobs),
+			b.variable("environment", "Environment", nil),
+			b.variable("cloud_provider", "Cloud provider", nil),
+		}},
+		Panels: b.panels,
+	}
+}
+
+// variable is a template variable selecting values of the label. If patterns are given, only the values they match
+// can be selected, and selecting all of them selects those values rather than every value.
+func (b *builder) variable(label, text string, patterns []string) Variable {
+	allValue := ".*"
+	if len(patterns) > 0 {
+		allValue = "(" + strings.Join(patterns, "|") + ")"
+	}
+	return Variable{
+		Name:       label,
+		Label:      text,
+		Type:       "query",
+		Datasource: b.opts.Datasource,
+		Query:      fmt.Sprintf("label_values(cicd_jUnitResult, %s)", label),
+		Regex:      JobsRegex(patterns),
+		Refresh:    2,
+		Multi:      true,
+		IncludeAll: true,
+		AllValue:   allValue,
+		Current:    VariableCurrent{Text: "All", Value: "$__all"},
+		Sort:       1,
+	}
+}
+
+// JobsRegex returns the Grafana regex of a template variable matching any of the patterns, or an empty string if
+// there are none and every value is matched.
+func JobsRegex(patterns []string) string {
+	if len(patterns) == 0 {
+		return ""
+	}
+	return "/^(" + strings.Join(patterns, "|") + ")$/"
+}
+
+func target(expr, legend string) Target {
+	return Target{RefID: "A", Expr: expr, LegendFormat: legend}
+}
@@ -0,0 +1,108 @@
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func TestDashboards(t *testing.T) {
+	dashboards := Dashboards(Options{Jobs: []string{"osde2e-stage-aws-e2e-.*", "osde2e-prod-gcp-e2e-default"}, Datasource: "Metrics"})
+	if len(dashboards) != 2 || dashboards[0].UID != ResultsUID || dashboards[1].UID != ClustersUID {
+		t.Fatalf("expected the results and clusters dashboards, got %d dashboards", len(dashboards))
+	}
+
+	for _, dashboard := range dashboards {
+		job := dashboard.Templating.List[0]
+		if job.Regex != "/^(osde2e-stage-aws-e2e-.*|osde2e-prod-gcp-e2e-default)$/" || job.AllValue != "(osde2e-stage-aws-e2e-.*|osde2e-prod-gcp-e2e-default)" {
+			t.Errorf("expected the job variable of %s to be restricted to the configured jobs, got %+v", dashboard.Title, job)
+		}
+
+		for _, panel := range dashboard.Panels {
+			if panel.Datasource != "Metrics" {
+				t.Errorf("expected panel %s to use the configured datasource, got %q", panel.Title, panel.Datasource)
+			}
+			for _, target := range panel.Targets {
+				if !strings.Contains(target.Expr, `job=~"$job"`) {
+					t.Errorf("expected the query of panel %s to select the jobs, got %s", panel.Title, target.Expr)
+				}
+			}
+		}
+	}
+
+	job := Dashboards(Options{})[0].Templating.List[0]
+	if job.Regex != "" || job.AllValue != ".*" {
+		t.Errorf("expected every job to be shown if no jobs are configured, got %+v", job)
+	}
+}
+
+func TestProvision(t *testing.T) {
+	var provisioned []dashboardRequest
+	createdFolder := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer key" {
+			t.Errorf("expected the API key to be sent, got %q", auth)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == foldersPath:
+			w.Write([]byte(`[{"id":3,"uid":"abc","title":"Other"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == foldersPath:
+			var f folder
+			json.NewDecoder(r.Body).Decode(&f)
+			createdFolder = f.Title
+			w.Write([]byte(`{"id":7,"uid":"def","title":"osde2e"}`))
+		case r.Method == http.MethodPost && r.URL.Path == dashboardsPath:
+			var req dashboardRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode dashboard: %v", err)
+			}
+			provisioned = append(provisioned, req)
+			w.Write([]byte(`{"status":"success","url":"/d/` + req.Dashboard.UID + `/x"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := New(server.URL+"/", "key")
+	client.retry = backoff.Constant(time.Millisecond, 3)
+
+	urls, err := client.Provision("osde2e", Dashboards(Options{}))
+	if err != nil {
+		t.Fatalf("failed to provision dashboards: %v", err)
+	}
+
+	if createdFolder != "osde2e" {
+		t.Errorf("expected the folder to be created, got %q", createdFolder)
+	}
+	if len(provisioned) != 2 || provisioned[0].FolderID != 7 || !provisioned[0].Overwrite {
+		t.Errorf("expected both dashboards to be provisioned in the new folder, got %+v", provisioned)
+	}
+
+	expected := []string{server.URL + "/d/" + ResultsUID + "/x", server.URL + "/d/" + ClustersUID + "/x"}
+	if !reflect.DeepEqual(urls, expected) {
+		t.Errorf("expected URLs %v, got %v", expected, urls)
+	}
+}
+
+func TestProvisionRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, "bad")
+	client.retry = backoff.Constant(time.Millisecond, 3)
+
+	if _, err := client.Provision("General", Dashboards(Options{})); err == nil || !strings.Contains(err.Error(), "invalid API key") {
+		t.Errorf("expected the rejection to be returned, got %v", err)
+	}
+}
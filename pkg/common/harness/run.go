@@ -0,0 +1,85 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/runner"
+)
+
+// kubeconfigPath is where the kubeconfig is mounted in harnesses run locally.
+const kubeconfigPath = "/osde2e/kubeconfig"
+
+// RunLocal runs a harness image with a container engine such as podman or docker, pointed at the cluster of the given
+// kubeconfig, and returns the results it wrote along with its output. Results are returned even if the harness fails,
+// as long as the harness could be run.
+func RunLocal(engine, image string, kubeconfig []byte, timeout time.Duration) (map[string][]byte, []byte, error) {
+	dir, err := ioutil.TempDir("", "osde2e-harness")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating the harness's directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	outputDir := filepath.Join(dir, "results")
+	if err = os.Mkdir(outputDir, 0777); err != nil {
+		return nil, nil, fmt.Errorf("error creating the harness's output directory: %v", err)
+	}
+	// the harness may not run as the user creating the directory
+	if err = os.Chmod(outputDir, 0777); err != nil {
+		return nil, nil, fmt.Errorf("error opening up the harness's output directory: %v", err)
+	}
+
+	kubeconfigFile := filepath.Join(dir, "kubeconfig")
+	if err = ioutil.WriteFile(kubeconfigFile, kubeconfig, 0644); err != nil {
+		return nil, nil, fmt.Errorf("error writing the harness's kubeconfig: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, engine, "run", "--rm",
+		"-v", outputDir+":"+runner.DefaultRunner.OutputDir+":z",
+		"-v", kubeconfigFile+":"+kubeconfigPath+":ro,z",
+		"-e", "KUBECONFIG="+kubeconfigPath,
+		image)
+	output, runErr := cmd.CombinedOutput()
+	if _, ok := runErr.(*exec.Error); ok {
+		return nil, output, fmt.Errorf("couldn't run %s: %v", engine, runErr)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		runErr = fmt.Errorf("harness didn't finish within %v", timeout)
+	}
+
+	results, err := ReadResults(outputDir)
+	if err != nil {
+		return nil, output, err
+	}
+	return results, output, runErr
+}
+
+// ReadResults reads the results a harness wrote to a directory, keyed by their path in it.
+func ReadResults(dir string) (map[string][]byte, error) {
+	results := map[string][]byte{}
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		if results[filepath.ToSlash(name)], err = ioutil.ReadFile(file); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading the harness's results: %v", err)
+	}
+	return results, nil
+}
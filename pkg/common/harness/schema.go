@@ -0,0 +1,124 @@
+// Package harness checks that the results written by addon test harnesses follow the schema osde2e reads, so
+// harnesses producing results that would be dropped or misread are caught before they run against real clusters.
+//
+// A harness writes its results to the root of its output directory:
+//
+//	junit*.xml           one or more JUnit reports, each with a single <testsuite> root holding its <testcase>s
+//	addon-metadata.json  optional, a JSON object whose numeric fields are published as addon metadata
+package harness
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/openshift/osde2e/pkg/common/metadata"
+)
+
+var junitFileRegex = regexp.MustCompile(`^junit.*\.xml$`)
+
+// Problem is a way in which a harness's results don't follow the schema.
+type Problem struct {
+	// File is the result file with the problem, if the problem is with a single file.
+	File string
+
+	// Message says what is wrong and how to fix it.
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.File == "" {
+		return p.Message
+	}
+	return p.File + ": " + p.Message
+}
+
+// junitSuite is a JUnit report as written by harnesses. Its counts aren't checked, as Ginkgo doesn't count skipped and
+// pending specs in them but still reports them as <testcase>s.
+type junitSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string    `xml:"name,attr"`
+	Failure *struct{} `xml:"failure"`
+	Error   *struct{} `xml:"error"`
+}
+
+// Validate checks the result files of a harness, keyed by their path in the output directory, and returns the
+// problems found. No problems means the results will be read as the harness intended.
+func Validate(results map[string][]byte) []Problem {
+	var problems []Problem
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reports := 0
+	for _, name := range names {
+		base := path.Base(name)
+		topLevel := path.Dir(path.Clean(name)) == "."
+		switch {
+		case junitFileRegex.MatchString(base) && !topLevel:
+			problems = append(problems, Problem{name, "JUnit reports are only read from the root of the output directory, move it there"})
+		case junitFileRegex.MatchString(base):
+			reports++
+			problems = append(problems, validateJUnit(name, results[name])...)
+		case base == metadata.AddonMetadataFile && !topLevel:
+			problems = append(problems, Problem{name, "addon metadata is only read from the root of the output directory, move it there"})
+		case base == metadata.AddonMetadataFile:
+			problems = append(problems, validateMetadata(name, results[name])...)
+		}
+	}
+
+	if reports == 0 {
+		problems = append(problems, Problem{Message: "no JUnit report was written, write at least one junit*.xml file to the root of the output directory"})
+	}
+	return problems
+}
+
+// validateJUnit checks a JUnit report.
+func validateJUnit(name string, data []byte) []Problem {
+	var suite junitSuite
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		if bytes.Contains(data, []byte("<testsuites")) {
+			return []Problem{{name, "the root element is <testsuites>, but only a single <testsuite> is read from each report, write one junit*.xml file per suite"}}
+		}
+		return []Problem{{name, fmt.Sprintf("not a JUnit report with a <testsuite> root: %v", err)}}
+	}
+
+	var problems []Problem
+	if suite.Name == "" {
+		problems = append(problems, Problem{name, "the <testsuite> has no name attribute, which results are grouped by"})
+	}
+	if len(suite.TestCases) == 0 {
+		problems = append(problems, Problem{name, "the <testsuite> has no <testcase>s"})
+	}
+
+	for i, testCase := range suite.TestCases {
+		if testCase.Name == "" {
+			problems = append(problems, Problem{name, fmt.Sprintf("<testcase> %d has no name attribute", i+1)})
+		}
+		if testCase.Failure == nil && testCase.Error != nil {
+			problems = append(problems, Problem{name, fmt.Sprintf("<testcase> %q has an <error>, which is read as passed, report it with <failure> instead", testCase.Name)})
+		}
+	}
+	return problems
+}
+
+// validateMetadata checks addon metadata.
+func validateMetadata(name string, data []byte) []Problem {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return []Problem{{name, fmt.Sprintf("addon metadata must be a JSON object: %v", err)}}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package harness
+
+import (
+	"strings"
+	"testing"
+)
+
+const validReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="Prow Operator" tests="2" failures="1">
+  <testcase name="has its CRDs" classname="operator"></testcase>
+  <testcase name="has its deployment" classname="operator"><failure type="Failure">0 replicas ready</failure></testcase>
+</testsuite>`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  map[string][]byte
+		problems []string
+	}{
+		{
+			name: "valid",
+			results: map[string][]byte{
+				"junit.xml":                []byte(validReport),
+				"addon-metadata.json":      []byte(`{"operator":{"install-seconds":30}}`),
+				"containerLogs/tests.log":  []byte("ok"),
+				"junit_extra_not_read.txt": []byte("ignored"),
+			},
+		},
+		{
+			name: "ginkgo report with a skipped spec",
+			results: map[string][]byte{"junit.xml": []byte(`<testsuite name="Prow Operator" tests="1" failures="0">
+  <testcase name="has its CRDs" classname="operator"></testcase>
+  <testcase name="has its webhook" classname="operator"><skipped></skipped></testcase>
+</testsuite>`)},
+		},
+		{
+			name:     "no report",
+			results:  map[string][]byte{"addon-metadata.json": []byte(`{}`)},
+			problems: []string{"no JUnit report was written"},
+		},
+		{
+			name: "misplaced files",
+			results: map[string][]byte{
+				"junit.xml":               []byte(validReport),
+				"reports/junit_other.xml": []byte(validReport),
+				"out/addon-metadata.json": []byte(`{}`),
+			},
+			problems: []string{
+				"out/addon-metadata.json: addon metadata is only read from the root",
+				"reports/junit_other.xml: JUnit reports are only read from the root",
+			},
+		},
+		{
+			name:     "testsuites root",
+			results:  map[string][]byte{"junit.xml": []byte(`<testsuites><testsuite name="a"><testcase name="b"/></testsuite></testsuites>`)},
+			problems: []string{"junit.xml: the root element is <testsuites>"},
+		},
+		{
+			name:     "not xml",
+			results:  map[string][]byte{"junit.xml": []byte(`PASS`)},
+			problems: []string{"junit.xml: not a JUnit report"},
+		},
+		{
+			name: "bad suite",
+			results: map[string][]byte{"junit_1.xml": []byte(`<testsuite tests="3" failures="0">
+  <testcase></testcase>
+  <testcase name="errors"><error>panic</error></testcase>
+</testsuite>`)},
+			problems: []string{
+				"junit_1.xml: the <testsuite> has no name attribute",
+				"junit_1.xml: <testcase> 1 has no name attribute",
+				`junit_1.xml: <testcase> "errors" has an <error>`,
+			},
+		},
+		{
+			name:     "empty suite",
+			results:  map[string][]byte{"junit.xml": []byte(`<testsuite name="a"></testsuite>`)},
+			problems: []string{"junit.xml: the <testsuite> has no <testcase>s"},
+		},
+		{
+			name: "metadata isn't an object",
+			results: map[string][]byte{
+				"junit.xml":           []byte(validReport),
+				"addon-metadata.json": []byte(`[1, 2]`),
+			},
+			problems: []string{"addon-metadata.json: addon metadata must be a JSON object"},
+		},
+	}
+
+	for _, test := range tests {
+		problems := Validate(test.results)
+		if len(problems) != len(test.problems) {
+			t.Errorf("%s: expected %d problems, got %v", test.name, len(test.problems), problems)
+			continue
+		}
+		for i, expected := range test.problems {
+			if !strings.HasPrefix(problems[i].String(), expected) {
+				t.Errorf("%s: expected problem %d to start with %q, got %q", test.name, i+1, expected, problems[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	projectv1 "github.com/openshift/api/project/v1"
+	v1 "k8s.io/api/core/v1"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+)
+
+const (
+	// RunLabel labels the objects created for a run, including its namespaces, with the run's project, so the run's
+	// resource budget covers all of its namespaces and objects it leaves behind can be found.
+	RunLabel = "osde2e.openshift.io/run"
+
+	// ResourceBudgetFile is where the resources requested by a run's namespaces are reported.
+	ResourceBudgetFile = "resource-budget.yaml"
+
+	// budgetLimitRange names the LimitRange giving containers without requests the default requests.
+	budgetLimitRange = "osde2e-resource-budget"
+)
+
+var clusterResourceQuotaResource = schema.GroupVersionResource{Group: "quota.openshift.io", Version: "v1", Resource: "clusterresourcequotas"}
+
+// ResourceBudgetUsage is what the namespaces of a run requested compared to the run's resource budget. Resources are
+// named as in ResourceQuotas, ex. "requests.cpu".
+type ResourceBudgetUsage struct {
+	// Hard is the budget.
+	Hard map[string]string `yaml:"hard"`
+
+	// Used is what the run's namespaces requested at the end of the run.
+	Used map[string]string `yaml:"used"`
+
+	// Namespaces is what each of the run's namespaces requested.
+	Namespaces map[string]map[string]string `yaml:"namespaces"`
+
+	// Denied counts the objects each namespace was denied because the budget would have been exceeded.
+	Denied map[string]int `yaml:"denied,omitempty"`
+}
+
+// resourceBudget returns the budget configured for runs, or nil if there is none.
+func resourceBudget() (v1.ResourceList, error) {
+	cfg := config.Instance.ResourceBudget
+	budget := v1.ResourceList{}
+	for name, value := range map[v1.ResourceName]string{v1.ResourceRequestsCPU: cfg.CPU, v1.ResourceRequestsMemory: cfg.Memory} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource budget for %s: %v", name, err)
+		}
+		budget[name] = quantity
+	}
+
+	if len(budget) == 0 {
+		return nil, nil
+	}
+	return budget, nil
+}
+
+// enforceResourceBudget caps the resources a project created for the run may request. The budget is a
+// ClusterResourceQuota, created with the run's project, that covers every namespace labelled with the run.
+func (h *H) enforceResourceBudget(proj *projectv1.Project) error {
+	budget, err := resourceBudget()
+	if err != nil || budget == nil {
+		return err
+	}
+
+	cfg := config.Instance.ResourceBudget
+	defaults := v1.ResourceList{}
+	for name, value := range map[v1.ResourceName]string{v1.ResourceCPU: cfg.DefaultCPURequest, v1.ResourceMemory: cfg.DefaultMemoryRequest} {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid default %s request: %v", name, err)
+		}
+		defaults[name] = quantity
+	}
+
+	// pods can't be created in namespaces with a quota on requests unless every container requests resources
+	_, err = h.Kube().CoreV1().LimitRanges(proj.Name).Create(&v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: budgetLimitRange},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{{Type: v1.LimitTypeContainer, DefaultRequest: defaults}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't set default requests for project %s: %v", proj.Name, err)
+	}
+
+	if proj.Name != h.State.Project {
+		return nil
+	}
+
+	hard := map[string]interface{}{}
+	for name, quantity := range budget {
+		hard[string(name)] = quantity.String()
+	}
+
+	gvk := schema.FromAPIVersionAndKind("project.openshift.io/v1", "Project")
+	owner := metav1.NewControllerRef(proj, gvk)
+	quota := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "quota.openshift.io/v1",
+		"kind":       "ClusterResourceQuota",
+		"metadata": map[string]interface{}{
+			"name": proj.Name,
+			// the quota is deleted with the run's project
+			"ownerReferences": []interface{}{map[string]interface{}{
+				"apiVersion":         owner.APIVersion,
+				"kind":               owner.Kind,
+				"name":               owner.Name,
+				"uid":                string(owner.UID),
+				"controller":         true,
+				"blockOwnerDeletion": true,
+			}},
+		},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"matchLabels": map[string]interface{}{RunLabel: proj.Name},
+				},
+			},
+			"quota": map[string]interface{}{"hard": hard},
+		},
+	}}
+	if _, err = h.Dynamic().Resource(clusterResourceQuotaResource).Create(quota, metav1.CreateOptions{}); err != nil && !kerror.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create the resource budget of the run: %v", err)
+	}
+	return nil
+}
+
+// ResourceBudgetUsage returns what the run's namespaces requested compared to the run's budget, or nil if there is
+// no budget.
+func (h *H) ResourceBudgetUsage() (*ResourceBudgetUsage, error) {
+	if budget, err := resourceBudget(); err != nil || budget == nil {
+		return nil, err
+	}
+
+	quota, err := h.Dynamic().Resource(clusterResourceQuotaResource).Get(h.State.Project, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the resource budget of the run: %v", err)
+	}
+
+	usage := &ResourceBudgetUsage{
+		Namespaces: map[string]map[string]string{},
+		Denied:     map[string]int{},
+	}
+	usage.Hard, _, _ = unstructured.NestedStringMap(quota.Object, "status", "total", "hard")
+	usage.Used, _, _ = unstructured.NestedStringMap(quota.Object, "status", "total", "used")
+
+	namespaces, _, _ := unstructured.NestedSlice(quota.Object, "status", "namespaces")
+	for _, ns := range namespaces {
+		ns, ok := ns.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(ns, "namespace")
+		usage.Namespaces[name], _, _ = unstructured.NestedStringMap(ns, "status", "used")
+
+		events, err := h.Kube().CoreV1().Events(name).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't list events of namespace %s: %v", name, err)
+		}
+		for _, event := range events.Items {
+			if !strings.Contains(event.Message, "exceeded quota") {
+				continue
+			}
+			// repeated events are counted once per occurrence
+			if event.Count > 1 {
+				usage.Denied[name] += int(event.Count)
+			} else {
+				usage.Denied[name]++
+			}
+		}
+	}
+	return usage, nil
+}
+
+// Summary describes the usage in one line.
+func (u *ResourceBudgetUsage) Summary() string {
+	var names []string
+	for name := range u.Hard {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %s of %s", name, u.Used[name], u.Hard[name]))
+	}
+
+	denied := 0
+	for _, count := range u.Denied {
+		denied += count
+	}
+	return fmt.Sprintf("%s, %d object(s) denied", strings.Join(parts, ", "), denied)
+}
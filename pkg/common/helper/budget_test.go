@@ -0,0 +1,50 @@
+package helper
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+)
+
+func TestResourceBudget(t *testing.T) {
+	defer func(budget config.ResourceBudgetConfig) {
+		config.Instance.ResourceBudget = budget
+	}(config.Instance.ResourceBudget)
+
+	config.Instance.ResourceBudget = config.ResourceBudgetConfig{}
+	if budget, err := resourceBudget(); err != nil || budget != nil {
+		t.Errorf("expected no budget when none is configured, got %v, %v", budget, err)
+	}
+
+	config.Instance.ResourceBudget = config.ResourceBudgetConfig{Memory: "16Gi"}
+	budget, err := resourceBudget()
+	if err != nil {
+		t.Fatalf("failed to parse budget: %v", err)
+	}
+	if _, ok := budget[v1.ResourceRequestsCPU]; ok {
+		t.Errorf("expected CPU not to be budgeted, got %v", budget)
+	}
+	if memory := budget[v1.ResourceRequestsMemory]; memory.String() != "16Gi" {
+		t.Errorf("expected a memory budget of 16Gi, got %s", memory.String())
+	}
+
+	config.Instance.ResourceBudget = config.ResourceBudgetConfig{CPU: "eight"}
+	if _, err = resourceBudget(); err == nil {
+		t.Errorf("expected an invalid budget to return an error")
+	}
+}
+
+func TestResourceBudgetUsageSummary(t *testing.T) {
+	usage := &ResourceBudgetUsage{
+		Hard:   map[string]string{"requests.memory": "16Gi", "requests.cpu": "8"},
+		Used:   map[string]string{"requests.memory": "2Gi", "requests.cpu": "1500m"},
+		Denied: map[string]int{"osde2e-abcde": 2, "osde2e-fghij": 1},
+	}
+
+	expected := "requests.cpu 1500m of 8, requests.memory 2Gi of 16Gi, 3 object(s) denied"
+	if summary := usage.Summary(); summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, summary)
+	}
+}
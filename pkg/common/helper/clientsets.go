@@ -20,6 +20,15 @@ func (h *H) Impersonate(user rest.ImpersonationConfig) *H {
 	return h
 }
 
+// AsToken returns a copy of the helper whose clients authenticate with a bearer token, such as one issued to a
+// user, instead of the cluster admin's credentials.
+func (h *H) AsToken(token string) *H {
+	user := *h
+	user.restConfig = rest.AnonymousClientConfig(h.restConfig)
+	user.restConfig.BearerToken = token
+	return &user
+}
+
 // Cfg return a client for the Config API.
 func (h *H) Cfg() config.Interface {
 	client, err := config.NewForConfig(h.restConfig)
@@ -1,11 +1,13 @@
 package helper
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	kubev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -16,9 +18,9 @@ func (h *H) WaitForEndpointReady(svc *kubev1.Service, n int, dur time.Duration)
 		return errors.New("svc was nil")
 	}
 
-	for i := 0; i < n; i++ {
+	err := backoff.Constant(dur, n).Retry(context.Background(), func(ctx context.Context) error {
 		if endpoints, err := h.Kube().CoreV1().Endpoints(svc.Namespace).Get(svc.Name, metav1.GetOptions{}); err != nil {
-			log.Println(err)
+			logging.Errorf("%v", err)
 		} else if endpoints != nil {
 			for _, subset := range endpoints.Subsets {
 				if len(subset.Addresses) > 0 {
@@ -27,9 +29,11 @@ func (h *H) WaitForEndpointReady(svc *kubev1.Service, n int, dur time.Duration)
 			}
 		}
 
-		log.Printf("Waiting for Endpoint '%s/%s' to be ready...", svc.Namespace, svc.Name)
-		time.Sleep(dur)
+		logging.Infof("Waiting for Endpoint '%s/%s' to be ready...", svc.Namespace, svc.Name)
+		return fmt.Errorf("endpoint not ready")
+	})
+	if err != nil {
+		return fmt.Errorf("timeout waiting for Endpoint '%s/%s' to be ready", svc.Namespace, svc.Name)
 	}
-
-	return fmt.Errorf("timeout waiting for Endpoint '%s/%s' to be ready", svc.Namespace, svc.Name)
+	return nil
 }
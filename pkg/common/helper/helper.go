@@ -4,7 +4,6 @@ package helper
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"math/rand"
 	"strings"
 	"text/template"
@@ -22,6 +21,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/state"
 	"github.com/openshift/osde2e/pkg/common/util"
 )
@@ -89,6 +89,7 @@ func (h *H) Setup() error {
 	}
 
 	Expect(err).ShouldNot(HaveOccurred(), "failed to configure client")
+	h.restConfig.WrapTransport = h.labelObjects
 
 	if h.State.Project == "" {
 		// setup project and dedicated-admin account to run tests
@@ -97,7 +98,7 @@ func (h *H) Setup() error {
 		suffix := util.RandomStr(5)
 		h.State.Project = "osde2e-" + suffix
 
-		log.Printf("Setup called for %s", h.State.Project)
+		logging.Debugf("Setup called for %s", h.State.Project)
 
 		h.proj, err = h.createProject(suffix)
 		if h.OutsideGinkgo && err != nil {
@@ -111,7 +112,7 @@ func (h *H) Setup() error {
 		time.Sleep(60 * time.Second)
 
 	} else {
-		log.Printf("Setting project name to %s", h.State.Project)
+		logging.Debugf("Setting project name to %s", h.State.Project)
 		h.proj, err = h.Project().ProjectV1().Projects().Get(h.State.Project, metav1.GetOptions{})
 		if h.OutsideGinkgo && err != nil {
 			return fmt.Errorf("error retrieving project: %s", err.Error())
@@ -137,12 +138,13 @@ func (h *H) Cleanup() {
 
 	h.restConfig, err = clientcmd.RESTConfigFromKubeConfig(h.Kubeconfig.Contents)
 	Expect(err).ShouldNot(HaveOccurred(), "failed to configure client")
+	h.restConfig.WrapTransport = h.labelObjects
 
 	// Set the SA back to the default. This is required for cleanup in case other helper calls switched SAs
 	h.SetServiceAccount(config.Instance.Tests.ServiceAccount)
 
 	if h.proj == nil && h.State.Project != "" {
-		log.Printf("Setting project name to %s", h.State.Project)
+		logging.Debugf("Setting project name to %s", h.State.Project)
 		h.proj, err = h.Project().ProjectV1().Projects().Get(h.State.Project, metav1.GetOptions{})
 		Expect(err).ShouldNot(HaveOccurred(), "failed to retrieve project")
 		Expect(h.proj).ShouldNot(BeNil())
@@ -167,7 +169,7 @@ func (h *H) CreateServiceAccounts() *H {
 	})
 	Expect(err).NotTo(HaveOccurred())
 	h.CreateClusterRoleBinding(sa, "dedicated-admins-project")
-	log.Printf("Created SA: %v", sa.GetName())
+	logging.Debugf("Created SA: %v", sa.GetName())
 
 	// Create cluster dedicated-admin account
 	sa, err = h.Kube().CoreV1().ServiceAccounts(h.CurrentProject()).Create(&v1.ServiceAccount{
@@ -177,7 +179,7 @@ func (h *H) CreateServiceAccounts() *H {
 	})
 	Expect(err).NotTo(HaveOccurred())
 	h.CreateClusterRoleBinding(sa, "dedicated-admins-cluster")
-	log.Printf("Created SA: %v", sa.GetName())
+	logging.Debugf("Created SA: %v", sa.GetName())
 
 	// Create cluster-admin account
 	sa, err = h.Kube().CoreV1().ServiceAccounts(h.CurrentProject()).Create(&v1.ServiceAccount{
@@ -187,7 +189,7 @@ func (h *H) CreateServiceAccounts() *H {
 	})
 	Expect(err).NotTo(HaveOccurred())
 	h.CreateClusterRoleBinding(sa, "cluster-admin")
-	log.Printf("Created SA: %v", sa.GetName())
+	logging.Debugf("Created SA: %v", sa.GetName())
 
 	return h
 }
@@ -225,7 +227,7 @@ func (h *H) CreateClusterRoleBinding(sa *v1.ServiceAccount, clusterRole string)
 // SetServiceAccount sets the serviceAccount you want all helper commands to run as
 func (h *H) SetServiceAccount(sa string) *H {
 	if h.restConfig == nil {
-		log.Print("No restconfig found in SetServiceAccount")
+		logging.Errorf("No restconfig found in SetServiceAccount")
 		return nil
 	}
 
@@ -245,7 +247,7 @@ func (h *H) SetServiceAccount(sa string) *H {
 	h.restConfig.Impersonate = rest.ImpersonationConfig{
 		UserName: h.ServiceAccount,
 	}
-	log.Printf("ServiceAccount is now set to `%v`", h.ServiceAccount)
+	logging.Debugf("ServiceAccount is now set to `%v`", h.ServiceAccount)
 
 	return h
 }
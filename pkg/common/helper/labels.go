@@ -0,0 +1,248 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// SuiteLabel labels the objects created by a spec with the suite it belongs to.
+	SuiteLabel = "osde2e.openshift.io/suite"
+
+	// SpecLabel labels the objects created by a spec with the spec. Label values are limited to 63 characters, so
+	// the spec's full name is also in an annotation with the same key.
+	SpecLabel = "osde2e.openshift.io/spec"
+
+	// maxLabelLength is the longest a label value may be.
+	maxLabelLength = 63
+)
+
+// invalidLabelChars can't be used in label values.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// RunObject is an object labeled as created by a run.
+type RunObject struct {
+	Resource  string `yaml:"resource"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Name      string `yaml:"name"`
+	Suite     string `yaml:"suite,omitempty"`
+	Spec      string `yaml:"spec,omitempty"`
+}
+
+// String identifies the object and the spec that created it.
+func (o RunObject) String() string {
+	name := o.Resource + "/" + o.Name
+	if o.Namespace != "" {
+		name = o.Resource + "/" + o.Namespace + "/" + o.Name
+	}
+	if o.Spec != "" {
+		name += " (" + o.Spec + ")"
+	}
+	return name
+}
+
+// labelTransport labels every object created through it with the run, and with the suite and spec running when it
+// was created, so objects left on a cluster can be attributed to the spec that created them.
+type labelTransport struct {
+	next  http.RoundTripper
+	state *state.State
+}
+
+// labelObjects wraps the transport of the helper's clients so they label the objects they create.
+func (h *H) labelObjects(rt http.RoundTripper) http.RoundTripper {
+	return &labelTransport{next: rt, state: h.State}
+}
+
+// RoundTrip labels the object in the body of create requests.
+func (t *labelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || req.Body == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return t.next.RoundTrip(req)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	labels, annotations := runLabels(t.state.Project, currentSpec())
+	if labeled, err := labelObject(body, labels, annotations); err == nil {
+		body = labeled
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return t.next.RoundTrip(req)
+}
+
+// currentSpec describes the running spec, if there is one.
+func currentSpec() (spec ginkgo.GinkgoTestDescription) {
+	// Ginkgo panics if it hasn't run a suite yet
+	defer func() {
+		recover()
+	}()
+	return ginkgo.CurrentGinkgoTestDescription()
+}
+
+// runLabels returns the labels and annotations of objects created by a run while spec is running.
+func runLabels(project string, spec ginkgo.GinkgoTestDescription) (labels, annotations map[string]string) {
+	labels, annotations = map[string]string{}, map[string]string{}
+	if project != "" {
+		labels[RunLabel] = project
+	}
+	if len(spec.ComponentTexts) > 0 {
+		labels[SuiteLabel] = labelValue(spec.ComponentTexts[0])
+		labels[SpecLabel] = labelValue(spec.FullTestText)
+		annotations[SpecLabel] = spec.FullTestText
+	}
+	return labels, annotations
+}
+
+// labelObject adds labels and annotations an object doesn't already have to its JSON.
+func labelObject(data []byte, labels, annotations map[string]string) ([]byte, error) {
+	if len(labels) == 0 {
+		return data, nil
+	}
+
+	// numbers are kept as they are, instead of being converted to floats
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	meta, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		if _, set := obj["metadata"]; set {
+			return nil, fmt.Errorf("object metadata isn't an object")
+		}
+		meta = map[string]interface{}{}
+		obj["metadata"] = meta
+	}
+
+	for key, values := range map[string]map[string]string{"labels": labels, "annotations": annotations} {
+		if len(values) == 0 {
+			continue
+		}
+
+		existing, ok := meta[key].(map[string]interface{})
+		if !ok {
+			existing = map[string]interface{}{}
+			meta[key] = existing
+		}
+		for name, value := range values {
+			if _, set := existing[name]; !set {
+				existing[name] = value
+			}
+		}
+	}
+	return json.Marshal(obj)
+}
+
+// labelValue makes s a valid label value, replacing invalid characters and shortening it if it's too long. Shortened
+// values end with a hash of s, so they stay distinct.
+func labelValue(s string) string {
+	value := strings.Trim(invalidLabelChars.ReplaceAllString(s, "_"), "._-")
+	if len(value) > maxLabelLength {
+		hash := fnv.New32a()
+		hash.Write([]byte(s))
+		suffix := fmt.Sprintf("-%08x", hash.Sum32())
+		value = strings.TrimRight(value[:maxLabelLength-len(suffix)], "._-") + suffix
+	}
+	return value
+}
+
+// RunObjects lists the objects labeled as created by the run that will be left on the cluster once the run's project
+// is deleted. Objects being deleted, in namespaces being deleted, or owned by other objects aren't listed.
+func (h *H) RunObjects() ([]RunObject, error) {
+	resourceLists, err := h.Kube().Discovery().ServerPreferredResources()
+	if err != nil && len(resourceLists) == 0 {
+		return nil, fmt.Errorf("error discovering resources: %v", err)
+	}
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, resourceLists)
+
+	client := h.Dynamic()
+	listOpts := metav1.ListOptions{LabelSelector: RunLabel + "=" + h.State.Project}
+
+	deletedNamespaces := map[string]bool{}
+	namespaces, err := h.Kube().CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing namespaces: %v", err)
+	}
+	for _, ns := range namespaces.Items {
+		if ns.DeletionTimestamp != nil {
+			deletedNamespaces[ns.Name] = true
+		}
+	}
+
+	var objects []RunObject
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+
+			list, err := client.Resource(gv.WithResource(resource.Name)).List(listOpts)
+			if kerror.IsNotFound(err) || kerror.IsMethodNotSupported(err) || kerror.IsForbidden(err) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("error listing %s: %v", resource.Name, err)
+			}
+
+			for _, item := range list.Items {
+				if item.GetDeletionTimestamp() != nil || deletedNamespaces[item.GetNamespace()] || len(item.GetOwnerReferences()) > 0 {
+					continue
+				}
+
+				// the run's project, and everything in it, is deleted when the run is cleaned up
+				if item.GetNamespace() == h.State.Project || (!resource.Namespaced && item.GetName() == h.State.Project) {
+					continue
+				}
+
+				spec := item.GetAnnotations()[SpecLabel]
+				if spec == "" {
+					spec = item.GetLabels()[SpecLabel]
+				}
+				objects = append(objects, RunObject{
+					Resource:  resource.Name,
+					Namespace: item.GetNamespace(),
+					Name:      item.GetName(),
+					Suite:     item.GetLabels()[SuiteLabel],
+					Spec:      spec,
+				})
+			}
+		}
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].String() < objects[j].String()
+	})
+	return objects, nil
+}
@@ -0,0 +1,103 @@
+package helper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+func TestLabelValue(t *testing.T) {
+	tests := map[string]string{
+		"[Suite: e2e] Pods":                    "Suite_e2e_Pods",
+		"should be healthy.":                   "should_be_healthy",
+		strings.Repeat("a very long spec ", 5): "a_very_long_spec_a_very_long_spec_a_very_long_spec_a_v-",
+	}
+
+	for s, expected := range tests {
+		value := labelValue(s)
+		if !strings.HasPrefix(value, expected) {
+			t.Errorf("expected the label value of %q to start with %q, got %q", s, expected, value)
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			t.Errorf("expected %q to be a valid label value: %v", value, errs)
+		}
+	}
+
+	if labelValue(strings.Repeat("x", 70)+"1") == labelValue(strings.Repeat("x", 70)+"2") {
+		t.Errorf("expected shortened label values to stay distinct")
+	}
+}
+
+func TestLabelTransport(t *testing.T) {
+	var created v1.ConfigMap
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err == nil {
+			err = json.Unmarshal(data, &created)
+		}
+		if err != nil {
+			t.Errorf("failed to read created object: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	h := &H{State: &state.State{Project: "osde2e-abcde"}}
+	kube, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, WrapTransport: h.labelObjects})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = kube.CoreV1().ConfigMaps("default").Create(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Labels: map[string]string{"app": "test", RunLabel: "kept"}},
+		Data:       map[string]string{"replicas": "3"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create config map: %v", err)
+	}
+
+	if created.Labels["app"] != "test" || created.Labels[RunLabel] != "kept" || created.Data["replicas"] != "3" {
+		t.Errorf("expected the object's own labels and data to be kept, got %+v", created)
+	}
+	if _, ok := created.Labels[SpecLabel]; ok {
+		t.Errorf("expected no spec label outside of a spec, got %v", created.Labels)
+	}
+}
+
+func TestRunLabels(t *testing.T) {
+	labels, annotations := runLabels("osde2e-abcde", ginkgo.GinkgoTestDescription{
+		ComponentTexts: []string{"[Suite: e2e] Pods", "should be healthy"},
+		FullTestText:   "[Suite: e2e] Pods should be healthy",
+	})
+
+	expected := map[string]string{RunLabel: "osde2e-abcde", SuiteLabel: "Suite_e2e_Pods", SpecLabel: "Suite_e2e_Pods_should_be_healthy"}
+	for key, value := range expected {
+		if labels[key] != value {
+			t.Errorf("expected label %s to be %q, got %q", key, value, labels[key])
+		}
+	}
+	if annotations[SpecLabel] != "[Suite: e2e] Pods should be healthy" {
+		t.Errorf("expected the full spec name to be annotated, got %v", annotations)
+	}
+
+	data, err := labelObject([]byte(`{"kind":"Pod","spec":{"priority":2147483647}}`), labels, annotations)
+	if err != nil {
+		t.Fatalf("failed to label object: %v", err)
+	}
+	if !strings.Contains(string(data), `"priority":2147483647`) || !strings.Contains(string(data), `"osde2e.openshift.io/run":"osde2e-abcde"`) {
+		t.Errorf("expected a labeled object with its numbers unchanged, got %s", data)
+	}
+}
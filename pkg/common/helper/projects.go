@@ -10,10 +10,19 @@ import (
 func (h *H) createProject(suffix string) (*projectv1.Project, error) {
 	proj := &projectv1.Project{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "osde2e-" + suffix,
+			Name:   "osde2e-" + suffix,
+			Labels: map[string]string{RunLabel: h.State.Project},
 		},
 	}
-	return h.Project().ProjectV1().Projects().Create(proj)
+	proj, err := h.Project().ProjectV1().Projects().Create(proj)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.enforceResourceBudget(proj); err != nil {
+		return nil, err
+	}
+	return proj, nil
 }
 
 func (h *H) cleanup(projectName string) error {
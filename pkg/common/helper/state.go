@@ -1,11 +1,11 @@
 package helper
 
 import (
-	"log"
-
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
 )
 
 var (
@@ -78,7 +78,7 @@ func (h *H) GetClusterState() (resources map[schema.GroupVersionResource]*unstru
 	// retrieve cluster-wide resources
 	for _, r := range desiredClusterResources {
 		if list, err := client.Resource(r).List(listOpts); err != nil {
-			log.Printf("Encountered error listing getting resource '%s': %v", r, err)
+			logging.Errorf("Encountered error listing getting resource '%s': %v", r, err)
 		} else {
 			resources[r] = list
 		}
@@ -87,7 +87,7 @@ func (h *H) GetClusterState() (resources map[schema.GroupVersionResource]*unstru
 	// retrieve namespaces resources
 	for _, r := range desiredResources {
 		if list, err := client.Resource(r).Namespace(metav1.NamespaceAll).List(listOpts); err != nil {
-			log.Printf("Encountered error listing getting resource '%s': %v", r, err)
+			logging.Errorf("Encountered error listing getting resource '%s': %v", r, err)
 		} else {
 			resources[r] = list
 		}
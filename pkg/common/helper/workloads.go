@@ -16,9 +16,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/openshift/osde2e/pkg/common/ratelimit"
 )
 
-// ApplyYamlInFolder reads a folder and attempts to create objects in K8s with the yaml
+// ApplyYamlInFolder reads a folder and attempts to create objects in K8s with the yaml, at the rate set by
+// SCALE_CREATE_RATE
 func ApplyYamlInFolder(folder, namespace string, kube kubernetes.Interface) ([]runtime.Object, error) {
 	var (
 		objects []runtime.Object
@@ -39,10 +42,12 @@ func ApplyYamlInFolder(folder, namespace string, kube kubernetes.Interface) ([]r
 		return objects, err
 	}
 
+	limiter := ratelimit.Creations()
 	for _, file := range files {
 		if obj, err = ReadK8sYaml(file); err != nil {
 			return objects, err
 		}
+		limiter.Wait()
 		if obj, err = CreateRuntimeObject(obj, namespace, kube); err != nil {
 			return objects, err
 		}
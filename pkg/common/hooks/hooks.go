@@ -0,0 +1,147 @@
+// Package hooks lets external integrations run at points of an osde2e run, such as to update a CMDB or to
+// clean up resources osde2e doesn't know about.
+//
+// Hooks are either Go functions registered by packages compiled into osde2e, or webhooks configured by URL
+// that are sent the run context as JSON.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+// webhookTimeout is how long each attempt to send a webhook may take.
+const webhookTimeout = 30 * time.Second
+
+// Point is a point of the run that hooks are invoked at.
+type Point string
+
+const (
+	// PreProvision is before a cluster is launched. It isn't reached when an existing cluster is used.
+	PreProvision Point = "pre-provision"
+
+	// PostInstall is once the cluster is ready and its addons are installed.
+	PostInstall Point = "post-install"
+
+	// PreTeardown is before the cluster is deleted.
+	PreTeardown Point = "pre-teardown"
+
+	// PostRun is once the run has finished and its results are written.
+	PostRun Point = "post-run"
+)
+
+// Context describes the run a hook is invoked for.
+type Context struct {
+	Point          Point  `json:"point"`
+	JobName        string `json:"job-name,omitempty"`
+	JobID          int    `json:"job-id"`
+	Environment    string `json:"environment,omitempty"`
+	ClusterID      string `json:"cluster-id,omitempty"`
+	ClusterName    string `json:"cluster-name,omitempty"`
+	ClusterVersion string `json:"cluster-version,omitempty"`
+	UpgradeVersion string `json:"upgrade-version,omitempty"`
+	ReportDir      string `json:"report-dir,omitempty"`
+
+	// Passed is whether the run passed. It is only set at PostRun.
+	Passed *bool `json:"passed,omitempty"`
+
+	// Error is why the run failed. It is only set at PostRun.
+	Error string `json:"error,omitempty"`
+}
+
+// Hook is invoked at the point it was registered for.
+type Hook func(ctx Context) error
+
+type registeredHook struct {
+	name string
+	hook Hook
+}
+
+var (
+	mutex    sync.Mutex
+	registry = map[Point][]registeredHook{}
+
+	// client and retry are used to send webhooks.
+	client = &http.Client{Timeout: webhookTimeout}
+	retry  = func() backoff.Backoff {
+		b := backoff.Exponential(time.Second, 10*time.Second)
+		b.MaxAttempts = 3
+		return b
+	}()
+)
+
+// Register adds a hook that is invoked at point. Hooks are invoked in the order they're registered.
+func Register(point Point, name string, hook Hook) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	registry[point] = append(registry[point], registeredHook{name: name, hook: hook})
+}
+
+// Run invokes the hooks registered for the context's point, then sends the context to each webhook. Every hook
+// is invoked even if earlier ones fail, and their failures are returned together.
+func Run(ctx Context, webhooks []string) error {
+	mutex.Lock()
+	registered := append([]registeredHook{}, registry[ctx.Point]...)
+	mutex.Unlock()
+
+	var failures []string
+	for _, r := range registered {
+		if err := r.hook(ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("hook %s: %v", r.name, err))
+		}
+	}
+
+	for _, url := range webhooks {
+		if err := sendWebhook(url, ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("webhook %s: %v", url, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%s hooks failed: %s", ctx.Point, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// sendWebhook posts the context to url. Server errors are retried.
+func sendWebhook(url string, hookCtx Context) error {
+	body, err := json.Marshal(hookCtx)
+	if err != nil {
+		return err
+	}
+
+	return retry.Retry(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+
+		data, _ := ioutil.ReadAll(resp.Body)
+		err = fmt.Errorf("returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+		if resp.StatusCode < http.StatusInternalServerError {
+			return backoff.Permanent(err)
+		}
+		return err
+	})
+}
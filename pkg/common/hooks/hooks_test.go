@@ -0,0 +1,83 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func resetRegistry() func() {
+	saved := registry
+	registry = map[Point][]registeredHook{}
+	return func() { registry = saved }
+}
+
+func TestRun(t *testing.T) {
+	defer resetRegistry()()
+
+	var invoked []string
+	Register(PostInstall, "first", func(ctx Context) error {
+		invoked = append(invoked, "first:"+ctx.ClusterID)
+		return fmt.Errorf("cmdb unavailable")
+	})
+	Register(PostInstall, "second", func(ctx Context) error {
+		invoked = append(invoked, "second:"+ctx.ClusterID)
+		return nil
+	})
+	Register(PostRun, "other", func(ctx Context) error {
+		invoked = append(invoked, "other")
+		return nil
+	})
+
+	var received Context
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode context: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	err := Run(Context{Point: PostInstall, ClusterID: "abc"}, []string{server.URL})
+	if err == nil || !strings.Contains(err.Error(), "hook first: cmdb unavailable") {
+		t.Errorf("expected the failure of the first hook to be returned, got %v", err)
+	}
+
+	if fmt.Sprint(invoked) != "[first:abc second:abc]" {
+		t.Errorf("expected both post-install hooks to be invoked in order, got %v", invoked)
+	}
+
+	if received.Point != PostInstall || received.ClusterID != "abc" {
+		t.Errorf("unexpected context sent to webhook: %+v", received)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	defer func(b backoff.Backoff) { retry = b }(retry)
+	retry = backoff.Constant(time.Millisecond, 3)
+
+	for status, expectedAttempts := range map[int]int{
+		http.StatusServiceUnavailable: 3,
+		http.StatusNotFound:           1,
+	} {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(status)
+		}))
+
+		if err := Run(Context{Point: PostRun}, []string{server.URL}); err == nil {
+			t.Errorf("%d: expected an error", status)
+		}
+		server.Close()
+
+		if attempts != expectedAttempts {
+			t.Errorf("%d: expected %d attempts, got %d", status, expectedAttempts, attempts)
+		}
+	}
+}
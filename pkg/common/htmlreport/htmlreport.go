@@ -0,0 +1,297 @@
+// Package htmlreport renders the results of a run as a single self-contained HTML page, so they can be read without
+// piecing together its JUnit files, metadata, and logs.
+package htmlreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/templates"
+)
+
+const (
+	// ReportFile is the name of the HTML report written to the report directory.
+	ReportFile = "report.html"
+
+	// maxOutputBytes is how much of the end of each failed spec's output is included in the report.
+	maxOutputBytes = 64 * 1024
+
+	// maxArtifactDepth is how many directories deep artifacts are listed one by one. Deeper directories, such as the
+	// output of must-gather, are each listed as a single artifact.
+	maxArtifactDepth = 2
+)
+
+var reportTemplate *template.Template
+
+func init() {
+	var err error
+
+	reportTemplate, err = templates.LoadHTMLTemplate("/assets/reports/run.html.template")
+
+	if err != nil {
+		panic(fmt.Sprintf("error loading run report template: %v", err))
+	}
+}
+
+// Report is the results of a run.
+type Report struct {
+	Title     string
+	Passed    bool
+	Generated time.Time
+
+	// Metadata is the run's metadata, if it was written.
+	Metadata *metadata.Metadata
+
+	Phases    []Phase
+	Timings   []Timing
+	Artifacts []Artifact
+
+	// Unparsable are the JUnit files whose results couldn't be read, so they're missing from the phases.
+	Unparsable []string
+}
+
+// Phase is the results of the specs of a phase, or of another directory of JUnit files.
+type Phase struct {
+	Name     string
+	Tests    int
+	Failures int
+	Skipped  int
+	Seconds  float64
+
+	// Failed are the specs that failed.
+	Failed []Spec
+}
+
+// Spec is a failed spec.
+type Spec struct {
+	Name    string
+	Seconds float64
+	Failure string
+
+	// Output is the end of the output captured while the spec ran.
+	Output string
+}
+
+// Timing is how long a step of the run took.
+type Timing struct {
+	Name    string
+	Seconds float64
+}
+
+// Artifact is a file in the report directory, relative to it, or a directory too deep to list its files.
+type Artifact struct {
+	Path string
+	Size int64
+
+	// Files is the number of files in the directory, if the artifact is one.
+	Files int
+}
+
+// Duration formats the time the phase's specs took.
+func (p Phase) Duration() string {
+	return formatSeconds(p.Seconds)
+}
+
+// Duration formats the time the spec took.
+func (s Spec) Duration() string {
+	return formatSeconds(s.Seconds)
+}
+
+// Duration formats the time the step took.
+func (t Timing) Duration() string {
+	return formatSeconds(t.Seconds)
+}
+
+// FormattedSize formats the size of the artifact.
+func (a Artifact) FormattedSize() string {
+	switch {
+	case a.Size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(a.Size)/(1<<20))
+	case a.Size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(a.Size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", a.Size)
+	}
+}
+
+// Load reads the results of a run from its report directory.
+func Load(reportDir string) (*Report, error) {
+	report := &Report{Generated: time.Now().UTC()}
+	phases := map[string]*Phase{}
+
+	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(reportDir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+
+		if info.IsDir() {
+			if name != "." && strings.Count(name, "/")+1 >= maxArtifactDepth {
+				artifact, err := directoryArtifact(path, name)
+				if err != nil {
+					return err
+				}
+				report.Artifacts = append(report.Artifacts, artifact)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if name != ReportFile {
+			report.Artifacts = append(report.Artifacts, Artifact{Path: name, Size: info.Size()})
+		}
+
+		if base := filepath.Base(name); strings.HasPrefix(base, "junit") && strings.HasSuffix(base, ".xml") {
+			phaseName := filepath.ToSlash(filepath.Dir(name))
+			if phaseName == "." {
+				phaseName = "run"
+			}
+			phase := phases[phaseName]
+			if phase == nil {
+				phase = &Phase{Name: phaseName}
+			}
+			// one broken file shouldn't keep the rest of the results out of the report
+			if err := phase.addResults(path); err != nil {
+				logging.Warnf("Leaving %s out of the run report: %v", name, err)
+				report.Unparsable = append(report.Unparsable, name)
+				return nil
+			}
+			phases[phaseName] = phase
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading the report directory: %v", err)
+	}
+
+	for _, phase := range phases {
+		report.Phases = append(report.Phases, *phase)
+	}
+	sort.Slice(report.Phases, func(i, j int) bool { return report.Phases[i].Name < report.Phases[j].Name })
+
+	if data, err := ioutil.ReadFile(filepath.Join(reportDir, metadata.MetadataFile)); err == nil {
+		report.Metadata = &metadata.Metadata{}
+		if err = json.Unmarshal(data, report.Metadata); err != nil {
+			return nil, fmt.Errorf("error parsing metadata: %v", err)
+		}
+		report.Timings = timings(report.Metadata)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading metadata: %v", err)
+	}
+	return report, nil
+}
+
+// directoryArtifact returns an artifact for a directory holding the total size and number of its files.
+func directoryArtifact(path, name string) (Artifact, error) {
+	artifact := Artifact{Path: name + "/"}
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			artifact.Size += info.Size()
+			artifact.Files++
+		}
+		return err
+	})
+	return artifact, err
+}
+
+// addResults adds the results of a JUnit file to the phase. Nothing is added if the file can't be parsed.
+func (p *Phase) addResults(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var suite reporters.JUnitTestSuite
+	if err = xml.Unmarshal(data, &suite); err != nil {
+		return fmt.Errorf("error parsing %s: %v", file, err)
+	}
+
+	for _, testcase := range suite.TestCases {
+		p.Tests++
+		p.Seconds += testcase.Time
+		if testcase.Skipped != nil {
+			p.Skipped++
+		} else if testcase.FailureMessage != nil {
+			p.Failures++
+			p.Failed = append(p.Failed, Spec{
+				Name:    testcase.Name,
+				Seconds: testcase.Time,
+				Failure: testcase.FailureMessage.Message,
+				Output:  tail(testcase.SystemOut, maxOutputBytes),
+			})
+		}
+	}
+	return nil
+}
+
+// timings returns the durations recorded in the metadata, leaving out steps that didn't happen.
+func timings(m *metadata.Metadata) []Timing {
+	var timings []Timing
+	for _, t := range []Timing{
+		{"OCM reporting installed", m.TimeToOCMReportingInstalled},
+		{"Cluster ready", m.TimeToClusterReady},
+		{"Certificate issued", m.TimeToCertificateIssued},
+		{"Upgraded cluster", m.TimeToUpgradedCluster},
+		{"Upgraded cluster ready", m.TimeToUpgradedClusterReady},
+		{"Hibernate", m.TimeToHibernate},
+		{"Resume", m.TimeToResume},
+		{"Resumed cluster healthy", m.TimeToResumedClusterHealthy},
+		{"Machine replaced", m.TimeToMachineReplaced},
+	} {
+		if t.Seconds > 0 {
+			timings = append(timings, t)
+		}
+	}
+	return timings
+}
+
+// Render renders the report as HTML.
+func (r *Report) Render() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("error rendering run report: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Write renders the report to the report directory.
+func (r *Report) Write(reportDir string) error {
+	data, err := r.Render()
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filepath.Join(reportDir, ReportFile), data, os.FileMode(0644)); err != nil {
+		return fmt.Errorf("unable to write run report: %v", err)
+	}
+	return nil
+}
+
+// tail returns the last n bytes of s, noting when the start was cut off.
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return fmt.Sprintf("[%d bytes of earlier output omitted]\n", len(s)-n) + s[len(s)-n:]
+}
+
+// formatSeconds formats seconds as a duration rounded to the second.
+func formatSeconds(seconds float64) string {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Second).String()
+}
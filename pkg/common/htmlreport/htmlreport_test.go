@@ -0,0 +1,101 @@
+package htmlreport
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, data string) {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "htmlreport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "install/junit_abc.xml", `<testsuite name="OSD e2e suite" tests="3" failures="1">
+<testcase name="[install] creates a route" time="12.5"><failure type="Failure">expected &lt;script&gt; to be ready</failure><system-out>pod logs</system-out></testcase>
+<testcase name="[install] lists nodes" time="1"></testcase>
+<testcase name="[install] skipped spec" time="0"><skipped></skipped></testcase>
+</testsuite>`)
+	writeFile(t, dir, "install/spec-durations.json", `[]`)
+	writeFile(t, dir, "upgrade/junit_broken.xml", `<testsuite name="OSD e2e suite"`)
+	writeFile(t, dir, "install/must-gather/quay-io/namespaces/a.yaml", "a: b")
+	writeFile(t, dir, "install/must-gather/quay-io/namespaces/c.yaml", "c: d")
+	writeFile(t, dir, "metadata.json", `{"cluster-id": "abc", "cluster-version": "openshift-v4.5.1", "console-url": "https://console.example.com", "time-to-cluster-ready": "1800", "time-to-upgraded-cluster": "0"}`)
+
+	report, err := Load(dir)
+	if err != nil {
+		t.Fatalf("failed to load report: %v", err)
+	}
+	report.Title = "osde2e-stage-aws-e2e"
+
+	if len(report.Phases) != 1 {
+		t.Fatalf("expected one phase, got %v", report.Phases)
+	}
+	if phase := report.Phases[0]; phase.Name != "install" || phase.Tests != 3 || phase.Failures != 1 || phase.Skipped != 1 || phase.Seconds != 13.5 {
+		t.Errorf("unexpected install phase: %+v", phase)
+	}
+	if len(report.Timings) != 1 || report.Timings[0].Duration() != "30m0s" {
+		t.Errorf("expected only the time to cluster ready, got %v", report.Timings)
+	}
+	if len(report.Unparsable) != 1 || report.Unparsable[0] != "upgrade/junit_broken.xml" {
+		t.Errorf("expected the broken JUnit file to be recorded, got %v", report.Unparsable)
+	}
+	if len(report.Artifacts) != 5 {
+		t.Errorf("expected five artifacts, got %v", report.Artifacts)
+	}
+	if artifact := report.Artifacts[1]; artifact.Path != "install/must-gather/" || artifact.Files != 2 || artifact.Size != 8 {
+		t.Errorf("expected must-gather to be listed as one artifact, got %+v", artifact)
+	}
+
+	if err = report.Write(dir); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, ReportFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, expected := range []string{
+		"<title>osde2e-stage-aws-e2e</title>",
+		"openshift-v4.5.1",
+		`<a href="https://console.example.com">`,
+		"[install] creates a route (13s)",
+		"expected &lt;script&gt; to be ready",
+		"pod logs",
+		`<a href="install/junit_abc.xml">`,
+		`<a href="install/must-gather/">install/must-gather/</a> (2 files)`,
+		`<li><a href="upgrade/junit_broken.xml">`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected the report to contain %s, got:\n%s", expected, html)
+		}
+	}
+
+	// the report doesn't list itself
+	if report, err = Load(dir); err != nil || len(report.Artifacts) != 5 {
+		t.Errorf("expected the report to be left out of the artifacts, got %v: %v", report, err)
+	}
+}
+
+func TestTail(t *testing.T) {
+	if output := tail("short", 10); output != "short" {
+		t.Errorf("expected short output to be kept, got %s", output)
+	}
+	if output := tail("0123456789", 4); output != "[6 bytes of earlier output omitted]\n6789" {
+		t.Errorf("expected the end of the output, got %s", output)
+	}
+}
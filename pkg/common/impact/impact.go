@@ -0,0 +1,130 @@
+// Package impact selects the suites affected by a set of changed components.
+package impact
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/markbates/pkger"
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultMapping is the maintained mapping of components to suites.
+const DefaultMapping = "/assets/impact/mapping.yaml"
+
+// Mapping maps components to the suites that exercise them.
+type Mapping struct {
+	// Always are suites that run whenever any component changes.
+	Always []string `yaml:"always"`
+
+	// Components are the components with known suites.
+	Components []Component `yaml:"components"`
+}
+
+// Component is a component of the payload and the suites affected when it changes.
+type Component struct {
+	// Name is the name of the component.
+	Name string `yaml:"name"`
+
+	// Images are the images shipped by the component, as named in the release payload.
+	Images []string `yaml:"images"`
+
+	// Suites are prefixes of the test contexts affected by the component.
+	Suites []string `yaml:"suites"`
+}
+
+// LoadMapping loads a mapping from a file or, if file is empty, the maintained mapping.
+func LoadMapping(file string) (*Mapping, error) {
+	var data []byte
+	var err error
+	if file != "" {
+		if data, err = ioutil.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("error reading impact mapping %s: %v", file, err)
+		}
+	} else {
+		reader, err := pkger.Open(DefaultMapping)
+		if err != nil {
+			return nil, fmt.Errorf("error opening impact mapping: %v", err)
+		}
+		defer reader.Close()
+
+		if data, err = ioutil.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("error reading impact mapping: %v", err)
+		}
+	}
+
+	mapping := &Mapping{}
+	if err = yaml.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("error parsing impact mapping: %v", err)
+	}
+	return mapping, nil
+}
+
+// Suites returns the suites impacted by the changed components or images. If any of them isn't in the
+// mapping, the impact is unknown and every suite should run, which is reported by returning false.
+func (m *Mapping) Suites(changed []string) ([]string, bool) {
+	impacted := map[string]bool{}
+	for _, suite := range m.Always {
+		impacted[suite] = true
+	}
+
+	for _, name := range changed {
+		component := m.component(imageName(name))
+		if component == nil {
+			return nil, false
+		}
+
+		for _, suite := range component.Suites {
+			impacted[suite] = true
+		}
+	}
+
+	suites := make([]string, 0, len(impacted))
+	for suite := range impacted {
+		suites = append(suites, suite)
+	}
+	sort.Strings(suites)
+	return suites, true
+}
+
+// component finds a component by its name or the name of one of its images.
+func (m *Mapping) component(name string) *Component {
+	for i, component := range m.Components {
+		if component.Name == name {
+			return &m.Components[i]
+		}
+		for _, image := range component.Images {
+			if image == name {
+				return &m.Components[i]
+			}
+		}
+	}
+	return nil
+}
+
+// imageName strips the repository, tag, and digest from an image reference.
+func imageName(ref string) string {
+	name := strings.TrimSpace(ref)
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// Selects returns true if a test context belongs to one of the suites.
+func Selects(suites []string, context string) bool {
+	for _, suite := range suites {
+		if strings.HasPrefix(context, suite) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package impact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuites(t *testing.T) {
+	mapping := &Mapping{
+		Always: []string{"[Suite: e2e] Cluster state"},
+		Components: []Component{
+			{Name: "cluster-ingress-operator", Images: []string{"haproxy-router"}, Suites: []string{"[Suite: e2e] Routes"}},
+			{Name: "certman-operator", Suites: []string{"[Suite: operators] [OSD] Certman Operator"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		changed []string
+		suites  []string
+		known   bool
+	}{
+		{
+			name:    "component name",
+			changed: []string{"certman-operator"},
+			suites:  []string{"[Suite: e2e] Cluster state", "[Suite: operators] [OSD] Certman Operator"},
+			known:   true,
+		},
+		{
+			name:    "image reference",
+			changed: []string{"quay.io/openshift/haproxy-router:4.5", "registry.example.com/certman-operator@sha256:abc"},
+			suites:  []string{"[Suite: e2e] Cluster state", "[Suite: e2e] Routes", "[Suite: operators] [OSD] Certman Operator"},
+			known:   true,
+		},
+		{
+			name:    "unmapped component",
+			changed: []string{"certman-operator", "kube-apiserver"},
+			known:   false,
+		},
+	}
+
+	for _, test := range tests {
+		suites, known := mapping.Suites(test.changed)
+		if known != test.known {
+			t.Errorf("%s: expected known to be %t, got %t", test.name, test.known, known)
+		}
+		if known && !reflect.DeepEqual(suites, test.suites) {
+			t.Errorf("%s: expected suites %v, got %v", test.name, test.suites, suites)
+		}
+	}
+}
+
+func TestSelects(t *testing.T) {
+	suites := []string{"[Suite: e2e] Routes", "[Suite: app-builds]"}
+
+	if !Selects(suites, "[Suite: app-builds] OpenShift Application Build E2E") {
+		t.Errorf("expected a context under an impacted suite to be selected")
+	}
+
+	if Selects(suites, "[Suite: e2e] Storage") {
+		t.Errorf("expected a context outside the impacted suites not to be selected")
+	}
+}
+
+func TestDefaultMapping(t *testing.T) {
+	mapping, err := LoadMapping("")
+	if err != nil {
+		t.Fatalf("failed to load the maintained mapping: %v", err)
+	}
+
+	for _, component := range mapping.Components {
+		if component.Name == "" || len(component.Suites) == 0 {
+			t.Errorf("component %+v must have a name and suites", component)
+		}
+	}
+}
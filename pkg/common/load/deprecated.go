@@ -0,0 +1,150 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+const (
+	// DeprecatedEnvTag is the Go struct tag containing a comma-delimited list of environment variables that
+	// have been replaced by the option. They are still honored when the current variable isn't set.
+	DeprecatedEnvTag = "deprecatedEnv"
+
+	// DeprecatedYAMLTag is the Go struct tag containing a comma-delimited list of YAML keys, in the same
+	// section as the option, that have been replaced by the option.
+	DeprecatedYAMLTag = "deprecatedYAML"
+)
+
+var (
+	deprecationsMutex sync.Mutex
+	deprecations      = map[string]bool{}
+)
+
+// Deprecations returns warnings for every deprecated config option that has been used so far.
+func Deprecations() []string {
+	deprecationsMutex.Lock()
+	defer deprecationsMutex.Unlock()
+
+	warnings := make([]string, 0, len(deprecations))
+	for warning := range deprecations {
+		warnings = append(warnings, warning)
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// warnDeprecated records and logs a deprecation warning once.
+func warnDeprecated(format string, args ...interface{}) {
+	warning := fmt.Sprintf(format, args...)
+
+	deprecationsMutex.Lock()
+	defer deprecationsMutex.Unlock()
+	if !deprecations[warning] {
+		logging.Warnf("%s", warning)
+		deprecations[warning] = true
+	}
+}
+
+// deprecatedEnv returns the value of a deprecated environment variable for the field, if one is set.
+func deprecatedEnv(f reflect.StructField) (string, bool) {
+	tag, ok := f.Tag.Lookup(DeprecatedEnvTag)
+	if !ok {
+		return "", false
+	}
+
+	for _, env := range strings.Split(tag, ",") {
+		if value := os.Getenv(env); value != "" {
+			warnDeprecated("environment variable %s is deprecated, use %s instead", env, f.Tag.Get(EnvVarTag))
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// migrateYAML renames deprecated keys in a YAML document to the keys of the options replacing them.
+func migrateYAML(data []byte, t reflect.Type, source string) ([]byte, error) {
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	migrated, changed := migrateMapSlice(doc, t, source, "")
+	if !changed {
+		return data, nil
+	}
+	return yaml.Marshal(migrated)
+}
+
+// migrateMapSlice walks a YAML map alongside the struct type it will be unmarshaled into.
+func migrateMapSlice(doc yaml.MapSlice, t reflect.Type, source, path string) (yaml.MapSlice, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return doc, false
+	}
+
+	changed := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		if tag, ok := f.Tag.Lookup(DeprecatedYAMLTag); ok {
+			for _, old := range strings.Split(tag, ",") {
+				var renamed bool
+				if doc, renamed = renameKey(doc, old, key); renamed {
+					warnDeprecated("%s: %s%s is deprecated, use %s%s instead", source, path, old, path, key)
+					changed = true
+				}
+			}
+		}
+
+		for j, item := range doc {
+			if item.Key != key {
+				continue
+			}
+			if nested, ok := item.Value.(yaml.MapSlice); ok {
+				var nestedChanged bool
+				if doc[j].Value, nestedChanged = migrateMapSlice(nested, f.Type, source, path+key+"."); nestedChanged {
+					changed = true
+				}
+			}
+		}
+	}
+	return doc, changed
+}
+
+// renameKey renames old to key in a YAML map. If both are present, the current key wins and the old one is dropped.
+func renameKey(doc yaml.MapSlice, old, key string) (yaml.MapSlice, bool) {
+	hasKey := false
+	for _, item := range doc {
+		if item.Key == key {
+			hasKey = true
+		}
+	}
+
+	result := yaml.MapSlice{}
+	renamed := false
+	for _, item := range doc {
+		if item.Key == old {
+			renamed = true
+			if hasKey {
+				continue
+			}
+			item.Key = key
+		}
+		result = append(result, item)
+	}
+	return result, renamed
+}
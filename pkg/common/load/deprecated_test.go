@@ -0,0 +1,109 @@
+package load
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type deprecatedTestConfig struct {
+	Name string `env:"DEPRECATION_TEST_NAME" deprecatedEnv:"DEPRECATION_TEST_OLD_NAME" yaml:"name" deprecatedYAML:"oldName"`
+
+	Nested struct {
+		Count int `env:"DEPRECATION_TEST_COUNT" yaml:"count" deprecatedYAML:"number,amount"`
+	} `yaml:"nested"`
+}
+
+func writeYAML(t *testing.T, contents string) string {
+	return writeConfig(t, "config.yaml", contents)
+}
+
+func writeConfig(t *testing.T, name, contents string) string {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	file := filepath.Join(dir, name)
+	if err = ioutil.WriteFile(file, []byte(contents), os.FileMode(0644)); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return file
+}
+
+func hasWarning(substr string) bool {
+	for _, warning := range Deprecations() {
+		if strings.Contains(warning, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDeprecatedYAML(t *testing.T) {
+	file := writeYAML(t, "oldName: legacy\nnested:\n  amount: 3\n")
+	defer os.RemoveAll(filepath.Dir(file))
+
+	cfg := &deprecatedTestConfig{}
+	if err := IntoObject(cfg, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Name != "legacy" {
+		t.Errorf("expected deprecated key to set name, got %s", cfg.Name)
+	}
+
+	if cfg.Nested.Count != 3 {
+		t.Errorf("expected deprecated nested key to set count, got %d", cfg.Nested.Count)
+	}
+
+	if !hasWarning("nested.amount is deprecated, use nested.count instead") {
+		t.Errorf("expected a warning for the deprecated nested key, got %v", Deprecations())
+	}
+}
+
+func TestDeprecatedYAMLPrefersReplacement(t *testing.T) {
+	file := writeYAML(t, "oldName: legacy\nname: current\n")
+	defer os.RemoveAll(filepath.Dir(file))
+
+	cfg := &deprecatedTestConfig{}
+	if err := IntoObject(cfg, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Name != "current" {
+		t.Errorf("expected the replacement key to take precedence, got %s", cfg.Name)
+	}
+}
+
+func TestDeprecatedEnv(t *testing.T) {
+	os.Setenv("DEPRECATION_TEST_OLD_NAME", "from-old-env")
+	defer os.Unsetenv("DEPRECATION_TEST_OLD_NAME")
+
+	cfg := &deprecatedTestConfig{}
+	if err := IntoObject(cfg, nil, "", ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Name != "from-old-env" {
+		t.Errorf("expected deprecated environment variable to set name, got %s", cfg.Name)
+	}
+
+	if !hasWarning("DEPRECATION_TEST_OLD_NAME is deprecated, use DEPRECATION_TEST_NAME instead") {
+		t.Errorf("expected a warning for the deprecated environment variable, got %v", Deprecations())
+	}
+
+	os.Setenv("DEPRECATION_TEST_NAME", "from-env")
+	defer os.Unsetenv("DEPRECATION_TEST_NAME")
+
+	cfg = &deprecatedTestConfig{}
+	if err := IntoObject(cfg, nil, "", ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Name != "from-env" {
+		t.Errorf("expected the replacement environment variable to take precedence, got %s", cfg.Name)
+	}
+}
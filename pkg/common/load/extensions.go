@@ -0,0 +1,205 @@
+package load
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// extension is a config object owned by a component, such as a cluster provider, rather than the global config.
+type extension struct {
+	section string
+	object  interface{}
+}
+
+var (
+	extensionsMutex sync.Mutex
+	extensions      = map[interface{}][]extension{}
+)
+
+// RegisterExtension registers a config object that is loaded whenever parent is loaded by IntoObject.
+// The object uses the same struct tags as the global config and is read from the given top-level YAML section.
+// This lets components own their options and defaults without adding them to the global config.
+func RegisterExtension(parent interface{}, section string, object interface{}) {
+	if reflect.TypeOf(object).Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("config extension for section %s must be a pointer", section))
+	}
+
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+	extensions[parent] = append(extensions[parent], extension{section, object})
+}
+
+// Extensions returns the config objects registered for parent, keyed by their YAML section.
+func Extensions(parent interface{}) map[string]interface{} {
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+
+	objects := map[string]interface{}{}
+	for _, ext := range extensions[parent] {
+		objects[ext.section] = ext.object
+	}
+	return objects
+}
+
+// ExtensionSections returns the sections of the config objects registered for parent in a stable order.
+func ExtensionSections(parent interface{}) []string {
+	var sections []string
+	for section := range Extensions(parent) {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// CloneExtensions registers empty config objects for clone in the same sections, and of the same types, as the ones
+// registered for parent. Loading and validating clone, such as a reloaded config, then covers them without touching
+// the objects of parent, which components keep using until MoveExtensions.
+func CloneExtensions(parent, clone interface{}) {
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+
+	for _, ext := range extensions[parent] {
+		object := reflect.New(reflect.TypeOf(ext.object).Elem()).Interface()
+		extensions[clone] = append(extensions[clone], extension{ext.section, object})
+	}
+}
+
+// MoveExtensions copies the config objects registered for from into the objects registered for to in the same
+// sections, then forgets the objects of from.
+func MoveExtensions(from, to interface{}) {
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+
+	for _, src := range extensions[from] {
+		for _, dst := range extensions[to] {
+			if dst.section == src.section {
+				reflect.ValueOf(dst.object).Elem().Set(reflect.ValueOf(src.object).Elem())
+			}
+		}
+	}
+	delete(extensions, from)
+}
+
+// ForgetExtensions forgets the config objects registered for parent, such as a cloned config that is discarded.
+func ForgetExtensions(parent interface{}) {
+	extensionsMutex.Lock()
+	defer extensionsMutex.Unlock()
+	delete(extensions, parent)
+}
+
+// loadExtensions loads the config objects registered for parent using the same sources as the parent.
+func loadExtensions(parent interface{}, configs []string, profiles []profile, customConfig, customConfigFormat string) error {
+	extensionsMutex.Lock()
+	exts := append([]extension{}, extensions[parent]...)
+	extensionsMutex.Unlock()
+
+	for _, ext := range exts {
+		if err := loadDefaults(ext.object); err != nil {
+			return fmt.Errorf("error loading defaults for %s: %v", ext.section, err)
+		}
+
+		wrapper := sectionWrapper(ext)
+		for _, config := range configs {
+			if err := loadYAMLFromConfigs(wrapper.Interface(), config); err != nil {
+				return fmt.Errorf("error loading %s from YAML: %v", ext.section, err)
+			}
+		}
+
+		for _, p := range profiles {
+			if err := p.load(wrapper.Interface()); err != nil {
+				return fmt.Errorf("error loading %s from profile %s: %v", ext.section, p.name, err)
+			}
+		}
+
+		if customConfig != "" {
+			if err := loadFromFile(wrapper.Interface(), customConfig, customConfigFormat); err != nil {
+				return fmt.Errorf("error loading %s from custom config: %v", ext.section, err)
+			}
+		}
+
+		if err := loadFromEnv(ext.object); err != nil {
+			return fmt.Errorf("error loading %s from environment: %v", ext.section, err)
+		}
+	}
+	return nil
+}
+
+// overlayExtensions loads the given YAML files and then the custom config into the config objects registered for
+// parent.
+func overlayExtensions(parent interface{}, files []string, customConfig, customConfigFormat string) error {
+	extensionsMutex.Lock()
+	exts := append([]extension{}, extensions[parent]...)
+	extensionsMutex.Unlock()
+
+	for _, ext := range exts {
+		wrapper := sectionWrapper(ext)
+		for _, file := range files {
+			if err := loadFromFile(wrapper.Interface(), file, YAMLFormat); err != nil {
+				return fmt.Errorf("error loading %s from %s: %v", ext.section, file, err)
+			}
+		}
+
+		if customConfig != "" {
+			if err := loadFromFile(wrapper.Interface(), customConfig, customConfigFormat); err != nil {
+				return fmt.Errorf("error loading %s from %s: %v", ext.section, customConfig, err)
+			}
+		}
+
+		if err := loadFromEnv(ext.object); err != nil {
+			return fmt.Errorf("error loading %s from environment: %v", ext.section, err)
+		}
+	}
+	return nil
+}
+
+// sectionWrapper returns a pointer to a struct with a single field, tagged with the extension's section, that
+// points at the extension object. Unmarshaling a full config document into it only populates that section.
+func sectionWrapper(ext extension) reflect.Value {
+	wrapperType := reflect.StructOf([]reflect.StructField{
+		{
+			Name: "Section",
+			Type: reflect.TypeOf(ext.object),
+			Tag:  reflect.StructTag(fmt.Sprintf(`yaml:"%s"`, ext.section)),
+		},
+	})
+
+	wrapper := reflect.New(wrapperType)
+	wrapper.Elem().Field(0).Set(reflect.ValueOf(ext.object))
+	return wrapper
+}
+
+// MarshalExtensions adds the config objects registered for parent to a resolved YAML config, merging
+// them into any existing section of the same name.
+func MarshalExtensions(parent interface{}, cfg yaml.MapSlice) (yaml.MapSlice, error) {
+	objects := Extensions(parent)
+	for _, section := range ExtensionSections(parent) {
+		data, err := yaml.Marshal(objects[section])
+		if err != nil {
+			return nil, err
+		}
+
+		values := yaml.MapSlice{}
+		if err = yaml.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+
+		merged := false
+		for i, item := range cfg {
+			if item.Key != section {
+				continue
+			}
+			existing, _ := item.Value.(yaml.MapSlice)
+			cfg[i].Value = append(existing, values...)
+			merged = true
+		}
+
+		if !merged {
+			cfg = append(cfg, yaml.MapItem{Key: section, Value: values})
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,106 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+type extensionTestParent struct {
+	Provider struct {
+		Name string `env:"EXTENSION_TEST_PROVIDER" default:"global" yaml:"name"`
+	} `yaml:"provider"`
+}
+
+type extensionTestConfig struct {
+	RoleARN string `env:"EXTENSION_TEST_ROLE_ARN" default:"arn:default" yaml:"roleARN"`
+	Retries int    `env:"EXTENSION_TEST_RETRIES" default:"3" yaml:"retries"`
+}
+
+func TestExtensions(t *testing.T) {
+	parent, ext := &extensionTestParent{}, &extensionTestConfig{}
+	RegisterExtension(parent, "provider", ext)
+
+	file := writeYAML(t, "provider:\n  name: custom\n  roleARN: arn:custom\n")
+	defer os.RemoveAll(filepath.Dir(file))
+
+	os.Setenv("EXTENSION_TEST_RETRIES", "5")
+	defer os.Unsetenv("EXTENSION_TEST_RETRIES")
+
+	if err := IntoObject(parent, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if parent.Provider.Name != "custom" {
+		t.Errorf("expected the parent to be loaded from the same section, got %s", parent.Provider.Name)
+	}
+
+	if ext.RoleARN != "arn:custom" {
+		t.Errorf("expected the extension to be loaded from YAML, got %s", ext.RoleARN)
+	}
+
+	if ext.Retries != 5 {
+		t.Errorf("expected the extension to be loaded from the environment, got %d", ext.Retries)
+	}
+
+	cfg, err := MarshalExtensions(parent, yaml.MapSlice{
+		{Key: "provider", Value: yaml.MapSlice{{Key: "name", Value: "custom"}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal extensions: %v", err)
+	}
+
+	if len(cfg) != 1 {
+		t.Fatalf("expected the extension to be merged into the existing section, got %v", cfg)
+	}
+
+	section, _ := cfg[0].Value.(yaml.MapSlice)
+	if len(section) != 3 || section[1].Key != "roleARN" || section[1].Value != "arn:custom" {
+		t.Errorf("unexpected merged section %v", section)
+	}
+}
+
+func TestExtensionDefaults(t *testing.T) {
+	parent, ext := &extensionTestParent{}, &extensionTestConfig{}
+	RegisterExtension(parent, "provider", ext)
+
+	if err := IntoObject(parent, nil, "", ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if ext.RoleARN != "arn:default" || ext.Retries != 3 {
+		t.Errorf("expected extension defaults to be loaded, got %+v", ext)
+	}
+
+	if _, ok := Extensions(&extensionTestParent{})["provider"]; ok {
+		t.Errorf("extensions should only be registered for their parent")
+	}
+}
+
+func TestCloneExtensions(t *testing.T) {
+	parent, ext := &extensionTestParent{}, &extensionTestConfig{RoleARN: "arn:current"}
+	RegisterExtension(parent, "provider", ext)
+
+	file := writeYAML(t, "provider:\n  roleARN: arn:reloaded\n  retries: -1\n")
+	defer os.RemoveAll(filepath.Dir(file))
+
+	clone := &extensionTestParent{}
+	CloneExtensions(parent, clone)
+	if err := IntoObject(clone, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if ext.RoleARN != "arn:current" {
+		t.Errorf("expected loading the clone to leave the extension alone, got %s", ext.RoleARN)
+	}
+
+	MoveExtensions(clone, parent)
+	if ext.RoleARN != "arn:reloaded" || ext.Retries != -1 {
+		t.Errorf("expected the reloaded extension to be moved, got %+v", ext)
+	}
+	if len(Extensions(clone)) != 0 {
+		t.Errorf("expected the extensions of the clone to be forgotten")
+	}
+}
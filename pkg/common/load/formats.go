@@ -0,0 +1,91 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Formats of config files.
+const (
+	// YAMLFormat is the format of configs without a .json or .toml extension.
+	YAMLFormat = "yaml"
+
+	// JSONFormat is the format of configs with a .json extension.
+	JSONFormat = "json"
+
+	// TOMLFormat is the format of configs with a .toml extension.
+	TOMLFormat = "toml"
+)
+
+// fileFormat returns the format of a config file. Unless it is overridden, the format is detected from the file's
+// extension.
+func fileFormat(name, override string) (string, error) {
+	switch override {
+	case YAMLFormat, JSONFormat, TOMLFormat:
+		return override, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown config format %s, expected %s, %s, or %s", override, YAMLFormat, JSONFormat, TOMLFormat)
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return JSONFormat, nil
+	case ".toml":
+		return TOMLFormat, nil
+	default:
+		return YAMLFormat, nil
+	}
+}
+
+// toYAML converts a config to YAML, so configs in every format use the same keys and are migrated and loaded the
+// same way.
+func toYAML(data []byte, format string) ([]byte, error) {
+	var doc interface{}
+	switch format {
+	case JSONFormat:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("error parsing JSON: %v", err)
+		}
+		doc = jsonNumbers(doc)
+	case TOMLFormat:
+		table := map[string]interface{}{}
+		if _, err := toml.Decode(string(data), &table); err != nil {
+			return nil, fmt.Errorf("error parsing TOML: %v", err)
+		}
+		doc = table
+	default:
+		return data, nil
+	}
+	return yaml.Marshal(doc)
+}
+
+// jsonNumbers replaces JSON numbers with integers or floats. Otherwise, large integers would be written to YAML in
+// scientific notation, which can't be loaded into integer options.
+func jsonNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jsonNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
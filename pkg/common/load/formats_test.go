@@ -0,0 +1,112 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type formatTestConfig struct {
+	Name    string   `env:"FORMAT_TEST_NAME" yaml:"name"`
+	Enabled bool     `env:"FORMAT_TEST_ENABLED" yaml:"enabled"`
+	Tags    []string `env:"FORMAT_TEST_TAGS" yaml:"tags"`
+
+	Nested struct {
+		Count int `env:"FORMAT_TEST_COUNT" yaml:"count" deprecatedYAML:"amount"`
+		Size  int `env:"FORMAT_TEST_SIZE" yaml:"size"`
+	} `yaml:"nested"`
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		description string
+		name        string
+		format      string
+		contents    string
+	}{
+		{
+			description: "yaml",
+			name:        "config.yaml",
+			contents:    "name: test\nenabled: true\ntags: [a, b]\nnested:\n  count: 3\n  size: 4000000000\n",
+		},
+		{
+			description: "json",
+			name:        "config.json",
+			contents:    `{"name": "test", "enabled": true, "tags": ["a", "b"], "nested": {"count": 3, "size": 4000000000}}`,
+		},
+		{
+			description: "toml",
+			name:        "config.toml",
+			contents:    "name = \"test\"\nenabled = true\ntags = [\"a\", \"b\"]\n\n[nested]\ncount = 3\nsize = 4000000000\n",
+		},
+		{
+			description: "json with deprecated key",
+			name:        "config.json",
+			contents:    `{"name": "test", "enabled": true, "tags": ["a", "b"], "nested": {"amount": 3, "size": 4000000000}}`,
+		},
+		{
+			description: "toml format override",
+			name:        "config",
+			format:      TOMLFormat,
+			contents:    "name = \"test\"\nenabled = true\ntags = [\"a\", \"b\"]\n\n[nested]\ncount = 3\nsize = 4000000000\n",
+		},
+	}
+
+	for _, test := range tests {
+		file := writeConfig(t, test.name, test.contents)
+		defer os.RemoveAll(filepath.Dir(file))
+
+		cfg := &formatTestConfig{}
+		if err := IntoObject(cfg, nil, file, test.format); err != nil {
+			t.Errorf("%s: failed to load config: %v", test.description, err)
+			continue
+		}
+
+		if cfg.Name != "test" || !cfg.Enabled || len(cfg.Tags) != 2 || cfg.Nested.Count != 3 || cfg.Nested.Size != 4000000000 {
+			t.Errorf("%s: config wasn't loaded correctly: %+v", test.description, cfg)
+		}
+	}
+}
+
+func TestFormatPrecedence(t *testing.T) {
+	file := writeConfig(t, "config.json", `{"name": "custom", "nested": {"count": 3}}`)
+	defer os.RemoveAll(filepath.Dir(file))
+
+	os.Setenv("FORMAT_TEST_COUNT", "5")
+	defer os.Unsetenv("FORMAT_TEST_COUNT")
+
+	cfg := &formatTestConfig{}
+	if err := IntoObject(cfg, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Name != "custom" {
+		t.Errorf("expected the JSON config to set name, got %s", cfg.Name)
+	}
+	if cfg.Nested.Count != 5 {
+		t.Errorf("expected the environment to take precedence over the JSON config, got %d", cfg.Nested.Count)
+	}
+
+	overlay := writeConfig(t, "overlay.toml", "name = \"overlay\"\n")
+	defer os.RemoveAll(filepath.Dir(overlay))
+
+	if err := Overlay(cfg, nil, overlay, ""); err != nil {
+		t.Fatalf("failed to overlay config: %v", err)
+	}
+	if cfg.Name != "overlay" || cfg.Nested.Count != 5 {
+		t.Errorf("expected the TOML overlay to set name and keep the environment's count, got %+v", cfg)
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	file := writeConfig(t, "config.json", `{"name": `)
+	defer os.RemoveAll(filepath.Dir(file))
+
+	if err := IntoObject(&formatTestConfig{}, nil, file, ""); err == nil {
+		t.Errorf("expected an error loading invalid JSON")
+	}
+
+	if err := IntoObject(&formatTestConfig{}, nil, file, "xml"); err == nil {
+		t.Errorf("expected an error loading an unknown format")
+	}
+}
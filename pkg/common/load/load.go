@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/markbates/pkger"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/util"
 	"gopkg.in/yaml.v2"
 )
@@ -37,11 +38,15 @@ func init() {
 	rand.Seed(time.Now().Unix())
 }
 
-// IntoObject populates an object based on the tags specified in the object.
-func IntoObject(object interface{}, configs []string, customConfig string) error {
+// IntoObject populates an object based on the tags specified in the object. The custom config may be YAML, JSON, or
+// TOML. Its format is detected from its extension unless customConfigFormat is set. The profiles listed by the
+// custom config, or by PROFILES, are merged in order before it. The source of every option is recorded for
+// EffectiveConfig.
+func IntoObject(object interface{}, configs []string, customConfig, customConfigFormat string) error {
 	if objectType := reflect.TypeOf(object); objectType.Kind() != reflect.Ptr {
 		return fmt.Errorf("the supplied object must be a pointer")
 	}
+	trackSources(object)
 
 	// Populate the defaults first, then read the YAML, then override with the environment
 	// 1. Load defaults
@@ -55,11 +60,32 @@ func IntoObject(object interface{}, configs []string, customConfig string) error
 		}
 	}
 
-	// 2b. Custom YAML configs
+	// 2b. Profiles, each after the profiles it builds on
+	profiles, err := resolveProfiles(customConfig, customConfigFormat)
+	if err != nil {
+		return fmt.Errorf("error resolving profiles: %v", err)
+	}
+
+	var sources []string
+	for _, p := range profiles {
+		sources = append(sources, p.source())
+	}
+	if len(profiles) > 0 {
+		logging.Infof("Merging profiles in order: %s", strings.Join(sources, ", "))
+	}
+	recordProfiles(object, sources)
+
+	for _, p := range profiles {
+		if err := p.load(object); err != nil {
+			return fmt.Errorf("error loading profile %s: %v", p.name, err)
+		}
+	}
+
+	// 2c. Custom configs
 	if customConfig != "" {
-		log.Printf("Custom YAML config provided, loading from %s", customConfig)
-		if err := loadYAMLFromFile(object, customConfig); err != nil {
-			return fmt.Errorf("error loading custom config from YAML: %v", err)
+		logging.Infof("Custom config provided, loading from %s", customConfig)
+		if err := loadFromFile(object, customConfig, customConfigFormat); err != nil {
+			return fmt.Errorf("error loading custom config: %v", err)
 		}
 	}
 
@@ -69,6 +95,76 @@ func IntoObject(object interface{}, configs []string, customConfig string) error
 		return fmt.Errorf("error loading config from environment: %v", err)
 	}
 
+	// 4. Load config owned by other components, such as cluster providers, from the same sources.
+	if err := loadExtensions(object, configs, profiles, customConfig, customConfigFormat); err != nil {
+		return fmt.Errorf("error loading config extensions: %v", err)
+	}
+
+	// 5. Replace references to secrets with the secrets they point to.
+	if err := resolveSecrets(object); err != nil {
+		return err
+	}
+
+	recordEnvSources(object)
+	return nil
+}
+
+// Overlay loads the given YAML files and then the custom config into an already populated object, in order, and
+// then reapplies the environment so that environment variables keep precedence.
+func Overlay(object interface{}, files []string, customConfig, customConfigFormat string) error {
+	if objectType := reflect.TypeOf(object); objectType.Kind() != reflect.Ptr {
+		return fmt.Errorf("the supplied object must be a pointer")
+	}
+
+	for _, file := range files {
+		if err := loadFromFile(object, file, YAMLFormat); err != nil {
+			return fmt.Errorf("error loading %s: %v", file, err)
+		}
+	}
+
+	if customConfig != "" {
+		if err := loadFromFile(object, customConfig, customConfigFormat); err != nil {
+			return fmt.Errorf("error loading %s: %v", customConfig, err)
+		}
+	}
+
+	if err := loadFromEnv(object); err != nil {
+		return fmt.Errorf("error loading config from environment: %v", err)
+	}
+
+	if err := overlayExtensions(object, files, customConfig, customConfigFormat); err != nil {
+		return fmt.Errorf("error loading config extensions: %v", err)
+	}
+
+	if err := resolveSecrets(object); err != nil {
+		return err
+	}
+
+	recordEnvSources(object)
+	return nil
+}
+
+// File loads a YAML file written from an already loaded object, such as a config handed off to another process, into
+// the object and the config objects registered for it as is. The environment isn't reapplied, as it was already
+// applied to the object the file was written from.
+func File(object interface{}, file string) error {
+	if objectType := reflect.TypeOf(object); objectType.Kind() != reflect.Ptr {
+		return fmt.Errorf("the supplied object must be a pointer")
+	}
+
+	if err := loadFromFile(object, file, YAMLFormat); err != nil {
+		return fmt.Errorf("error loading %s: %v", file, err)
+	}
+
+	extensionsMutex.Lock()
+	exts := append([]extension{}, extensions[object]...)
+	extensionsMutex.Unlock()
+
+	for _, ext := range exts {
+		if err := loadFromFile(sectionWrapper(ext).Interface(), file, YAMLFormat); err != nil {
+			return fmt.Errorf("error loading %s from %s: %v", ext.section, file, err)
+		}
+	}
 	return nil
 }
 
@@ -81,7 +177,9 @@ func load(v reflect.Value, source string) error {
 
 		if f.Type.Kind() == reflect.Struct {
 			// Specific to supporting AddOns via ENV
-			load(v.FieldByIndex(f.Index), source)
+			if err := load(v.FieldByIndex(f.Index), source); err != nil {
+				return err
+			}
 		} else {
 			if source == "default" {
 				if setValue, ok = f.Tag.Lookup(DefaultTag); !ok {
@@ -89,8 +187,12 @@ func load(v reflect.Value, source string) error {
 				}
 			}
 			if source == "env" {
-				if env, ok := f.Tag.Lookup(EnvVarTag); ok {
-					if setValue = os.Getenv(env); setValue == "" {
+				env, ok := f.Tag.Lookup(EnvVarTag)
+				if !ok {
+					continue
+				}
+				if setValue = os.Getenv(env); setValue == "" {
+					if setValue, ok = deprecatedEnv(f); !ok {
 						continue
 					}
 				}
@@ -110,49 +212,82 @@ func load(v reflect.Value, source string) error {
 // It also works on handling special cases for default loading.
 func loadDefaults(object interface{}) error {
 	v := reflect.ValueOf(object).Elem()
-	load(v, "default")
-	return nil
+	return load(v, "default")
 }
 
 // loadYAMLFromConfigs accepts a config name and attempts to unmarshal the config from the /configs directory.
 func loadYAMLFromConfigs(object interface{}, name string) error {
+	data, err := readConfig(name)
+	if err != nil {
+		return err
+	}
+	return unmarshalInto(object, data, name, ConfigSource+name)
+}
+
+// readConfig reads a config from the /configs directory.
+func readConfig(name string) ([]byte, error) {
 	var file http.File
-	var data []byte
 	var err error
 
 	if file, err = pkger.Open(filepath.Join("/configs", name+".yaml")); err != nil {
-		return fmt.Errorf("error trying to open config %s: %v", name, err)
+		return nil, fmt.Errorf("error trying to open config %s: %v", name, err)
 	}
+	defer file.Close()
 
-	if data, err = ioutil.ReadAll(file); err != nil {
-		return err
-	}
+	return ioutil.ReadAll(file)
+}
 
-	if err = yaml.Unmarshal(data, object); err != nil {
+// loadFromFile accepts file info and attempts to unmarshal the file into the config. If format is empty, it is
+// detected from the file's extension.
+func loadFromFile(object interface{}, name, format string) error {
+	data, path, err := readFile(name, format)
+	if err != nil {
 		return err
 	}
-
-	return nil
+	return unmarshalInto(object, data, name, FileSource+path)
 }
 
-// loadYAMLFromFile accepts file info and attempts to unmarshal the file into the // config.
-func loadYAMLFromFile(object interface{}, name string) error {
+// readFile reads a config file as YAML and returns its absolute path. If format is empty, it is detected from the
+// file's extension.
+func readFile(name, format string) ([]byte, string, error) {
 	var data []byte
 	var err error
 	var dir, path string
 
-	if dir, err = os.Getwd(); err != nil {
-		log.Fatalf("Unable to get CWD: %s", err.Error())
+	if format, err = fileFormat(name, format); err != nil {
+		return nil, "", err
 	}
-	// TODO: This needs to change once we stop branching out execution the way we do it currently
-	// It's fragile
-	if path, err = filepath.Abs(filepath.Join(dir, name)); err != nil {
-		return err
+
+	if filepath.IsAbs(name) {
+		path = name
+	} else {
+		if dir, err = os.Getwd(); err != nil {
+			log.Fatalf("Unable to get CWD: %s", err.Error())
+		}
+		// TODO: This needs to change once we stop branching out execution the way we do it currently
+		// It's fragile
+		if path, err = filepath.Abs(filepath.Join(dir, name)); err != nil {
+			return nil, "", err
+		}
 	}
 
 	path = filepath.Clean(path)
 
 	if data, err = ioutil.ReadFile(path); err != nil {
+		return nil, "", err
+	}
+
+	if data, err = toYAML(data, format); err != nil {
+		return nil, "", fmt.Errorf("error reading %s: %v", name, err)
+	}
+	return data, path, nil
+}
+
+// unmarshalInto migrates a YAML config and unmarshals it into the object, recording the source of every option it
+// sets.
+func unmarshalInto(object interface{}, data []byte, name, source string) error {
+	var err error
+	if data, err = migrateYAML(data, reflect.TypeOf(object), name); err != nil {
 		return err
 	}
 
@@ -160,25 +295,35 @@ func loadYAMLFromFile(object interface{}, name string) error {
 		return err
 	}
 
-	return nil
+	return recordFileSources(object, data, source)
 }
 
 // loadFromEnv sets values from environment variables specified in `env` tags.
 func loadFromEnv(object interface{}) error {
 	v := reflect.ValueOf(object).Elem()
-	load(v, "env")
-
-	return nil
+	return load(v, "env")
 }
 
+// durationType is the type of time.Duration options, which are parsed as durations rather than integers.
+var durationType = reflect.TypeOf(time.Duration(0))
+
 func processValueFromString(f reflect.StructField, field reflect.Value, value string) error {
+	if f.Type == durationType {
+		if duration, err := time.ParseDuration(value); err == nil {
+			field.SetInt(int64(duration))
+		} else {
+			return fmt.Errorf("error parsing duration value for field %s: %v", f.Name, err)
+		}
+		return nil
+	}
+
 	switch f.Type.Kind() {
 	case reflect.String:
 		// Add special processing for the __TMP_DIR__ string so that directory creation is handled
 		// internally to config loading.
 		if value == "__TMP_DIR__" {
 			if dir, err := ioutil.TempDir("", "osde2e"); err == nil {
-				log.Printf("Generated temporary directory %s for field %s", dir, f.Name)
+				logging.Debugf("Generated temporary directory %s for field %s", dir, f.Name)
 				field.SetString(dir)
 			} else {
 				return fmt.Errorf("error generating temporary directory for field %s: %v", f.Name, err)
@@ -186,7 +331,7 @@ func processValueFromString(f reflect.StructField, field reflect.Value, value st
 		} else if rndStringRegex.MatchString(value) {
 			if rndStringLen, err := strconv.Atoi(rndStringRegex.FindStringSubmatch(value)[1]); err == nil {
 				rndString := util.RandomStr(rndStringLen)
-				log.Printf("Generated random string %s for field %s", rndString, f.Name)
+				logging.Debugf("Generated random string %s for field %s", rndString, f.Name)
 				field.SetString(rndString)
 			} else {
 				return fmt.Errorf("error generating random string for field %s: %v", f.Name, err)
@@ -203,12 +348,14 @@ func processValueFromString(f reflect.StructField, field reflect.Value, value st
 	case reflect.Slice:
 		fallthrough
 	case reflect.Array:
+		// the value replaces the slice, so that reapplying the environment doesn't add its values again
 		if value != "" {
-			value := string(value)
 			a := strings.Split(value, ",")
+			values := reflect.MakeSlice(f.Type, 0, len(a))
 			for i := range a {
-				field.Set(reflect.Append(field, reflect.ValueOf(a[i])))
+				values = reflect.Append(values, reflect.ValueOf(a[i]))
 			}
+			field.Set(values)
 		}
 		// We shouldn't be setting any slices with string vars
 		// Specifically, Addons and Kubeconfig Contents
@@ -220,6 +367,32 @@ func processValueFromString(f reflect.StructField, field reflect.Value, value st
 		} else {
 			return fmt.Errorf("error parsing int value for field %s: %v", f.Name, err)
 		}
+	case reflect.Float32:
+		fallthrough
+	case reflect.Float64:
+		if num, err := strconv.ParseFloat(value, 64); err == nil {
+			field.SetFloat(num)
+		} else {
+			return fmt.Errorf("error parsing float value for field %s: %v", f.Name, err)
+		}
+	case reflect.Map:
+		// Maps are set as comma-delimited key=value pairs, ex. "team=sd,env=stage"
+		if f.Type.Key().Kind() != reflect.String || f.Type.Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s for field %s", f.Type, f.Name)
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(f.Type))
+		}
+		for _, pair := range strings.Split(value, ",") {
+			if pair == "" {
+				continue
+			}
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("error parsing map value for field %s: %q is not a key=value pair", f.Name, pair)
+			}
+			field.SetMapIndex(reflect.ValueOf(strings.TrimSpace(kv[0])), reflect.ValueOf(strings.TrimSpace(kv[1])))
+		}
 	}
 	return nil
 }
@@ -0,0 +1,107 @@
+package load
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type valueTestConfig struct {
+	Timeout time.Duration     `env:"VALUE_TEST_TIMEOUT" default:"45m" yaml:"timeout"`
+	Ratio   float64           `env:"VALUE_TEST_RATIO" default:"0.75" yaml:"ratio"`
+	Labels  map[string]string `env:"VALUE_TEST_LABELS" default:"team=sd,env=stage" yaml:"labels"`
+	Count   int               `yaml:"count"`
+	IDs     []string          `env:"VALUE_TEST_IDS" yaml:"ids"`
+}
+
+func TestValueTypes(t *testing.T) {
+	cfg := &valueTestConfig{}
+	if err := IntoObject(cfg, nil, "", ""); err != nil {
+		t.Fatalf("failed to load defaults: %v", err)
+	}
+
+	expected := valueTestConfig{
+		Timeout: 45 * time.Minute,
+		Ratio:   0.75,
+		Labels:  map[string]string{"team": "sd", "env": "stage"},
+	}
+	if !reflect.DeepEqual(*cfg, expected) {
+		t.Errorf("expected defaults %+v, got %+v", expected, *cfg)
+	}
+
+	file := writeConfig(t, "custom.yaml", "timeout: 1h30m\nratio: 1.25\nlabels:\n  team: osd\ncount: 3\n")
+	defer os.RemoveAll(filepath.Dir(file))
+
+	os.Setenv("VALUE_TEST_LABELS", "owner = osde2e")
+	defer os.Unsetenv("VALUE_TEST_LABELS")
+
+	cfg = &valueTestConfig{}
+	if err := IntoObject(cfg, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	// maps are merged, like they are when loading YAML
+	expected = valueTestConfig{
+		Timeout: 90 * time.Minute,
+		Ratio:   1.25,
+		Labels:  map[string]string{"team": "osd", "env": "stage", "owner": "osde2e"},
+		Count:   3,
+	}
+	if !reflect.DeepEqual(*cfg, expected) {
+		t.Errorf("expected %+v, got %+v", expected, *cfg)
+	}
+}
+
+func TestValueTypeErrors(t *testing.T) {
+	tests := []struct {
+		env   string
+		value string
+		err   string
+	}{
+		{"VALUE_TEST_TIMEOUT", "45", "error parsing duration value for field Timeout"},
+		{"VALUE_TEST_RATIO", "high", "error parsing float value for field Ratio"},
+		{"VALUE_TEST_LABELS", "team", "is not a key=value pair"},
+	}
+
+	for _, test := range tests {
+		os.Setenv(test.env, test.value)
+		err := IntoObject(&valueTestConfig{}, nil, "", "")
+		os.Unsetenv(test.env)
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s=%s: expected error containing %q, got %v", test.env, test.value, test.err, err)
+		}
+	}
+}
+
+func TestOverlaySlices(t *testing.T) {
+	file := writeConfig(t, "custom.yaml", "ids: [c]\ncount: 3\n")
+	defer os.RemoveAll(filepath.Dir(file))
+	overlay := filepath.Join(filepath.Dir(file), "overlay.yaml")
+	if err := ioutil.WriteFile(overlay, []byte("count: 4\n"), 0644); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+
+	os.Setenv("VALUE_TEST_IDS", "a,b")
+	defer os.Unsetenv("VALUE_TEST_IDS")
+
+	cfg := &valueTestConfig{}
+	if err := IntoObject(cfg, nil, file, ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(cfg.IDs, expected) {
+		t.Errorf("expected the environment to replace the slice with %v, got %v", expected, cfg.IDs)
+	}
+
+	// the environment is reapplied after the overlay without adding its values again
+	if err := Overlay(cfg, []string{overlay}, file, ""); err != nil {
+		t.Fatalf("failed to overlay config: %v", err)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(cfg.IDs, expected) || cfg.Count != 3 {
+		t.Errorf("expected %v and the custom config's count after the overlay, got %v and %d", expected, cfg.IDs, cfg.Count)
+	}
+}
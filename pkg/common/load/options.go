@@ -0,0 +1,82 @@
+package load
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Option describes a config option declared by the struct tags of an object loaded by IntoObject.
+type Option struct {
+	// Key is the YAML path of the option, ex. "ocm.env".
+	Key string
+
+	// Env is the environment variable that sets the option, if any.
+	Env string
+
+	// DeprecatedEnv are deprecated environment variables that still set the option.
+	DeprecatedEnv []string
+
+	// Section is the documentation section of the option.
+	Section string
+
+	// Default is the default value of the option, if HasDefault is set.
+	Default    string
+	HasDefault bool
+
+	// Rules are the validation rules of the option.
+	Rules []string
+
+	// Secret is set for options holding secrets.
+	Secret bool
+
+	// Type is the Go type of the option.
+	Type reflect.Type
+}
+
+// Options describes the options of an object, and of the config objects registered for it, in the order they're
+// declared.
+func Options(object interface{}) []Option {
+	options := describeOptions(reflect.TypeOf(object).Elem(), "", nil)
+
+	objects := Extensions(object)
+	for _, section := range ExtensionSections(object) {
+		options = describeOptions(reflect.TypeOf(objects[section]).Elem(), section, options)
+	}
+	return options
+}
+
+// describeOptions appends the options declared by the fields of a struct, descending into nested structs.
+func describeOptions(t reflect.Type, prefix string, options []Option) []Option {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Tag.Get("yaml") == "-" {
+			continue
+		}
+
+		path := yamlPath(prefix, f)
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}) {
+			options = describeOptions(f.Type, path, options)
+			continue
+		}
+
+		option := Option{
+			Key:     path,
+			Env:     f.Tag.Get(EnvVarTag),
+			Section: f.Tag.Get(SectionTag),
+			Type:    f.Type,
+		}
+		option.Default, option.HasDefault = f.Tag.Lookup(DefaultTag)
+		_, option.Secret = f.Tag.Lookup(SecretTag)
+		if deprecated, ok := f.Tag.Lookup(DeprecatedEnvTag); ok {
+			option.DeprecatedEnv = strings.Split(deprecated, ",")
+		}
+		if rules, ok := f.Tag.Lookup(ValidateTag); ok {
+			for _, rule := range strings.Split(rules, ",") {
+				option.Rules = append(option.Rules, strings.TrimSpace(rule))
+			}
+		}
+		options = append(options, option)
+	}
+	return options
+}
@@ -0,0 +1,171 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/markbates/pkger"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ProfilesKey is the top-level key of a custom config or profile listing the profiles it builds on.
+	ProfilesKey = "profiles"
+
+	// ProfilesEnvVar is the environment variable containing a comma-delimited list of profiles. It overrides the
+	// profiles listed by the custom config.
+	ProfilesEnvVar = "PROFILES"
+)
+
+// profileExtensions are the extensions tried, in order, when looking for a profile file.
+var profileExtensions = []string{".yaml", ".yml", ".json", ".toml"}
+
+// profile is a config merged before the custom config. It is either a file or a pre-canned config.
+type profile struct {
+	name string
+	path string
+}
+
+// source describes where the profile was loaded from.
+func (p profile) source() string {
+	if p.path == "" {
+		return ConfigSource + p.name
+	}
+	return FileSource + p.path
+}
+
+// read reads the profile as YAML.
+func (p profile) read() ([]byte, error) {
+	if p.path == "" {
+		return readConfig(p.name)
+	}
+	data, _, err := readFile(p.path, "")
+	return data, err
+}
+
+// load merges the profile into the object.
+func (p profile) load(object interface{}) error {
+	if p.path == "" {
+		return loadYAMLFromConfigs(object, p.name)
+	}
+	return loadFromFile(object, p.path, "")
+}
+
+// resolveProfiles returns the profiles to merge, in order. They are named by PROFILES or, if it isn't set, by the
+// custom config. Every profile is preceded by the profiles it builds on, and each is merged only once.
+func resolveProfiles(customConfig, customConfigFormat string) ([]profile, error) {
+	var names []string
+	var dir string
+	if customConfig != "" {
+		data, path, err := readFile(customConfig, customConfigFormat)
+		if err != nil {
+			return nil, err
+		}
+		if names, err = profileNames(data); err != nil {
+			return nil, fmt.Errorf("error reading profiles of %s: %v", customConfig, err)
+		}
+		dir = filepath.Dir(path)
+	}
+
+	if env := os.Getenv(ProfilesEnvVar); env != "" {
+		names = strings.Split(env, ",")
+	}
+
+	var resolved []profile
+	for _, name := range names {
+		if err := addProfile(strings.TrimSpace(name), dir, nil, &resolved); err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
+
+// addProfile appends a profile to resolved after the profiles it builds on. The chain is the sources of the profiles
+// currently being added, used to detect cycles.
+func addProfile(name, dir string, chain []string, resolved *[]profile) error {
+	if name == "" {
+		return nil
+	}
+
+	p, err := findProfile(name, dir)
+	if err != nil {
+		return err
+	}
+
+	for i, source := range chain {
+		if source == p.source() {
+			return fmt.Errorf("profile cycle: %s", strings.Join(append(chain[i:], source), " -> "))
+		}
+	}
+
+	for _, r := range *resolved {
+		if r.source() == p.source() {
+			return nil
+		}
+	}
+
+	data, err := p.read()
+	if err != nil {
+		return fmt.Errorf("error reading profile %s: %v", name, err)
+	}
+
+	parents, err := profileNames(data)
+	if err != nil {
+		return fmt.Errorf("error reading profiles of profile %s: %v", name, err)
+	}
+
+	parentDir := ""
+	if p.path != "" {
+		parentDir = filepath.Dir(p.path)
+	}
+	for _, parent := range parents {
+		if err := addProfile(parent, parentDir, append(chain, p.source()), resolved); err != nil {
+			return err
+		}
+	}
+
+	*resolved = append(*resolved, p)
+	return nil
+}
+
+// findProfile looks for a profile file in dir, trying each supported extension unless the name has one, and then
+// for a pre-canned config of the same name. Pre-canned configs can only build on other pre-canned configs.
+func findProfile(name, dir string) (profile, error) {
+	if dir != "" {
+		candidates := []string{name}
+		if filepath.Ext(name) == "" {
+			candidates = nil
+			for _, ext := range profileExtensions {
+				candidates = append(candidates, name+ext)
+			}
+		}
+
+		for _, candidate := range candidates {
+			path := candidate
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			if _, err := os.Stat(path); err == nil {
+				return profile{name: name, path: filepath.Clean(path)}, nil
+			}
+		}
+	}
+
+	if _, err := pkger.Stat(filepath.Join("/configs", name+".yaml")); err == nil {
+		return profile{name: name}, nil
+	}
+	return profile{}, fmt.Errorf("profile %s not found", name)
+}
+
+// profileNames returns the profiles listed by a YAML config.
+func profileNames(data []byte) ([]string, error) {
+	doc := struct {
+		Profiles []string `yaml:"profiles"`
+	}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Profiles, nil
+}
@@ -0,0 +1,142 @@
+package load
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type profileTestConfig struct {
+	Provider string `env:"PROFILE_TEST_PROVIDER" default:"mock" yaml:"provider"`
+	Region   string `env:"PROFILE_TEST_REGION" yaml:"region"`
+	Env      string `env:"PROFILE_TEST_ENV" yaml:"env"`
+	Upgrade  bool   `env:"PROFILE_TEST_UPGRADE" yaml:"upgrade"`
+	Suffix   string `env:"PROFILE_TEST_SUFFIX" yaml:"suffix"`
+}
+
+// writeProfiles writes each config into a temporary directory and returns the directory.
+func writeProfiles(t *testing.T, configs map[string]string) string {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	for name, contents := range configs {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), os.FileMode(0644)); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestProfiles(t *testing.T) {
+	dir := writeProfiles(t, map[string]string{
+		"base.yaml":    "provider: ocm\nregion: us-east-1\nenv: prod\n",
+		"aws.json":     `{"profiles": ["base"], "region": "us-west-2"}`,
+		"stage.toml":   "env = \"stage\"\n",
+		"upgrade.yaml": "profiles: [base]\nupgrade: true\nregion: eu-west-1\n",
+		"custom.yaml":  "profiles: [aws, stage, upgrade]\nsuffix: custom\n",
+	})
+	defer os.RemoveAll(dir)
+
+	os.Setenv("PROFILE_TEST_SUFFIX", "env")
+	defer os.Unsetenv("PROFILE_TEST_SUFFIX")
+
+	cfg := &profileTestConfig{}
+	if err := IntoObject(cfg, nil, filepath.Join(dir, "custom.yaml"), ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	expectedCfg := profileTestConfig{Provider: "ocm", Region: "eu-west-1", Env: "stage", Upgrade: true, Suffix: "env"}
+	if *cfg != expectedCfg {
+		t.Errorf("expected %+v, got %+v", expectedCfg, *cfg)
+	}
+
+	expectedProfiles := []string{
+		FileSource + filepath.Join(dir, "base.yaml"),
+		FileSource + filepath.Join(dir, "aws.json"),
+		FileSource + filepath.Join(dir, "stage.toml"),
+		FileSource + filepath.Join(dir, "upgrade.yaml"),
+	}
+	if profiles := Profiles(cfg); !reflect.DeepEqual(profiles, expectedProfiles) {
+		t.Errorf("expected profiles %v, got %v", expectedProfiles, profiles)
+	}
+
+	expectedSettings := []Setting{
+		{Key: "env", Value: "stage", Source: FileSource + filepath.Join(dir, "stage.toml")},
+		{Key: "provider", Value: "ocm", Source: FileSource + filepath.Join(dir, "base.yaml")},
+		{Key: "region", Value: "eu-west-1", Source: FileSource + filepath.Join(dir, "upgrade.yaml")},
+		{Key: "suffix", Value: "env", Source: EnvSource + "PROFILE_TEST_SUFFIX"},
+		{Key: "upgrade", Value: true, Source: FileSource + filepath.Join(dir, "upgrade.yaml")},
+	}
+	if settings := EffectiveConfig(cfg); !reflect.DeepEqual(settings, expectedSettings) {
+		t.Errorf("expected settings %v, got %v", expectedSettings, settings)
+	}
+}
+
+func TestProfilesFromEnvironment(t *testing.T) {
+	dir := writeProfiles(t, map[string]string{
+		"aws.yaml":    "region: us-west-2\n",
+		"stage.yaml":  "env: stage\n",
+		"custom.yaml": "profiles: [aws]\n",
+	})
+	defer os.RemoveAll(dir)
+
+	os.Setenv(ProfilesEnvVar, "stage")
+	defer os.Unsetenv(ProfilesEnvVar)
+
+	cfg := &profileTestConfig{}
+	if err := IntoObject(cfg, nil, filepath.Join(dir, "custom.yaml"), ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Env != "stage" || cfg.Region != "" {
+		t.Errorf("expected only the profiles in %s to be loaded, got %+v", ProfilesEnvVar, *cfg)
+	}
+
+	for _, setting := range EffectiveConfig(cfg) {
+		if setting.Key == "provider" && setting.Source != DefaultSource {
+			t.Errorf("expected provider to come from its default, got %s", setting.Source)
+		}
+		if setting.Key == "region" && setting.Source != UnsetSource {
+			t.Errorf("expected region to be unset, got %s", setting.Source)
+		}
+	}
+}
+
+func TestProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs map[string]string
+		err     string
+	}{
+		{
+			name: "missing profile",
+			configs: map[string]string{
+				"custom.yaml": "profiles: [missing]\n",
+			},
+			err: "profile missing not found",
+		},
+		{
+			name: "cycle",
+			configs: map[string]string{
+				"a.yaml":      "profiles: [b]\n",
+				"b.yaml":      "profiles: [a]\n",
+				"custom.yaml": "profiles: [a]\n",
+			},
+			err: "profile cycle",
+		},
+	}
+
+	for _, test := range tests {
+		dir := writeProfiles(t, test.configs)
+		err := IntoObject(&profileTestConfig{}, nil, filepath.Join(dir, "custom.yaml"), "")
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+		}
+		os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,217 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Sources of option values reported by EffectiveConfig.
+const (
+	// DefaultSource is the source of options set by their default tag.
+	DefaultSource = "default"
+
+	// UnsetSource is the source of options that were never set.
+	UnsetSource = "unset"
+
+	// ConfigSource prefixes the name of the pre-canned config that set an option.
+	ConfigSource = "config:"
+
+	// FileSource prefixes the path of the config file that set an option.
+	FileSource = "file:"
+
+	// EnvSource prefixes the environment variable that set an option.
+	EnvSource = "env:"
+)
+
+// Setting is the final value of an option and the source that supplied it.
+type Setting struct {
+	Key    string      `json:"key" yaml:"key"`
+	Value  interface{} `json:"value" yaml:"value"`
+	Source string      `json:"source" yaml:"source"`
+}
+
+// provenance records where the options of an object loaded by IntoObject came from.
+type provenance struct {
+	profiles []string
+	sources  map[string]string
+}
+
+var (
+	provenanceMutex sync.Mutex
+	provenances     = map[interface{}]*provenance{}
+)
+
+// trackSources starts recording the sources of an object's options, forgetting any previous load.
+func trackSources(object interface{}) {
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+	provenances[object] = &provenance{sources: map[string]string{}}
+}
+
+// recordProfiles records the profiles loaded into an object, in the order they were merged.
+func recordProfiles(object interface{}, profiles []string) {
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+	if p, ok := provenances[object]; ok {
+		p.profiles = profiles
+	}
+}
+
+// recordFileSources records the source of every option set by a YAML document. Objects that aren't tracked, such
+// as the wrappers used to load extensions, are ignored.
+func recordFileSources(object interface{}, data []byte, source string) error {
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+
+	p, ok := provenances[object]
+	if !ok {
+		return nil
+	}
+
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	recordKeys(p, doc, "", source)
+	return nil
+}
+
+// recordKeys records the source of every leaf of a YAML document.
+func recordKeys(p *provenance, doc yaml.MapSlice, prefix, source string) {
+	for _, item := range doc {
+		path := fmt.Sprint(item.Key)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		if section, ok := item.Value.(yaml.MapSlice); ok {
+			recordKeys(p, section, path, source)
+			continue
+		}
+		p.sources[path] = source
+	}
+}
+
+// recordEnvSources records the environment variables that set the options of an object and of the config objects
+// registered for it.
+func recordEnvSources(object interface{}) {
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+
+	p, ok := provenances[object]
+	if !ok {
+		return
+	}
+
+	recordEnv(p, reflect.ValueOf(object).Elem(), "")
+	objects := Extensions(object)
+	for _, section := range ExtensionSections(object) {
+		recordEnv(p, reflect.ValueOf(objects[section]).Elem(), section)
+	}
+}
+
+// recordEnv records the environment variables that set the fields of a struct, descending into nested structs.
+func recordEnv(p *provenance, v reflect.Value, prefix string) {
+	for i := 0; i < v.Type().NumField(); i++ {
+		f := v.Type().Field(i)
+		path := yamlPath(prefix, f)
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}) {
+			recordEnv(p, v.Field(i), path)
+			continue
+		}
+
+		if env := setEnvVar(f); env != "" {
+			p.sources[path] = EnvSource + env
+		}
+	}
+}
+
+// setEnvVar returns the environment variable, or deprecated environment variable, that set a field.
+func setEnvVar(f reflect.StructField) string {
+	env, ok := f.Tag.Lookup(EnvVarTag)
+	if !ok {
+		return ""
+	}
+	if os.Getenv(env) != "" {
+		return env
+	}
+
+	if tag, ok := f.Tag.Lookup(DeprecatedEnvTag); ok {
+		for _, deprecated := range strings.Split(tag, ",") {
+			if os.Getenv(deprecated) != "" {
+				return deprecated
+			}
+		}
+	}
+	return ""
+}
+
+// Profiles returns the profiles loaded into an object by IntoObject, in the order they were merged.
+func Profiles(object interface{}) []string {
+	provenanceMutex.Lock()
+	defer provenanceMutex.Unlock()
+
+	if p, ok := provenances[object]; ok {
+		return append([]string{}, p.profiles...)
+	}
+	return nil
+}
+
+// EffectiveConfig returns the final value of every option of an object loaded by IntoObject, and of the config
+// objects registered for it, along with the default, config, file, or environment variable that supplied it.
+// Settings are sorted by key.
+func EffectiveConfig(object interface{}) []Setting {
+	provenanceMutex.Lock()
+	sources := map[string]string{}
+	if p, ok := provenances[object]; ok {
+		for key, source := range p.sources {
+			sources[key] = source
+		}
+	}
+	provenanceMutex.Unlock()
+
+	var settings []Setting
+	settings = effectiveSettings(reflect.ValueOf(object).Elem(), "", sources, settings)
+	objects := Extensions(object)
+	for _, section := range ExtensionSections(object) {
+		settings = effectiveSettings(reflect.ValueOf(objects[section]).Elem(), section, sources, settings)
+	}
+
+	sort.SliceStable(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings
+}
+
+// effectiveSettings appends the settings of every exported field of a struct, descending into nested structs.
+func effectiveSettings(v reflect.Value, prefix string, sources map[string]string, settings []Setting) []Setting {
+	for i := 0; i < v.Type().NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" || f.Tag.Get("yaml") == "-" {
+			continue
+		}
+
+		path := yamlPath(prefix, f)
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}) {
+			settings = effectiveSettings(v.Field(i), path, sources, settings)
+			continue
+		}
+
+		source, ok := sources[path]
+		if !ok {
+			source = UnsetSource
+			if _, ok = f.Tag.Lookup(DefaultTag); ok {
+				source = DefaultSource
+			}
+		}
+		settings = append(settings, Setting{Key: path, Value: v.Field(i).Interface(), Source: source})
+	}
+	return settings
+}
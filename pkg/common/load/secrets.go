@@ -0,0 +1,141 @@
+package load
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// SecretTag is the Go struct tag marking options that hold secrets. The value of a secret option may be a reference
+// to a secret kept in a secrets backend, ex. "vault://secret/data/osde2e#ocm-token", which is replaced by the secret
+// when the option is loaded. Secrets are never written to reports.
+const SecretTag = "secret"
+
+// SecretResolver returns the secret a reference points to. The reference doesn't include the scheme.
+type SecretResolver func(ref string) (string, error)
+
+var (
+	secretsMutex    sync.Mutex
+	secretResolvers = map[string]SecretResolver{}
+
+	// resolvedSecrets caches secrets by reference, since the config and state are loaded from the same sources.
+	resolvedSecrets = map[string]string{}
+)
+
+// RegisterSecretResolver registers the resolver of references with the given scheme, ex. "vault" for
+// "vault://secret/data/osde2e#ocm-token".
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+	secretResolvers[scheme] = resolver
+}
+
+// SecretSchemes returns the schemes of the registered secret resolvers in a stable order.
+func SecretSchemes() []string {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	var schemes []string
+	for scheme := range secretResolvers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// SecretKeys returns the YAML paths of the secret options of an object and of the config objects registered for it.
+func SecretKeys(object interface{}) [][]string {
+	var keys [][]string
+	walkSecrets(reflect.ValueOf(object).Elem(), "", func(path string, f reflect.StructField, v reflect.Value) {
+		keys = append(keys, strings.Split(path, "."))
+	})
+
+	objects := Extensions(object)
+	for _, section := range ExtensionSections(object) {
+		walkSecrets(reflect.ValueOf(objects[section]).Elem(), section, func(path string, f reflect.StructField, v reflect.Value) {
+			keys = append(keys, strings.Split(path, "."))
+		})
+	}
+	return keys
+}
+
+// resolveSecrets replaces the references in the secret options of an object, and of the config objects registered
+// for it, with the secrets they point to. Values that aren't references to a registered scheme are left as is.
+func resolveSecrets(object interface{}) error {
+	var err error
+	resolve := func(path string, f reflect.StructField, v reflect.Value) {
+		if err != nil || v.Kind() != reflect.String {
+			return
+		}
+
+		var secret string
+		var ok bool
+		if secret, ok, err = resolveSecret(v.String()); err != nil {
+			err = fmt.Errorf("error resolving secret %s: %v", optionName(f, path), err)
+		} else if ok {
+			v.SetString(secret)
+		}
+	}
+
+	walkSecrets(reflect.ValueOf(object).Elem(), "", resolve)
+	objects := Extensions(object)
+	for _, section := range ExtensionSections(object) {
+		walkSecrets(reflect.ValueOf(objects[section]).Elem(), section, resolve)
+	}
+	return err
+}
+
+// resolveSecret returns the secret a value refers to, if it is a reference to a registered scheme.
+func resolveSecret(value string) (string, bool, error) {
+	i := strings.Index(value, "://")
+	if i < 0 {
+		return "", false, nil
+	}
+
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	scheme := value[:i]
+	resolver, ok := secretResolvers[scheme]
+	if !ok {
+		return "", false, nil
+	}
+
+	if secret, ok := resolvedSecrets[value]; ok {
+		return secret, true, nil
+	}
+
+	secret, err := resolver(value[i+len("://"):])
+	if err != nil {
+		return "", false, err
+	}
+
+	logging.Debugf("Resolved secret %s", value)
+	resolvedSecrets[value] = secret
+	return secret, true, nil
+}
+
+// walkSecrets calls fn for every secret option of a struct, descending into nested structs.
+func walkSecrets(v reflect.Value, prefix string, fn func(path string, f reflect.StructField, v reflect.Value)) {
+	for i := 0; i < v.Type().NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		path := yamlPath(prefix, f)
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}) {
+			walkSecrets(v.Field(i), path, fn)
+			continue
+		}
+
+		if _, ok := f.Tag.Lookup(SecretTag); ok {
+			fn(path, f, v.Field(i))
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type secretTestConfig struct {
+	Token   string `env:"SECRET_TEST_TOKEN" yaml:"token" secret:"true"`
+	Webhook string `env:"SECRET_TEST_WEBHOOK" yaml:"webhook" secret:"true"`
+	Name    string `env:"SECRET_TEST_NAME" yaml:"name"`
+	Nested  struct {
+		Password string `env:"SECRET_TEST_PASSWORD" yaml:"password" secret:"true"`
+	} `yaml:"nested"`
+}
+
+func TestSecrets(t *testing.T) {
+	calls := 0
+	RegisterSecretResolver("test", func(ref string) (string, error) {
+		calls++
+		if ref == "missing" {
+			return "", fmt.Errorf("secret not found")
+		}
+		return "resolved-" + ref, nil
+	})
+
+	os.Setenv("SECRET_TEST_TOKEN", "test://token")
+	os.Setenv("SECRET_TEST_WEBHOOK", "https://hooks.example.com/abc")
+	os.Setenv("SECRET_TEST_NAME", "test://name")
+	os.Setenv("SECRET_TEST_PASSWORD", "test://token")
+	defer func() {
+		for _, env := range []string{"SECRET_TEST_TOKEN", "SECRET_TEST_WEBHOOK", "SECRET_TEST_NAME", "SECRET_TEST_PASSWORD"} {
+			os.Unsetenv(env)
+		}
+	}()
+
+	cfg := &secretTestConfig{}
+	if err := IntoObject(cfg, nil, "", ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if cfg.Token != "resolved-token" || cfg.Nested.Password != "resolved-token" {
+		t.Errorf("expected secret references to be resolved, got %+v", *cfg)
+	}
+	if cfg.Webhook != "https://hooks.example.com/abc" {
+		t.Errorf("expected values that aren't references to be left as is, got %s", cfg.Webhook)
+	}
+	if cfg.Name != "test://name" {
+		t.Errorf("expected options that aren't secrets to be left as is, got %s", cfg.Name)
+	}
+	if calls != 1 {
+		t.Errorf("expected each reference to be resolved once, got %d calls", calls)
+	}
+
+	expectedKeys := [][]string{{"token"}, {"webhook"}, {"nested", "password"}}
+	if keys := SecretKeys(cfg); !reflect.DeepEqual(keys, expectedKeys) {
+		t.Errorf("expected secret keys %v, got %v", expectedKeys, keys)
+	}
+
+	os.Setenv("SECRET_TEST_TOKEN", "test://missing")
+	err := IntoObject(&secretTestConfig{}, nil, "", "")
+	if err == nil || !strings.Contains(err.Error(), "SECRET_TEST_TOKEN (token)") || !strings.Contains(err.Error(), "secret not found") {
+		t.Errorf("expected an error naming the option, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package load
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ValidateTag is the Go struct tag containing a comma-delimited list of rules the option's value must satisfy:
+//
+//	required          the option must be set
+//	oneof=a b c       the option must be one of the space-delimited values
+//	range=min:max     the option must be between min and max, inclusive; either bound may be omitted
+//	url               the option must be an absolute URL
+//	duration          the option must be a Go duration, ex. "1h30m"
+//
+// Rules other than required are skipped for empty strings, lists, and maps. Rules for list options apply to every item.
+const ValidateTag = "validate"
+
+// Validate checks the rules in the validate tags of an object loaded by IntoObject, and of the config objects
+// registered for it. Every violation is reported in the returned error, not just the first.
+func Validate(object interface{}) error {
+	v := reflect.ValueOf(object)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("the supplied object must be a pointer to a struct")
+	}
+
+	var violations *multierror.Error
+	validateStruct(v.Elem(), "", &violations)
+
+	objects := Extensions(object)
+	for _, section := range ExtensionSections(object) {
+		validateStruct(reflect.ValueOf(objects[section]).Elem(), section, &violations)
+	}
+
+	if violations != nil {
+		violations.ErrorFormat = formatViolations
+	}
+	return violations.ErrorOrNil()
+}
+
+// formatViolations lists violations one per line so they can all be fixed at once.
+func formatViolations(errs []error) string {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = "\t* " + err.Error()
+	}
+	return fmt.Sprintf("%d invalid config option(s):\n%s", len(errs), strings.Join(lines, "\n"))
+}
+
+// validateStruct checks every field of a struct, descending into nested structs. The prefix is the YAML path of
+// the struct.
+func validateStruct(v reflect.Value, prefix string, violations **multierror.Error) {
+	for i := 0; i < v.Type().NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		path := yamlPath(prefix, f)
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}) {
+			validateStruct(v.Field(i), path, violations)
+			continue
+		}
+
+		rules, ok := f.Tag.Lookup(ValidateTag)
+		if !ok {
+			continue
+		}
+
+		for _, rule := range strings.Split(rules, ",") {
+			if err := checkRule(strings.TrimSpace(rule), v.Field(i)); err != nil {
+				*violations = multierror.Append(*violations, fmt.Errorf("%s: %v", optionName(f, path), err))
+			}
+		}
+	}
+}
+
+// yamlPath returns the YAML path of a field.
+func yamlPath(prefix string, f reflect.StructField) string {
+	key := strings.Split(f.Tag.Get("yaml"), ",")[0]
+	if key == "" {
+		key = f.Name
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// optionName names an option the way a user would set it.
+func optionName(f reflect.StructField, path string) string {
+	if env, ok := f.Tag.Lookup(EnvVarTag); ok {
+		return fmt.Sprintf("%s (%s)", env, path)
+	}
+	return path
+}
+
+// checkRule returns an error if the value doesn't satisfy the rule.
+func checkRule(rule string, value reflect.Value) error {
+	name, arg := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		name, arg = rule[:i], rule[i+1:]
+	}
+
+	if name == "required" {
+		if value.IsZero() || ((value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.Len() == 0) {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+
+	if (value.Kind() == reflect.String || value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.Len() == 0 {
+		return nil
+	}
+
+	if value.Kind() == reflect.Slice {
+		for i := 0; i < value.Len(); i++ {
+			if err := checkValue(name, arg, value.Index(i)); err != nil {
+				return fmt.Errorf("item %d %v", i, err)
+			}
+		}
+		return nil
+	}
+	return checkValue(name, arg, value)
+}
+
+// checkValue checks a rule other than required against a single value.
+func checkValue(name, arg string, value reflect.Value) error {
+	switch name {
+	case "oneof":
+		allowed := strings.Fields(arg)
+		str := fmt.Sprint(value.Interface())
+		for _, a := range allowed {
+			if str == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("is %q, must be one of: %s", str, strings.Join(allowed, ", "))
+	case "range":
+		return checkRange(arg, value)
+	case "url":
+		u, err := url.Parse(value.String())
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("is %q, must be an absolute URL such as https://example.com", value.String())
+		}
+		return nil
+	case "duration":
+		if _, err := time.ParseDuration(value.String()); err != nil {
+			return fmt.Errorf("is %q, must be a duration such as 90s or 1h30m", value.String())
+		}
+		return nil
+	default:
+		return fmt.Errorf("has unknown validation rule %s", name)
+	}
+}
+
+// checkRange checks that a number is within the min:max bounds of a range rule.
+func checkRange(arg string, value reflect.Value) error {
+	bounds := strings.SplitN(arg, ":", 2)
+	if len(bounds) != 2 {
+		return fmt.Errorf("has invalid range rule %s, expected min:max", arg)
+	}
+
+	var n float64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = value.Float()
+	default:
+		return fmt.Errorf("has range rule but isn't a number")
+	}
+
+	for i, bound := range bounds {
+		if bound == "" {
+			continue
+		}
+		limit, err := strconv.ParseFloat(bound, 64)
+		if err != nil {
+			return fmt.Errorf("has invalid range rule %s: %v", arg, err)
+		}
+		if (i == 0 && n < limit) || (i == 1 && n > limit) {
+			return fmt.Errorf("is %v, must be %s", value.Interface(), describeRange(bounds[0], bounds[1]))
+		}
+	}
+	return nil
+}
+
+// describeRange describes the bounds of a range rule.
+func describeRange(min, max string) string {
+	switch {
+	case max == "":
+		return "at least " + min
+	case min == "":
+		return "at most " + max
+	default:
+		return fmt.Sprintf("between %s and %s", min, max)
+	}
+}
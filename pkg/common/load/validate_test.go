@@ -0,0 +1,61 @@
+package load
+
+import (
+	"strings"
+	"testing"
+)
+
+type validateTestConfig struct {
+	Provider string   `env:"VALIDATE_TEST_PROVIDER" yaml:"provider" validate:"required,oneof=ocm mock"`
+	URL      string   `env:"VALIDATE_TEST_URL" yaml:"url" validate:"url"`
+	Timeout  string   `yaml:"timeout" validate:"duration"`
+	Suites   []string `yaml:"suites" validate:"oneof=e2e scale"`
+
+	Nested struct {
+		Retries int     `env:"VALIDATE_TEST_RETRIES" yaml:"retries" validate:"range=1:5"`
+		Ratio   float64 `yaml:"ratio" validate:"range=:1"`
+	} `yaml:"nested"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := validateTestConfig{Provider: "ocm", URL: "https://example.com/path", Timeout: "1h30m", Suites: []string{"e2e"}}
+	valid.Nested.Retries = 3
+	if err := Validate(&valid); err != nil {
+		t.Errorf("expected valid config, got: %v", err)
+	}
+
+	invalid := validateTestConfig{URL: "example.com", Timeout: "90", Suites: []string{"e2e", "unknown"}}
+	invalid.Nested.Ratio = 1.5
+
+	err := Validate(&invalid)
+	if err == nil {
+		t.Fatalf("expected invalid config to fail validation")
+	}
+
+	for _, expected := range []string{
+		"6 invalid config option(s)",
+		`VALIDATE_TEST_PROVIDER (provider): is required`,
+		`VALIDATE_TEST_URL (url): is "example.com", must be an absolute URL`,
+		`timeout: is "90", must be a duration`,
+		`suites: item 1 is "unknown", must be one of: e2e, scale`,
+		`VALIDATE_TEST_RETRIES (nested.retries): is 0, must be between 1 and 5`,
+		`nested.ratio: is 1.5, must be at most 1`,
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got: %v", expected, err)
+		}
+	}
+}
+
+func TestValidateExtensions(t *testing.T) {
+	parent := &validateTestConfig{Provider: "mock"}
+	ext := &struct {
+		Count int `env:"VALIDATE_TEST_COUNT" yaml:"count" validate:"range=1:"`
+	}{}
+	RegisterExtension(parent, "ext", ext)
+
+	err := Validate(parent)
+	if err == nil || !strings.Contains(err.Error(), "VALIDATE_TEST_COUNT (ext.count): is 0, must be at least 1") {
+		t.Errorf("expected extension to fail validation, got: %v", err)
+	}
+}
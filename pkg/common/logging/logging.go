@@ -0,0 +1,321 @@
+// Package logging is the leveled, structured logger of osde2e. Messages carry fields, such as the cluster, phase, and
+// suite of the run, and are written as text or as JSON lines that a log store can ingest and query.
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TextFormat writes a message per line in the form of the standard library's logger, followed by its fields.
+	TextFormat = "text"
+
+	// JSONFormat writes a JSON object per line.
+	JSONFormat = "json"
+
+	// ClusterIDField is the ID of the cluster under test.
+	ClusterIDField = "cluster_id"
+
+	// PhaseField is the phase of the run, such as install or upgrade.
+	PhaseField = "phase"
+
+	// SuiteField is the context of the spec being run.
+	SuiteField = "suite"
+
+	// textTimeLayout matches the timestamps of the standard library's logger.
+	textTimeLayout = "2006/01/02 15:04:05"
+)
+
+// Level is the severity of a message.
+type Level int
+
+const (
+	// DebugLevel is for details only needed to debug osde2e itself.
+	DebugLevel Level = iota
+
+	// InfoLevel is for the progress of the run.
+	InfoLevel
+
+	// WarnLevel is for problems the run carries on from.
+	WarnLevel
+
+	// ErrorLevel is for failures.
+	ErrorLevel
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < DebugLevel || l > ErrorLevel {
+		return "unknown"
+	}
+	return levelNames[l]
+}
+
+// ParseLevel returns the level with the given name.
+func ParseLevel(name string) (Level, error) {
+	for i, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return Level(i), nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("unknown log level %q", name)
+}
+
+// Fields are the key/value pairs a message is logged with.
+type Fields map[string]interface{}
+
+// output is where messages are written and how.
+type output struct {
+	mutex      sync.Mutex
+	out        io.Writer
+	level      Level
+	format     string
+	fields     Fields
+	fieldFuncs map[string]func() string
+	now        func() time.Time
+}
+
+var std = &output{
+	out:        os.Stderr,
+	level:      InfoLevel,
+	format:     TextFormat,
+	fields:     Fields{},
+	fieldFuncs: map[string]func() string{},
+	now:        time.Now,
+}
+
+// Messages logged with the standard library's logger are logged at the info level, so they carry the run's fields
+// and share its format.
+func init() {
+	log.SetFlags(0)
+	log.SetOutput(Writer(InfoLevel))
+}
+
+// Configure sets the minimum level of the messages that are logged and their format, text or json.
+func Configure(level, format string) error {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	format = strings.ToLower(format)
+	if format == "" {
+		format = TextFormat
+	} else if format != TextFormat && format != JSONFormat {
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	std.level, std.format = l, format
+	return nil
+}
+
+// SetOutput sets where messages are written.
+func SetOutput(w io.Writer) {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	std.out = w
+}
+
+// Format returns the format messages are written in.
+func Format() string {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	return std.format
+}
+
+// SetField adds a field to every message. A nil value removes it.
+func SetField(key string, value interface{}) {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	if value == nil {
+		delete(std.fields, key)
+		return
+	}
+	std.fields[key] = value
+}
+
+// SetFieldFunc adds a field to every message whose value is looked up as each message is logged, for state that
+// changes during the run. The field is left out while its value is empty. A nil func removes it.
+func SetFieldFunc(key string, value func() string) {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	if value == nil {
+		delete(std.fieldFuncs, key)
+		return
+	}
+	std.fieldFuncs[key] = value
+}
+
+// Logger logs messages with a set of fields.
+type Logger struct {
+	fields Fields
+}
+
+// With returns a logger adding the fields to its messages.
+func With(fields Fields) *Logger {
+	return (&Logger{}).With(fields)
+}
+
+// WithField returns a logger adding the field to its messages.
+func WithField(key string, value interface{}) *Logger {
+	return With(Fields{key: value})
+}
+
+// With returns a logger adding the fields to the messages of this one.
+func (l *Logger) With(fields Fields) *Logger {
+	merged := Fields{}
+	for key, value := range l.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return &Logger{fields: merged}
+}
+
+// WithField returns a logger adding the field to the messages of this one.
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	return l.With(Fields{key: value})
+}
+
+// Debugf logs a debug message.
+func (l *Logger) Debugf(format string, args ...interface{}) { l.logf(DebugLevel, format, args...) }
+
+// Infof logs an information message.
+func (l *Logger) Infof(format string, args ...interface{}) { l.logf(InfoLevel, format, args...) }
+
+// Warnf logs a warning.
+func (l *Logger) Warnf(format string, args ...interface{}) { l.logf(WarnLevel, format, args...) }
+
+// Errorf logs an error.
+func (l *Logger) Errorf(format string, args ...interface{}) { l.logf(ErrorLevel, format, args...) }
+
+// Debugf logs a debug message.
+func Debugf(format string, args ...interface{}) { (&Logger{}).logf(DebugLevel, format, args...) }
+
+// Infof logs an information message.
+func Infof(format string, args ...interface{}) { (&Logger{}).logf(InfoLevel, format, args...) }
+
+// Warnf logs a warning.
+func Warnf(format string, args ...interface{}) { (&Logger{}).logf(WarnLevel, format, args...) }
+
+// Errorf logs an error.
+func Errorf(format string, args ...interface{}) { (&Logger{}).logf(ErrorLevel, format, args...) }
+
+func (l *Logger) logf(level Level, format string, args ...interface{}) {
+	std.write(level, fmt.Sprintf(format, args...), l.fields)
+}
+
+// Writer returns a writer logging what is written to it at the level, a message per write. It is used to send the
+// output of other loggers through this one.
+func Writer(level Level) io.Writer {
+	return levelWriter(level)
+}
+
+type levelWriter Level
+
+func (w levelWriter) Write(p []byte) (int, error) {
+	std.write(Level(w), strings.TrimSuffix(string(p), "\n"), nil)
+	return len(p), nil
+}
+
+func (o *output) write(level Level, msg string, fields Fields) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if level < o.level {
+		return
+	}
+
+	all := Fields{}
+	for key, value := range o.fields {
+		all[key] = value
+	}
+	for key, value := range o.fieldFuncs {
+		if v := value(); v != "" {
+			all[key] = v
+		}
+	}
+	for key, value := range fields {
+		all[key] = value
+	}
+
+	var line []byte
+	if o.format == JSONFormat {
+		line = formatJSON(o.now(), level, msg, all)
+	} else {
+		line = formatText(o.now(), level, msg, all)
+	}
+	o.out.Write(line)
+}
+
+// formatText writes the message as the standard library's logger would, with its level before it and its fields,
+// sorted by key, after it.
+func formatText(t time.Time, level Level, msg string, fields Fields) []byte {
+	var b bytes.Buffer
+	b.WriteString(t.Format(textTimeLayout))
+	if level != InfoLevel {
+		fmt.Fprintf(&b, " [%s]", strings.ToUpper(level.String()))
+	}
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for _, key := range sortedKeys(fields) {
+		value := fmt.Sprint(fields[key])
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	}
+	b.WriteString("\n")
+	return b.Bytes()
+}
+
+// formatJSON writes the message as a JSON object. Fields named after the time, level, or message are prefixed with
+// "field." so they can't replace them.
+func formatJSON(t time.Time, level Level, msg string, fields Fields) []byte {
+	entry := map[string]interface{}{}
+	for key, value := range fields {
+		if key == "time" || key == "level" || key == "msg" {
+			key = "field." + key
+		}
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		entry[key] = value
+	}
+	entry["time"] = t.UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{
+			"time":  entry["time"].(string),
+			"level": level.String(),
+			"msg":   msg,
+			"error": fmt.Sprintf("unable to encode fields: %v", err),
+		})
+	}
+	return append(data, '\n')
+}
+
+func sortedKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
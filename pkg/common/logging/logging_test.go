@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+// capture sends messages to a buffer at a fixed time until the returned func is called.
+func capture(t *testing.T, level, format string) (*bytes.Buffer, func()) {
+	var buf bytes.Buffer
+	out, prevLevel, prevFormat, now := std.out, std.level, std.format, std.now
+	SetOutput(&buf)
+	std.now = func() time.Time { return time.Date(2020, 10, 1, 12, 30, 0, 0, time.UTC) }
+	if err := Configure(level, format); err != nil {
+		t.Fatalf("failed to configure logging: %v", err)
+	}
+	return &buf, func() {
+		std.out, std.level, std.format, std.now = out, prevLevel, prevFormat, now
+	}
+}
+
+func TestText(t *testing.T) {
+	buf, restore := capture(t, "info", "text")
+	defer restore()
+
+	phase := "install"
+	SetFieldFunc(PhaseField, func() string { return phase })
+	defer SetFieldFunc(PhaseField, nil)
+	SetField(SuiteField, "[Suite: e2e] Pods")
+	defer SetField(SuiteField, nil)
+
+	Debugf("hidden")
+	WithField(ClusterIDField, "abc").Warnf("quota is %d", 1)
+	phase = ""
+	log.Printf("from the standard library")
+
+	expected := "2020/10/01 12:30:00 [WARN] quota is 1 cluster_id=abc phase=install suite=\"[Suite: e2e] Pods\"\n" +
+		"2020/10/01 12:30:00 from the standard library suite=\"[Suite: e2e] Pods\"\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	buf, restore := capture(t, "debug", "json")
+	defer restore()
+
+	With(Fields{ClusterIDField: "abc", "msg": "shadowed"}).WithField("error", errors.New("boom")).Debugf("deleting cluster")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"time":       "2020-10-01T12:30:00Z",
+		"level":      "debug",
+		"msg":        "deleting cluster",
+		"cluster_id": "abc",
+		"field.msg":  "shadowed",
+		"error":      "boom",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, entry[key])
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	_, restore := capture(t, "info", "text")
+	defer restore()
+
+	if err := Configure("verbose", "text"); err == nil {
+		t.Errorf("expected an unknown level to be an error")
+	}
+	if err := Configure("info", "xml"); err == nil {
+		t.Errorf("expected an unknown format to be an error")
+	}
+	if err := Configure("WARN", "JSON"); err != nil || std.level != WarnLevel || Format() != JSONFormat {
+		t.Errorf("expected levels and formats to be case insensitive, got %v", err)
+	}
+}
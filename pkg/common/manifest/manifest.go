@@ -0,0 +1,438 @@
+// Package manifest records the inputs of an osde2e run so that it can be reproduced later.
+package manifest
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// ManifestFile is the name of the reproducibility manifest written to the report directory.
+	ManifestFile string = "manifest.yaml"
+
+	// EffectiveConfigFile is the name of the report of where each option came from, written to the report directory.
+	EffectiveConfigFile string = "effective-config.yaml"
+
+	// redacted replaces the values of secrets in reports.
+	redacted = "REDACTED"
+)
+
+// BuildCommit is the git SHA osde2e was built from. It is set at build time using -ldflags.
+var BuildCommit = "unknown"
+
+// runSpecificConfigKeys are config options that are unique to each run and are not considered inputs.
+var runSpecificConfigKeys = [][]string{
+	{"reportDir"},
+	{"suffix"},
+	{"jobID"},
+}
+
+// secretConfigKeys returns the config options tagged as secrets, which are never written to reports. Providers
+// register their config sections when their packages are initialized, so the options are only looked up once a report
+// is generated.
+func secretConfigKeys() [][]string {
+	return load.SecretKeys(config.Instance)
+}
+
+// excludedConfigKeys returns the config options that aren't recorded in manifests.
+func excludedConfigKeys() [][]string {
+	return append(append([][]string{}, runSpecificConfigKeys...), secretConfigKeys()...)
+}
+
+// runSpecificStateKeys are state values that are unique to each run and are not considered inputs.
+var runSpecificStateKeys = [][]string{
+	{"cluster", "id"},
+	{"cluster", "name"},
+	{"cluster", "state"},
+	{"kubeconfig"},
+}
+
+// scenarioConfigKeys are the config options describing the shape of the cluster and the suites run against it.
+// Runs with the same values are the same scenario, no matter which job ran them.
+var scenarioConfigKeys = [][]string{
+	{"provider"},
+	{"ocm", "env"},
+	{"ocm", "clusterSpec"},
+	{"cluster", "multiAZ"},
+	{"cluster", "autoscalerMaxNodes"},
+	{"rosa", "computeMachineType"},
+	{"rosa", "computeNodes"},
+	{"addons", "ids"},
+	{"addons", "testHarnesses"},
+	{"tests", "testsToRun"},
+	{"tests", "informingSuites"},
+	{"tests", "focus"},
+	{"tests", "ginkgoSkip"},
+}
+
+// scenarioStateKeys are the state values describing the shape of the cluster.
+var scenarioStateKeys = [][]string{
+	{"cloudProvider", "providerId"},
+	{"cloudProvider", "region"},
+}
+
+// Manifest describes everything needed to replay an osde2e run with identical inputs.
+type Manifest struct {
+	// BuildCommit is the git SHA of the osde2e binary that produced this manifest.
+	BuildCommit string `yaml:"buildCommit"`
+
+	// ConfigHash is a SHA256 hash of the resolved config, excluding run specific values.
+	ConfigHash string `yaml:"configHash"`
+
+	// ScenarioFingerprint identifies the cluster shape and suites of the run. It doesn't change with the job name,
+	// so results of the same scenario can be compared across jobs.
+	ScenarioFingerprint string `yaml:"scenarioFingerprint"`
+
+	// Created is when the manifest was generated.
+	Created time.Time `yaml:"created"`
+
+	// ClusterVersion is the version the cluster was installed with.
+	ClusterVersion string `yaml:"clusterVersion"`
+
+	// UpgradeReleaseName is the release the cluster was upgraded to, if any.
+	UpgradeReleaseName string `yaml:"upgradeReleaseName,omitempty"`
+
+	// UpgradeImage is the release image the cluster was upgraded to, if any.
+	UpgradeImage string `yaml:"upgradeImage,omitempty"`
+
+	// HarnessImages maps the images used by runner pods to the digests they resolved to.
+	HarnessImages map[string]string `yaml:"harnessImages"`
+
+	// Deprecations are warnings for deprecated config options used by the run.
+	Deprecations []string `yaml:"deprecations,omitempty"`
+
+	// Config is the resolved config with run specific values removed.
+	Config yaml.MapSlice `yaml:"config"`
+
+	// State is the resolved initial state with run specific values removed.
+	State yaml.MapSlice `yaml:"state"`
+}
+
+var (
+	imagesMutex   sync.Mutex
+	harnessImages = map[string]string{}
+	pinnedImages  = map[string]string{}
+)
+
+// RecordImage records the digest an image resolved to on the cluster.
+func RecordImage(image, digest string) {
+	imagesMutex.Lock()
+	defer imagesMutex.Unlock()
+	harnessImages[image] = digest
+}
+
+// PinImages makes PinnedImage resolve images to the digests recorded by a previous run, so that a replay runs the
+// same harnesses even if their tags moved. The images whose digests can't be pulled by are returned.
+func PinImages(images map[string]string) []string {
+	imagesMutex.Lock()
+	defer imagesMutex.Unlock()
+
+	var unpinned []string
+	for image, digest := range images {
+		if ref := digestReference(digest); ref != "" {
+			pinnedImages[image] = ref
+		} else {
+			unpinned = append(unpinned, image)
+		}
+	}
+	sort.Strings(unpinned)
+	return unpinned
+}
+
+// PinnedImage returns the digest reference an image is pinned to, or the image itself if it isn't pinned.
+func PinnedImage(image string) string {
+	imagesMutex.Lock()
+	defer imagesMutex.Unlock()
+	if ref, ok := pinnedImages[image]; ok {
+		return ref
+	}
+	return image
+}
+
+// digestReference returns the reference an image ID reported by a container runtime can be pulled by, or an empty
+// string if it doesn't name a repository digest.
+func digestReference(imageID string) string {
+	ref := strings.TrimPrefix(imageID, "docker-pullable://")
+	if !strings.Contains(ref, "@sha256:") {
+		return ""
+	}
+	return ref
+}
+
+// Generate builds a manifest from the global config and state.
+func Generate() (*Manifest, error) {
+	cfg, err := resolve(config.Instance)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving config: %v", err)
+	}
+
+	// provider config is loaded separately from the global config but is part of what was run
+	if cfg, err = load.MarshalExtensions(config.Instance, cfg); err != nil {
+		return nil, fmt.Errorf("error resolving provider config: %v", err)
+	}
+	for _, key := range excludedConfigKeys() {
+		cfg = removeKey(cfg, key)
+	}
+
+	st, err := stripKeys(state.Instance, runSpecificStateKeys)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving state: %v", err)
+	}
+
+	hash, err := Hash(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing config: %v", err)
+	}
+
+	fingerprint, err := ScenarioFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	imagesMutex.Lock()
+	images := make(map[string]string, len(harnessImages))
+	for image, digest := range harnessImages {
+		images[image] = digest
+	}
+	imagesMutex.Unlock()
+
+	return &Manifest{
+		BuildCommit:         BuildCommit,
+		ConfigHash:          hash,
+		ScenarioFingerprint: fingerprint,
+		Created:             time.Now().UTC(),
+		ClusterVersion:      state.Instance.Cluster.Version,
+		UpgradeReleaseName:  state.Instance.Upgrade.ReleaseName,
+		UpgradeImage:        state.Instance.Upgrade.Image,
+		HarnessImages:       images,
+		Deprecations:        load.Deprecations(),
+		Config:              cfg,
+		State:               st,
+	}, nil
+}
+
+// Hash returns a stable SHA256 hash of the given resolved config.
+func Hash(cfg yaml.MapSlice) (string, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data)), nil
+}
+
+// ScenarioFingerprint returns a short, stable hash of the config and state options describing the cluster shape and
+// the suites being run.
+func ScenarioFingerprint() (string, error) {
+	cfg, err := resolve(config.Instance)
+	if err != nil {
+		return "", fmt.Errorf("error resolving config: %v", err)
+	}
+
+	if cfg, err = load.MarshalExtensions(config.Instance, cfg); err != nil {
+		return "", fmt.Errorf("error resolving provider config: %v", err)
+	}
+
+	st, err := resolve(state.Instance)
+	if err != nil {
+		return "", fmt.Errorf("error resolving state: %v", err)
+	}
+
+	scenario := yaml.MapSlice{
+		{Key: "config", Value: selectKeys(cfg, scenarioConfigKeys)},
+		{Key: "state", Value: selectKeys(st, scenarioStateKeys)},
+	}
+
+	hash, err := Hash(scenario)
+	if err != nil {
+		return "", fmt.Errorf("error hashing scenario: %v", err)
+	}
+	// the prefix of the hash is plenty to tell scenarios apart and keeps metric labels short
+	return strings.TrimPrefix(hash, "sha256:")[:12], nil
+}
+
+// Write generates a manifest and writes it into the given report directory.
+func Write(reportDir string) error {
+	m, err := Generate()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshaling manifest: %v", err)
+	}
+
+	return ioutil.WriteFile(filepath.Join(reportDir, ManifestFile), data, os.FileMode(0644))
+}
+
+// EffectiveConfig is the final value of every config option and state value, along with the default, config, file,
+// or environment variable that supplied it.
+type EffectiveConfig struct {
+	// Profiles are the profiles that were merged, in order.
+	Profiles []string `yaml:"profiles"`
+
+	Config []load.Setting `yaml:"config"`
+	State  []load.Setting `yaml:"state"`
+}
+
+// GenerateEffectiveConfig reports where the current config and state came from. Secrets are redacted.
+func GenerateEffectiveConfig() *EffectiveConfig {
+	cfg := load.EffectiveConfig(config.Instance)
+	secrets := secretConfigKeys()
+	for i, setting := range cfg {
+		for _, key := range secrets {
+			if setting.Key == strings.Join(key, ".") && setting.Value != "" {
+				cfg[i].Value = redacted
+			}
+		}
+	}
+
+	return &EffectiveConfig{
+		Profiles: load.Profiles(config.Instance),
+		Config:   cfg,
+		State:    load.EffectiveConfig(state.Instance),
+	}
+}
+
+// WriteEffectiveConfig writes the effective config report into the given report directory.
+func WriteEffectiveConfig(reportDir string) error {
+	data, err := yaml.Marshal(GenerateEffectiveConfig())
+	if err != nil {
+		return fmt.Errorf("error marshaling effective config: %v", err)
+	}
+
+	return ioutil.WriteFile(filepath.Join(reportDir, EffectiveConfigFile), data, os.FileMode(0644))
+}
+
+// Read loads a manifest from the given file.
+func Read(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest %s: %v", path, err)
+	}
+
+	m := &Manifest{}
+	if err = yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("error parsing manifest %s: %v", path, err)
+	}
+
+	return m, nil
+}
+
+// RecordsConfig returns whether the manifest records the value of a config option, given by its dotted YAML path.
+func (m *Manifest) RecordsConfig(key string) bool {
+	return lookupKey(m.Config, strings.Split(key, ".")) != nil
+}
+
+// RecordsState returns whether the manifest records a state value, given by its dotted YAML path.
+func (m *Manifest) RecordsState(key string) bool {
+	return lookupKey(m.State, strings.Split(key, ".")) != nil
+}
+
+// WriteInputs writes the config and state recorded in the manifest into the given directory as YAML files
+// that can be loaded as custom configs. The paths of the config and state files are returned.
+func (m *Manifest) WriteInputs(dir string) (string, string, error) {
+	configFile := filepath.Join(dir, "manifest-config.yaml")
+	stateFile := filepath.Join(dir, "manifest-state.yaml")
+
+	for file, contents := range map[string]yaml.MapSlice{configFile: m.Config, stateFile: m.State} {
+		data, err := yaml.Marshal(contents)
+		if err != nil {
+			return "", "", err
+		}
+
+		if err = ioutil.WriteFile(file, data, os.FileMode(0600)); err != nil {
+			return "", "", err
+		}
+	}
+
+	return configFile, stateFile, nil
+}
+
+// stripKeys round trips the object through YAML and removes the given key paths.
+func stripKeys(object interface{}, keys [][]string) (yaml.MapSlice, error) {
+	slice, err := resolve(object)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		slice = removeKey(slice, key)
+	}
+
+	return slice, nil
+}
+
+// resolve round trips the object through YAML.
+func resolve(object interface{}) (yaml.MapSlice, error) {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	slice := yaml.MapSlice{}
+	if err = yaml.Unmarshal(data, &slice); err != nil {
+		return nil, err
+	}
+	return slice, nil
+}
+
+// selectKeys returns the values at the given key paths of a YAML map, in the order of the key paths. Missing values
+// are recorded as null so that every key path is always part of the result.
+func selectKeys(slice yaml.MapSlice, keys [][]string) yaml.MapSlice {
+	result := yaml.MapSlice{}
+	for _, key := range keys {
+		result = append(result, yaml.MapItem{Key: strings.Join(key, "."), Value: lookupKey(slice, key)})
+	}
+	return result
+}
+
+// lookupKey returns the value at the given key path of a YAML map, or nil if there isn't one.
+func lookupKey(slice yaml.MapSlice, key []string) interface{} {
+	for _, item := range slice {
+		if item.Key != key[0] {
+			continue
+		}
+
+		if len(key) == 1 {
+			return item.Value
+		}
+
+		if nested, ok := item.Value.(yaml.MapSlice); ok {
+			return lookupKey(nested, key[1:])
+		}
+	}
+	return nil
+}
+
+// removeKey removes the item at the given key path from a YAML map.
+func removeKey(slice yaml.MapSlice, key []string) yaml.MapSlice {
+	result := yaml.MapSlice{}
+	for _, item := range slice {
+		if item.Key != key[0] {
+			result = append(result, item)
+			continue
+		}
+
+		if len(key) > 1 {
+			if nested, ok := item.Value.(yaml.MapSlice); ok {
+				item.Value = removeKey(nested, key[1:])
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,260 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+func TestStripKeys(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.OCM.Token = "secret-token"
+	cfg.OCM.Env = "stage"
+	cfg.Suffix = "abc"
+
+	slice, err := stripKeys(cfg, excludedConfigKeys())
+	if err != nil {
+		t.Fatalf("error stripping keys: %v", err)
+	}
+
+	data, err := yaml.Marshal(slice)
+	if err != nil {
+		t.Fatalf("error marshaling stripped config: %v", err)
+	}
+
+	stripped := &config.Config{}
+	if err = yaml.Unmarshal(data, stripped); err != nil {
+		t.Fatalf("error unmarshaling stripped config: %v", err)
+	}
+
+	if stripped.OCM.Token != "" {
+		t.Errorf("OCM token was not removed from the resolved config")
+	}
+
+	if stripped.Suffix != "" {
+		t.Errorf("suffix was not removed from the resolved config")
+	}
+
+	if stripped.OCM.Env != "stage" {
+		t.Errorf("expected OCM environment to be kept, got %s", stripped.OCM.Env)
+	}
+}
+
+func TestHashIgnoresRunSpecificValues(t *testing.T) {
+	first := &config.Config{Suffix: "abc", ReportDir: "/tmp/first"}
+	second := &config.Config{Suffix: "xyz", ReportDir: "/tmp/second"}
+	different := &config.Config{Suffix: "abc", ReportDir: "/tmp/first", Provider: "mock"}
+
+	hashes := []string{}
+	for _, cfg := range []*config.Config{first, second, different} {
+		slice, err := stripKeys(cfg, excludedConfigKeys())
+		if err != nil {
+			t.Fatalf("error stripping keys: %v", err)
+		}
+
+		hash, err := Hash(slice)
+		if err != nil {
+			t.Fatalf("error hashing config: %v", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if hashes[0] != hashes[1] {
+		t.Errorf("configs differing only by run specific values should hash the same")
+	}
+
+	if hashes[0] == hashes[2] {
+		t.Errorf("configs with different inputs should not hash the same")
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	state.Instance.Cluster.Version = "openshift-v4.4.3"
+	state.Instance.Cluster.ID = "some-cluster-id"
+	RecordImage("quay.io/example/harness:latest", "quay.io/example/harness@sha256:1234")
+
+	if err = Write(tmpDir); err != nil {
+		t.Fatalf("error writing manifest: %v", err)
+	}
+
+	m, err := Read(filepath.Join(tmpDir, ManifestFile))
+	if err != nil {
+		t.Fatalf("error reading manifest: %v", err)
+	}
+
+	if m.ClusterVersion != "openshift-v4.4.3" {
+		t.Errorf("expected cluster version openshift-v4.4.3, got %s", m.ClusterVersion)
+	}
+
+	if m.HarnessImages["quay.io/example/harness:latest"] != "quay.io/example/harness@sha256:1234" {
+		t.Errorf("harness image digest was not recorded")
+	}
+
+	_, stateFile, err := m.WriteInputs(tmpDir)
+	if err != nil {
+		t.Fatalf("error writing manifest inputs: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		t.Fatalf("error reading state inputs: %v", err)
+	}
+
+	replayed := &state.State{}
+	if err = yaml.Unmarshal(data, replayed); err != nil {
+		t.Fatalf("error parsing state inputs: %v", err)
+	}
+
+	if replayed.Cluster.ID != "" {
+		t.Errorf("cluster ID should not be replayed")
+	}
+
+	if replayed.Cluster.Version != "openshift-v4.4.3" {
+		t.Errorf("expected replayed cluster version openshift-v4.4.3, got %s", replayed.Cluster.Version)
+	}
+}
+
+func TestPinImages(t *testing.T) {
+	defer func() {
+		pinnedImages = map[string]string{}
+	}()
+
+	unpinned := PinImages(map[string]string{
+		"quay.io/example/harness:latest": "docker-pullable://quay.io/example/harness@sha256:1234",
+		"quay.io/example/other:latest":   "sha256:5678",
+	})
+
+	if len(unpinned) != 1 || unpinned[0] != "quay.io/example/other:latest" {
+		t.Errorf("expected only quay.io/example/other:latest to be unpinned, got %v", unpinned)
+	}
+
+	if image := PinnedImage("quay.io/example/harness:latest"); image != "quay.io/example/harness@sha256:1234" {
+		t.Errorf("expected harness to be pinned to its digest, got %s", image)
+	}
+
+	if image := PinnedImage("quay.io/example/other:latest"); image != "quay.io/example/other:latest" {
+		t.Errorf("expected image without a repository digest to be unchanged, got %s", image)
+	}
+}
+
+func TestRecordsConfig(t *testing.T) {
+	m := &Manifest{
+		Config: yaml.MapSlice{{Key: "ocm", Value: yaml.MapSlice{{Key: "env", Value: "stage"}}}},
+	}
+
+	if !m.RecordsConfig("ocm.env") {
+		t.Errorf("expected ocm.env to be recorded")
+	}
+
+	if m.RecordsConfig("ocm.token") || m.RecordsConfig("suffix") {
+		t.Errorf("expected unrecorded options not to be recorded")
+	}
+}
+
+func TestScenarioFingerprint(t *testing.T) {
+	defer func(cfg config.Config, st state.State) {
+		*config.Instance, *state.Instance = cfg, st
+	}(*config.Instance, *state.Instance)
+
+	config.Instance.JobName = "osde2e-stage-aws-e2e-default"
+	config.Instance.Tests.TestsToRun = []string{"[Suite: e2e]"}
+	state.Instance.CloudProvider.Region = "us-east-1"
+
+	first, err := ScenarioFingerprint()
+	if err != nil {
+		t.Fatalf("error fingerprinting scenario: %v", err)
+	}
+	if len(first) != 12 {
+		t.Errorf("expected a 12 character fingerprint, got %s", first)
+	}
+
+	// renaming the job or installing a different version is still the same scenario
+	config.Instance.JobName = "osde2e-stage-aws-e2e-renamed"
+	state.Instance.Cluster.Version = "openshift-v4.5.1"
+	if renamed, err := ScenarioFingerprint(); err != nil || renamed != first {
+		t.Errorf("expected fingerprint %s to be unchanged, got %s: %v", first, renamed, err)
+	}
+
+	config.Instance.Tests.TestsToRun = []string{"[Suite: scale]"}
+	if suites, err := ScenarioFingerprint(); err != nil || suites == first {
+		t.Errorf("expected fingerprint to change with the suites, got %s: %v", suites, err)
+	}
+
+	config.Instance.Tests.TestsToRun = []string{"[Suite: e2e]"}
+	state.Instance.CloudProvider.Region = "eu-west-1"
+	if region, err := ScenarioFingerprint(); err != nil || region == first {
+		t.Errorf("expected fingerprint to change with the region, got %s: %v", region, err)
+	}
+}
+
+func TestEffectiveConfigRedactsSecrets(t *testing.T) {
+	defer func(cfg config.Config) {
+		*config.Instance = cfg
+	}(*config.Instance)
+
+	config.Instance.OCM.Token = "secret-token"
+	config.Instance.OCM.Env = "stage"
+
+	found := 0
+	for _, setting := range GenerateEffectiveConfig().Config {
+		switch setting.Key {
+		case "ocm.token":
+			found++
+			if setting.Value != redacted {
+				t.Errorf("expected the OCM token to be redacted, got %v", setting.Value)
+			}
+		case "ocm.env":
+			found++
+			if setting.Value != "stage" {
+				t.Errorf("expected the OCM environment stage, got %v", setting.Value)
+			}
+		}
+	}
+
+	if found != 2 {
+		t.Errorf("expected the report to include the OCM token and environment")
+	}
+}
+
+func TestExtensionSecretsAreRedacted(t *testing.T) {
+	// registered after the package is initialized, like the sections of providers
+	extension := &struct {
+		Key    string `yaml:"key" secret:"true"`
+		Region string `yaml:"region"`
+	}{Key: "secret-key", Region: "us-east-1"}
+	load.RegisterExtension(config.Instance, "manifestTest", extension)
+
+	redactedKey := false
+	for _, setting := range GenerateEffectiveConfig().Config {
+		if setting.Key == "manifestTest.key" {
+			redactedKey = setting.Value == redacted
+		}
+	}
+	if !redactedKey {
+		t.Errorf("expected the secret of the extension to be redacted")
+	}
+
+	m, err := Generate()
+	if err != nil {
+		t.Fatalf("error generating manifest: %v", err)
+	}
+	if m.RecordsConfig("manifestTest.key") {
+		t.Errorf("expected the secret of the extension not to be recorded")
+	}
+	if !m.RecordsConfig("manifestTest.region") {
+		t.Errorf("expected the other options of the extension to be recorded")
+	}
+}
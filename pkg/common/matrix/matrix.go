@@ -0,0 +1,234 @@
+// Package matrix describes runs across versions, regions, and OCM environments and schedules them within the quota
+// each environment has left.
+package matrix
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// defaultTokenEnv is the environment variable holding the OCM token of environments that don't set their own.
+const defaultTokenEnv = "OCM_TOKEN"
+
+// Matrix is a set of runs and the OCM environments and accounts they may create clusters in.
+type Matrix struct {
+	// Environments are the OCM environments and accounts clusters can be created in, in order of preference.
+	Environments []Environment `yaml:"environments"`
+
+	// Entries are the runs of the matrix.
+	Entries []Entry `yaml:"entries"`
+}
+
+// Environment is an OCM environment and the account used to create clusters in it.
+type Environment struct {
+	// Name identifies the environment and account in schedules and reports.
+	Name string `yaml:"name"`
+
+	// Env is the OCM environment, e.g. stage or prod.
+	Env string `yaml:"env"`
+
+	// TokenEnv is the environment variable holding the OCM token of the account. Defaults to OCM_TOKEN.
+	TokenEnv string `yaml:"tokenEnv,omitempty"`
+}
+
+// Token returns the environment variable holding the OCM token of the environment's account.
+func (e Environment) Token() string {
+	if e.TokenEnv == "" {
+		return defaultTokenEnv
+	}
+	return e.TokenEnv
+}
+
+// Entry is a single run of the matrix.
+type Entry struct {
+	// Name identifies the entry and is the directory of its report.
+	Name string `yaml:"name"`
+
+	// Version is the cluster version to install. If empty, it is chosen by the configs.
+	Version string `yaml:"version,omitempty"`
+
+	// CloudProvider is the cloud provider of the cluster. Defaults to aws.
+	CloudProvider string `yaml:"cloudProvider,omitempty"`
+
+	// Region is the cloud provider region of the cluster. If empty, it is chosen by the configs.
+	Region string `yaml:"region,omitempty"`
+
+	// MultiAZ deploys the cluster across multiple availability zones.
+	MultiAZ bool `yaml:"multiAZ,omitempty"`
+
+	// Configs are built in configs added to the ones the matrix is run with.
+	Configs []string `yaml:"configs,omitempty"`
+
+	// Env are extra environment variables for the run.
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// Cloud returns the cloud provider of the entry's cluster.
+func (e Entry) Cloud() string {
+	if e.CloudProvider == "" {
+		return "aws"
+	}
+	return e.CloudProvider
+}
+
+// QuotaKey identifies a kind of cluster quota is tracked for.
+type QuotaKey struct {
+	CloudProvider string
+	MultiAZ       bool
+}
+
+// Key returns the kind of quota the entry's cluster uses.
+func (e Entry) Key() QuotaKey {
+	return QuotaKey{CloudProvider: e.Cloud(), MultiAZ: e.MultiAZ}
+}
+
+// Read loads and validates a matrix from the given file.
+func Read(file string) (*Matrix, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading matrix %s: %v", file, err)
+	}
+
+	m := &Matrix{}
+	if err = yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, fmt.Errorf("error parsing matrix %s: %v", file, err)
+	}
+	if err = m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid matrix %s: %v", file, err)
+	}
+	return m, nil
+}
+
+// Validate checks that the matrix has environments and entries, that their names are unique, and that each
+// environment names its OCM environment.
+func (m *Matrix) Validate() error {
+	if len(m.Environments) == 0 {
+		return fmt.Errorf("no environments")
+	}
+	if len(m.Entries) == 0 {
+		return fmt.Errorf("no entries")
+	}
+
+	names := map[string]bool{}
+	for _, env := range m.Environments {
+		if env.Name == "" {
+			return fmt.Errorf("environment without a name")
+		} else if env.Env == "" {
+			return fmt.Errorf("environment %s has no OCM environment", env.Name)
+		} else if names[env.Name] {
+			return fmt.Errorf("environment %s is listed more than once", env.Name)
+		}
+		names[env.Name] = true
+	}
+
+	names = map[string]bool{}
+	for _, entry := range m.Entries {
+		if entry.Name == "" {
+			return fmt.Errorf("entry without a name")
+		} else if names[entry.Name] {
+			return fmt.Errorf("entry %s is listed more than once", entry.Name)
+		}
+		names[entry.Name] = true
+	}
+	return nil
+}
+
+// Keys returns the kinds of quota the entries use, sorted.
+func (m *Matrix) Keys() []QuotaKey {
+	seen := map[QuotaKey]bool{}
+	keys := []QuotaKey{}
+	for _, entry := range m.Entries {
+		if key := entry.Key(); !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CloudProvider != keys[j].CloudProvider {
+			return keys[i].CloudProvider < keys[j].CloudProvider
+		}
+		return !keys[i].MultiAZ && keys[j].MultiAZ
+	})
+	return keys
+}
+
+// Quota is how many more clusters of each kind an environment can create. A kind that is missing is unknown and
+// treated as unlimited, as when the provider can't report quota.
+type Quota map[QuotaKey]int
+
+// Assignment is an entry and the environment it will create its cluster in.
+type Assignment struct {
+	Entry       string `yaml:"entry"`
+	Environment string `yaml:"environment"`
+}
+
+// Schedule is where each entry of a matrix will run.
+type Schedule struct {
+	// Assignments are the entries that fit in the environments' quota, in the matrix's order.
+	Assignments []Assignment `yaml:"assignments"`
+
+	// Unscheduled are the entries no environment had quota left for.
+	Unscheduled []string `yaml:"unscheduled,omitempty"`
+}
+
+// Plan assigns each entry to the environment with the most quota left for its cluster, preferring earlier
+// environments on ties. All clusters are assumed to exist at once, so entries that don't fit are reported up front
+// instead of failing midway.
+func (m *Matrix) Plan(quotas map[string]Quota) *Schedule {
+	remaining := map[string]Quota{}
+	for name, quota := range quotas {
+		remaining[name] = Quota{}
+		for key, count := range quota {
+			remaining[name][key] = count
+		}
+	}
+
+	schedule := &Schedule{Assignments: []Assignment{}}
+	for _, entry := range m.Entries {
+		key := entry.Key()
+		best, bestLeft, unlimited := "", 0, false
+		for _, env := range m.Environments {
+			left, known := remaining[env.Name][key]
+			if !known {
+				best, unlimited = env.Name, true
+				break
+			}
+			if left > bestLeft {
+				best, bestLeft = env.Name, left
+			}
+		}
+
+		if best == "" {
+			schedule.Unscheduled = append(schedule.Unscheduled, entry.Name)
+			continue
+		}
+		if !unlimited {
+			remaining[best][key]--
+		}
+		schedule.Assignments = append(schedule.Assignments, Assignment{Entry: entry.Name, Environment: best})
+	}
+	return schedule
+}
+
+// Environment returns the environment with the given name, or nil if the matrix doesn't have one.
+func (m *Matrix) Environment(name string) *Environment {
+	for i := range m.Environments {
+		if m.Environments[i].Name == name {
+			return &m.Environments[i]
+		}
+	}
+	return nil
+}
+
+// Entry returns the entry with the given name, or nil if the matrix doesn't have one.
+func (m *Matrix) Entry(name string) *Entry {
+	for i := range m.Entries {
+		if m.Entries[i].Name == name {
+			return &m.Entries[i]
+		}
+	}
+	return nil
+}
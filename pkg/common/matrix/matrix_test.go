@@ -0,0 +1,81 @@
+package matrix
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	m := &Matrix{
+		Environments: []Environment{{Name: "stage-a", Env: "stage"}, {Name: "stage-b", Env: "stage"}},
+		Entries: []Entry{
+			{Name: "4.5-us-east-1", Region: "us-east-1"},
+			{Name: "4.5-us-west-2", Region: "us-west-2"},
+			{Name: "4.6-us-east-1", Region: "us-east-1"},
+			{Name: "4.6-multi-az", MultiAZ: true},
+			{Name: "4.6-gcp", CloudProvider: "gcp"},
+			{Name: "4.6-eu-west-1", Region: "eu-west-1"},
+		},
+	}
+	quotas := map[string]Quota{
+		"stage-a": {{"aws", false}: 1, {"aws", true}: 0, {"gcp", false}: 0},
+		"stage-b": {{"aws", false}: 2, {"aws", true}: 1, {"gcp", false}: 0},
+	}
+
+	expected := &Schedule{
+		Assignments: []Assignment{
+			{Entry: "4.5-us-east-1", Environment: "stage-b"},
+			{Entry: "4.5-us-west-2", Environment: "stage-a"},
+			{Entry: "4.6-us-east-1", Environment: "stage-b"},
+			{Entry: "4.6-multi-az", Environment: "stage-b"},
+		},
+		Unscheduled: []string{"4.6-gcp", "4.6-eu-west-1"},
+	}
+	if schedule := m.Plan(quotas); !reflect.DeepEqual(schedule, expected) {
+		t.Errorf("expected schedule %+v, got %+v", expected, schedule)
+	}
+
+	if quotas["stage-b"][QuotaKey{"aws", false}] != 2 {
+		t.Errorf("expected planning to leave the queried quota unchanged")
+	}
+
+	// environments that can't report quota are treated as unlimited
+	delete(quotas, "stage-a")
+	schedule := m.Plan(quotas)
+	if len(schedule.Unscheduled) != 0 || len(schedule.Assignments) != len(m.Entries) {
+		t.Errorf("expected every entry to be scheduled, got %+v", schedule)
+	}
+}
+
+func TestRead(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := map[string]string{
+		"environments:\n- name: stage\n  env: stage\nentries:\n- name: a\n  region: us-east-1\n": "",
+		"environments:\n- name: stage\n  env: stage\nentries:\n- name: a\n- name: a\n":           "entry a is listed more than once",
+		"entries:\n- name: a\n": "no environments",
+		"environments:\n- name: stage\n  env: stage\nentries:\n- name: a\n  zone: us-east-1a\n":                   "field zone not found",
+		"environments:\n- name: stage\n  tokenEnv: STAGE_TOKEN\n- name: prod\n  env: prod\nentries:\n- name: a\n": "environment stage has no OCM environment",
+	}
+	for contents, expectedErr := range tests {
+		file := filepath.Join(tmpDir, "matrix.yaml")
+		if err = ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write matrix: %v", err)
+		}
+
+		_, err = Read(file)
+		if expectedErr == "" && err != nil {
+			t.Errorf("expected matrix %q to be valid, got: %v", contents, err)
+		} else if expectedErr != "" && (err == nil || !strings.Contains(err.Error(), expectedErr)) {
+			t.Errorf("expected matrix %q to fail with %q, got: %v", contents, expectedErr, err)
+		}
+	}
+}
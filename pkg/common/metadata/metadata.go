@@ -9,6 +9,8 @@ import (
 
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+	"github.com/openshift/osde2e/pkg/common/triage"
 )
 
 const (
@@ -29,8 +31,64 @@ type Metadata struct {
 	ClusterName          string `json:"cluster-name"`
 	ClusterVersion       string `json:"cluster-version"`
 	Environment          string `json:"environment"`
+	Region               string `json:"region"`
+	ConsoleURL           string `json:"console-url"`
+	APIURL               string `json:"api-url"`
+	ClusterPageURL       string `json:"cluster-page-url"`
 	UpgradeVersion       string `json:"upgrade-version,omitempty"`
 	UpgradeVersionSource string `json:"upgrade-version-source,omitempty"`
+	ScenarioCommit       string `json:"scenario-commit,omitempty"`
+	DeprovisionFailure   string `json:"deprovision-failure,omitempty"`
+	AbortReason          string `json:"abort-reason,omitempty"`
+
+	// FailureClassification is the category of the install or upgrade failure of the run
+	FailureClassification *triage.Classification `json:"failure-classification,omitempty"`
+
+	// SkippedHealthChecks are the health checks that were skipped when waiting for the cluster to be healthy
+	SkippedHealthChecks []string `json:"skipped-health-checks,omitempty"`
+
+	// VersionGates are the labels of the version gates the upgrade required, and AcknowledgedVersionGates the
+	// ones osde2e acknowledged
+	VersionGates             []string `json:"version-gates,omitempty"`
+	AcknowledgedVersionGates []string `json:"acknowledged-version-gates,omitempty"`
+
+	// LeakedStorage are the persistent volumes and EBS volumes left behind by the run
+	LeakedStorage []string `json:"leaked-storage,omitempty"`
+
+	// LeakedObjects are the objects created by the run's specs that were left on the cluster, and the specs that
+	// created them
+	LeakedObjects []string `json:"leaked-objects,omitempty"`
+
+	// ClusterDrift are the ways the cluster differed from what the provider believes was requested at the start and
+	// end of the run
+	ClusterDrift []string `json:"cluster-drift,omitempty"`
+
+	// ArtifactEncryptionKeys are the IDs of the keys the artifacts were encrypted for
+	ArtifactEncryptionKeys []string `json:"artifact-encryption-keys,omitempty"`
+
+	// SuiteClasses are the results of the blocking and informing suites in each phase
+	SuiteClasses []suiteclass.Result `json:"suite-classes,omitempty"`
+
+	// FlakyTests are the tests that failed and then passed when they were run again
+	FlakyTests []string `json:"flaky-tests,omitempty"`
+
+	// DNSPropagation is how many seconds the names of the cluster's API server and routes took to resolve
+	// on every resolver after install, by the name they're reported as
+	DNSPropagation map[string]float64 `json:"dns-propagation,omitempty"`
+
+	// DNSMismatches are what each resolver answered for the names of the cluster that resolved to different addresses
+	// on different resolvers, by the name they're reported as
+	DNSMismatches map[string][]string `json:"dns-mismatches,omitempty"`
+
+	// AlertDiffs are the alerts that started and stopped firing while the tests of each phase ran, by phase
+	AlertDiffs map[string]AlertDiff `json:"alert-diffs,omitempty"`
+
+	// OCMRetries counts the OCM requests that were retried, by the status code of the response or "connection" if
+	// there was none
+	OCMRetries map[string]int `json:"ocm-retries,omitempty"`
+
+	// Attempts are the earlier attempts of a run that was retried on a new cluster
+	Attempts []Attempt `json:"attempts,omitempty"`
 
 	// Metrics
 	TimeToOCMReportingInstalled float64        `json:"time-to-ocm-reporting-installed,string"`
@@ -40,9 +98,33 @@ type Metadata struct {
 	TimeToCertificateIssued     float64        `json:"time-to-certificate-issued,string"`
 	InstallPhasePassRate        float64        `json:"install-phase-pass-rate,string"`
 	UpgradePhasePassRate        float64        `json:"upgrade-phase-pass-rate,string"`
+	InstallPhaseFlakeRate       float64        `json:"install-phase-flake-rate,string"`
+	UpgradePhaseFlakeRate       float64        `json:"upgrade-phase-flake-rate,string"`
+	EtcdDBSizeBeforeUpgrade     float64        `json:"etcd-db-size-before-upgrade,string"`
+	EtcdDBSizeAfterUpgrade      float64        `json:"etcd-db-size-after-upgrade,string"`
+	TimeToHibernate             float64        `json:"time-to-hibernate,string"`
+	TimeToResume                float64        `json:"time-to-resume,string"`
+	TimeToResumedClusterHealthy float64        `json:"time-to-resumed-cluster-healthy,string"`
+	TimeToMachineReplaced       float64        `json:"time-to-machine-replaced,string"`
 	LogMetrics                  map[string]int `json:"log-metrics"`
 }
 
+// Attempt describes an attempt of a run that failed and was retried.
+type Attempt struct {
+	Number         int    `json:"number"`
+	ClusterID      string `json:"cluster-id"`
+	Phase          string `json:"phase"`
+	Classification string `json:"classification"`
+	Failure        string `json:"failure"`
+	ResultsDir     string `json:"results-dir"`
+}
+
+// AlertDiff describes how the alerts firing on the cluster changed while the tests of a phase ran.
+type AlertDiff struct {
+	Introduced []string `json:"introduced,omitempty"`
+	Resolved   []string `json:"resolved,omitempty"`
+}
+
 // Instance is the global metadata instance
 var Instance *Metadata
 
@@ -50,6 +132,8 @@ func init() {
 	Instance = &Metadata{}
 	Instance.InstallPhasePassRate = -1.0
 	Instance.UpgradePhasePassRate = -1.0
+	Instance.InstallPhaseFlakeRate = -1.0
+	Instance.UpgradePhaseFlakeRate = -1.0
 	Instance.LogMetrics = make(map[string]int)
 }
 
@@ -81,6 +165,15 @@ func (m *Metadata) SetEnvironment(env string) {
 	m.WriteToJSON(config.Instance.ReportDir)
 }
 
+// SetClusterAccess sets the region of the cluster and the URLs used to reach it
+func (m *Metadata) SetClusterAccess(region, consoleURL, apiURL, clusterPageURL string) {
+	m.Region = region
+	m.ConsoleURL = consoleURL
+	m.APIURL = apiURL
+	m.ClusterPageURL = clusterPageURL
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
 // SetUpgradeVersion sets the cluster upgrade version
 func (m *Metadata) SetUpgradeVersion(ver string) {
 	m.UpgradeVersion = ver
@@ -93,6 +186,97 @@ func (m *Metadata) SetUpgradeVersionSource(src string) {
 	m.WriteToJSON(config.Instance.ReportDir)
 }
 
+// SetScenarioCommit sets the commit of the scenario repository used for the run
+func (m *Metadata) SetScenarioCommit(commit string) {
+	m.ScenarioCommit = commit
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetDeprovisionFailure sets the classification of a failed cluster deletion
+func (m *Metadata) SetDeprovisionFailure(failure string) {
+	m.DeprovisionFailure = failure
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetLeakedStorage sets the storage left behind by the run
+func (m *Metadata) SetLeakedStorage(leaks []string) {
+	m.LeakedStorage = leaks
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetLeakedObjects sets the objects created by the run's specs that were left on the cluster
+func (m *Metadata) SetLeakedObjects(leaks []string) {
+	m.LeakedObjects = leaks
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetClusterDrift sets the ways the cluster differed from what the provider believes was requested
+func (m *Metadata) SetClusterDrift(drift []string) {
+	m.ClusterDrift = drift
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetAbortReason sets why the run was aborted
+func (m *Metadata) SetAbortReason(reason string) {
+	m.AbortReason = reason
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetFailureClassification sets the category of the install or upgrade failure of the run
+func (m *Metadata) SetFailureClassification(classification *triage.Classification) {
+	m.FailureClassification = classification
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetSkippedHealthChecks sets the health checks that were skipped
+func (m *Metadata) SetSkippedHealthChecks(checks []string) {
+	m.SkippedHealthChecks = checks
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetVersionGates sets the version gates the upgrade required and the ones osde2e acknowledged
+func (m *Metadata) SetVersionGates(gates, acknowledged []string) {
+	m.VersionGates = gates
+	m.AcknowledgedVersionGates = acknowledged
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetArtifactEncryptionKeys sets the IDs of the keys the artifacts were encrypted for
+func (m *Metadata) SetArtifactEncryptionKeys(keyIDs []string) {
+	m.ArtifactEncryptionKeys = keyIDs
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// AddSuiteClassResults records the results of the suite classes in a phase
+func (m *Metadata) AddSuiteClassResults(results []suiteclass.Result) {
+	m.SuiteClasses = append(m.SuiteClasses, results...)
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// ResetSuiteClassResults clears the results of the suite classes
+func (m *Metadata) ResetSuiteClassResults() {
+	m.SuiteClasses = nil
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// AddFlakyTests records tests that failed and then passed when they were run again
+func (m *Metadata) AddFlakyTests(tests []string) {
+	m.FlakyTests = append(m.FlakyTests, tests...)
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// ResetFlakyTests clears the flaky tests
+func (m *Metadata) ResetFlakyTests() {
+	m.FlakyTests = nil
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// AddAttempt records an attempt of the run that was retried
+func (m *Metadata) AddAttempt(attempt Attempt) {
+	m.Attempts = append(m.Attempts, attempt)
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
 // SetTimeToOCMReportingInstalled sets the time it took for OCM to report a cluster provisioned
 func (m *Metadata) SetTimeToOCMReportingInstalled(timeToOCMReportingInstalled float64) {
 	m.TimeToOCMReportingInstalled = timeToOCMReportingInstalled
@@ -123,6 +307,44 @@ func (m *Metadata) SetTimeToCertificateIssued(timeToCertificateIssued float64) {
 	m.WriteToJSON(config.Instance.ReportDir)
 }
 
+// SetDNSPropagation sets how long the names of the cluster took to propagate to DNS resolvers, and what the resolvers
+// answered for names they didn't agree on
+func (m *Metadata) SetDNSPropagation(seconds map[string]float64, mismatches map[string][]string) {
+	m.DNSPropagation = seconds
+	m.DNSMismatches = mismatches
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetAlertDiff sets the alerts that started and stopped firing while the tests of the given phase ran
+func (m *Metadata) SetAlertDiff(phase string, introduced, resolved []string) {
+	if m.AlertDiffs == nil {
+		m.AlertDiffs = map[string]AlertDiff{}
+	}
+	m.AlertDiffs[phase] = AlertDiff{Introduced: introduced, Resolved: resolved}
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetEtcdDBSize sets the size in bytes of the largest etcd database before and after an upgrade
+func (m *Metadata) SetEtcdDBSize(before, after float64) {
+	m.EtcdDBSizeBeforeUpgrade = before
+	m.EtcdDBSizeAfterUpgrade = after
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetHibernationTimes sets the time it took for the cluster to hibernate, to resume, and to be healthy after resuming
+func (m *Metadata) SetHibernationTimes(timeToHibernate, timeToResume, timeToResumedClusterHealthy float64) {
+	m.TimeToHibernate = timeToHibernate
+	m.TimeToResume = timeToResume
+	m.TimeToResumedClusterHealthy = timeToResumedClusterHealthy
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
+// SetTimeToMachineReplaced sets the time it took for a machine health check to replace an unhealthy worker
+func (m *Metadata) SetTimeToMachineReplaced(timeToMachineReplaced float64) {
+	m.TimeToMachineReplaced = timeToMachineReplaced
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
 // SetPassRate sets the passrate metadata metric for the given phase
 func (m *Metadata) SetPassRate(currentPhase string, passRate float64) {
 	if currentPhase == phase.InstallPhase {
@@ -135,6 +357,19 @@ func (m *Metadata) SetPassRate(currentPhase string, passRate float64) {
 	}
 }
 
+// SetFlakeRate sets the fraction of the tests run in the given phase that were flaky
+func (m *Metadata) SetFlakeRate(currentPhase string, flakeRate float64) {
+	if currentPhase == phase.InstallPhase {
+		m.InstallPhaseFlakeRate = flakeRate
+	} else if currentPhase == phase.UpgradePhase {
+		m.UpgradePhaseFlakeRate = flakeRate
+	} else {
+		// This is a developer issue, so this should fail ungracefully.
+		panic(fmt.Sprintf("Invalid phase: %s, couldn't set flake rate.", currentPhase))
+	}
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
 // ResetLogMetrics zeroes out old results to be used before a new run.
 func (m *Metadata) ResetLogMetrics() {
 	for metric := range m.LogMetrics {
@@ -154,6 +389,12 @@ func (m *Metadata) IncrementLogMetric(metric string, value int) {
 	m.WriteToJSON(config.Instance.ReportDir)
 }
 
+// SetOCMRetries sets the counts of retried OCM requests
+func (m *Metadata) SetOCMRetries(retries map[string]int) {
+	m.OCMRetries = retries
+	m.WriteToJSON(config.Instance.ReportDir)
+}
+
 // WriteToJSON will marshall the metadata struct and write it into the given file.
 func (m *Metadata) WriteToJSON(reportDir string) (err error) {
 	var data []byte
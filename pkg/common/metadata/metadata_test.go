@@ -43,6 +43,10 @@ func TestMetadata(t *testing.T) {
 				ClusterName:                 "test-name",
 				ClusterVersion:              "test-version",
 				Environment:                 "test-environment",
+				Region:                      "test-region",
+				ConsoleURL:                  "test-console-url",
+				APIURL:                      "test-api-url",
+				ClusterPageURL:              "test-cluster-page-url",
 				UpgradeVersion:              "test-upgrade",
 				TimeToOCMReportingInstalled: 123.45,
 				TimeToClusterReady:          456.78,
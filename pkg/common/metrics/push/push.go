@@ -0,0 +1,211 @@
+// Package push pushes run-level metrics to a Prometheus Pushgateway at the end of each phase, so dashboards can
+// follow how long clusters take to provision and upgrade and how many tests pass without parsing JUnit files.
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+const (
+	// DefaultJob is the job metrics are grouped under if none is given.
+	DefaultJob = "osde2e"
+
+	namespace = "osde2e"
+
+	// jobIDLabel groups the metrics of each run, so concurrent runs of a job don't replace each other's metrics.
+	jobIDLabel = "job_id"
+
+	// phaseLabel groups the metrics of each phase, so pushing one phase doesn't replace the metrics of the other.
+	phaseLabel = "phase"
+
+	requestTimeout = 30 * time.Second
+)
+
+// Run is the run-level metrics of a phase. Durations that are zero weren't measured and aren't pushed.
+type Run struct {
+	// Phase is the phase that ended.
+	Phase string
+
+	// JobID identifies the run among the runs of its job.
+	JobID string
+
+	// Labels are added to every metric, such as the cluster and versions of the run.
+	Labels map[string]string
+
+	// ProvisionSeconds is how long the cluster took to be reported installed by its provider.
+	ProvisionSeconds float64
+
+	// HealthCheckWaitSeconds is how long the cluster took to pass its health checks once installed or upgraded.
+	HealthCheckWaitSeconds float64
+
+	// UpgradeSeconds is how long the upgrade took.
+	UpgradeSeconds float64
+
+	// Passed, Failed, and Skipped count the tests of the phase.
+	Passed  int
+	Failed  int
+	Skipped int
+
+	// Success is whether the phase passed.
+	Success bool
+}
+
+// Pusher pushes metrics to a Pushgateway.
+type Pusher struct {
+	baseURL string
+	job     string
+	token   string
+	client  *http.Client
+	retry   backoff.Backoff
+	now     func() time.Time
+}
+
+// New creates a pusher for the Pushgateway at baseURL grouping metrics under job. The token, if any, is sent as a
+// bearer token.
+func New(baseURL, job, token string) *Pusher {
+	if job == "" {
+		job = DefaultJob
+	}
+
+	retry := backoff.Exponential(5*time.Second, time.Minute)
+	retry.MaxAttempts = 5
+
+	return &Pusher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		job:     job,
+		token:   token,
+		client:  &http.Client{Timeout: requestTimeout},
+		retry:   retry,
+		now:     time.Now,
+	}
+}
+
+// Push replaces the metrics of the run's phase in the group of the job and run.
+func (p *Pusher) Push(run Run) error {
+	body, err := p.exposition(run)
+	if err != nil {
+		return err
+	}
+
+	address := p.baseURL + "/metrics/" + groupingPath("job", p.job) + "/" + groupingPath(jobIDLabel, run.JobID) + "/" + groupingPath(phaseLabel, run.Phase)
+	return p.retry.Retry(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodPut, address, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", string(expfmt.FmtText))
+		if p.token != "" {
+			req.Header.Set("Authorization", "Bearer "+p.token)
+		}
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			data, _ := ioutil.ReadAll(resp.Body)
+			err = fmt.Errorf("Pushgateway returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+			if resp.StatusCode < http.StatusInternalServerError {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// exposition returns the run's metrics in the Prometheus text format.
+func (p *Pusher) exposition(run Run) ([]byte, error) {
+	labelNames := make([]string, 0, len(run.Labels))
+	for name := range run.Labels {
+		labelNames = append(labelNames, name)
+	}
+	sort.Strings(labelNames)
+
+	registry := prometheus.NewRegistry()
+	gauge := func(name, help string, extraLabels ...string) *prometheus.GaugeVec {
+		vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: namespace, Name: name, Help: help}, append(append([]string{}, labelNames...), extraLabels...))
+		registry.MustRegister(vec)
+		return vec
+	}
+	labels := func(extra ...string) prometheus.Labels {
+		l := prometheus.Labels{}
+		for name, value := range run.Labels {
+			l[name] = value
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			l[extra[i]] = extra[i+1]
+		}
+		return l
+	}
+
+	durations := []struct {
+		name, help string
+		seconds    float64
+	}{
+		{"provision_duration_seconds", "Time for the cluster to be reported installed by its provider.", run.ProvisionSeconds},
+		{"healthcheck_wait_seconds", "Time for the cluster to pass its health checks once installed or upgraded.", run.HealthCheckWaitSeconds},
+		{"upgrade_duration_seconds", "Time for the cluster to be upgraded.", run.UpgradeSeconds},
+	}
+	for _, duration := range durations {
+		if duration.seconds > 0 {
+			gauge(duration.name, duration.help).With(labels()).Set(duration.seconds)
+		}
+	}
+
+	tests := gauge("tests", "Tests of the phase by result.", "result")
+	tests.With(labels("result", "passed")).Set(float64(run.Passed))
+	tests.With(labels("result", "failed")).Set(float64(run.Failed))
+	tests.With(labels("result", "skipped")).Set(float64(run.Skipped))
+
+	success := 0.0
+	if run.Success {
+		success = 1
+	}
+	gauge("phase_success", "Whether the phase passed.").With(labels()).Set(success)
+	gauge("phase_completion_timestamp_seconds", "Time the phase ended.").With(labels()).Set(float64(p.now().Unix()))
+
+	families, err := registry.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("error gathering metrics: %v", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := expfmt.NewEncoder(&buf, expfmt.FmtText)
+	for _, family := range families {
+		if err = encoder.Encode(family); err != nil {
+			return nil, fmt.Errorf("error encoding metric family: %v", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// groupingPath returns the path of a label of the grouping key. Values that can't be put in a path are base64
+// encoded.
+func groupingPath(name, value string) string {
+	if value == "" {
+		// the Pushgateway reads a lone "=" as an empty base64 value
+		return name + "@base64/="
+	}
+	if strings.Contains(value, "/") {
+		return name + "@base64/" + base64.URLEncoding.EncodeToString([]byte(value))
+	}
+	return name + "/" + url.PathEscape(value)
+}
@@ -0,0 +1,103 @@
+package push
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func testPusher(url, job string) *Pusher {
+	p := New(url, job, "secret")
+	p.retry = backoff.Constant(time.Millisecond, 3)
+	p.now = func() time.Time { return time.Unix(1600000000, 0) }
+	return p
+}
+
+func TestPush(t *testing.T) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected request: %s %v", r.Method, r.Header)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		path, body = r.URL.EscapedPath(), string(data)
+	}))
+	defer server.Close()
+
+	run := Run{
+		Phase:                  "install",
+		JobID:                  "123",
+		Labels:                 map[string]string{"cluster_id": "abc", "install_version": "openshift-v4.5.1"},
+		ProvisionSeconds:       1800,
+		HealthCheckWaitSeconds: 600,
+		Passed:                 10,
+		Failed:                 2,
+		Success:                false,
+	}
+	if err := testPusher(server.URL, "osde2e-stage-aws-e2e").Push(run); err != nil {
+		t.Fatalf("failed to push: %v", err)
+	}
+
+	if expected := "/metrics/job/osde2e-stage-aws-e2e/job_id/123/phase/install"; path != expected {
+		t.Errorf("expected the metrics to be grouped under %s, got %s", expected, path)
+	}
+
+	for _, expected := range []string{
+		`osde2e_provision_duration_seconds{cluster_id="abc",install_version="openshift-v4.5.1"} 1800`,
+		`osde2e_healthcheck_wait_seconds{cluster_id="abc",install_version="openshift-v4.5.1"} 600`,
+		`osde2e_tests{cluster_id="abc",install_version="openshift-v4.5.1",result="passed"} 10`,
+		`osde2e_tests{cluster_id="abc",install_version="openshift-v4.5.1",result="failed"} 2`,
+		`osde2e_tests{cluster_id="abc",install_version="openshift-v4.5.1",result="skipped"} 0`,
+		`osde2e_phase_success{cluster_id="abc",install_version="openshift-v4.5.1"} 0`,
+		`osde2e_phase_completion_timestamp_seconds{cluster_id="abc",install_version="openshift-v4.5.1"} 1.6e+09`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("expected the metrics to include %s, got:\n%s", expected, body)
+		}
+	}
+	if strings.Contains(body, "upgrade_duration_seconds") {
+		t.Errorf("expected durations that weren't measured to be left out, got:\n%s", body)
+	}
+}
+
+func TestPushErrors(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if strings.Contains(r.URL.Path, "upgrade") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	p := testPusher(server.URL, "")
+	if err := p.Push(Run{Phase: "install"}); err == nil || requests != 3 {
+		t.Errorf("expected server errors to be retried, got %d requests: %v", requests, err)
+	}
+
+	requests = 0
+	if err := p.Push(Run{Phase: "upgrade"}); err == nil || requests != 1 {
+		t.Errorf("expected client errors not to be retried, got %d requests: %v", requests, err)
+	}
+}
+
+func TestGroupingPath(t *testing.T) {
+	tests := map[string]string{
+		"osde2e":        "job/osde2e",
+		"":              "job@base64/=",
+		"team/nightly":  "job@base64/dGVhbS9uaWdodGx5",
+		"nightly run 1": "job/nightly%20run%201",
+	}
+	for value, expected := range tests {
+		if path := groupingPath("job", value); path != expected {
+			t.Errorf("expected %q to be %s, got %s", value, expected, path)
+		}
+	}
+}
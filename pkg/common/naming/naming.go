@@ -0,0 +1,118 @@
+// Package naming generates cluster names from a template, so what a cluster is for can be told from its name. The
+// name of a cluster is also the prefix of its DNS domain.
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+const (
+	// MaxLength is the longest name generated, as names are used as DNS labels.
+	MaxLength = 63
+
+	// suffixLength is the length of the random suffix added to names that are already taken.
+	suffixLength = 3
+
+	// attempts is how many names are tried before giving up on finding one that isn't taken.
+	attempts = 5
+)
+
+// invalidChars are the characters that can't be used in a DNS label.
+var invalidChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Values are what name templates can refer to.
+type Values struct {
+	// Job is the name of the job running osde2e.
+	Job string
+
+	// JobID is the ID of the job running osde2e.
+	JobID int
+
+	// Purpose describes what the cluster is for.
+	Purpose string
+
+	// Version is the version the cluster is installed with, with dashes instead of dots.
+	Version string
+
+	// Date is the date the name was generated, as YYYYMMDD.
+	Date string
+
+	// Suffix is a random suffix.
+	Suffix string
+}
+
+// NewValues returns the values for a cluster installed with version, which may have the OpenShift version prefix.
+func NewValues(job string, jobID int, purpose, version, suffix string) Values {
+	version = strings.TrimPrefix(version, util.VersionPrefix)
+	return Values{
+		Job:     job,
+		JobID:   jobID,
+		Purpose: purpose,
+		Version: strings.Replace(version, ".", "-", -1),
+		Date:    time.Now().UTC().Format("20060102"),
+		Suffix:  suffix,
+	}
+}
+
+// TakenFunc returns true if a cluster already has a name.
+type TakenFunc func(name string) (bool, error)
+
+// Generate renders the template into a valid cluster name. If the name is taken, a random suffix is added until a
+// free name is found. If taken is nil, names aren't checked.
+func Generate(nameTemplate string, values Values, taken TakenFunc) (string, error) {
+	tmpl, err := template.New("name").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing cluster name template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("error executing cluster name template: %v", err)
+	}
+
+	base := sanitize(buf.String(), MaxLength)
+	if base == "" {
+		return "", fmt.Errorf("cluster name template %q generated an empty name", nameTemplate)
+	}
+
+	name := base
+	for i := 0; i < attempts; i++ {
+		if taken == nil {
+			return name, nil
+		}
+
+		isTaken, err := taken(name)
+		if err != nil {
+			return "", fmt.Errorf("error checking if cluster name %s is taken: %v", name, err)
+		}
+		if !isTaken {
+			return name, nil
+		}
+
+		suffix := "-" + util.RandomStr(suffixLength)
+		name = sanitize(base, MaxLength-len(suffix)) + suffix
+	}
+	return "", fmt.Errorf("couldn't find a cluster name based on %s that isn't taken", base)
+}
+
+// sanitize makes a name a valid DNS label of at most maxLength characters: lowercase, starting with a letter, and
+// containing only letters, digits, and dashes.
+func sanitize(name string, maxLength int) string {
+	name = invalidChars.ReplaceAllString(strings.ToLower(name), "-")
+	for strings.Contains(name, "--") {
+		name = strings.Replace(name, "--", "-", -1)
+	}
+
+	name = strings.TrimLeft(name, "-0123456789")
+	if len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	return strings.Trim(name, "-")
+}
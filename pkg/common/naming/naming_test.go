@@ -0,0 +1,78 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	values := NewValues("osde2e-Prod AWS_e2e", 123, "upgrade", "openshift-v4.5.1", "x1z")
+
+	tests := []struct {
+		description string
+		template    string
+		expected    string
+	}{
+		{
+			description: "default",
+			template:    "ci-cluster-{{.Version}}-{{.Suffix}}",
+			expected:    "ci-cluster-4-5-1-x1z",
+		},
+		{
+			description: "job and purpose",
+			template:    "{{.Purpose}}-{{.Job}}-{{.JobID}}",
+			expected:    "upgrade-osde2e-prod-aws-e2e-123",
+		},
+		{
+			description: "leading digits and dashes",
+			template:    "{{.JobID}}--{{.Purpose}}-",
+			expected:    "upgrade",
+		},
+		{
+			description: "too long",
+			template:    strings.Repeat("a", 70) + "-{{.Suffix}}",
+			expected:    strings.Repeat("a", MaxLength),
+		},
+	}
+
+	for _, test := range tests {
+		if name, err := Generate(test.template, values, nil); err != nil || name != test.expected {
+			t.Errorf("%s: expected %s, got %s: %v", test.description, test.expected, name, err)
+		}
+	}
+}
+
+func TestGenerateTaken(t *testing.T) {
+	values := NewValues("", 0, "ci", "4.5.1", "abc")
+
+	checked := []string{}
+	name, err := Generate("{{.Purpose}}-{{.Version}}", values, func(name string) (bool, error) {
+		checked = append(checked, name)
+		return len(checked) < 3, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to generate name: %v", err)
+	}
+	if len(checked) != 3 || checked[0] != "ci-4-5-1" || name != checked[2] || !strings.HasPrefix(name, "ci-4-5-1-") {
+		t.Errorf("expected a suffix to be added to taken names, checked %v and got %s", checked, name)
+	}
+
+	if _, err = Generate("{{.Purpose}}", values, func(string) (bool, error) { return true, nil }); err == nil {
+		t.Errorf("expected an error when every name is taken")
+	}
+
+	if _, err = Generate("{{.Purpose}}", values, func(string) (bool, error) { return false, fmt.Errorf("status 503") }); err == nil {
+		t.Errorf("expected an error when names can't be checked")
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	values := NewValues("", 0, "", "", "")
+
+	for _, template := range []string{"{{.Purpose", "{{.Owner}}", "{{.Job}}---"} {
+		if name, err := Generate(template, values, nil); err == nil {
+			t.Errorf("expected template %q to fail, got %s", template, name)
+		}
+	}
+}
@@ -1,9 +1,119 @@
 package phase
 
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
 const (
 	// InstallPhase is the install phase.
 	InstallPhase = "install"
 
 	// UpgradePhase is the upgrade phase.
 	UpgradePhase = "upgrade"
-)
\ No newline at end of file
+)
+
+var (
+	deadlineMutex sync.Mutex
+	deadline      time.Time
+
+	abortMutex  sync.Mutex
+	abortErr    error
+	abortCtx    context.Context
+	abortCancel context.CancelFunc
+	cleaningUp  bool
+)
+
+func init() {
+	abortCtx, abortCancel = context.WithCancel(context.Background())
+}
+
+// SetDeadline sets the time the current phase must finish by. A zero time removes the deadline.
+func SetDeadline(t time.Time) {
+	deadlineMutex.Lock()
+	defer deadlineMutex.Unlock()
+	deadline = t
+}
+
+// Deadline returns the deadline of the current phase and whether one is set.
+func Deadline() (time.Time, bool) {
+	deadlineMutex.Lock()
+	defer deadlineMutex.Unlock()
+	return deadline, !deadline.IsZero()
+}
+
+// Context returns a context that is cancelled when the current phase deadline passes or the run is aborted.
+// The context never expires if there is no deadline.
+func Context() (context.Context, context.CancelFunc) {
+	parent := abortContext()
+	if d, ok := Deadline(); ok {
+		return context.WithDeadline(parent, d)
+	}
+	return context.WithCancel(parent)
+}
+
+// Poll runs condition immediately and then every interval until it's done, it fails, or timeout passes, like
+// wait.PollImmediate. Polling stops as soon as the current phase deadline passes or the run is aborted, rather than
+// at the next interval, so that OCM polls don't hold up a phase that is being torn down.
+func Poll(interval, timeout time.Duration, condition wait.ConditionFunc) error {
+	phaseCtx, cancel := Context()
+	defer cancel()
+
+	ctx, cancelTimeout := context.WithTimeout(phaseCtx, timeout)
+	defer cancelTimeout()
+
+	err := wait.PollImmediateUntil(interval, condition, ctx.Done())
+	if err == wait.ErrWaitTimeout && phaseCtx.Err() != nil {
+		if reason := Aborted(); reason != nil {
+			return fmt.Errorf("run was aborted while polling (%v): %w", reason, err)
+		}
+		return fmt.Errorf("phase deadline passed while polling: %w", err)
+	}
+	return err
+}
+
+// Abort stops the run, cancelling the contexts of phases until cleanup begins. Only the first reason is kept.
+func Abort(reason error) {
+	abortMutex.Lock()
+	defer abortMutex.Unlock()
+	if abortErr == nil {
+		abortErr = reason
+		abortCancel()
+	}
+}
+
+// Aborted returns why the run was aborted, or nil if it wasn't.
+func Aborted() error {
+	abortMutex.Lock()
+	defer abortMutex.Unlock()
+	return abortErr
+}
+
+// BeginCleanup marks the start of cleanup. Contexts returned from then on aren't cancelled by an abort,
+// so that clusters and other resources can still be removed.
+func BeginCleanup() {
+	abortMutex.Lock()
+	defer abortMutex.Unlock()
+	cleaningUp = true
+}
+
+// EndCleanup marks the end of cleanup when the run continues afterwards, such as when it is retried.
+// Contexts returned from then on are cancelled by an abort again.
+func EndCleanup() {
+	abortMutex.Lock()
+	defer abortMutex.Unlock()
+	cleaningUp = false
+}
+
+func abortContext() context.Context {
+	abortMutex.Lock()
+	defer abortMutex.Unlock()
+	if cleaningUp {
+		return context.Background()
+	}
+	return abortCtx
+}
@@ -0,0 +1,117 @@
+package phase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestContext(t *testing.T) {
+	SetDeadline(time.Time{})
+	ctx, cancel := Context()
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("context should not have a deadline when the phase has none")
+	}
+	cancel()
+
+	d := time.Now().Add(time.Hour)
+	SetDeadline(d)
+	defer SetDeadline(time.Time{})
+
+	ctx, cancel = Context()
+	defer cancel()
+	if ctxDeadline, ok := ctx.Deadline(); !ok || !ctxDeadline.Equal(d) {
+		t.Errorf("expected context deadline %v, got %v", d, ctxDeadline)
+	}
+
+	SetDeadline(time.Now().Add(-time.Second))
+	expired, cancelExpired := Context()
+	defer cancelExpired()
+	if expired.Err() == nil {
+		t.Errorf("context should be expired once the phase deadline passes")
+	}
+}
+
+func TestAbort(t *testing.T) {
+	defer func() {
+		abortErr, cleaningUp = nil, false
+		abortCtx, abortCancel = context.WithCancel(context.Background())
+	}()
+
+	inFlight, cancel := Context()
+	defer cancel()
+
+	reason := errors.New("budget exceeded")
+	Abort(reason)
+	Abort(errors.New("second reason"))
+
+	if Aborted() != reason {
+		t.Errorf("expected the first abort reason to be kept, got %v", Aborted())
+	}
+
+	if inFlight.Err() == nil {
+		t.Errorf("contexts in flight should be cancelled when the run is aborted")
+	}
+
+	aborted, cancelAborted := Context()
+	defer cancelAborted()
+	if aborted.Err() == nil {
+		t.Errorf("contexts should be cancelled once the run is aborted")
+	}
+
+	BeginCleanup()
+	cleanup, cancelCleanup := Context()
+	defer cancelCleanup()
+	if cleanup.Err() != nil {
+		t.Errorf("contexts used for cleanup shouldn't be cancelled by the abort")
+	}
+
+	EndCleanup()
+	resumed, cancelResumed := Context()
+	defer cancelResumed()
+	if resumed.Err() == nil {
+		t.Errorf("contexts should be cancelled by the abort once cleanup ends")
+	}
+}
+
+func TestPoll(t *testing.T) {
+	defer func() {
+		abortErr, cleaningUp = nil, false
+		abortCtx, abortCancel = context.WithCancel(context.Background())
+	}()
+
+	polls := 0
+	err := Poll(time.Millisecond, 20*time.Millisecond, func() (bool, error) {
+		polls++
+		return false, nil
+	})
+	if err != wait.ErrWaitTimeout || polls == 0 {
+		t.Errorf("expected polling to time out after polling, got %v after %d polls", err, polls)
+	}
+
+	// an abort stops polling without waiting for the next interval
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		Abort(errors.New("budget exceeded"))
+	}()
+
+	started := time.Now()
+	err = Poll(time.Hour, time.Hour, func() (bool, error) {
+		return false, nil
+	})
+	if err == nil || err == wait.ErrWaitTimeout {
+		t.Errorf("expected an error saying the run was aborted, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("polling should stop as soon as the run is aborted, took %v", elapsed)
+	}
+
+	// cleanup still polls until the condition is done
+	BeginCleanup()
+	if err = Poll(time.Millisecond, time.Second, func() (bool, error) { return true, nil }); err != nil {
+		t.Errorf("polling during cleanup shouldn't be stopped by the abort: %v", err)
+	}
+}
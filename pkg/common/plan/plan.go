@@ -0,0 +1,147 @@
+// Package plan describes the operations a run will make against its provider, so that they can be reviewed before
+// they're made and replayed later exactly as reviewed.
+package plan
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Kinds of operations.
+const (
+	// CreateCluster creates the cluster under test from the recorded payload.
+	CreateCluster = "create-cluster"
+
+	// InstallAddons installs addons onto the cluster.
+	InstallAddons = "install-addons"
+
+	// ConfigureAutoscaler configures the cluster autoscaler.
+	ConfigureAutoscaler = "configure-autoscaler"
+
+	// AddIdentityProvider adds an external OpenID Connect identity provider to the cluster.
+	AddIdentityProvider = "add-identity-provider"
+)
+
+// Plan is the sequence of operations a run will make against its provider, in the order they're made.
+type Plan struct {
+	// Created is when the plan was made.
+	Created time.Time `yaml:"created"`
+
+	// Provider and Environment are where the operations are made. A plan can only be applied to them.
+	Provider    string `yaml:"provider"`
+	Environment string `yaml:"environment"`
+
+	// Cluster is the cluster under test.
+	Cluster Cluster `yaml:"cluster"`
+
+	// Upgrade is the release the cluster is upgraded to, if any.
+	Upgrade *Upgrade `yaml:"upgrade,omitempty"`
+
+	// Operations are made in order.
+	Operations []Operation `yaml:"operations"`
+}
+
+// Cluster is the cluster a plan creates or uses.
+type Cluster struct {
+	// ID is set when the plan uses an existing cluster.
+	ID string `yaml:"id,omitempty"`
+
+	Name          string `yaml:"name"`
+	Version       string `yaml:"version"`
+	CloudProvider string `yaml:"cloudProvider"`
+	Region        string `yaml:"region"`
+}
+
+// Upgrade is the release a plan upgrades the cluster to.
+type Upgrade struct {
+	ReleaseName string `yaml:"releaseName"`
+	Image       string `yaml:"image,omitempty"`
+}
+
+// Operation is a change a run makes through its provider. Only the fields of its kind are set. Secrets are never
+// recorded, they're supplied by the config when the plan is applied.
+type Operation struct {
+	Kind string `yaml:"kind"`
+
+	// Description says what the operation does for reviewers.
+	Description string `yaml:"description"`
+
+	// Payload is the request creating the cluster, as sent to the provider.
+	Payload string `yaml:"payload,omitempty"`
+
+	// Addons are the IDs of the addons to install.
+	Addons []string `yaml:"addons,omitempty"`
+
+	Autoscaler *Autoscaler `yaml:"autoscaler,omitempty"`
+
+	IdentityProvider *IdentityProvider `yaml:"identityProvider,omitempty"`
+}
+
+// Autoscaler is the cluster autoscaler configuration of an operation.
+type Autoscaler struct {
+	MaxNodesTotal        int    `yaml:"maxNodesTotal"`
+	ScaleDownUtilization string `yaml:"scaleDownUtilization,omitempty"`
+}
+
+// IdentityProvider is the identity provider added by an operation, without its client secret.
+type IdentityProvider struct {
+	Issuer      string   `yaml:"issuer"`
+	ClientID    string   `yaml:"clientID"`
+	GroupsClaim string   `yaml:"groupsClaim"`
+	ExtraScopes []string `yaml:"extraScopes,omitempty"`
+}
+
+// Read loads a plan from the given file.
+func Read(file string) (*Plan, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading plan %s: %v", file, err)
+	}
+
+	p := &Plan{}
+	if err = yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("error parsing plan %s: %v", file, err)
+	}
+	return p, nil
+}
+
+// Write saves the plan to the given file.
+func (p *Plan) Write(file string) error {
+	data, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, os.FileMode(0644))
+}
+
+// Marshal returns the plan as YAML.
+func (p *Plan) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling plan: %v", err)
+	}
+	return data, nil
+}
+
+// Operation returns the operation of the given kind, or nil if the plan doesn't make it.
+func (p *Plan) Operation(kind string) *Operation {
+	for i := range p.Operations {
+		if p.Operations[i].Kind == kind {
+			return &p.Operations[i]
+		}
+	}
+	return nil
+}
+
+// Check returns an error if the plan wasn't made for the given provider and environment.
+func (p *Plan) Check(provider, environment string) error {
+	if p.Provider != provider || p.Environment != environment {
+		return fmt.Errorf("plan was made for provider %s in environment %s, not provider %s in environment %s",
+			p.Provider, p.Environment, provider, environment)
+	}
+	return nil
+}
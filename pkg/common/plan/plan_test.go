@@ -0,0 +1,71 @@
+package plan
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plan")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := &Plan{
+		Created:     time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		Provider:    "ocm",
+		Environment: "stage",
+		Cluster:     Cluster{Name: "osde2e-abcde", Version: "openshift-v4.5.1", CloudProvider: "aws", Region: "us-east-1"},
+		Upgrade:     &Upgrade{ReleaseName: "4.5.2"},
+		Operations: []Operation{
+			{Kind: CreateCluster, Description: "Create cluster osde2e-abcde", Payload: "{\n  \"name\": \"osde2e-abcde\"\n}"},
+			{Kind: InstallAddons, Description: "Install addons", Addons: []string{"prow-operator"}},
+			{Kind: ConfigureAutoscaler, Description: "Configure the autoscaler", Autoscaler: &Autoscaler{MaxNodesTotal: 12}},
+		},
+	}
+
+	file := filepath.Join(dir, "plan.yaml")
+	if err = p.Write(file); err != nil {
+		t.Fatalf("failed to write plan: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read plan file: %v", err)
+	}
+	// payloads are kept readable for reviewers
+	if !strings.Contains(string(data), "payload: |-") {
+		t.Errorf("expected the payload as a block, got:\n%s", data)
+	}
+
+	read, err := Read(file)
+	if err != nil {
+		t.Fatalf("failed to read plan: %v", err)
+	}
+	if !reflect.DeepEqual(read, p) {
+		t.Errorf("expected %+v, got %+v", p, read)
+	}
+
+	if op := read.Operation(InstallAddons); op == nil || op.Addons[0] != "prow-operator" {
+		t.Errorf("expected the install addons operation, got %+v", op)
+	}
+	if op := read.Operation(AddIdentityProvider); op != nil {
+		t.Errorf("expected no identity provider operation, got %+v", op)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := &Plan{Provider: "ocm", Environment: "stage"}
+	if err := p.Check("ocm", "stage"); err != nil {
+		t.Errorf("expected the plan to apply to its own environment: %v", err)
+	}
+	if err := p.Check("ocm", "prod"); err == nil {
+		t.Errorf("expected a stage plan not to apply to prod")
+	}
+}
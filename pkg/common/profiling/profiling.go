@@ -0,0 +1,127 @@
+// Package profiling exposes the runtime profiles of long-running osde2e processes so that their memory and
+// goroutine growth can be diagnosed.
+package profiling
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"os/signal"
+	"path/filepath"
+	rpprof "runtime/pprof"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+const (
+	// ProfilesDir is the directory in the report directory that profiles are written to.
+	ProfilesDir = "profiles"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// Profiles are the runtime profiles written by WriteProfiles.
+var Profiles = []string{"heap", "allocs", "goroutine", "threadcreate", "block", "mutex"}
+
+// Handler serves the pprof endpoints under /debug/pprof/ and the published expvars under /debug/vars.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// Serve serves Handler on addr until the returned function is called.
+func Serve(addr string) (stop func(), err error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't listen on %s: %v", addr, err)
+	}
+
+	server := &http.Server{Handler: Handler()}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logging.Errorf("Error serving profiles: %v", err)
+		}
+	}()
+	logging.Infof("Serving profiles on http://%s/debug/pprof/", listener.Addr())
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logging.Errorf("Error stopping the profiling server: %v", err)
+		}
+	}, nil
+}
+
+// WriteOnSignal writes the runtime profiles to dir every time the process receives sig, until the returned
+// function is called.
+func WriteOnSignal(dir string, sig os.Signal) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, sig)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				if written, err := WriteProfiles(dir, time.Now()); err != nil {
+					logging.Errorf("Error writing profiles: %v", err)
+				} else {
+					logging.Infof("Wrote profiles to %s", written)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// WriteProfiles writes the runtime profiles to a directory for the time they were taken under dir, and
+// returns that directory.
+func WriteProfiles(dir string, now time.Time) (string, error) {
+	profileDir := filepath.Join(dir, ProfilesDir, now.UTC().Format("20060102T150405Z"))
+	if err := os.MkdirAll(profileDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("couldn't create profile directory: %v", err)
+	}
+
+	for _, name := range Profiles {
+		if err := writeProfile(filepath.Join(profileDir, name+".pprof"), name); err != nil {
+			return "", err
+		}
+	}
+	return profileDir, nil
+}
+
+func writeProfile(path, name string) error {
+	profile := rpprof.Lookup(name)
+	if profile == nil {
+		return fmt.Errorf("unknown profile %s", name)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("couldn't create %s profile: %v", name, err)
+	}
+	defer f.Close()
+
+	if err = profile.WriteTo(f, 0); err != nil {
+		return fmt.Errorf("couldn't write %s profile: %v", name, err)
+	}
+	return nil
+}
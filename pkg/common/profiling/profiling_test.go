@@ -0,0 +1,54 @@
+package profiling
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/goroutine?debug=1", "/debug/vars"} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("couldn't get %s: %v", path, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected %s to be served, got status %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestWriteProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiling")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	written, err := WriteProfiles(dir, time.Date(2020, 4, 1, 12, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("couldn't write profiles: %v", err)
+	}
+
+	if expected := filepath.Join(dir, ProfilesDir, "20200401T123000Z"); written != expected {
+		t.Errorf("expected profiles in %s, got %s", expected, written)
+	}
+
+	for _, name := range Profiles {
+		info, err := os.Stat(filepath.Join(written, name+".pprof"))
+		if err != nil {
+			t.Errorf("expected %s profile: %v", name, err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s profile is empty", name)
+		}
+	}
+}
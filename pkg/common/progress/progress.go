@@ -0,0 +1,305 @@
+// Package progress emits machine-readable events describing how far a run has got, so CI frontends can render
+// progress bars and fold the log into sections. Events are sent as JSON, one per line or datagram, to a file, a UDP
+// address, or an HTTP endpoint.
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// Types of events.
+const (
+	// PhaseStarted is sent when a phase, such as running the install tests or upgrading the cluster, starts.
+	PhaseStarted = "phase-started"
+
+	// PhaseEnded is sent when a phase ends.
+	PhaseEnded = "phase-ended"
+
+	// SpecStarted is sent before each spec that will run.
+	SpecStarted = "spec-started"
+
+	// SpecCompleted is sent after each spec, with the counts of the specs completed so far in the phase.
+	SpecCompleted = "spec-completed"
+)
+
+// States of phases and specs.
+const (
+	Passed  = "passed"
+	Failed  = "failed"
+	Skipped = "skipped"
+)
+
+// Event describes the progress of a run.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Type  string    `json:"type"`
+	Phase string    `json:"phase"`
+
+	// Spec is the name of the started or completed spec, and State how the spec or phase ended.
+	Spec  string `json:"spec,omitempty"`
+	State string `json:"state,omitempty"`
+
+	// Total is the number of specs that will run in the phase, and Percent how many of them have completed.
+	Total     int     `json:"total,omitempty"`
+	Completed int     `json:"completed,omitempty"`
+	Passed    int     `json:"passed,omitempty"`
+	Failed    int     `json:"failed,omitempty"`
+	Skipped   int     `json:"skipped,omitempty"`
+	Percent   float64 `json:"percent,omitempty"`
+}
+
+const (
+	// httpTimeout is how long the HTTP endpoint has to accept an event.
+	httpTimeout = 5 * time.Second
+
+	// httpQueueSize is how many events can wait to be sent to the HTTP endpoint.
+	httpQueueSize = 256
+
+	// httpDrainTimeout is how long stopping waits for queued events to be sent to the HTTP endpoint.
+	httpDrainTimeout = 10 * time.Second
+)
+
+// sink sends encoded events somewhere.
+type sink interface {
+	send(data []byte) error
+	close() error
+}
+
+var (
+	mutex   sync.Mutex
+	current sink
+
+	// sendFailed is set once sending an event fails, so failures are only logged once.
+	sendFailed bool
+
+	// subscribers are called with every event, keyed by their subscription.
+	subscribers      = map[int]func(Event){}
+	nextSubscription int
+
+	now = time.Now
+)
+
+// Start sends events to endpoint until Stop is called. The endpoint is a file path, a udp://host:port address, or an
+// http(s) URL events are POSTed to. If endpoint is empty, events are dropped.
+func Start(endpoint string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if current != nil {
+		current.close()
+		current = nil
+	}
+	sendFailed = false
+
+	if endpoint == "" {
+		return nil
+	}
+
+	s, err := open(endpoint)
+	if err != nil {
+		return fmt.Errorf("error opening progress endpoint %s: %v", endpoint, err)
+	}
+	current = s
+	return nil
+}
+
+// Stop stops sending events.
+func Stop() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if current != nil {
+		if err := current.close(); err != nil {
+			logging.Errorf("Error closing progress endpoint: %v", err)
+		}
+		current = nil
+	}
+}
+
+// Subscribe calls fn with every event emitted, whether or not events are sent to an endpoint, until the returned
+// function is called. fn is called while events are being emitted, so it must not block or emit events itself.
+func Subscribe(fn func(Event)) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	subscription := nextSubscription
+	nextSubscription++
+	subscribers[subscription] = fn
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		delete(subscribers, subscription)
+	}
+}
+
+// Emit sends an event, setting its time if it isn't set.
+func Emit(event Event) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = now()
+	}
+
+	for _, fn := range subscribers {
+		fn(event)
+	}
+
+	if current == nil {
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err == nil {
+		err = current.send(data)
+	}
+	if err != nil && !sendFailed {
+		sendFailed = true
+		logging.Errorf("Error sending progress event, further errors won't be logged: %v", err)
+	}
+}
+
+// StartPhase sends a PhaseStarted event for a phase without specs.
+func StartPhase(phase string) {
+	Emit(Event{Type: PhaseStarted, Phase: phase})
+}
+
+// EndPhase sends a PhaseEnded event for a phase without specs.
+func EndPhase(phase string, passed bool) {
+	state := Passed
+	if !passed {
+		state = Failed
+	}
+	Emit(Event{Type: PhaseEnded, Phase: phase, State: state})
+}
+
+func open(endpoint string) (sink, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "udp":
+		conn, err := net.Dial("udp", u.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &udpSink{conn: conn}, nil
+	case "http", "https":
+		return newHTTPSink(endpoint), nil
+	case "file":
+		endpoint = u.Path
+	}
+
+	file, err := os.OpenFile(endpoint, os.O_CREATE|os.O_APPEND|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: file}, nil
+}
+
+// fileSink appends events to a file, one per line.
+type fileSink struct {
+	file *os.File
+}
+
+func (s *fileSink) send(data []byte) error {
+	_, err := s.file.Write(append(data, '\n'))
+	return err
+}
+
+func (s *fileSink) close() error {
+	return s.file.Close()
+}
+
+// udpSink sends each event in its own datagram.
+type udpSink struct {
+	conn net.Conn
+}
+
+func (s *udpSink) send(data []byte) error {
+	_, err := s.conn.Write(data)
+	return err
+}
+
+func (s *udpSink) close() error {
+	return s.conn.Close()
+}
+
+// httpSink POSTs each event from its own goroutine, so a slow endpoint doesn't hold up the run while it emits events.
+// Events are dropped if too many are waiting to be sent.
+type httpSink struct {
+	url    string
+	client *http.Client
+
+	events chan []byte
+	done   chan struct{}
+}
+
+func newHTTPSink(url string) *httpSink {
+	s := &httpSink{
+		url:    url,
+		client: &http.Client{Timeout: httpTimeout},
+		events: make(chan []byte, httpQueueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *httpSink) send(data []byte) error {
+	select {
+	case s.events <- data:
+		return nil
+	default:
+		return fmt.Errorf("%d progress events are waiting to be sent, dropping event", httpQueueSize)
+	}
+}
+
+// run POSTs queued events until the sink is closed.
+func (s *httpSink) run() {
+	defer close(s.done)
+
+	failed := false
+	for data := range s.events {
+		if err := s.post(data); err != nil && !failed {
+			failed = true
+			logging.Errorf("Error sending progress event, further errors won't be logged: %v", err)
+		}
+	}
+}
+
+func (s *httpSink) post(data []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("progress endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// close waits a while for the queued events to be sent.
+func (s *httpSink) close() error {
+	close(s.events)
+	select {
+	case <-s.done:
+		return nil
+	case <-time.After(httpDrainTimeout):
+		return fmt.Errorf("gave up sending %d progress events after %v", len(s.events), httpDrainTimeout)
+	}
+}
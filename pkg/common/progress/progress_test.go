@@ -0,0 +1,112 @@
+package progress
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEmitHTTP(t *testing.T) {
+	received := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	if err := Start(server.URL); err != nil {
+		t.Fatalf("failed to start progress: %v", err)
+	}
+	defer Stop()
+
+	EndPhase("teardown", false)
+	if event := <-received; event.Type != PhaseEnded || event.Phase != "teardown" || event.State != Failed || event.Time.IsZero() {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestEmitSlowHTTP(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan Event, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	if err := Start(server.URL); err != nil {
+		t.Fatalf("failed to start progress: %v", err)
+	}
+
+	// emitting doesn't wait for the endpoint
+	started := time.Now()
+	for _, phase := range []string{"install", "upgrade", "teardown"} {
+		StartPhase(phase)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("expected emitting to a slow endpoint not to block, took %v", elapsed)
+	}
+
+	// queued events are still sent when stopping
+	close(release)
+	Stop()
+	if len(received) != 3 {
+		t.Errorf("expected the 3 queued events to be sent, got %d", len(received))
+	}
+}
+
+func TestEmitUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	if err = Start("udp://" + conn.LocalAddr().String()); err != nil {
+		t.Fatalf("failed to start progress: %v", err)
+	}
+	defer Stop()
+
+	StartPhase("upgrade-cluster")
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	data := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(data)
+	if err != nil {
+		t.Fatalf("failed to read event: %v", err)
+	}
+
+	var event Event
+	if err = json.Unmarshal(data[:n], &event); err != nil {
+		t.Fatalf("failed to decode event: %v", err)
+	}
+	if event.Type != PhaseStarted || event.Phase != "upgrade-cluster" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	var received []Event
+	unsubscribe := Subscribe(func(event Event) {
+		received = append(received, event)
+	})
+
+	// subscribers get events even when there's no endpoint
+	StartPhase("install")
+	unsubscribe()
+	EndPhase("install", true)
+
+	if len(received) != 1 || received[0].Type != PhaseStarted || received[0].Phase != "install" || received[0].Time.IsZero() {
+		t.Errorf("expected only the event emitted while subscribed, got %+v", received)
+	}
+}
@@ -2,36 +2,167 @@ package prometheus
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/prometheus/client_golang/api"
 )
 
-// weatherRoundTripper is like api.DefaultRoundTripper with an added stripping of cert verification
-// and adding the bearer token to the HTTP request
-var weatherRoundTripper http.RoundTripper = &http.Transport{
-	Proxy: func(request *http.Request) (*url.URL, error) {
-		request.Header.Add("Authorization", "Bearer "+config.Instance.Prometheus.BearerToken)
-		return http.ProxyFromEnvironment(request)
-	},
-	DialContext: (&net.Dialer{
-		Timeout:   30 * time.Second,
-		KeepAlive: 30 * time.Second,
-	}).DialContext,
-	TLSClientConfig: &tls.Config{
-		InsecureSkipVerify: true,
-	},
-	TLSHandshakeTimeout: 10 * time.Second,
+var (
+	// transport is shared by the clients created for every query, so their connections are reused. It is rebuilt
+	// when the Prometheus config changes.
+	transport       *http.Transport
+	transportConfig config.PrometheusConfig
+	transportMutex  sync.Mutex
+)
+
+func init() {
+	// clients are created for every query, but connections to the previous Prometheus would be reused
+	config.OnChange(func(previous, current config.Config) {
+		if previous.Prometheus != current.Prometheus {
+			logging.Infof("Prometheus config changed, closing connections to %s", previous.Prometheus.Address)
+			transportMutex.Lock()
+			defer transportMutex.Unlock()
+			if transport != nil {
+				transport.CloseIdleConnections()
+				transport = nil
+			}
+		}
+	})
 }
 
-// CreateClient will create a Prometheus client based off of the global config.
+// CreateClient will create a Prometheus client based off of the global config. If a Thanos querier is configured, it
+// is queried instead of Prometheus, as the configured tenant.
 func CreateClient() (api.Client, error) {
+	cfg := config.Instance.Prometheus
+	roundTripper, err := newRoundTripper(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return api.NewClient(api.Config{
-		Address:      config.Instance.Prometheus.Address,
-		RoundTripper: weatherRoundTripper,
+		Address:      address(cfg),
+		RoundTripper: roundTripper,
 	})
 }
+
+// address returns the address of the Thanos querier, if any, or else of Prometheus.
+func address(cfg config.PrometheusConfig) string {
+	if cfg.ThanosAddress != "" {
+		return cfg.ThanosAddress
+	}
+	return cfg.Address
+}
+
+// newRoundTripper returns a round tripper authenticating to Prometheus with the bearer token over the shared
+// transport, building the transport if the config changed. Requests to a Thanos querier also carry the tenant and
+// the resolution of the data to query.
+func newRoundTripper(cfg config.PrometheusConfig) (http.RoundTripper, error) {
+	transportMutex.Lock()
+	defer transportMutex.Unlock()
+
+	if transport == nil || transportConfig != cfg {
+		t, err := newTransport(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if transport != nil {
+			transport.CloseIdleConnections()
+		}
+		transport, transportConfig = t, cfg
+	}
+	rt := &headerRoundTripper{token: cfg.BearerToken, next: transport}
+	if cfg.ThanosAddress != "" {
+		if cfg.ThanosTenant != "" {
+			rt.headers = http.Header{}
+			rt.headers.Set(cfg.ThanosTenantHeader, cfg.ThanosTenant)
+		}
+		if cfg.ThanosMaxSourceResolution != "" {
+			rt.params = url.Values{"max_source_resolution": {cfg.ThanosMaxSourceResolution}}
+		}
+	}
+	return rt, nil
+}
+
+// newTransport is like api.DefaultRoundTripper, verifying Prometheus' certificate with the system's certificate
+// authorities and those of the CA bundle, and presenting the client certificate, if any. Verification is only
+// skipped if insecure connections are allowed.
+func newTransport(cfg config.PrometheusConfig) (*http.Transport, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CABundle != "" {
+		pem, err := ioutil.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the Prometheus CA bundle: %v", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in the Prometheus CA bundle %s", cfg.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		if cfg.ClientCert == "" || cfg.ClientKey == "" {
+			return nil, fmt.Errorf("both a client certificate and key are needed to authenticate to Prometheus with a certificate")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the Prometheus client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}, nil
+}
+
+// headerRoundTripper adds the bearer token, headers, and query parameters to every request.
+type headerRoundTripper struct {
+	token   string
+	headers http.Header
+	params  url.Values
+	next    http.RoundTripper
+}
+
+func (rt *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.token == "" && len(rt.headers) == 0 && len(rt.params) == 0 {
+		return rt.next.RoundTrip(req)
+	}
+
+	// round trippers mustn't modify the request they're given
+	req = req.Clone(req.Context())
+	if rt.token != "" {
+		req.Header.Set("Authorization", "Bearer "+rt.token)
+	}
+	for key, values := range rt.headers {
+		req.Header[key] = values
+	}
+	if len(rt.params) > 0 {
+		query := req.URL.Query()
+		for key, values := range rt.params {
+			query[key] = values
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	return rt.next.RoundTrip(req)
+}
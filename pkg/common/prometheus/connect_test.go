@@ -0,0 +1,118 @@
+package prometheus
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+)
+
+func TestRoundTripper(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	caBundle := filepath.Join(tmpDir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err = ioutil.WriteFile(caBundle, certPEM, 0600); err != nil {
+		t.Fatalf("failed to write CA bundle: %v", err)
+	}
+
+	get := func(cfg config.PrometheusConfig) (*http.Response, error) {
+		rt, err := newRoundTripper(cfg)
+		if err != nil {
+			t.Fatalf("failed to create round tripper: %v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		return rt.RoundTrip(req)
+	}
+
+	if _, err = get(config.PrometheusConfig{BearerToken: "secret"}); err == nil {
+		t.Errorf("expected an untrusted certificate to be rejected")
+	}
+
+	resp, err := get(config.PrometheusConfig{BearerToken: "secret", CABundle: caBundle})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the certificate to be trusted with the CA bundle and the token to be sent, got %v: %v", resp, err)
+	}
+
+	resp, err = get(config.PrometheusConfig{BearerToken: "other", InsecureSkipVerify: true})
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected an insecure connection with the other token, got %v: %v", resp, err)
+	}
+}
+
+func TestTransportConfigErrors(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	empty := filepath.Join(tmpDir, "empty.pem")
+	if err = ioutil.WriteFile(empty, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := map[string]config.PrometheusConfig{
+		"error reading the Prometheus CA bundle":   {CABundle: filepath.Join(tmpDir, "missing.pem")},
+		"no certificates found":                    {CABundle: empty},
+		"both a client certificate and key":        {ClientCert: empty},
+		"error loading the Prometheus client cert": {ClientCert: empty, ClientKey: empty},
+	}
+	for expected, cfg := range tests {
+		if _, err := newTransport(cfg); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q for %+v, got %v", expected, cfg, err)
+		}
+	}
+}
+
+func TestThanos(t *testing.T) {
+	var tenant, resolution string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant, resolution = r.Header.Get("X-Scope-OrgID"), r.URL.Query().Get("max_source_resolution")
+	}))
+	defer server.Close()
+
+	cfg := config.PrometheusConfig{
+		Address:                   "https://prometheus.example.com",
+		ThanosAddress:             server.URL,
+		ThanosTenant:              "osde2e",
+		ThanosTenantHeader:        "X-Scope-OrgID",
+		ThanosMaxSourceResolution: "1h",
+	}
+	if address(cfg) != server.URL {
+		t.Errorf("expected the Thanos querier to be queried instead of Prometheus, got %s", address(cfg))
+	}
+
+	rt, err := newRoundTripper(cfg)
+	if err != nil {
+		t.Fatalf("failed to create round tripper: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/query?query=up", nil)
+	if _, err = rt.RoundTrip(req); err != nil {
+		t.Fatalf("failed to query Thanos: %v", err)
+	}
+
+	if tenant != "osde2e" || resolution != "1h" {
+		t.Errorf("expected the tenant and resolution to be sent, got %q and %q", tenant, resolution)
+	}
+	if req.Header.Get("X-Scope-OrgID") != "" || req.URL.Query().Get("max_source_resolution") != "" {
+		t.Errorf("expected the original request to be left unchanged")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/providers/mock"
 	"github.com/openshift/osde2e/pkg/common/providers/ocmprovider"
+	"github.com/openshift/osde2e/pkg/common/providers/rosaprovider"
 	"github.com/openshift/osde2e/pkg/common/spi"
 )
 
@@ -13,15 +14,51 @@ const (
 	// OCM provider.
 	OCM = "ocm"
 
+	// ROSA provider.
+	ROSA = "rosa"
+
 	// Mock provider.
 	Mock = "mock"
 )
 
+// providerConfigs are the config objects owned by each provider. They're validated when the provider is selected.
+var providerConfigs = map[string]spi.ProviderConfig{
+	OCM:  ocmprovider.Options,
+	ROSA: rosaprovider.Options,
+}
+
+// validateProviderConfig checks the config owned by the selected provider, if it has any.
+func validateProviderConfig() error {
+	if providerConfig, ok := providerConfigs[config.Instance.Provider]; ok {
+		if err := providerConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid config for provider %s: %v", config.Instance.Provider, err)
+		}
+	}
+	return nil
+}
+
+// applyProviderConfig sets the options derived from the config owned by the selected provider, once it's valid.
+func applyProviderConfig() error {
+	if config.Instance.Provider == OCM {
+		return ocmprovider.Options.ApplyClusterSpec()
+	}
+	return nil
+}
+
 // ClusterProvider returns the provisioner configured by the config object.
 func ClusterProvider() (spi.Provider, error) {
+	if err := validateProviderConfig(); err != nil {
+		return nil, err
+	}
+	if err := applyProviderConfig(); err != nil {
+		return nil, err
+	}
+
 	switch config.Instance.Provider {
 	case OCM:
 		return ocmprovider.New(config.Instance.OCM.Token, config.Instance.OCM.Env, config.Instance.OCM.Debug)
+	case ROSA:
+		return rosaprovider.New(config.Instance.OCM.Token, config.Instance.OCM.Env, config.Instance.OCM.Debug)
 	case Mock:
 		return mock.New(config.Instance.OCM.Env)
 	default:
@@ -31,11 +68,26 @@ func ClusterProvider() (spi.Provider, error) {
 
 // ClusterProviderForProduction returns the provisioner configured by the config object using the production environment.
 func ClusterProviderForProduction() (spi.Provider, error) {
+	return ClusterProviderForEnvironment("prod", config.Instance.OCM.Token)
+}
+
+// ClusterProviderForEnvironment returns the provisioner configured by the config object using the given environment
+// and OCM token.
+func ClusterProviderForEnvironment(env, token string) (spi.Provider, error) {
+	if err := validateProviderConfig(); err != nil {
+		return nil, err
+	}
+	if err := applyProviderConfig(); err != nil {
+		return nil, err
+	}
+
 	switch config.Instance.Provider {
 	case OCM:
-		return ocmprovider.New(config.Instance.OCM.Token, "prod", config.Instance.OCM.Debug)
+		return ocmprovider.New(token, env, config.Instance.OCM.Debug)
+	case ROSA:
+		return rosaprovider.New(token, env, config.Instance.OCM.Debug)
 	case Mock:
-		return mock.New("prod")
+		return mock.New(env)
 	default:
 		return nil, fmt.Errorf("unrecognized provisioner: %s", config.Instance.Provider)
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/markbates/pkger"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/triage"
 	"github.com/openshift/osde2e/pkg/common/util"
 )
 
@@ -99,7 +100,7 @@ func (m *MockProvider) GetCluster(clusterID string) (*spi.Cluster, error) {
 	if cluster, ok := m.clusters[clusterID]; ok {
 		return cluster, nil
 	}
-	return nil, fmt.Errorf("couldn't find cluster in mock provider")
+	return nil, &triage.OCMError{Status: http.StatusNotFound, Err: fmt.Errorf("couldn't find cluster in mock provider")}
 }
 
 // ClusterKubeconfig mocks a cluster kubeconfig operation.
@@ -158,6 +159,9 @@ func (m *MockProvider) InstallAddons(clusterID string, addonIDs []string) (int,
 		Region(cluster.Region()).
 		ExpirationTimestamp(cluster.ExpirationTimestamp()).
 		Flavour(cluster.Flavour()).
+		ConsoleURL(cluster.ConsoleURL()).
+		APIURL(cluster.APIURL()).
+		PageURL(cluster.PageURL()).
 		Addons(addonIDs).
 		Build()
 
@@ -0,0 +1,98 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ocm "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// accessTransparencyPath is the path of the access transparency API. It isn't included in the OCM SDK yet.
+const accessTransparencyPath = "/api/access_transparency/v1"
+
+type accessProtection struct {
+	Enabled bool `json:"enabled"`
+}
+
+type accessRequestList struct {
+	Items []accessRequest `json:"items"`
+}
+
+type accessRequest struct {
+	ID             string    `json:"id"`
+	ClusterID      string    `json:"cluster_id"`
+	SubscriptionID string    `json:"subscription_id"`
+	RequestedBy    string    `json:"requested_by"`
+	Justification  string    `json:"justification"`
+	CreatedAt      time.Time `json:"created_at"`
+	DeadlineAt     time.Time `json:"deadline_at"`
+	Status         struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// AccessProtection returns whether access protection is enabled for a cluster.
+func (o *OCMProvider) AccessProtection(clusterID string) (bool, error) {
+	protection := accessProtection{}
+	err := o.getAccessTransparency("/access_protection", &protection, "clusterId", clusterID)
+	if err == spi.ErrAccessTransparencyUnsupported {
+		return false, err
+	} else if err != nil {
+		return false, fmt.Errorf("couldn't retrieve access protection for cluster '%s': %v", clusterID, err)
+	}
+	return protection.Enabled, nil
+}
+
+// AccessRequests lists the access requests made for a cluster.
+func (o *OCMProvider) AccessRequests(clusterID string) ([]spi.AccessRequest, error) {
+	list := accessRequestList{}
+	err := o.getAccessTransparency("/access_requests", &list, "search", fmt.Sprintf("cluster_id='%s'", clusterID))
+	if err == spi.ErrAccessTransparencyUnsupported {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't retrieve access requests for cluster '%s': %v", clusterID, err)
+	}
+
+	requests := make([]spi.AccessRequest, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, spi.AccessRequest{
+			ID:             item.ID,
+			ClusterID:      item.ClusterID,
+			SubscriptionID: item.SubscriptionID,
+			RequestedBy:    item.RequestedBy,
+			Justification:  item.Justification,
+			State:          item.Status.State,
+			CreatedAt:      item.CreatedAt,
+			DeadlineAt:     item.DeadlineAt,
+		})
+	}
+	return requests, nil
+}
+
+// getAccessTransparency requests a resource from the access transparency API and decodes it into v.
+func (o *OCMProvider) getAccessTransparency(path string, v interface{}, parameter, value string) error {
+	var resp *ocm.Response
+	err := retryWithContext(func(ctx context.Context) error {
+		var err error
+		resp, err = o.conn.Get().Path(accessTransparencyPath+path).Parameter(parameter, value).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		if resp.Status() == http.StatusNotFound {
+			return backoff.Permanent(spi.ErrAccessTransparencyUnsupported)
+		}
+		return checkResponse(resp, http.StatusOK)
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp.Bytes(), v)
+}
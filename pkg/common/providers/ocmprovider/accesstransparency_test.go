@@ -0,0 +1,118 @@
+package ocmprovider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ocm "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// testProvider returns a provider connected to a test server.
+func testProvider(t *testing.T, handler http.HandlerFunc) (*OCMProvider, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+
+	// the SDK only needs an unexpired access token, it's never verified
+	claims := fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(time.Hour).Unix())
+	token := "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + "."
+
+	conn, err := ocm.NewConnectionBuilder().URL(server.URL).Tokens(token).Build()
+	if err != nil {
+		server.Close()
+		t.Fatalf("failed to connect to test server: %v", err)
+	}
+	return &OCMProvider{conn: conn}, server.Close
+}
+
+func TestAccessTransparency(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case accessTransparencyPath + "/access_protection":
+			if r.URL.Query().Get("clusterId") != "abc" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"enabled":true}`)
+		case accessTransparencyPath + "/access_requests":
+			fmt.Fprint(w, `{
+				"kind": "AccessRequestList",
+				"page": 1,
+				"size": 1,
+				"total": 1,
+				"items": [{
+					"kind": "AccessRequest",
+					"id": "1",
+					"href": "/api/access_transparency/v1/access_requests/1",
+					"cluster_id": "abc",
+					"subscription_id": "sub",
+					"requested_by": "sre",
+					"justification": "incident",
+					"duration": "8h",
+					"created_at": "2020-06-01T12:00:00Z",
+					"deadline_at": "2020-06-02T12:00:00Z",
+					"status": {"state": "Pending"}
+				}]
+			}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	enabled, err := provider.AccessProtection("abc")
+	if err != nil || !enabled {
+		t.Errorf("expected access protection to be enabled, got %t: %v", enabled, err)
+	}
+
+	if _, err = provider.AccessProtection("other"); err == nil {
+		t.Errorf("expected an error for a bad request")
+	}
+
+	requests, err := provider.AccessRequests("abc")
+	if err != nil {
+		t.Fatalf("failed to list access requests: %v", err)
+	}
+
+	if len(requests) != 1 || requests[0].State != spi.AccessRequestPending || requests[0].RequestedBy != "sre" || requests[0].SubscriptionID != "sub" {
+		t.Fatalf("unexpected access requests %+v", requests)
+	}
+
+	created, deadline := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC), time.Date(2020, 6, 2, 12, 0, 0, 0, time.UTC)
+	if !requests[0].CreatedAt.Equal(created) || !requests[0].DeadlineAt.Equal(deadline) {
+		t.Errorf("expected the request to be made at %s and decided on by %s, got %s and %s", created, deadline, requests[0].CreatedAt, requests[0].DeadlineAt)
+	}
+}
+
+func TestAccessTransparencyUnsupported(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	attempts := 0
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer closeServer()
+
+	if _, err := provider.AccessRequests("abc"); err != spi.ErrAccessTransparencyUnsupported {
+		t.Errorf("expected access transparency to be unsupported, got %v", err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("unsupported requests shouldn't be retried, made %d attempts", attempts)
+	}
+}
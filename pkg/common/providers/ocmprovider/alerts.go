@@ -0,0 +1,32 @@
+package ocmprovider
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// FiringAlerts returns the alerts OCM reports as firing on a cluster, from the metrics the cluster sends to telemetry.
+func (o *OCMProvider) FiringAlerts(clusterID string) ([]spi.Alert, error) {
+	var resp *v1.AlertsMetricQueryGetResponse
+	err := retryWithContext(func(ctx context.Context) error {
+		var err error
+		resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).MetricQueries().Alerts().Get().SendContext(ctx)
+		if resp != nil && resp.Error() != nil {
+			return errResp(resp.Status(), resp.Error())
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get alerts of cluster '%s': %v", clusterID, err)
+	}
+
+	var alerts []spi.Alert
+	for _, alert := range resp.Body().Alerts() {
+		alerts = append(alerts, spi.Alert{Name: alert.Name(), Severity: string(alert.Severity())})
+	}
+	return alerts, nil
+}
@@ -0,0 +1,37 @@
+package ocmprovider
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestFiringAlerts(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clusters_mgmt/v1/clusters/abc/metric_queries/alerts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"alerts":[{"name":"Watchdog","severity":"none"},{"name":"KubeAPIDown","severity":"critical"}]}`)
+	})
+	defer closeServer()
+
+	alerts, err := provider.FiringAlerts("abc")
+	if err != nil {
+		t.Fatalf("failed to get alerts: %v", err)
+	}
+
+	expected := []spi.Alert{{Name: "Watchdog", Severity: "none"}, {Name: "KubeAPIDown", Severity: "critical"}}
+	if !reflect.DeepEqual(alerts, expected) {
+		t.Errorf("expected alerts %v, got %v", expected, alerts)
+	}
+}
@@ -0,0 +1,80 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	ocm "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/triage"
+)
+
+// autoscalerPathFmt is the path of a cluster's autoscaler. It isn't included in the OCM SDK yet.
+const autoscalerPathFmt = "/api/clusters_mgmt/v1/clusters/%s/autoscaler"
+
+type autoscaler struct {
+	ResourceLimits struct {
+		MaxNodesTotal int `json:"max_nodes_total"`
+	} `json:"resource_limits"`
+	ScaleDown struct {
+		Enabled              bool   `json:"enabled"`
+		UtilizationThreshold string `json:"utilization_threshold,omitempty"`
+	} `json:"scale_down"`
+}
+
+// ConfigureAutoscaler creates the cluster's autoscaler or updates the existing one.
+func (o *OCMProvider) ConfigureAutoscaler(clusterID string, settings spi.AutoscalerSettings) error {
+	body := autoscaler{}
+	body.ResourceLimits.MaxNodesTotal = settings.MaxNodesTotal
+	body.ScaleDown.Enabled = true
+	body.ScaleDown.UtilizationThreshold = settings.ScaleDownUtilizationThreshold
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf(autoscalerPathFmt, clusterID)
+	err = retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Get().Path(path).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		request, expected := o.conn.Patch(), http.StatusOK
+		if resp.Status() == http.StatusNotFound {
+			// an autoscaler that doesn't exist yet is created
+			request, expected = o.conn.Post(), http.StatusCreated
+		} else if err = checkResponse(resp, http.StatusOK); err != nil {
+			return err
+		}
+
+		if resp, err = request.Path(path).Bytes(data).SendContext(ctx); err != nil {
+			return err
+		}
+		return checkResponse(resp, expected)
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't configure autoscaler for cluster '%s': %v", clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Configured autoscaler for cluster '%s' with at most %d nodes", clusterID, settings.MaxNodesTotal)
+	return nil
+}
+
+// checkResponse returns an error for unexpected responses. Only server errors are retried.
+func checkResponse(resp *ocm.Response, expected int) error {
+	switch {
+	case resp.Status() == expected:
+		return nil
+	case resp.Status() >= http.StatusInternalServerError:
+		return &triage.OCMError{Status: resp.Status(), Err: fmt.Errorf("api error: %s", resp.String())}
+	default:
+		return backoff.Permanent(&triage.OCMError{Status: resp.Status(), Err: fmt.Errorf("api error: %s", resp.String())})
+	}
+}
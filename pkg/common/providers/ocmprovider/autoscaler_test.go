@@ -0,0 +1,98 @@
+package ocmprovider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestConfigureAutoscaler(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	settings := spi.AutoscalerSettings{MaxNodesTotal: 12, ScaleDownUtilizationThreshold: "0.4"}
+	tests := []struct {
+		name     string
+		exists   bool
+		expected string
+	}{
+		{"creates a missing autoscaler", false, http.MethodPost},
+		{"updates an existing autoscaler", true, http.MethodPatch},
+	}
+
+	for _, test := range tests {
+		var methods []string
+		var body string
+		provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			if r.URL.Path != fmt.Sprintf(autoscalerPathFmt, "abc") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			switch r.Method {
+			case http.MethodGet:
+				if !test.exists {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprint(w, `{"resource_limits":{"max_nodes_total":6}}`)
+			case http.MethodPost:
+				data, _ := ioutil.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, body)
+			case http.MethodPatch:
+				data, _ := ioutil.ReadAll(r.Body)
+				body = string(data)
+				fmt.Fprint(w, body)
+			}
+		})
+
+		if err := provider.ConfigureAutoscaler("abc", settings); err != nil {
+			t.Errorf("%s: failed to configure autoscaler: %v", test.name, err)
+		}
+		closeServer()
+
+		if len(methods) != 2 || methods[1] != test.expected {
+			t.Errorf("%s: expected a GET followed by a %s, got %v", test.name, test.expected, methods)
+		}
+
+		if !strings.Contains(body, `"max_nodes_total":12`) || !strings.Contains(body, `"utilization_threshold":"0.4"`) {
+			t.Errorf("%s: unexpected autoscaler %s", test.name, body)
+		}
+	}
+}
+
+func TestConfigureAutoscalerRejected(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	attempts := 0
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"reason":"max nodes is below the number of compute nodes"}`)
+	})
+	defer closeServer()
+
+	if err := provider.ConfigureAutoscaler("abc", spi.AutoscalerSettings{MaxNodesTotal: 1}); err == nil {
+		t.Errorf("expected a rejected autoscaler to return an error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("rejected requests shouldn't be retried, made %d attempts", attempts)
+	}
+}
@@ -0,0 +1,82 @@
+package ocmprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/triage"
+)
+
+// clustersPath is where clusters are created. CCS clusters are created without the OCM SDK, as it doesn't include
+// their subnets and security groups yet.
+const clustersPath = "/api/clusters_mgmt/v1/clusters"
+
+// ccsPayload returns the JSON description of the cluster, adding the CCS account and its credentials, the subnets to
+// install in, and the security groups to attach to compute nodes. The credentials are only added here, so they're
+// never part of a cluster payload written to a plan.
+func ccsPayload(cluster *v1.Cluster) ([]byte, error) {
+	if provider := cluster.CloudProvider().ID(); provider != "aws" {
+		return nil, fmt.Errorf("CCS clusters can only be created on AWS, not %s", provider)
+	}
+
+	var buf bytes.Buffer
+	if err := v1.MarshalCluster(cluster, &buf); err != nil {
+		return nil, fmt.Errorf("couldn't marshal cluster description: %v", err)
+	}
+
+	body := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &body); err != nil {
+		return nil, err
+	}
+
+	aws, _ := body["aws"].(map[string]interface{})
+	if aws == nil {
+		aws = map[string]interface{}{}
+	}
+	aws["account_id"] = Options.CCSAWSAccountID
+	aws["access_key_id"] = Options.CCSAWSAccessKeyID
+	aws["secret_access_key"] = Options.CCSAWSSecretAccessKey
+	if len(Options.SubnetIDs) > 0 {
+		aws["subnet_ids"] = Options.SubnetIDs
+	}
+	if groups := config.Instance.Cluster.SecurityGroups; len(groups) > 0 {
+		aws["additional_compute_security_group_ids"] = groups
+	}
+	body["aws"] = aws
+	body["byoc"] = true
+	body["ccs"] = map[string]interface{}{"enabled": true}
+
+	return json.Marshal(body)
+}
+
+// addCCSCluster requests the creation of a CCS cluster, returning its ID.
+func (o *OCMProvider) addCCSCluster(cluster *v1.Cluster) (string, error) {
+	data, err := ccsPayload(cluster)
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	err = retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Post().Path(clustersPath).Bytes(data).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		if err = checkResponse(resp, http.StatusCreated); err != nil {
+			return err
+		}
+		return json.Unmarshal(resp.Bytes(), &created)
+	})
+	if err != nil {
+		return "", &triage.ProvisionError{Err: fmt.Errorf("couldn't create CCS cluster: %w", err)}
+	}
+	return created.ID, nil
+}
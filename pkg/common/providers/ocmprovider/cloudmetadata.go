@@ -0,0 +1,140 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+const (
+	// metadataCacheSubdir is the directory in the user's cache directory metadata is cached in by default.
+	metadataCacheSubdir = "osde2e/ocm-metadata"
+
+	regionsKeyFmt = "regions_%s"
+)
+
+// unsafePathChars are replaced in environments and cache keys, which may be URLs, so they can be used in paths.
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// cachedMetadata is a metadata response cached on disk.
+type cachedMetadata struct {
+	Fetched time.Time       `json:"fetched"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Regions returns the regions of a cloud provider.
+func (o *OCMProvider) Regions(cloudProvider string) ([]spi.CloudRegion, error) {
+	var regions []spi.CloudRegion
+	err := o.cachedMetadata(fmt.Sprintf(regionsKeyFmt, cloudProvider), &regions, func() (interface{}, error) {
+		var regions []spi.CloudRegion
+		for page := 1; ; page++ {
+			var resp *v1.CloudRegionsListResponse
+			err := retryWithContext(func(ctx context.Context) error {
+				var err error
+				resp, err = o.conn.ClustersMgmt().V1().CloudProviders().CloudProvider(cloudProvider).Regions().List().
+					Page(page).
+					Size(PageSize).
+					SendContext(ctx)
+
+				if resp != nil && resp.Error() != nil {
+					return errResp(resp.Status(), resp.Error())
+				}
+
+				return err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("couldn't list regions of cloud provider %s: %v", cloudProvider, err)
+			}
+
+			resp.Items().Each(func(region *v1.CloudRegion) bool {
+				regions = append(regions, spi.CloudRegion{ID: region.ID(), DisplayName: region.DisplayName()})
+				return true
+			})
+
+			if page*PageSize >= resp.Total() {
+				return regions, nil
+			}
+		}
+	})
+	return regions, err
+}
+
+// cachedMetadata loads metadata into v from the cache if it was fetched within the cache's TTL, or at any time when
+// offline. Otherwise, it's fetched and cached.
+func (o *OCMProvider) cachedMetadata(key string, v interface{}, fetch func() (interface{}, error)) error {
+	path, err := metadataCachePath(o.env, key)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Duration(Options.MetadataCacheTTL) * time.Hour
+	if data, err := ioutil.ReadFile(path); err == nil {
+		var cached cachedMetadata
+		if err = json.Unmarshal(data, &cached); err != nil {
+			logging.Warnf("Ignoring unreadable cached OCM metadata %s: %v", path, err)
+		} else if Options.Offline || time.Since(cached.Fetched) < ttl {
+			return json.Unmarshal(cached.Data, v)
+		}
+	}
+
+	if Options.Offline {
+		return fmt.Errorf("%s isn't cached in %s, and can't be fetched from OCM offline", key, path)
+	}
+
+	fetched, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(fetched)
+	if err != nil {
+		return err
+	}
+
+	if ttl > 0 {
+		if err := writeCachedMetadata(path, data); err != nil {
+			logging.Warnf("Unable to cache OCM metadata in %s: %v", path, err)
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// metadataCachePath is where metadata for an environment is cached.
+func metadataCachePath(env, key string) (string, error) {
+	dir := Options.MetadataCacheDir
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("no OCM_METADATA_CACHE_DIR is set and the user has no cache directory: %v", err)
+		}
+		dir = filepath.Join(userCacheDir, metadataCacheSubdir)
+	}
+
+	if env == "" {
+		env = "default"
+	}
+	return filepath.Join(dir, unsafePathChars.ReplaceAllString(env, "_"), unsafePathChars.ReplaceAllString(key, "_")+".json"), nil
+}
+
+// writeCachedMetadata caches metadata fetched now.
+func writeCachedMetadata(path string, data []byte) error {
+	cached, err := json.Marshal(cachedMetadata{Fetched: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, cached, 0644)
+}
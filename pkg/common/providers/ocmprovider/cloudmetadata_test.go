@@ -0,0 +1,96 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestCloudMetadataCache(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	defer func(options Config) { *Options = options }(*Options)
+	Options.NumRetries, Options.RequestTimeout = 1, 30
+
+	cacheDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(cacheDir)
+	Options.MetadataCacheDir, Options.MetadataCacheTTL, Options.Offline = cacheDir, 24, false
+
+	requests := 0
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/api/clusters_mgmt/v1/cloud_providers/aws/regions":
+			fmt.Fprint(w, `{"kind":"CloudRegionList","page":1,"size":2,"total":2,"items":[`+
+				`{"kind":"CloudRegion","id":"us-east-1","display_name":"US East, N. Virginia"},{"kind":"CloudRegion","id":"eu-west-1"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	expected := []spi.CloudRegion{{ID: "us-east-1", DisplayName: "US East, N. Virginia"}, {ID: "eu-west-1"}}
+	for i := 0; i < 2; i++ {
+		regions, err := provider.Regions("aws")
+		if err != nil {
+			t.Fatalf("failed to list regions: %v", err)
+		}
+		if !reflect.DeepEqual(regions, expected) {
+			t.Errorf("expected %+v, got %+v", expected, regions)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the regions to be fetched once and then cached, got %d requests", requests)
+	}
+
+	// expired metadata is fetched again
+	path, err := metadataCachePath("", "regions_aws")
+	if err != nil {
+		t.Fatalf("failed to get cache path: %v", err)
+	}
+	expire := func() {
+		data, _ := json.Marshal(cachedMetadata{Fetched: time.Now().Add(-25 * time.Hour), Data: json.RawMessage(`[{"ID":"us-west-2"}]`)})
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("failed to write cache: %v", err)
+		}
+	}
+	expire()
+	if regions, err := provider.Regions("aws"); err != nil || !reflect.DeepEqual(regions, expected) || requests != 2 {
+		t.Errorf("expected expired regions to be fetched again, got %+v after %d requests: %v", regions, requests, err)
+	}
+
+	// offline, cached metadata is used however old it is, and nothing is fetched
+	Options.Offline = true
+	expire()
+	if regions, err := provider.Regions("aws"); err != nil || !reflect.DeepEqual(regions, []spi.CloudRegion{{ID: "us-west-2"}}) {
+		t.Errorf("expected expired regions to be used offline, got %+v: %v", regions, err)
+	}
+	if _, err := provider.Regions("gcp"); err == nil {
+		t.Errorf("expected uncached regions to fail offline")
+	}
+	if requests != 2 {
+		t.Errorf("expected no requests offline, got %d", requests-2)
+	}
+
+	// without a TTL, metadata is always fetched
+	Options.Offline, Options.MetadataCacheTTL = false, 0
+	for i := 0; i < 2; i++ {
+		if regions, err := provider.Regions("aws"); err != nil || !reflect.DeepEqual(regions, expected) {
+			t.Errorf("expected %+v, got %+v: %v", expected, regions, err)
+		}
+	}
+	if requests != 4 {
+		t.Errorf("expected the regions to be fetched each time, got %d requests", requests-2)
+	}
+}
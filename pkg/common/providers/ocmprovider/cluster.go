@@ -1,15 +1,19 @@
 package ocmprovider
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-	"log"
 	"os/user"
 	"time"
 
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/triage"
 )
 
 const (
@@ -21,22 +25,76 @@ const (
 
 	// OwnedBy property which will tell who made the cluster.
 	OwnedBy = "OwnedBy"
+
+	// ClaimedBy property holding the job of the run that adopted the cluster.
+	ClaimedBy = "ClaimedBy"
+
+	// ClaimedAt property holding when the cluster was claimed, in RFC 3339 format.
+	ClaimedAt = "ClaimedAt"
 )
 
 // LaunchCluster setups an new cluster using the OSD API and returns it's ID.
 func (o *OCMProvider) LaunchCluster() (string, error) {
+	return o.launchCluster(state.Instance.Cluster.Name, Options.ClusterSpec)
+}
+
+// LaunchPeerCluster creates a cluster alongside the cluster under test, shaped by a cluster spec.
+func (o *OCMProvider) LaunchPeerCluster(name, clusterSpec string) (string, error) {
+	return o.launchCluster(name, clusterSpec)
+}
+
+func (o *OCMProvider) launchCluster(name, clusterSpec string) (string, error) {
+	logging.Infof("Creating cluster '%s'...", name)
+
+	newCluster, err := o.newCluster(name, clusterSpec)
+	if err != nil {
+		return "", err
+	}
+	return o.addCluster(newCluster)
+}
+
+// ClusterPayload returns the request that LaunchCluster would send to create the cluster, as indented JSON. It has
+// no expiration, which is set when the cluster is launched.
+func (o *OCMProvider) ClusterPayload(name string) (string, error) {
+	newCluster, err := o.newCluster(name, Options.ClusterSpec)
+	if err != nil {
+		return "", err
+	}
+
+	cluster, err := newCluster.Build()
+	if err != nil {
+		return "", fmt.Errorf("couldn't build cluster description: %v", err)
+	}
+
+	var payload, indented bytes.Buffer
+	if err = v1.MarshalCluster(cluster, &payload); err != nil {
+		return "", fmt.Errorf("couldn't marshal cluster description: %v", err)
+	}
+	if err = json.Indent(&indented, payload.Bytes(), "", "  "); err != nil {
+		return "", err
+	}
+	return indented.String(), nil
+}
+
+// LaunchClusterFromPayload creates a cluster from a payload returned by ClusterPayload and returns its ID.
+func (o *OCMProvider) LaunchClusterFromPayload(payload string) (string, error) {
+	cluster, err := v1.UnmarshalCluster(payload)
+	if err != nil {
+		return "", fmt.Errorf("couldn't parse cluster description: %v", err)
+	}
+
+	logging.Infof("Creating cluster '%s' from a plan...", cluster.Name())
+	return o.addCluster(v1.NewCluster().Copy(cluster))
+}
+
+// newCluster describes the cluster to create from the config and state, shaped by a cluster spec.
+func (o *OCMProvider) newCluster(name, clusterSpec string) (*v1.ClusterBuilder, error) {
 	cfg := config.Instance
 	state := state.Instance
 
-	log.Printf("Creating cluster '%s'...", state.Cluster.Name)
-
 	// choose flavour based on config
 	flavourID := DefaultFlavour
 
-	// Calculate an expiration date for the cluster so that it will be automatically deleted if
-	// we happen to forget to do it:
-	expiration := time.Now().Add(time.Duration(cfg.Cluster.ExpiryInMinutes) * time.Minute).UTC() // UTC() to workaround SDA-1567.
-
 	var username string
 
 	// If JobID is not equal to -1, then we're running on prow.
@@ -47,14 +105,19 @@ func (o *OCMProvider) LaunchCluster() (string, error) {
 		user, err := user.Current()
 
 		if err != nil {
-			return "", fmt.Errorf("unable to get current user: %v", err)
+			return nil, fmt.Errorf("unable to get current user: %v", err)
 		}
 
 		username = user.Username
 	}
 
+	properties := map[string]string{
+		MadeByOSDe2e: "true",
+		OwnedBy:      username,
+	}
+
 	newCluster := v1.NewCluster().
-		Name(state.Cluster.Name).
+		Name(name).
 		Flavour(v1.NewFlavour().
 			ID(flavourID)).
 		Region(v1.NewCloudRegion().
@@ -64,11 +127,7 @@ func (o *OCMProvider) LaunchCluster() (string, error) {
 			ID(state.Cluster.Version)).
 		CloudProvider(v1.NewCloudProvider().
 			ID(state.CloudProvider.CloudProviderID)).
-		ExpirationTimestamp(expiration).
-		Properties(map[string]string{
-			MadeByOSDe2e: "true",
-			OwnedBy:      username,
-		})
+		Properties(properties)
 
 	// Configure the cluster to be Multi-AZ if configured
 	// We must manually configure the number of compute nodes
@@ -81,28 +140,51 @@ func (o *OCMProvider) LaunchCluster() (string, error) {
 			MultiAZ(cfg.Cluster.MultiAZ)
 	}
 
-	cluster, err := newCluster.Build()
+	if clusterSpec != "" {
+		spec, err := LoadClusterSpec(clusterSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		logging.Debugf("Using cluster spec %s", clusterSpec)
+		newCluster = spec.apply(newCluster, properties)
+	}
+
+	return newCluster, nil
+}
+
+// addCluster sets the expiration of a cluster and requests its creation, returning its ID.
+func (o *OCMProvider) addCluster(newCluster *v1.ClusterBuilder) (string, error) {
+	// Calculate an expiration date for the cluster so that it will be automatically deleted if
+	// we happen to forget to do it:
+	expiration := time.Now().Add(time.Duration(config.Instance.Cluster.ExpiryInMinutes) * time.Minute).UTC() // UTC() to workaround SDA-1567.
+
+	cluster, err := newCluster.ExpirationTimestamp(expiration).Build()
 	if err != nil {
 		return "", fmt.Errorf("couldn't build cluster description: %v", err)
 	}
 
+	if Options.CCS {
+		return o.addCCSCluster(cluster)
+	}
+
 	var resp *v1.ClustersAddResponse
 
-	err = retryer().Do(func() error {
+	err = retryWithContext(func(ctx context.Context) error {
 		var err error
 		resp, err = o.conn.ClustersMgmt().V1().Clusters().Add().
 			Body(cluster).
-			Send()
+			SendContext(ctx)
 
 		if resp != nil && resp.Error() != nil {
-			return errResp(resp.Error())
+			return errResp(resp.Status(), resp.Error())
 		}
 
 		return err
 	})
 
 	if err != nil {
-		return "", fmt.Errorf("couldn't create cluster: %v", err)
+		return "", &triage.ProvisionError{Err: fmt.Errorf("couldn't create cluster: %w", err)}
 	}
 	return resp.Body().ID(), nil
 }
@@ -111,20 +193,20 @@ func (o *OCMProvider) LaunchCluster() (string, error) {
 func (o *OCMProvider) DeleteCluster(clusterID string) error {
 	var resp *v1.ClusterDeleteResponse
 
-	err := retryer().Do(func() error {
+	err := retryWithContext(func(ctx context.Context) error {
 		var err error
 		resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 			Delete().
-			Send()
+			SendContext(ctx)
 
 		if err != nil {
-			log.Printf("couldn't delete cluster: %v", err)
+			logging.Errorf("couldn't delete cluster: %v", err)
 			return err
 		}
 
 		if resp != nil && resp.Error() != nil {
-			err = errResp(resp.Error())
-			log.Printf("%v", err)
+			err = errResp(resp.Status(), resp.Error())
+			logging.Errorf("%v", err)
 			return err
 		}
 
@@ -141,23 +223,23 @@ func (o *OCMProvider) DeleteCluster(clusterID string) error {
 func (o *OCMProvider) GetCluster(clusterID string) (*spi.Cluster, error) {
 	var resp *v1.ClusterGetResponse
 
-	err := retryer().Do(func() error {
+	err := retryWithContext(func(ctx context.Context) error {
 		var err error
 		resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 			Get().
-			Send()
+			SendContext(ctx)
+
+		if resp != nil && resp.Error() != nil {
+			logging.Errorf("error while trying to retrieve cluster: %v", err)
+			return errResp(resp.Status(), resp.Error())
+		}
 
 		if err != nil {
 			err = fmt.Errorf("couldn't retrieve cluster '%s': %v", clusterID, err)
-			log.Printf("%v", err)
+			logging.Errorf("%v", err)
 			return err
 		}
 
-		if resp != nil && resp.Error() != nil {
-			log.Printf("error while trying to retrieve cluster: %v", err)
-			return errResp(resp.Error())
-		}
-
 		return nil
 	})
 
@@ -178,6 +260,7 @@ func (o *OCMProvider) GetCluster(clusterID string) (*spi.Cluster, error) {
 
 	if id, ok := ocmCluster.GetID(); ok {
 		cluster.ID(id)
+		cluster.PageURL(clusterPageURL(o.env, id))
 	}
 
 	if version, ok := ocmCluster.GetVersion(); ok {
@@ -192,22 +275,34 @@ func (o *OCMProvider) GetCluster(clusterID string) (*spi.Cluster, error) {
 		cluster.State(ocmStateToInternalState(state))
 	}
 
+	if console, ok := ocmCluster.GetConsole(); ok {
+		cluster.ConsoleURL(console.URL())
+	}
+
+	if api, ok := ocmCluster.GetAPI(); ok {
+		cluster.APIURL(api.URL())
+	}
+
+	if nodes, ok := ocmCluster.GetNodes(); ok {
+		cluster.ComputeNodes(nodes.Compute())
+	}
+
 	var addonsResp *v1.AddOnInstallationsListResponse
-	err = retryer().Do(func() error {
+	err = retryWithContext(func(ctx context.Context) error {
 		var err error
 		addonsResp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).Addons().
 			List().
-			Send()
+			SendContext(ctx)
 
 		if err != nil {
 			err = fmt.Errorf("couldn't retrieve addons for cluster '%s': %v", clusterID, err)
-			log.Printf("%v", err)
+			logging.Errorf("%v", err)
 			return err
 		}
 
 		if addonsResp != nil && addonsResp.Error() != nil {
-			log.Printf("error while trying to retrieve addons list for cluster: %v", err)
-			return errResp(resp.Error())
+			logging.Errorf("error while trying to retrieve addons list for cluster: %v", err)
+			return errResp(resp.Status(), resp.Error())
 		}
 
 		return nil
@@ -235,21 +330,21 @@ func (o *OCMProvider) GetCluster(clusterID string) (*spi.Cluster, error) {
 func (o *OCMProvider) ClusterKubeconfig(clusterID string) ([]byte, error) {
 	var resp *v1.CredentialsGetResponse
 
-	err := retryer().Do(func() error {
+	err := retryWithContext(func(ctx context.Context) error {
 		var err error
 		resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 			Credentials().
 			Get().
-			Send()
+			SendContext(ctx)
 
 		if err != nil {
-			log.Printf("couldn't get credentials: %v", err)
+			logging.Errorf("couldn't get credentials: %v", err)
 			return err
 		}
 
 		if resp != nil && resp.Error() != nil {
-			err = errResp(resp.Error())
-			log.Printf("%v", err)
+			err = errResp(resp.Status(), resp.Error())
+			logging.Errorf("%v", err)
 			return err
 		}
 
@@ -271,16 +366,16 @@ func (o *OCMProvider) InstallAddons(clusterID string, addonIDs []string) (num in
 	for _, addonID := range addonIDs {
 		var addonResp *v1.AddOnGetResponse
 
-		err = retryer().Do(func() error {
+		err = retryWithContext(func(ctx context.Context) error {
 			var err error
-			addonResp, err = addonsClient.Addon(addonID).Get().Send()
+			addonResp, err = addonsClient.Addon(addonID).Get().SendContext(ctx)
 
 			if err != nil {
 				return err
 			}
 
 			if addonResp != nil && addonResp.Error() != nil {
-				return errResp(addonResp.Error())
+				return errResp(addonResp.Status(), addonResp.Error())
 			}
 
 			return nil
@@ -306,7 +401,7 @@ func (o *OCMProvider) InstallAddons(clusterID string, addonIDs []string) (num in
 		}
 
 		if alreadyInstalled {
-			log.Printf("Addon %s is already installed. Skipping.", addonID)
+			logging.Infof("Addon %s is already installed. Skipping.", addonID)
 			continue
 		}
 
@@ -318,17 +413,17 @@ func (o *OCMProvider) InstallAddons(clusterID string, addonIDs []string) (num in
 
 			var aoar *v1.AddOnInstallationsAddResponse
 
-			err = retryer().Do(func() error {
+			err = retryWithContext(func(ctx context.Context) error {
 				var err error
-				aoar, err = clusterClient.Addons().Add().Body(addonInstallation).Send()
+				aoar, err = clusterClient.Addons().Add().Body(addonInstallation).SendContext(ctx)
 				if err != nil {
-					log.Printf("couldn't install addons: %v", err)
+					logging.Errorf("couldn't install addons: %v", err)
 					return err
 				}
 
 				if aoar.Error() != nil {
 					err = fmt.Errorf("error (%v) sending request: %v", aoar.Status(), aoar.Error())
-					log.Printf("%v", err)
+					logging.Errorf("%v", err)
 					return err
 				}
 
@@ -339,7 +434,7 @@ func (o *OCMProvider) InstallAddons(clusterID string, addonIDs []string) (num in
 				return 0, err
 			}
 
-			log.Printf("Installed Addon: %s", addonID)
+			logging.Infof("Installed Addon: %s", addonID)
 
 			num++
 		}
@@ -362,6 +457,12 @@ func ocmStateToInternalState(state v1.ClusterState) spi.ClusterState {
 		return spi.ClusterStateReady
 	case v1.ClusterStateUninstalling:
 		return spi.ClusterStateUninstalling
+	case ocmClusterStatePoweringDown:
+		return spi.ClusterStatePoweringDown
+	case ocmClusterStateHibernating:
+		return spi.ClusterStateHibernating
+	case ocmClusterStateResuming:
+		return spi.ClusterStateResuming
 	default:
 		return spi.ClusterStateUnknown
 	}
@@ -0,0 +1,115 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+func TestLaunchPeerCluster(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	defer func(name, version string) {
+		state.Instance.Cluster.Name, state.Instance.Cluster.Version = name, version
+	}(state.Instance.Cluster.Name, state.Instance.Cluster.Version)
+	state.Instance.Cluster.Name, state.Instance.Cluster.Version = "primary", "openshift-v4.5.1"
+
+	var created map[string]interface{}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/clusters_mgmt/v1/clusters" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &created); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind":"Cluster","id":"peer-id"}`)
+	})
+	defer closeServer()
+
+	id, err := provider.LaunchPeerCluster("primary-peer", "submariner-peer")
+	if err != nil {
+		t.Fatalf("couldn't launch peer cluster: %v", err)
+	}
+
+	if id != "peer-id" {
+		t.Errorf("expected the peer's ID, got %s", id)
+	}
+	if created["name"] != "primary-peer" {
+		t.Errorf("expected the peer's name, got %v", created["name"])
+	}
+	if version, _ := created["version"].(map[string]interface{}); version["id"] != "openshift-v4.5.1" {
+		t.Errorf("expected the peer to have the version of the cluster under test, got %v", created["version"])
+	}
+	if network, _ := created["network"].(map[string]interface{}); network["machine_cidr"] != "10.1.0.0/16" {
+		t.Errorf("expected the peer's network from its spec, got %v", created["network"])
+	}
+}
+
+func TestLaunchCCSCluster(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	defer func(opts Config) { *Options = opts }(*Options)
+	Options.CCS, Options.CCSAWSAccountID, Options.CCSAWSAccessKeyID, Options.CCSAWSSecretAccessKey = true, "123456789012", "id", "secret"
+	Options.SubnetIDs = []string{"subnet-1", "subnet-2"}
+
+	defer func(groups []string) { config.Instance.Cluster.SecurityGroups = groups }(config.Instance.Cluster.SecurityGroups)
+	config.Instance.Cluster.SecurityGroups = []string{"sg-1"}
+
+	defer func(cloudProvider string) { state.Instance.CloudProvider.CloudProviderID = cloudProvider }(state.Instance.CloudProvider.CloudProviderID)
+	state.Instance.CloudProvider.CloudProviderID = "aws"
+
+	var created map[string]interface{}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/clusters_mgmt/v1/clusters" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &created); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"kind":"Cluster","id":"ccs-id"}`)
+	})
+	defer closeServer()
+
+	id, err := provider.LaunchPeerCluster("ccs", "")
+	if err != nil {
+		t.Fatalf("couldn't launch CCS cluster: %v", err)
+	}
+	if id != "ccs-id" {
+		t.Errorf("expected the cluster's ID, got %s", id)
+	}
+
+	if ccs, _ := created["ccs"].(map[string]interface{}); ccs["enabled"] != true {
+		t.Errorf("expected a CCS cluster, got %v", created["ccs"])
+	}
+	aws, _ := created["aws"].(map[string]interface{})
+	if aws["account_id"] != "123456789012" || aws["secret_access_key"] != "secret" {
+		t.Errorf("expected the CCS account and credentials, got %v", aws)
+	}
+	if fmt.Sprint(aws["subnet_ids"]) != "[subnet-1 subnet-2]" || fmt.Sprint(aws["additional_compute_security_group_ids"]) != "[sg-1]" {
+		t.Errorf("expected the subnets and security groups, got %v", aws)
+	}
+	if created["name"] != "ccs" {
+		t.Errorf("expected the cluster's name, got %v", created["name"])
+	}
+}
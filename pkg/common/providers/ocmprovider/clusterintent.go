@@ -0,0 +1,75 @@
+package ocmprovider
+
+import (
+	"fmt"
+
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// addonInstallationsPathFmt is the path of the addons installed on a cluster.
+const addonInstallationsPathFmt = "/api/clusters_mgmt/v1/clusters/%s/addons"
+
+type intendedCluster struct {
+	Nodes struct {
+		Compute          int `json:"compute"`
+		AutoscaleCompute *struct {
+			MinReplicas int `json:"min_replicas"`
+			MaxReplicas int `json:"max_replicas"`
+		} `json:"autoscale_compute"`
+	} `json:"nodes"`
+	Network struct {
+		MachineCIDR string `json:"machine_cidr"`
+		ServiceCIDR string `json:"service_cidr"`
+		PodCIDR     string `json:"pod_cidr"`
+		HostPrefix  int    `json:"host_prefix"`
+	} `json:"network"`
+}
+
+type identityProviderList struct {
+	Items []identityProvider `json:"items"`
+}
+
+type addonInstallationList struct {
+	Items []struct {
+		ID string `json:"id"`
+	} `json:"items"`
+}
+
+// ClusterIntent returns the nodes, network, identity providers, and addons OCM believes were requested for a cluster.
+func (o *OCMProvider) ClusterIntent(clusterID string) (*spi.ClusterIntent, error) {
+	var cluster intendedCluster
+	if err := o.getJSON(fmt.Sprintf(clusterPathFmt, clusterID), &cluster); err != nil {
+		return nil, fmt.Errorf("couldn't retrieve cluster '%s': %v", clusterID, err)
+	}
+
+	intent := &spi.ClusterIntent{
+		MinComputeNodes:   cluster.Nodes.Compute,
+		MaxComputeNodes:   cluster.Nodes.Compute,
+		MachineCIDR:       cluster.Network.MachineCIDR,
+		ServiceCIDR:       cluster.Network.ServiceCIDR,
+		PodCIDR:           cluster.Network.PodCIDR,
+		HostPrefix:        cluster.Network.HostPrefix,
+		IdentityProviders: []string{},
+		Addons:            []string{},
+	}
+	if autoscale := cluster.Nodes.AutoscaleCompute; autoscale != nil {
+		intent.MinComputeNodes, intent.MaxComputeNodes = autoscale.MinReplicas, autoscale.MaxReplicas
+	}
+
+	var idps identityProviderList
+	if err := o.getJSON(fmt.Sprintf(identityProvidersPathFmt, clusterID), &idps); err != nil {
+		return nil, fmt.Errorf("couldn't retrieve identity providers of cluster '%s': %v", clusterID, err)
+	}
+	for _, idp := range idps.Items {
+		intent.IdentityProviders = append(intent.IdentityProviders, idp.Name)
+	}
+
+	var addons addonInstallationList
+	if err := o.getJSON(fmt.Sprintf(addonInstallationsPathFmt, clusterID), &addons); err != nil {
+		return nil, fmt.Errorf("couldn't retrieve addons of cluster '%s': %v", clusterID, err)
+	}
+	for _, addon := range addons.Items {
+		intent.Addons = append(intent.Addons, addon.ID)
+	}
+	return intent, nil
+}
@@ -0,0 +1,52 @@
+package ocmprovider
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestClusterIntent(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf(clusterPathFmt, "abc"):
+			fmt.Fprint(w, `{"nodes":{"compute":4,"autoscale_compute":{"min_replicas":2,"max_replicas":6}},`+
+				`"network":{"machine_cidr":"10.0.0.0/16","service_cidr":"172.30.0.0/16","pod_cidr":"10.128.0.0/14","host_prefix":23}}`)
+		case fmt.Sprintf(identityProvidersPathFmt, "abc"):
+			fmt.Fprint(w, `{"items":[{"type":"GithubIdentityProvider","name":"github"}]}`)
+		case fmt.Sprintf(addonInstallationsPathFmt, "abc"):
+			fmt.Fprint(w, `{"items":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	intent, err := provider.ClusterIntent("abc")
+	if err != nil {
+		t.Fatalf("failed to get cluster intent: %v", err)
+	}
+
+	expected := &spi.ClusterIntent{
+		MinComputeNodes:   2,
+		MaxComputeNodes:   6,
+		MachineCIDR:       "10.0.0.0/16",
+		ServiceCIDR:       "172.30.0.0/16",
+		PodCIDR:           "10.128.0.0/14",
+		HostPrefix:        23,
+		IdentityProviders: []string{"github"},
+		Addons:            []string{},
+	}
+	if !reflect.DeepEqual(intent, expected) {
+		t.Errorf("expected intent %+v, got %+v", expected, intent)
+	}
+}
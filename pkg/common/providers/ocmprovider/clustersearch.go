@@ -0,0 +1,400 @@
+package ocmprovider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	accounts "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// FindCluster returns the ID of the most recently created ready cluster matching the search that isn't claimed by a run.
+func (o *OCMProvider) FindCluster(search spi.ClusterSearch) (string, error) {
+	query := clusterSearchQuery(search)
+
+	for page := 1; ; page++ {
+		var resp *v1.ClustersListResponse
+		err := retryWithContext(func(ctx context.Context) error {
+			var err error
+			resp, err = o.conn.ClustersMgmt().V1().Clusters().List().
+				Search(query).
+				Order("creation_timestamp desc").
+				Page(page).
+				Size(PageSize).
+				SendContext(ctx)
+
+			if resp != nil && resp.Error() != nil {
+				return errResp(resp.Status(), resp.Error())
+			}
+
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("couldn't search for clusters matching %q: %v", query, err)
+		}
+
+		var clusterID string
+		resp.Items().Each(func(cluster *v1.Cluster) bool {
+			if claimHeld(cluster.Properties(), time.Now()) {
+				return true
+			}
+			clusterID = cluster.ID()
+			return false
+		})
+		if clusterID != "" {
+			return clusterID, nil
+		}
+
+		if page*PageSize >= resp.Total() {
+			logging.Warnf("No unclaimed clusters match %q", query)
+			return "", nil
+		}
+	}
+}
+
+// claimHeld returns true if a cluster is claimed by a run that may still be using it. Claims older than the install
+// and test timeouts were left by runs that died without releasing them. Claims without a time are always held, as
+// they can't be told apart from claims made by runs still using the cluster.
+func claimHeld(properties map[string]string, now time.Time) bool {
+	if properties[ClaimedBy] == "" {
+		return false
+	}
+
+	claimedAt, err := time.Parse(time.RFC3339, properties[ClaimedAt])
+	if err != nil {
+		return true
+	}
+
+	cfg := config.Instance.Cluster
+	expiry := time.Duration(cfg.InstallTimeout+cfg.AdoptTestTimeout) * time.Minute
+	return now.Sub(claimedAt) < expiry
+}
+
+// claimSettle is how long a claim is given to be overwritten by a run claiming the cluster at the same time before
+// it's read back.
+var claimSettle = 5 * time.Second
+
+// ClaimCluster sets the ClaimedBy property of a cluster to the claim and ClaimedAt to the current time. Stale claims
+// are taken over. OCM can't update a property conditionally, so the claim is read back after claimSettle, and false is
+// returned if another run claimed the cluster in the meantime.
+func (o *OCMProvider) ClaimCluster(clusterID, claim string) (bool, error) {
+	cluster, err := o.getOCMCluster(clusterID)
+	if err != nil {
+		return false, err
+	}
+	if owner := cluster.Properties()[ClaimedBy]; owner != "" && owner != claim {
+		if claimHeld(cluster.Properties(), time.Now()) {
+			return false, nil
+		}
+		logging.Warnf("Taking over the stale claim of %s on cluster '%s', made at %s", owner, clusterID, cluster.Properties()[ClaimedAt])
+	}
+
+	if err = o.setClaim(clusterID, cluster.Properties(), claim); err != nil {
+		return false, err
+	}
+
+	time.Sleep(claimSettle)
+
+	if cluster, err = o.getOCMCluster(clusterID); err != nil {
+		return false, err
+	}
+	return cluster.Properties()[ClaimedBy] == claim, nil
+}
+
+// ReleaseCluster clears the ClaimedBy property of a cluster, unless another run has claimed it since.
+func (o *OCMProvider) ReleaseCluster(clusterID, claim string) error {
+	cluster, err := o.getOCMCluster(clusterID)
+	if err != nil {
+		return err
+	}
+	if cluster.Properties()[ClaimedBy] != claim {
+		return nil
+	}
+	return o.setClaim(clusterID, cluster.Properties(), "")
+}
+
+// setClaim updates the ClaimedBy and ClaimedAt properties of a cluster, keeping its other properties. An empty claim
+// clears both.
+func (o *OCMProvider) setClaim(clusterID string, properties map[string]string, claim string) error {
+	updated := make(map[string]string, len(properties)+2)
+	for key, value := range properties {
+		updated[key] = value
+	}
+	updated[ClaimedBy], updated[ClaimedAt] = claim, ""
+	if claim != "" {
+		updated[ClaimedAt] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	body, err := v1.NewCluster().Properties(updated).Build()
+	if err != nil {
+		return fmt.Errorf("couldn't build update of cluster '%s': %v", clusterID, err)
+	}
+
+	err = retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+			Update().
+			Body(body).
+			SendContext(ctx)
+
+		if resp != nil && resp.Error() != nil {
+			return errResp(resp.Status(), resp.Error())
+		}
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't set the %s property of cluster '%s': %v", ClaimedBy, clusterID, err)
+	}
+	return nil
+}
+
+// getOCMCluster returns a cluster as OCM describes it.
+func (o *OCMProvider) getOCMCluster(clusterID string) (*v1.Cluster, error) {
+	var resp *v1.ClusterGetResponse
+	err := retryWithContext(func(ctx context.Context) error {
+		var err error
+		resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+			Get().
+			SendContext(ctx)
+
+		if resp != nil && resp.Error() != nil {
+			return errResp(resp.Status(), resp.Error())
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't retrieve cluster '%s': %v", clusterID, err)
+	}
+	return resp.Body(), nil
+}
+
+// NameTaken returns true if a cluster visible to the OCM account already has a name.
+func (o *OCMProvider) NameTaken(name string) (bool, error) {
+	query := "name = " + quoteSearchValue(name)
+
+	var resp *v1.ClustersListResponse
+	err := retryWithContext(func(ctx context.Context) error {
+		var err error
+		resp, err = o.conn.ClustersMgmt().V1().Clusters().List().
+			Search(query).
+			Size(1).
+			SendContext(ctx)
+
+		if resp != nil && resp.Error() != nil {
+			return errResp(resp.Status(), resp.Error())
+		}
+
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("couldn't search for clusters named '%s': %v", name, err)
+	}
+	return resp.Items().Len() > 0, nil
+}
+
+// existingClustersBatch is the number of cluster IDs searched for at once.
+const existingClustersBatch = 50
+
+// ExistingClusters returns which of the cluster IDs belong to clusters that are still visible to the OCM account.
+func (o *OCMProvider) ExistingClusters(ids []string) (map[string]bool, error) {
+	existing := make(map[string]bool, len(ids))
+	for start := 0; start < len(ids); start += existingClustersBatch {
+		end := start + existingClustersBatch
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		quoted := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			quoted = append(quoted, quoteSearchValue(id))
+		}
+		query := "id in (" + strings.Join(quoted, ", ") + ")"
+
+		var resp *v1.ClustersListResponse
+		err := retryWithContext(func(ctx context.Context) error {
+			var err error
+			resp, err = o.conn.ClustersMgmt().V1().Clusters().List().
+				Search(query).
+				Size(end - start).
+				SendContext(ctx)
+
+			if resp != nil && resp.Error() != nil {
+				return errResp(resp.Status(), resp.Error())
+			}
+
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't search for clusters matching %q: %v", query, err)
+		}
+
+		// a truncated page can't tell which clusters are missing
+		if resp.Total() > resp.Items().Len() {
+			return nil, fmt.Errorf("search for clusters matching %q returned %d of %d clusters", query, resp.Items().Len(), resp.Total())
+		}
+
+		resp.Items().Each(func(cluster *v1.Cluster) bool {
+			existing[cluster.ID()] = true
+			return true
+		})
+	}
+	return existing, nil
+}
+
+// OSDe2eClusters returns the clusters created by the OCM account that have the property set on clusters osde2e
+// creates. Clusters created by other accounts of the organization, such as other CI jobs, are left out.
+func (o *OCMProvider) OSDe2eClusters() ([]spi.ClusterSummary, error) {
+	username, err := o.currentUsername()
+	if err != nil {
+		return nil, err
+	}
+	query := "properties." + MadeByOSDe2e + " = 'true' and creator.username = " + quoteSearchValue(username)
+
+	var clusters []spi.ClusterSummary
+	for page := 1; ; page++ {
+		var resp *v1.ClustersListResponse
+		err := retryWithContext(func(ctx context.Context) error {
+			var err error
+			resp, err = o.conn.ClustersMgmt().V1().Clusters().List().
+				Search(query).
+				Page(page).
+				Size(PageSize).
+				SendContext(ctx)
+
+			if resp != nil && resp.Error() != nil {
+				return errResp(resp.Status(), resp.Error())
+			}
+
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't search for clusters matching %q: %v", query, err)
+		}
+
+		resp.Items().Each(func(cluster *v1.Cluster) bool {
+			clusters = append(clusters, spi.ClusterSummary{
+				ID:      cluster.ID(),
+				Name:    cluster.Name(),
+				State:   ocmStateToInternalState(cluster.State()),
+				Created: cluster.CreationTimestamp(),
+				Expires: cluster.ExpirationTimestamp(),
+			})
+			return true
+		})
+
+		if page*PageSize >= resp.Total() {
+			return clusters, nil
+		}
+	}
+}
+
+// currentUsername returns the username of the OCM account.
+func (o *OCMProvider) currentUsername() (string, error) {
+	var resp *accounts.CurrentAccountGetResponse
+	err := retryWithContext(func(ctx context.Context) error {
+		var err error
+		resp, err = o.conn.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+
+		if resp != nil && resp.Error() != nil {
+			return errResp(resp.Status(), resp.Error())
+		}
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("couldn't get current account: %v", err)
+	}
+
+	if resp.Body().Username() == "" {
+		return "", fmt.Errorf("current account '%s' has no username", resp.Body().ID())
+	}
+	return resp.Body().Username(), nil
+}
+
+// SearchClusters returns the ready clusters matching the search, most recently created first, up to limit clusters.
+func (o *OCMProvider) SearchClusters(search spi.ClusterSearch, limit int) ([]spi.ClusterSummary, error) {
+	query := clusterSearchQuery(search)
+
+	var clusters []spi.ClusterSummary
+	for page := 1; ; page++ {
+		var resp *v1.ClustersListResponse
+		err := retryWithContext(func(ctx context.Context) error {
+			var err error
+			resp, err = o.conn.ClustersMgmt().V1().Clusters().List().
+				Search(query).
+				Order("creation_timestamp desc").
+				Page(page).
+				Size(PageSize).
+				SendContext(ctx)
+
+			if resp != nil && resp.Error() != nil {
+				return errResp(resp.Status(), resp.Error())
+			}
+
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("couldn't search for clusters matching %q: %v", query, err)
+		}
+
+		resp.Items().Each(func(cluster *v1.Cluster) bool {
+			if limit > 0 && len(clusters) >= limit {
+				return false
+			}
+			clusters = append(clusters, spi.ClusterSummary{
+				ID:      cluster.ID(),
+				Name:    cluster.Name(),
+				Version: cluster.Version().ID(),
+				State:   ocmStateToInternalState(cluster.State()),
+				Created: cluster.CreationTimestamp(),
+				Expires: cluster.ExpirationTimestamp(),
+			})
+			return true
+		})
+
+		if (limit > 0 && len(clusters) >= limit) || page*PageSize >= resp.Total() {
+			return clusters, nil
+		}
+	}
+}
+
+// clusterSearchQuery returns the OCM search query for ready clusters matching the search.
+func clusterSearchQuery(search spi.ClusterSearch) string {
+	clauses := []string{"state = 'ready'"}
+	match := func(field, value string) {
+		if value != "" {
+			clauses = append(clauses, fmt.Sprintf("%s = %s", field, quoteSearchValue(value)))
+		}
+	}
+
+	match("version.id", search.Version)
+	match("cloud_provider.id", search.CloudProvider)
+	match("region.id", search.Region)
+
+	// properties are matched in a stable order so the query is reproducible
+	names := make([]string, 0, len(search.Properties))
+	for name := range search.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		match("properties."+name, search.Properties[name])
+	}
+
+	if search.Query != "" {
+		clauses = append(clauses, "("+search.Query+")")
+	}
+	return strings.Join(clauses, " and ")
+}
+
+// quoteSearchValue quotes a value for an OCM search query, doubling any quotes it contains.
+func quoteSearchValue(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
@@ -0,0 +1,258 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestFindCluster(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	search := spi.ClusterSearch{
+		Version:    "openshift-v4.5.1",
+		Region:     "us-east-1",
+		Properties: map[string]string{"pool": "ci", "team": "o'brien"},
+		Query:      "multi_az = 'false'",
+	}
+	expectedQuery := "state = 'ready' and version.id = 'openshift-v4.5.1' and region.id = 'us-east-1' and " +
+		"properties.pool = 'ci' and properties.team = 'o''brien' and (multi_az = 'false')"
+
+	found := true
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clusters_mgmt/v1/clusters" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if query := r.URL.Query().Get("search"); query != expectedQuery {
+			t.Errorf("expected search %q, got %q", expectedQuery, query)
+		}
+		if found {
+			fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":3,"total":3,"items":[`+
+				`{"kind":"Cluster","id":"claimed","properties":{"ClaimedBy":"job-1"}},`+
+				`{"kind":"Cluster","id":"stale","properties":{"ClaimedBy":"job-0","ClaimedAt":"2020-06-01T12:00:00Z"}},{"kind":"Cluster","id":"abc"}]}`)
+		} else {
+			fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":0,"total":0,"items":[]}`)
+		}
+	})
+	defer closeServer()
+
+	if id, err := provider.FindCluster(search); err != nil || id != "stale" {
+		t.Errorf("expected to find the cluster with a stale claim, got '%s': %v", id, err)
+	}
+
+	found = false
+	if id, err := provider.FindCluster(search); err != nil || id != "" {
+		t.Errorf("expected no cluster to be found, got '%s': %v", id, err)
+	}
+}
+
+func TestClaimCluster(t *testing.T) {
+	defer func(policy backoff.Backoff, settle time.Duration) { ocmBackoff, claimSettle = policy, settle }(ocmBackoff, claimSettle)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	claimSettle = 0
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+	config.Instance.Cluster.InstallTimeout, config.Instance.Cluster.AdoptTestTimeout = 135, 480
+
+	properties := map[string]string{MadeByOSDe2e: "true"}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/clusters_mgmt/v1/clusters/abc" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var body struct {
+				Properties map[string]string `json:"properties"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("error decoding update: %v", err)
+			}
+			properties = body.Properties
+		}
+		data, _ := json.Marshal(properties)
+		fmt.Fprintf(w, `{"kind":"Cluster","id":"abc","properties":%s}`, data)
+	})
+	defer closeServer()
+
+	if claimed, err := provider.ClaimCluster("abc", "job-1"); err != nil || !claimed {
+		t.Fatalf("expected to claim cluster abc: %v", err)
+	}
+	if _, err := time.Parse(time.RFC3339, properties[ClaimedAt]); err != nil {
+		t.Errorf("expected the time of the claim to be recorded: %v", err)
+	}
+	if expected := map[string]string{MadeByOSDe2e: "true", ClaimedBy: "job-1", ClaimedAt: properties[ClaimedAt]}; !reflect.DeepEqual(properties, expected) {
+		t.Errorf("expected properties %v, got %v", expected, properties)
+	}
+
+	if claimed, err := provider.ClaimCluster("abc", "job-2"); err != nil || claimed {
+		t.Errorf("expected cluster claimed by another run not to be claimed: %v", err)
+	}
+
+	if err := provider.ReleaseCluster("abc", "job-2"); err != nil || properties[ClaimedBy] != "job-1" {
+		t.Errorf("expected another run's claim to be kept: %v", err)
+	}
+
+	properties[ClaimedAt] = "2020-06-01T12:00:00Z"
+	if claimed, err := provider.ClaimCluster("abc", "job-2"); err != nil || !claimed {
+		t.Errorf("expected a stale claim to be taken over: %v", err)
+	}
+
+	if err := provider.ReleaseCluster("abc", "job-2"); err != nil || properties[ClaimedBy] != "" || properties[ClaimedAt] != "" {
+		t.Errorf("expected claim to be released: %v", err)
+	}
+}
+
+func TestNameTaken(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search") == "name = 'ci-cluster-4-5-1-abc'" {
+			fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":1,"total":1,"items":[{"kind":"Cluster","id":"abc"}]}`)
+		} else {
+			fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":0,"total":0,"items":[]}`)
+		}
+	})
+	defer closeServer()
+
+	if taken, err := provider.NameTaken("ci-cluster-4-5-1-abc"); err != nil || !taken {
+		t.Errorf("expected name to be taken, got %t: %v", taken, err)
+	}
+	if taken, err := provider.NameTaken("ci-cluster-4-5-1-def"); err != nil || taken {
+		t.Errorf("expected name to be free, got %t: %v", taken, err)
+	}
+}
+
+func TestExistingClusters(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	var searches []string
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		searches = append(searches, r.URL.Query().Get("search"))
+		if strings.Contains(r.URL.Query().Get("search"), "'abc'") {
+			fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":1,"total":1,"items":[{"kind":"Cluster","id":"abc"}]}`)
+		} else {
+			fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":0,"total":0,"items":[]}`)
+		}
+	})
+	defer closeServer()
+
+	ids := []string{"abc"}
+	for i := 1; i < existingClustersBatch+1; i++ {
+		ids = append(ids, fmt.Sprintf("gone-%d", i))
+	}
+
+	existing, err := provider.ExistingClusters(ids)
+	if err != nil {
+		t.Fatalf("failed to check clusters: %v", err)
+	}
+	if !reflect.DeepEqual(existing, map[string]bool{"abc": true}) {
+		t.Errorf("expected only cluster abc to exist, got %v", existing)
+	}
+	if len(searches) != 2 || searches[1] != "id in ('gone-50')" {
+		t.Errorf("expected the IDs to be searched for in batches, got %q", searches)
+	}
+
+	truncated, closeTruncated := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"kind":"ClusterList","page":1,"size":1,"total":2,"items":[{"kind":"Cluster","id":"abc"}]}`)
+	})
+	defer closeTruncated()
+
+	if _, err = truncated.ExistingClusters([]string{"abc", "def"}); err == nil {
+		t.Errorf("expected a truncated search to be inconclusive")
+	}
+}
+
+func TestOSDe2eClusters(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/accounts_mgmt/v1/current_account" {
+			fmt.Fprint(w, `{"kind":"Account","id":"123","username":"osde2e-ci"}`)
+			return
+		}
+		if query := r.URL.Query().Get("search"); query != "properties.MadeByOSDe2e = 'true' and creator.username = 'osde2e-ci'" {
+			t.Errorf("unexpected search %q", query)
+		}
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprintf(w, `{"kind":"ClusterList","page":1,"size":1,"total":%d,"items":[{"kind":"Cluster","id":"abc","name":"ci-cluster-abc","state":"error",`+
+				`"creation_timestamp":"2020-06-01T12:00:00Z","expiration_timestamp":"2020-06-01T18:00:00Z"}]}`, PageSize+1)
+		} else {
+			fmt.Fprintf(w, `{"kind":"ClusterList","page":2,"size":1,"total":%d,"items":[{"kind":"Cluster","id":"def","state":"ready"}]}`, PageSize+1)
+		}
+	})
+	defer closeServer()
+
+	clusters, err := provider.OSDe2eClusters()
+	if err != nil {
+		t.Fatalf("failed to list clusters: %v", err)
+	}
+
+	expected := []spi.ClusterSummary{
+		{
+			ID:      "abc",
+			Name:    "ci-cluster-abc",
+			State:   spi.ClusterStateError,
+			Created: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+			Expires: time.Date(2020, 6, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{ID: "def", State: spi.ClusterStateReady},
+	}
+	if !reflect.DeepEqual(clusters, expected) {
+		t.Errorf("expected %+v, got %+v", expected, clusters)
+	}
+}
+
+func TestSearchClusters(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	pages := 0
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if query := r.URL.Query().Get("search"); query != "state = 'ready' and region.id = 'us-east-1'" {
+			t.Errorf("unexpected search %q", query)
+		}
+		pages++
+		if r.URL.Query().Get("page") == "1" {
+			fmt.Fprintf(w, `{"kind":"ClusterList","page":1,"size":2,"total":%d,"items":[`+
+				`{"kind":"Cluster","id":"abc","name":"prod-abc","state":"ready","version":{"id":"openshift-v4.5.1"}},`+
+				`{"kind":"Cluster","id":"def","state":"ready"}]}`, PageSize+2)
+		} else {
+			fmt.Fprintf(w, `{"kind":"ClusterList","page":2,"size":2,"total":%d,"items":[{"kind":"Cluster","id":"ghi","state":"ready"},{"kind":"Cluster","id":"jkl","state":"ready"}]}`, PageSize+2)
+		}
+	})
+	defer closeServer()
+
+	search := spi.ClusterSearch{Region: "us-east-1"}
+	clusters, err := provider.SearchClusters(search, 0)
+	if err != nil {
+		t.Fatalf("failed to search for clusters: %v", err)
+	}
+	if len(clusters) != 4 || pages != 2 {
+		t.Errorf("expected 4 clusters from 2 pages, got %d from %d: %+v", len(clusters), pages, clusters)
+	}
+	if expected := (spi.ClusterSummary{ID: "abc", Name: "prod-abc", Version: "openshift-v4.5.1", State: spi.ClusterStateReady}); clusters[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, clusters[0])
+	}
+
+	pages = 0
+	if clusters, err = provider.SearchClusters(search, 1); err != nil || len(clusters) != 1 || pages != 1 {
+		t.Errorf("expected the search to stop at 1 cluster, got %d from %d pages: %v", len(clusters), pages, err)
+	}
+}
@@ -0,0 +1,161 @@
+package ocmprovider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/markbates/pkger"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// clusterSpecsDir holds the maintained cluster specs, which can be referenced by name.
+const clusterSpecsDir = "/assets/cluster-specs"
+
+// ClusterSpec describes the shape of a cluster to create. Fields that aren't set keep the values osde2e chooses.
+type ClusterSpec struct {
+	// Version is the version to install, ex. openshift-v4.5.1. It is used instead of choosing one.
+	Version string `yaml:"version"`
+
+	// CloudProvider is the cloud provider to create the cluster in, ex. aws.
+	CloudProvider string `yaml:"cloudProvider"`
+
+	// Region is the cloud region to create the cluster in.
+	Region string `yaml:"region"`
+
+	// MultiAZ deploys the cluster across multiple availability zones.
+	MultiAZ *bool `yaml:"multiAZ"`
+
+	// Nodes are the number of nodes of each role.
+	Nodes ClusterSpecNodes `yaml:"nodes"`
+
+	// Network are the CIDRs of the cluster network.
+	Network ClusterSpecNetwork `yaml:"network"`
+
+	// LoadBalancerQuota is the number of load balancers the cluster may create.
+	LoadBalancerQuota int `yaml:"loadBalancerQuota"`
+
+	// Addons are the IDs of addons to install once the cluster is ready, in addition to ADDON_IDS.
+	Addons []string `yaml:"addons"`
+
+	// Properties are added to the properties osde2e sets on the cluster.
+	Properties map[string]string `yaml:"properties"`
+}
+
+// ClusterSpecNodes are the number of nodes of each role in a cluster spec.
+type ClusterSpecNodes struct {
+	Compute int `yaml:"compute"`
+	Infra   int `yaml:"infra"`
+	Master  int `yaml:"master"`
+}
+
+// ClusterSpecNetwork is the cluster network of a cluster spec.
+type ClusterSpecNetwork struct {
+	MachineCIDR string `yaml:"machineCIDR"`
+	ServiceCIDR string `yaml:"serviceCIDR"`
+	PodCIDR     string `yaml:"podCIDR"`
+}
+
+// LoadClusterSpec loads a cluster spec from a file, or a maintained spec by name.
+func LoadClusterSpec(name string) (*ClusterSpec, error) {
+	data, err := readClusterSpec(name)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &ClusterSpec{}
+	if err = yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("error parsing cluster spec %s: %v", name, err)
+	}
+	return spec, nil
+}
+
+func readClusterSpec(name string) ([]byte, error) {
+	if _, err := os.Stat(name); err == nil {
+		return ioutil.ReadFile(name)
+	}
+
+	reader, err := pkger.Open(filepath.Join(clusterSpecsDir, name+".yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("no cluster spec file or maintained cluster spec named %s", name)
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// applyOptions sets the options osde2e uses to choose the cluster's version, location, and addons from the spec.
+// Applying a spec more than once has no further effect.
+func (s *ClusterSpec) applyOptions(cfg *config.Config, st *state.State) {
+	if s.Version != "" {
+		st.Cluster.Version = s.Version
+	}
+	if s.CloudProvider != "" {
+		st.CloudProvider.CloudProviderID = s.CloudProvider
+	}
+	if s.Region != "" {
+		st.CloudProvider.Region = s.Region
+	}
+	if s.MultiAZ != nil {
+		cfg.Cluster.MultiAZ = *s.MultiAZ
+	}
+
+	for _, addon := range s.Addons {
+		found := false
+		for _, id := range cfg.Addons.IDs {
+			found = found || id == addon
+		}
+		if !found {
+			cfg.Addons.IDs = append(cfg.Addons.IDs, addon)
+		}
+	}
+}
+
+// apply sets the nodes, network, quotas, and properties of the spec on a cluster being created.
+func (s *ClusterSpec) apply(cluster *v1.ClusterBuilder, properties map[string]string) *v1.ClusterBuilder {
+	if nodes := s.Nodes; nodes != (ClusterSpecNodes{}) {
+		builder := v1.NewClusterNodes()
+		if nodes.Compute > 0 {
+			builder = builder.Compute(nodes.Compute)
+		}
+		if nodes.Infra > 0 {
+			builder = builder.Infra(nodes.Infra)
+		}
+		if nodes.Master > 0 {
+			builder = builder.Master(nodes.Master)
+		}
+		cluster = cluster.Nodes(builder)
+	}
+
+	if network := s.Network; network != (ClusterSpecNetwork{}) {
+		builder := v1.NewNetwork()
+		if network.MachineCIDR != "" {
+			builder = builder.MachineCIDR(network.MachineCIDR)
+		}
+		if network.ServiceCIDR != "" {
+			builder = builder.ServiceCIDR(network.ServiceCIDR)
+		}
+		if network.PodCIDR != "" {
+			builder = builder.PodCIDR(network.PodCIDR)
+		}
+		cluster = cluster.Network(builder)
+	}
+
+	if s.LoadBalancerQuota > 0 {
+		cluster = cluster.LoadBalancerQuota(s.LoadBalancerQuota)
+	}
+
+	// properties set by osde2e identify its clusters, so they can't be overridden
+	merged := map[string]string{}
+	for key, value := range s.Properties {
+		merged[key] = value
+	}
+	for key, value := range properties {
+		merged[key] = value
+	}
+	return cluster.Properties(merged)
+}
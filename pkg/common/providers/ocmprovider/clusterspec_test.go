@@ -0,0 +1,129 @@
+package ocmprovider
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const testClusterSpec = `
+version: openshift-v4.5.1
+region: eu-west-1
+multiAZ: true
+nodes:
+  compute: 6
+  infra: 3
+network:
+  machineCIDR: 10.0.0.0/16
+  podCIDR: 10.128.0.0/14
+addons:
+- managed-odh
+- prow-operator
+properties:
+  team: networking
+  MadeByOSDe2e: "false"
+`
+
+func writeClusterSpec(t *testing.T, spec string) string {
+	f, err := ioutil.TempFile("", "cluster-spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err = f.WriteString(spec); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestClusterSpec(t *testing.T) {
+	file := writeClusterSpec(t, testClusterSpec)
+	defer os.Remove(file)
+
+	spec, err := LoadClusterSpec(file)
+	if err != nil {
+		t.Fatalf("couldn't load cluster spec: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Addons.IDs = []string{"prow-operator"}
+	st := &state.State{}
+	st.CloudProvider.CloudProviderID = "aws"
+
+	// applying the spec again shouldn't add its addons twice
+	spec.applyOptions(cfg, st)
+	spec.applyOptions(cfg, st)
+
+	if st.Cluster.Version != "openshift-v4.5.1" || st.CloudProvider.Region != "eu-west-1" || st.CloudProvider.CloudProviderID != "aws" || !cfg.Cluster.MultiAZ {
+		t.Errorf("unexpected options from the spec: %+v %+v %+v", st.Cluster, st.CloudProvider, cfg.Cluster)
+	}
+	if expected := []string{"prow-operator", "managed-odh"}; !reflect.DeepEqual(cfg.Addons.IDs, expected) {
+		t.Errorf("expected addons %v, got %v", expected, cfg.Addons.IDs)
+	}
+
+	cluster, err := spec.apply(v1.NewCluster(), map[string]string{MadeByOSDe2e: "true"}).Build()
+	if err != nil {
+		t.Fatalf("couldn't build cluster: %v", err)
+	}
+
+	if cluster.Nodes().Compute() != 6 || cluster.Nodes().Infra() != 3 || cluster.Nodes().Master() != 0 {
+		t.Errorf("unexpected nodes %+v", cluster.Nodes())
+	}
+	if cluster.Network().MachineCIDR() != "10.0.0.0/16" || cluster.Network().PodCIDR() != "10.128.0.0/14" || cluster.Network().ServiceCIDR() != "" {
+		t.Errorf("unexpected network %+v", cluster.Network())
+	}
+	if expected := map[string]string{"team": "networking", MadeByOSDe2e: "true"}; !reflect.DeepEqual(cluster.Properties(), expected) {
+		t.Errorf("expected properties %v, got %v", expected, cluster.Properties())
+	}
+}
+
+func TestLoadClusterSpec(t *testing.T) {
+	invalid := writeClusterSpec(t, "nodes:\n  workers: 3\n")
+	defer os.Remove(invalid)
+
+	tests := []struct {
+		Name    string
+		Spec    string
+		Success bool
+	}{
+		{"maintained", "large-multi-az", true},
+		{"unknown field", invalid, false},
+		{"missing", "no-such-spec", false},
+	}
+
+	for _, test := range tests {
+		if _, err := LoadClusterSpec(test.Spec); (err == nil) != test.Success {
+			t.Errorf("%s: unexpected result loading cluster spec: %v", test.Name, err)
+		}
+	}
+}
+
+func TestApplyClusterSpec(t *testing.T) {
+	defer func(cfg config.Config, st state.State) { *config.Instance, *state.Instance = cfg, st }(*config.Instance, *state.Instance)
+
+	file := writeClusterSpec(t, testClusterSpec)
+	defer os.Remove(file)
+
+	config.Instance.OCM.Token, state.Instance.Cluster.Version = "token", ""
+	c := Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", ClusterSpec: file}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if state.Instance.Cluster.Version != "" {
+		t.Errorf("expected validating the config to leave the version alone, got %s", state.Instance.Cluster.Version)
+	}
+
+	if err := c.ApplyClusterSpec(); err != nil {
+		t.Fatalf("couldn't apply cluster spec: %v", err)
+	}
+	if state.Instance.Cluster.Version != "openshift-v4.5.1" {
+		t.Errorf("expected the version of the spec, got %s", state.Instance.Cluster.Version)
+	}
+}
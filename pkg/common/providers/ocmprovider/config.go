@@ -0,0 +1,122 @@
+package ocmprovider
+
+import (
+	"fmt"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// Config contains options only used by the OCM provider. It is read from the ocm section alongside the global config.
+type Config struct {
+	// NumRetries is the number of times to retry each OCM call.
+	NumRetries int `env:"NUM_RETRIES" sect:"ocm" default:"3" yaml:"numRetries" validate:"range=1:"`
+
+	// RequestTimeout is the number of seconds each OCM call may take before it is cancelled and retried.
+	RequestTimeout int `env:"OCM_REQUEST_TIMEOUT" sect:"ocm" default:"120" yaml:"requestTimeout" validate:"range=1:"`
+
+	// TransportAttempts is the number of times each request of an OCM call is sent before its failure is returned to
+	// the call, which may then be retried as a whole.
+	TransportAttempts int `env:"OCM_TRANSPORT_ATTEMPTS" sect:"ocm" default:"4" yaml:"transportAttempts" validate:"range=1:"`
+
+	// RetryPolicies maps the status codes of OCM responses to how requests receiving them are retried: backoff,
+	// retry-after, or none. Responses with other status codes aren't retried.
+	RetryPolicies map[string]string `env:"OCM_RETRY_POLICIES" sect:"ocm" default:"429=retry-after,500=backoff,502=backoff,503=retry-after,504=backoff" yaml:"retryPolicies"`
+
+	// LogLevel is the minimum level of OCM SDK messages to log: debug, info, warn, error, or off. DEBUG_OSD sets it to debug.
+	LogLevel string `env:"OCM_LOG_LEVEL" sect:"ocm" default:"warn" yaml:"logLevel"`
+
+	// LogLevels is a comma-delimited list of levels for the http, auth, and connection subsystems of the OCM SDK, ex. "http=debug,auth=info"
+	LogLevels []string `env:"OCM_LOG_LEVELS" sect:"ocm" yaml:"logLevels"`
+
+	// ClusterSpec is a cluster spec file, or the name of a maintained cluster spec, describing the cluster to create.
+	ClusterSpec string `env:"CLUSTER_SPEC" sect:"ocm" yaml:"clusterSpec"`
+
+	// MetadataCacheDir is where the regions of OCM's cloud providers are cached. If empty, they're cached
+	// in osde2e's directory of the user's cache directory.
+	MetadataCacheDir string `env:"OCM_METADATA_CACHE_DIR" sect:"ocm" yaml:"metadataCacheDir"`
+
+	// MetadataCacheTTL is the number of hours cached metadata is used for before it's fetched again. If 0, metadata
+	// isn't cached.
+	MetadataCacheTTL int `env:"OCM_METADATA_CACHE_TTL" sect:"ocm" default:"24" yaml:"metadataCacheTTL" validate:"range=0:"`
+
+	// CCS creates clusters in a customer's cloud account (Customer Cloud Subscription) rather than Red Hat's. Only AWS
+	// accounts are supported.
+	CCS bool `env:"OCM_CCS" sect:"ocm" default:"false" yaml:"ccs"`
+
+	// CCSAWSAccountID is the ID of the AWS account CCS clusters are created in.
+	CCSAWSAccountID string `env:"OCM_CCS_AWS_ACCOUNT_ID" sect:"ocm" yaml:"ccsAWSAccountID"`
+
+	// CCSAWSAccessKeyID and CCSAWSSecretAccessKey are the credentials OCM installs CCS clusters with.
+	CCSAWSAccessKeyID     string `env:"OCM_CCS_AWS_ACCESS_KEY_ID" sect:"ocm" yaml:"ccsAWSAccessKeyID" secret:"true"`
+	CCSAWSSecretAccessKey string `env:"OCM_CCS_AWS_SECRET_ACCESS_KEY" sect:"ocm" yaml:"ccsAWSSecretAccessKey" secret:"true"`
+
+	// SubnetIDs are the existing subnets CCS clusters are installed in. They're needed to attach additional security
+	// groups, which belong to the subnets' VPC.
+	SubnetIDs []string `env:"OCM_AWS_SUBNET_IDS" sect:"ocm" yaml:"subnetIDs"`
+
+	// Offline uses cached metadata however old it is, and fails instead of fetching metadata that isn't cached.
+	Offline bool `env:"OCM_OFFLINE" sect:"ocm" default:"false" yaml:"offline"`
+}
+
+// Options is the loaded OCM provider config.
+var Options = new(Config)
+
+func init() {
+	load.RegisterExtension(config.Instance, "ocm", Options)
+}
+
+// Validate checks that the OCM provider has been configured correctly.
+func (c *Config) Validate() error {
+	if config.Instance.OCM.Token == "" {
+		return fmt.Errorf("an OCM token must be set with OCM_TOKEN to use the OCM provider")
+	}
+
+	if c.NumRetries < 1 {
+		return fmt.Errorf("NUM_RETRIES must be at least 1, got %d", c.NumRetries)
+	}
+
+	if c.RequestTimeout <= 0 {
+		return fmt.Errorf("OCM_REQUEST_TIMEOUT must be positive, got %d", c.RequestTimeout)
+	}
+
+	if _, err := parseRetryPolicies(c.RetryPolicies); err != nil {
+		return err
+	}
+
+	if _, _, err := parseLogLevels(c.LogLevel, c.LogLevels); err != nil {
+		return err
+	}
+
+	if c.CCS && (c.CCSAWSAccountID == "" || c.CCSAWSAccessKeyID == "" || c.CCSAWSSecretAccessKey == "") {
+		return fmt.Errorf("an AWS account ID, access key ID, and secret access key must be set to create CCS clusters")
+	}
+
+	if len(config.Instance.Cluster.SecurityGroups) > 0 && (!c.CCS || len(c.SubnetIDs) == 0) {
+		return fmt.Errorf("additional security groups can only be attached to CCS clusters installed in existing subnets, set OCM_CCS and OCM_AWS_SUBNET_IDS")
+	}
+
+	if c.ClusterSpec != "" {
+		if _, err := LoadClusterSpec(c.ClusterSpec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyClusterSpec sets the options osde2e uses to choose the cluster's version, location, and addons from the
+// cluster spec, if one is set. They have to be known before the cluster is created, so this is done once the config
+// is valid rather than when the cluster is built.
+func (c *Config) ApplyClusterSpec() error {
+	if c.ClusterSpec == "" {
+		return nil
+	}
+
+	spec, err := LoadClusterSpec(c.ClusterSpec)
+	if err != nil {
+		return err
+	}
+	spec.applyOptions(config.Instance, state.Instance)
+	return nil
+}
@@ -0,0 +1,48 @@
+package ocmprovider
+
+import (
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+)
+
+func TestValidate(t *testing.T) {
+	defer func(token string) { config.Instance.OCM.Token = token }(config.Instance.OCM.Token)
+	defer func(groups []string) { config.Instance.Cluster.SecurityGroups = groups }(config.Instance.Cluster.SecurityGroups)
+
+	ccs := Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", CCS: true, CCSAWSAccountID: "123456789012", CCSAWSAccessKeyID: "id", CCSAWSSecretAccessKey: "secret"}
+	ccsInSubnets := ccs
+	ccsInSubnets.SubnetIDs = []string{"subnet-1"}
+
+	tests := []struct {
+		Name           string
+		Token          string
+		Config         Config
+		SecurityGroups []string
+		Success        bool
+	}{
+		{"valid", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn"}, nil, true},
+		{"missing token", "", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn"}, nil, false},
+		{"no retries", "token", Config{NumRetries: 0, RequestTimeout: 120, LogLevel: "warn"}, nil, false},
+		{"log levels", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "off", LogLevels: []string{"http=debug"}}, nil, true},
+		{"unknown log level", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "verbose"}, nil, false},
+		{"unknown log subsystem", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", LogLevels: []string{"metrics=debug"}}, nil, false},
+		{"no timeout", "token", Config{NumRetries: 3, RequestTimeout: 0, LogLevel: "warn"}, nil, false},
+		{"retry policies", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", RetryPolicies: map[string]string{"503": "retry-after"}}, nil, true},
+		{"unknown retry policy", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", RetryPolicies: map[string]string{"503": "forever"}}, nil, false},
+		{"missing cluster spec", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", ClusterSpec: "no-such-spec"}, nil, false},
+		{"ccs", "token", ccs, nil, true},
+		{"ccs without credentials", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn", CCS: true}, nil, false},
+		{"security groups", "token", ccsInSubnets, []string{"sg-1"}, true},
+		{"security groups without subnets", "token", ccs, []string{"sg-1"}, false},
+		{"security groups without ccs", "token", Config{NumRetries: 3, RequestTimeout: 120, LogLevel: "warn"}, []string{"sg-1"}, false},
+	}
+
+	for _, test := range tests {
+		config.Instance.OCM.Token = test.Token
+		config.Instance.Cluster.SecurityGroups = test.SecurityGroups
+		if err := test.Config.Validate(); (err == nil) != test.Success {
+			t.Errorf("%s: unexpected validation result: %v", test.Name, err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// deleteProtectionPathFmt is the path of a cluster's delete protection. It isn't included in the OCM SDK yet.
+const deleteProtectionPathFmt = "/api/clusters_mgmt/v1/clusters/%s/delete_protection"
+
+type deleteProtection struct {
+	Enabled bool `json:"enabled"`
+}
+
+type deleteProtectedCluster struct {
+	DeleteProtection deleteProtection `json:"delete_protection"`
+}
+
+// DeleteProtected returns true if OCM refuses to delete a cluster.
+func (o *OCMProvider) DeleteProtected(clusterID string) (bool, error) {
+	var cluster deleteProtectedCluster
+	if err := o.getJSON(fmt.Sprintf(clusterPathFmt, clusterID), &cluster); err != nil {
+		return false, fmt.Errorf("couldn't retrieve cluster '%s': %v", clusterID, err)
+	}
+	return cluster.DeleteProtection.Enabled, nil
+}
+
+// SetDeleteProtection enables or disables the delete protection of a cluster.
+func (o *OCMProvider) SetDeleteProtection(clusterID string, enabled bool) error {
+	data, err := json.Marshal(deleteProtection{Enabled: enabled})
+	if err != nil {
+		return err
+	}
+
+	err = retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Patch().Path(fmt.Sprintf(deleteProtectionPathFmt, clusterID)).Bytes(data).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		if resp.Status() == http.StatusNoContent {
+			return nil
+		}
+		return checkResponse(resp, http.StatusOK)
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't set delete protection of cluster '%s': %v", clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Set delete protection of cluster '%s' to %t", clusterID, enabled)
+	return nil
+}
@@ -0,0 +1,62 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func TestDeleteProtection(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	var protection deleteProtection
+	deleted := false
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(clusterPathFmt, "abc"):
+			json.NewEncoder(w).Encode(deleteProtectedCluster{DeleteProtection: protection})
+		case r.Method == http.MethodPatch && r.URL.Path == fmt.Sprintf(deleteProtectionPathFmt, "abc"):
+			if err := json.NewDecoder(r.Body).Decode(&protection); err != nil {
+				t.Errorf("failed to decode delete protection: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == fmt.Sprintf(clusterPathFmt, "abc"):
+			if protection.Enabled {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"kind":"Error","reason":"Delete protection is enabled"}`)
+				return
+			}
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	if err := provider.SetDeleteProtection("abc", true); err != nil {
+		t.Fatalf("failed to enable delete protection: %v", err)
+	}
+	if protected, err := provider.DeleteProtected("abc"); err != nil || !protected {
+		t.Errorf("expected cluster to be protected, got %t: %v", protected, err)
+	}
+	if err := provider.DeleteCluster("abc"); err == nil || deleted {
+		t.Errorf("expected deleting a protected cluster to be refused")
+	}
+
+	if err := provider.SetDeleteProtection("abc", false); err != nil {
+		t.Fatalf("failed to disable delete protection: %v", err)
+	}
+	if protected, err := provider.DeleteProtected("abc"); err != nil || protected {
+		t.Errorf("expected cluster to be unprotected, got %t: %v", protected, err)
+	}
+	if err := provider.DeleteCluster("abc"); err != nil || !deleted {
+		t.Errorf("expected unprotected cluster to be deleted: %v", err)
+	}
+}
@@ -1,5 +1,7 @@
 package ocmprovider
 
+import "fmt"
+
 const (
 	integration = "int"
 	stage       = "stage"
@@ -8,8 +10,17 @@ const (
 	integrationURL = "https://api-integration.6943.hive-integration.openshiftapps.com"
 	stageURL       = "https://api.stage.openshift.com"
 	prodURL        = "https://api.openshift.com"
+
+	// clusterPageFmt is the path of a cluster's page in the OCM UI.
+	clusterPageFmt = "%s/openshift/details/%s"
 )
 
+// consoleURLs are the OCM UIs of each environment. Integration has no public UI.
+var consoleURLs = map[string]string{
+	stage: "https://qaprodauth.cloud.redhat.com",
+	prod:  "https://cloud.redhat.com",
+}
+
 // Environments are known instance of OSD.
 var Environments = environments{
 	// default to using integration environment
@@ -31,3 +42,12 @@ func (e environments) Choose(desired string) string {
 		return e.Choose(val)
 	}
 }
+
+// clusterPageURL returns the URL of a cluster's page in the OCM UI of env, or an empty string if env has no UI.
+func clusterPageURL(env, clusterID string) string {
+	consoleURL, ok := consoleURLs[env]
+	if !ok || clusterID == "" {
+		return ""
+	}
+	return fmt.Sprintf(clusterPageFmt, consoleURL, clusterID)
+}
@@ -0,0 +1,22 @@
+package ocmprovider
+
+import "testing"
+
+func TestClusterPageURL(t *testing.T) {
+	tests := []struct {
+		Env       string
+		ClusterID string
+		Expected  string
+	}{
+		{prod, "abc", "https://cloud.redhat.com/openshift/details/abc"},
+		{stage, "abc", "https://qaprodauth.cloud.redhat.com/openshift/details/abc"},
+		{integration, "abc", ""},
+		{prod, "", ""},
+	}
+
+	for _, test := range tests {
+		if url := clusterPageURL(test.Env, test.ClusterID); url != test.Expected {
+			t.Errorf("%s/%s: expected %q, got %q", test.Env, test.ClusterID, test.Expected, url)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package ocmprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+const (
+	// hibernatePathFmt and resumePathFmt are the paths of a cluster's hibernation actions. They aren't included
+	// in the OCM SDK yet.
+	hibernatePathFmt = "/api/clusters_mgmt/v1/clusters/%s/hibernate"
+	resumePathFmt    = "/api/clusters_mgmt/v1/clusters/%s/resume"
+)
+
+// Cluster states used by hibernation, which the OCM SDK doesn't know about yet.
+const (
+	ocmClusterStatePoweringDown v1.ClusterState = "powering_down"
+	ocmClusterStateHibernating  v1.ClusterState = "hibernating"
+	ocmClusterStateResuming     v1.ClusterState = "resuming"
+)
+
+// Hibernate starts hibernating a cluster.
+func (o *OCMProvider) Hibernate(clusterID string) error {
+	if err := o.postHibernationAction(fmt.Sprintf(hibernatePathFmt, clusterID)); err != nil {
+		return fmt.Errorf("couldn't hibernate cluster '%s': %v", clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Hibernating cluster '%s'", clusterID)
+	return nil
+}
+
+// Resume starts resuming a hibernating cluster.
+func (o *OCMProvider) Resume(clusterID string) error {
+	if err := o.postHibernationAction(fmt.Sprintf(resumePathFmt, clusterID)); err != nil {
+		return fmt.Errorf("couldn't resume cluster '%s': %v", clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Resuming cluster '%s'", clusterID)
+	return nil
+}
+
+func (o *OCMProvider) postHibernationAction(path string) error {
+	return retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Post().Path(path).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		return checkResponse(resp, http.StatusAccepted)
+	})
+}
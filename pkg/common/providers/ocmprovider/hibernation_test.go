@@ -0,0 +1,85 @@
+package ocmprovider
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestHibernation(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	var paths []string
+	failures := 1
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer closeServer()
+
+	if err := provider.Hibernate("abc"); err != nil {
+		t.Errorf("failed to hibernate: %v", err)
+	}
+
+	if err := provider.Resume("abc"); err != nil {
+		t.Errorf("failed to resume: %v", err)
+	}
+
+	expected := []string{fmt.Sprintf(hibernatePathFmt, "abc"), fmt.Sprintf(resumePathFmt, "abc")}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("expected requests to %v, got %v", expected, paths)
+	}
+}
+
+func TestHibernationRejected(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	attempts := 0
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"reason":"cluster is not ready"}`)
+	})
+	defer closeServer()
+
+	if err := provider.Hibernate("abc"); err == nil {
+		t.Errorf("expected a rejected hibernation to return an error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("rejected requests shouldn't be retried, made %d attempts", attempts)
+	}
+}
+
+func TestHibernationStates(t *testing.T) {
+	tests := map[string]spi.ClusterState{
+		"powering_down": spi.ClusterStatePoweringDown,
+		"hibernating":   spi.ClusterStateHibernating,
+		"resuming":      spi.ClusterStateResuming,
+	}
+
+	for state, expected := range tests {
+		if internal := ocmStateToInternalState(v1.ClusterState(state)); internal != expected {
+			t.Errorf("expected state %s to be %s, got %s", state, expected, internal)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+// Paths of hosted cluster resources. They aren't included in the OCM SDK yet.
+const (
+	clusterPathFmt                     = "/api/clusters_mgmt/v1/clusters/%s"
+	controlPlaneUpgradePoliciesPathFmt = "/api/clusters_mgmt/v1/clusters/%s/control_plane/upgrade_policies"
+	nodePoolsPathFmt                   = "/api/clusters_mgmt/v1/clusters/%s/node_pools"
+	nodePoolUpgradePoliciesPathFmt     = "/api/clusters_mgmt/v1/clusters/%s/node_pools/%s/upgrade_policies"
+)
+
+// Upgrade policy types and the schedule of upgrades requested by osde2e.
+const (
+	controlPlaneUpgradeType = "ControlPlane"
+	nodePoolUpgradeType     = "NodePool"
+	manualScheduleType      = "manual"
+)
+
+// upgradeLeadTime is how far in the future upgrades are scheduled, as OCM doesn't accept upgrades that start
+// right away.
+const upgradeLeadTime = 6 * time.Minute
+
+type hostedCluster struct {
+	Hypershift struct {
+		Enabled bool `json:"enabled"`
+	} `json:"hypershift"`
+}
+
+type nodePoolList struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Version struct {
+			ID string `json:"id"`
+		} `json:"version"`
+		Replicas int `json:"replicas"`
+		Status   struct {
+			CurrentReplicas int `json:"current_replicas"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type upgradePolicy struct {
+	UpgradeType  string    `json:"upgrade_type"`
+	ScheduleType string    `json:"schedule_type"`
+	Version      string    `json:"version"`
+	NextRun      time.Time `json:"next_run"`
+}
+
+// HostedControlPlane returns whether the cluster has a hosted control plane.
+func (o *OCMProvider) HostedControlPlane(clusterID string) (bool, error) {
+	var cluster hostedCluster
+	if err := o.getJSON(fmt.Sprintf(clusterPathFmt, clusterID), &cluster); err != nil {
+		return false, fmt.Errorf("couldn't retrieve cluster '%s': %v", clusterID, err)
+	}
+	return cluster.Hypershift.Enabled, nil
+}
+
+// UpgradeControlPlane schedules an upgrade of the cluster's hosted control plane.
+func (o *OCMProvider) UpgradeControlPlane(clusterID, version string) error {
+	path := fmt.Sprintf(controlPlaneUpgradePoliciesPathFmt, clusterID)
+	if err := o.scheduleUpgrade(path, controlPlaneUpgradeType, version); err != nil {
+		return fmt.Errorf("couldn't upgrade control plane of cluster '%s': %v", clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Scheduled upgrade of the control plane of cluster '%s' to %s", clusterID, version)
+	return nil
+}
+
+// NodePools lists the node pools of the cluster.
+func (o *OCMProvider) NodePools(clusterID string) ([]spi.NodePool, error) {
+	var list nodePoolList
+	if err := o.getJSON(fmt.Sprintf(nodePoolsPathFmt, clusterID), &list); err != nil {
+		return nil, fmt.Errorf("couldn't list node pools of cluster '%s': %v", clusterID, err)
+	}
+
+	nodePools := make([]spi.NodePool, 0, len(list.Items))
+	for _, item := range list.Items {
+		nodePools = append(nodePools, spi.NodePool{
+			ID:              item.ID,
+			Version:         strings.TrimPrefix(item.Version.ID, util.VersionPrefix),
+			Replicas:        item.Replicas,
+			CurrentReplicas: item.Status.CurrentReplicas,
+		})
+	}
+	return nodePools, nil
+}
+
+// UpgradeNodePool schedules an upgrade of one of the cluster's node pools.
+func (o *OCMProvider) UpgradeNodePool(clusterID, nodePoolID, version string) error {
+	path := fmt.Sprintf(nodePoolUpgradePoliciesPathFmt, clusterID, nodePoolID)
+	if err := o.scheduleUpgrade(path, nodePoolUpgradeType, version); err != nil {
+		return fmt.Errorf("couldn't upgrade node pool '%s' of cluster '%s': %v", nodePoolID, clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Scheduled upgrade of node pool '%s' of cluster '%s' to %s", nodePoolID, clusterID, version)
+	return nil
+}
+
+func (o *OCMProvider) scheduleUpgrade(path, upgradeType, version string) error {
+	data, err := json.Marshal(upgradePolicy{
+		UpgradeType:  upgradeType,
+		ScheduleType: manualScheduleType,
+		Version:      strings.TrimPrefix(version, util.VersionPrefix),
+		NextRun:      time.Now().UTC().Add(upgradeLeadTime),
+	})
+	if err != nil {
+		return err
+	}
+
+	return retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Post().Path(path).Bytes(data).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		return checkResponse(resp, http.StatusCreated)
+	})
+}
+
+func (o *OCMProvider) getJSON(path string, into interface{}) error {
+	var body []byte
+	err := retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Get().Path(path).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		if err = checkResponse(resp, http.StatusOK); err != nil {
+			return err
+		}
+		body = resp.Bytes()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, into)
+}
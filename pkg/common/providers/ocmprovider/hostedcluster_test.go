@@ -0,0 +1,97 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func TestHostedCluster(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	policies := map[string]upgradePolicy{}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(clusterPathFmt, "abc"):
+			fmt.Fprint(w, `{"id":"abc","hypershift":{"enabled":true}}`)
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(nodePoolsPathFmt, "abc"):
+			fmt.Fprint(w, `{"items":[{"id":"workers","version":{"id":"openshift-v4.12.3"},"replicas":2,"status":{"current_replicas":1}}]}`)
+		case r.Method == http.MethodPost:
+			var policy upgradePolicy
+			if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+				t.Errorf("failed to decode upgrade policy: %v", err)
+			}
+			policies[r.URL.Path] = policy
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	hosted, err := provider.HostedControlPlane("abc")
+	if err != nil || !hosted {
+		t.Errorf("expected a hosted control plane, got %v, %v", hosted, err)
+	}
+
+	nodePools, err := provider.NodePools("abc")
+	if err != nil {
+		t.Fatalf("failed to list node pools: %v", err)
+	}
+	if len(nodePools) != 1 || nodePools[0].ID != "workers" || nodePools[0].Version != "4.12.3" || nodePools[0].Replicas != 2 || nodePools[0].CurrentReplicas != 1 {
+		t.Errorf("unexpected node pools %+v", nodePools)
+	}
+
+	if err = provider.UpgradeControlPlane("abc", "openshift-v4.12.5"); err != nil {
+		t.Errorf("failed to upgrade control plane: %v", err)
+	}
+	if err = provider.UpgradeNodePool("abc", "workers", "4.12.5"); err != nil {
+		t.Errorf("failed to upgrade node pool: %v", err)
+	}
+
+	for path, upgradeType := range map[string]string{
+		fmt.Sprintf(controlPlaneUpgradePoliciesPathFmt, "abc"):        controlPlaneUpgradeType,
+		fmt.Sprintf(nodePoolUpgradePoliciesPathFmt, "abc", "workers"): nodePoolUpgradeType,
+	} {
+		policy, ok := policies[path]
+		if !ok {
+			t.Errorf("no upgrade policy was created at %s", path)
+			continue
+		}
+		if policy.UpgradeType != upgradeType || policy.Version != "4.12.5" || policy.ScheduleType != manualScheduleType {
+			t.Errorf("unexpected upgrade policy at %s: %+v", path, policy)
+		}
+		if !policy.NextRun.After(time.Now()) {
+			t.Errorf("upgrade at %s should be scheduled in the future, got %v", path, policy.NextRun)
+		}
+	}
+}
+
+func TestUpgradeNodePoolRejected(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	attempts := 0
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"reason":"node pool version can't be newer than the control plane"}`)
+	})
+	defer closeServer()
+
+	if err := provider.UpgradeNodePool("abc", "workers", "4.13.0"); err == nil {
+		t.Errorf("expected a rejected upgrade to return an error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("rejected requests shouldn't be retried, made %d attempts", attempts)
+	}
+}
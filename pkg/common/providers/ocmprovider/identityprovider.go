@@ -0,0 +1,103 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+const (
+	// identityProvidersPathFmt is the path of a cluster's identity providers. The OCM SDK doesn't support the groups
+	// claim of OpenID identity providers yet, so they are created directly.
+	identityProvidersPathFmt = "/api/clusters_mgmt/v1/clusters/%s/identity_providers"
+
+	// identityProviderPathFmt is the path of one of a cluster's identity providers.
+	identityProviderPathFmt = identityProvidersPathFmt + "/%s"
+)
+
+type openIDClaims struct {
+	Email             []string `json:"email,omitempty"`
+	Name              []string `json:"name,omitempty"`
+	PreferredUsername []string `json:"preferred_username,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+}
+
+type openIDIdentityProvider struct {
+	ClientID     string       `json:"client_id"`
+	ClientSecret string       `json:"client_secret"`
+	Issuer       string       `json:"issuer"`
+	ExtraScopes  []string     `json:"extra_scopes,omitempty"`
+	Claims       openIDClaims `json:"claims"`
+}
+
+type identityProvider struct {
+	ID            string                  `json:"id,omitempty"`
+	Type          string                  `json:"type"`
+	Name          string                  `json:"name"`
+	MappingMethod string                  `json:"mapping_method"`
+	OpenID        *openIDIdentityProvider `json:"open_id,omitempty"`
+}
+
+// AddOpenIDIdentityProvider adds an OpenID Connect identity provider to a cluster. Users are mapped by their claims
+// and the groups in the configured claim are synced to OpenShift groups.
+func (o *OCMProvider) AddOpenIDIdentityProvider(clusterID string, idp spi.OpenIDIdentityProvider) (string, error) {
+	data, err := json.Marshal(identityProvider{
+		Type:          "OpenIDIdentityProvider",
+		Name:          idp.Name,
+		MappingMethod: "claim",
+		OpenID: &openIDIdentityProvider{
+			ClientID:     idp.ClientID,
+			ClientSecret: idp.ClientSecret,
+			Issuer:       idp.Issuer,
+			ExtraScopes:  idp.ExtraScopes,
+			Claims: openIDClaims{
+				Email:             []string{"email"},
+				Name:              []string{"name"},
+				PreferredUsername: []string{"preferred_username"},
+				Groups:            []string{idp.GroupsClaim},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var created identityProvider
+	err = retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Post().Path(fmt.Sprintf(identityProvidersPathFmt, clusterID)).Bytes(data).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		if err = checkResponse(resp, http.StatusCreated); err != nil {
+			return err
+		}
+		return json.Unmarshal(resp.Bytes(), &created)
+	})
+	if err != nil {
+		return "", fmt.Errorf("couldn't add identity provider '%s' to cluster '%s': %v", idp.Name, clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Added OpenID identity provider '%s' (%s) to cluster '%s'", idp.Name, created.ID, clusterID)
+	return created.ID, nil
+}
+
+// DeleteIdentityProvider removes an identity provider from a cluster.
+func (o *OCMProvider) DeleteIdentityProvider(clusterID, idpID string) error {
+	err := retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Delete().Path(fmt.Sprintf(identityProviderPathFmt, clusterID, idpID)).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		return checkResponse(resp, http.StatusNoContent)
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't delete identity provider '%s' from cluster '%s': %v", idpID, clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Deleted identity provider '%s' from cluster '%s'", idpID, clusterID)
+	return nil
+}
@@ -0,0 +1,71 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestIdentityProviders(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	var created identityProvider
+	deleted := ""
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == fmt.Sprintf(identityProvidersPathFmt, "abc"):
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("failed to decode identity provider: %v", err)
+			}
+			created.ID = "idp-1"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(created)
+		case r.Method == http.MethodDelete && r.URL.Path == fmt.Sprintf(identityProviderPathFmt, "abc", "idp-1"):
+			deleted = "idp-1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	id, err := provider.AddOpenIDIdentityProvider("abc", spi.OpenIDIdentityProvider{
+		Name:         "external",
+		Issuer:       "https://idp.example.com",
+		ClientID:     "osde2e",
+		ClientSecret: "secret",
+		GroupsClaim:  "roles",
+		ExtraScopes:  []string{"groups"},
+	})
+	if err != nil {
+		t.Fatalf("failed to add identity provider: %v", err)
+	}
+	if id != "idp-1" {
+		t.Errorf("expected identity provider idp-1, got %s", id)
+	}
+
+	if created.Type != "OpenIDIdentityProvider" || created.MappingMethod != "claim" || created.OpenID == nil {
+		t.Fatalf("unexpected identity provider %+v", created)
+	}
+	if created.OpenID.Issuer != "https://idp.example.com" || created.OpenID.ClientID != "osde2e" {
+		t.Errorf("unexpected OpenID settings %+v", *created.OpenID)
+	}
+	if len(created.OpenID.Claims.Groups) != 1 || created.OpenID.Claims.Groups[0] != "roles" {
+		t.Errorf("expected the groups claim roles, got %v", created.OpenID.Claims.Groups)
+	}
+
+	if err = provider.DeleteIdentityProvider("abc", id); err != nil || deleted != "idp-1" {
+		t.Errorf("expected identity provider to be deleted: %v", err)
+	}
+
+	if err = provider.DeleteIdentityProvider("abc", "missing"); err == nil {
+		t.Errorf("expected deleting a missing identity provider to fail")
+	}
+}
@@ -0,0 +1,212 @@
+package ocmprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ocm "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// logLevel is the severity of a message logged by the OCM SDK.
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+	levelOff
+)
+
+var logLevelNames = map[string]logLevel{
+	"debug": levelDebug,
+	"info":  levelInfo,
+	"warn":  levelWarn,
+	"error": levelError,
+	"off":   levelOff,
+}
+
+func (l logLevel) String() string {
+	for name, level := range logLevelNames {
+		if level == l {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+const (
+	// httpSubsystem logs the requests and responses dumped by the SDK.
+	httpSubsystem = "http"
+
+	// authSubsystem logs token requests and expiry.
+	authSubsystem = "auth"
+
+	// connectionSubsystem logs everything else, such as how the connection was built.
+	connectionSubsystem = "connection"
+)
+
+// redactedPatterns match credentials the SDK doesn't redact itself.
+var redactedPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`),
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+}
+
+// sdkLogger sends the OCM SDK's logs to the osde2e log with per-subsystem levels and redaction.
+type sdkLogger struct {
+	defaultLevel logLevel
+	levels       map[string]logLevel
+	secrets      []string
+}
+
+var _ ocm.Logger = &sdkLogger{}
+
+// newSDKLogger creates a logger with the levels configured in Options that redacts the token. Debug enables every subsystem.
+func newSDKLogger(token string, debug bool) (*sdkLogger, error) {
+	defaultLevel, levels, err := parseLogLevels(Options.LogLevel, Options.LogLevels)
+	if err != nil {
+		return nil, err
+	}
+
+	if debug {
+		defaultLevel = levelDebug
+	}
+
+	var secrets []string
+	if token != "" {
+		secrets = append(secrets, token)
+	}
+
+	return &sdkLogger{
+		defaultLevel: defaultLevel,
+		levels:       levels,
+		secrets:      secrets,
+	}, nil
+}
+
+// parseLogLevels parses the default level and a list of overrides in the form subsystem=level.
+// Warnings and errors are logged if no level is set.
+func parseLogLevels(defaultName string, overrides []string) (logLevel, map[string]logLevel, error) {
+	if defaultName == "" {
+		defaultName = "warn"
+	}
+
+	defaultLevel, ok := logLevelNames[strings.ToLower(defaultName)]
+	if !ok {
+		return 0, nil, fmt.Errorf("unknown OCM log level %q", defaultName)
+	}
+
+	levels := map[string]logLevel{}
+	for _, override := range overrides {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 {
+			return 0, nil, fmt.Errorf("OCM log level %q must be in the form subsystem=level", override)
+		}
+
+		subsystem := strings.TrimSpace(parts[0])
+		if subsystem != httpSubsystem && subsystem != authSubsystem && subsystem != connectionSubsystem {
+			return 0, nil, fmt.Errorf("unknown OCM log subsystem %q", subsystem)
+		}
+
+		level, ok := logLevelNames[strings.ToLower(strings.TrimSpace(parts[1]))]
+		if !ok {
+			return 0, nil, fmt.Errorf("unknown OCM log level %q for %s", parts[1], subsystem)
+		}
+		levels[subsystem] = level
+	}
+	return defaultLevel, levels, nil
+}
+
+// level returns the minimum level logged for a subsystem.
+func (l *sdkLogger) level(subsystem string) logLevel {
+	if level, ok := l.levels[subsystem]; ok {
+		return level
+	}
+	return l.defaultLevel
+}
+
+// enabled returns true if any subsystem logs messages at the level. The SDK only checks this
+// once, for example to decide whether to dump requests, so filtering happens as messages are logged.
+func (l *sdkLogger) enabled(level logLevel) bool {
+	for _, subsystem := range []string{httpSubsystem, authSubsystem, connectionSubsystem} {
+		if l.level(subsystem) <= level {
+			return true
+		}
+	}
+	return false
+}
+
+// DebugEnabled returns true if debug messages are logged for any subsystem.
+func (l *sdkLogger) DebugEnabled() bool { return l.enabled(levelDebug) }
+
+// InfoEnabled returns true if information messages are logged for any subsystem.
+func (l *sdkLogger) InfoEnabled() bool { return l.enabled(levelInfo) }
+
+// WarnEnabled returns true if warnings are logged for any subsystem.
+func (l *sdkLogger) WarnEnabled() bool { return l.enabled(levelWarn) }
+
+// ErrorEnabled returns true if errors are logged for any subsystem.
+func (l *sdkLogger) ErrorEnabled() bool { return l.enabled(levelError) }
+
+// Debug logs a debug message.
+func (l *sdkLogger) Debug(ctx context.Context, format string, args ...interface{}) {
+	l.log(levelDebug, format, args...)
+}
+
+// Info logs an information message.
+func (l *sdkLogger) Info(ctx context.Context, format string, args ...interface{}) {
+	l.log(levelInfo, format, args...)
+}
+
+// Warn logs a warning.
+func (l *sdkLogger) Warn(ctx context.Context, format string, args ...interface{}) {
+	l.log(levelWarn, format, args...)
+}
+
+// Error logs an error.
+func (l *sdkLogger) Error(ctx context.Context, format string, args ...interface{}) {
+	l.log(levelError, format, args...)
+}
+
+func (l *sdkLogger) log(level logLevel, format string, args ...interface{}) {
+	subsystem := subsystemOf(format)
+	if level < l.level(subsystem) {
+		return
+	}
+	msg := fmt.Sprintf("ocm-sdk %s %s: %s", subsystem, level, l.redact(fmt.Sprintf(format, args...)))
+	switch level {
+	case levelWarn:
+		logging.Warnf("%s", msg)
+	case levelError:
+		logging.Errorf("%s", msg)
+	default:
+		// the OCM log levels already chose which of these are logged, so LOG_LEVEL doesn't filter them again
+		logging.Infof("%s", msg)
+	}
+}
+
+// redact removes credentials from a message.
+func (l *sdkLogger) redact(msg string) string {
+	for _, secret := range l.secrets {
+		msg = strings.Replace(msg, secret, "***", -1)
+	}
+	msg = redactedPatterns[0].ReplaceAllString(msg, "${1}***")
+	return redactedPatterns[1].ReplaceAllString(msg, "***")
+}
+
+// subsystemOf classifies a message by its format. The SDK doesn't say where its messages come from.
+func subsystemOf(format string) string {
+	switch {
+	case strings.HasPrefix(format, "Request ") || strings.HasPrefix(format, "Response ") || format == "%s":
+		// dumped bodies are logged with a bare %s
+		return httpSubsystem
+	case strings.Contains(strings.ToLower(format), "token"):
+		return authSubsystem
+	default:
+		return connectionSubsystem
+	}
+}
@@ -0,0 +1,73 @@
+package ocmprovider
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+func TestSDKLoggerLevels(t *testing.T) {
+	defer func(level string, levels []string) { Options.LogLevel, Options.LogLevels = level, levels }(Options.LogLevel, Options.LogLevels)
+	Options.LogLevel, Options.LogLevels = "warn", []string{"http=debug"}
+
+	logger, err := newSDKLogger("", false)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	if !logger.DebugEnabled() {
+		t.Errorf("expected debug to be enabled when a subsystem logs debug messages")
+	}
+
+	output := captureLog(func() {
+		logger.Debug(context.TODO(), "Request URL is '%s'", "https://api.openshift.com/api/clusters_mgmt/v1/clusters")
+		logger.Debug(context.TODO(), "%s token expires in %s", "Access", "5m")
+		logger.Warn(nil, "Connection uses a deprecated option")
+	})
+
+	if !strings.Contains(output, "ocm-sdk http debug: Request URL is") {
+		t.Errorf("expected http debug messages to be logged, got %q", output)
+	}
+
+	if strings.Contains(output, "token expires") {
+		t.Errorf("expected auth debug messages to be filtered, got %q", output)
+	}
+
+	if !strings.Contains(output, "ocm-sdk connection warn: Connection uses a deprecated option") {
+		t.Errorf("expected warnings to be logged, got %q", output)
+	}
+}
+
+func TestSDKLoggerRedacts(t *testing.T) {
+	defer func(level string) { Options.LogLevel = level }(Options.LogLevel)
+	Options.LogLevel = "debug"
+
+	logger, err := newSDKLogger("offline-token", false)
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	output := captureLog(func() {
+		logger.Debug(nil, "%s", `{"token":"offline-token","header":"Bearer abc.def-ghi","jwt":"eyJhbGciOiJub25lIn0.eyJ0eXAiOiJCZWFyZXIifQ."}`)
+	})
+
+	for _, secret := range []string{"offline-token", "abc.def-ghi", "eyJhbGci"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("expected %s to be redacted, got %q", secret, output)
+		}
+	}
+}
+
+// captureLog returns what fn logs.
+func captureLog(fn func()) string {
+	buf := &bytes.Buffer{}
+	logging.SetOutput(buf)
+	defer logging.SetOutput(os.Stderr)
+
+	fn()
+	return buf.String()
+}
@@ -1,6 +1,7 @@
 package ocmprovider
 
 import (
+	"context"
 	"fmt"
 	"math"
 
@@ -18,20 +19,20 @@ func (o *OCMProvider) Logs(clusterID string) (logs map[string][]byte, err error)
 	for _, logID := range ids {
 		var resp *v1.LogGetResponse
 
-		retryer().Do(func() error {
+		err = retryWithContext(func(ctx context.Context) error {
 			var err error
 			resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 				Logs().
 				Log(logID).
 				Get().Parameter("tail", math.MaxInt32-1).
-				Send()
+				SendContext(ctx)
 
 			if err != nil {
 				return err
 			}
 
 			if resp != nil && resp.Error() != nil {
-				return errResp(resp.Error())
+				return errResp(resp.Status(), resp.Error())
 			}
 
 			return nil
@@ -48,19 +49,19 @@ func (o *OCMProvider) Logs(clusterID string) (logs map[string][]byte, err error)
 func (o *OCMProvider) getLogList(clusterID string) ([]string, error) {
 	var resp *v1.LogsListResponse
 
-	err := retryer().Do(func() error {
+	err := retryWithContext(func(ctx context.Context) error {
 		var err error
 		resp, err = o.conn.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 			Logs().
 			List().
-			Send()
+			SendContext(ctx)
 
 		if err != nil {
 			return err
 		}
 
 		if resp != nil && resp.Error() != nil {
-			return errResp(resp.Error())
+			return errResp(resp.Status(), resp.Error())
 		}
 
 		return nil
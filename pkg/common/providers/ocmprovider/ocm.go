@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/triage"
 
 	ocm "github.com/openshift-online/ocm-sdk-go"
 	ocmerr "github.com/openshift-online/ocm-sdk-go/errors"
@@ -59,9 +60,7 @@ func OCMConnection(token, env string, debug bool) (*ocm.Connection, error) {
 		return connection, nil
 	}
 
-	logger, err := ocm.NewGoLoggerBuilder().
-		Debug(debug).
-		Build()
+	logger, err := newSDKLogger(token, debug)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't build logger: %v", err)
 	}
@@ -74,6 +73,7 @@ func OCMConnection(token, env string, debug bool) (*ocm.Connection, error) {
 		TokenURL(TokenURL).
 		Client(ClientID, "").
 		Logger(logger).
+		TransportWrapper(newRetryTransport).
 		Tokens(token)
 
 	connection, err := builder.Build()
@@ -142,10 +142,10 @@ func (o *OCMProvider) CincinnatiChannel() spi.CincinnatiChannel {
 	return spi.CincinnatiStableChannel
 }
 
-// ErrResp takes an OCM error and converts it into a regular Golang error.
-func errResp(resp *ocmerr.Error) error {
+// ErrResp takes an OCM error and the status it was returned with and converts it into a regular Golang error.
+func errResp(status int, resp *ocmerr.Error) error {
 	if resp != nil {
-		return fmt.Errorf("api error: %s", resp.Reason())
+		return &triage.OCMError{Status: status, Err: fmt.Errorf("api error: %s", resp.Reason())}
 	}
 	return nil
 }
@@ -1,13 +1,14 @@
 package ocmprovider
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 
 	accounts "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/state"
 )
 
@@ -20,16 +21,16 @@ const (
 func (o *OCMProvider) CheckQuota() (bool, error) {
 	// get flavour being deployed
 	var flavourResp *v1.FlavourGetResponse
-	err := retryer().Do(func() error {
+	err := retryWithContext(func(ctx context.Context) error {
 		var err error
-		flavourResp, err = o.conn.ClustersMgmt().V1().Flavours().Flavour(DefaultFlavour).Get().Send()
+		flavourResp, err = o.conn.ClustersMgmt().V1().Flavours().Flavour(DefaultFlavour).Get().SendContext(ctx)
 
 		if err != nil {
 			return err
 		}
 
 		if flavourResp != nil && flavourResp.Error() != nil {
-			err = errResp(flavourResp.Error())
+			err = errResp(flavourResp.Status(), flavourResp.Error())
 			if err != nil {
 				return err
 			}
@@ -61,7 +62,7 @@ func (o *OCMProvider) CheckQuota() (bool, error) {
 	resourceClusterType := fmt.Sprintf(resourceClusterFmt, state.Instance.CloudProvider.CloudProviderID)
 	for _, q := range quotaList.Slice() {
 		if quotaFound = HasQuotaFor(q, resourceClusterType, machineType); quotaFound {
-			log.Printf("Quota for test config (%s/%s/multiAZ=%t) found: total=%d, remaining: %d",
+			logging.Infof("Quota for test config (%s/%s/multiAZ=%t) found: total=%d, remaining: %d",
 				resourceClusterType, machineType, config.Instance.Cluster.MultiAZ, q.Allowed(), q.Allowed()-q.Reserved())
 			break
 		}
@@ -70,9 +71,40 @@ func (o *OCMProvider) CheckQuota() (bool, error) {
 	return quotaFound, nil
 }
 
+// RemainingQuota returns how many more clusters of the cloud provider and availability zone type the account can
+// create. Clusters use the quota of a single machine type, so this is the most any one of the matching quotas allows.
+func (o *OCMProvider) RemainingQuota(cloudProvider string, multiAZ bool) (int, error) {
+	quotaList, err := o.currentAccountQuota()
+	if err != nil {
+		return 0, fmt.Errorf("could not get quota: %v", err)
+	}
+
+	azType := "single"
+	if multiAZ {
+		azType = "multi"
+	}
+
+	remaining := 0
+	resourceClusterType := fmt.Sprintf(resourceClusterFmt, cloudProvider)
+	for _, q := range quotaList.Slice() {
+		if q.ResourceType() != resourceClusterType || q.AvailabilityZoneType() != azType {
+			continue
+		}
+		if left := q.Allowed() - q.Reserved(); left > remaining {
+			remaining = left
+		}
+	}
+	return remaining, nil
+}
+
 // CurrentAccountQuota returns quota available for the current account's organization in the environment.
 func (o *OCMProvider) currentAccountQuota() (*accounts.QuotaSummaryList, error) {
-	resp, err := o.conn.AccountsMgmt().V1().CurrentAccount().Get().Send()
+	var resp *accounts.CurrentAccountGetResponse
+	err := retryWithContext(func(ctx context.Context) error {
+		var err error
+		resp, err = o.conn.AccountsMgmt().V1().CurrentAccount().Get().SendContext(ctx)
+		return err
+	})
 	if err != nil || resp == nil {
 		return nil, fmt.Errorf("couldn't get current account: %v", err)
 	}
@@ -86,16 +118,16 @@ func (o *OCMProvider) currentAccountQuota() (*accounts.QuotaSummaryList, error)
 	orgID := acc.Organization().ID()
 
 	var quotaList *accounts.QuotaSummaryListResponse
-	err = retryer().Do(func() error {
+	err = retryWithContext(func(ctx context.Context) error {
 		var err error
-		quotaList, err = o.conn.AccountsMgmt().V1().Organizations().Organization(orgID).QuotaSummary().List().Send()
+		quotaList, err = o.conn.AccountsMgmt().V1().Organizations().Organization(orgID).QuotaSummary().List().SendContext(ctx)
 
 		if err != nil {
 			return err
 		}
 
 		if quotaList != nil && quotaList.Error() != nil {
-			return errResp(quotaList.Error())
+			return errResp(quotaList.Status(), quotaList.Error())
 		}
 
 		return nil
@@ -0,0 +1,53 @@
+package ocmprovider
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func TestRemainingQuota(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/accounts_mgmt/v1/current_account":
+			fmt.Fprint(w, `{"id":"account","organization":{"id":"org"}}`)
+		case "/api/accounts_mgmt/v1/organizations/org/quota_summary":
+			fmt.Fprint(w, `{"items":[
+				{"resource_type":"cluster.aws","resource_name":"m5.xlarge","availability_zone_type":"single","allowed":10,"reserved":7},
+				{"resource_type":"cluster.aws","resource_name":"r5.xlarge","availability_zone_type":"single","allowed":2,"reserved":0},
+				{"resource_type":"cluster.aws","resource_name":"m5.xlarge","availability_zone_type":"multi","allowed":1,"reserved":1},
+				{"resource_type":"cluster.gcp","resource_name":"custom-4-16384","availability_zone_type":"single","allowed":5,"reserved":0}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	tests := []struct {
+		cloudProvider string
+		multiAZ       bool
+		expected      int
+	}{
+		{"aws", false, 3},
+		{"aws", true, 0},
+		{"gcp", false, 5},
+		{"gcp", true, 0},
+	}
+	for _, test := range tests {
+		remaining, err := provider.RemainingQuota(test.cloudProvider, test.multiAZ)
+		if err != nil {
+			t.Fatalf("failed to get remaining quota: %v", err)
+		}
+		if remaining != test.expected {
+			t.Errorf("expected %d %s clusters (multiAZ=%t) left, got %d", test.expected, test.cloudProvider, test.multiAZ, remaining)
+		}
+	}
+}
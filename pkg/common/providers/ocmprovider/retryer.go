@@ -1,28 +1,55 @@
 package ocmprovider
 
 import (
-	"log"
-	"sync"
+	"context"
+	"fmt"
 	"time"
 
-	"github.com/adamliesko/retry"
-	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/phase"
 )
 
-var ocmOnce = sync.Once{}
-var ocmRetryer *retry.Retryer
-
-// Retryer returns a retryer meant for OCM interactions.
-func retryer() *retry.Retryer {
-	ocmOnce.Do(func() {
-		ocmRetryer = retry.New(retry.SleepFn(func(attempts int) {
-			time.Sleep(time.Duration(2^attempts) * time.Second)
-		}))
-		ocmRetryer.Tries = config.Instance.OCM.NumRetries
-		ocmRetryer.AfterEachFailFn = func(err error) {
-			log.Printf("error during OCM attempt: %v", err)
+// ocmBackoff is the retry policy for OCM interactions. The number of attempts comes from the provider config.
+var ocmBackoff = backoff.Exponential(2*time.Second, time.Minute)
+
+// retryer returns the retry policy for OCM interactions.
+func retryer() backoff.Backoff {
+	policy := ocmBackoff
+	policy.MaxAttempts = Options.NumRetries
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		logging.Warnf("error during OCM attempt %d, retrying in %s: %v", attempt, delay, err)
+	}
+	return policy
+}
+
+// retryWithContext runs an OCM request using the retry policy. Each attempt gets a context bounded by the
+// OCM request timeout and the current phase deadline. No further attempts are made once the phase deadline passes
+// or the run is aborted, or once the transport already retried a request or declined to because it may have been
+// processed.
+func retryWithContext(fn func(ctx context.Context) error) error {
+	phaseCtx, cancel := phase.Context()
+	defer cancel()
+
+	err := retryer().Retry(phaseCtx, func(ctx context.Context) error {
+		ctx, cancelAttempt := context.WithTimeout(ctx, time.Duration(Options.RequestTimeout)*time.Second)
+		defer cancelAttempt()
+
+		ctx, attempt := withCallAttempt(ctx)
+		if err := fn(ctx); err != nil {
+			if attempt.isFinal() {
+				return backoff.Permanent(err)
+			}
+			return err
 		}
+		return nil
 	})
 
-	return ocmRetryer
+	if err != nil && phaseCtx.Err() != nil {
+		if reason := phase.Aborted(); reason != nil {
+			return fmt.Errorf("run was aborted before OCM request could complete (%v): %w", reason, err)
+		}
+		return fmt.Errorf("phase deadline passed before OCM request could complete: %w", err)
+	}
+	return err
 }
@@ -1,8 +1,15 @@
 package ocmprovider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/triage"
 )
 
 func TestRetryer(t *testing.T) {
@@ -45,13 +52,19 @@ func TestRetryer(t *testing.T) {
 		},
 	}
 
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries = 3
+
 	for _, test := range tests {
-		retryer := retryer()
-		retryer.Tries = 3
-		err := retryer.Do(test.Function)
+		attempts := 0
+		err := retryWithContext(func(ctx context.Context) error {
+			attempts++
+			return test.Function()
+		})
 
-		if test.Attempts != retryer.Attempts() {
-			t.Fatalf("Test %s: number of expected attempts did not match: expected %d, got %d", test.Name, test.Attempts, retryer.Attempts())
+		if test.Attempts != attempts {
+			t.Fatalf("Test %s: number of expected attempts did not match: expected %d, got %d", test.Name, test.Attempts, attempts)
 		}
 
 		if (err == nil) != test.Success {
@@ -63,3 +76,57 @@ func TestRetryer(t *testing.T) {
 		}
 	}
 }
+
+func TestRetryWithContext(t *testing.T) {
+	Options.RequestTimeout = 30
+	Options.NumRetries = 3
+
+	// each attempt should be bounded by the request timeout
+	err := retryWithContext(func(ctx context.Context) error {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 30*time.Second {
+			return fmt.Errorf("expected attempt to have a deadline within the request timeout")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("request should have succeeded: %v", err)
+	}
+
+	// no attempts should be made once the phase deadline has passed
+	phase.SetDeadline(time.Now().Add(-time.Minute))
+	defer phase.SetDeadline(time.Time{})
+
+	called := false
+	err = retryWithContext(func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if err == nil {
+		t.Errorf("expected an error once the phase deadline passed")
+	}
+	if called {
+		t.Errorf("request should not be attempted after the phase deadline")
+	}
+}
+
+func TestRetryWithContextKeepsOCMError(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Minute, time.Minute)
+	Options.RequestTimeout = 30
+	Options.NumRetries = 3
+
+	// the phase deadline passes while waiting to retry a request OCM was unavailable for
+	phase.SetDeadline(time.Now().Add(50 * time.Millisecond))
+	defer phase.SetDeadline(time.Time{})
+
+	err := retryWithContext(func(ctx context.Context) error {
+		return &triage.OCMError{Status: 503, Err: errors.New("api error: unavailable")}
+	})
+	if err == nil {
+		t.Fatalf("expected an error once the phase deadline passed")
+	}
+
+	if classification := triage.ClassifyError(err, nil); classification.Rule != "ocm-unavailable" {
+		t.Errorf("expected %q to be classified as ocm-unavailable, got %v", err, classification)
+	}
+}
@@ -0,0 +1,236 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// The SDK compatibility tests check the provider against recorded OCM API requests and responses, so that an SDK
+// bump changing how the models are written or read fails here rather than silently in a run. They're run by
+// scripts/bump-ocm-sdk.sh.
+
+// sdkCompatFixture reads a recorded API document from testdata/sdk-compat.
+func sdkCompatFixture(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "sdk-compat", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return data
+}
+
+// expectSDKCompatRequest compares a request body written by the SDK with a recorded one.
+func expectSDKCompatRequest(t *testing.T, name string, body map[string]interface{}) {
+	var expected map[string]interface{}
+	if err := json.Unmarshal(sdkCompatFixture(t, name), &expected); err != nil {
+		t.Fatalf("failed to parse fixture %s: %v", name, err)
+	}
+
+	if !reflect.DeepEqual(body, expected) {
+		actual, _ := json.MarshalIndent(body, "", "  ")
+		t.Errorf("the SDK no longer writes the request recorded in %s, got:\n%s", name, actual)
+	}
+}
+
+func useSDKCompatBackoff() func() {
+	policy, numRetries, requestTimeout := ocmBackoff, Options.NumRetries, Options.RequestTimeout
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+	return func() {
+		ocmBackoff, Options.NumRetries, Options.RequestTimeout = policy, numRetries, requestTimeout
+	}
+}
+
+func TestSDKCompatGetCluster(t *testing.T) {
+	defer useSDKCompatBackoff()()
+
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/clusters_mgmt/v1/clusters/1a2b3c":
+			w.Write(sdkCompatFixture(t, "cluster.json"))
+		case "/api/clusters_mgmt/v1/clusters/1a2b3c/addons":
+			w.Write(sdkCompatFixture(t, "addon_installations.json"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	cluster, err := provider.GetCluster("1a2b3c")
+	if err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+
+	tests := map[string]struct {
+		actual, expected interface{}
+	}{
+		"ID":             {cluster.ID(), "1a2b3c"},
+		"name":           {cluster.Name(), "osde2e-abcde"},
+		"region":         {cluster.Region(), "us-east-1"},
+		"flavour":        {cluster.Flavour(), "osd-4"},
+		"version":        {cluster.Version(), "openshift-v4.5.1"},
+		"cloud provider": {cluster.CloudProvider(), "aws"},
+		"state":          {cluster.State(), spi.ClusterStateReady},
+		"console URL":    {cluster.ConsoleURL(), "https://console-openshift-console.apps.osde2e-abcde.example.com"},
+		"API URL":        {cluster.APIURL(), "https://api.osde2e-abcde.example.com:6443"},
+		"compute nodes":  {cluster.ComputeNodes(), 4},
+		"addons":         {cluster.Addons(), []string{"dbaas-operator"}},
+	}
+	for field, test := range tests {
+		if !reflect.DeepEqual(test.actual, test.expected) {
+			t.Errorf("expected the cluster's %s to be read as %v, got %v", field, test.expected, test.actual)
+		}
+	}
+}
+
+func TestSDKCompatLaunchCluster(t *testing.T) {
+	defer useSDKCompatBackoff()()
+
+	defer func(jobID int, multiAZ bool) {
+		config.Instance.JobID, config.Instance.Cluster.MultiAZ = jobID, multiAZ
+	}(config.Instance.JobID, config.Instance.Cluster.MultiAZ)
+	config.Instance.JobID, config.Instance.Cluster.MultiAZ = 1, true
+
+	defer func(version, provider, region string) {
+		state.Instance.Cluster.Version, state.Instance.CloudProvider.CloudProviderID, state.Instance.CloudProvider.Region = version, provider, region
+	}(state.Instance.Cluster.Version, state.Instance.CloudProvider.CloudProviderID, state.Instance.CloudProvider.Region)
+	state.Instance.Cluster.Version, state.Instance.CloudProvider.CloudProviderID, state.Instance.CloudProvider.Region = "openshift-v4.5.1", "aws", "us-east-1"
+
+	var created map[string]interface{}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/clusters_mgmt/v1/clusters" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &created); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write(sdkCompatFixture(t, "cluster.json"))
+	})
+	defer closeServer()
+
+	id, err := provider.launchCluster("osde2e-abcde", "")
+	if err != nil {
+		t.Fatalf("failed to launch cluster: %v", err)
+	}
+	if id != "1a2b3c" {
+		t.Errorf("expected the created cluster's ID to be read, got %s", id)
+	}
+
+	// the expiration depends on when the cluster is launched, so only its format is checked
+	expiration, _ := created["expiration_timestamp"].(string)
+	if _, err := time.Parse(time.RFC3339, expiration); err != nil {
+		t.Errorf("expected an RFC 3339 expiration timestamp, got %v", created["expiration_timestamp"])
+	}
+	delete(created, "expiration_timestamp")
+
+	expectSDKCompatRequest(t, "cluster_request.json", created)
+}
+
+func TestSDKCompatInstallAddons(t *testing.T) {
+	defer useSDKCompatBackoff()()
+
+	var installed map[string]interface{}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/clusters_mgmt/v1/addons/prow-operator":
+			w.Write(sdkCompatFixture(t, "addon.json"))
+		case r.URL.Path == "/api/clusters_mgmt/v1/clusters/1a2b3c":
+			w.Write(sdkCompatFixture(t, "cluster.json"))
+		case r.URL.Path == "/api/clusters_mgmt/v1/clusters/1a2b3c/addons" && r.Method == http.MethodGet:
+			w.Write(sdkCompatFixture(t, "addon_installations.json"))
+		case r.URL.Path == "/api/clusters_mgmt/v1/clusters/1a2b3c/addons" && r.Method == http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &installed); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	num, err := provider.InstallAddons("1a2b3c", []string{"prow-operator"})
+	if err != nil {
+		t.Fatalf("failed to install addons: %v", err)
+	}
+	if num != 1 {
+		t.Errorf("expected 1 addon to be installed, got %d", num)
+	}
+
+	expectSDKCompatRequest(t, "addon_installation_request.json", installed)
+}
+
+func TestSDKCompatLaunchClusterFromPayload(t *testing.T) {
+	defer useSDKCompatBackoff()()
+
+	defer func(jobID int, multiAZ bool) {
+		config.Instance.JobID, config.Instance.Cluster.MultiAZ = jobID, multiAZ
+	}(config.Instance.JobID, config.Instance.Cluster.MultiAZ)
+	config.Instance.JobID, config.Instance.Cluster.MultiAZ = 1, true
+
+	defer func(version, provider, region string) {
+		state.Instance.Cluster.Version, state.Instance.CloudProvider.CloudProviderID, state.Instance.CloudProvider.Region = version, provider, region
+	}(state.Instance.Cluster.Version, state.Instance.CloudProvider.CloudProviderID, state.Instance.CloudProvider.Region)
+	state.Instance.Cluster.Version, state.Instance.CloudProvider.CloudProviderID, state.Instance.CloudProvider.Region = "openshift-v4.5.1", "aws", "us-east-1"
+
+	var created map[string]interface{}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/clusters_mgmt/v1/clusters" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &created); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write(sdkCompatFixture(t, "cluster.json"))
+	})
+	defer closeServer()
+
+	payload, err := provider.ClusterPayload("osde2e-abcde")
+	if err != nil {
+		t.Fatalf("failed to describe cluster: %v", err)
+	}
+	if strings.Contains(payload, "expiration_timestamp") {
+		t.Errorf("expected the payload to leave the expiration to launch time, got %s", payload)
+	}
+
+	// the config and state a plan was made with are no longer used when it's applied
+	state.Instance.Cluster.Version, state.Instance.CloudProvider.Region = "openshift-v4.6.0", "eu-west-1"
+
+	id, err := provider.LaunchClusterFromPayload(payload)
+	if err != nil {
+		t.Fatalf("failed to launch cluster from payload: %v", err)
+	}
+	if id != "1a2b3c" {
+		t.Errorf("expected the created cluster's ID to be read, got %s", id)
+	}
+
+	if _, ok := created["expiration_timestamp"]; !ok {
+		t.Errorf("expected the launched cluster to expire")
+	}
+	delete(created, "expiration_timestamp")
+
+	expectSDKCompatRequest(t, "cluster_request.json", created)
+}
@@ -0,0 +1,253 @@
+package ocmprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+)
+
+// Retry policies for OCM responses, chosen by status code with OCM_RETRY_POLICIES.
+const (
+	// retryPolicyBackoff retries with exponential backoff and jitter.
+	retryPolicyBackoff = "backoff"
+
+	// retryPolicyRetryAfter retries once the Retry-After header says to, or with backoff if there isn't one.
+	retryPolicyRetryAfter = "retry-after"
+
+	// retryPolicyNone returns the response without retrying.
+	retryPolicyNone = "none"
+
+	// connectionRetry is how retries of requests that got no response are counted.
+	connectionRetry = "connection"
+)
+
+// maxRetryAfter caps how long a Retry-After header can make a request wait.
+const maxRetryAfter = time.Minute
+
+// transportBackoff is the retry policy of single OCM requests. The number of attempts comes from the provider config.
+var transportBackoff = backoff.Exponential(time.Second, 30*time.Second)
+
+var (
+	retriesMutex sync.Mutex
+	retries      = map[string]int{}
+)
+
+// idempotentMethods are the methods whose requests can be sent again after any failure. Other requests, such as the
+// POST creating a cluster, may have been processed by OCM when it responds with an error or the connection fails.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// unprocessedStatuses are the statuses OCM responds with when it rejected a request without processing it, so any
+// request receiving them can be sent again.
+var unprocessedStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+// callAttemptKey is the context key of the callAttempt of an OCM call.
+type callAttemptKey struct{}
+
+// callAttempt records whether an attempt of an OCM call must not be retried as a whole, because the transport already
+// retried its request or the request can't safely be sent again.
+type callAttempt struct {
+	mutex sync.Mutex
+	final bool
+}
+
+// withCallAttempt returns a context for an attempt of an OCM call whose requests can mark the attempt final.
+func withCallAttempt(ctx context.Context) (context.Context, *callAttempt) {
+	attempt := &callAttempt{}
+	return context.WithValue(ctx, callAttemptKey{}, attempt), attempt
+}
+
+// isFinal reports whether the call must not be retried.
+func (a *callAttempt) isFinal() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.final
+}
+
+// markFinal stops the call of the request's context from being retried, if it's an attempt of an OCM call.
+func markFinal(ctx context.Context) {
+	if attempt, ok := ctx.Value(callAttemptKey{}).(*callAttempt); ok {
+		attempt.mutex.Lock()
+		attempt.final = true
+		attempt.mutex.Unlock()
+	}
+}
+
+// retryTransport retries OCM requests that fail with a transient error, such as a 5xx or rate-limited response,
+// beneath the SDK. Retries are counted by status code in the run metadata.
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+// newRetryTransport wraps the transport of an OCM connection.
+func newRetryTransport(next http.RoundTripper) http.RoundTripper {
+	return &retryTransport{next: next}
+}
+
+// RoundTrip sends a request, retrying it according to the policy for the status code of its response. Requests that
+// aren't idempotent are only retried when OCM didn't process them. The last response is returned as is once attempts
+// are used up, so the SDK still sees the error OCM returned.
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	policies, err := parseRetryPolicies(Options.RetryPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	// the body is replayed for every attempt
+	var body []byte
+	if request.Body != nil {
+		body, err = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read request body: %v", err)
+		}
+	}
+
+	policy := transportBackoff
+	policy.MaxAttempts = Options.TransportAttempts
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		logging.Warnf("OCM request %s %s failed on attempt %d, retrying in %s: %v", request.Method, request.URL.Path, attempt, delay, err)
+	}
+
+	idempotent := idempotentMethods[request.Method]
+
+	var response *http.Response
+	attempts := 0
+	err = policy.Retry(request.Context(), func(ctx context.Context) error {
+		attempts++
+		attempt := request.WithContext(ctx)
+		if body != nil {
+			attempt.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		var err error
+		if response, err = t.next.RoundTrip(attempt); err != nil {
+			if ctx.Err() != nil || !idempotent {
+				return backoff.Permanent(err)
+			}
+			countRetry(connectionRetry, attempts, policy.MaxAttempts)
+			return err
+		}
+
+		statusPolicy, ok := policies[response.StatusCode]
+		if !ok || statusPolicy == retryPolicyNone {
+			return nil
+		}
+		if !idempotent && !unprocessedStatuses[response.StatusCode] {
+			markFinal(request.Context())
+			return nil
+		}
+		if attempts >= policy.MaxAttempts {
+			// the call isn't retried on top of the transport's retries
+			if attempts > 1 {
+				markFinal(request.Context())
+			}
+			return nil
+		}
+
+		err = fmt.Errorf("OCM responded %s", response.Status)
+		delay, hasDelay := retryAfter(response)
+		discard(response)
+		countRetry(strconv.Itoa(response.StatusCode), attempts, policy.MaxAttempts)
+
+		if statusPolicy == retryPolicyRetryAfter && hasDelay {
+			return backoff.RetryAfter(err, delay)
+		}
+		return err
+	})
+
+	if err != nil {
+		if !idempotent || attempts > 1 {
+			markFinal(request.Context())
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+// retryAfter reads how long the Retry-After header of a response says to wait, as seconds or a date.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	switch {
+	case delay < 0:
+		delay = 0
+	case delay > maxRetryAfter:
+		delay = maxRetryAfter
+	}
+	return delay, true
+}
+
+// discard drains and closes the body of a response that won't be returned, so its connection can be reused.
+func discard(response *http.Response) {
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+}
+
+// countRetry records a retry in the run metadata, unless the attempt was the last one.
+func countRetry(reason string, attempt, maxAttempts int) {
+	if maxAttempts > 0 && attempt >= maxAttempts {
+		return
+	}
+
+	retriesMutex.Lock()
+	defer retriesMutex.Unlock()
+
+	retries[reason]++
+	counts := make(map[string]int, len(retries))
+	for reason, count := range retries {
+		counts[reason] = count
+	}
+	metadata.Instance.SetOCMRetries(counts)
+}
+
+// parseRetryPolicies maps status codes to the retry policies configured for them.
+func parseRetryPolicies(policies map[string]string) (map[int]string, error) {
+	parsed := make(map[int]string, len(policies))
+	for code, policy := range policies {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 100 || status > 599 {
+			return nil, fmt.Errorf("invalid status code %s in OCM_RETRY_POLICIES", code)
+		}
+
+		switch policy {
+		case retryPolicyBackoff, retryPolicyRetryAfter, retryPolicyNone:
+			parsed[status] = policy
+		default:
+			return nil, fmt.Errorf("invalid retry policy %s for status code %s, expected %s, %s, or %s", policy, code, retryPolicyBackoff, retryPolicyRetryAfter, retryPolicyNone)
+		}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,160 @@
+package ocmprovider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+)
+
+func TestRetryTransport(t *testing.T) {
+	defer func(policy backoff.Backoff) { transportBackoff = policy }(transportBackoff)
+	transportBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+
+	defer func(attempts int, policies map[string]string) {
+		Options.TransportAttempts, Options.RetryPolicies = attempts, policies
+	}(Options.TransportAttempts, Options.RetryPolicies)
+	Options.TransportAttempts = 3
+	Options.RetryPolicies = map[string]string{"429": retryPolicyRetryAfter, "500": retryPolicyBackoff, "503": retryPolicyNone}
+
+	defer func() { retries = map[string]int{} }()
+
+	tests := []struct {
+		description string
+		method      string
+		statuses    []int
+		expected    int
+		attempts    int
+	}{
+		{"success", http.MethodPost, []int{200}, 200, 1},
+		{"rate limited then success", http.MethodPost, []int{429, 429, 201}, 201, 2 + 1},
+		{"attempts used up", http.MethodPut, []int{500, 500, 500, 500}, 500, 3},
+		{"not idempotent", http.MethodPost, []int{500, 201}, 500, 1},
+		{"not retried by policy", http.MethodGet, []int{503, 200}, 503, 1},
+		{"not retried without policy", http.MethodGet, []int{404, 200}, 404, 1},
+	}
+
+	for _, test := range tests {
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+
+			status := test.statuses[len(bodies)-1]
+			if status == 429 {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"kind":"Error"}`))
+		}))
+
+		client := &http.Client{Transport: newRetryTransport(http.DefaultTransport)}
+		request, _ := http.NewRequest(test.method, server.URL, strings.NewReader(`{"name":"osde2e"}`))
+		response, err := client.Do(request)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.description, err)
+			continue
+		}
+
+		body, _ := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != test.expected || string(body) != `{"kind":"Error"}` {
+			t.Errorf("%s: expected the %d response, got %d: %s", test.description, test.expected, response.StatusCode, body)
+		}
+
+		if len(bodies) != test.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", test.description, test.attempts, len(bodies))
+		}
+		for _, body := range bodies {
+			if body != `{"name":"osde2e"}` {
+				t.Errorf("%s: expected every attempt to send the request body, got %q", test.description, body)
+			}
+		}
+	}
+
+	// only the attempts that were retried are counted
+	if retries := metadata.Instance.OCMRetries; retries["429"] != 2 || retries["500"] != 2 || len(retries) != 2 {
+		t.Errorf("expected 2 retries of 429 and 500 responses, got %v", retries)
+	}
+}
+
+func TestRetryTransportCall(t *testing.T) {
+	defer func(transport, call backoff.Backoff) { transportBackoff, ocmBackoff = transport, call }(transportBackoff, ocmBackoff)
+	transportBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	ocmBackoff = transportBackoff
+
+	defer func(attempts, numRetries int, policies map[string]string) {
+		Options.TransportAttempts, Options.NumRetries, Options.RetryPolicies = attempts, numRetries, policies
+	}(Options.TransportAttempts, Options.NumRetries, Options.RetryPolicies)
+	Options.TransportAttempts, Options.NumRetries, Options.RequestTimeout = 2, 3, 30
+	Options.RetryPolicies = map[string]string{"500": retryPolicyBackoff}
+
+	defer func() { retries = map[string]int{} }()
+
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// calls retried by the transport, or whose requests may have been processed, aren't retried again
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport)}
+	for method, expected := range map[string]int{http.MethodGet: 2, http.MethodPost: 1} {
+		sent = 0
+		err := retryWithContext(func(ctx context.Context) error {
+			request, _ := http.NewRequest(method, server.URL, nil)
+			response, err := client.Do(request.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			response.Body.Close()
+			return fmt.Errorf("OCM responded %s", response.Status)
+		})
+		if err == nil || sent != expected {
+			t.Errorf("%s: expected %d requests and an error, got %d: %v", method, expected, sent, err)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"3600", maxRetryAfter, true},
+		{"Mon, 01 Jun 2020 12:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, test := range tests {
+		response := &http.Response{Header: http.Header{}}
+		response.Header.Set("Retry-After", test.value)
+		if delay, ok := retryAfter(response); delay != test.expected || ok != test.ok {
+			t.Errorf("%q: expected %s (%v), got %s (%v)", test.value, test.expected, test.ok, delay, ok)
+		}
+	}
+}
+
+func TestParseRetryPolicies(t *testing.T) {
+	if _, err := parseRetryPolicies(map[string]string{"503": retryPolicyRetryAfter, "500": retryPolicyNone}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := parseRetryPolicies(map[string]string{"5xx": retryPolicyBackoff}); err == nil {
+		t.Errorf("expected an invalid status code to be rejected")
+	}
+	if _, err := parseRetryPolicies(map[string]string{"503": "forever"}); err == nil {
+		t.Errorf("expected an invalid policy to be rejected")
+	}
+}
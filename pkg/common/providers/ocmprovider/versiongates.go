@@ -0,0 +1,116 @@
+package ocmprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+// Paths of version gates and the cluster's agreements to them. They aren't included in the OCM SDK yet.
+const (
+	versionGatesPath      = "/api/clusters_mgmt/v1/version_gates"
+	gateAgreementsPathFmt = "/api/clusters_mgmt/v1/clusters/%s/gate_agreements"
+)
+
+type versionGateList struct {
+	Items []struct {
+		ID                 string `json:"id"`
+		Label              string `json:"label"`
+		Description        string `json:"description"`
+		DocumentationURL   string `json:"documentation_url"`
+		VersionRawIDPrefix string `json:"version_raw_id_prefix"`
+		STSOnly            bool   `json:"sts_only"`
+	} `json:"items"`
+}
+
+type gateAgreementList struct {
+	Items []gateAgreement `json:"items"`
+}
+
+type gateAgreement struct {
+	VersionGate struct {
+		ID string `json:"id"`
+	} `json:"version_gate"`
+}
+
+type stsCluster struct {
+	AWS struct {
+		STS struct {
+			Enabled bool `json:"enabled"`
+		} `json:"sts"`
+	} `json:"aws"`
+}
+
+// UnacknowledgedVersionGates lists the version gates of the version's minor release that the cluster hasn't agreed to.
+func (o *OCMProvider) UnacknowledgedVersionGates(clusterID, version string) ([]spi.VersionGate, error) {
+	var cluster stsCluster
+	if err := o.getJSON(fmt.Sprintf(clusterPathFmt, clusterID), &cluster); err != nil {
+		return nil, fmt.Errorf("couldn't retrieve cluster '%s': %v", clusterID, err)
+	}
+
+	var gates versionGateList
+	if err := o.getJSON(versionGatesPath, &gates); err != nil {
+		return nil, fmt.Errorf("couldn't list version gates: %v", err)
+	}
+
+	var agreements gateAgreementList
+	if err := o.getJSON(fmt.Sprintf(gateAgreementsPathFmt, clusterID), &agreements); err != nil {
+		return nil, fmt.Errorf("couldn't list gate agreements of cluster '%s': %v", clusterID, err)
+	}
+
+	agreed := map[string]bool{}
+	for _, agreement := range agreements.Items {
+		agreed[agreement.VersionGate.ID] = true
+	}
+
+	version = strings.TrimPrefix(version, util.VersionPrefix)
+	var unacknowledged []spi.VersionGate
+	for _, gate := range gates.Items {
+		if agreed[gate.ID] || (gate.STSOnly && !cluster.AWS.STS.Enabled) || !inMinorRelease(version, gate.VersionRawIDPrefix) {
+			continue
+		}
+
+		unacknowledged = append(unacknowledged, spi.VersionGate{
+			ID:               gate.ID,
+			Label:            gate.Label,
+			Description:      gate.Description,
+			DocumentationURL: gate.DocumentationURL,
+		})
+	}
+	return unacknowledged, nil
+}
+
+// AcknowledgeVersionGate agrees to a version gate for the cluster.
+func (o *OCMProvider) AcknowledgeVersionGate(clusterID, gateID string) error {
+	var agreement gateAgreement
+	agreement.VersionGate.ID = gateID
+	data, err := json.Marshal(agreement)
+	if err != nil {
+		return err
+	}
+
+	err = retryWithContext(func(ctx context.Context) error {
+		resp, err := o.conn.Post().Path(fmt.Sprintf(gateAgreementsPathFmt, clusterID)).Bytes(data).SendContext(ctx)
+		if err != nil {
+			return err
+		}
+		return checkResponse(resp, http.StatusCreated)
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't acknowledge version gate '%s' for cluster '%s': %v", gateID, clusterID, err)
+	}
+
+	logging.WithField(logging.ClusterIDField, clusterID).Infof("Acknowledged version gate '%s' for cluster '%s'", gateID, clusterID)
+	return nil
+}
+
+// inMinorRelease returns whether version is in the minor release prefix, such as 4.9.
+func inMinorRelease(version, prefix string) bool {
+	return prefix != "" && (version == prefix || strings.HasPrefix(version, prefix+"."))
+}
@@ -0,0 +1,69 @@
+package ocmprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestVersionGates(t *testing.T) {
+	defer func(policy backoff.Backoff) { ocmBackoff = policy }(ocmBackoff)
+	ocmBackoff = backoff.Exponential(time.Millisecond, 10*time.Millisecond)
+	Options.NumRetries, Options.RequestTimeout = 3, 30
+
+	agreed := []string{"agreed"}
+	provider, closeServer := testProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(clusterPathFmt, "abc"):
+			fmt.Fprint(w, `{"id":"abc","aws":{"sts":{"enabled":false}}}`)
+		case r.Method == http.MethodGet && r.URL.Path == versionGatesPath:
+			fmt.Fprint(w, `{"items":[
+				{"id":"api-removals","label":"api.openshift.com/gate-ocp","description":"APIs are removed","documentation_url":"https://example.com/removals","version_raw_id_prefix":"4.9"},
+				{"id":"sts","label":"api.openshift.com/gate-sts","version_raw_id_prefix":"4.9","sts_only":true},
+				{"id":"agreed","label":"api.openshift.com/gate-ocp","version_raw_id_prefix":"4.9"},
+				{"id":"other-minor","label":"api.openshift.com/gate-ocp","version_raw_id_prefix":"4.10"}
+			]}`)
+		case r.Method == http.MethodGet && r.URL.Path == fmt.Sprintf(gateAgreementsPathFmt, "abc"):
+			var list gateAgreementList
+			for _, id := range agreed {
+				var agreement gateAgreement
+				agreement.VersionGate.ID = id
+				list.Items = append(list.Items, agreement)
+			}
+			json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPost && r.URL.Path == fmt.Sprintf(gateAgreementsPathFmt, "abc"):
+			var agreement gateAgreement
+			if err := json.NewDecoder(r.Body).Decode(&agreement); err != nil {
+				t.Errorf("failed to decode gate agreement: %v", err)
+			}
+			agreed = append(agreed, agreement.VersionGate.ID)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closeServer()
+
+	gates, err := provider.UnacknowledgedVersionGates("abc", "openshift-v4.9.10")
+	if err != nil {
+		t.Fatalf("failed to list version gates: %v", err)
+	}
+	expected := []spi.VersionGate{{ID: "api-removals", Label: "api.openshift.com/gate-ocp", Description: "APIs are removed", DocumentationURL: "https://example.com/removals"}}
+	if !reflect.DeepEqual(gates, expected) {
+		t.Errorf("expected gates %+v, got %+v", expected, gates)
+	}
+
+	if err = provider.AcknowledgeVersionGate("abc", "api-removals"); err != nil {
+		t.Fatalf("failed to acknowledge version gate: %v", err)
+	}
+	if gates, err = provider.UnacknowledgedVersionGates("abc", "4.9.10"); err != nil || len(gates) != 0 {
+		t.Errorf("expected no unacknowledged gates, got %+v: %v", gates, err)
+	}
+}
@@ -1,12 +1,13 @@
 package ocmprovider
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"sort"
 
 	"github.com/Masterminds/semver"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/util"
 )
@@ -31,20 +32,20 @@ func (o *OCMProvider) Versions() (*spi.VersionList, error) {
 		versions := []*spi.Version{}
 
 		page := 1
-		log.Printf("Querying cluster versions endpoint.")
+		logging.Debugf("Querying cluster versions endpoint.")
 		for {
 			var resp *v1.VersionsListResponse
-			err = retryer().Do(func() error {
+			err = retryWithContext(func(ctx context.Context) error {
 				var err error
 
-				resp, err = o.conn.ClustersMgmt().V1().Versions().List().Page(page).Size(PageSize).Send()
+				resp, err = o.conn.ClustersMgmt().V1().Versions().List().Page(page).Size(PageSize).SendContext(ctx)
 
 				if err != nil {
 					return err
 				}
 
 				if resp != nil && resp.Error() != nil {
-					return errResp(resp.Error())
+					return errResp(resp.Status(), resp.Error())
 				}
 
 				return nil
@@ -53,15 +54,15 @@ func (o *OCMProvider) Versions() (*spi.VersionList, error) {
 			if err != nil {
 				err = fmt.Errorf("failed getting list of OSD versions: %v", err)
 			} else if resp != nil {
-				err = errResp(resp.Error())
+				err = errResp(resp.Status(), resp.Error())
 			}
 
 			if err != nil {
-				log.Print("error getting cluster versions from getSemverList.Response")
-				log.Printf("Response Headers: %v", resp.Header())
-				log.Printf("Response Error(s): %v", resp.Error())
-				log.Printf("HTTP Code: %d", resp.Status())
-				log.Printf("Size of response: %d", resp.Size())
+				logging.Errorf("error getting cluster versions from getSemverList.Response")
+				logging.Debugf("Response Headers: %v", resp.Header())
+				logging.Debugf("Response Error(s): %v", resp.Error())
+				logging.Debugf("HTTP Code: %d", resp.Status())
+				logging.Debugf("Size of response: %d", resp.Size())
 
 				err = fmt.Errorf("couldn't retrieve available versions: %v", err)
 				return
@@ -70,7 +71,7 @@ func (o *OCMProvider) Versions() (*spi.VersionList, error) {
 			// parse versions, filter for major+minor nightlies, then sort
 			resp.Items().Each(func(v *v1.Version) bool {
 				if version, err := util.OpenshiftVersionToSemver(v.ID()); err != nil {
-					log.Printf("could not parse version '%s': %v", v.ID(), err)
+					logging.Warnf("could not parse version '%s': %v", v.ID(), err)
 				} else if v.Enabled() {
 					versions = append(versions, spi.NewVersionBuilder().
 						Version(version).
@@ -0,0 +1,46 @@
+package rosaprovider
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/providers/ocmprovider"
+)
+
+// Config contains options only used by the ROSA provider. It is read from the rosa section alongside the global config.
+type Config struct {
+	// CLI is the path of the rosa CLI used to create and delete clusters.
+	CLI string `env:"ROSA_CLI" sect:"rosa" default:"rosa" yaml:"cli"`
+
+	// ComputeMachineType is the instance type of compute nodes. If empty, the CLI's default is used.
+	ComputeMachineType string `env:"ROSA_COMPUTE_MACHINE_TYPE" sect:"rosa" yaml:"computeMachineType"`
+
+	// ComputeNodes is the number of compute nodes. If 0, the CLI's default is used.
+	ComputeNodes int `env:"ROSA_COMPUTE_NODES" sect:"rosa" default:"0" yaml:"computeNodes"`
+}
+
+// Options is the loaded ROSA provider config.
+var Options = new(Config)
+
+func init() {
+	load.RegisterExtension(config.Instance, "rosa", Options)
+}
+
+// Validate checks that the ROSA provider has been configured correctly. Clusters are read through OCM, so the OCM
+// provider's config must be valid too.
+func (c *Config) Validate() error {
+	if err := ocmprovider.Options.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := exec.LookPath(c.CLI); err != nil {
+		return fmt.Errorf("the rosa CLI %s set by ROSA_CLI can't be run: %v", c.CLI, err)
+	}
+
+	if c.ComputeNodes < 0 {
+		return fmt.Errorf("ROSA_COMPUTE_NODES can't be negative, got %d", c.ComputeNodes)
+	}
+	return nil
+}
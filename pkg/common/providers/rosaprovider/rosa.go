@@ -0,0 +1,148 @@
+// Package rosaprovider creates and deletes ROSA clusters with the rosa CLI. ROSA clusters are managed by OCM, so
+// everything else is done through the OCM provider.
+package rosaprovider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/providers/ocmprovider"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// insufficientQuota is how the rosa CLI reports that the AWS account doesn't have enough quota for a cluster.
+const insufficientQuota = "Insufficient AWS quotas"
+
+// ROSAProvider provisions clusters using the rosa CLI.
+type ROSAProvider struct {
+	*ocmprovider.OCMProvider
+
+	cli string
+}
+
+// New logs the rosa CLI into the OCM environment and returns a ROSAProvider.
+func New(token string, env string, debug bool) (*ROSAProvider, error) {
+	ocm, err := ocmprovider.New(token, env, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ROSAProvider{
+		OCMProvider: ocm,
+		cli:         Options.CLI,
+	}
+
+	if err = r.login(token, env); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// login logs the rosa CLI into the OCM environment. The token is passed in the environment rather than as an
+// argument, so it can't be seen in the process list.
+func (r *ROSAProvider) login(token, env string) error {
+	_, err := r.run([]string{"ROSA_TOKEN=" + token}, "login", "--env", ocmprovider.Environments.Choose(env))
+	return err
+}
+
+// LaunchCluster starts creating a cluster with the rosa CLI and returns its ID.
+func (r *ROSAProvider) LaunchCluster() (string, error) {
+	cfg := config.Instance
+	state := state.Instance
+
+	logging.Infof("Creating ROSA cluster '%s'...", state.Cluster.Name)
+
+	args := []string{"create", "cluster",
+		"--cluster-name", state.Cluster.Name,
+		"--region", state.CloudProvider.Region,
+		"--version", strings.TrimPrefix(state.Cluster.Version, ocmprovider.VersionPrefix+"v"),
+	}
+	if cfg.Cluster.MultiAZ {
+		args = append(args, "--multi-az")
+	}
+	if Options.ComputeMachineType != "" {
+		args = append(args, "--compute-machine-type", Options.ComputeMachineType)
+	}
+	if Options.ComputeNodes > 0 {
+		args = append(args, "--compute-nodes", strconv.Itoa(Options.ComputeNodes))
+	}
+
+	if _, err := r.rosa(append(args, "--yes")...); err != nil {
+		return "", fmt.Errorf("couldn't create cluster: %v", err)
+	}
+
+	// the cluster is created asynchronously, so its ID is looked up by name
+	out, err := r.rosa("describe", "cluster", "--cluster", state.Cluster.Name, "--output", "json")
+	if err != nil {
+		return "", fmt.Errorf("couldn't retrieve cluster '%s': %v", state.Cluster.Name, err)
+	}
+
+	var cluster struct {
+		ID string `json:"id"`
+	}
+	if err = json.Unmarshal(out, &cluster); err != nil || cluster.ID == "" {
+		return "", fmt.Errorf("couldn't find the ID of cluster '%s' in %q: %v", state.Cluster.Name, out, err)
+	}
+	return cluster.ID, nil
+}
+
+// DeleteCluster starts deleting a cluster with the rosa CLI.
+func (r *ROSAProvider) DeleteCluster(clusterID string) error {
+	if _, err := r.rosa("delete", "cluster", "--cluster", clusterID, "--yes"); err != nil {
+		return fmt.Errorf("couldn't delete cluster '%s': %v", clusterID, err)
+	}
+	return nil
+}
+
+// CheckQuota returns true if the AWS account has enough quota for a ROSA cluster in the region under test.
+func (r *ROSAProvider) CheckQuota() (bool, error) {
+	_, err := r.rosa("verify", "quota", "--region", state.Instance.CloudProvider.Region)
+	if rosaErr, ok := err.(*rosaError); ok && strings.Contains(rosaErr.stderr, insufficientQuota) {
+		logging.Warnf("Not enough quota for a ROSA cluster: %v", err)
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("couldn't verify quota: %v", err)
+	}
+	return true, nil
+}
+
+// rosaError is returned when the rosa CLI ran but failed.
+type rosaError struct {
+	command string
+	err     error
+	stderr  string
+}
+
+func (e *rosaError) Error() string {
+	return fmt.Sprintf("error running rosa %s: %v: %s", e.command, e.err, e.stderr)
+}
+
+// rosa runs the rosa CLI and returns its output.
+func (r *ROSAProvider) rosa(args ...string) ([]byte, error) {
+	return r.run(nil, args...)
+}
+
+// run runs the rosa CLI with additional environment variables and returns its output. Only the command is included in
+// errors.
+func (r *ROSAProvider) run(env []string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(r.cli, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if _, ok := err.(*exec.ExitError); ok {
+		return nil, &rosaError{command: args[0], err: err, stderr: strings.TrimSpace(stderr.String())}
+	} else if err != nil {
+		return nil, fmt.Errorf("error running rosa %s: %v", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package rosaprovider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// fakeCLI writes a script standing in for the rosa CLI that records its arguments and fails to verify quota with the
+// given error.
+func fakeCLI(t *testing.T, dir, quotaError string) string {
+	script := `#!/bin/sh
+echo "$@" >> "$(dirname "$0")/calls"
+case "$1 $2" in
+"login --env") echo "$ROSA_TOKEN" > "$(dirname "$0")/token" ;;
+"describe cluster") echo '{"id":"abc","name":"'"$4"'"}' ;;
+"verify quota") echo "` + quotaError + `" >&2; exit 1 ;;
+esac
+`
+	cli := filepath.Join(dir, "rosa")
+	if err := ioutil.WriteFile(cli, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake rosa CLI: %v", err)
+	}
+	return cli
+}
+
+func TestROSAProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rosa")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(s state.State, multiAZ bool, opts Config) {
+		*state.Instance, config.Instance.Cluster.MultiAZ, *Options = s, multiAZ, opts
+	}(*state.Instance, config.Instance.Cluster.MultiAZ, *Options)
+	state.Instance.Cluster.Name = "osde2e-abc"
+	state.Instance.Cluster.Version = "openshift-v4.5.1"
+	state.Instance.CloudProvider.Region = "us-east-1"
+	config.Instance.Cluster.MultiAZ = true
+	Options.ComputeNodes = 6
+
+	provider := &ROSAProvider{cli: fakeCLI(t, dir, "ERR: Insufficient AWS quotas")}
+
+	if err := provider.login("secret-token", "stage"); err != nil {
+		t.Errorf("failed to log in: %v", err)
+	}
+
+	if id, err := provider.LaunchCluster(); err != nil || id != "abc" {
+		t.Errorf("expected cluster abc to be launched, got '%s': %v", id, err)
+	}
+
+	if err := provider.DeleteCluster("abc"); err != nil {
+		t.Errorf("failed to delete cluster: %v", err)
+	}
+
+	if hasQuota, err := provider.CheckQuota(); hasQuota || err != nil {
+		t.Errorf("expected insufficient quota without an error, got %t: %v", hasQuota, err)
+	}
+
+	calls, err := ioutil.ReadFile(filepath.Join(dir, "calls"))
+	if err != nil {
+		t.Fatalf("failed to read calls to the fake rosa CLI: %v", err)
+	}
+
+	expected := []string{
+		"login --env https://api.stage.openshift.com",
+		"create cluster --cluster-name osde2e-abc --region us-east-1 --version 4.5.1 --multi-az --compute-nodes 6 --yes",
+		"describe cluster --cluster osde2e-abc --output json",
+		"delete cluster --cluster abc --yes",
+		"verify quota --region us-east-1",
+	}
+	if actual := strings.Split(strings.TrimSpace(string(calls)), "\n"); strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected calls %q, got %q", expected, actual)
+	}
+
+	if token, err := ioutil.ReadFile(filepath.Join(dir, "token")); err != nil || strings.TrimSpace(string(token)) != "secret-token" {
+		t.Errorf("expected the token to be passed in ROSA_TOKEN, got %q: %v", token, err)
+	}
+}
+
+func TestROSAProviderQuotaError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rosa")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	provider := &ROSAProvider{cli: fakeCLI(t, dir, "ERR: Failed to create AWS client: no credentials")}
+	if hasQuota, err := provider.CheckQuota(); hasQuota || err == nil {
+		t.Errorf("expected failures other than insufficient quota to be returned, got %t: %v", hasQuota, err)
+	}
+}
+
+func TestROSAProviderMissingCLI(t *testing.T) {
+	provider := &ROSAProvider{cli: "/nonexistent/rosa"}
+	if _, err := provider.CheckQuota(); err == nil {
+		t.Errorf("expected an error when the rosa CLI can't be run")
+	}
+}
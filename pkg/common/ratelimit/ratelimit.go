@@ -0,0 +1,63 @@
+// Package ratelimit paces how fast load tests create Kubernetes objects, so every run creates the same load and the
+// performance numbers of runs can be compared. It paces the upgrade seed and the workloads created by the helper.
+// OCM calls, the scale suites' external workloads, and image pulls, which are meant to happen at once, aren't paced.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+)
+
+// Limiter is a token bucket that lets objects be created at a steady rate, with bursts of up to its burst size.
+type Limiter struct {
+	limiter flowcontrol.RateLimiter
+
+	mutex   sync.Mutex
+	ops     int
+	started time.Time
+}
+
+// New returns a Limiter allowing opsPerSecond operations. If opsPerSecond is 0, operations aren't limited.
+func New(opsPerSecond, burst int) *Limiter {
+	limiter := flowcontrol.NewFakeAlwaysRateLimiter()
+	if opsPerSecond > 0 {
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = flowcontrol.NewTokenBucketRateLimiter(float32(opsPerSecond), burst)
+	}
+	return &Limiter{limiter: limiter}
+}
+
+// Creations returns a Limiter for creating objects, configured by SCALE_CREATE_RATE and SCALE_CREATE_BURST.
+func Creations() *Limiter {
+	return New(config.Instance.Scale.CreateRate, config.Instance.Scale.CreateBurst)
+}
+
+// Wait blocks until another operation is allowed. It is safe to call from multiple goroutines.
+func (l *Limiter) Wait() {
+	l.limiter.Accept()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.ops == 0 {
+		l.started = time.Now()
+	}
+	l.ops++
+}
+
+// Rate returns the number of operations per second allowed since the first one.
+func (l *Limiter) Rate() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	elapsed := time.Since(l.started).Seconds()
+	if l.ops == 0 || elapsed == 0 {
+		return 0
+	}
+	return float64(l.ops) / elapsed
+}
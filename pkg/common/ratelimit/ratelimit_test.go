@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	tests := []struct {
+		description string
+		rate        int
+		burst       int
+		minDuration time.Duration
+	}{
+		{description: "unlimited"},
+		// the first burst is allowed right away, the remaining 20 operations take 200ms
+		{description: "limited", rate: 100, burst: 5, minDuration: 190 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		limiter := New(test.rate, test.burst)
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for w := 0; w < 5; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 5; i++ {
+					limiter.Wait()
+				}
+			}()
+		}
+		wg.Wait()
+		elapsed := time.Since(start)
+
+		if elapsed < test.minDuration {
+			t.Errorf("%s: expected 25 operations to take at least %v, took %v", test.description, test.minDuration, elapsed)
+		}
+		if test.rate > 0 && (limiter.Rate() > float64(test.rate)*1.5 || limiter.Rate() < float64(test.rate)/2) {
+			t.Errorf("%s: expected a rate near %d/s, got %.1f/s", test.description, test.rate, limiter.Rate())
+		}
+	}
+}
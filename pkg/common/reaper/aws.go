@@ -0,0 +1,47 @@
+package reaper
+
+import (
+	"github.com/openshift/osde2e/pkg/common/aws"
+)
+
+// awsCloud is an AWS account's resources in a region.
+type awsCloud struct {
+	region, idTag, nameTag, namePrefix string
+}
+
+// AWS returns the resources in an AWS region that are tagged with a cluster ID. Only IAM roles and S3 buckets whose
+// name starts with namePrefix are checked.
+func AWS(region, idTag, nameTag, namePrefix string) Cloud {
+	return &awsCloud{region, idTag, nameTag, namePrefix}
+}
+
+func (a *awsCloud) Name() string {
+	return "aws"
+}
+
+func (a *awsCloud) Kinds() []string {
+	return aws.ResourceKinds
+}
+
+func (a *awsCloud) Resources() ([]Resource, error) {
+	tagged, err := aws.TaggedResources(a.region, a.idTag, a.namePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(tagged))
+	for _, r := range tagged {
+		resources = append(resources, Resource{
+			Kind:        r.Kind,
+			ID:          r.ID,
+			ClusterID:   r.Tags[a.idTag],
+			ClusterName: r.Tags[a.nameTag],
+			Created:     r.Created,
+		})
+	}
+	return resources, nil
+}
+
+func (a *awsCloud) Delete(r Resource) error {
+	return aws.DeleteResource(a.region, aws.TaggedResource{Kind: r.Kind, ID: r.ID})
+}
@@ -0,0 +1,122 @@
+package reaper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// Reasons clusters are deleted.
+const (
+	ReapedExpired = "expired"
+	ReapedError   = "in error state"
+)
+
+// ClusterOptions configure which clusters are deleted.
+type ClusterOptions struct {
+	// ErrorAge is how long after it was created a cluster in the error state is deleted. The provider doesn't record
+	// when a cluster entered the error state, and most clusters fail while installing, so its age is used instead.
+	ErrorAge time.Duration
+
+	// DryRun reports what would be deleted without deleting anything.
+	DryRun bool
+}
+
+// ReapedCluster is a cluster that was deleted.
+type ReapedCluster struct {
+	ID     string `yaml:"id"`
+	Name   string `yaml:"name"`
+	State  string `yaml:"state"`
+	Reason string `yaml:"reason"`
+
+	// Error is why the cluster couldn't be deleted.
+	Error string `yaml:"error,omitempty"`
+}
+
+// ClusterReport summarizes a pass over the clusters created by osde2e.
+type ClusterReport struct {
+	DryRun bool `yaml:"dryRun"`
+
+	// Scanned is the number of clusters created by osde2e that were found.
+	Scanned int `yaml:"scanned"`
+
+	// Deleted are the clusters that were deleted, or would have been during a dry run.
+	Deleted []ReapedCluster `yaml:"deleted"`
+
+	Failed []ReapedCluster `yaml:"failed,omitempty"`
+}
+
+// ReapClusters deletes the clusters created by osde2e that are past their expiration, or that have been in the
+// error state for longer than the error age. Clusters already being deleted are left alone. Deletion failures are
+// reported rather than returned.
+func ReapClusters(provider spi.ClusterListProvider, deleteCluster func(clusterID string) error, opts ClusterOptions, now time.Time) (*ClusterReport, error) {
+	clusters, err := provider.OSDe2eClusters()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ID < clusters[j].ID
+	})
+
+	report := &ClusterReport{
+		DryRun:  opts.DryRun,
+		Scanned: len(clusters),
+	}
+
+	for _, cluster := range clusters {
+		var reason string
+		switch {
+		case cluster.State == spi.ClusterStateUninstalling:
+			continue
+		case !cluster.Expires.IsZero() && now.After(cluster.Expires):
+			reason = ReapedExpired
+		case cluster.State == spi.ClusterStateError && !cluster.Created.IsZero() && now.Sub(cluster.Created) > opts.ErrorAge:
+			reason = ReapedError
+		default:
+			continue
+		}
+
+		reaped := ReapedCluster{ID: cluster.ID, Name: cluster.Name, State: string(cluster.State), Reason: reason}
+		if opts.DryRun {
+			logging.WithField(logging.ClusterIDField, cluster.ID).Infof("Would delete cluster %s (%s), which is %s.", cluster.ID, cluster.Name, reason)
+			report.Deleted = append(report.Deleted, reaped)
+			continue
+		}
+
+		logging.WithField(logging.ClusterIDField, cluster.ID).Infof("Deleting cluster %s (%s), which is %s...", cluster.ID, cluster.Name, reason)
+		if err := deleteCluster(cluster.ID); err != nil {
+			logging.WithField(logging.ClusterIDField, cluster.ID).Errorf("Failed to delete cluster %s: %v", cluster.ID, err)
+			reaped.Error = err.Error()
+			report.Failed = append(report.Failed, reaped)
+		} else {
+			report.Deleted = append(report.Deleted, reaped)
+		}
+	}
+	return report, nil
+}
+
+// Summary describes the report in a few lines, for posting to chat.
+func (r *ClusterReport) Summary() string {
+	var b strings.Builder
+
+	verb := "Deleted"
+	if r.DryRun {
+		verb = "Would delete"
+	}
+	fmt.Fprintf(&b, "%s %d of %d osde2e clusters.", verb, len(r.Deleted), r.Scanned)
+	if len(r.Failed) > 0 {
+		fmt.Fprintf(&b, " %d couldn't be deleted.", len(r.Failed))
+	}
+
+	for _, c := range r.Deleted {
+		fmt.Fprintf(&b, "\n• %s (%s): %s", c.Name, c.ID, c.Reason)
+	}
+	for _, c := range r.Failed {
+		fmt.Fprintf(&b, "\n• %s (%s): %s, failed to delete: %s", c.Name, c.ID, c.Reason, c.Error)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package reaper
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// fakeClusters lists a fixed set of clusters.
+type fakeClusters []spi.ClusterSummary
+
+func (f fakeClusters) OSDe2eClusters() ([]spi.ClusterSummary, error) {
+	return f, nil
+}
+
+func TestReapClusters(t *testing.T) {
+	now := time.Date(2020, 6, 2, 12, 0, 0, 0, time.UTC)
+	clusters := fakeClusters{
+		{ID: "expired", Name: "ci-cluster-expired", State: spi.ClusterStateReady, Created: now.Add(-8 * time.Hour), Expires: now.Add(-2 * time.Hour)},
+		{ID: "errored", Name: "ci-cluster-errored", State: spi.ClusterStateError, Created: now.Add(-7 * time.Hour), Expires: now.Add(time.Hour)},
+		{ID: "stuck", Name: "ci-cluster-stuck", State: spi.ClusterStateError, Created: now.Add(-7 * time.Hour)},
+		{ID: "failing", Name: "ci-cluster-failing", State: spi.ClusterStateError, Created: now.Add(-time.Hour)},
+		{ID: "running", Name: "ci-cluster-running", State: spi.ClusterStateReady, Created: now.Add(-time.Hour), Expires: now.Add(5 * time.Hour)},
+		{ID: "deleting", Name: "ci-cluster-deleting", State: spi.ClusterStateUninstalling, Expires: now.Add(-time.Hour)},
+	}
+
+	var deleted []string
+	deleteCluster := func(id string) error {
+		if id == "stuck" {
+			return fmt.Errorf("cluster is protected")
+		}
+		deleted = append(deleted, id)
+		return nil
+	}
+
+	report, err := ReapClusters(clusters, deleteCluster, ClusterOptions{ErrorAge: 6 * time.Hour}, now)
+	if err != nil {
+		t.Fatalf("failed to reap clusters: %v", err)
+	}
+
+	if expected := []string{"errored", "expired"}; !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected %v to be deleted, got %v", expected, deleted)
+	}
+
+	expected := &ClusterReport{
+		Scanned: 6,
+		Deleted: []ReapedCluster{
+			{ID: "errored", Name: "ci-cluster-errored", State: "error", Reason: ReapedError},
+			{ID: "expired", Name: "ci-cluster-expired", State: "ready", Reason: ReapedExpired},
+		},
+		Failed: []ReapedCluster{
+			{ID: "stuck", Name: "ci-cluster-stuck", State: "error", Reason: ReapedError, Error: "cluster is protected"},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("expected %+v, got %+v", expected, report)
+	}
+
+	summary := "Deleted 2 of 6 osde2e clusters. 1 couldn't be deleted.\n" +
+		"• ci-cluster-errored (errored): in error state\n" +
+		"• ci-cluster-expired (expired): expired\n" +
+		"• ci-cluster-stuck (stuck): in error state, failed to delete: cluster is protected"
+	if report.Summary() != summary {
+		t.Errorf("expected summary %q, got %q", summary, report.Summary())
+	}
+
+	deleted = nil
+	if report, err = ReapClusters(clusters, deleteCluster, ClusterOptions{ErrorAge: 6 * time.Hour, DryRun: true}, now); err != nil {
+		t.Fatalf("failed to reap clusters: %v", err)
+	}
+	if len(deleted) != 0 || len(report.Deleted) != 3 {
+		t.Errorf("expected a dry run to only report what would be deleted, got %+v", report)
+	}
+}
@@ -0,0 +1,46 @@
+package reaper
+
+import (
+	"github.com/openshift/osde2e/pkg/common/gcp"
+)
+
+// gcpCloud is a GCP project's resources.
+type gcpCloud struct {
+	project, idLabel, nameLabel string
+}
+
+// GCP returns the resources in a GCP project that are labeled with a cluster ID.
+func GCP(project, idLabel, nameLabel string) Cloud {
+	return &gcpCloud{project, idLabel, nameLabel}
+}
+
+func (g *gcpCloud) Name() string {
+	return "gcp"
+}
+
+func (g *gcpCloud) Kinds() []string {
+	return gcp.ResourceKinds
+}
+
+func (g *gcpCloud) Resources() ([]Resource, error) {
+	labeled, err := gcp.LabeledResources(g.project, g.idLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]Resource, 0, len(labeled))
+	for _, r := range labeled {
+		resources = append(resources, Resource{
+			Kind:        r.Kind,
+			ID:          r.ID,
+			ClusterID:   r.Labels[g.idLabel],
+			ClusterName: r.Labels[g.nameLabel],
+			Created:     r.Created,
+		})
+	}
+	return resources, nil
+}
+
+func (g *gcpCloud) Delete(r Resource) error {
+	return gcp.DeleteResource(g.project, gcp.LabeledResource{Kind: r.Kind, ID: r.ID})
+}
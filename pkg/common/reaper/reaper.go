@@ -0,0 +1,213 @@
+// Package reaper finds cloud resources left behind by clusters that no longer exist and deletes them.
+package reaper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// Reasons clusters' resources are kept.
+const (
+	KeptNoClusterID = "no cluster ID"
+	KeptOtherName   = "not an osde2e cluster"
+	KeptUnknownAge  = "unknown age"
+	KeptYoung       = "younger than the TTL"
+	KeptExists      = "cluster still exists"
+)
+
+// Resource is a cloud resource owned by a cluster.
+type Resource struct {
+	Kind        string    `yaml:"kind"`
+	ID          string    `yaml:"id"`
+	ClusterID   string    `yaml:"clusterID"`
+	ClusterName string    `yaml:"clusterName,omitempty"`
+	Created     time.Time `yaml:"created,omitempty"`
+}
+
+// Cloud is a cloud account resources can be reaped from.
+type Cloud interface {
+	// Name is the name of the cloud provider.
+	Name() string
+
+	// Kinds are the kinds of resources found, in the order they must be deleted.
+	Kinds() []string
+
+	// Resources returns the resources in the account that are owned by a cluster.
+	Resources() ([]Resource, error)
+
+	// Delete deletes a resource.
+	Delete(r Resource) error
+}
+
+// ExistsFunc returns which of the cluster IDs belong to clusters that still exist.
+type ExistsFunc func(ids []string) (map[string]bool, error)
+
+// ExistsInAny checks every environment for the clusters, keyed by environment name, and returns the clusters that
+// exist in any of them. Clusters created in any environment can own resources in the same cloud account, so if any
+// environment can't be checked no cluster is known not to exist and an error is returned.
+func ExistsInAny(envs map[string]ExistsFunc) ExistsFunc {
+	return func(ids []string) (map[string]bool, error) {
+		if len(envs) == 0 {
+			return nil, fmt.Errorf("no environments to check")
+		}
+
+		names := make([]string, 0, len(envs))
+		for name := range envs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		existing := map[string]bool{}
+		for _, name := range names {
+			found, err := envs[name](ids)
+			if err != nil {
+				return nil, fmt.Errorf("error checking environment %s: %v", name, err)
+			}
+			for id, ok := range found {
+				if ok {
+					existing[id] = true
+				}
+			}
+		}
+		return existing, nil
+	}
+}
+
+// Options configure which clusters' resources are reaped.
+type Options struct {
+	// TTL is how long after a cluster's first resource was created its resources can be reaped.
+	TTL time.Duration
+
+	// NamePrefix is the prefix of the names of clusters created by osde2e. Other clusters' resources are kept.
+	NamePrefix string
+
+	// DryRun reports what would be deleted without deleting anything.
+	DryRun bool
+}
+
+// Failure is a resource that couldn't be deleted.
+type Failure struct {
+	Resource Resource `yaml:"resource"`
+	Error    string   `yaml:"error"`
+}
+
+// Report summarizes a reaping.
+type Report struct {
+	Cloud  string `yaml:"cloud"`
+	DryRun bool   `yaml:"dryRun"`
+
+	// Scanned is the number of resources owned by a cluster that were found.
+	Scanned int `yaml:"scanned"`
+
+	// Deleted are the resources that were deleted, or would have been during a dry run.
+	Deleted []Resource `yaml:"deleted"`
+
+	Failed []Failure `yaml:"failed,omitempty"`
+
+	// Kept is the number of clusters whose resources were kept, by reason.
+	Kept map[string]int `yaml:"kept,omitempty"`
+}
+
+// cluster is the resources owned by a cluster.
+type cluster struct {
+	id        string
+	name      string
+	created   time.Time
+	resources []Resource
+}
+
+// Reap deletes the resources of osde2e clusters that are older than the TTL and no longer exist. Resources of different
+// clusters are deleted in kind order, so dependent resources are deleted first. Deletion failures are reported rather
+// than returned.
+func Reap(cloud Cloud, exists ExistsFunc, opts Options, now time.Time) (*Report, error) {
+	resources, err := cloud.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Cloud:   cloud.Name(),
+		DryRun:  opts.DryRun,
+		Scanned: len(resources),
+		Kept:    map[string]int{},
+	}
+
+	clusters := map[string]*cluster{}
+	for _, r := range resources {
+		c, ok := clusters[r.ClusterID]
+		if !ok {
+			c = &cluster{id: r.ClusterID}
+			clusters[r.ClusterID] = c
+		}
+		c.resources = append(c.resources, r)
+		if c.name == "" {
+			c.name = r.ClusterName
+		}
+		if !r.Created.IsZero() && (c.created.IsZero() || r.Created.Before(c.created)) {
+			c.created = r.Created
+		}
+	}
+
+	var candidates []string
+	for id, c := range clusters {
+		switch {
+		case id == "":
+			report.Kept[KeptNoClusterID]++
+		case opts.NamePrefix != "" && !strings.HasPrefix(c.name, opts.NamePrefix):
+			report.Kept[KeptOtherName]++
+		case c.created.IsZero():
+			report.Kept[KeptUnknownAge]++
+		case now.Sub(c.created) < opts.TTL:
+			report.Kept[KeptYoung]++
+		default:
+			candidates = append(candidates, id)
+		}
+	}
+	sort.Strings(candidates)
+
+	if len(candidates) > 0 {
+		existing, err := exists(candidates)
+		if err != nil {
+			return nil, fmt.Errorf("error checking whether clusters still exist: %v", err)
+		}
+
+		reapable := candidates[:0]
+		for _, id := range candidates {
+			if existing[id] {
+				report.Kept[KeptExists]++
+			} else {
+				reapable = append(reapable, id)
+			}
+		}
+		candidates = reapable
+	}
+
+	for _, kind := range cloud.Kinds() {
+		for _, id := range candidates {
+			for _, r := range clusters[id].resources {
+				if r.Kind != kind {
+					continue
+				}
+
+				if opts.DryRun {
+					logging.WithField(logging.ClusterIDField, r.ClusterID).Infof("Would delete %s %s of cluster %s (%s).", r.Kind, r.ID, r.ClusterID, r.ClusterName)
+					report.Deleted = append(report.Deleted, r)
+					continue
+				}
+
+				logging.WithField(logging.ClusterIDField, r.ClusterID).Infof("Deleting %s %s of cluster %s (%s)...", r.Kind, r.ID, r.ClusterID, r.ClusterName)
+				if err := cloud.Delete(r); err != nil {
+					logging.Errorf("Failed to delete %s %s: %v", r.Kind, r.ID, err)
+					report.Failed = append(report.Failed, Failure{Resource: r, Error: err.Error()})
+				} else {
+					report.Deleted = append(report.Deleted, r)
+				}
+			}
+		}
+	}
+	return report, nil
+}
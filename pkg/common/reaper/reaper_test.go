@@ -0,0 +1,125 @@
+package reaper
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeCloud records the resources it's asked to delete.
+type fakeCloud struct {
+	resources []Resource
+	deleted   []string
+}
+
+func (f *fakeCloud) Name() string {
+	return "fake"
+}
+
+func (f *fakeCloud) Kinds() []string {
+	return []string{"lb", "vpc"}
+}
+
+func (f *fakeCloud) Resources() ([]Resource, error) {
+	return f.resources, nil
+}
+
+func (f *fakeCloud) Delete(r Resource) error {
+	if r.ID == "stuck" {
+		return fmt.Errorf("DependencyViolation")
+	}
+	f.deleted = append(f.deleted, r.Kind+" "+r.ID)
+	return nil
+}
+
+func TestReap(t *testing.T) {
+	now := time.Date(2020, 6, 2, 12, 0, 0, 0, time.UTC)
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+
+	newCloud := func() *fakeCloud {
+		return &fakeCloud{resources: []Resource{
+			// leaked by a deleted cluster; the VPC doesn't record when it was created
+			{Kind: "vpc", ID: "vpc-a", ClusterID: "a", ClusterName: "ci-cluster-a"},
+			{Kind: "lb", ID: "lb-a", ClusterID: "a", ClusterName: "ci-cluster-a", Created: old},
+			{Kind: "vpc", ID: "stuck", ClusterID: "b", ClusterName: "ci-cluster-b", Created: old},
+			{Kind: "lb", ID: "lb-b", ClusterID: "b", ClusterName: "ci-cluster-b", Created: old},
+
+			{Kind: "lb", ID: "lb-live", ClusterID: "live", ClusterName: "ci-cluster-live", Created: old},
+			{Kind: "lb", ID: "lb-young", ClusterID: "young", ClusterName: "ci-cluster-young", Created: recent},
+			{Kind: "lb", ID: "lb-prod", ClusterID: "prod", ClusterName: "customer", Created: old},
+			{Kind: "vpc", ID: "vpc-unknown", ClusterID: "unknown", ClusterName: "ci-cluster-unknown"},
+		}}
+	}
+
+	var checked []string
+	exists := func(ids []string) (map[string]bool, error) {
+		checked = ids
+		return map[string]bool{"live": true}, nil
+	}
+	opts := Options{TTL: 24 * time.Hour, NamePrefix: "ci-cluster-"}
+
+	cloud := newCloud()
+	report, err := Reap(cloud, exists, opts, now)
+	if err != nil {
+		t.Fatalf("failed to reap: %v", err)
+	}
+
+	if expected := []string{"a", "b", "live"}; !reflect.DeepEqual(checked, expected) {
+		t.Errorf("expected only old osde2e clusters to be checked, got %v", checked)
+	}
+	if expected := []string{"lb lb-a", "lb lb-b", "vpc vpc-a"}; !reflect.DeepEqual(cloud.deleted, expected) {
+		t.Errorf("expected %v to be deleted in kind order, got %v", expected, cloud.deleted)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Resource.ID != "stuck" || report.Failed[0].Error != "DependencyViolation" {
+		t.Errorf("expected the VPC that couldn't be deleted to be reported, got %+v", report.Failed)
+	}
+
+	expectedKept := map[string]int{KeptExists: 1, KeptYoung: 1, KeptOtherName: 1, KeptUnknownAge: 1}
+	if report.Scanned != 8 || len(report.Deleted) != 3 || !reflect.DeepEqual(report.Kept, expectedKept) {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	opts.DryRun = true
+	cloud = newCloud()
+	if report, err = Reap(cloud, exists, opts, now); err != nil {
+		t.Fatalf("failed to reap: %v", err)
+	}
+	if len(cloud.deleted) != 0 || len(report.Deleted) != 4 || len(report.Failed) != 0 {
+		t.Errorf("expected a dry run to only report what would be deleted, got %+v", report)
+	}
+}
+
+func TestExistsInAny(t *testing.T) {
+	envs := map[string]ExistsFunc{
+		"int": func(ids []string) (map[string]bool, error) {
+			return map[string]bool{"a": true}, nil
+		},
+		"stage": func(ids []string) (map[string]bool, error) {
+			return map[string]bool{"b": true, "c": false}, nil
+		},
+	}
+
+	existing, err := ExistsInAny(envs)([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("failed to check environments: %v", err)
+	}
+	if expected := map[string]bool{"a": true, "b": true}; !reflect.DeepEqual(existing, expected) {
+		t.Errorf("expected clusters in any environment to exist, got %v", existing)
+	}
+
+	envs["prod"] = func(ids []string) (map[string]bool, error) {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	if _, err = ExistsInAny(envs)([]string{"a", "b", "c"}); err == nil {
+		t.Errorf("expected an environment that can't be checked to fail the check")
+	}
+	if _, err = ExistsInAny(nil)([]string{"a"}); err == nil {
+		t.Errorf("expected checking no environments to fail")
+	}
+
+	cloud := &fakeCloud{resources: []Resource{{Kind: "lb", ID: "lb-a", ClusterID: "a", Created: time.Unix(0, 0)}}}
+	if _, err = Reap(cloud, ExistsInAny(envs), Options{}, time.Now()); err == nil || len(cloud.deleted) != 0 {
+		t.Errorf("expected nothing to be deleted when an environment can't be checked, deleted %v", cloud.deleted)
+	}
+}
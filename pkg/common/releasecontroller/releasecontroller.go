@@ -0,0 +1,133 @@
+// Package releasecontroller reports osde2e verdicts to the OpenShift release-controller so they can gate payloads.
+package releasecontroller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+const (
+	// StateSucceeded is the state of a verification whose blocking suites passed.
+	StateSucceeded = "Succeeded"
+
+	// StateFailed is the state of a verification whose blocking suites failed.
+	StateFailed = "Failed"
+
+	// verificationPathFmt is the path verdicts for a release tag are posted to.
+	verificationPathFmt = "/api/v1/releasestream/%s/release/%s/verification"
+
+	requestTimeout = 30 * time.Second
+)
+
+// Verdict is the outcome of an osde2e run for a release.
+type Verdict struct {
+	// Release is the release tag that was tested, such as 4.6.0-0.nightly-2020-06-01-123456.
+	Release string `json:"-"`
+
+	// Verification names the check in the release's acceptance workflow.
+	Verification string `json:"verification"`
+
+	// State is StateSucceeded or StateFailed.
+	State string `json:"state"`
+
+	// URL links to the results of the run.
+	URL string `json:"url,omitempty"`
+
+	// Message describes the verdict.
+	Message string `json:"message,omitempty"`
+}
+
+// Client posts verdicts to a release-controller.
+type Client struct {
+	baseURL string
+	token   string
+	stream  string
+	client  *http.Client
+	retry   backoff.Backoff
+}
+
+// New creates a client for the release-controller at baseURL. If stream is empty, it is derived from each release tag.
+func New(baseURL, token, stream string) *Client {
+	retry := backoff.Exponential(5*time.Second, time.Minute)
+	retry.MaxAttempts = 5
+
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		stream:  stream,
+		client:  &http.Client{Timeout: requestTimeout},
+		retry:   retry,
+	}
+}
+
+// Post sends a verdict to the release-controller. Server errors are retried.
+func (c *Client) Post(verdict Verdict) error {
+	stream := c.stream
+	if stream == "" {
+		stream = Stream(verdict.Release)
+	}
+	if stream == "" {
+		return fmt.Errorf("couldn't determine the release stream of %s", verdict.Release)
+	}
+
+	body, err := json.Marshal(verdict)
+	if err != nil {
+		return err
+	}
+
+	endpoint := c.baseURL + fmt.Sprintf(verificationPathFmt, url.PathEscape(stream), url.PathEscape(verdict.Release))
+	err = c.retry.Retry(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/json")
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+
+		data, _ := ioutil.ReadAll(resp.Body)
+		err = fmt.Errorf("release-controller returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+		if resp.StatusCode < http.StatusInternalServerError {
+			return backoff.Permanent(err)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't post verdict for %s: %v", verdict.Release, err)
+	}
+	return nil
+}
+
+// streamTagRE matches release tags that are named after their stream and the time they were built.
+var streamTagRE = regexp.MustCompile(`^(.+)-\d{4}-\d{2}-\d{2}-\d{6}$`)
+
+// Stream returns the release stream of a nightly or CI release tag, or an empty string for other tags.
+func Stream(release string) string {
+	match := streamTagRE.FindStringSubmatch(release)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
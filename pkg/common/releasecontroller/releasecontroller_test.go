@@ -0,0 +1,92 @@
+package releasecontroller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+func testClient(url, stream string) *Client {
+	c := New(url, "secret", stream)
+	c.retry = backoff.Constant(time.Millisecond, 3)
+	return c
+}
+
+func TestPost(t *testing.T) {
+	var received Verdict
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected a POST, got %s", r.Method)
+		}
+		if path := "/api/v1/releasestream/4.6.0-0.nightly/release/4.6.0-0.nightly-2020-06-01-123456/verification"; r.URL.Path != path {
+			t.Errorf("expected path %s, got %s", path, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("expected the token to be sent, got %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode verdict: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	verdict := Verdict{
+		Release:      "4.6.0-0.nightly-2020-06-01-123456",
+		Verification: "osd-e2e",
+		State:        StateSucceeded,
+		URL:          "https://example.com/job/1",
+	}
+	if err := testClient(server.URL+"/", "").Post(verdict); err != nil {
+		t.Fatalf("failed to post verdict: %v", err)
+	}
+
+	if received.Verification != "osd-e2e" || received.State != StateSucceeded || received.URL != verdict.URL {
+		t.Errorf("unexpected verdict received: %+v", received)
+	}
+}
+
+func TestPostRetries(t *testing.T) {
+	for status, expectedAttempts := range map[int]int{
+		http.StatusServiceUnavailable: 3,
+		http.StatusUnauthorized:       1,
+	} {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(status)
+		}))
+
+		err := testClient(server.URL, "4.6.0-0.ci").Post(Verdict{Release: "4.6.0-rc.1", State: StateFailed})
+		server.Close()
+
+		if err == nil {
+			t.Errorf("%d: expected posting to fail", status)
+		}
+		if attempts != expectedAttempts {
+			t.Errorf("%d: expected %d attempts, got %d", status, expectedAttempts, attempts)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	tests := map[string]string{
+		"4.6.0-0.nightly-2020-06-01-123456": "4.6.0-0.nightly",
+		"4.5.0-0.ci-2020-05-30-010203":      "4.5.0-0.ci",
+		"4.5.2":                             "",
+		"4.6.0-rc.1":                        "",
+	}
+	for release, expected := range tests {
+		if stream := Stream(release); stream != expected {
+			t.Errorf("expected stream of %s to be %q, got %q", release, expected, stream)
+		}
+	}
+
+	if err := New("http://localhost", "", "").Post(Verdict{Release: "4.5.2"}); err == nil {
+		t.Errorf("expected posting without a stream to fail")
+	}
+}
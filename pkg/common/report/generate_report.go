@@ -3,12 +3,12 @@ package report
 import (
 	"context"
 	"fmt"
-	"log"
 	"regexp"
 	"sort"
 	"time"
 
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/prometheus"
 
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
@@ -18,19 +18,22 @@ import (
 const (
 	gateQuery = `count by (job, install_version, suite, testname, result) (cicd_jUnitResult)`
 
+	failureQuery = `count by (job, category) (cicd_failure_classification)`
+
 	stepDurationInHours = 4
 )
 
 type reportData struct {
-	Versions []string
-	Failures map[string]int
+	Versions          []string
+	Failures          map[string]int
+	FailureCategories map[string]int
 }
 
 // GenerateReport generates a weather report.
 func GenerateReport() (WeatherReport, error) {
 	// Range for the queries issued to Prometheus
 	queryRange := v1.Range{
-		Start: time.Now().Add(-time.Hour * config.Instance.Weather.StartOfTimeWindowInHours),
+		Start: time.Now().Add(-time.Hour * time.Duration(config.Instance.Weather.StartOfTimeWindowInHours)),
 		End:   time.Now(),
 		Step:  stepDurationInHours * time.Hour,
 	}
@@ -57,7 +60,7 @@ func GenerateReport() (WeatherReport, error) {
 	}
 
 	if len(warnings) > 0 {
-		log.Printf("Warnings: %v", warnings)
+		logging.Warnf("Warnings: %v", warnings)
 	}
 
 	// Generate report from query results.
@@ -68,6 +71,20 @@ func GenerateReport() (WeatherReport, error) {
 			return WeatherReport{}, err
 		}
 
+		// Failure categories separate runs broken by the infrastructure from runs broken by the product.
+		failureResults, warnings, err := promAPI.QueryRange(context, failureQuery, queryRange)
+		if err != nil {
+			return WeatherReport{}, fmt.Errorf("error during query: %v", err)
+		}
+
+		if len(warnings) > 0 {
+			logging.Warnf("Warnings: %v", warnings)
+		}
+
+		if failureMatrix, ok := failureResults.(model.Matrix); ok {
+			addFailureCategories(jobReportData, failureMatrix)
+		}
+
 		weatherReport := WeatherReport{
 			ReportDate: time.Now().UTC(),
 		}
@@ -87,6 +104,8 @@ func GenerateReport() (WeatherReport, error) {
 					Viable:       len(reportData.Failures) == 0,
 					Versions:     reportData.Versions,
 					FailingTests: arrayFromMapKeys(reportData.Failures),
+
+					FailureCategories: reportData.FailureCategories,
 				})
 			}
 		}
@@ -135,6 +154,21 @@ func generateVersionsAndFailures(matrixResults model.Matrix) (map[string]*report
 	return jobReportData, nil
 }
 
+// addFailureCategories counts the failed runs of each job by the category of their failure.
+func addFailureCategories(jobReportData map[string]*reportData, matrixResults model.Matrix) {
+	for _, sample := range matrixResults {
+		r, ok := jobReportData[fmt.Sprintf("%s", sample.Metric["job"])]
+		if !ok {
+			continue
+		}
+
+		if r.FailureCategories == nil {
+			r.FailureCategories = map[string]int{}
+		}
+		r.FailureCategories[fmt.Sprintf("%s", sample.Metric["category"])] += len(sample.Values)
+	}
+}
+
 // addVersion adds versions to the reportData, eliminating duplicates.
 func (r *reportData) addVersion(versionToAdd string) {
 	for _, version := range r.Versions {
@@ -40,6 +40,9 @@ type JobReport struct {
 	Viable       bool     `json:"viable"`
 	Versions     []string `json:"versions"`
 	FailingTests []string `json:"failingTests,omitempty"`
+
+	// FailureCategories are the number of failed runs by the category of their failure.
+	FailureCategories map[string]int `json:"failureCategories,omitempty"`
 }
 
 // Len is the number of jobs in the weather report.
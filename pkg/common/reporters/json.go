@@ -0,0 +1,210 @@
+// Package reporters contains Ginkgo reporters for osde2e's artifacts.
+package reporters
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// Report is a JSON report of a suite run by Ginkgo.
+type Report struct {
+	SuiteDescription string       `json:"SuiteDescription"`
+	SuiteSucceeded   bool         `json:"SuiteSucceeded"`
+	StartTime        time.Time    `json:"StartTime"`
+	EndTime          time.Time    `json:"EndTime"`
+	RunTime          float64      `json:"RunTime"`
+	SpecReports      []SpecReport `json:"SpecReports"`
+}
+
+// SpecReport is the result of a spec or of a suite setup node, such as a BeforeSuite.
+type SpecReport struct {
+	ContainerHierarchyTexts []string  `json:"ContainerHierarchyTexts"`
+	LeafNodeType            string    `json:"LeafNodeType"`
+	LeafNodeText            string    `json:"LeafNodeText"`
+	LeafNodeLocation        string    `json:"LeafNodeLocation,omitempty"`
+	State                   string    `json:"State"`
+	StartTime               time.Time `json:"StartTime"`
+	EndTime                 time.Time `json:"EndTime"`
+	RunTime                 float64   `json:"RunTime"`
+	Failure                 *Failure  `json:"Failure,omitempty"`
+	Labels                  []string  `json:"Labels,omitempty"`
+}
+
+// Failure describes why a spec failed.
+type Failure struct {
+	Message        string `json:"Message"`
+	Location       string `json:"Location"`
+	ForwardedPanic string `json:"ForwardedPanic,omitempty"`
+}
+
+// JSONReporter writes a JSON report with the start and end of every spec, which can be used as a timeline of the run.
+type JSONReporter struct {
+	filename string
+
+	mutex     sync.Mutex
+	report    Report
+	specStart time.Time
+	now       func() time.Time
+	labels    func(texts []string) []string
+}
+
+// NewJSONReporter creates a reporter that writes its report to filename once the suite ends.
+func NewJSONReporter(filename string) *JSONReporter {
+	return &JSONReporter{
+		filename: filename,
+		now:      time.Now,
+	}
+}
+
+// WithLabels labels each spec with the labels returned for the texts of its containers and itself.
+func (r *JSONReporter) WithLabels(labels func(texts []string) []string) *JSONReporter {
+	r.labels = labels
+	return r
+}
+
+// SpecSuiteWillBegin starts the report.
+func (r *JSONReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.report = Report{
+		SuiteDescription: summary.SuiteDescription,
+		StartTime:        r.now(),
+		SpecReports:      []SpecReport{},
+	}
+}
+
+// BeforeSuiteDidRun records the BeforeSuite node.
+func (r *JSONReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	r.addSetup("BeforeSuite", setupSummary)
+}
+
+// SpecWillRun records when a spec started.
+func (r *JSONReporter) SpecWillRun(specSummary *types.SpecSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.specStart = r.now()
+}
+
+// SpecDidComplete records the result of a spec.
+func (r *JSONReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	end := r.now()
+	start := r.specStart
+	if start.IsZero() {
+		start = end.Add(-specSummary.RunTime)
+	}
+	r.specStart = time.Time{}
+
+	texts := specSummary.ComponentTexts
+	spec := SpecReport{
+		ContainerHierarchyTexts: []string{},
+		LeafNodeType:            "It",
+		State:                   stateName(specSummary.State),
+		StartTime:               start,
+		EndTime:                 end,
+		RunTime:                 specSummary.RunTime.Seconds(),
+		Failure:                 failure(specSummary.State, specSummary.Failure),
+	}
+	if specSummary.IsMeasurement {
+		spec.LeafNodeType = "Measure"
+	}
+
+	// the first component is the top level of the suite, and the last is the spec itself
+	if len(texts) > 1 {
+		spec.ContainerHierarchyTexts = append(spec.ContainerHierarchyTexts, texts[1:len(texts)-1]...)
+		spec.LeafNodeText = texts[len(texts)-1]
+		if r.labels != nil {
+			spec.Labels = r.labels(texts[1:])
+		}
+	}
+	if locations := specSummary.ComponentCodeLocations; len(locations) > 0 {
+		spec.LeafNodeLocation = locations[len(locations)-1].String()
+	}
+	r.report.SpecReports = append(r.report.SpecReports, spec)
+}
+
+// AfterSuiteDidRun records the AfterSuite node.
+func (r *JSONReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	r.addSetup("AfterSuite", setupSummary)
+}
+
+// SpecSuiteDidEnd writes the report.
+func (r *JSONReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.report.SuiteSucceeded = summary.SuiteSucceeded
+	r.report.EndTime = r.now()
+	r.report.RunTime = r.report.EndTime.Sub(r.report.StartTime).Seconds()
+
+	data, err := json.MarshalIndent(r.report, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(r.filename, data, os.FileMode(0644))
+	}
+	if err != nil {
+		logging.Errorf("Error writing JSON report %s: %v", r.filename, err)
+	}
+}
+
+// addSetup records a suite setup node. Nodes that didn't run, such as a missing AfterSuite, are left out.
+func (r *JSONReporter) addSetup(nodeType string, setupSummary *types.SetupSummary) {
+	if setupSummary.State == types.SpecStateInvalid {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	end := r.now()
+	r.report.SpecReports = append(r.report.SpecReports, SpecReport{
+		ContainerHierarchyTexts: []string{},
+		LeafNodeType:            nodeType,
+		LeafNodeLocation:        setupSummary.CodeLocation.String(),
+		State:                   stateName(setupSummary.State),
+		StartTime:               end.Add(-setupSummary.RunTime),
+		EndTime:                 end,
+		RunTime:                 setupSummary.RunTime.Seconds(),
+		Failure:                 failure(setupSummary.State, setupSummary.Failure),
+	})
+}
+
+func failure(state types.SpecState, specFailure types.SpecFailure) *Failure {
+	if !state.IsFailure() {
+		return nil
+	}
+	return &Failure{
+		Message:        specFailure.Message,
+		Location:       specFailure.Location.String(),
+		ForwardedPanic: specFailure.ForwardedPanic,
+	}
+}
+
+// stateName returns the name of a spec state in the report.
+func stateName(state types.SpecState) string {
+	switch state {
+	case types.SpecStatePending:
+		return "pending"
+	case types.SpecStateSkipped:
+		return "skipped"
+	case types.SpecStatePassed:
+		return "passed"
+	case types.SpecStateFailed:
+		return "failed"
+	case types.SpecStatePanicked:
+		return "panicked"
+	case types.SpecStateTimedOut:
+		return "timedout"
+	default:
+		return "invalid"
+	}
+}
@@ -0,0 +1,123 @@
+package reporters
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+)
+
+func TestJSONReporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := start
+	filename := filepath.Join(dir, "report.json")
+	reporter := NewJSONReporter(filename)
+	reporter.now = func() time.Time { return clock }
+
+	reporter.SpecSuiteWillBegin(config.GinkgoConfig, &types.SuiteSummary{SuiteDescription: "OSD e2e suite"})
+
+	clock = clock.Add(time.Minute)
+	reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed, RunTime: time.Minute})
+
+	reporter.SpecWillRun(&types.SpecSummary{})
+	clock = clock.Add(30 * time.Second)
+	reporter.SpecDidComplete(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: e2e] Pods", "should be healthy"},
+		State:          types.SpecStateFailed,
+		RunTime:        30 * time.Second,
+		Failure:        types.SpecFailure{Message: "pods are crashing"},
+	})
+
+	reporter.SpecWillRun(&types.SpecSummary{})
+	reporter.SpecDidComplete(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: e2e] Routes", "should be reachable"},
+		State:          types.SpecStateSkipped,
+	})
+
+	clock = clock.Add(time.Second)
+	reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: false})
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	report := Report{}
+	if err = json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	if report.SuiteDescription != "OSD e2e suite" || report.SuiteSucceeded || report.RunTime != 91 {
+		t.Errorf("unexpected suite results %+v", report)
+	}
+
+	if len(report.SpecReports) != 3 {
+		t.Fatalf("expected a BeforeSuite and two specs, got %+v", report.SpecReports)
+	}
+
+	if setup := report.SpecReports[0]; setup.LeafNodeType != "BeforeSuite" || !setup.StartTime.Equal(start) {
+		t.Errorf("unexpected BeforeSuite report %+v", setup)
+	}
+
+	failed := report.SpecReports[1]
+	if failed.LeafNodeText != "should be healthy" || len(failed.ContainerHierarchyTexts) != 1 || failed.ContainerHierarchyTexts[0] != "[Suite: e2e] Pods" {
+		t.Errorf("unexpected spec texts %+v", failed)
+	}
+
+	if failed.State != "failed" || failed.Failure == nil || failed.Failure.Message != "pods are crashing" {
+		t.Errorf("expected the failure to be reported, got %+v", failed)
+	}
+
+	if !failed.StartTime.Equal(start.Add(time.Minute)) || !failed.EndTime.Equal(start.Add(90*time.Second)) {
+		t.Errorf("unexpected spec timeline %s to %s", failed.StartTime, failed.EndTime)
+	}
+
+	if skipped := report.SpecReports[2]; skipped.State != "skipped" || skipped.Failure != nil {
+		t.Errorf("unexpected skipped spec report %+v", skipped)
+	}
+}
+
+func TestJSONReporterLabels(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "report.json")
+	reporter := NewJSONReporter(filename).WithLabels(func(texts []string) []string {
+		return []string{texts[0]}
+	})
+
+	reporter.SpecSuiteWillBegin(config.GinkgoConfig, &types.SuiteSummary{SuiteDescription: "OSD e2e suite"})
+	reporter.SpecDidComplete(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: informing] Nodes", "should be labeled"},
+		State:          types.SpecStatePassed,
+	})
+	reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: true})
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	report := Report{}
+	if err = json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+
+	if labels := report.SpecReports[0].Labels; len(labels) != 1 || labels[0] != "[Suite: informing] Nodes" {
+		t.Errorf("unexpected spec labels %v", labels)
+	}
+}
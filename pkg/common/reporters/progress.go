@@ -0,0 +1,107 @@
+package reporters
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+
+	"github.com/openshift/osde2e/pkg/common/progress"
+)
+
+// ProgressReporter emits progress events as the specs of a phase complete.
+type ProgressReporter struct {
+	phase string
+
+	mutex sync.Mutex
+	event progress.Event
+}
+
+// NewProgressReporter creates a reporter for the specs of a phase.
+func NewProgressReporter(phase string) *ProgressReporter {
+	return &ProgressReporter{phase: phase}
+}
+
+// SpecSuiteWillBegin emits the start of the phase with the number of specs that will run.
+func (r *ProgressReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.event = progress.Event{Phase: r.phase, Total: summary.NumberOfSpecsThatWillBeRun}
+	started := r.event
+	started.Type = progress.PhaseStarted
+	progress.Emit(started)
+}
+
+// BeforeSuiteDidRun does nothing.
+func (r *ProgressReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {}
+
+// SpecWillRun emits the start of specs that will run.
+func (r *ProgressReporter) SpecWillRun(specSummary *types.SpecSummary) {
+	if specSummary.State == types.SpecStateSkipped || specSummary.State == types.SpecStatePending {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	started := progress.Event{Type: progress.SpecStarted, Phase: r.phase, Spec: specName(specSummary)}
+	progress.Emit(started)
+}
+
+// SpecDidComplete emits the counts of the specs completed so far.
+func (r *ProgressReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	state := stateName(specSummary.State)
+	switch {
+	case specSummary.State.IsFailure():
+		r.event.Failed++
+	case specSummary.State == types.SpecStatePassed:
+		r.event.Passed++
+	case specSummary.RunTime > 0:
+		// the spec skipped itself while running
+		r.event.Skipped++
+	default:
+		// specs filtered out by focus and skip aren't included in the total
+		return
+	}
+
+	r.event.Completed++
+	if r.event.Total > 0 {
+		r.event.Percent = 100 * float64(r.event.Completed) / float64(r.event.Total)
+	}
+
+	completed := r.event
+	completed.Type = progress.SpecCompleted
+	completed.State = state
+	completed.Spec = specName(specSummary)
+	progress.Emit(completed)
+}
+
+// specName is the full text of a spec, without the top level container every spec is in.
+func specName(specSummary *types.SpecSummary) string {
+	if texts := specSummary.ComponentTexts; len(texts) > 1 {
+		return strings.Join(texts[1:], " ")
+	}
+	return ""
+}
+
+// AfterSuiteDidRun does nothing.
+func (r *ProgressReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {}
+
+// SpecSuiteDidEnd emits the end of the phase.
+func (r *ProgressReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ended := r.event
+	ended.Type = progress.PhaseEnded
+	ended.State = progress.Passed
+	if !summary.SuiteSucceeded {
+		ended.State = progress.Failed
+	}
+	progress.Emit(ended)
+}
@@ -0,0 +1,84 @@
+package reporters
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+
+	"github.com/openshift/osde2e/pkg/common/progress"
+)
+
+func TestProgressReporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "progress.json")
+	if err = progress.Start(filename); err != nil {
+		t.Fatalf("failed to start progress: %v", err)
+	}
+
+	reporter := NewProgressReporter("install")
+	reporter.SpecSuiteWillBegin(config.GinkgoConfig, &types.SuiteSummary{NumberOfSpecsThatWillBeRun: 4})
+	reporter.SpecWillRun(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: e2e] Pods", "should be healthy"},
+	})
+	reporter.SpecDidComplete(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: e2e] Pods", "should be healthy"},
+		State:          types.SpecStatePassed,
+		RunTime:        time.Second,
+	})
+	reporter.SpecDidComplete(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: e2e] Routes", "should be reachable"},
+		State:          types.SpecStateFailed,
+		RunTime:        time.Second,
+	})
+	reporter.SpecWillRun(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: scale] Nodes", "should scale"},
+		State:          types.SpecStateSkipped,
+	})
+	reporter.SpecDidComplete(&types.SpecSummary{
+		ComponentTexts: []string{"[Top Level]", "[Suite: scale] Nodes", "should scale"},
+		State:          types.SpecStateSkipped,
+	})
+	reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: false})
+	progress.Stop()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("failed to open progress events: %v", err)
+	}
+	defer file.Close()
+
+	var events []progress.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event progress.Event
+		if err = json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("failed to parse progress event %s: %v", scanner.Text(), err)
+		}
+		event.Time = time.Time{}
+		events = append(events, event)
+	}
+
+	expected := []progress.Event{
+		{Type: progress.PhaseStarted, Phase: "install", Total: 4},
+		{Type: progress.SpecStarted, Phase: "install", Spec: "[Suite: e2e] Pods should be healthy"},
+		{Type: progress.SpecCompleted, Phase: "install", Spec: "[Suite: e2e] Pods should be healthy", State: "passed", Total: 4, Completed: 1, Passed: 1, Percent: 25},
+		{Type: progress.SpecCompleted, Phase: "install", Spec: "[Suite: e2e] Routes should be reachable", State: "failed", Total: 4, Completed: 2, Passed: 1, Failed: 1, Percent: 50},
+		{Type: progress.PhaseEnded, Phase: "install", State: progress.Failed, Total: 4, Completed: 2, Passed: 1, Failed: 1, Percent: 50},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected events:\n%+v\ngot:\n%+v", expected, events)
+	}
+}
@@ -0,0 +1,241 @@
+// Package rundiff compares the results of two osde2e runs.
+package rundiff
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/openshift/osde2e/pkg/common/metadata"
+)
+
+// Run is the results of an osde2e run.
+type Run struct {
+	// Specs are keyed by the directory of their JUnit file, such as the phase, and their name.
+	Specs map[string]Spec
+
+	// Metrics are the numeric values of the run's metadata.
+	Metrics map[string]float64
+}
+
+// Spec is the result of a spec in a run.
+type Spec struct {
+	Failed  bool
+	Skipped bool
+
+	// Duration is in seconds.
+	Duration float64
+}
+
+// Diff is what changed between two runs.
+type Diff struct {
+	// NewlyFailing are specs that fail in the second run but didn't in the first.
+	NewlyFailing []string `json:"newlyFailing"`
+
+	// Fixed are specs that failed in the first run and pass in the second.
+	Fixed []string `json:"fixed"`
+
+	// Added and Removed are specs that only exist in the second or first run.
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+
+	// Durations are the changes in duration of specs that ran in both runs, largest first.
+	Durations []Delta `json:"durations"`
+
+	// Metrics are the metrics that changed, by name.
+	Metrics []Delta `json:"metrics"`
+}
+
+// Delta is the change of a value between two runs.
+type Delta struct {
+	Name   string  `json:"name"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Change float64 `json:"change"`
+}
+
+// Load reads the results of a run from its report directory.
+func Load(reportDir string) (*Run, error) {
+	if info, err := os.Stat(reportDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a report directory", reportDir)
+	}
+
+	run := &Run{Specs: map[string]Spec{}, Metrics: map[string]float64{}}
+	err := filepath.Walk(reportDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasPrefix(info.Name(), "junit") || !strings.HasSuffix(info.Name(), ".xml") {
+			return err
+		}
+
+		dir, err := filepath.Rel(reportDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		return run.addJUnit(path, filepath.ToSlash(dir))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = run.addMetadata(filepath.Join(reportDir, metadata.MetadataFile)); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (r *Run) addJUnit(path, dir string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var suite reporters.JUnitTestSuite
+	if err = xml.Unmarshal(data, &suite); err != nil {
+		return fmt.Errorf("error parsing JUnit file %s: %v", path, err)
+	}
+
+	for _, testCase := range suite.TestCases {
+		name := testCase.Name
+		if dir != "." {
+			name = dir + "/" + name
+		}
+
+		// specs run more than once, such as in several JUnit files of a phase, fail if any run failed and are only
+		// skipped if every run was
+		spec, seen := r.Specs[name]
+		r.Specs[name] = Spec{
+			Failed:   spec.Failed || testCase.FailureMessage != nil,
+			Skipped:  (!seen || spec.Skipped) && testCase.Skipped != nil,
+			Duration: spec.Duration + testCase.Time,
+		}
+	}
+	return nil
+}
+
+// addMetadata reads the numeric fields and log metrics of a run's metadata. Runs without metadata have no metrics.
+func (r *Run) addMetadata(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var m metadata.Metadata
+	if err = json.NewDecoder(f).Decode(&m); err != nil && err != io.EOF {
+		return fmt.Errorf("error parsing metadata %s: %v", path, err)
+	}
+
+	value := reflect.ValueOf(m)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() != reflect.Float64 {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		r.Metrics[name] = value.Field(i).Float()
+	}
+
+	for name, count := range m.LogMetrics {
+		r.Metrics["log-metrics."+name] = float64(count)
+	}
+	return nil
+}
+
+// Compare returns what changed from the before run to the after run. Changes in duration smaller than
+// minDurationChange seconds are ignored.
+func Compare(before, after *Run, minDurationChange float64) *Diff {
+	diff := &Diff{
+		NewlyFailing: []string{},
+		Fixed:        []string{},
+		Added:        []string{},
+		Removed:      []string{},
+		Durations:    []Delta{},
+		Metrics:      []Delta{},
+	}
+
+	for name, spec := range after.Specs {
+		previous, ok := before.Specs[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+		}
+
+		if spec.Failed && !previous.Failed {
+			diff.NewlyFailing = append(diff.NewlyFailing, name)
+		} else if ok && previous.Failed && !spec.Failed && !spec.Skipped {
+			diff.Fixed = append(diff.Fixed, name)
+		}
+
+		if ok && !spec.Skipped && !previous.Skipped && math.Abs(spec.Duration-previous.Duration) >= minDurationChange {
+			diff.Durations = append(diff.Durations, delta(name, previous.Duration, spec.Duration))
+		}
+	}
+
+	for name := range before.Specs {
+		if _, ok := after.Specs[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	for name, value := range after.Metrics {
+		if previous, ok := before.Metrics[name]; ok && previous != value {
+			diff.Metrics = append(diff.Metrics, delta(name, previous, value))
+		}
+	}
+
+	sort.Strings(diff.NewlyFailing)
+	sort.Strings(diff.Fixed)
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Durations, func(i, j int) bool {
+		if a, b := math.Abs(diff.Durations[i].Change), math.Abs(diff.Durations[j].Change); a != b {
+			return a > b
+		}
+		return diff.Durations[i].Name < diff.Durations[j].Name
+	})
+	sort.Slice(diff.Metrics, func(i, j int) bool {
+		return diff.Metrics[i].Name < diff.Metrics[j].Name
+	})
+	return diff
+}
+
+func delta(name string, before, after float64) Delta {
+	return Delta{Name: name, Before: before, After: after, Change: after - before}
+}
+
+// WriteText writes the diff for people to read.
+func (d *Diff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	writeNames := func(title string, names []string) {
+		fmt.Fprintf(&b, "%s (%d):\n", title, len(names))
+		for _, name := range names {
+			fmt.Fprintf(&b, "  %s\n", name)
+		}
+	}
+	writeDeltas := func(title, format, changeFormat string, deltas []Delta) {
+		fmt.Fprintf(&b, "%s (%d):\n", title, len(deltas))
+		for _, d := range deltas {
+			fmt.Fprintf(&b, "  %s: "+format+" -> "+format+" ("+changeFormat+")\n", d.Name, d.Before, d.After, d.Change)
+		}
+	}
+
+	writeNames("Newly failing", d.NewlyFailing)
+	writeNames("Fixed", d.Fixed)
+	writeNames("Added", d.Added)
+	writeNames("Removed", d.Removed)
+	writeDeltas("Duration changes (seconds)", "%.1f", "%+.1f", d.Durations)
+	writeDeltas("Metric changes", "%g", "%+g", d.Metrics)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,94 @@
+package rundiff
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeRun writes a report directory with an install phase JUnit file and metadata.
+func writeRun(t *testing.T, junit, metadata string) string {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	if err = os.MkdirAll(filepath.Join(dir, "install"), os.FileMode(0755)); err != nil {
+		t.Fatalf("failed to create phase directory: %v", err)
+	}
+	files := map[string]string{
+		"install/junit_abc.xml": `<testsuite name="OSD e2e suite">` + junit + `</testsuite>`,
+		"metadata.json":         metadata,
+	}
+	for name, contents := range files {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), os.FileMode(0644)); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestCompare(t *testing.T) {
+	beforeDir := writeRun(t, `
+		<testcase name="stays passing" time="10"></testcase>
+		<testcase name="breaks" time="5"></testcase>
+		<testcase name="gets fixed" time="20"><failure type="Failure">timed out</failure></testcase>
+		<testcase name="gets skipped" time="0"><failure type="Failure">timed out</failure></testcase>
+		<testcase name="removed" time="1"></testcase>`,
+		`{"time-to-cluster-ready":"1200","install-phase-pass-rate":"0.6","log-metrics":{"panics":0}}`)
+	defer os.RemoveAll(beforeDir)
+
+	afterDir := writeRun(t, `
+		<testcase name="stays passing" time="70"></testcase>
+		<testcase name="breaks" time="6"><failure type="Failure">unexpected error</failure></testcase>
+		<testcase name="gets fixed" time="20"></testcase>
+		<testcase name="gets skipped" time="0"><skipped></skipped></testcase>
+		<testcase name="added" time="1"><failure type="Failure">not found</failure></testcase>`,
+		`{"time-to-cluster-ready":"1500","install-phase-pass-rate":"0.6","log-metrics":{"panics":2}}`)
+	defer os.RemoveAll(afterDir)
+
+	before, err := Load(beforeDir)
+	if err != nil {
+		t.Fatalf("failed to load run: %v", err)
+	}
+	after, err := Load(afterDir)
+	if err != nil {
+		t.Fatalf("failed to load run: %v", err)
+	}
+
+	diff := Compare(before, after, 30)
+	expected := &Diff{
+		NewlyFailing: []string{"install/added", "install/breaks"},
+		Fixed:        []string{"install/gets fixed"},
+		Added:        []string{"install/added"},
+		Removed:      []string{"install/removed"},
+		Durations:    []Delta{{Name: "install/stays passing", Before: 10, After: 70, Change: 60}},
+		Metrics: []Delta{
+			{Name: "log-metrics.panics", Before: 0, After: 2, Change: 2},
+			{Name: "time-to-cluster-ready", Before: 1200, After: 1500, Change: 300},
+		},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("expected diff:\n%+v\ngot:\n%+v", expected, diff)
+	}
+
+	out := &bytes.Buffer{}
+	if err = diff.WriteText(out); err != nil {
+		t.Fatalf("failed to write diff: %v", err)
+	}
+	for _, line := range []string{"Newly failing (2):", "  install/stays passing: 10.0 -> 70.0 (+60.0)", "  time-to-cluster-ready: 1200 -> 1500 (+300)"} {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("expected output to contain %q, got:\n%s", line, out)
+		}
+	}
+}
+
+func TestLoadMissingRun(t *testing.T) {
+	if _, err := Load(filepath.Join(os.TempDir(), "osde2e-no-such-run")); err == nil {
+		t.Error("expected loading a missing report directory to fail")
+	}
+}
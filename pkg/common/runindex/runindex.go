@@ -0,0 +1,149 @@
+// Package runindex publishes a summary of each osde2e run to a central index, so that fleet-wide QE dashboards can
+// find runs without access to the artifacts of every CI system.
+package runindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+const (
+	// VerdictPassed is the verdict of a run whose tests passed.
+	VerdictPassed = "passed"
+
+	// VerdictFailed is the verdict of a run that failed.
+	VerdictFailed = "failed"
+
+	requestTimeout = 30 * time.Second
+)
+
+// Record summarizes a run.
+type Record struct {
+	Job            string    `json:"job"`
+	JobID          int       `json:"jobID"`
+	Provider       string    `json:"provider"`
+	Environment    string    `json:"environment"`
+	ClusterID      string    `json:"clusterID,omitempty"`
+	ClusterVersion string    `json:"clusterVersion"`
+	UpgradeVersion string    `json:"upgradeVersion,omitempty"`
+	Verdict        string    `json:"verdict"`
+	Message        string    `json:"message,omitempty"`
+	ArtifactURL    string    `json:"artifactURL,omitempty"`
+	Finished       time.Time `json:"finished"`
+}
+
+// name identifies a record in a bucket. Runs without a job ID are named after when they finished.
+func (r Record) name() string {
+	job := r.Job
+	if job == "" {
+		job = "local"
+	}
+
+	id := r.Finished.UTC().Format("20060102-150405")
+	if r.JobID != -1 {
+		id = strconv.Itoa(r.JobID)
+	}
+	return job + "/" + id + ".json"
+}
+
+// Publisher adds records to the index.
+type Publisher struct {
+	url    string
+	token  string
+	client *http.Client
+	retry  backoff.Backoff
+
+	// writeToS3 stores a record in a bucket. It is replaced in tests.
+	writeToS3 func(key string, data []byte) error
+}
+
+// New creates a publisher for the index at indexURL. An http(s) URL is an endpoint records are POSTed to, with token
+// as a bearer token if it's set. An s3 URL is a prefix records are stored under, by job name and ID.
+func New(indexURL, token string) (*Publisher, error) {
+	u, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid run index URL %s: %v", indexURL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "s3":
+	default:
+		return nil, fmt.Errorf("unsupported run index URL %s, expected an http, https, or s3 URL", indexURL)
+	}
+
+	retry := backoff.Exponential(5*time.Second, time.Minute)
+	retry.MaxAttempts = 5
+
+	return &Publisher{
+		url:       indexURL,
+		token:     token,
+		client:    &http.Client{Timeout: requestTimeout},
+		retry:     retry,
+		writeToS3: aws.WriteToS3,
+	}, nil
+}
+
+// Publish adds a record to the index, retrying failures that may be transient.
+func (p *Publisher) Publish(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(p.url, "s3://") {
+		key := strings.TrimSuffix(p.url, "/") + "/" + record.name()
+		err = p.retry.Retry(context.Background(), func(ctx context.Context) error {
+			return p.writeToS3(key, data)
+		})
+	} else {
+		err = p.retry.Retry(context.Background(), func(ctx context.Context) error {
+			return p.post(ctx, data)
+		})
+	}
+
+	if err != nil {
+		return fmt.Errorf("couldn't publish the record of %s to the run index: %v", record.name(), err)
+	}
+	return nil
+}
+
+// post sends a record to the index endpoint. Only server errors are retried.
+func (p *Publisher) post(ctx context.Context, data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	err = fmt.Errorf("run index returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+		return backoff.Permanent(err)
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package runindex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/backoff"
+)
+
+var record = Record{
+	Job:            "osde2e-stage-aws-e2e-default",
+	JobID:          1234,
+	Provider:       "ocm",
+	Environment:    "stage",
+	ClusterVersion: "openshift-v4.6.1",
+	Verdict:        VerdictPassed,
+	ArtifactURL:    "https://example.com/logs/osde2e-stage-aws-e2e-default/1234",
+	Finished:       time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func testPublisher(t *testing.T, url string) *Publisher {
+	p, err := New(url, "secret")
+	if err != nil {
+		t.Fatalf("failed to create publisher: %v", err)
+	}
+	p.retry = backoff.Constant(time.Millisecond, 3)
+	return p
+}
+
+func TestPublishHTTP(t *testing.T) {
+	for status, expectedAttempts := range map[int]int{
+		http.StatusCreated:            1,
+		http.StatusServiceUnavailable: 3,
+		http.StatusBadRequest:         1,
+	} {
+		var received Record
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+				t.Errorf("expected the token to be sent, got %q", auth)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("failed to decode record: %v", err)
+			}
+			w.WriteHeader(status)
+		}))
+
+		err := testPublisher(t, server.URL).Publish(record)
+		server.Close()
+
+		if failed := status >= 300; failed != (err != nil) {
+			t.Errorf("%d: unexpected result: %v", status, err)
+		}
+		if attempts != expectedAttempts {
+			t.Errorf("%d: expected %d attempts, got %d", status, expectedAttempts, attempts)
+		}
+		if received != record {
+			t.Errorf("%d: expected %+v to be sent, got %+v", status, record, received)
+		}
+	}
+}
+
+func TestPublishS3(t *testing.T) {
+	p := testPublisher(t, "s3://bucket/runs/")
+
+	attempts := 0
+	written := map[string][]byte{}
+	p.writeToS3 = func(key string, data []byte) error {
+		if attempts++; attempts == 1 {
+			return errors.New("slow down")
+		}
+		written[key] = data
+		return nil
+	}
+
+	if err := p.Publish(record); err != nil {
+		t.Fatalf("failed to publish record: %v", err)
+	}
+
+	data, ok := written["s3://bucket/runs/osde2e-stage-aws-e2e-default/1234.json"]
+	if !ok {
+		t.Fatalf("expected the record to be stored by job name and ID, got %v", written)
+	}
+
+	var stored Record
+	if err := json.Unmarshal(data, &stored); err != nil || stored != record {
+		t.Errorf("expected %+v to be stored, got %+v (%v)", record, stored, err)
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	local := Record{JobID: -1, Finished: time.Date(2020, 6, 1, 12, 30, 5, 0, time.UTC)}
+	if name := local.name(); name != "local/20200601-123005.json" {
+		t.Errorf("expected a run without a job to be named after when it finished, got %s", name)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("ftp://example.com/runs", ""); err == nil {
+		t.Errorf("expected an unsupported URL to be rejected")
+	}
+}
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"fmt"
+
+	kubev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RoleARNAnnotation tells the pod identity webhook which IAM role a ServiceAccount assumes.
+	RoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
+	// DefaultWebIdentityAudience is the audience STS expects in web identity tokens.
+	DefaultWebIdentityAudience = "sts.amazonaws.com"
+
+	// webIdentityTokenDir is where the projected token is mounted.
+	webIdentityTokenDir = "/var/run/secrets/osde2e/serviceaccount"
+
+	// webIdentityTokenFile is the name of the projected token within webIdentityTokenDir.
+	webIdentityTokenFile = "token"
+)
+
+// WebIdentity lets a Pod obtain short-lived cloud credentials by exchanging a projected ServiceAccount token with STS.
+type WebIdentity struct {
+	// RoleARN is the IAM role assumed by the Pod.
+	RoleARN string
+
+	// Audience of the projected token. Defaults to DefaultWebIdentityAudience.
+	Audience string
+}
+
+// TokenDir is the directory the projected token is mounted in.
+func (w WebIdentity) TokenDir() string {
+	return webIdentityTokenDir
+}
+
+// TokenFile is the full path of the projected token.
+func (w WebIdentity) TokenFile() string {
+	return fmt.Sprintf("%s/%s", webIdentityTokenDir, webIdentityTokenFile)
+}
+
+// TokenAudience returns the audience of the projected token.
+func (w WebIdentity) TokenAudience() string {
+	if w.Audience == "" {
+		return DefaultWebIdentityAudience
+	}
+	return w.Audience
+}
+
+// AssignRole annotates a ServiceAccount with the IAM role it should assume.
+func (r *Runner) AssignRole(sa *kubev1.ServiceAccount, roleARN string) (*kubev1.ServiceAccount, error) {
+	if sa.Annotations == nil {
+		sa.Annotations = map[string]string{}
+	}
+	sa.Annotations[RoleARNAnnotation] = roleARN
+
+	updated, err := r.Kube.CoreV1().ServiceAccounts(sa.Namespace).Update(sa)
+	if err != nil {
+		return nil, fmt.Errorf("error annotating ServiceAccount with role: %v", err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWebIdentityTokenAudience(t *testing.T) {
+	if audience := (WebIdentity{}).TokenAudience(); audience != DefaultWebIdentityAudience {
+		t.Errorf("expected audience %s, got %s", DefaultWebIdentityAudience, audience)
+	}
+
+	if audience := (WebIdentity{Audience: "osde2e"}).TokenAudience(); audience != "osde2e" {
+		t.Errorf("expected audience osde2e, got %s", audience)
+	}
+}
+
+func TestAssignRole(t *testing.T) {
+	sa := &kubev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "harness",
+			Namespace: "runner-ns",
+		},
+	}
+
+	def := *DefaultRunner
+	r := &def
+	r.Kube = fake.NewSimpleClientset(sa)
+
+	roleARN := "arn:aws:iam::123456789012:role/harness"
+	if _, err := r.AssignRole(sa, roleARN); err != nil {
+		t.Fatalf("failed to assign role: %v", err)
+	}
+
+	updated, err := r.Kube.CoreV1().ServiceAccounts(sa.Namespace).Get(sa.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get ServiceAccount: %v", err)
+	}
+
+	if updated.Annotations[RoleARNAnnotation] != roleARN {
+		t.Errorf("expected ServiceAccount to be annotated with %s, got %v", roleARN, updated.Annotations)
+	}
+}
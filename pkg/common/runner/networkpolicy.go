@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"fmt"
+
+	kubev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// apiServerNamespace and apiServerService identify the service fronting the Kubernetes API.
+	apiServerNamespace = "default"
+	apiServerService   = "kubernetes"
+)
+
+// dnsPorts are the ports cluster DNS may be served on. OpenShift serves DNS on 5353.
+var dnsPorts = []int{53, 5353}
+
+// hardenPodSpec applies a restrictive securityContext to every container in a PodSpec.
+// Explicitly configured capabilities are left intact.
+func hardenPodSpec(podSpec *kubev1.PodSpec) {
+	for _, containers := range [][]kubev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			securityContext := containers[i].SecurityContext.DeepCopy()
+			if securityContext == nil {
+				securityContext = &kubev1.SecurityContext{}
+			}
+
+			securityContext.Privileged = pointer.BoolPtr(false)
+			securityContext.AllowPrivilegeEscalation = pointer.BoolPtr(false)
+			if securityContext.Capabilities == nil {
+				securityContext.Capabilities = &kubev1.Capabilities{
+					Drop: []kubev1.Capability{"ALL"},
+				}
+			}
+			containers[i].SecurityContext = securityContext
+		}
+	}
+}
+
+// createNetworkPolicy limits egress from every pod in the runner's namespace to cluster DNS, the
+// Kubernetes API, and any configured CIDRs.
+func (r *Runner) createNetworkPolicy(allowedCIDRs []string) (*networkingv1.NetworkPolicy, error) {
+	egress, err := r.apiServerEgressRules()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't determine API server endpoints: %v", err)
+	}
+
+	egress = append(egress, dnsEgressRule())
+	for _, cidr := range allowedCIDRs {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+				},
+			},
+		})
+	}
+
+	return r.Kube.NetworkingV1().NetworkPolicies(r.Namespace).Create(&networkingv1.NetworkPolicy{
+		ObjectMeta: r.meta(),
+		Spec: networkingv1.NetworkPolicySpec{
+			// select all pods so that workloads launched by the runner are covered too
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egress,
+		},
+	})
+}
+
+// apiServerEgressRules allows egress to the addresses backing the Kubernetes API service.
+func (r *Runner) apiServerEgressRules() ([]networkingv1.NetworkPolicyEgressRule, error) {
+	endpoints, err := r.Kube.CoreV1().Endpoints(apiServerNamespace).Get(apiServerService, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []networkingv1.NetworkPolicyEgressRule
+	for _, subset := range endpoints.Subsets {
+		rule := networkingv1.NetworkPolicyEgressRule{}
+		for _, addr := range subset.Addresses {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: addr.IP + "/32"},
+			})
+		}
+		for _, port := range subset.Ports {
+			protocol, portNum := port.Protocol, intstr.FromInt(int(port.Port))
+			rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{
+				Protocol: &protocol,
+				Port:     &portNum,
+			})
+		}
+		if len(rule.To) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s/%s", apiServerNamespace, apiServerService)
+	}
+	return rules, nil
+}
+
+// dnsEgressRule allows DNS lookups against any destination.
+func dnsEgressRule() networkingv1.NetworkPolicyEgressRule {
+	rule := networkingv1.NetworkPolicyEgressRule{}
+	for _, port := range dnsPorts {
+		for _, protocol := range []kubev1.Protocol{kubev1.ProtocolUDP, kubev1.ProtocolTCP} {
+			protocol, portNum := protocol, intstr.FromInt(port)
+			rule.Ports = append(rule.Ports, networkingv1.NetworkPolicyPort{
+				Protocol: &protocol,
+				Port:     &portNum,
+			})
+		}
+	}
+	return rule
+}
@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHardenPodSpec(t *testing.T) {
+	podSpec := kubev1.PodSpec{
+		InitContainers: []kubev1.Container{{Name: "init"}},
+		Containers: []kubev1.Container{
+			DefaultContainer,
+			{
+				Name: "explicit-caps",
+				SecurityContext: &kubev1.SecurityContext{
+					Capabilities: &kubev1.Capabilities{Add: []kubev1.Capability{"NET_BIND_SERVICE"}},
+				},
+			},
+		},
+	}
+
+	hardenPodSpec(&podSpec)
+
+	for _, c := range append(podSpec.InitContainers, podSpec.Containers...) {
+		sc := c.SecurityContext
+		if sc == nil {
+			t.Fatalf("container %s has no securityContext", c.Name)
+		}
+		if sc.Privileged == nil || *sc.Privileged {
+			t.Errorf("container %s should not be privileged", c.Name)
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			t.Errorf("container %s should not allow privilege escalation", c.Name)
+		}
+	}
+
+	if drop := podSpec.Containers[0].SecurityContext.Capabilities.Drop; len(drop) != 1 || drop[0] != "ALL" {
+		t.Errorf("expected all capabilities to be dropped, got %v", drop)
+	}
+
+	if caps := podSpec.Containers[1].SecurityContext.Capabilities; len(caps.Drop) != 0 || len(caps.Add) != 1 {
+		t.Errorf("explicit capabilities should be left intact, got %v", caps)
+	}
+
+	if DefaultContainer.SecurityContext.Privileged != nil {
+		t.Errorf("hardening should not modify the DefaultContainer")
+	}
+}
+
+func TestCreateNetworkPolicy(t *testing.T) {
+	client := fake.NewSimpleClientset(&kubev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiServerService,
+			Namespace: apiServerNamespace,
+		},
+		Subsets: []kubev1.EndpointSubset{
+			{
+				Addresses: []kubev1.EndpointAddress{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+				Ports:     []kubev1.EndpointPort{{Name: "https", Port: 6443, Protocol: kubev1.ProtocolTCP}},
+			},
+		},
+	})
+
+	def := *DefaultRunner
+	r := &def
+	r.Kube = client
+	r.Namespace = "runner-ns"
+
+	policy, err := r.createNetworkPolicy([]string{"52.216.0.0/15"})
+	if err != nil {
+		t.Fatalf("failed to create NetworkPolicy: %v", err)
+	}
+
+	if len(policy.Spec.PolicyTypes) != 1 || policy.Spec.PolicyTypes[0] != networkingv1.PolicyTypeEgress {
+		t.Errorf("expected an egress only policy, got %v", policy.Spec.PolicyTypes)
+	}
+
+	// API server, DNS, and the configured CIDR
+	if len(policy.Spec.Egress) != 3 {
+		t.Fatalf("expected 3 egress rules, got %d", len(policy.Spec.Egress))
+	}
+
+	apiRule := policy.Spec.Egress[0]
+	if len(apiRule.To) != 2 || apiRule.To[0].IPBlock.CIDR != "10.0.0.1/32" {
+		t.Errorf("expected API server addresses to be allowed, got %v", apiRule.To)
+	}
+	if len(apiRule.Ports) != 1 || apiRule.Ports[0].Port.IntValue() != 6443 {
+		t.Errorf("expected API server port to be allowed, got %v", apiRule.Ports)
+	}
+
+	if cidrRule := policy.Spec.Egress[2]; cidrRule.To[0].IPBlock.CIDR != "52.216.0.0/15" {
+		t.Errorf("expected configured CIDR to be allowed, got %v", cidrRule.To)
+	}
+
+	if _, err = client.NetworkingV1().NetworkPolicies(r.Namespace).Get(policy.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("NetworkPolicy was not created in the runner namespace: %v", err)
+	}
+}
+
+func TestCreateNetworkPolicyWithoutAPIServer(t *testing.T) {
+	def := *DefaultRunner
+	r := &def
+	r.Kube = fake.NewSimpleClientset()
+	r.Namespace = "runner-ns"
+
+	if _, err := r.createNetworkPolicy(nil); err == nil {
+		t.Errorf("expected an error when the API server endpoints can't be found")
+	}
+}
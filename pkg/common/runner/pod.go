@@ -3,10 +3,12 @@ package runner
 import (
 	"errors"
 	"fmt"
-	"log"
 	"path/filepath"
 	"time"
 
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/manifest"
 	"github.com/openshift/osde2e/pkg/common/util"
 	kubev1 "k8s.io/api/core/v1"
 	kerror "k8s.io/apimachinery/pkg/api/errors"
@@ -119,7 +121,7 @@ func (r *Runner) createPod() (pod *kubev1.Pod, err error) {
 		// Verify the configMap has been created before proceeding
 		err = wait.PollImmediate(fastPoll, configMapCreateTimeout, func() (done bool, err error) {
 			if configMap, err = r.Kube.CoreV1().ConfigMaps(r.Namespace).Get(configMap.Name, metav1.GetOptions{}); err != nil {
-				log.Printf("Error creating %s config map: %v", configMap.Name, err)
+				logging.Errorf("Error creating %s config map: %v", configMap.Name, err)
 			}
 			return err == nil, nil
 		})
@@ -133,7 +135,7 @@ func (r *Runner) createPod() (pod *kubev1.Pod, err error) {
 	for i, container := range pod.Spec.Containers {
 		if container.Name == "" || container.Name == r.Name {
 			pod.Spec.Containers[i].Name = r.Name
-			pod.Spec.Containers[i].Image = r.ImageName
+			pod.Spec.Containers[i].Image = manifest.PinnedImage(r.ImageName)
 
 			// run command in pod if, present
 			if len(r.Cmd) != 0 {
@@ -149,11 +151,15 @@ func (r *Runner) createPod() (pod *kubev1.Pod, err error) {
 	// setup git repos to be cloned in init containers
 	r.Repos.ConfigurePod(&pod.Spec)
 
+	if !config.Instance.Tests.DisableHarnessHardening {
+		hardenPodSpec(&pod.Spec)
+	}
+
 	// retry until Pod can be created or timeout occurs
 	var createdPod *kubev1.Pod
 	err = wait.PollImmediate(fastPoll, podCreateTimeout, func() (done bool, err error) {
 		if createdPod, err = r.Kube.CoreV1().Pods(r.Namespace).Create(pod); err != nil {
-			log.Printf("Error creating %s runner Pod: %v", r.Name, err)
+			logging.Errorf("Error creating %s runner Pod: %v", r.Name, err)
 		}
 		return err == nil, nil
 	})
@@ -172,6 +178,10 @@ func (r *Runner) waitForPodRunning(pod *kubev1.Pod) error {
 		} else if pod.Status.Phase == kubev1.PodFailed || pod.Status.Phase == kubev1.PodUnknown {
 			err = fmt.Errorf("failed waiting for Pod: the Pod has a phase of %s", pod.Status.Phase)
 		} else if pod.Status.Phase == kubev1.PodRunning {
+			// record the digests images resolved to so runs can be reproduced
+			for _, status := range pod.Status.ContainerStatuses {
+				manifest.RecordImage(status.Image, status.ImageID)
+			}
 			done = true
 		} else {
 			pendingCount++
@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"fmt"
+
+	kubev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RBACProfile is a named set of permissions that can be granted to a runner managed ServiceAccount.
+type RBACProfile string
+
+const (
+	// ProfileReadOnly can view resources across the cluster and administer the runner namespace.
+	ProfileReadOnly RBACProfile = "read-only"
+
+	// ProfileNamespaceAdmin can only administer the runner namespace.
+	ProfileNamespaceAdmin RBACProfile = "namespace-admin"
+
+	// ProfileClusterAdmin has full access to the cluster.
+	ProfileClusterAdmin RBACProfile = "cluster-admin"
+
+	// serviceAccountLabel is set on bindings so they can be found when the ServiceAccount is cleaned up.
+	serviceAccountLabel = "osde2e-service-account"
+)
+
+// ParseRBACProfile returns the RBACProfile with the given name.
+func ParseRBACProfile(name string) (RBACProfile, error) {
+	switch profile := RBACProfile(name); profile {
+	case ProfileReadOnly, ProfileNamespaceAdmin, ProfileClusterAdmin:
+		return profile, nil
+	}
+	return "", fmt.Errorf("unknown RBAC profile '%s', expected one of %s, %s, or %s", name, ProfileReadOnly, ProfileNamespaceAdmin, ProfileClusterAdmin)
+}
+
+// CreateServiceAccount creates a ServiceAccount in the runner namespace with the permissions of the given profile.
+// It should be removed with DeleteServiceAccount once it is no longer needed.
+func (r *Runner) CreateServiceAccount(profile RBACProfile) (*kubev1.ServiceAccount, error) {
+	if _, err := ParseRBACProfile(string(profile)); err != nil {
+		return nil, err
+	}
+
+	sa, err := r.Kube.CoreV1().ServiceAccounts(r.Namespace).Create(&kubev1.ServiceAccount{
+		ObjectMeta: r.meta(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating ServiceAccount: %v", err)
+	}
+
+	subjects := []rbacv1.Subject{
+		{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		},
+	}
+	bindingMeta := metav1.ObjectMeta{
+		GenerateName: sa.Name + "-",
+		Labels: map[string]string{
+			serviceAccountLabel: sa.Name,
+		},
+	}
+
+	var clusterRoles []string
+	switch profile {
+	case ProfileReadOnly:
+		clusterRoles = []string{"view"}
+	case ProfileClusterAdmin:
+		clusterRoles = []string{"cluster-admin"}
+	}
+
+	for _, clusterRole := range clusterRoles {
+		_, err = r.Kube.RbacV1().ClusterRoleBindings().Create(&rbacv1.ClusterRoleBinding{
+			ObjectMeta: bindingMeta,
+			Subjects:   subjects,
+			RoleRef:    clusterRoleRef(clusterRole),
+		})
+		if err != nil {
+			return sa, fmt.Errorf("error binding ClusterRole %s: %v", clusterRole, err)
+		}
+	}
+
+	// every profile can manage the runner namespace so harnesses can run workloads and push results
+	_, err = r.Kube.RbacV1().RoleBindings(r.Namespace).Create(&rbacv1.RoleBinding{
+		ObjectMeta: bindingMeta,
+		Subjects:   subjects,
+		RoleRef:    clusterRoleRef("admin"),
+	})
+	if err != nil {
+		return sa, fmt.Errorf("error binding namespace admin: %v", err)
+	}
+
+	r.Printf("Created ServiceAccount '%s/%s' with the %s profile", sa.Namespace, sa.Name, profile)
+	return sa, nil
+}
+
+// DeleteServiceAccount removes a ServiceAccount and the bindings created for it by CreateServiceAccount.
+func (r *Runner) DeleteServiceAccount(sa *kubev1.ServiceAccount) error {
+	listOpts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", serviceAccountLabel, sa.Name),
+	}
+
+	clusterRoleBindings, err := r.Kube.RbacV1().ClusterRoleBindings().List(listOpts)
+	if err != nil {
+		return fmt.Errorf("error listing ClusterRoleBindings: %v", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		if err = r.Kube.RbacV1().ClusterRoleBindings().Delete(binding.Name, &metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("error deleting ClusterRoleBinding %s: %v", binding.Name, err)
+		}
+	}
+
+	roleBindings, err := r.Kube.RbacV1().RoleBindings(sa.Namespace).List(listOpts)
+	if err != nil {
+		return fmt.Errorf("error listing RoleBindings: %v", err)
+	}
+	for _, binding := range roleBindings.Items {
+		if err = r.Kube.RbacV1().RoleBindings(sa.Namespace).Delete(binding.Name, &metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("error deleting RoleBinding %s: %v", binding.Name, err)
+		}
+	}
+
+	if err = r.Kube.CoreV1().ServiceAccounts(sa.Namespace).Delete(sa.Name, &metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("error deleting ServiceAccount: %v", err)
+	}
+	return nil
+}
+
+func clusterRoleRef(name string) rbacv1.RoleRef {
+	return rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     name,
+	}
+}
@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseRBACProfile(t *testing.T) {
+	for _, name := range []string{"read-only", "namespace-admin", "cluster-admin"} {
+		if _, err := ParseRBACProfile(name); err != nil {
+			t.Errorf("expected %s to be a valid profile: %v", name, err)
+		}
+	}
+
+	if _, err := ParseRBACProfile("root"); err == nil {
+		t.Errorf("expected an error for an unknown profile")
+	}
+}
+
+func TestServiceAccountProfiles(t *testing.T) {
+	tests := []struct {
+		profile      RBACProfile
+		clusterRoles []string
+	}{
+		{ProfileReadOnly, []string{"view"}},
+		{ProfileNamespaceAdmin, nil},
+		{ProfileClusterAdmin, []string{"cluster-admin"}},
+	}
+
+	for _, test := range tests {
+		client := fake.NewSimpleClientset()
+
+		def := *DefaultRunner
+		r := &def
+		r.Kube = client
+		r.Namespace = "runner-ns"
+
+		sa, err := r.CreateServiceAccount(test.profile)
+		if err != nil {
+			t.Fatalf("%s: failed to create ServiceAccount: %v", test.profile, err)
+		}
+
+		crbs, err := client.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("%s: failed to list ClusterRoleBindings: %v", test.profile, err)
+		}
+		if len(crbs.Items) != len(test.clusterRoles) {
+			t.Errorf("%s: expected %d ClusterRoleBindings, got %d", test.profile, len(test.clusterRoles), len(crbs.Items))
+		}
+		for i, crb := range crbs.Items {
+			if crb.RoleRef.Name != test.clusterRoles[i] {
+				t.Errorf("%s: expected ClusterRole %s, got %s", test.profile, test.clusterRoles[i], crb.RoleRef.Name)
+			}
+			if crb.Subjects[0].Name != sa.Name || crb.Subjects[0].Namespace != r.Namespace {
+				t.Errorf("%s: binding does not reference the created ServiceAccount", test.profile)
+			}
+		}
+
+		rbs, err := client.RbacV1().RoleBindings(r.Namespace).List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("%s: failed to list RoleBindings: %v", test.profile, err)
+		}
+		if len(rbs.Items) != 1 || rbs.Items[0].RoleRef.Name != "admin" {
+			t.Errorf("%s: expected the ServiceAccount to administer the runner namespace", test.profile)
+		}
+
+		if err = r.DeleteServiceAccount(sa); err != nil {
+			t.Fatalf("%s: failed to delete ServiceAccount: %v", test.profile, err)
+		}
+
+		crbs, _ = client.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+		rbs, _ = client.RbacV1().RoleBindings(r.Namespace).List(metav1.ListOptions{})
+		if len(crbs.Items) != 0 || len(rbs.Items) != 0 {
+			t.Errorf("%s: bindings were not cleaned up", test.profile)
+		}
+
+		if _, err = client.CoreV1().ServiceAccounts(r.Namespace).Get(sa.Name, metav1.GetOptions{}); err == nil {
+			t.Errorf("%s: ServiceAccount was not cleaned up", test.profile)
+		}
+	}
+}
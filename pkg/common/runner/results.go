@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"path"
 	"strconv"
 	"strings"
@@ -13,6 +12,8 @@ import (
 	"golang.org/x/net/html"
 	"k8s.io/apimachinery/pkg/util/wait"
 	restclient "k8s.io/client-go/rest"
+
+	"github.com/openshift/osde2e/pkg/common/logging"
 )
 
 var (
@@ -80,7 +81,7 @@ func (r *Runner) downloadLinks(n *html.Node, results map[string][]byte, director
 						newDirectory = path.Join(directory, a.Val)
 					}
 
-					log.Println("Downloading directory " + newDirectory)
+					logging.Debugf("Downloading directory %s", newDirectory)
 					directoryResults, err := r.retrieveResultsForDirectory(newDirectory)
 
 					if err != nil {
@@ -98,7 +99,7 @@ func (r *Runner) downloadLinks(n *html.Node, results map[string][]byte, director
 					}
 
 					filename := a.Val
-					log.Println("Downloading " + filename)
+					logging.Debugf("Downloading %s", filename)
 					results[path.Join(directory, filename)] = data
 				}
 			}
@@ -4,9 +4,10 @@ package runner
 import (
 	"fmt"
 	"log"
-	"os"
 
 	image "github.com/openshift/client-go/image/clientset/versioned"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/util"
 	kubev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,7 +31,7 @@ var DefaultRunner = &Runner{
 		RestartPolicy: kubev1.RestartPolicyNever,
 	},
 	OutputDir: "/test-run-results",
-	Logger:    log.New(os.Stderr, "", log.LstdFlags),
+	Logger:    log.New(logging.Writer(logging.InfoLevel), "", 0),
 }
 
 // Runner runs the OpenShift extended test suite within a cluster.
@@ -71,6 +72,9 @@ type Runner struct {
 	// Repos are cloned and mounted into the test Pod.
 	Repos
 
+	// RestrictEgress limits network egress from the runner's namespace to the Kubernetes API and configured CIDRs.
+	RestrictEgress bool
+
 	// Logger receives all messages.
 	*log.Logger
 
@@ -91,30 +95,37 @@ func (r *Runner) Run(timeoutInSeconds int, stopCh <-chan struct{}) (err error) {
 			return
 		}
 	}
-	log.Printf("Using '%s' as image for runner", r.ImageName)
+	logging.Debugf("Using '%s' as image for runner", r.ImageName)
+
+	if r.RestrictEgress && !config.Instance.Tests.DisableHarnessHardening {
+		logging.Infof("Restricting egress for %s runner...", r.Name)
+		if _, err = r.createNetworkPolicy(config.Instance.Tests.HarnessEgressCIDRs); err != nil {
+			return fmt.Errorf("error creating NetworkPolicy: %v", err)
+		}
+	}
 
-	log.Printf("Creating %s runner Pod...", r.Name)
+	logging.Infof("Creating %s runner Pod...", r.Name)
 	var pod *kubev1.Pod
 	if pod, err = r.createPod(); err != nil {
 		return
 	}
 
-	log.Printf("Waiting for %s runner Pod to start...", r.Name)
+	logging.Infof("Waiting for %s runner Pod to start...", r.Name)
 	if err = r.waitForPodRunning(pod); err != nil {
 		return
 	}
 	r.status = StatusRunning
 
-	log.Printf("Creating service for %s runner Pod...", r.Name)
+	logging.Infof("Creating service for %s runner Pod...", r.Name)
 	if r.svc, err = r.createService(pod); err != nil {
 		return
 	}
 
-	log.Printf("Waiting for endpoints of %s runner Pod with a timeout of %d seconds...", r.Name, timeoutInSeconds)
+	logging.Infof("Waiting for endpoints of %s runner Pod with a timeout of %d seconds...", r.Name, timeoutInSeconds)
 	var completionErr error
 	completionErr = r.waitForCompletion(pod.Name, timeoutInSeconds)
 
-	log.Printf("Collecting logs from containers on %s runner Pod...", r.Name)
+	logging.Infof("Collecting logs from containers on %s runner Pod...", r.Name)
 	if err = r.getAllLogsFromPod(pod.Name); err != nil {
 		return
 	}
@@ -123,7 +134,7 @@ func (r *Runner) Run(timeoutInSeconds int, stopCh <-chan struct{}) (err error) {
 		return completionErr
 	}
 
-	log.Printf("%s runner is done", r.Name)
+	logging.Infof("%s runner is done", r.Name)
 	r.status = StatusDone
 	return nil
 }
@@ -7,8 +7,8 @@ import (
 	"path/filepath"
 	"time"
 
-	"github.com/emicklei/go-restful/log"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/state"
 
 	"github.com/hashicorp/go-multierror"
@@ -100,7 +100,7 @@ func (r *Runner) getAllLogsFromPod(podName string) error {
 	var allErrors *multierror.Error
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		func() {
-			log.Printf("Trying to get logs for %s:%s", podName, containerStatus.Name)
+			logging.Debugf("Trying to get logs for %s:%s", podName, containerStatus.Name)
 			request := r.Kube.CoreV1().Pods(r.svc.Namespace).GetLogs(podName, &kubev1.PodLogOptions{Container: containerStatus.Name})
 
 			logStream, err := request.Stream()
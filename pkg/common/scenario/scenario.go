@@ -0,0 +1,89 @@
+// Package scenario fetches run scenarios that are maintained in a Git repository outside of osde2e.
+//
+// A scenario is a YAML file using the same schema as osde2e configs. It can define the suites to
+// run, the addon harnesses to use, upgrade behavior, and any other config option.
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// Fetch clones the scenario repository at the configured ref into dir and returns the paths of the
+// requested scenario files along with the commit they were read from.
+func Fetch(cfg config.ScenarioConfig, dir string) ([]string, string, error) {
+	if len(cfg.Names) == 0 {
+		return nil, "", fmt.Errorf("a scenario repo was provided but no scenarios were selected")
+	}
+
+	// without a ref, runs would use whatever was last pushed to the default branch
+	if cfg.Ref == "" {
+		return nil, "", fmt.Errorf("a scenario repo was provided but SCENARIO_REF doesn't pin a tag or commit")
+	}
+
+	if err := Clone(cfg.Repo, cfg.Ref, dir); err != nil {
+		return nil, "", err
+	}
+
+	commit, err := git(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, "", err
+	}
+
+	files, err := Files(filepath.Join(dir, cfg.Dir), cfg.Names)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logging.Infof("Using scenarios %s from %s at %s", strings.Join(cfg.Names, ","), cfg.Repo, commit)
+	return files, commit, nil
+}
+
+// Clone checks out the given ref of a Git repository into dir.
+func Clone(repo, ref, dir string) error {
+	if _, err := git("", "clone", "--quiet", repo, dir); err != nil {
+		return err
+	}
+
+	if ref != "" {
+		if _, err := git(dir, "checkout", "--quiet", ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files returns the paths of the named scenarios within dir, confirming each exists.
+func Files(dir string, names []string) ([]string, error) {
+	var files []string
+	for _, name := range names {
+		file := filepath.Join(dir, name+".yaml")
+		if _, err := os.Stat(file); err != nil {
+			return nil, fmt.Errorf("scenario %s not found: %v", name, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// git runs a git command, optionally within dir, and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,105 @@
+package scenario
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+)
+
+// setupRepo creates a local Git repository with a single scenario and returns its path and commit.
+func setupRepo(t *testing.T) (string, string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is required to test scenarios")
+	}
+
+	repo, err := ioutil.TempDir("", "scenario-repo")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	if err = os.MkdirAll(filepath.Join(repo, "scenarios"), os.FileMode(0755)); err != nil {
+		t.Fatalf("failed to create scenarios directory: %v", err)
+	}
+
+	scenario := []byte("tests:\n  testsToRun:\n  - '[Suite: e2e]'\n")
+	if err = ioutil.WriteFile(filepath.Join(repo, "scenarios", "nightly.yaml"), scenario, os.FileMode(0644)); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=osde2e", "-c", "user.email=osde2e@example.com", "commit", "--quiet", "-m", "add nightly scenario"},
+	} {
+		if _, err = git(repo, args...); err != nil {
+			t.Fatalf("failed to setup repo: %v", err)
+		}
+	}
+
+	commit, err := git(repo, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatalf("failed to get commit: %v", err)
+	}
+	return repo, commit
+}
+
+func TestFetch(t *testing.T) {
+	repo, commit := setupRepo(t)
+	defer os.RemoveAll(repo)
+
+	dir, err := ioutil.TempDir("", "scenario-clone")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files, fetched, err := Fetch(config.ScenarioConfig{
+		Repo:  repo,
+		Ref:   commit,
+		Dir:   "scenarios",
+		Names: []string{"nightly"},
+	}, dir)
+	if err != nil {
+		t.Fatalf("failed to fetch scenarios: %v", err)
+	}
+
+	if fetched != commit {
+		t.Errorf("expected scenarios from commit %s, got %s", commit, fetched)
+	}
+
+	if len(files) != 1 || filepath.Base(files[0]) != "nightly.yaml" {
+		t.Errorf("expected the nightly scenario to be returned, got %v", files)
+	}
+}
+
+func TestFetchMissingScenario(t *testing.T) {
+	repo, commit := setupRepo(t)
+	defer os.RemoveAll(repo)
+
+	dir, err := ioutil.TempDir("", "scenario-clone")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, _, err = Fetch(config.ScenarioConfig{
+		Repo:  repo,
+		Ref:   commit,
+		Dir:   "scenarios",
+		Names: []string{"weekly"},
+	}, dir); err == nil {
+		t.Errorf("expected an error for a scenario that doesn't exist")
+	}
+
+	if _, _, err = Fetch(config.ScenarioConfig{Repo: repo, Names: []string{"nightly"}}, dir); err == nil {
+		t.Errorf("expected an error when no ref is pinned")
+	}
+
+	if _, _, err = Fetch(config.ScenarioConfig{Repo: repo}, dir); err == nil {
+		t.Errorf("expected an error when no scenarios are selected")
+	}
+}
@@ -0,0 +1,17 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"strings"
+)
+
+// resolveFile returns the contents of a file, such as a key of a Kubernetes secret mounted into the pod osde2e runs
+// in. Trailing newlines are removed.
+func resolveFile(ref string) (string, error) {
+	path, key := splitKey(ref)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return selectKey(strings.TrimRight(string(data), "\r\n"), key)
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/openshift/osde2e/pkg/common/gcp"
+)
+
+// gcpSecretManagerURL is the URL of the GCP Secret Manager API.
+var gcpSecretManagerURL = "https://secretmanager.googleapis.com/v1/"
+
+// resolveGCP returns a GCP Secret Manager secret version, ex. projects/p/secrets/s/versions/latest. The version
+// defaults to latest if the reference names a secret.
+func resolveGCP(ref string) (string, error) {
+	name, key := splitKey(ref)
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	token, err := gcp.AccessToken()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := gcp.Request(http.MethodGet, gcpSecretManagerURL+name+":access", "Bearer "+token, nil)
+	if err != nil {
+		return "", fmt.Errorf("error accessing %s: %v", name, err)
+	}
+
+	version := struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}{}
+	if err = json.Unmarshal(data, &version); err != nil {
+		return "", fmt.Errorf("error parsing %s: %v", name, err)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(version.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("error decoding %s: %v", name, err)
+	}
+	return selectKey(string(secret), key)
+}
@@ -0,0 +1,69 @@
+// Package secrets resolves references to secrets kept in secrets backends, so credentials never need to be passed to
+// osde2e as plain environment variables. Options tagged as secrets may be set to a reference instead of a secret:
+//
+//	vault://<path>#<key>               a key of a Vault secret, using VAULT_ADDR and VAULT_TOKEN
+//	aws-sm://<name or ARN>[#<key>]      an AWS Secrets Manager secret, using the AWS SDK's credentials
+//	gcp-sm://<secret version>[#<key>]   a GCP Secret Manager secret version, ex. projects/p/secrets/s/versions/latest
+//	file://<path>[#<key>]               a file, such as a mounted Kubernetes secret
+//
+// The key selects a field of a secret holding a JSON object.
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/load"
+)
+
+func init() {
+	load.RegisterSecretResolver("vault", resolveVault)
+	load.RegisterSecretResolver("aws-sm", resolveAWS)
+	load.RegisterSecretResolver("gcp-sm", resolveGCP)
+	load.RegisterSecretResolver("file", resolveFile)
+}
+
+// resolveAWS returns an AWS Secrets Manager secret.
+func resolveAWS(ref string) (string, error) {
+	id, key := splitKey(ref)
+	secret, err := aws.SecretValue(id)
+	if err != nil {
+		return "", err
+	}
+	return selectKey(secret, key)
+}
+
+// splitKey splits a reference into the secret and the key of the field selected from it, if any.
+func splitKey(ref string) (string, string) {
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// selectKey returns a field of a secret holding a JSON object, or the whole secret if key is empty.
+func selectKey(secret, key string) (string, error) {
+	if key == "" {
+		return secret, nil
+	}
+
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("can't select key %s, the secret isn't a JSON object", key)
+	}
+	return fieldString(fields, key)
+}
+
+// fieldString returns a field of a secret as a string.
+func fieldString(fields map[string]interface{}, key string) (string, error) {
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("the secret has no key %s", key)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(value), nil
+}
@@ -0,0 +1,129 @@
+package secrets
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/gcp"
+)
+
+func TestSelectKey(t *testing.T) {
+	tests := []struct {
+		secret   string
+		key      string
+		expected string
+		err      bool
+	}{
+		{"plain", "", "plain", false},
+		{`{"token":"abc","port":8080}`, "token", "abc", false},
+		{`{"token":"abc","port":8080}`, "port", "8080", false},
+		{`{"token":"abc"}`, "missing", "", true},
+		{"plain", "token", "", true},
+	}
+
+	for _, test := range tests {
+		value, err := selectKey(test.secret, test.key)
+		if (err != nil) != test.err || value != test.expected {
+			t.Errorf("selecting %q from %s: expected %q (error %t), got %q: %v", test.key, test.secret, test.expected, test.err, value, err)
+		}
+	}
+}
+
+func TestResolveVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/osde2e":
+			fmt.Fprint(w, `{"data":{"data":{"ocm-token":"abc","slack":"def"},"metadata":{"version":3}}}`)
+		case "/v1/kv/osde2e":
+			fmt.Fprint(w, `{"data":{"token":"ghi"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("VAULT_ADDR", server.URL)
+	os.Setenv("VAULT_TOKEN", "vault-token")
+	defer os.Unsetenv("VAULT_ADDR")
+	defer os.Unsetenv("VAULT_TOKEN")
+
+	tests := []struct {
+		ref      string
+		expected string
+		err      bool
+	}{
+		{"secret/data/osde2e#ocm-token", "abc", false},
+		{"secret/data/osde2e", "", true},
+		{"kv/osde2e", "ghi", false},
+		{"secret/data/missing#token", "", true},
+	}
+
+	for _, test := range tests {
+		value, err := resolveVault(test.ref)
+		if (err != nil) != test.err || value != test.expected {
+			t.Errorf("resolving %s: expected %q (error %t), got %q: %v", test.ref, test.expected, test.err, value, err)
+		}
+	}
+}
+
+func TestResolveGCP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token" && r.Header.Get("Metadata-Flavor") == "Google":
+			fmt.Fprint(w, `{"access_token":"gcp-token","expires_in":3599,"token_type":"Bearer"}`)
+		case r.Header.Get("Authorization") != "Bearer gcp-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v1/projects/p/secrets/ocm/versions/latest:access":
+			// {"token":"abc"}
+			fmt.Fprint(w, `{"name":"projects/p/secrets/ocm/versions/2","payload":{"data":"eyJ0b2tlbiI6ImFiYyJ9"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	defer func(api, metadata string) {
+		gcpSecretManagerURL, gcp.MetadataTokenURL = api, metadata
+	}(gcpSecretManagerURL, gcp.MetadataTokenURL)
+	gcpSecretManagerURL, gcp.MetadataTokenURL = server.URL+"/v1/", server.URL+"/token"
+
+	if value, err := resolveGCP("projects/p/secrets/ocm#token"); err != nil || value != "abc" {
+		t.Errorf("expected abc, got %q: %v", value, err)
+	}
+
+	if _, err := resolveGCP("projects/p/secrets/missing/versions/1"); err == nil {
+		t.Errorf("expected a missing secret to fail")
+	}
+}
+
+func TestResolveFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(file, []byte("abc\n"), os.FileMode(0600)); err != nil {
+		t.Fatalf("failed to write secret: %v", err)
+	}
+
+	if value, err := resolveFile(file); err != nil || value != "abc" {
+		t.Errorf("expected abc, got %q: %v", value, err)
+	}
+
+	if _, err := resolveFile(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("expected a missing file to fail")
+	}
+}
@@ -0,0 +1,92 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// resolveVault returns a key of a Vault secret. Both the KV version 1 and version 2 secrets engines are supported;
+// the path is the API path of the secret, ex. secret/data/osde2e for version 2. The key may be omitted if the secret
+// has a single key.
+func resolveVault(ref string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR must be set to resolve Vault secrets")
+	}
+
+	token, err := vaultToken()
+	if err != nil {
+		return "", err
+	}
+
+	path, key := splitKey(ref)
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Vault returned %s for %s: %s", resp.Status, path, strings.TrimSpace(string(data)))
+	}
+
+	secret := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err = json.Unmarshal(data, &secret); err != nil {
+		return "", fmt.Errorf("error parsing Vault secret %s: %v", path, err)
+	}
+
+	fields := secret.Data
+	// KV version 2 secrets nest their fields under data, next to their metadata
+	if nested, ok := fields["data"].(map[string]interface{}); ok {
+		if _, versioned := fields["metadata"]; versioned {
+			fields = nested
+		}
+	}
+
+	if key == "" {
+		if len(fields) != 1 {
+			return "", fmt.Errorf("Vault secret %s has %d keys, select one with #<key>", path, len(fields))
+		}
+		for k := range fields {
+			key = k
+		}
+	}
+	return fieldString(fields, key)
+}
+
+// vaultToken returns the token set by VAULT_TOKEN or, like the Vault CLI, stored in ~/.vault-token.
+func vaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err == nil {
+		if data, err := ioutil.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+	return "", fmt.Errorf("VAULT_TOKEN must be set to resolve Vault secrets")
+}
@@ -0,0 +1,46 @@
+package spi
+
+import (
+	"errors"
+	"time"
+)
+
+// AccessRequest states.
+const (
+	// AccessRequestPending is an access request waiting for a decision.
+	AccessRequestPending = "Pending"
+
+	// AccessRequestApproved is an access request the customer approved.
+	AccessRequestApproved = "Approved"
+
+	// AccessRequestDenied is an access request the customer denied.
+	AccessRequestDenied = "Denied"
+
+	// AccessRequestExpired is an access request that wasn't decided on before its deadline.
+	AccessRequestExpired = "Expired"
+)
+
+// ErrAccessTransparencyUnsupported is returned when the environment doesn't support access transparency.
+var ErrAccessTransparencyUnsupported = errors.New("access transparency is not supported in this environment")
+
+// AccessTransparencyProvider is implemented by providers that support access protection. When access
+// protection is enabled for a cluster, SRE must request access and the customer must approve it.
+type AccessTransparencyProvider interface {
+	// AccessProtection returns whether access protection is enabled for a cluster.
+	AccessProtection(clusterID string) (bool, error)
+
+	// AccessRequests lists the access requests made for a cluster.
+	AccessRequests(clusterID string) ([]AccessRequest, error)
+}
+
+// AccessRequest is a request by SRE to access a cluster.
+type AccessRequest struct {
+	ID             string
+	ClusterID      string
+	SubscriptionID string
+	RequestedBy    string
+	Justification  string
+	State          string
+	CreatedAt      time.Time
+	DeadlineAt     time.Time
+}
@@ -0,0 +1,16 @@
+package spi
+
+// Alert is an alert firing on a cluster.
+type Alert struct {
+	// Name is the name of the alert, ex. KubeAPIDown.
+	Name string
+
+	// Severity is the severity of the alert: critical, warning, or none.
+	Severity string
+}
+
+// AlertsProvider is implemented by providers that can report the alerts firing on a cluster.
+type AlertsProvider interface {
+	// FiringAlerts returns the alerts firing on a cluster.
+	FiringAlerts(clusterID string) ([]Alert, error)
+}
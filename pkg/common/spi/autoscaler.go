@@ -0,0 +1,17 @@
+package spi
+
+// AutoscalerProvider is implemented by providers that can configure the cluster autoscaler.
+type AutoscalerProvider interface {
+	// ConfigureAutoscaler creates or updates the cluster autoscaler of a cluster.
+	ConfigureAutoscaler(clusterID string, settings AutoscalerSettings) error
+}
+
+// AutoscalerSettings are the cluster autoscaler options osde2e can configure.
+type AutoscalerSettings struct {
+	// MaxNodesTotal is the maximum number of nodes in the cluster.
+	MaxNodesTotal int
+
+	// ScaleDownUtilizationThreshold is the node utilization, between 0 and 1, below which a node may be removed.
+	// If empty, the provider's default is used.
+	ScaleDownUtilizationThreshold string
+}
@@ -0,0 +1,13 @@
+package spi
+
+// CloudRegion is a region of a cloud provider clusters can be created in.
+type CloudRegion struct {
+	ID          string
+	DisplayName string
+}
+
+// CloudMetadataProvider is implemented by providers that can describe the regions clusters can be created in.
+type CloudMetadataProvider interface {
+	// Regions returns the regions of a cloud provider.
+	Regions(cloudProvider string) ([]CloudRegion, error)
+}
@@ -22,6 +22,12 @@ const (
 	ClusterStateUninstalling ClusterState = "uninstalling"
 	// ClusterStateUnknown the cluster state is unknown.
 	ClusterStateUnknown ClusterState = "unknown"
+	// ClusterStatePoweringDown the cluster is being hibernated.
+	ClusterStatePoweringDown ClusterState = "powering_down"
+	// ClusterStateHibernating the cluster is hibernating.
+	ClusterStateHibernating ClusterState = "hibernating"
+	// ClusterStateResuming the cluster is resuming from hibernation.
+	ClusterStateResuming ClusterState = "resuming"
 )
 
 // Cluster is the intermediary cluster object between a provisioner and osde2e.
@@ -35,6 +41,10 @@ type Cluster struct {
 	state               ClusterState
 	flavour             string
 	addons              []string
+	consoleURL          string
+	apiURL              string
+	pageURL             string
+	computeNodes        int
 }
 
 // ID returns the cluster ID.
@@ -82,6 +92,26 @@ func (c *Cluster) Addons() []string {
 	return c.addons
 }
 
+// ConsoleURL returns the URL of the cluster's web console.
+func (c *Cluster) ConsoleURL() string {
+	return c.consoleURL
+}
+
+// APIURL returns the URL of the cluster's API server.
+func (c *Cluster) APIURL() string {
+	return c.apiURL
+}
+
+// PageURL returns the URL of the cluster's page in the provider's UI.
+func (c *Cluster) PageURL() string {
+	return c.pageURL
+}
+
+// ComputeNodes returns the number of compute nodes the cluster should have.
+func (c *Cluster) ComputeNodes() int {
+	return c.computeNodes
+}
+
 // ClusterBuilder is a struct that can create cluster objects.
 type ClusterBuilder struct {
 	id                  string
@@ -93,6 +123,10 @@ type ClusterBuilder struct {
 	state               ClusterState
 	flavour             string
 	addons              []string
+	consoleURL          string
+	apiURL              string
+	pageURL             string
+	computeNodes        int
 }
 
 // NewClusterBuilder creates a new cluster builder that can create a new cluster.
@@ -163,6 +197,30 @@ func (cb *ClusterBuilder) AddAddon(addon string) *ClusterBuilder {
 	return cb
 }
 
+// ConsoleURL sets the web console URL for a cluster builder.
+func (cb *ClusterBuilder) ConsoleURL(consoleURL string) *ClusterBuilder {
+	cb.consoleURL = consoleURL
+	return cb
+}
+
+// APIURL sets the API server URL for a cluster builder.
+func (cb *ClusterBuilder) APIURL(apiURL string) *ClusterBuilder {
+	cb.apiURL = apiURL
+	return cb
+}
+
+// PageURL sets the provider UI page URL for a cluster builder.
+func (cb *ClusterBuilder) PageURL(pageURL string) *ClusterBuilder {
+	cb.pageURL = pageURL
+	return cb
+}
+
+// ComputeNodes sets the number of compute nodes for a cluster builder.
+func (cb *ClusterBuilder) ComputeNodes(computeNodes int) *ClusterBuilder {
+	cb.computeNodes = computeNodes
+	return cb
+}
+
 // Build will create the cluster from the cluster build.
 func (cb *ClusterBuilder) Build() *Cluster {
 	return &Cluster{
@@ -175,5 +233,9 @@ func (cb *ClusterBuilder) Build() *Cluster {
 		state:               cb.state,
 		flavour:             cb.flavour,
 		addons:              cb.addons,
+		consoleURL:          cb.consoleURL,
+		apiURL:              cb.apiURL,
+		pageURL:             cb.pageURL,
+		computeNodes:        cb.computeNodes,
 	}
 }
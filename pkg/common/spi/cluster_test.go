@@ -18,6 +18,10 @@ func TestClusterBuilder(t *testing.T) {
 		ExpirationTimestamp(expirationTimestamp).
 		Flavour("test-flavour").
 		Addons([]string{"test-addon1", "test-addon2"}).
+		ConsoleURL("test-console-url").
+		APIURL("test-api-url").
+		PageURL("test-page-url").
+		ComputeNodes(4).
 		Build()
 
 	definedCluster := Cluster{
@@ -30,6 +34,10 @@ func TestClusterBuilder(t *testing.T) {
 		expirationTimestamp: expirationTimestamp,
 		flavour:             "test-flavour",
 		addons:              []string{"test-addon1", "test-addon2"},
+		consoleURL:          "test-console-url",
+		apiURL:              "test-api-url",
+		pageURL:             "test-page-url",
+		computeNodes:        4,
 	}
 
 	if !reflect.DeepEqual(definedCluster, *builtCluster) {
@@ -0,0 +1,26 @@
+package spi
+
+// ClusterIntentProvider is implemented by providers that can report how a cluster was requested to be configured,
+// so that the cluster can be checked for drift from it.
+type ClusterIntentProvider interface {
+	// ClusterIntent returns how the provider believes a cluster was requested to be configured.
+	ClusterIntent(clusterID string) (*ClusterIntent, error)
+}
+
+// ClusterIntent is how a cluster is configured. Zero values and nil lists are unknown, and aren't compared.
+type ClusterIntent struct {
+	// MinComputeNodes and MaxComputeNodes bound the number of compute nodes. They differ if compute nodes autoscale.
+	MinComputeNodes int `yaml:"minComputeNodes,omitempty"`
+	MaxComputeNodes int `yaml:"maxComputeNodes,omitempty"`
+
+	MachineCIDR string `yaml:"machineCIDR,omitempty"`
+	ServiceCIDR string `yaml:"serviceCIDR,omitempty"`
+	PodCIDR     string `yaml:"podCIDR,omitempty"`
+	HostPrefix  int    `yaml:"hostPrefix,omitempty"`
+
+	// IdentityProviders are the names of the cluster's identity providers.
+	IdentityProviders []string `yaml:"identityProviders,omitempty"`
+
+	// Addons are the IDs of the addons installed on the cluster.
+	Addons []string `yaml:"addons,omitempty"`
+}
@@ -0,0 +1,64 @@
+package spi
+
+import "time"
+
+// ClusterSearch describes an existing cluster a run can use instead of creating one. Empty fields match any cluster.
+type ClusterSearch struct {
+	Version       string
+	CloudProvider string
+	Region        string
+
+	// Properties are the properties, such as labels set when the cluster was created, a cluster must have.
+	Properties map[string]string
+
+	// Query is added to the search as is, in the provider's search syntax.
+	Query string
+}
+
+// ClusterSearchProvider is implemented by providers that can search for existing clusters.
+type ClusterSearchProvider interface {
+	// FindCluster returns the ID of a ready cluster matching the search that isn't claimed by a run, or an empty string
+	// if none match.
+	FindCluster(search ClusterSearch) (string, error)
+
+	// ClaimCluster claims a cluster for a run, so that other runs no longer find it. It returns false if another run
+	// claimed the cluster first.
+	ClaimCluster(clusterID, claim string) (bool, error)
+
+	// ReleaseCluster removes a run's claim on a cluster, so that other runs can find it again.
+	ReleaseCluster(clusterID, claim string) error
+
+	// NameTaken returns true if a cluster in any state already has a name.
+	NameTaken(name string) (bool, error)
+}
+
+// ClusterExistenceProvider is implemented by providers that can check whether clusters still exist.
+type ClusterExistenceProvider interface {
+	// ExistingClusters returns which of the cluster IDs belong to clusters that still exist, in any state.
+	ExistingClusters(ids []string) (map[string]bool, error)
+}
+
+// ClusterSummary describes an existing cluster.
+type ClusterSummary struct {
+	ID      string
+	Name    string
+	Version string
+	State   ClusterState
+	Created time.Time
+
+	// Expires is when the provider deletes the cluster. It's zero if the cluster doesn't expire.
+	Expires time.Time
+}
+
+// ClusterListProvider is implemented by providers that can list the clusters created by osde2e.
+type ClusterListProvider interface {
+	// OSDe2eClusters returns the clusters in any state that were created by osde2e using the provider's account.
+	OSDe2eClusters() ([]ClusterSummary, error)
+}
+
+// ClusterSampleProvider is implemented by providers that can list the existing clusters matching a search.
+type ClusterSampleProvider interface {
+	// SearchClusters returns the ready clusters matching the search, most recently created first. No more than limit
+	// clusters are returned, unless limit is 0.
+	SearchClusters(search ClusterSearch, limit int) ([]ClusterSummary, error)
+}
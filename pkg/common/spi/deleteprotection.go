@@ -0,0 +1,11 @@
+package spi
+
+// DeleteProtectionProvider is implemented by providers that can protect clusters from being deleted. Deleting a
+// protected cluster is refused until its protection is removed.
+type DeleteProtectionProvider interface {
+	// DeleteProtected returns true if a cluster is protected from being deleted.
+	DeleteProtected(clusterID string) (bool, error)
+
+	// SetDeleteProtection protects a cluster from being deleted, or removes its protection.
+	SetDeleteProtection(clusterID string, enabled bool) error
+}
@@ -0,0 +1,11 @@
+package spi
+
+// HibernationProvider is implemented by providers that can hibernate clusters. A hibernating cluster's
+// instances are stopped until it is resumed.
+type HibernationProvider interface {
+	// Hibernate starts hibernating a cluster and returns without waiting for it to finish.
+	Hibernate(clusterID string) error
+
+	// Resume starts resuming a hibernating cluster and returns without waiting for it to finish.
+	Resume(clusterID string) error
+}
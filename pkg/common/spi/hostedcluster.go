@@ -0,0 +1,27 @@
+package spi
+
+// HostedClusterProvider is implemented by providers that can manage clusters with hosted control planes. The
+// control plane and the node pools of a hosted cluster are upgraded independently of each other. Versions are
+// OpenShift release versions, such as 4.12.3.
+type HostedClusterProvider interface {
+	// HostedControlPlane returns whether a cluster has a hosted control plane.
+	HostedControlPlane(clusterID string) (bool, error)
+
+	// UpgradeControlPlane schedules an upgrade of a hosted control plane to version.
+	UpgradeControlPlane(clusterID, version string) error
+
+	// NodePools lists the node pools of a hosted cluster.
+	NodePools(clusterID string) ([]NodePool, error)
+
+	// UpgradeNodePool schedules an upgrade of a node pool to version. The version may not be newer than the
+	// control plane's.
+	UpgradeNodePool(clusterID, nodePoolID, version string) error
+}
+
+// NodePool is a group of worker nodes of a hosted cluster that share a version and configuration.
+type NodePool struct {
+	ID              string
+	Version         string
+	Replicas        int
+	CurrentReplicas int
+}
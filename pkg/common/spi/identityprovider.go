@@ -0,0 +1,29 @@
+package spi
+
+// IdentityProviderProvider is implemented by providers that can configure how users log in to clusters.
+type IdentityProviderProvider interface {
+	// AddOpenIDIdentityProvider adds an external OpenID Connect identity provider to a cluster and returns its ID.
+	AddOpenIDIdentityProvider(clusterID string, idp OpenIDIdentityProvider) (string, error)
+
+	// DeleteIdentityProvider removes an identity provider from a cluster.
+	DeleteIdentityProvider(clusterID, idpID string) error
+}
+
+// OpenIDIdentityProvider is an external OpenID Connect identity provider. Users are mapped to OpenShift users by
+// their claims, and the groups in their groups claim are synced to OpenShift groups.
+type OpenIDIdentityProvider struct {
+	// Name is the name of the identity provider on the cluster. It prefixes the identities of its users.
+	Name string
+
+	// Issuer is the URL of the OpenID Connect issuer.
+	Issuer string
+
+	ClientID     string
+	ClientSecret string
+
+	// GroupsClaim is the claim listing the groups of a user.
+	GroupsClaim string
+
+	// ExtraScopes are requested in addition to the openid scope, such as a scope needed for the groups claim.
+	ExtraScopes []string
+}
@@ -0,0 +1,9 @@
+package spi
+
+// MultiClusterProvider is implemented by providers that can create clusters alongside the cluster under test, for
+// testing multi-cluster offerings.
+type MultiClusterProvider interface {
+	// LaunchPeerCluster creates a cluster with the version and location of the cluster under test, shaped by a
+	// cluster spec, and returns its ID. The peer is deleted with DeleteCluster.
+	LaunchPeerCluster(name, clusterSpec string) (string, error)
+}
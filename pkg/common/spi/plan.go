@@ -0,0 +1,11 @@
+package spi
+
+// PlanProvider is implemented by providers that can describe the cluster they would create, so that it can be
+// reviewed before it's created.
+type PlanProvider interface {
+	// ClusterPayload returns the request LaunchCluster would send to create a cluster with the given name.
+	ClusterPayload(name string) (string, error)
+
+	// LaunchClusterFromPayload creates a cluster from a payload returned by ClusterPayload and returns its ID.
+	LaunchClusterFromPayload(payload string) (string, error)
+}
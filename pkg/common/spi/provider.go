@@ -74,3 +74,10 @@ type Provider interface {
 	// used. This is only a prefix, so "fast," "stable," etc.
 	CincinnatiChannel() CincinnatiChannel
 }
+
+// ProviderConfig is config owned by a provider rather than the global config. Providers register it with
+// the config loader so that their options and defaults are loaded alongside the global config.
+type ProviderConfig interface {
+	// Validate returns an error if the provider can't be used with the loaded config.
+	Validate() error
+}
@@ -0,0 +1,8 @@
+package spi
+
+// QuotaProvider is implemented by providers that can report how much cluster quota is left in their environment.
+type QuotaProvider interface {
+	// RemainingQuota returns how many more clusters of the cloud provider and availability zone type the account can
+	// create.
+	RemainingQuota(cloudProvider string, multiAZ bool) (int, error)
+}
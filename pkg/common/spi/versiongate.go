@@ -0,0 +1,20 @@
+package spi
+
+// VersionGateProvider is implemented by providers that require upgrades to some versions to be acknowledged first,
+// for example because APIs are removed in them. Versions are OpenShift release versions, such as 4.9.10.
+type VersionGateProvider interface {
+	// UnacknowledgedVersionGates lists the gates that must be acknowledged before a cluster can be upgraded to
+	// version.
+	UnacknowledgedVersionGates(clusterID, version string) ([]VersionGate, error)
+
+	// AcknowledgeVersionGate acknowledges a gate for a cluster.
+	AcknowledgeVersionGate(clusterID, gateID string) error
+}
+
+// VersionGate is a change in a version that must be acknowledged before upgrading to it.
+type VersionGate struct {
+	ID               string
+	Label            string
+	Description      string
+	DocumentationURL string
+}
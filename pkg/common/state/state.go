@@ -1,11 +1,20 @@
 // Package state provides common state across osde2e
 package state
 
-import "github.com/openshift/osde2e/pkg/common/spi"
+import (
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
 
 // Instance is the global state for osde2e runs
 var Instance = new(State)
 
+// Everything osde2e logs is tagged with the cluster under test and the current phase.
+func init() {
+	logging.SetFieldFunc(logging.ClusterIDField, func() string { return Instance.Cluster.ID })
+	logging.SetFieldFunc(logging.PhaseField, func() string { return Instance.Phase })
+}
+
 // State dictates the behavior of cluster tests.
 type State struct {
 	Cluster ClusterState `yaml:"cluster"`
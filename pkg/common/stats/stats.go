@@ -0,0 +1,13 @@
+// Package stats summarizes measurements taken by tests.
+package stats
+
+import "math"
+
+// Percentile returns the nearest-rank percentile of sorted values, which must not be empty.
+func Percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,12 @@
+package stats
+
+import "testing"
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for p, expected := range map[float64]float64{0: 1, 50: 5, 95: 10, 100: 10} {
+		if value := Percentile(sorted, p); value != expected {
+			t.Errorf("expected p%v to be %v, got %v", p, expected, value)
+		}
+	}
+}
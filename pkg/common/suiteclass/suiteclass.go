@@ -0,0 +1,129 @@
+// Package suiteclass sorts suites into classes. Blocking suites gate the run, while informing suites are reported
+// without failing it and have their own time budget, so that they always get to run.
+package suiteclass
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Blocking suites gate the run. Suites that aren't informing are blocking.
+	Blocking = "blocking"
+
+	// Informing suites are reported without failing the run.
+	Informing = "informing"
+)
+
+// Classes are all the suite classes, in the order they're reported.
+var Classes = []string{Blocking, Informing}
+
+// Of returns the class of a test context. Contexts belonging to one of the informing suites are informing.
+func Of(informingSuites []string, testContext string) string {
+	for _, suite := range informingSuites {
+		if suite != "" && strings.HasPrefix(testContext, suite) {
+			return Informing
+		}
+	}
+	return Blocking
+}
+
+// Budgets track the time the specs of each class have spent in a phase against the class's timeout.
+type Budgets struct {
+	mutex    sync.Mutex
+	timeouts map[string]time.Duration
+	spent    map[string]time.Duration
+}
+
+// NewBudgets creates budgets with a timeout for each class. Classes without a positive timeout are unlimited.
+func NewBudgets(timeouts map[string]time.Duration) *Budgets {
+	return &Budgets{
+		timeouts: timeouts,
+		spent:    map[string]time.Duration{},
+	}
+}
+
+// Spend charges the time a spec took to its class.
+func (b *Budgets) Spend(class string, d time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.spent[class] += d
+}
+
+// Exceeded returns whether a class has used up its timeout.
+func (b *Budgets) Exceeded(class string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	timeout := b.timeouts[class]
+	return timeout > 0 && b.spent[class] >= timeout
+}
+
+// Timeout returns the timeout of a class.
+func (b *Budgets) Timeout(class string) time.Duration {
+	return b.timeouts[class]
+}
+
+// Result tallies the tests of a class in a phase.
+type Result struct {
+	Phase    string `json:"phase"`
+	Class    string `json:"class"`
+	Tests    int    `json:"tests"`
+	Failures int    `json:"failures"`
+	Skipped  int    `json:"skipped"`
+}
+
+// Passed returns true if none of the class's tests failed.
+func (r Result) Passed() bool {
+	return r.Failures == 0
+}
+
+// Tally counts the results of each class in a phase.
+type Tally map[string]*Result
+
+// NewTally creates a tally with an empty result for every class.
+func NewTally(phase string) Tally {
+	tally := Tally{}
+	for _, class := range Classes {
+		tally[class] = &Result{Phase: phase, Class: class}
+	}
+	return tally
+}
+
+// Add counts a test of a class.
+func (t Tally) Add(class string, failed, skipped bool) {
+	result := t[class]
+	result.Tests++
+	if failed {
+		result.Failures++
+	}
+	if skipped {
+		result.Skipped++
+	}
+}
+
+// Results returns the results of every class, in the order they're reported.
+func (t Tally) Results() []Result {
+	var results []Result
+	for _, class := range Classes {
+		results = append(results, *t[class])
+	}
+	return results
+}
+
+// Passed returns whether the phase passed. Informing failures only fail it if failOnInforming is set.
+func (t Tally) Passed(failOnInforming bool) bool {
+	if !t[Blocking].Passed() {
+		return false
+	}
+	return !failOnInforming || t[Informing].Passed()
+}
+
+// Failures returns the number of failed tests across all classes.
+func (t Tally) Failures() int {
+	failures := 0
+	for _, result := range t {
+		failures += result.Failures
+	}
+	return failures
+}
@@ -0,0 +1,79 @@
+package suiteclass
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOf(t *testing.T) {
+	informing := []string{"[Suite: informing]", "[Suite: scale]"}
+
+	var tests = []struct {
+		context  string
+		expected string
+	}{
+		{"[Suite: informing] [OSD] Node OS content", Informing},
+		{"[Suite: scale] Performance", Informing},
+		{"[Suite: e2e] Pods", Blocking},
+		{"BeforeSuite", Blocking},
+	}
+
+	for _, test := range tests {
+		if class := Of(informing, test.context); class != test.expected {
+			t.Errorf("expected %s to be %s, got %s", test.context, test.expected, class)
+		}
+	}
+}
+
+func TestBudgets(t *testing.T) {
+	budgets := NewBudgets(map[string]time.Duration{Informing: 10 * time.Minute})
+
+	budgets.Spend(Blocking, time.Hour)
+	budgets.Spend(Informing, 9*time.Minute)
+	if budgets.Exceeded(Blocking) {
+		t.Error("expected blocking suites to be unlimited")
+	}
+	if budgets.Exceeded(Informing) {
+		t.Error("expected informing suites to be within their timeout")
+	}
+
+	budgets.Spend(Informing, time.Minute)
+	if !budgets.Exceeded(Informing) {
+		t.Error("expected informing suites to have used up their timeout")
+	}
+}
+
+func TestTallyPassed(t *testing.T) {
+	var tests = []struct {
+		description      string
+		blockingFailed   bool
+		informingFailed  bool
+		failOnInforming  bool
+		expectedPassed   bool
+		expectedFailures int
+	}{
+		{"all passed", false, false, false, true, 0},
+		{"blocking failure", true, false, false, false, 1},
+		{"informing failure", false, true, false, true, 1},
+		{"informing failure failing the run", false, true, true, false, 1},
+	}
+
+	for _, test := range tests {
+		tally := NewTally("install")
+		tally.Add(Blocking, test.blockingFailed, false)
+		tally.Add(Blocking, false, true)
+		tally.Add(Informing, test.informingFailed, false)
+
+		if passed := tally.Passed(test.failOnInforming); passed != test.expectedPassed {
+			t.Errorf("%s: expected passed to be %t, got %t", test.description, test.expectedPassed, passed)
+		}
+		if failures := tally.Failures(); failures != test.expectedFailures {
+			t.Errorf("%s: expected %d failures, got %d", test.description, test.expectedFailures, failures)
+		}
+	}
+
+	results := NewTally("upgrade").Results()
+	if len(results) != 2 || results[0].Class != Blocking || results[1].Class != Informing || results[0].Phase != "upgrade" {
+		t.Errorf("unexpected results %v", results)
+	}
+}
@@ -3,6 +3,7 @@ package templates
 
 import (
 	"fmt"
+	htmltemplate "html/template"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
@@ -13,6 +14,27 @@ import (
 
 // LoadTemplate will load a text template from osde2e's assets and compile it.
 func LoadTemplate(path string) (*template.Template, error) {
+	data, err := readAsset(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return template.New(filepath.Base(path)).Parse(string(data))
+}
+
+// LoadHTMLTemplate will load an HTML template from osde2e's assets and compile it. Values are escaped for the
+// context they're rendered in.
+func LoadHTMLTemplate(path string) (*htmltemplate.Template, error) {
+	data, err := readAsset(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return htmltemplate.New(filepath.Base(path)).Parse(string(data))
+}
+
+// readAsset reads a template from osde2e's assets.
+func readAsset(path string) ([]byte, error) {
 	var (
 		fileReader http.File
 		data       []byte
@@ -26,6 +48,5 @@ func LoadTemplate(path string) (*template.Template, error) {
 	if data, err = ioutil.ReadAll(fileReader); err != nil {
 		return nil, fmt.Errorf("unable to read template: %v", err)
 	}
-
-	return template.New(filepath.Base(path)).Parse(string(data))
+	return data, nil
 }
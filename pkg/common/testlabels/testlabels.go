@@ -0,0 +1,238 @@
+// Package testlabels labels suites, and selects them with expressions over their labels, ex. "informing && !storage".
+// Test packages declare the labels of their suites when they're imported.
+package testlabels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	mutex    sync.Mutex
+	declared = map[string][]string{}
+
+	// labelRegex matches valid labels.
+	labelRegex = regexp.MustCompile(`^[a-zA-Z0-9_.:/-]+$`)
+
+	// suiteNameRegex matches the name of a suite at the start of a test context, ex. "e2e" in "[Suite: e2e] Pods".
+	suiteNameRegex = regexp.MustCompile(`^\[Suite: ([^\]]+)\]`)
+)
+
+// Declare labels the test contexts starting with prefix. It returns true so that test packages can declare labels
+// as they're imported:
+//
+//	var _ = testlabels.Declare("[Suite: e2e] Storage", "storage")
+//
+// It panics if a label is invalid.
+func Declare(prefix string, labels ...string) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, label := range labels {
+		if !labelRegex.MatchString(label) {
+			panic(fmt.Sprintf("invalid label %q declared on %s", label, prefix))
+		}
+		declared[prefix] = append(declared[prefix], label)
+	}
+	return true
+}
+
+// Of returns the sorted labels of a test context: the name of its suite, and the labels declared on its prefixes.
+func Of(testContext string) []string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	set := map[string]bool{}
+	if match := suiteNameRegex.FindStringSubmatch(testContext); match != nil {
+		set[match[1]] = true
+	}
+	for prefix, labels := range declared {
+		if strings.HasPrefix(testContext, prefix) {
+			for _, label := range labels {
+				set[label] = true
+			}
+		}
+	}
+
+	labels := make([]string, 0, len(set))
+	for label := range set {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// Expression selects test contexts by their labels.
+type Expression struct {
+	text string
+	root node
+}
+
+// Parse parses a label expression. Labels are combined with && (and), || (or), and ! (not), and grouped with
+// parentheses. && binds tighter than ||.
+func Parse(text string) (*Expression, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty label expression")
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("invalid label expression %q: %v", text, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid label expression %q: unexpected %q", text, p.tokens[p.pos])
+	}
+	return &Expression{text: text, root: root}, nil
+}
+
+// Matches returns true if labels satisfy the expression.
+func (e *Expression) Matches(labels []string) bool {
+	set := map[string]bool{}
+	for _, label := range labels {
+		set[label] = true
+	}
+	return e.root.eval(set)
+}
+
+// String returns the expression as it was written.
+func (e *Expression) String() string {
+	return e.text
+}
+
+// node is a node of a parsed expression.
+type node interface {
+	eval(labels map[string]bool) bool
+}
+
+type labelNode string
+
+func (n labelNode) eval(labels map[string]bool) bool {
+	return labels[string(n)]
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(labels map[string]bool) bool {
+	return !n.operand.eval(labels)
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(labels map[string]bool) bool {
+	return n.left.eval(labels) && n.right.eval(labels)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(labels map[string]bool) bool {
+	return n.left.eval(labels) || n.right.eval(labels)
+}
+
+// tokenize splits an expression into operators, parentheses, and labels.
+func tokenize(text string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(text); {
+		switch {
+		case text[i] == ' ' || text[i] == '\t':
+			i++
+		case strings.HasPrefix(text[i:], "&&"), strings.HasPrefix(text[i:], "||"):
+			tokens = append(tokens, text[i:i+2])
+			i += 2
+		case text[i] == '!' || text[i] == '(' || text[i] == ')':
+			tokens = append(tokens, text[i:i+1])
+			i++
+		default:
+			end := i
+			for end < len(text) && labelRegex.MatchString(text[end:end+1]) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("invalid character %q in label expression %q", text[i], text)
+			}
+			tokens = append(tokens, text[i:end])
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parser parses tokens by recursive descent.
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+// peek returns the next token, or an empty string at the end.
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// or parses operands separated by ||.
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	for err == nil && p.peek() == "||" {
+		p.pos++
+		var right node
+		if right, err = p.and(); err == nil {
+			left = orNode{left, right}
+		}
+	}
+	return left, err
+}
+
+// and parses operands separated by &&.
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	for err == nil && p.peek() == "&&" {
+		p.pos++
+		var right node
+		if right, err = p.unary(); err == nil {
+			left = andNode{left, right}
+		}
+	}
+	return left, err
+}
+
+// unary parses a label, a negation, or a parenthesized expression.
+func (p *parser) unary() (node, error) {
+	token := p.peek()
+	p.pos++
+
+	switch token {
+	case "":
+		return nil, fmt.Errorf("unexpected end")
+	case "!":
+		operand, err := p.unary()
+		return notNode{operand}, err
+	case "(":
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return inner, nil
+	case "&&", "||", ")":
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+	return labelNode(token), nil
+}
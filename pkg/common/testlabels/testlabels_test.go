@@ -0,0 +1,56 @@
+package testlabels
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	Declare("[Suite: e2e] Storage", "storage", "disruptive")
+	Declare("[Suite: e2e]", "core")
+
+	tests := map[string][]string{
+		"[Suite: e2e] Storage":      {"core", "disruptive", "e2e", "storage"},
+		"[Suite: e2e] Pods":         {"core", "e2e"},
+		"[Suite: operators] [OSD]":  {"operators"},
+		"Cluster state should work": {},
+	}
+	for testContext, expected := range tests {
+		if labels := Of(testContext); !reflect.DeepEqual(labels, expected) {
+			t.Errorf("expected the labels of %q to be %v, got %v", testContext, expected, labels)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	labels := []string{"informing", "storage", "e2e"}
+
+	tests := map[string]bool{
+		"informing":                           true,
+		"!informing":                          false,
+		"informing && !storage":               false,
+		"informing && storage":                true,
+		"blocking || storage":                 true,
+		"blocking || upgrade && storage":      false,
+		"(blocking || upgrade) && storage":    false,
+		"(blocking || informing) && !upgrade": true,
+		"!!e2e":                               true,
+		"scale-nodes || e2e":                  true,
+	}
+	for text, expected := range tests {
+		expr, err := Parse(text)
+		if err != nil {
+			t.Errorf("failed to parse %q: %v", text, err)
+			continue
+		}
+		if matched := expr.Matches(labels); matched != expected {
+			t.Errorf("expected %q to match %v: %t, got %t", text, labels, expected, matched)
+		}
+	}
+
+	for _, text := range []string{"", "informing &&", "&& storage", "(informing", "informing)", "informing storage", "informing & storage", "!"} {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("expected %q to be invalid", text)
+		}
+	}
+}
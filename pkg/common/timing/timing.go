@@ -0,0 +1,124 @@
+// Package timing reports how long each spec takes and fails specs that take much longer than the time allotted to
+// them, so the duration of suites doesn't creep up unnoticed.
+package timing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// CSVReportFile is the name of the CSV report of spec durations written to each phase's directory.
+	CSVReportFile = "spec-durations.csv"
+
+	// JSONReportFile is the name of the JSON report of spec durations written to each phase's directory.
+	JSONReportFile = "spec-durations.json"
+)
+
+// Budget is the time allotted to specs.
+type Budget struct {
+	// Seconds are the seconds allotted to each spec, by name.
+	Seconds map[string]float64
+
+	// Factor is how many times its allotted time a spec may take before it fails.
+	Factor float64
+}
+
+// LoadBudget reads a budget file mapping spec names to the seconds allotted to them. If file is empty, no spec has
+// a budget. factor must be at least 1.
+func LoadBudget(file string, factor float64) (*Budget, error) {
+	if factor < 1 {
+		return nil, fmt.Errorf("duration budget factor must be at least 1, got %v", factor)
+	}
+
+	budget := &Budget{Seconds: map[string]float64{}, Factor: factor}
+	if file == "" {
+		return budget, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading duration budget: %v", err)
+	}
+
+	if err = yaml.Unmarshal(data, &budget.Seconds); err != nil {
+		return nil, fmt.Errorf("error parsing duration budget %s: %v", file, err)
+	}
+	return budget, nil
+}
+
+// Check returns an error if a spec took longer than its budget allows.
+func (b *Budget) Check(name string, seconds float64) error {
+	allotted, ok := b.Seconds[name]
+	if !ok || allotted <= 0 {
+		return nil
+	}
+
+	if limit := allotted * b.Factor; seconds > limit {
+		return fmt.Errorf("took %.1fs, more than %.1f times its budget of %.1fs", seconds, b.Factor, allotted)
+	}
+	return nil
+}
+
+// Spec is how long a spec took.
+type Spec struct {
+	Phase   string  `json:"phase"`
+	Name    string  `json:"name"`
+	Result  string  `json:"result"`
+	Seconds float64 `json:"seconds"`
+
+	// BudgetSeconds is the time allotted to the spec, if it has a budget.
+	BudgetSeconds float64 `json:"budgetSeconds,omitempty"`
+
+	// OverBudget is true if the spec failed because it took too long.
+	OverBudget bool `json:"overBudget,omitempty"`
+}
+
+// WriteReport writes the durations of specs to CSV and JSON files in dir, slowest first.
+func WriteReport(dir string, specs []Spec) error {
+	sorted := append([]Spec{}, specs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Seconds > sorted[j].Seconds
+	})
+
+	data, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, JSONReportFile), data, os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(dir, CSVReportFile))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"phase", "name", "result", "seconds", "budget_seconds", "over_budget"})
+	for _, spec := range sorted {
+		budget := ""
+		if spec.BudgetSeconds > 0 {
+			budget = strconv.FormatFloat(spec.BudgetSeconds, 'f', -1, 64)
+		}
+		w.Write([]string{
+			spec.Phase,
+			spec.Name,
+			spec.Result,
+			strconv.FormatFloat(spec.Seconds, 'f', 3, 64),
+			budget,
+			strconv.FormatBool(spec.OverBudget),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
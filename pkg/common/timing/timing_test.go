@@ -0,0 +1,93 @@
+package timing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBudget(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	file := filepath.Join(tmpDir, "budget.yaml")
+	if err = ioutil.WriteFile(file, []byte("'[Suite: e2e] slow spec': 60\n'[Suite: e2e] fast spec': 5\n"), os.FileMode(0644)); err != nil {
+		t.Fatalf("failed to write budget: %v", err)
+	}
+
+	budget, err := LoadBudget(file, 1.5)
+	if err != nil {
+		t.Fatalf("failed to load budget: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		seconds    float64
+		overBudget bool
+	}{
+		{"[Suite: e2e] slow spec", 85, false},
+		{"[Suite: e2e] slow spec", 95, true},
+		{"[Suite: e2e] fast spec", 7.6, true},
+		{"[Suite: e2e] spec without a budget", 1000, false},
+	}
+	for _, test := range tests {
+		if err := budget.Check(test.name, test.seconds); (err != nil) != test.overBudget {
+			t.Errorf("%s taking %.1fs: expected over budget to be %t, got %v", test.name, test.seconds, test.overBudget, err)
+		}
+	}
+
+	for _, factor := range []float64{0, 0.5, -1} {
+		if _, err := LoadBudget("", factor); err == nil {
+			t.Errorf("expected factor %v to be rejected", factor)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	specs := []Spec{
+		{Phase: "install", Name: "fast, with a comma", Result: "passed", Seconds: 1.5},
+		{Phase: "install", Name: "slow", Result: "failed", Seconds: 95, BudgetSeconds: 60, OverBudget: true},
+	}
+	if err = WriteReport(tmpDir, specs); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(tmpDir, CSVReportFile))
+	if err != nil {
+		t.Fatalf("failed to open CSV report: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV report: %v", err)
+	}
+	if len(rows) != 3 || rows[1][1] != "slow" || rows[1][4] != "60" || rows[1][5] != "true" || rows[2][1] != "fast, with a comma" || rows[2][3] != "1.500" {
+		t.Errorf("unexpected CSV report, expected slowest spec first: %v", rows)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(tmpDir, JSONReportFile))
+	if err != nil {
+		t.Fatalf("failed to read JSON report: %v", err)
+	}
+
+	var written []Spec
+	if err = json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("failed to parse JSON report: %v", err)
+	}
+	if len(written) != 2 || written[0] != specs[1] || written[1] != specs[0] {
+		t.Errorf("unexpected JSON report, expected slowest spec first: %+v", written)
+	}
+}
@@ -0,0 +1,90 @@
+package triage
+
+import (
+	"errors"
+	"net/http"
+)
+
+// The typed errors below let callers branch on what failed with errors.As rather than by matching error messages.
+// They don't change the message of the error they wrap, so failures are logged and matched by rules as before.
+
+// ProvisionError is a cluster that failed to be created or to finish installing.
+type ProvisionError struct {
+	ClusterID string
+	Err       error
+}
+
+func (e *ProvisionError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the error that caused the cluster to fail to provision.
+func (e *ProvisionError) Unwrap() error { return e.Err }
+
+// HealthcheckError is an installed or upgraded cluster that didn't become healthy.
+type HealthcheckError struct {
+	ClusterID string
+	Err       error
+}
+
+func (e *HealthcheckError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the error that kept the cluster from becoming healthy.
+func (e *HealthcheckError) Unwrap() error { return e.Err }
+
+// HarnessError is an addon test harness that failed to run or reported results osde2e can't read.
+type HarnessError struct {
+	Image string
+	Err   error
+}
+
+func (e *HarnessError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the error the harness failed with.
+func (e *HarnessError) Unwrap() error { return e.Err }
+
+// OCMError is a request OCM responded to with an error status.
+type OCMError struct {
+	Status int
+	Err    error
+}
+
+func (e *OCMError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the error OCM responded with.
+func (e *OCMError) Unwrap() error { return e.Err }
+
+// Unavailable returns true if OCM itself failed or was overloaded, rather than rejecting the request.
+func (e *OCMError) Unavailable() bool {
+	return e.Status >= http.StatusInternalServerError || e.Status == http.StatusTooManyRequests
+}
+
+// ClassifyError categorizes a failure like Classify, but uses the types of the errors it wraps before their messages.
+// OCM being unavailable and failures of the infrastructure found in the messages or logs are still ruled out first.
+func ClassifyError(err error, logs map[string][]byte) Classification {
+	var ocmErr *OCMError
+	if errors.As(err, &ocmErr) && ocmErr.Unavailable() {
+		return Classification{Category: OCMBackend, Rule: "ocm-unavailable"}
+	}
+
+	classification := Classify(err.Error(), logs)
+	if Infrastructure(classification.Category) {
+		return classification
+	}
+
+	var (
+		provisionErr   *ProvisionError
+		healthcheckErr *HealthcheckError
+		harnessErr     *HarnessError
+	)
+	switch {
+	case ocmErr != nil:
+		// requests OCM rejects are usually ones osde2e shouldn't have made
+		return Classification{Category: TestBug, Rule: "ocm-rejected"}
+	case errors.As(err, &provisionErr):
+		return Classification{Category: ProductBug, Rule: "provision-failed"}
+	case errors.As(err, &healthcheckErr):
+		return Classification{Category: ProductBug, Rule: "cluster-unhealthy"}
+	case errors.As(err, &harnessErr):
+		return Classification{Category: TestBug, Rule: "harness-failed"}
+	}
+	return classification
+}
@@ -0,0 +1,71 @@
+package triage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		logs        map[string][]byte
+		expected    Classification
+	}{
+		{
+			description: "OCM unavailable wrapped in a provision error",
+			err:         fmt.Errorf("could not launch cluster: %w", &ProvisionError{Err: &OCMError{Status: 502, Err: errors.New("api error: bad gateway")}}),
+			expected:    Classification{Category: OCMBackend, Rule: "ocm-unavailable"},
+		},
+		{
+			description: "request rejected by OCM",
+			err:         fmt.Errorf("could not launch cluster: %w", &ProvisionError{Err: &OCMError{Status: 400, Err: errors.New("api error: invalid version")}}),
+			expected:    Classification{Category: TestBug, Rule: "ocm-rejected"},
+		},
+		{
+			description: "provision error explained by the install logs",
+			err:         fmt.Errorf("failed waiting for cluster ready: %w", &ProvisionError{ClusterID: "abc", Err: errors.New("timed out waiting for the condition")}),
+			logs:        map[string][]byte{"install": []byte("Error: VcpuLimitExceeded")},
+			expected:    Classification{Category: CloudCapacity, Rule: "cloud-capacity"},
+		},
+		{
+			description: "provision error",
+			err:         fmt.Errorf("failed waiting for cluster ready: %w", &ProvisionError{ClusterID: "abc", Err: errors.New("timed out waiting for the condition")}),
+			expected:    Classification{Category: ProductBug, Rule: "provision-failed"},
+		},
+		{
+			description: "health check error",
+			err:         fmt.Errorf("failed waiting for cluster ready: %w", &HealthcheckError{ClusterID: "abc", Err: errors.New("nodes aren't ready")}),
+			expected:    Classification{Category: ProductBug, Rule: "cluster-unhealthy"},
+		},
+		{
+			description: "harness error",
+			err:         &HarnessError{Image: "quay.io/example/harness", Err: errors.New("job addon-tests-1 failed")},
+			expected:    Classification{Category: TestBug, Rule: "harness-failed"},
+		},
+		{
+			description: "untyped errors are classified by their message",
+			err:         errors.New("failed to upgrade cluster: timed out after 90 min waiting for upgrade"),
+			expected:    Classification{Category: ProductBug, Rule: "upgrade-failed"},
+		},
+	}
+
+	for _, test := range tests {
+		if classification := ClassifyError(test.err, test.logs); classification != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.description, test.expected, classification)
+		}
+	}
+}
+
+func TestTypedErrorsKeepMessages(t *testing.T) {
+	err := fmt.Errorf("could not launch cluster: %w", &ProvisionError{Err: &OCMError{Status: 503, Err: errors.New("api error: unavailable")}})
+	if expected := "could not launch cluster: api error: unavailable"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	var ocmErr *OCMError
+	if !errors.As(err, &ocmErr) || ocmErr.Status != 503 {
+		t.Errorf("expected to find the OCM error with its status in %v", err)
+	}
+}
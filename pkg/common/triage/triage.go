@@ -0,0 +1,92 @@
+// Package triage classifies why a run failed, so failures of the infrastructure a run depends on can be told apart
+// from failures of the product and of the tests themselves.
+package triage
+
+import (
+	"regexp"
+	"sort"
+)
+
+// Categories of failures.
+const (
+	// CloudCapacity failures are caused by the cloud provider running out of capacity or throttling requests.
+	CloudCapacity = "cloud-capacity"
+
+	// OCMBackend failures are caused by OCM being unavailable or failing to provision the cluster.
+	OCMBackend = "ocm-backend"
+
+	// ProductBug failures are clusters that didn't install, upgrade, or become healthy.
+	ProductBug = "product-bug"
+
+	// TestBug failures are caused by osde2e or its tests.
+	TestBug = "test-bug"
+
+	// Unknown failures didn't match any rule.
+	Unknown = "unknown"
+)
+
+// Categories are all of the categories a failure can be classified into.
+var Categories = []string{CloudCapacity, OCMBackend, ProductBug, TestBug, Unknown}
+
+// rule maps failures matching a pattern to a category. Rules that apply to logs are also matched against the
+// cluster's provisioning logs, otherwise only the failure itself is matched.
+type rule struct {
+	name     string
+	category string
+	logs     bool
+	pattern  *regexp.Regexp
+}
+
+// rules are checked in order, so the infrastructure is ruled out before a failure is blamed on the product.
+var rules = []rule{
+	{"cloud-capacity", CloudCapacity, true, regexp.MustCompile(`InsufficientInstanceCapacity|InstanceLimitExceeded|VcpuLimitExceeded|AddressLimitExceeded|VpcLimitExceeded|(?i)insufficient capacity|quota exceeded`)},
+	{"cloud-throttling", CloudCapacity, true, regexp.MustCompile(`RequestLimitExceeded|Throttling|Rate exceeded`)},
+	{"ocm-unavailable", OCMBackend, false, regexp.MustCompile(`(couldn't|could not) (create|retrieve|launch) cluster[^\n]*(status 50[0234]|(?i)service unavailable|connection refused|timeout)`)},
+	{"ocm-error", OCMBackend, false, regexp.MustCompile(`could not retrieve cluster information from OCM|error getting cluster provisioning client|Encountered error waiting for cluster`)},
+	{"provisioner-error", OCMBackend, true, regexp.MustCompile(`(?i)(hive|provisioner|clusterdeployment)[^\n]*(internal error|failed to provision)`)},
+	{"test-panic", TestBug, false, regexp.MustCompile(`(?i)runtime error|nil pointer dereference|test panicked`)},
+	{"test-setup", TestBug, false, regexp.MustCompile(`(?i)templated command|error (while )?loading [^\n]*template|unable to generate helper|error (loading|parsing) (config|manifest|cluster spec)`)},
+	{"install-errored", ProductBug, false, regexp.MustCompile(`the installation of cluster '[^']*' has errored`)},
+	{"bootstrap-failed", ProductBug, true, regexp.MustCompile(`(?i)bootstrap failed|failed to initialize the cluster|failed waiting for Kubernetes API`)},
+	{"cluster-unhealthy", ProductBug, false, regexp.MustCompile(`failed waiting for cluster ready|PollClusterHealth|timed out waiting for the condition|errored: `)},
+	{"upgrade-failed", ProductBug, false, regexp.MustCompile(`failed to upgrade cluster|(?i)upgrade[^\n]*(degraded|failing)`)},
+}
+
+// Classification is the category of a failure and the rule that put it there.
+type Classification struct {
+	// Phase is the phase the failure happened in. It is set by the caller.
+	Phase string `json:"phase,omitempty"`
+
+	Category string `json:"category"`
+	Rule     string `json:"rule,omitempty"`
+}
+
+// Classify categorizes a failure using the failure and the cluster's provisioning logs.
+func Classify(failure string, logs map[string][]byte) Classification {
+	// logs are checked in a stable order so classifications are reproducible
+	names := make([]string, 0, len(logs))
+	for name := range logs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, r := range rules {
+		if r.pattern.MatchString(failure) {
+			return Classification{Category: r.category, Rule: r.name}
+		}
+		if !r.logs {
+			continue
+		}
+		for _, name := range names {
+			if r.pattern.Match(logs[name]) {
+				return Classification{Category: r.category, Rule: r.name}
+			}
+		}
+	}
+	return Classification{Category: Unknown}
+}
+
+// Infrastructure returns true for categories of failures that aren't caused by what is being tested.
+func Infrastructure(category string) bool {
+	return category == CloudCapacity || category == OCMBackend
+}
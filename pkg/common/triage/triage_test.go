@@ -0,0 +1,62 @@
+package triage
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		description string
+		failure     string
+		logs        map[string][]byte
+		expected    Classification
+	}{
+		{
+			description: "cloud capacity in the failure",
+			failure:     "could not launch cluster: couldn't create cluster: InsufficientInstanceCapacity: no m5.xlarge",
+			expected:    Classification{Category: CloudCapacity, Rule: "cloud-capacity"},
+		},
+		{
+			description: "errored install explained by the install logs",
+			failure:     "failed waiting for cluster ready: the installation of cluster 'abc' has errored",
+			logs:        map[string][]byte{"install": []byte("level=error msg=\"Error: VcpuLimitExceeded: You have requested more vCPU capacity\"")},
+			expected:    Classification{Category: CloudCapacity, Rule: "cloud-capacity"},
+		},
+		{
+			description: "errored install with a failed bootstrap",
+			failure:     "failed waiting for cluster ready: the installation of cluster 'abc' has errored",
+			logs:        map[string][]byte{"install": []byte("level=fatal msg=\"Bootstrap failed to complete\"")},
+			expected:    Classification{Category: ProductBug, Rule: "install-errored"},
+		},
+		{
+			description: "OCM unavailable",
+			failure:     "could not retrieve cluster information from OCM: couldn't retrieve cluster 'abc': status 503",
+			expected:    Classification{Category: OCMBackend, Rule: "ocm-unavailable"},
+		},
+		{
+			description: "test panic isn't matched in logs",
+			failure:     "failed waiting for cluster ready: timed out waiting for the condition",
+			logs:        map[string][]byte{"install": []byte("panic: runtime error: invalid memory address")},
+			expected:    Classification{Category: ProductBug, Rule: "cluster-unhealthy"},
+		},
+		{
+			description: "test bug",
+			failure:     "failed seeding cluster before upgrade: error while loading seed template: file does not exist",
+			expected:    Classification{Category: TestBug, Rule: "test-setup"},
+		},
+		{
+			description: "upgrade failure",
+			failure:     "failed to upgrade cluster: timed out after 90 min waiting for upgrade",
+			expected:    Classification{Category: ProductBug, Rule: "upgrade-failed"},
+		},
+		{
+			description: "unmatched",
+			failure:     "something unexpected happened",
+			expected:    Classification{Category: Unknown},
+		},
+	}
+
+	for _, test := range tests {
+		if classification := Classify(test.failure, test.logs); classification != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.description, test.expected, classification)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package upgrade
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/runner"
+)
+
+const (
+	// EtcdReportFile is the name of the report comparing etcd before and after an upgrade.
+	EtcdReportFile = "etcd-report.json"
+
+	// etcdStatusFile is the name of the file the etcd status is written to by the runner.
+	etcdStatusFile = "etcd-status.json"
+
+	// etcdStatusCmd collects the status of every etcd member from one of the etcd pods.
+	etcdStatusCmd = "pod=$(oc get pods -n openshift-etcd -l app=etcd -o jsonpath='{.items[0].metadata.name}') && " +
+		"oc exec -n openshift-etcd $pod -c etcdctl -- etcdctl endpoint status --cluster -w json > %s/" + etcdStatusFile
+
+	etcdStatusTimeoutInSeconds = 300
+)
+
+// EtcdMemberStatus is the status of an etcd member as reported by etcdctl.
+type EtcdMemberStatus struct {
+	Endpoint string `json:"Endpoint"`
+	Status   struct {
+		Header struct {
+			Revision int64 `json:"revision"`
+		} `json:"header"`
+		DBSize      int64 `json:"dbSize"`
+		DBSizeInUse int64 `json:"dbSizeInUse"`
+	} `json:"Status"`
+}
+
+// EtcdMemberReport compares an etcd member before and after an upgrade.
+type EtcdMemberReport struct {
+	Endpoint string `json:"endpoint"`
+
+	DBSizeBefore      int64 `json:"dbSizeBefore"`
+	DBSizeAfter       int64 `json:"dbSizeAfter"`
+	DBSizeInUseBefore int64 `json:"dbSizeInUseBefore"`
+	DBSizeInUseAfter  int64 `json:"dbSizeInUseAfter"`
+
+	// FragmentationBefore and FragmentationAfter are the fraction of the database that isn't in use.
+	// Compaction frees space in the database and defragmentation returns it to the filesystem.
+	FragmentationBefore float64 `json:"fragmentationBefore"`
+	FragmentationAfter  float64 `json:"fragmentationAfter"`
+
+	// Revisions is the number of revisions written during the upgrade.
+	Revisions int64 `json:"revisions"`
+
+	// Defragmented is true if the database shrank during the upgrade, which only happens on defragmentation.
+	Defragmented bool `json:"defragmented"`
+}
+
+// EtcdStatus collects the status of the etcd members in the cluster.
+func EtcdStatus(h *helper.H) ([]EtcdMemberStatus, error) {
+	previous := h.ServiceAccount
+	defer h.SetServiceAccount(previous)
+	h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
+
+	r := h.Runner(fmt.Sprintf(etcdStatusCmd, runner.DefaultRunner.OutputDir))
+	r.Name = "etcd-status"
+
+	stopCh := make(chan struct{})
+	if err := r.Run(etcdStatusTimeoutInSeconds, stopCh); err != nil {
+		return nil, fmt.Errorf("error collecting etcd status: %v", err)
+	}
+
+	results, err := r.RetrieveResults()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving etcd status: %v", err)
+	}
+
+	members := []EtcdMemberStatus{}
+	if err = json.Unmarshal(results[etcdStatusFile], &members); err != nil {
+		return nil, fmt.Errorf("error parsing etcd status: %v", err)
+	}
+	return members, nil
+}
+
+// CompareEtcdStatus reports how the etcd members changed between two statuses.
+func CompareEtcdStatus(before, after []EtcdMemberStatus) []EtcdMemberReport {
+	previous := map[string]EtcdMemberStatus{}
+	for _, member := range before {
+		previous[member.Endpoint] = member
+	}
+
+	reports := []EtcdMemberReport{}
+	for _, member := range after {
+		report := EtcdMemberReport{
+			Endpoint:           member.Endpoint,
+			DBSizeAfter:        member.Status.DBSize,
+			DBSizeInUseAfter:   member.Status.DBSizeInUse,
+			FragmentationAfter: fragmentation(member),
+		}
+
+		if prev, ok := previous[member.Endpoint]; ok {
+			report.DBSizeBefore = prev.Status.DBSize
+			report.DBSizeInUseBefore = prev.Status.DBSizeInUse
+			report.FragmentationBefore = fragmentation(prev)
+			report.Revisions = member.Status.Header.Revision - prev.Status.Header.Revision
+			report.Defragmented = member.Status.DBSize < prev.Status.DBSize
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// WriteEtcdReport writes the comparison of etcd before and after an upgrade to the report directory.
+func WriteEtcdReport(reports []EtcdMemberReport) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(config.Instance.ReportDir, phase.UpgradePhase)
+	if err = os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, EtcdReportFile), data, os.FileMode(0644))
+}
+
+func fragmentation(member EtcdMemberStatus) float64 {
+	if member.Status.DBSize == 0 {
+		return 0
+	}
+	return float64(member.Status.DBSize-member.Status.DBSizeInUse) / float64(member.Status.DBSize)
+}
+
+// largestDBSize returns the size of the largest etcd database in a status.
+func largestDBSize(members []EtcdMemberStatus) int64 {
+	var size int64
+	for _, member := range members {
+		if member.Status.DBSize > size {
+			size = member.Status.DBSize
+		}
+	}
+	return size
+}
@@ -4,13 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strings"
 	"sync"
 
 	"github.com/Masterminds/semver"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	"github.com/openshift/osde2e/pkg/common/providers"
 	"github.com/openshift/osde2e/pkg/common/state"
@@ -91,7 +91,7 @@ func (s *smallCincinnatiCache) loadCincinnatiData(channel string) error {
 		currentVersion, err := semver.NewVersion(release.Version)
 
 		if err != nil {
-			log.Printf("Unable to parse version for %s, skipping", release.Version)
+			logging.Warnf("Unable to parse version for %s, skipping", release.Version)
 			continue
 		}
 
@@ -217,7 +217,7 @@ func VersionToChannel(version *semver.Version) (string, error) {
 
 func ensureReleasePrefix(release string) string {
 	if len(release) > 0 && !strings.Contains(release, "openshift-v") {
-		log.Printf("Version %s didn't have prefix. Adding....", release)
+		logging.Debugf("Version %s didn't have prefix. Adding....", release)
 		release = "openshift-v" + release
 	}
 	return release
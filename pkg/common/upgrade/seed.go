@@ -0,0 +1,231 @@
+package upgrade
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/markbates/pkger"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/ratelimit"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// SeedLabel marks the namespaces created to seed a cluster before upgrade.
+	SeedLabel = "osde2e-upgrade-seed"
+
+	// seedWorkers is the number of namespaces seeded at the same time.
+	seedWorkers = 10
+)
+
+// SeedProfile describes the objects created in a cluster before upgrading it so that etcd holds a
+// production-like amount of data. Profiles can be derived from fleet telemetry and supplied as a file.
+type SeedProfile struct {
+	// Namespaces is the number of namespaces to create.
+	Namespaces int `yaml:"namespaces"`
+
+	// ConfigMapsPerNamespace is the number of ConfigMaps created in each namespace.
+	ConfigMapsPerNamespace int `yaml:"configMapsPerNamespace"`
+
+	// SecretsPerNamespace is the number of Secrets created in each namespace.
+	SecretsPerNamespace int `yaml:"secretsPerNamespace"`
+
+	// ObjectSize is the number of bytes of data in each ConfigMap and Secret.
+	ObjectSize int `yaml:"objectSize"`
+}
+
+// Objects returns the number of objects the profile creates.
+func (p *SeedProfile) Objects() int {
+	return p.Namespaces * (1 + p.ConfigMapsPerNamespace + p.SecretsPerNamespace)
+}
+
+// LoadSeedProfile loads a profile from a file or, if no such file exists, one of the profiles in /assets/upgrade/seed.
+func LoadSeedProfile(name string) (*SeedProfile, error) {
+	var data []byte
+	if _, err := os.Stat(name); err == nil {
+		if data, err = ioutil.ReadFile(name); err != nil {
+			return nil, fmt.Errorf("error reading seed profile %s: %v", name, err)
+		}
+	} else {
+		file, err := pkger.Open(filepath.Join("/assets/upgrade/seed", name+".yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("seed profile %s is not a file or built-in profile: %v", name, err)
+		}
+		defer file.Close()
+
+		if data, err = ioutil.ReadAll(file); err != nil {
+			return nil, fmt.Errorf("error reading seed profile %s: %v", name, err)
+		}
+	}
+
+	profile := &SeedProfile{}
+	if err := yaml.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("error parsing seed profile %s: %v", name, err)
+	}
+	return profile, nil
+}
+
+// seedClient returns a clientset for the cluster that isn't held back by the default client rate limits, or by
+// client rate limits lower than the rate objects are created at.
+func seedClient() (kubernetes.Interface, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(state.Instance.Kubeconfig.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("error generating restconfig: %v", err)
+	}
+	restConfig.QPS = 50
+	restConfig.Burst = 100
+	if rate := config.Instance.Scale.CreateRate; float32(rate) > restConfig.QPS {
+		restConfig.QPS, restConfig.Burst = float32(rate), 2*rate
+	}
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// Seed creates the namespaces and objects described by a profile, waiting on the limiter before creating each one.
+func Seed(kube kubernetes.Interface, profile *SeedProfile, limiter *ratelimit.Limiter) error {
+	logging.Infof("Seeding cluster with %d objects across %d namespaces...", profile.Objects(), profile.Namespaces)
+	start := time.Now()
+
+	data := strings.Repeat("x", profile.ObjectSize)
+	namespaces := make(chan int)
+	errs := make(chan error, profile.Namespaces)
+
+	var wg sync.WaitGroup
+	for w := 0; w < seedWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range namespaces {
+				if err := seedNamespace(kube, fmt.Sprintf("%s-%d", SeedLabel, i), profile, data, limiter); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < profile.Namespaces; i++ {
+		namespaces <- i
+	}
+	close(namespaces)
+	wg.Wait()
+	close(errs)
+
+	if err, failed := <-errs; failed {
+		return err
+	}
+
+	logging.Infof("Seeded %d objects in %v, %.1f objects/s.", profile.Objects(), time.Since(start).Round(time.Second), limiter.Rate())
+	return nil
+}
+
+// seedNamespace creates a single seeded namespace and its objects.
+func seedNamespace(kube kubernetes.Interface, name string, profile *SeedProfile, data string, limiter *ratelimit.Limiter) error {
+	ns := &kubev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{SeedLabel: "true"},
+		},
+	}
+	limiter.Wait()
+	if _, err := kube.CoreV1().Namespaces().Create(ns); err != nil {
+		return fmt.Errorf("error creating seed namespace %s: %v", name, err)
+	}
+
+	for i := 0; i < profile.ConfigMapsPerNamespace; i++ {
+		cm := &kubev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("seed-%d", i)},
+			Data:       map[string]string{"data": data},
+		}
+		limiter.Wait()
+		if _, err := kube.CoreV1().ConfigMaps(name).Create(cm); err != nil {
+			return fmt.Errorf("error creating seed ConfigMap in %s: %v", name, err)
+		}
+	}
+
+	for i := 0; i < profile.SecretsPerNamespace; i++ {
+		secret := &kubev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("seed-%d", i)},
+			StringData: map[string]string{"data": data},
+		}
+		limiter.Wait()
+		if _, err := kube.CoreV1().Secrets(name).Create(secret); err != nil {
+			return fmt.Errorf("error creating seed Secret in %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// RemoveSeed starts deleting the namespaces created by Seed. It does not wait for them to be removed.
+func RemoveSeed(kube kubernetes.Interface) error {
+	namespaces, err := kube.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: SeedLabel + "=true"})
+	if err != nil {
+		return fmt.Errorf("error listing seed namespaces: %v", err)
+	}
+
+	for _, ns := range namespaces.Items {
+		if err = kube.CoreV1().Namespaces().Delete(ns.Name, &metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("error deleting seed namespace %s: %v", ns.Name, err)
+		}
+	}
+	return nil
+}
+
+// seedCluster seeds the cluster using a profile and returns the status of etcd once it has been seeded. If seeding
+// fails, whatever was seeded is removed.
+func seedCluster(h *helper.H, profileName string) ([]EtcdMemberStatus, error) {
+	profile, err := LoadSeedProfile(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	kube, err := seedClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Seed(kube, profile, ratelimit.Creations()); err != nil {
+		if removeErr := RemoveSeed(kube); removeErr != nil {
+			logging.Warnf("Unable to remove the objects seeded before seeding failed: %v", removeErr)
+		}
+		return nil, err
+	}
+
+	before, err := EtcdStatus(h)
+	if err != nil {
+		logging.Warnf("Unable to collect etcd status before upgrade: %v", err)
+	}
+	return before, nil
+}
+
+// reportSeededUpgrade reports how etcd changed during the upgrade and then removes the seeded objects.
+func reportSeededUpgrade(h *helper.H, before []EtcdMemberStatus) {
+	if after, err := EtcdStatus(h); err != nil {
+		logging.Warnf("Unable to collect etcd status after upgrade: %v", err)
+	} else {
+		if err = WriteEtcdReport(CompareEtcdStatus(before, after)); err != nil {
+			logging.Warnf("Unable to write etcd report: %v", err)
+		}
+		metadata.Instance.SetEtcdDBSize(float64(largestDBSize(before)), float64(largestDBSize(after)))
+	}
+
+	kube, err := seedClient()
+	if err == nil {
+		err = RemoveSeed(kube)
+	}
+	if err != nil {
+		logging.Warnf("Unable to remove seeded objects: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package upgrade
+
+import (
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift/osde2e/pkg/common/ratelimit"
+)
+
+func TestLoadSeedProfile(t *testing.T) {
+	for _, name := range []string{"small", "medium", "large"} {
+		profile, err := LoadSeedProfile(name)
+		if err != nil {
+			t.Fatalf("failed to load built-in profile %s: %v", name, err)
+		}
+
+		if profile.Objects() == 0 || profile.ObjectSize == 0 {
+			t.Errorf("built-in profile %s should create objects: %+v", name, profile)
+		}
+	}
+
+	if _, err := LoadSeedProfile("does-not-exist"); err == nil {
+		t.Errorf("expected an error loading a profile that doesn't exist")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	other := &kubev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	kube := fake.NewSimpleClientset(other)
+
+	profile := &SeedProfile{
+		Namespaces:             12,
+		ConfigMapsPerNamespace: 3,
+		SecretsPerNamespace:    2,
+		ObjectSize:             16,
+	}
+	if err := Seed(kube, profile, ratelimit.New(0, 0)); err != nil {
+		t.Fatalf("failed to seed cluster: %v", err)
+	}
+
+	namespaces, err := kube.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: SeedLabel + "=true"})
+	if err != nil {
+		t.Fatalf("failed to list namespaces: %v", err)
+	}
+	if len(namespaces.Items) != profile.Namespaces {
+		t.Fatalf("expected %d seeded namespaces, got %d", profile.Namespaces, len(namespaces.Items))
+	}
+
+	cms, err := kube.CoreV1().ConfigMaps(namespaces.Items[0].Name).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list ConfigMaps: %v", err)
+	}
+	if len(cms.Items) != profile.ConfigMapsPerNamespace || len(cms.Items[0].Data["data"]) != profile.ObjectSize {
+		t.Errorf("unexpected seeded ConfigMaps: %v", cms.Items)
+	}
+
+	if err = RemoveSeed(kube); err != nil {
+		t.Fatalf("failed to remove seed: %v", err)
+	}
+
+	remaining, err := kube.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list namespaces: %v", err)
+	}
+	if len(remaining.Items) != 1 || remaining.Items[0].Name != "other" {
+		t.Errorf("only seeded namespaces should be removed, %d remain", len(remaining.Items))
+	}
+}
+
+func TestCompareEtcdStatus(t *testing.T) {
+	status := func(endpoint string, revision, size, inUse int64) EtcdMemberStatus {
+		member := EtcdMemberStatus{Endpoint: endpoint}
+		member.Status.Header.Revision = revision
+		member.Status.DBSize = size
+		member.Status.DBSizeInUse = inUse
+		return member
+	}
+
+	before := []EtcdMemberStatus{status("a", 100, 1000, 800), status("b", 100, 1000, 800)}
+	after := []EtcdMemberStatus{status("a", 250, 1200, 600), status("b", 250, 700, 600)}
+
+	reports := CompareEtcdStatus(before, after)
+	if len(reports) != 2 {
+		t.Fatalf("expected a report for each member, got %v", reports)
+	}
+
+	if reports[0].Revisions != 150 || reports[0].Defragmented || reports[0].FragmentationAfter != 0.5 {
+		t.Errorf("unexpected report for a grown member: %+v", reports[0])
+	}
+
+	if !reports[1].Defragmented || reports[1].FragmentationBefore != 0.2 {
+		t.Errorf("unexpected report for a defragmented member: %+v", reports[1])
+	}
+
+	if largestDBSize(after) != 1200 {
+		t.Errorf("expected the largest database to be 1200 bytes, got %d", largestDBSize(after))
+	}
+}
@@ -3,7 +3,6 @@ package upgrade
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
@@ -14,7 +13,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/openshift/osde2e/pkg/common/cluster"
+	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
@@ -51,9 +52,22 @@ func RunUpgrade(provider spi.Provider) error {
 	}
 
 	if h.Upgrade.Image != "" {
-		log.Printf("Upgrading cluster to UPGRADE_IMAGE '%s'", h.Upgrade.Image)
+		logging.Infof("Upgrading cluster to UPGRADE_IMAGE '%s'", h.Upgrade.Image)
 	} else {
-		log.Printf("Upgrading cluster to cluster image set with version %s", h.Upgrade.ReleaseName)
+		logging.Infof("Upgrading cluster to cluster image set with version %s", h.Upgrade.ReleaseName)
+	}
+
+	if h.Upgrade.ReleaseName != "" {
+		if err = HandleVersionGates(provider, state.Instance.Cluster.ID, h.Upgrade.ReleaseName); err != nil {
+			return fmt.Errorf("failed handling version gates: %v", err)
+		}
+	}
+
+	var etcdBefore []EtcdMemberStatus
+	if config.Instance.Upgrade.SeedProfile != "" {
+		if etcdBefore, err = seedCluster(h, config.Instance.Upgrade.SeedProfile); err != nil {
+			return fmt.Errorf("failed seeding cluster before upgrade: %v", err)
+		}
 	}
 
 	upgradeStarted = time.Now()
@@ -62,18 +76,18 @@ func RunUpgrade(provider spi.Provider) error {
 	if err != nil {
 		return fmt.Errorf("failed triggering upgrade: %v", err)
 	}
-	log.Println("Cluster acknowledged update request.")
+	logging.Infof("Cluster acknowledged update request.")
 
-	log.Println("Upgrading...")
+	logging.Infof("Upgrading...")
 	done = false
 	if err = wait.PollImmediate(10*time.Second, MaxDuration, func() (bool, error) {
 		done, msg, err = IsUpgradeDone(h, desired.Spec.DesiredUpdate)
 		if !done {
-			log.Printf("Upgrade in progress: %s", msg)
+			logging.Infof("Upgrade in progress: %s", msg)
 		}
 		return done, err
 	}); err != nil {
-		return fmt.Errorf("failed to upgrade cluster: %v", err)
+		return fmt.Errorf("failed to upgrade cluster: %w", err)
 	}
 
 	if !done {
@@ -83,10 +97,14 @@ func RunUpgrade(provider spi.Provider) error {
 	metadata.Instance.SetTimeToUpgradedCluster(time.Since(upgradeStarted).Seconds())
 
 	if err = cluster.WaitForClusterReady(provider, state.Instance.Cluster.ID); err != nil {
-		return fmt.Errorf("failed waiting for cluster ready: %v", err)
+		return fmt.Errorf("failed waiting for cluster ready: %w", err)
+	}
+
+	if config.Instance.Upgrade.SeedProfile != "" {
+		reportSeededUpgrade(h, etcdBefore)
 	}
 
-	log.Println("Upgrade complete!")
+	logging.Infof("Upgrade complete!")
 	return nil
 }
 
@@ -169,7 +187,7 @@ func IsUpgradeDone(h *helper.H, desired *configv1.Update) (done bool, msg string
 	cfgClient, getOpts := h.Cfg(), metav1.GetOptions{}
 	cVersion, err := cfgClient.ConfigV1().ClusterVersions().Get(ClusterVersionName, getOpts)
 	if err != nil {
-		log.Printf("error getting ClusterVersion '%s': %v", ClusterVersionName, err)
+		logging.Errorf("error getting ClusterVersion '%s': %v", ClusterVersionName, err)
 	}
 
 	// ensure working towards correct desired
@@ -0,0 +1,78 @@
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// versionGatePollInterval is how often acknowledgements of version gates are checked while waiting for them.
+var versionGatePollInterval = 30 * time.Second
+
+// HandleVersionGates makes sure the version gates of an upgrade are acknowledged before it is scheduled. The gates
+// are recorded in the metadata. If UPGRADE_ACKNOWLEDGE_VERSION_GATES is set, osde2e acknowledges them, otherwise it
+// waits up to UPGRADE_VERSION_GATE_WAIT minutes for them to be acknowledged. Providers without version gates are
+// ignored.
+func HandleVersionGates(provider spi.Provider, clusterID, version string) error {
+	gateProvider, ok := provider.(spi.VersionGateProvider)
+	if !ok {
+		return nil
+	}
+
+	cfg := config.Instance.Upgrade
+	return handleVersionGates(gateProvider, clusterID, version, cfg.AcknowledgeVersionGates, time.Duration(cfg.VersionGateWait)*time.Minute)
+}
+
+func handleVersionGates(provider spi.VersionGateProvider, clusterID, version string, acknowledge bool, timeout time.Duration) error {
+	gates, err := provider.UnacknowledgedVersionGates(clusterID, version)
+	if err != nil {
+		return err
+	}
+	if len(gates) == 0 {
+		return nil
+	}
+
+	labels := make([]string, 0, len(gates))
+	for _, gate := range gates {
+		logging.Infof("Upgrade to %s requires acknowledging version gate '%s' (%s): %s %s", version, gate.ID, gate.Label, gate.Description, gate.DocumentationURL)
+		labels = append(labels, gate.Label)
+	}
+
+	if acknowledge {
+		var acknowledged []string
+		for _, gate := range gates {
+			if err = provider.AcknowledgeVersionGate(clusterID, gate.ID); err != nil {
+				metadata.Instance.SetVersionGates(labels, acknowledged)
+				return err
+			}
+			acknowledged = append(acknowledged, gate.Label)
+		}
+		metadata.Instance.SetVersionGates(labels, acknowledged)
+		return nil
+	}
+
+	metadata.Instance.SetVersionGates(labels, nil)
+	if timeout <= 0 {
+		return fmt.Errorf("upgrade to %s requires acknowledging version gates %v, set UPGRADE_ACKNOWLEDGE_VERSION_GATES to acknowledge them", version, labels)
+	}
+
+	logging.Infof("Waiting up to %v for the version gates to be acknowledged...", timeout)
+	err = phase.Poll(versionGatePollInterval, timeout, func() (bool, error) {
+		if gates, err = provider.UnacknowledgedVersionGates(clusterID, version); err != nil {
+			logging.Errorf("Error checking version gates: %v", err)
+			return false, nil
+		}
+		return len(gates) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("version gates of the upgrade to %s weren't acknowledged within %v", version, timeout)
+	}
+
+	logging.Infof("The version gates of the upgrade to %s were acknowledged", version)
+	return nil
+}
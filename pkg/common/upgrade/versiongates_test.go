@@ -0,0 +1,64 @@
+package upgrade
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+type fakeGateProvider struct {
+	gates        []spi.VersionGate
+	acknowledged []string
+
+	// polls acknowledges the gates after the given number of checks
+	polls int
+}
+
+func (f *fakeGateProvider) UnacknowledgedVersionGates(clusterID, version string) ([]spi.VersionGate, error) {
+	if f.polls--; f.polls == 0 {
+		f.gates = nil
+	}
+	return f.gates, nil
+}
+
+func (f *fakeGateProvider) AcknowledgeVersionGate(clusterID, gateID string) error {
+	f.acknowledged = append(f.acknowledged, gateID)
+	return nil
+}
+
+func TestHandleVersionGates(t *testing.T) {
+	defer func(interval time.Duration) { versionGatePollInterval = interval }(versionGatePollInterval)
+	versionGatePollInterval = time.Millisecond
+
+	gates := []spi.VersionGate{{ID: "removals", Label: "api.openshift.com/gate-ocp"}}
+
+	provider := &fakeGateProvider{gates: gates}
+	if err := handleVersionGates(provider, "abc", "4.9.10", true, 0); err != nil {
+		t.Errorf("failed to acknowledge version gates: %v", err)
+	}
+	if !reflect.DeepEqual(provider.acknowledged, []string{"removals"}) {
+		t.Errorf("expected the gate to be acknowledged, got %v", provider.acknowledged)
+	}
+	if !reflect.DeepEqual(metadata.Instance.AcknowledgedVersionGates, []string{"api.openshift.com/gate-ocp"}) {
+		t.Errorf("expected the acknowledged gate to be recorded, got %v", metadata.Instance.AcknowledgedVersionGates)
+	}
+
+	if err := handleVersionGates(&fakeGateProvider{gates: gates}, "abc", "4.9.10", false, 0); err == nil {
+		t.Error("expected unacknowledged version gates to fail the upgrade")
+	}
+
+	provider = &fakeGateProvider{gates: gates, polls: 3}
+	if err := handleVersionGates(provider, "abc", "4.9.10", false, time.Second); err != nil {
+		t.Errorf("expected to wait for the version gates to be acknowledged: %v", err)
+	}
+	if len(provider.acknowledged) != 0 || !reflect.DeepEqual(metadata.Instance.VersionGates, []string{"api.openshift.com/gate-ocp"}) || metadata.Instance.AcknowledgedVersionGates != nil {
+		t.Errorf("unexpected version gates recorded %v, acknowledged %v", metadata.Instance.VersionGates, metadata.Instance.AcknowledgedVersionGates)
+	}
+
+	if err := handleVersionGates(&fakeGateProvider{gates: gates}, "abc", "4.9.10", false, 10*time.Millisecond); err == nil {
+		t.Error("expected version gates that are never acknowledged to fail the upgrade")
+	}
+}
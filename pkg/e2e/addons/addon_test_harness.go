@@ -2,15 +2,20 @@ package addons
 
 import (
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/harness"
 	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/runner"
 	"github.com/openshift/osde2e/pkg/common/templates"
+	"github.com/openshift/osde2e/pkg/common/triage"
+	kubev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,49 +37,136 @@ var _ = ginkgo.Describe("[Suite: addons] Addon Test Harness", func() {
 
 	addonTimeoutInSeconds := 3600
 	ginkgo.It("should run until completion", func() {
-		// We don't know what a test harness may need so let's give them everything.
+		cfg := config.Instance.Addons
+		groups, err := scheduleHarnesses(cfg.TestHarnesses, cfg.TestHarnessGroups, cfg.TestHarnessConcurrency, cfg.TestHarnessGroupOrder)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The runner orchestrates the harness, so it keeps full access. Harnesses get a scoped ServiceAccount.
 		h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
-		for _, harness := range config.Instance.Addons.TestHarnesses {
-			// configure tests
-			// setup runner
-			r := h.RunnerWithNoCommand()
-
-			latestImageStream, err := r.GetLatestImageStreamTag()
-			Expect(err).NotTo(HaveOccurred())
-			addonTestCommand, err := h.ConvertTemplateToString(addonTestTemplate, struct {
-				Timeout              int
-				Image                string
-				OutputDir            string
-				ServiceAccount       string
-				PushResultsContainer string
-			}{
-				Timeout:              addonTimeoutInSeconds,
-				Image:                harness,
-				OutputDir:            runner.DefaultRunner.OutputDir,
-				ServiceAccount:       h.GetNamespacedServiceAccount(),
-				PushResultsContainer: latestImageStream,
-			})
-			Expect(err).NotTo(HaveOccurred())
-
-			r.Name = "addon-tests"
-			r.Cmd = addonTestCommand
-
-			// run tests
-			stopCh := make(chan struct{})
-			err = r.Run(addonTimeoutInSeconds, stopCh)
-			Expect(err).NotTo(HaveOccurred())
-
-			// get results
-			results, err := r.RetrieveResults()
-			Expect(err).NotTo(HaveOccurred())
-
-			// write results
-			h.WriteResults(results)
-
-			// ensure job has not failed
-			job, err := h.Kube().BatchV1().Jobs(r.Namespace).Get("addon-tests", metav1.GetOptions{})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(job.Status.Failed).Should(BeNumerically("==", 0))
+
+		// results are written once every harness is done, as harnesses may run at the same time
+		results := make([]map[string][]byte, len(cfg.TestHarnesses))
+		errs := runHarnesses(groups, func(i int) error {
+			var err error
+			results[i], err = runHarness(h, cfg.TestHarnesses[i], fmt.Sprintf("addon-tests-%d", i+1), addonTimeoutInSeconds)
+			return err
+		})
+
+		for i, harness := range cfg.TestHarnesses {
+			if results[i] != nil {
+				h.WriteResults(results[i])
+			}
+			Expect(errs[i]).NotTo(HaveOccurred(), "harness %s failed", harness)
 		}
 	}, float64(addonTimeoutInSeconds+30))
 })
+
+// runHarness runs a harness image as a Job with the given name and returns its results.
+func runHarness(h *helper.H, image, name string, timeoutInSeconds int) (map[string][]byte, error) {
+	// hardened harnesses can't reach STS to exchange their token unless its addresses are allowed
+	cfg := config.Instance
+	if cfg.Addons.TestHarnessRoleARN != "" && !cfg.Tests.DisableHarnessHardening && len(cfg.Tests.HarnessEgressCIDRs) == 0 {
+		return nil, fmt.Errorf("harness %s assumes a role, but egress is limited to the cluster: set HARNESS_EGRESS_CIDRS to include the addresses of STS and the AWS APIs it calls, or DISABLE_HARNESS_HARDENING", image)
+	}
+
+	// setup runner
+	r := h.RunnerWithNoCommand()
+	r.Name = name
+
+	profile, err := harnessProfile(image)
+	if err != nil {
+		return nil, err
+	}
+
+	sa, err := r.CreateServiceAccount(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// remove harness permissions as soon as the harness is done, without losing its results if that fails
+	defer func(sa *kubev1.ServiceAccount) {
+		if deleteErr := r.DeleteServiceAccount(sa); deleteErr != nil {
+			logging.Errorf("Failed to delete ServiceAccount of harness %s: %v", image, deleteErr)
+		}
+	}(sa)
+
+	// harnesses calling cloud APIs exchange a ServiceAccount token for short-lived credentials
+	var webIdentity *runner.WebIdentity
+	if roleARN := config.Instance.Addons.TestHarnessRoleARN; roleARN != "" {
+		webIdentity = &runner.WebIdentity{
+			RoleARN:  roleARN,
+			Audience: config.Instance.Addons.TestHarnessTokenAudience,
+		}
+		if sa, err = r.AssignRole(sa, roleARN); err != nil {
+			return nil, err
+		}
+	}
+
+	latestImageStream, err := r.GetLatestImageStreamTag()
+	if err != nil {
+		return nil, err
+	}
+	addonTestCommand, err := h.ConvertTemplateToString(addonTestTemplate, struct {
+		Name                 string
+		Timeout              int
+		Image                string
+		OutputDir            string
+		ServiceAccount       string
+		PushResultsContainer string
+		Harden               bool
+		WebIdentity          *runner.WebIdentity
+	}{
+		Name:                 name,
+		Timeout:              timeoutInSeconds,
+		Image:                image,
+		OutputDir:            runner.DefaultRunner.OutputDir,
+		ServiceAccount:       sa.Name,
+		PushResultsContainer: latestImageStream,
+		Harden:               !config.Instance.Tests.DisableHarnessHardening,
+		WebIdentity:          webIdentity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Cmd = addonTestCommand
+	r.RestrictEgress = true
+
+	// run tests
+	stopCh := make(chan struct{})
+	if err = r.Run(timeoutInSeconds, stopCh); err != nil {
+		return nil, &triage.HarnessError{Image: image, Err: err}
+	}
+
+	// get results
+	results, err := r.RetrieveResults()
+	if err != nil {
+		return nil, err
+	}
+
+	// ensure job has not failed
+	job, err := h.Kube().BatchV1().Jobs(r.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return results, err
+	}
+	if job.Status.Failed != 0 {
+		return results, &triage.HarnessError{Image: image, Err: fmt.Errorf("job %s failed", name)}
+	}
+
+	// results that don't follow the schema may be dropped or misread, but the harness's own results still stand
+	for _, problem := range harness.Validate(results) {
+		logging.Warnf("Results of %s don't follow the harness schema: %s", image, problem)
+	}
+	return results, nil
+}
+
+// harnessProfile returns the RBAC profile configured for the given harness image.
+func harnessProfile(harness string) (runner.RBACProfile, error) {
+	profile := config.Instance.Addons.TestHarnessProfile
+	for _, override := range config.Instance.Addons.TestHarnessProfiles {
+		if parts := strings.SplitN(override, "=", 2); len(parts) == 2 && parts[0] == harness {
+			profile = parts[1]
+		}
+	}
+	return runner.ParseRBACProfile(profile)
+}
@@ -0,0 +1,106 @@
+package addons
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultHarnessGroup is the group of harnesses that aren't assigned to one.
+const defaultHarnessGroup = "default"
+
+// harnessGroup is a set of harnesses that run together, up to concurrency of them at a time. Harnesses are indexes
+// into the configured list of harnesses.
+type harnessGroup struct {
+	name        string
+	harnesses   []int
+	concurrency int
+}
+
+// scheduleHarnesses splits harnesses into the groups they are assigned with entries of the form <image>=<group>.
+// Groups run one after another: first those listed in order, then the rest in the order their first harness is
+// listed. Each group runs as many harnesses at a time as it is given with entries of the form <group>=<number>, or
+// one at a time.
+func scheduleHarnesses(harnesses, assignments, concurrency, order []string) ([]harnessGroup, error) {
+	groupOf := map[string]string{}
+	for _, assignment := range assignments {
+		parts := strings.SplitN(assignment, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("harness group %q is not of the form <image>=<group>", assignment)
+		}
+		groupOf[parts[0]] = parts[1]
+	}
+
+	limits := map[string]int{}
+	for _, limit := range concurrency {
+		parts := strings.SplitN(limit, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("harness concurrency %q is not of the form <group>=<number>", limit)
+		}
+		n, err := strconv.Atoi(parts[1])
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("harness concurrency of group %s must be a positive number, got %q", parts[0], parts[1])
+		}
+		limits[parts[0]] = n
+	}
+
+	byName := map[string]*harnessGroup{}
+	var names []string
+	for i, harness := range harnesses {
+		name := groupOf[harness]
+		if name == "" {
+			name = defaultHarnessGroup
+		}
+
+		group, ok := byName[name]
+		if !ok {
+			group = &harnessGroup{name: name, concurrency: 1}
+			if limit, ok := limits[name]; ok {
+				group.concurrency = limit
+			}
+			byName[name] = group
+			names = append(names, name)
+		}
+		group.harnesses = append(group.harnesses, i)
+	}
+
+	var groups []harnessGroup
+	scheduled := map[string]bool{}
+	for _, name := range append(order, names...) {
+		if group, ok := byName[name]; ok && !scheduled[name] {
+			scheduled[name] = true
+			groups = append(groups, *group)
+		}
+	}
+	return groups, nil
+}
+
+// runHarnesses runs each group after the previous one has finished, running up to the group's concurrency harnesses
+// at a time. The errors returned by run are returned by harness index.
+func runHarnesses(groups []harnessGroup, run func(harness int) error) map[int]error {
+	errs := map[int]error{}
+	var mutex sync.Mutex
+	for _, group := range groups {
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, group.concurrency)
+		for _, harness := range group.harnesses {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(harness int) {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+
+				if err := run(harness); err != nil {
+					mutex.Lock()
+					errs[harness] = err
+					mutex.Unlock()
+				}
+			}(harness)
+		}
+		wg.Wait()
+	}
+	return errs
+}
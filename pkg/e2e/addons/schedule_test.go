@@ -0,0 +1,90 @@
+package addons
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduleHarnesses(t *testing.T) {
+	harnesses := []string{"quay.io/a", "quay.io/net-1", "quay.io/b", "quay.io/net-2", "quay.io/c"}
+	assignments := []string{"quay.io/net-1=network-destructive", "quay.io/net-2=network-destructive", "quay.io/c=late"}
+
+	groups, err := scheduleHarnesses(harnesses, assignments, []string{"default=4"}, []string{"late"})
+	if err != nil {
+		t.Fatalf("failed to schedule harnesses: %v", err)
+	}
+
+	expected := []harnessGroup{
+		{name: "late", harnesses: []int{4}, concurrency: 1},
+		{name: "default", harnesses: []int{0, 2}, concurrency: 4},
+		{name: "network-destructive", harnesses: []int{1, 3}, concurrency: 1},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected groups %+v, got %+v", expected, groups)
+	}
+}
+
+func TestScheduleHarnessesInvalid(t *testing.T) {
+	if _, err := scheduleHarnesses([]string{"quay.io/a"}, []string{"quay.io/a"}, nil, nil); err == nil {
+		t.Error("expected a group without a name to be rejected")
+	}
+	if _, err := scheduleHarnesses([]string{"quay.io/a"}, nil, []string{"default=0"}, nil); err == nil {
+		t.Error("expected a concurrency of 0 to be rejected")
+	}
+}
+
+func TestRunHarnesses(t *testing.T) {
+	groups := []harnessGroup{
+		{name: "default", harnesses: []int{0, 1, 2}, concurrency: 2},
+		{name: "serial", harnesses: []int{3, 4}, concurrency: 1},
+	}
+
+	var mutex sync.Mutex
+	running, maxRunning := map[string]int{}, map[string]int{}
+	var finished []int
+	errs := runHarnesses(groups, func(harness int) error {
+		group := "default"
+		if harness > 2 {
+			group = "serial"
+		}
+
+		mutex.Lock()
+		// groups never overlap
+		for other, n := range running {
+			if other != group && n > 0 {
+				t.Errorf("harness %d of group %s ran while group %s was running", harness, group, other)
+			}
+		}
+		running[group]++
+		if running[group] > maxRunning[group] {
+			maxRunning[group] = running[group]
+		}
+		mutex.Unlock()
+
+		// give harnesses of the group a chance to overlap
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running[group]--
+		finished = append(finished, harness)
+		mutex.Unlock()
+
+		if harness == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if len(errs) != 1 || errs[1] == nil {
+		t.Errorf("expected only harness 1 to fail, got %v", errs)
+	}
+	if maxRunning["default"] != 2 || maxRunning["serial"] != 1 {
+		t.Errorf("expected harnesses to run up to their concurrency, got %v", maxRunning)
+	}
+	if len(finished) != 5 || finished[3] != 3 || finished[4] != 4 {
+		t.Errorf("expected the serial harnesses to run last in order, got %v", finished)
+	}
+}
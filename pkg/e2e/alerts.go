@@ -0,0 +1,121 @@
+package e2e
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// alertsReportFile is the JUnit report of the alerts that changed while a phase's tests ran.
+const alertsReportFile = "junit_alerts.xml"
+
+// firingAlerts returns the alerts firing on the cluster, or false if they can't be read.
+func firingAlerts() ([]spi.Alert, bool) {
+	if config.Instance.DryRun || state.Instance.Cluster.ID == "" {
+		return nil, false
+	}
+	alertsProvider, ok := provider.(spi.AlertsProvider)
+	if !ok {
+		return nil, false
+	}
+
+	alerts, err := alertsProvider.FiringAlerts(state.Instance.Cluster.ID)
+	if err != nil {
+		logging.Warnf("Unable to get the alerts firing on the cluster: %v", err)
+		return nil, false
+	}
+	return alerts, true
+}
+
+// reportAlertDiff compares the alerts firing now with the ones firing before the phase's tests ran and records the
+// alerts the tests introduced and resolved in the metadata and a JUnit report, so that degraded operators can be
+// attributed to the phase's suites. The report is informational and has no failures, since anything reading JUnit
+// results would otherwise count new alerts as failed tests.
+func reportAlertDiff(phase, phaseDirectory string, before []spi.Alert) {
+	after, ok := firingAlerts()
+	if !ok {
+		return
+	}
+
+	introduced, resolved := diffAlerts(before, after)
+	if len(introduced) > 0 {
+		log.Printf("Alerts started firing during the %s phase: %s", phase, strings.Join(introduced, ", "))
+	}
+	if len(resolved) > 0 {
+		log.Printf("Alerts stopped firing during the %s phase: %s", phase, strings.Join(resolved, ", "))
+	}
+	metadata.Instance.SetAlertDiff(phase, introduced, resolved)
+
+	suite := alertDiffSuite(introduced, resolved)
+	data, err := xml.Marshal(&suite)
+	if err != nil {
+		logging.Warnf("error marshalling alerts junit: %v", err)
+		return
+	}
+	if err = ioutil.WriteFile(filepath.Join(phaseDirectory, alertsReportFile), data, 0644); err != nil {
+		logging.Warnf("error writing to junit file: %v", err)
+	}
+}
+
+// diffAlerts returns the sorted alerts firing after but not before, and before but not after, named with their
+// severity.
+func diffAlerts(before, after []spi.Alert) (introduced, resolved []string) {
+	firingBefore, firingAfter := alertNames(before), alertNames(after)
+	for name := range firingAfter {
+		if !firingBefore[name] {
+			introduced = append(introduced, name)
+		}
+	}
+	for name := range firingBefore {
+		if !firingAfter[name] {
+			resolved = append(resolved, name)
+		}
+	}
+	sort.Strings(introduced)
+	sort.Strings(resolved)
+	return introduced, resolved
+}
+
+// alertNames returns the set of alerts by their name and severity.
+func alertNames(alerts []spi.Alert) map[string]bool {
+	names := map[string]bool{}
+	for _, alert := range alerts {
+		names[fmt.Sprintf("%s (%s)", alert.Name, alert.Severity)] = true
+	}
+	return names
+}
+
+// alertDiffSuite returns a test case for each alert that started or stopped firing. Alerts that started firing are
+// skipped rather than failed, and resolved alerts are passed.
+func alertDiffSuite(introduced, resolved []string) reporters.JUnitTestSuite {
+	suite := reporters.JUnitTestSuite{Name: "Alerts"}
+	for _, name := range introduced {
+		suite.TestCases = append(suite.TestCases, reporters.JUnitTestCase{
+			ClassName: "Alerts",
+			Name:      fmt.Sprintf("[Alerts] %s", name),
+			Skipped:   &reporters.JUnitSkipped{},
+			SystemOut: fmt.Sprintf("%s started firing while the tests ran", name),
+		})
+	}
+	for _, name := range resolved {
+		suite.TestCases = append(suite.TestCases, reporters.JUnitTestCase{
+			ClassName:     "Alerts",
+			Name:          fmt.Sprintf("[Alerts] %s", name),
+			PassedMessage: &reporters.JUnitPassedMessage{Message: fmt.Sprintf("%s stopped firing while the tests ran", name)},
+		})
+	}
+	suite.Tests = len(suite.TestCases)
+	return suite
+}
@@ -0,0 +1,42 @@
+package e2e
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestDiffAlerts(t *testing.T) {
+	before := []spi.Alert{
+		{Name: "Watchdog", Severity: "none"},
+		{Name: "KubePodNotReady", Severity: "warning"},
+		{Name: "ClusterOperatorDegraded", Severity: "warning"},
+	}
+	after := []spi.Alert{
+		{Name: "Watchdog", Severity: "none"},
+		{Name: "ClusterOperatorDegraded", Severity: "critical"},
+		{Name: "KubeDeploymentReplicasMismatch", Severity: "warning"},
+	}
+
+	introduced, resolved := diffAlerts(before, after)
+	if expected := []string{"ClusterOperatorDegraded (critical)", "KubeDeploymentReplicasMismatch (warning)"}; !reflect.DeepEqual(introduced, expected) {
+		t.Errorf("expected introduced alerts %v, got %v", expected, introduced)
+	}
+	if expected := []string{"ClusterOperatorDegraded (warning)", "KubePodNotReady (warning)"}; !reflect.DeepEqual(resolved, expected) {
+		t.Errorf("expected resolved alerts %v, got %v", expected, resolved)
+	}
+
+	suite := alertDiffSuite(introduced, resolved)
+	if suite.Tests != 4 || suite.Failures != 0 {
+		t.Errorf("expected 4 test cases without failures, got %d with %d", suite.Tests, suite.Failures)
+	}
+	if suite.TestCases[0].Skipped == nil || suite.TestCases[2].PassedMessage == nil {
+		t.Errorf("expected introduced alerts to be skipped and resolved alerts to pass, got %+v", suite.TestCases)
+	}
+	for _, testcase := range suite.TestCases {
+		if testcase.FailureMessage != nil {
+			t.Errorf("expected no alert to fail, got %+v", testcase)
+		}
+	}
+}
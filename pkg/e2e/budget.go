@@ -0,0 +1,98 @@
+package e2e
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/budget"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// budgetInterval is how often the run budget is checked.
+const budgetInterval = time.Minute
+
+// runBudget tracks what the run uses. It is nil when no limits are configured.
+var runBudget *budget.Tracker
+
+// startBudget starts enforcing the configured run budget. Exceeding it aborts the run.
+func startBudget() {
+	cfg := config.Instance.Budget
+	limits := budget.Limits{
+		MaxClusters:  cfg.MaxClusters,
+		MaxNodeHours: float64(cfg.MaxNodeHours),
+		MaxDuration:  time.Duration(cfg.MaxRunDuration) * time.Minute,
+	}
+	if !limits.Enabled() {
+		return
+	}
+
+	runBudget = budget.New(limits, time.Now())
+	runBudget.Watch(budgetInterval, countNodes, abortRun)
+}
+
+// stopBudget stops enforcing the run budget.
+func stopBudget() {
+	if runBudget != nil {
+		runBudget.Stop()
+	}
+}
+
+// resumeBudget enforces the run budget again after it was stopped, keeping what has been used so far.
+func resumeBudget() {
+	if runBudget != nil {
+		runBudget.Watch(budgetInterval, countNodes, abortRun)
+	}
+}
+
+// reserveCluster checks that the run may create another cluster.
+func reserveCluster() error {
+	if runBudget == nil {
+		return nil
+	}
+
+	if err := runBudget.ReserveCluster(time.Now()); err != nil {
+		abortRun(err)
+		return err
+	}
+	return nil
+}
+
+// AbortRun stops the run so that it can be cleaned up, such as when a command gives up waiting for it. RunTests
+// still returns once the run has been cleaned up.
+func AbortRun(reason error) {
+	abortRun(reason)
+}
+
+// abortRun stops the run so that it can be cleaned up.
+func abortRun(reason error) {
+	logging.Warnf("Aborting run: %v", reason)
+	metadata.Instance.SetAbortReason(reason.Error())
+	phase.Abort(reason)
+}
+
+// countNodes returns the number of nodes in the cluster under test.
+func countNodes() (int, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(state.Instance.Kubeconfig.Contents)
+	if err != nil {
+		return 0, err
+	}
+	restConfig.Timeout = 30 * time.Second
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	nodes, err := kube.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return len(nodes.Items), nil
+}
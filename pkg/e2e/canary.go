@@ -0,0 +1,69 @@
+package e2e
+
+import (
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/canary"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// upgradingPhase labels canary samples taken while the cluster is upgrading.
+const upgradingPhase = "upgrading"
+
+// startCanary starts probing the cluster in the background unless it has been disabled.
+func startCanary(phase string) *canary.Prober {
+	interval := config.Instance.Tests.CanaryInterval
+	if interval <= 0 || config.Instance.DryRun {
+		return nil
+	}
+
+	prober := canary.New(time.Duration(interval)*time.Second, canaryProbes)
+	prober.SetPhase(phase)
+	prober.Start()
+	return prober
+}
+
+// canaryProbes returns the canary's probes of the cluster, including one for node drift if it's enabled.
+func canaryProbes() (map[string]canary.Probe, error) {
+	probes, err := canary.ClusterProbes()
+	if err != nil || !config.Instance.Tests.CanaryNodeDrift || provider == nil {
+		return probes, err
+	}
+
+	autoscaling := config.Instance.Cluster.AutoscalerMaxNodes > 0
+	drift, err := canary.NodeDriftProbe(desiredComputeNodes, autoscaling)
+	if err != nil {
+		return nil, err
+	}
+	probes["node-drift"] = drift
+	return probes, nil
+}
+
+// desiredComputeNodes returns the number of compute nodes the provider says the cluster under test should have.
+func desiredComputeNodes() (int, error) {
+	cluster, err := provider.GetCluster(state.Instance.Cluster.ID)
+	if err != nil {
+		return 0, err
+	}
+	return cluster.ComputeNodes(), nil
+}
+
+// setCanaryPhase labels subsequent canary samples with the phase of the run.
+func setCanaryPhase(prober *canary.Prober, phase string) {
+	if prober != nil {
+		prober.SetPhase(phase)
+	}
+}
+
+// stopCanary stops probing and writes the availability timeline to the report directory.
+func stopCanary(prober *canary.Prober) {
+	if prober == nil || !prober.Stop() {
+		return
+	}
+
+	if err := prober.Write(config.Instance.ReportDir); err != nil {
+		logging.Warnf("Error writing canary timeline: %v", err)
+	}
+}
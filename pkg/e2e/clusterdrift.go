@@ -0,0 +1,305 @@
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	osconfig "github.com/openshift/client-go/config/clientset/versioned"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// clusterDriftFile is the name of the report of drift between the cluster and its provider written to the report dir.
+	clusterDriftFile = "cluster-drift.yaml"
+
+	// driftAtStart and driftAtEnd are when in the run the cluster is checked for drift.
+	driftAtStart = "start"
+	driftAtEnd   = "end"
+)
+
+// addonResource are the addons installed by the addon operator, named by addon ID.
+var addonResource = schema.GroupVersionResource{Group: "addons.managed.openshift.io", Version: "v1alpha1", Resource: "addons"}
+
+// clusterDrift is the report of the cluster's drift from its provider's intent.
+var clusterDrift ClusterDriftReport
+
+// ClusterDriftReport compares the cluster with how its provider believes it was requested at the start and end of
+// the run.
+type ClusterDriftReport struct {
+	Start *ClusterDriftCheck `yaml:"start,omitempty"`
+	End   *ClusterDriftCheck `yaml:"end,omitempty"`
+}
+
+// ClusterDriftCheck is how a cluster was requested to be configured and how it was configured at a point in the run.
+type ClusterDriftCheck struct {
+	Intended *spi.ClusterIntent `yaml:"intended"`
+	Actual   *spi.ClusterIntent `yaml:"actual"`
+	Drift    []ClusterDrift     `yaml:"drift,omitempty"`
+}
+
+// ClusterDrift is a field in which a cluster differs from its provider's intent.
+type ClusterDrift struct {
+	Field    string `yaml:"field"`
+	Intended string `yaml:"intended"`
+	Actual   string `yaml:"actual"`
+}
+
+// String describes the drift.
+func (d ClusterDrift) String() string {
+	return fmt.Sprintf("%s: intended %s, actual %s", d.Field, d.Intended, d.Actual)
+}
+
+// checkClusterDrift compares the cluster with how its provider believes it was requested, when the provider can tell,
+// and reports the discrepancies. Drift is flagged, but doesn't fail the run.
+func checkClusterDrift(when string) {
+	cfg := config.Instance
+	if !cfg.Cluster.CheckDrift || cfg.DryRun || state.Instance.Cluster.ID == "" || len(state.Instance.Kubeconfig.Contents) == 0 {
+		return
+	}
+
+	intentProvider, ok := provider.(spi.ClusterIntentProvider)
+	if !ok {
+		return
+	}
+
+	intent, err := intentProvider.ClusterIntent(state.Instance.Cluster.ID)
+	if err != nil {
+		logging.Warnf("Unable to get the intended configuration of the cluster: %v", err)
+		return
+	}
+
+	actual, err := observeCluster(state.Instance.Kubeconfig.Contents)
+	if err != nil {
+		logging.Warnf("Unable to get the configuration of the cluster: %v", err)
+		return
+	}
+
+	check := &ClusterDriftCheck{Intended: intent, Actual: actual, Drift: compareCluster(intent, actual)}
+	if when == driftAtStart {
+		clusterDrift.Start = check
+	} else {
+		clusterDrift.End = check
+	}
+
+	if len(check.Drift) == 0 {
+		log.Printf("The cluster is configured as %s intended at the %s of the run.", cfg.Provider, when)
+	} else {
+		logging.Warnf("The cluster has drifted from how %s intended it at the %s of the run:", cfg.Provider, when)
+		for _, drift := range check.Drift {
+			logging.Warnf("  %s", drift)
+		}
+	}
+	clusterDrift.write()
+}
+
+// write writes the report to the report dir and records the drift in the metadata.
+func (r ClusterDriftReport) write() {
+	var drift []string
+	for when, check := range map[string]*ClusterDriftCheck{driftAtStart: r.Start, driftAtEnd: r.End} {
+		if check == nil {
+			continue
+		}
+		for _, d := range check.Drift {
+			drift = append(drift, when+": "+d.String())
+		}
+	}
+	sort.Strings(drift)
+	metadata.Instance.SetClusterDrift(drift)
+
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		logging.Warnf("Unable to marshal cluster drift report: %v", err)
+		return
+	}
+
+	path := filepath.Join(config.Instance.ReportDir, clusterDriftFile)
+	if err = ioutil.WriteFile(path, data, os.FileMode(0644)); err != nil {
+		logging.Warnf("Unable to write cluster drift report: %v", err)
+	}
+}
+
+// observeCluster gets the compute nodes, network, identity providers, and addons of the cluster of a kubeconfig.
+func observeCluster(kubeconfig []byte) (*spi.ClusterIntent, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error generating restconfig: %v", err)
+	}
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cfgClient, err := osconfig.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := &spi.ClusterIntent{IdentityProviders: []string{}}
+
+	nodes, err := kube.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %v", err)
+	}
+	actual.MinComputeNodes = computeNodes(nodes.Items)
+	actual.MaxComputeNodes = actual.MinComputeNodes
+
+	network, err := cfgClient.ConfigV1().Networks().Get("cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the cluster network: %v", err)
+	}
+	if len(network.Spec.ClusterNetwork) > 0 {
+		actual.PodCIDR = network.Spec.ClusterNetwork[0].CIDR
+		actual.HostPrefix = int(network.Spec.ClusterNetwork[0].HostPrefix)
+	}
+	if len(network.Spec.ServiceNetwork) > 0 {
+		actual.ServiceCIDR = network.Spec.ServiceNetwork[0]
+	}
+
+	installConfig, err := kube.CoreV1().ConfigMaps("kube-system").Get("cluster-config-v1", metav1.GetOptions{})
+	if err == nil {
+		actual.MachineCIDR = machineCIDR(installConfig.Data["install-config"])
+	} else if !kerror.IsNotFound(err) && !kerror.IsForbidden(err) {
+		return nil, fmt.Errorf("error getting the install config: %v", err)
+	}
+
+	oauth, err := cfgClient.ConfigV1().OAuths().Get("cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the cluster OAuth config: %v", err)
+	}
+	for _, idp := range oauth.Spec.IdentityProviders {
+		actual.IdentityProviders = append(actual.IdentityProviders, idp.Name)
+	}
+
+	// addons are only known on clusters running the addon operator
+	addons, err := dynamicClient.Resource(addonResource).List(metav1.ListOptions{})
+	if err == nil {
+		actual.Addons = []string{}
+		for _, addon := range addons.Items {
+			actual.Addons = append(actual.Addons, addon.GetName())
+		}
+	} else if !kerror.IsNotFound(err) && !kerror.IsForbidden(err) {
+		return nil, fmt.Errorf("error listing addons: %v", err)
+	}
+	return actual, nil
+}
+
+// computeNodes counts the worker nodes that aren't also masters or infra nodes.
+func computeNodes(nodes []kubev1.Node) int {
+	count := 0
+	for _, node := range nodes {
+		labels := node.GetLabels()
+		if _, worker := labels["node-role.kubernetes.io/worker"]; !worker {
+			continue
+		}
+		_, master := labels["node-role.kubernetes.io/master"]
+		_, infra := labels["node-role.kubernetes.io/infra"]
+		if !master && !infra {
+			count++
+		}
+	}
+	return count
+}
+
+// machineCIDR returns the machine network of an install config.
+func machineCIDR(installConfig string) string {
+	var parsed struct {
+		Networking struct {
+			MachineCIDR    string `yaml:"machineCIDR"`
+			MachineNetwork []struct {
+				CIDR string `yaml:"cidr"`
+			} `yaml:"machineNetwork"`
+		} `yaml:"networking"`
+	}
+	if err := yaml.Unmarshal([]byte(installConfig), &parsed); err != nil {
+		return ""
+	}
+
+	if len(parsed.Networking.MachineNetwork) > 0 {
+		return parsed.Networking.MachineNetwork[0].CIDR
+	}
+	return parsed.Networking.MachineCIDR
+}
+
+// compareCluster returns the fields in which the actual configuration of a cluster differs from the intended one.
+// Fields that are unknown in either aren't compared.
+func compareCluster(intended, actual *spi.ClusterIntent) []ClusterDrift {
+	var drift []ClusterDrift
+
+	if intended.MaxComputeNodes > 0 && actual.MaxComputeNodes > 0 &&
+		(actual.MinComputeNodes < intended.MinComputeNodes || actual.MaxComputeNodes > intended.MaxComputeNodes) {
+		wanted := strconv.Itoa(intended.MinComputeNodes)
+		if intended.MaxComputeNodes != intended.MinComputeNodes {
+			wanted += "-" + strconv.Itoa(intended.MaxComputeNodes)
+		}
+		drift = append(drift, ClusterDrift{Field: "compute nodes", Intended: wanted, Actual: strconv.Itoa(actual.MinComputeNodes)})
+	}
+
+	for _, field := range []struct {
+		name             string
+		intended, actual string
+	}{
+		{"machine CIDR", intended.MachineCIDR, actual.MachineCIDR},
+		{"service CIDR", intended.ServiceCIDR, actual.ServiceCIDR},
+		{"pod CIDR", intended.PodCIDR, actual.PodCIDR},
+	} {
+		if field.intended != "" && field.actual != "" && field.intended != field.actual {
+			drift = append(drift, ClusterDrift{Field: field.name, Intended: field.intended, Actual: field.actual})
+		}
+	}
+
+	if intended.HostPrefix > 0 && actual.HostPrefix > 0 && intended.HostPrefix != actual.HostPrefix {
+		drift = append(drift, ClusterDrift{Field: "host prefix", Intended: strconv.Itoa(intended.HostPrefix), Actual: strconv.Itoa(actual.HostPrefix)})
+	}
+
+	for _, field := range []struct {
+		name             string
+		intended, actual []string
+	}{
+		{"identity providers", intended.IdentityProviders, actual.IdentityProviders},
+		{"addons", intended.Addons, actual.Addons},
+	} {
+		if field.intended == nil || field.actual == nil {
+			continue
+		}
+		if wanted, got := sortedList(field.intended), sortedList(field.actual); wanted != got {
+			drift = append(drift, ClusterDrift{Field: field.name, Intended: wanted, Actual: got})
+		}
+	}
+	return drift
+}
+
+// sortedList lists names in order, or "none" if there are none.
+func sortedList(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
@@ -0,0 +1,80 @@
+package e2e
+
+import (
+	"reflect"
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+func TestCompareCluster(t *testing.T) {
+	intended := &spi.ClusterIntent{
+		MinComputeNodes:   2,
+		MaxComputeNodes:   4,
+		MachineCIDR:       "10.0.0.0/16",
+		ServiceCIDR:       "172.30.0.0/16",
+		PodCIDR:           "10.128.0.0/14",
+		HostPrefix:        23,
+		IdentityProviders: []string{"github", "cluster-admin"},
+		Addons:            []string{"cluster-logging-operator"},
+	}
+	actual := &spi.ClusterIntent{
+		MinComputeNodes:   5,
+		MaxComputeNodes:   5,
+		ServiceCIDR:       "172.30.0.0/16",
+		PodCIDR:           "10.132.0.0/14",
+		HostPrefix:        23,
+		IdentityProviders: []string{"cluster-admin", "github"},
+	}
+
+	expected := []ClusterDrift{
+		{Field: "compute nodes", Intended: "2-4", Actual: "5"},
+		{Field: "pod CIDR", Intended: "10.128.0.0/14", Actual: "10.132.0.0/14"},
+	}
+	if drift := compareCluster(intended, actual); !reflect.DeepEqual(drift, expected) {
+		t.Errorf("expected drift %v, got %v", expected, drift)
+	}
+
+	actual.MinComputeNodes, actual.MaxComputeNodes = 3, 3
+	actual.PodCIDR = intended.PodCIDR
+	actual.IdentityProviders = []string{}
+	actual.Addons = []string{}
+	expected = []ClusterDrift{
+		{Field: "identity providers", Intended: "cluster-admin, github", Actual: "none"},
+		{Field: "addons", Intended: "cluster-logging-operator", Actual: "none"},
+	}
+	if drift := compareCluster(intended, actual); !reflect.DeepEqual(drift, expected) {
+		t.Errorf("expected drift %v, got %v", expected, drift)
+	}
+}
+
+func TestComputeNodes(t *testing.T) {
+	node := func(roles ...string) kubev1.Node {
+		labels := map[string]string{}
+		for _, role := range roles {
+			labels["node-role.kubernetes.io/"+role] = ""
+		}
+		return kubev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+	}
+
+	nodes := []kubev1.Node{node("master"), node("master", "worker"), node("infra", "worker"), node("worker"), node("worker")}
+	if count := computeNodes(nodes); count != 2 {
+		t.Errorf("expected 2 compute nodes, got %d", count)
+	}
+}
+
+func TestMachineCIDR(t *testing.T) {
+	tests := map[string]string{
+		"networking:\n  machineNetwork:\n  - cidr: 10.0.0.0/16\n": "10.0.0.0/16",
+		"networking:\n  machineCIDR: 10.1.0.0/16\n":               "10.1.0.0/16",
+		"not: [valid": "",
+	}
+	for installConfig, expected := range tests {
+		if cidr := machineCIDR(installConfig); cidr != expected {
+			t.Errorf("expected machine CIDR %q, got %q", expected, cidr)
+		}
+	}
+}
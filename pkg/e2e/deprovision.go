@@ -0,0 +1,146 @@
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/events"
+	"github.com/openshift/osde2e/pkg/common/hooks"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/triage"
+)
+
+const (
+	// deprovisionDir is the directory in the report dir deprovision artifacts are written to.
+	deprovisionDir = "deprovision"
+
+	// deprovisionHung is the classification used when a deletion never finished and nothing in the logs explains why.
+	deprovisionHung = "hung"
+
+	// deprovisionUnknown is the classification used when a deletion failed for an unrecognized reason.
+	deprovisionUnknown = "unknown"
+)
+
+// deprovisionFailures maps failure classifications to patterns found in uninstall logs. They are checked in order.
+var deprovisionFailures = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"cloud-dependency-violation", regexp.MustCompile(`DependencyViolation|has dependencies and cannot be deleted`)},
+	{"cloud-permissions", regexp.MustCompile(`AccessDenied|UnauthorizedOperation|is not authorized to perform`)},
+	{"cloud-throttling", regexp.MustCompile(`RequestLimitExceeded|Throttling|Rate exceeded`)},
+	{"cloud-credentials", regexp.MustCompile(`InvalidClientTokenId|AuthFailure`)},
+	{"dns", regexp.MustCompile(`(?i)(hosted zone|route53)[^\n]*(error|fail)`)},
+	{"timeout", regexp.MustCompile(`(?i)timed out|deadline exceeded`)},
+}
+
+// deleteCluster deletes a cluster and, if a deprovision timeout is configured or its volumes are audited, waits for the
+// deletion to finish.
+// If the deletion fails or hangs, the uninstall logs are captured and the failure is classified.
+func deleteCluster(clusterID string) error {
+	runHooks(hooks.PreTeardown)
+
+	// the delete-protection suite may have deleted the cluster already
+	if state.Instance.Cluster.State == spi.ClusterStateUninstalling {
+		log.Printf("Cluster '%s' is already being deleted.", clusterID)
+	} else if err := provider.DeleteCluster(clusterID); err != nil {
+		captureDeprovisionFailure(clusterID, false)
+		return err
+	}
+
+	timeout := deprovisionWait()
+	if timeout == 0 {
+		return nil
+	}
+
+	log.Printf("Waiting %v for cluster '%s' to be deleted...", timeout, clusterID)
+	var failed bool
+	err := phase.Poll(30*time.Second, timeout, func() (bool, error) {
+		cluster, err := provider.GetCluster(clusterID)
+		var ocmErr *triage.OCMError
+		if errors.As(err, &ocmErr) && ocmErr.Status == http.StatusNotFound {
+			// the provider no longer knows about the cluster
+			log.Printf("Cluster '%s' is gone.", clusterID)
+			return true, nil
+		} else if err != nil {
+			// other errors don't mean the cluster is gone, so keep checking
+			logging.Warnf("Unable to check whether cluster '%s' has been deleted: %v", clusterID, err)
+			return false, nil
+		}
+
+		if cluster.State() == spi.ClusterStateError {
+			failed = true
+			return false, fmt.Errorf("cluster '%s' is in an error state", clusterID)
+		}
+		return false, nil
+	})
+
+	if err != nil {
+		captureDeprovisionFailure(clusterID, !failed)
+		return fmt.Errorf("cluster '%s' was not deleted: %v", clusterID, err)
+	}
+	return nil
+}
+
+// deprovisionWait returns how long to wait for a cluster to be deleted. The deletion is always waited on when the
+// cluster's EBS volumes are audited, since they can only be checked once it's gone.
+func deprovisionWait() time.Duration {
+	timeout := config.Instance.Cluster.DeprovisionTimeout
+	if timeout == 0 && config.Instance.Cluster.AuditVolumes {
+		timeout = volumeAuditDeprovisionTimeout
+	}
+	return time.Duration(timeout) * time.Minute
+}
+
+// captureDeprovisionFailure writes the cluster's uninstall logs to the report dir and records why deletion failed.
+func captureDeprovisionFailure(clusterID string, timedOut bool) {
+	events.RecordEvent(events.DeprovisionFailed)
+
+	logs, err := provider.Logs(clusterID)
+	if err != nil {
+		logging.Warnf("Unable to retrieve uninstall logs for cluster '%s': %v", clusterID, err)
+	}
+
+	dir := filepath.Join(config.Instance.ReportDir, deprovisionDir)
+	if err = os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		logging.Warnf("Unable to create deprovision artifact directory: %v", err)
+	} else {
+		for name, data := range logs {
+			if err = ioutil.WriteFile(filepath.Join(dir, name+"-log.txt"), data, os.FileMode(0644)); err != nil {
+				logging.Warnf("Unable to write uninstall log '%s': %v", name, err)
+			}
+		}
+	}
+
+	failure := classifyDeprovisionFailure(logs, timedOut)
+	logging.Errorf("Deprovision of cluster '%s' failed, classified as: %s", clusterID, failure)
+	metadata.Instance.SetDeprovisionFailure(failure)
+}
+
+// classifyDeprovisionFailure determines why a deletion failed from the uninstall logs.
+func classifyDeprovisionFailure(logs map[string][]byte, timedOut bool) string {
+	for _, failure := range deprovisionFailures {
+		for _, data := range logs {
+			if failure.pattern.Match(data) {
+				return failure.name
+			}
+		}
+	}
+
+	if timedOut {
+		return deprovisionHung
+	}
+	return deprovisionUnknown
+}
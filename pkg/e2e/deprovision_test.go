@@ -0,0 +1,86 @@
+package e2e
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/events"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/providers/mock"
+)
+
+func TestClassifyDeprovisionFailure(t *testing.T) {
+	tests := []struct {
+		logs     map[string][]byte
+		timedOut bool
+		expected string
+	}{
+		{map[string][]byte{"uninstall": []byte("level=error msg=DependencyViolation: resource sg-123 has a dependent object")}, false, "cloud-dependency-violation"},
+		{map[string][]byte{"uninstall": []byte("UnauthorizedOperation: You are not authorized")}, true, "cloud-permissions"},
+		{map[string][]byte{"uninstall": []byte("Throttling: Rate exceeded")}, false, "cloud-throttling"},
+		{map[string][]byte{"uninstall": []byte("deleted route53 record\nlevel=error msg=failed to delete hosted zone: failure")}, false, "dns"},
+		{map[string][]byte{"uninstall": []byte("deleted route53 record")}, true, deprovisionHung},
+		{map[string][]byte{"uninstall": []byte("nothing useful")}, false, deprovisionUnknown},
+		{nil, true, deprovisionHung},
+	}
+
+	for _, test := range tests {
+		if failure := classifyDeprovisionFailure(test.logs, test.timedOut); failure != test.expected {
+			t.Errorf("expected %s for logs %q, got %s", test.expected, test.logs, failure)
+		}
+	}
+}
+
+func TestDeleteClusterFailure(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = resetEvents(); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	config.Instance.ReportDir = tmpDir
+	if provider, err = mock.New("prod"); err != nil {
+		t.Fatalf("failed to create mock provider: %v", err)
+	}
+	defer func() { provider = nil }()
+
+	if err = deleteCluster("fail"); err == nil {
+		t.Errorf("expected deleting the cluster to fail")
+	}
+
+	if !reflect.DeepEqual(events.GetListOfEvents(), []string{string(events.DeprovisionFailed)}) {
+		t.Errorf("the DeprovisionFailed event was not recorded")
+	}
+
+	if metadata.Instance.DeprovisionFailure != deprovisionUnknown {
+		t.Errorf("expected deprovision failure to be classified as %s, got %s", deprovisionUnknown, metadata.Instance.DeprovisionFailure)
+	}
+}
+
+func TestDeleteClusterWaitsUntilGone(t *testing.T) {
+	var err error
+	if provider, err = mock.New("prod"); err != nil {
+		t.Fatalf("failed to create mock provider: %v", err)
+	}
+	defer func() { provider = nil }()
+
+	defer func(timeout int64) { config.Instance.Cluster.DeprovisionTimeout = timeout }(config.Instance.Cluster.DeprovisionTimeout)
+	config.Instance.Cluster.DeprovisionTimeout = 1
+
+	clusterID, err := provider.LaunchCluster()
+	if err != nil {
+		t.Fatalf("failed to launch cluster: %v", err)
+	}
+
+	// the cluster is only gone once the provider no longer finds it
+	if err = deleteCluster(clusterID); err != nil {
+		t.Errorf("expected the cluster to be deleted: %v", err)
+	}
+}
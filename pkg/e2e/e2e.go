@@ -14,29 +14,46 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onsi/ginkgo"
 	ginkgoConfig "github.com/onsi/ginkgo/config"
 	"github.com/onsi/ginkgo/reporters"
 	"github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
 
+	"github.com/openshift/osde2e/pkg/common/artifacts"
+	"github.com/openshift/osde2e/pkg/common/attestation"
 	"github.com/openshift/osde2e/pkg/common/aws"
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/events"
 	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/htmlreport"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/manifest"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/progress"
 	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/releasecontroller"
+	osde2eReporters "github.com/openshift/osde2e/pkg/common/reporters"
+	"github.com/openshift/osde2e/pkg/common/runindex"
 	"github.com/openshift/osde2e/pkg/common/runner"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+	"github.com/openshift/osde2e/pkg/common/timing"
 	"github.com/openshift/osde2e/pkg/common/upgrade"
+	"github.com/openshift/osde2e/pkg/common/util"
 	"github.com/openshift/osde2e/pkg/debug"
 )
 
 const (
 	// hiveLog is the name of the hive log file.
 	hiveLog string = "hive-log.txt"
+
+	// teardownPhase labels progress events sent while the cluster is deleted.
+	teardownPhase = "teardown"
 )
 
 // provisioner is used to deploy and manage clusters.
@@ -46,8 +63,64 @@ var provider spi.Provider
 func RunTests() bool {
 	testing.Init()
 
-	if err := runGinkgoTests(); err != nil {
-		log.Printf("Tests failed: %v", err)
+	if err := progress.Start(config.Instance.Tests.ProgressEndpoint); err != nil {
+		logging.Warnf("Unable to send progress events: %v", err)
+	}
+	defer progress.Stop()
+
+	err := runGinkgoTests()
+
+	// compact artifacts before they are bundled or signed
+	if config.Instance.Tests.CompactArtifacts && config.Instance.ReportDir != "" {
+		if compactErr := compactArtifacts(config.Instance.ReportDir); compactErr != nil {
+			logging.Warnf("Unable to compact artifacts: %v", compactErr)
+		}
+	}
+
+	// the report links to the artifacts as they're left once compacted
+	if config.Instance.ReportDir != "" {
+		if reportErr := writeHTMLReport(config.Instance.ReportDir, err == nil); reportErr != nil {
+			logging.Warnf("Unable to write the HTML report: %v", reportErr)
+		}
+	}
+
+	// suites are read from the JUnit results before they're encrypted
+	if config.Instance.GateAlert.RoutingKey != "" {
+		if alertErr := pageGateRegressions(err); alertErr != nil {
+			logging.Warnf("Unable to track gate regressions: %v", alertErr)
+		}
+	}
+
+	if keyring := config.Instance.Tests.ArtifactEncryptionKeyring; keyring != "" && config.Instance.ReportDir != "" {
+		if encryptErr := encryptArtifacts(config.Instance.ReportDir, keyring); encryptErr != nil {
+			logging.Warnf("Unable to encrypt artifacts: %v", encryptErr)
+			err = fmt.Errorf("artifacts could not be encrypted: %v", encryptErr)
+		}
+	}
+
+	// sign the results last so that every result file is covered
+	if key := config.Instance.Tests.AttestationKey; key != "" && config.Instance.ReportDir != "" {
+		if attestErr := attestation.Write(config.Instance.ReportDir, key, err == nil); attestErr != nil {
+			logging.Warnf("Unable to attest to results: %v", attestErr)
+		}
+	}
+
+	if config.Instance.ReleaseController.URL != "" {
+		if postErr := postVerdict(err); postErr != nil {
+			logging.Warnf("Unable to post verdict to the release-controller: %v", postErr)
+		}
+	}
+
+	if config.Instance.RunIndex.URL != "" && !config.Instance.DryRun {
+		if publishErr := publishRun(err); publishErr != nil {
+			logging.Warnf("Unable to publish run to the run index: %v", publishErr)
+		}
+	}
+
+	runPostRunHooks(err)
+
+	if err != nil {
+		logging.Errorf("Tests failed: %v", err)
 		return false
 	}
 
@@ -66,6 +139,17 @@ func runGinkgoTests() error {
 	ginkgoConfig.GinkgoConfig.FocusString = cfg.Tests.GinkgoFocus
 	ginkgoConfig.GinkgoConfig.DryRun = cfg.DryRun
 
+	if err = selectImpactedSuites(); err != nil {
+		return fmt.Errorf("could not select the suites impacted by changed components: %v", err)
+	}
+
+	if err = selectLabeledSuites(); err != nil {
+		return fmt.Errorf("could not select the suites by their labels: %v", err)
+	}
+
+	startBudget()
+	defer stopBudget()
+
 	state := state.Instance
 
 	// setup OSD unless Kubeconfig is present
@@ -78,23 +162,23 @@ func runGinkgoTests() error {
 
 		metadata.Instance.SetEnvironment(provider.Environment())
 
-		// configure cluster and upgrade versions
-		if err = ChooseVersions(); err != nil {
+		releaseLock, err := lockEnvironment(provider.Environment())
+		if err != nil {
+			return fmt.Errorf("could not lock environment: %v", err)
+		}
+		defer releaseLock()
+
+		// configure cluster and upgrade versions, unless a plan already chose them
+		if cfg.Tests.ApplyPlan != "" {
+			if err = applyPlan(cfg.Tests.ApplyPlan); err != nil {
+				return fmt.Errorf("could not apply plan: %v", err)
+			}
+		} else if err = ChooseVersions(); err != nil {
 			return fmt.Errorf("failed to configure versions: %v", err)
 		}
 
-		switch {
-		case !state.Cluster.EnoughVersionsForOldestOrMiddleTest:
-			log.Printf("There were not enough available cluster image sets to choose and oldest or middle cluster image set to test against. Skipping tests.")
-			return nil
-		case !state.Cluster.PreviousVersionFromDefaultFound:
-			log.Printf("No previous version from default found with the given arguments.")
-			return nil
-		case state.Upgrade.UpgradeVersionEqualToInstallVersion:
-			log.Printf("Install version and upgrade version are the same. Skipping tests.")
-			return nil
-		case state.Upgrade.ReleaseName == NoVersionFound:
-			log.Printf("No valid upgrade versions were found. Skipping tests.")
+		if reason := skipReason(); reason != "" {
+			log.Print(reason)
 			return nil
 		}
 
@@ -103,7 +187,7 @@ func runGinkgoTests() error {
 			if cfg.DryRun {
 				log.Printf("This is a dry run. Skipping quota check.")
 			} else if enoughQuota, err := provider.CheckQuota(); err != nil {
-				log.Printf("Failed to check if enough quota is available: %v", err)
+				logging.Errorf("Failed to check if enough quota is available: %v", err)
 			} else if !enoughQuota {
 				return fmt.Errorf("currently not enough quota exists to run this test")
 			}
@@ -112,35 +196,95 @@ func runGinkgoTests() error {
 
 	// setup reporter
 	if err = os.Mkdir(cfg.ReportDir, os.ModePerm); err != nil {
-		log.Printf("Could not create reporter directory: %v", err)
+		logging.Warnf("Could not create reporter directory: %v", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = runAttempt()
+		if !shouldRetry(attempt) {
+			return err
+		}
+
+		if retryErr := prepareRetry(attempt); retryErr != nil {
+			logging.Errorf("Unable to retry on a new cluster: %v", retryErr)
+			return err
+		}
 	}
+}
+
+// skipReason explains why the chosen versions can't be tested, or is empty if they can.
+func skipReason() string {
+	state := state.Instance
+
+	switch {
+	case !state.Cluster.EnoughVersionsForOldestOrMiddleTest:
+		return "There were not enough available cluster image sets to choose and oldest or middle cluster image set to test against. Skipping tests."
+	case !state.Cluster.PreviousVersionFromDefaultFound:
+		return "No previous version from default found with the given arguments."
+	case state.Upgrade.UpgradeVersionEqualToInstallVersion:
+		return "Install version and upgrade version are the same. Skipping tests."
+	case state.Upgrade.ReleaseName == NoVersionFound:
+		return "No valid upgrade versions were found. Skipping tests."
+	}
+	return ""
+}
+
+// runAttempt runs the install and upgrade phases against a cluster, then cleans up after them.
+func runAttempt() (err error) {
+	cfg := config.Instance
+	state := state.Instance
 
 	log.Println("Running e2e tests...")
 
+	prober := startCanary(phase.InstallPhase)
+	defer stopCanary(prober)
+
 	testsPassed := runTestsInPhase(phase.InstallPhase, "OSD e2e suite")
 	upgradeTestsPassed := true
 
 	// upgrade cluster if requested
-	if state.Upgrade.Image != "" || state.Upgrade.ReleaseName != "" {
+	if reason := phase.Aborted(); reason != nil {
+		logging.Warnf("Skipping the upgrade as the run was aborted: %v", reason)
+	} else if state.Upgrade.Image != "" || state.Upgrade.ReleaseName != "" {
 		if state.Kubeconfig.Contents != nil {
-			if err = upgrade.RunUpgrade(provider); err != nil {
+			setCanaryPhase(prober, upgradingPhase)
+			progress.StartPhase(upgradingPhase)
+			err = upgrade.RunUpgrade(provider)
+			progress.EndPhase(upgradingPhase, err == nil)
+			if err != nil {
 				events.RecordEvent(events.UpgradeFailed)
-				return fmt.Errorf("error performing upgrade: %v", err)
+				recordPhaseFailure(phase.UpgradePhase, err)
+				return fmt.Errorf("error performing upgrade: %w", err)
 			}
 			events.RecordEvent(events.UpgradeSuccessful)
 
+			setCanaryPhase(prober, phase.UpgradePhase)
 			log.Println("Running e2e tests POST-UPGRADE...")
 			upgradeTestsPassed = runTestsInPhase(phase.UpgradePhase, "OSD e2e suite post-upgrade")
 		} else {
-			log.Println("No Kubeconfig found from initial cluster setup. Unable to run upgrade.")
+			logging.Warnf("No Kubeconfig found from initial cluster setup. Unable to run upgrade.")
 		}
 	}
 
+	stopCanary(prober)
+	stopBudget()
+	phase.BeginCleanup()
+
+	checkClusterDrift(driftAtEnd)
+
 	if cfg.ReportDir != "" {
 		if err = metadata.Instance.WriteToJSON(cfg.ReportDir); err != nil {
 			return fmt.Errorf("error while writing the custom metadata: %v", err)
 		}
 
+		if err = manifest.Write(cfg.ReportDir); err != nil {
+			return fmt.Errorf("error while writing the reproducibility manifest: %v", err)
+		}
+
+		if err = manifest.WriteEffectiveConfig(cfg.ReportDir); err != nil {
+			return fmt.Errorf("error while writing the effective config: %v", err)
+		}
+
 		checkBeforeMetricsGeneration()
 
 		newMetrics := NewMetrics()
@@ -163,16 +307,44 @@ func runGinkgoTests() error {
 		}
 	}
 
-	if cfg.Cluster.DestroyAfterTest {
+	// volumes are listed before the cluster is deleted, so their EBS volumes can be checked afterwards
+	var storageAudit *StorageAudit
+	if (cfg.Cluster.AuditStorage || cfg.Cluster.AuditVolumes) && state.Kubeconfig.Contents != nil && !cfg.DryRun {
+		var auditErr error
+		if storageAudit, auditErr = auditPersistentVolumes(); auditErr != nil {
+			logging.Warnf("Unable to audit persistent volumes: %v", auditErr)
+		}
+	}
+
+	// clusters created by an aborted run are always removed so they can't keep using the shared account
+	aborted := phase.Aborted()
+	if adoptedCluster {
+		log.Printf("Not destroying cluster '%s' as it was adopted by this run.", state.Cluster.ID)
+		releaseAdoptedCluster(provider)
+	} else if cfg.Cluster.DestroyAfterTest || (aborted != nil && launchedCluster) {
 		log.Printf("Destroying cluster '%s'...", state.Cluster.ID)
 
-		if err = provider.DeleteCluster(state.Cluster.ID); err != nil {
+		progress.StartPhase(teardownPhase)
+		err = deleteCluster(state.Cluster.ID)
+		progress.EndPhase(teardownPhase, err == nil)
+		if err != nil {
 			return fmt.Errorf("error deleting cluster: %s", err.Error())
 		}
+		destroyedCluster = true
+
+		if storageAudit != nil && cfg.Cluster.AuditVolumes {
+			if auditErr := storageAudit.auditVolumes(); auditErr != nil {
+				logging.Warnf("Unable to check for leaked EBS volumes: %v", auditErr)
+			}
+		}
 	} else {
 		log.Printf("For debugging, please look for cluster ID %s in environment %s", state.Cluster.ID, provider.Environment())
 	}
 
+	if storageAudit != nil {
+		storageAudit.write()
+	}
+
 	if !cfg.DryRun {
 		h := helper.NewOutsideGinkgo()
 
@@ -184,6 +356,14 @@ func runGinkgoTests() error {
 
 	}
 
+	if aborted != nil {
+		return fmt.Errorf("run was aborted: %v", aborted)
+	}
+
+	if storageAudit != nil && len(storageAudit.Leaks()) > 0 {
+		return fmt.Errorf("storage was leaked: %s", strings.Join(storageAudit.Leaks(), ", "))
+	}
+
 	if !testsPassed || !upgradeTestsPassed {
 		return fmt.Errorf("please inspect logs for more details")
 	}
@@ -208,11 +388,11 @@ func cleanupAfterE2E(h *helper.H) (errors []error) {
 		err := r.Run(mustGatherTimeoutInSeconds, stopCh)
 
 		if err != nil {
-			log.Printf("Error running must-gather: %s", err.Error())
+			logging.Warnf("Error running must-gather: %s", err.Error())
 		} else {
 			gatherResults, err := r.RetrieveResults()
 			if err != nil {
-				log.Printf("Error retrieving must-gather results: %s", err.Error())
+				logging.Warnf("Error retrieving must-gather results: %s", err.Error())
 			} else {
 				h.WriteResults(gatherResults)
 			}
@@ -225,17 +405,17 @@ func cleanupAfterE2E(h *helper.H) (errors []error) {
 	for resource, list := range clusterState {
 		data, err := json.MarshalIndent(list, "", "    ")
 		if err != nil {
-			log.Printf("error marshalling JSON for %s/%s/%s", resource.Group, resource.Version, resource.Resource)
+			logging.Warnf("error marshalling JSON for %s/%s/%s", resource.Group, resource.Version, resource.Resource)
 		} else {
 			var gbuf bytes.Buffer
 			zw := gzip.NewWriter(&gbuf)
 			_, err = zw.Write(data)
 			if err != nil {
-				log.Print("Error writing data to buffer")
+				logging.Warnf("Error writing data to buffer")
 			}
 			err = zw.Close()
 			if err != nil {
-				log.Print("Error closing writer to buffer")
+				logging.Warnf("Error closing writer to buffer")
 			}
 			// include gzip in filename to mark compressed data
 			filename := fmt.Sprintf("%s-%s-%s.json.gzip", resource.Group, resource.Version, resource.Resource)
@@ -250,12 +430,12 @@ func cleanupAfterE2E(h *helper.H) (errors []error) {
 	log.Print("Gathering cluster state from OCM")
 	if len(state.Cluster.ID) > 0 {
 		if provider, err = providers.ClusterProvider(); err != nil {
-			log.Printf("Error getting cluster provider: %s", err.Error())
+			logging.Warnf("Error getting cluster provider: %s", err.Error())
 		}
 
 		cluster, err := provider.GetCluster(state.Cluster.ID)
 		if err != nil {
-			log.Printf("error getting Cluster state: %s", err.Error())
+			logging.Warnf("error getting Cluster state: %s", err.Error())
 		} else {
 			log.Printf("Cluster addons: %v", cluster.Addons())
 			log.Printf("Cluster cloud provider: %v", cluster.CloudProvider())
@@ -268,6 +448,18 @@ func cleanupAfterE2E(h *helper.H) (errors []error) {
 		log.Print("No cluster ID set. Skipping OCM Queries.")
 	}
 
+	// objects left behind by the run's specs are looked for before the helper is cleaned up
+	if !cfg.DryRun {
+		if err = writeLeakedObjects(h); err != nil {
+			logging.Warnf("Error looking for objects left behind by the run: %v", err)
+		}
+	}
+
+	// the budget is deleted with the run's project, so its usage is gathered first
+	if err = writeResourceBudgetUsage(h); err != nil {
+		logging.Warnf("Error gathering resource budget usage: %v", err)
+	}
+
 	// We need to clean up our helper tests manually.
 	if !cfg.DryRun {
 		h.Cleanup()
@@ -276,37 +468,74 @@ func cleanupAfterE2E(h *helper.H) (errors []error) {
 	return errors
 }
 
+// writeResourceBudgetUsage reports what the run's namespaces requested compared to the run's resource budget, if
+// there is one.
+func writeResourceBudgetUsage(h *helper.H) error {
+	usage, err := h.ResourceBudgetUsage()
+	if err != nil || usage == nil {
+		return err
+	}
+
+	log.Printf("Resource budget usage: %s", usage.Summary())
+
+	data, err := yaml.Marshal(usage)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(config.Instance.ReportDir, helper.ResourceBudgetFile), data, os.ModePerm)
+}
+
 func runTestsInPhase(phase string, description string) bool {
 	cfg := config.Instance
 	state := state.Instance
 
 	state.Phase = phase
+	defer setPhaseDeadline()()
+	startClassBudgets()
+
 	phaseDirectory := filepath.Join(cfg.ReportDir, phase)
 	if _, err := os.Stat(phaseDirectory); os.IsNotExist(err) {
 		if err := os.Mkdir(phaseDirectory, os.FileMode(0755)); err != nil {
-			log.Printf("error while creating phase directory %s", phaseDirectory)
+			logging.Errorf("error while creating phase directory %s", phaseDirectory)
 			return false
 		}
 	}
-	phaseReportPath := filepath.Join(phaseDirectory, fmt.Sprintf("junit_%v.xml", cfg.Suffix))
-	phaseReporter := reporters.NewJUnitReporter(phaseReportPath)
 	ginkgoPassed := false
+	alertsBefore, alertsRead := firingAlerts()
 
-	// We need this anonymous function to make sure GinkgoRecover runs where we want it to
-	// and will still execute the rest of the function regardless whether the tests pass or fail.
-	func() {
-		defer ginkgo.GinkgoRecover()
-		ginkgoPassed = ginkgo.RunSpecsWithDefaultAndCustomReporters(ginkgo.GinkgoT(), description, []ginkgo.Reporter{phaseReporter})
-	}()
+	if cfg.Tests.Parallelism > 1 && !cfg.DryRun {
+		ginkgoPassed = runSpecsInParallel(phase, description, phaseDirectory)
+	} else {
+		phaseReportPath := filepath.Join(phaseDirectory, fmt.Sprintf("junit_%v.xml", cfg.Suffix))
+		phaseReporter := reporters.NewJUnitReporter(phaseReportPath)
+		jsonReporter := osde2eReporters.NewJSONReporter(filepath.Join(phaseDirectory, fmt.Sprintf("report_%v.json", cfg.Suffix))).WithLabels(specLabels)
+
+		// We need this anonymous function to make sure GinkgoRecover runs where we want it to
+		// and will still execute the rest of the function regardless whether the tests pass or fail.
+		func() {
+			defer ginkgo.GinkgoRecover()
+			ginkgoPassed = ginkgo.RunSpecsWithDefaultAndCustomReporters(ginkgo.GinkgoT(), description, []ginkgo.Reporter{phaseReporter, jsonReporter, osde2eReporters.NewProgressReporter(phase)})
+		}()
+	}
+	ginkgoPassed = retryFailedSpecs(phase, description, phaseDirectory, ginkgoPassed)
 
 	files, err := ioutil.ReadDir(phaseDirectory)
 	if err != nil {
-		log.Printf("error reading phase directory: %s", err.Error())
+		logging.Errorf("error reading phase directory: %s", err.Error())
+		return false
+	}
+
+	budget, err := timing.LoadBudget(cfg.Tests.DurationBudget, cfg.Tests.DurationBudgetFactor)
+	if err != nil {
+		logging.Errorf("error loading duration budget: %v", err)
 		return false
 	}
 
 	numTests := 0
 	numPassingTests := 0
+	var flakyTests []string
+	classes := suiteclass.NewTally(phase)
+	var durations []timing.Spec
 
 	for _, file := range files {
 		if file != nil {
@@ -314,14 +543,14 @@ func runTestsInPhase(phase string, description string) bool {
 			if junitFileRegex.MatchString(file.Name()) {
 				data, err := ioutil.ReadFile(filepath.Join(phaseDirectory, file.Name()))
 				if err != nil {
-					log.Printf("error opening junit file %s: %s", file.Name(), err.Error())
+					logging.Errorf("error opening junit file %s: %s", file.Name(), err.Error())
 					return false
 				}
 				// Use Ginkgo's JUnitTestSuite to unmarshal the JUnit XML file
 				var testSuite reporters.JUnitTestSuite
 
 				if err = xml.Unmarshal(data, &testSuite); err != nil {
-					log.Printf("error unmarshalling junit xml: %s", err.Error())
+					logging.Errorf("error unmarshalling junit xml: %s", err.Error())
 					return false
 				}
 
@@ -329,12 +558,28 @@ func runTestsInPhase(phase string, description string) bool {
 					isSkipped := testcase.Skipped != nil
 					isFail := testcase.FailureMessage != nil
 
+					duration := timing.Spec{Phase: phase, Name: testcase.Name, Seconds: testcase.Time, BudgetSeconds: budget.Seconds[testcase.Name]}
+					if err := budget.Check(testcase.Name, testcase.Time); err != nil && !isFail && !isSkipped {
+						logging.Errorf("%s %v", testcase.Name, err)
+						testSuite.TestCases[i].FailureMessage = &reporters.JUnitFailureMessage{Type: "Failure", Message: err.Error()}
+						testSuite.Failures++
+						isFail = true
+						duration.OverBudget = true
+					}
+					duration.Result = specResult(isFail, isSkipped)
+					durations = append(durations, duration)
+
+					classes.Add(suiteclass.Of(cfg.Tests.InformingSuites, testcase.Name), isFail, isSkipped)
+
 					if !isSkipped {
 						numTests++
 					}
 					if !isFail && !isSkipped {
 						numPassingTests++
 					}
+					if isFlaky(testcase) && !isFail {
+						flakyTests = append(flakyTests, fmt.Sprintf("[%s] %s", phase, testcase.Name))
+					}
 
 					testSuite.TestCases[i].Name = fmt.Sprintf("[%s] %s", phase, testcase.Name)
 				}
@@ -343,24 +588,46 @@ func runTestsInPhase(phase string, description string) bool {
 
 				err = ioutil.WriteFile(filepath.Join(phaseDirectory, file.Name()), data, 0644)
 				if err != nil {
-					log.Printf("error writing to junit file: %s", err.Error())
+					logging.Errorf("error writing to junit file: %s", err.Error())
 					return false
 				}
 			}
 		}
 	}
 
+	if err = timing.WriteReport(phaseDirectory, durations); err != nil {
+		logging.Warnf("error writing spec durations: %v", err)
+	}
+
 	passRate := float64(numPassingTests) / float64(numTests)
 
+	if cfg.Tests.RetryCount > 0 && numTests > 0 {
+		if len(flakyTests) > 0 {
+			logging.Warnf("%d specs of the %s phase are flaky and should be quarantined: %s", len(flakyTests), phase, strings.Join(flakyTests, ", "))
+		}
+		metadata.Instance.AddFlakyTests(flakyTests)
+		metadata.Instance.SetFlakeRate(phase, float64(len(flakyTests))/float64(numTests))
+	}
+
+	metadata.Instance.AddSuiteClassResults(classes.Results())
+	for _, result := range classes.Results() {
+		log.Printf("%s suites in the %s phase: %d tests, %d failed, %d skipped", strings.Title(result.Class), phase, result.Tests, result.Failures, result.Skipped)
+	}
+
+	// only blocking failures fail the phase, unless a failure wasn't attributed to any test
+	phasePassed := classes.Passed(cfg.Tests.FailOnInforming) && (ginkgoPassed || classes.Failures() > 0)
+
 	if math.IsNaN(passRate) {
-		log.Printf("Pass rate is NaN: numPassingTests = %d, numTests = %d", numPassingTests, numTests)
+		logging.Warnf("Pass rate is NaN: numPassingTests = %d, numTests = %d", numPassingTests, numTests)
 	} else {
 		metadata.Instance.SetPassRate(phase, passRate)
 	}
 
+	pushPhaseMetrics(phase, classes.Results(), phasePassed)
+
 	files, err = ioutil.ReadDir(cfg.ReportDir)
 	if err != nil {
-		log.Printf("error reading phase directory: %s", err.Error())
+		logging.Errorf("error reading phase directory: %s", err.Error())
 		return false
 	}
 
@@ -371,7 +638,7 @@ func runTestsInPhase(phase string, description string) bool {
 		if logFileRegex.MatchString(file.Name()) {
 			data, err := ioutil.ReadFile(filepath.Join(cfg.ReportDir, file.Name()))
 			if err != nil {
-				log.Printf("error opening log file %s: %s", file.Name(), err.Error())
+				logging.Errorf("error opening log file %s: %s", file.Name(), err.Error())
 				return false
 			}
 			for _, metric := range cfg.LogMetrics {
@@ -409,32 +676,59 @@ func runTestsInPhase(phase string, description string) bool {
 
 	err = ioutil.WriteFile(filepath.Join(phaseDirectory, "junit_logmetrics.xml"), data, 0644)
 	if err != nil {
-		log.Printf("error writing to junit file: %s", err.Error())
+		logging.Errorf("error writing to junit file: %s", err.Error())
 		return false
 	}
 
+	// the alerts report is written once the phase's results are tallied, so alerts don't fail the phase
+	if alertsRead {
+		reportAlertDiff(phase, phaseDirectory, alertsBefore)
+	}
+
 	if !cfg.DryRun && state.Cluster.State == spi.ClusterStateReady {
 		h := helper.NewOutsideGinkgo()
 		if h == nil {
-			log.Println("Unable to generate helper outside of ginkgo")
-			return ginkgoPassed
+			logging.Warnf("Unable to generate helper outside of ginkgo")
+			return phasePassed
 		}
 		dependencies, err := debug.GenerateDependencies(h.Kube())
 		if err != nil {
-			log.Printf("Error generating dependencies: %s", err.Error())
+			logging.Warnf("Error generating dependencies: %s", err.Error())
 		} else {
 			if err = ioutil.WriteFile(filepath.Join(phaseDirectory, "dependencies.txt"), []byte(dependencies), 0644); err != nil {
-				log.Printf("Error writing dependencies.txt: %s", err.Error())
+				logging.Warnf("Error writing dependencies.txt: %s", err.Error())
 			}
 
 			err := debug.GenerateDiff(cfg.BaseJobURL, phase, dependencies, cfg.JobName, cfg.JobID)
 			if err != nil {
-				log.Printf("Error generating diff: %s", err.Error())
+				logging.Warnf("Error generating diff: %s", err.Error())
 			}
 
 		}
 	}
-	return ginkgoPassed
+	return phasePassed
+}
+
+// specResult names the result of a spec in the spec durations report.
+func specResult(failed, skipped bool) string {
+	switch {
+	case failed:
+		return "failed"
+	case skipped:
+		return "skipped"
+	default:
+		return "passed"
+	}
+}
+
+// setPhaseDeadline bounds OCM calls made during a phase by the phase timeout. The returned function clears the deadline.
+func setPhaseDeadline() func() {
+	if timeout := config.Instance.Tests.PhaseTimeout; timeout > 0 {
+		phase.SetDeadline(time.Now().Add(time.Duration(timeout) * time.Minute))
+	}
+	return func() {
+		phase.SetDeadline(time.Time{})
+	}
 }
 
 // checkBeforeMetricsGeneration runs a variety of checks before generating metrics.
@@ -447,6 +741,129 @@ func checkBeforeMetricsGeneration() error {
 	return nil
 }
 
+// postVerdict reports whether the blocking suites passed for the tested release to the release-controller.
+func postVerdict(runErr error) error {
+	cfg := config.Instance
+	if cfg.DryRun || strings.HasPrefix(cfg.JobName, "rehearse-") {
+		log.Printf("Job %s is a rehearsal or dry run, so the verdict isn't posted.", cfg.JobName)
+		return nil
+	}
+
+	release := gatedRelease()
+	if release == "" {
+		return fmt.Errorf("no release was tested")
+	}
+
+	verdict := releasecontroller.Verdict{
+		Release:      release,
+		Verification: cfg.ReleaseController.Verification,
+		State:        releasecontroller.StateSucceeded,
+		Message:      "OSD blocking suites passed",
+	}
+	if runErr != nil {
+		verdict.State = releasecontroller.StateFailed
+		verdict.Message = fmt.Sprintf("OSD blocking suites failed: %v", runErr)
+	}
+	verdict.URL = jobURL()
+
+	client := releasecontroller.New(cfg.ReleaseController.URL, cfg.ReleaseController.Token, cfg.ReleaseController.Stream)
+	if err := client.Post(verdict); err != nil {
+		return err
+	}
+	log.Printf("Posted %s verdict for %s to the release-controller.", verdict.State, release)
+	return nil
+}
+
+// gatedRelease returns the release the run gates, which is the release being upgraded to for upgrade runs.
+func gatedRelease() string {
+	release := state.Instance.Cluster.Version
+	if state.Instance.Upgrade.ReleaseName != "" {
+		release = state.Instance.Upgrade.ReleaseName
+	}
+	return strings.TrimPrefix(release, util.VersionPrefix)
+}
+
+// publishRun publishes a summary of the run to the run index.
+func publishRun(runErr error) error {
+	publisher, err := runindex.New(config.Instance.RunIndex.URL, config.Instance.RunIndex.Token)
+	if err != nil {
+		return err
+	}
+
+	record := runindex.Record{
+		Job:            config.Instance.JobName,
+		JobID:          config.Instance.JobID,
+		Provider:       config.Instance.Provider,
+		Environment:    metadata.Instance.Environment,
+		ClusterID:      state.Instance.Cluster.ID,
+		ClusterVersion: state.Instance.Cluster.Version,
+		UpgradeVersion: state.Instance.Upgrade.ReleaseName,
+		Verdict:        runindex.VerdictPassed,
+		ArtifactURL:    jobURL(),
+		Finished:       time.Now(),
+	}
+	if runErr != nil {
+		record.Verdict = runindex.VerdictFailed
+		record.Message = runErr.Error()
+	}
+
+	if err = publisher.Publish(record); err != nil {
+		return err
+	}
+	log.Printf("Published %s run to the run index.", record.Verdict)
+	return nil
+}
+
+// jobURL returns where the artifacts of the job are, or an empty string if the run isn't a job.
+func jobURL() string {
+	cfg := config.Instance
+	if cfg.JobID == -1 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%d", cfg.BaseJobURL, cfg.JobName, cfg.JobID)
+}
+
+// writeHTMLReport renders the results of the run as a single HTML page in the report directory.
+func writeHTMLReport(reportDir string, passed bool) error {
+	report, err := htmlreport.Load(reportDir)
+	if err != nil {
+		return err
+	}
+	report.Passed = passed
+	report.Title = "osde2e run"
+	if cfg := config.Instance; cfg.JobName != "" {
+		report.Title = fmt.Sprintf("%s #%d", cfg.JobName, cfg.JobID)
+	}
+	return report.Write(reportDir)
+}
+
+// compactArtifacts removes duplicate artifacts in the report directory and compresses large text artifacts.
+func compactArtifacts(reportDir string) error {
+	index, err := artifacts.CompactReportDir(reportDir, config.Instance.Tests.ArtifactCompressionThreshold*1024)
+	if err != nil {
+		return err
+	}
+	log.Printf("Compacted %d artifacts in %s from %d to %d bytes", len(index.Files), reportDir, index.OriginalSize, index.StoredSize)
+	return nil
+}
+
+// encryptArtifacts replaces the artifacts in the report directory with a bundle encrypted for the keys in keyring.
+func encryptArtifacts(reportDir, keyring string) error {
+	recipients, err := artifacts.LoadRecipients(keyring)
+	if err != nil {
+		return err
+	}
+
+	keyIDs := artifacts.KeyIDs(recipients)
+	metadata.Instance.SetArtifactEncryptionKeys(keyIDs)
+
+	if err = artifacts.EncryptReportDir(reportDir, recipients); err != nil {
+		return err
+	}
+	log.Printf("Encrypted artifacts in %s for keys %s", reportDir, strings.Join(keyIDs, ", "))
+	return nil
+}
+
 // uploadFileToMetricsBucket uploads the given file (with absolute path) to the metrics S3 bucket "incoming" directory.
 func uploadFileToMetricsBucket(filename string) error {
 	data, err := ioutil.ReadFile(filename)
@@ -0,0 +1,119 @@
+package e2e
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	ginkgoConfig "github.com/onsi/ginkgo/config"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/impact"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/manifest"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/plan"
+)
+
+// ExecutionPlan is what a run with the current config would do, resolved without creating a cluster.
+type ExecutionPlan struct {
+	// Profiles are the profiles that were merged, in order.
+	Profiles []string `yaml:"profiles,omitempty"`
+
+	// Config are the options set by configs, files, and environment variables rather than defaults. Secrets are
+	// redacted.
+	Config []load.Setting `yaml:"config"`
+
+	// Kubeconfig is the kubeconfig of the existing cluster the run would test, instead of using a provider.
+	Kubeconfig string `yaml:"kubeconfig,omitempty"`
+
+	// Plan is the cluster, versions, and provider operations the run would use.
+	Plan *plan.Plan `yaml:"plan,omitempty"`
+
+	// Phases are the phases the specs would run in.
+	Phases []string `yaml:"phases"`
+
+	// Specs are the specs that would run in each phase, unless the run is aborted or a suite class runs out of time.
+	Specs []string `yaml:"specs"`
+}
+
+// Marshal encodes the execution plan as YAML.
+func (p *ExecutionPlan) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling execution plan: %v", err)
+	}
+	return data, nil
+}
+
+// BuildExecutionPlan resolves the config, versions, upgrade, and specs of a run with the current config, without
+// creating a cluster or making any other changes.
+func BuildExecutionPlan() (*ExecutionPlan, error) {
+	testing.Init()
+	cfg := config.Instance
+
+	effective := manifest.GenerateEffectiveConfig()
+	p := &ExecutionPlan{Profiles: effective.Profiles, Phases: []string{phase.InstallPhase}}
+	for _, setting := range effective.Config {
+		if setting.Source != load.DefaultSource && setting.Source != load.UnsetSource {
+			p.Config = append(p.Config, setting)
+		}
+	}
+
+	var err error
+	switch {
+	case cfg.Kubeconfig.Path != "":
+		p.Kubeconfig = cfg.Kubeconfig.Path
+	case cfg.Tests.ApplyPlan != "":
+		if p.Plan, err = plan.Read(cfg.Tests.ApplyPlan); err != nil {
+			return nil, err
+		}
+	default:
+		if p.Plan, err = buildPlan(false); err != nil {
+			return nil, err
+		}
+	}
+	if p.Plan != nil && p.Plan.Upgrade != nil {
+		p.Phases = append(p.Phases, phase.UpgradePhase)
+	}
+
+	specs, err := plannedSpecs()
+	if err != nil {
+		return nil, err
+	}
+	p.Specs = specs
+	return p, nil
+}
+
+// plannedSpecs lists the specs selected by the config, sorted by name since Ginkgo shuffles them with each run's seed.
+func plannedSpecs() ([]string, error) {
+	cfg := config.Instance
+
+	skip, focus := ginkgoConfig.GinkgoConfig.SkipString, ginkgoConfig.GinkgoConfig.FocusString
+	ginkgoConfig.GinkgoConfig.SkipString = cfg.Tests.GinkgoSkip
+	ginkgoConfig.GinkgoConfig.FocusString = cfg.Tests.GinkgoFocus
+	defer func() {
+		ginkgoConfig.GinkgoConfig.SkipString, ginkgoConfig.GinkgoConfig.FocusString = skip, focus
+	}()
+
+	if err := selectImpactedSuites(); err != nil {
+		return nil, fmt.Errorf("could not select the suites impacted by changed components: %v", err)
+	}
+	if err := selectLabeledSuites(); err != nil {
+		return nil, fmt.Errorf("could not select the suites by their labels: %v", err)
+	}
+
+	specs := []string{}
+	for _, spec := range listSpecs("OSD e2e suite") {
+		if !spec.runs || !selectsContext(spec.context) {
+			continue
+		}
+		if impactedSuites != nil && !impact.Selects(impactedSuites, spec.context) {
+			continue
+		}
+		specs = append(specs, spec.fullText())
+	}
+	sort.Strings(specs)
+	return specs, nil
+}
@@ -0,0 +1,68 @@
+package e2e
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/plan"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+func TestBuildExecutionPlan(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	defer func(cfg config.Config, st state.State) {
+		*config.Instance, *state.Instance = cfg, st
+	}(*config.Instance, *state.Instance)
+
+	p := &plan.Plan{
+		Provider:    "mock",
+		Environment: "stage",
+		Cluster:     plan.Cluster{Name: "osde2e-abcde", Version: "openshift-v4.5.1"},
+		Upgrade:     &plan.Upgrade{ReleaseName: "openshift-v4.5.2"},
+		Operations:  []plan.Operation{{Kind: plan.CreateCluster, Payload: "{}"}},
+	}
+	file := filepath.Join(tmpDir, "plan.yaml")
+	if err = p.Write(file); err != nil {
+		t.Fatalf("failed to write plan: %v", err)
+	}
+
+	os.Setenv("TESTS_TO_RUN", "[Suite: e2e]")
+	defer os.Unsetenv("TESTS_TO_RUN")
+	if err = load.IntoObject(config.Instance, []string{}, "", ""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	config.Instance.Tests.ApplyPlan = file
+
+	executionPlan, err := BuildExecutionPlan()
+	if err != nil {
+		t.Fatalf("failed to build execution plan: %v", err)
+	}
+
+	if executionPlan.Plan == nil || executionPlan.Plan.Cluster.Name != "osde2e-abcde" {
+		t.Errorf("expected the applied plan's cluster, got %+v", executionPlan.Plan)
+	}
+	if !reflect.DeepEqual(executionPlan.Phases, []string{"install", "upgrade"}) {
+		t.Errorf("expected install and upgrade phases, got %v", executionPlan.Phases)
+	}
+
+	found := false
+	for _, setting := range executionPlan.Config {
+		if setting.Source == load.DefaultSource || setting.Source == load.UnsetSource {
+			t.Errorf("expected only the options that aren't defaults, got %+v", setting)
+		}
+		found = found || setting.Key == "tests.testsToRun"
+	}
+	if !found {
+		t.Errorf("expected the tests to run to be in the execution plan's config, got %+v", executionPlan.Config)
+	}
+}
@@ -0,0 +1,288 @@
+// Package faultinjection contains opt-in suites that inject infrastructure faults into a cluster and verify it survives them.
+package faultinjection
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// AZFailureReportFile is the name of the report of recovery timings written by the AZ failure suite.
+	AZFailureReportFile = "az-failure-report.yaml"
+
+	// zoneLabel is the node label holding the availability zone of a node.
+	zoneLabel = "failure-domain.beta.kubernetes.io/zone"
+
+	// maxAPIInterruption is the longest the API may be unreachable while a zone is down.
+	maxAPIInterruption = 2 * time.Minute
+
+	pollInterval = 5 * time.Second
+)
+
+// AZFailureReport records what was taken down and how long the cluster took to recover.
+type AZFailureReport struct {
+	Zone      string   `yaml:"zone"`
+	Instances []string `yaml:"instances"`
+	Nodes     []string `yaml:"nodes"`
+
+	// Outage is how long the zone was kept down.
+	Outage time.Duration `yaml:"outage"`
+
+	// APIUnavailable is the total time the API was unreachable during the outage, and LongestAPIInterruption
+	// is the longest single stretch of it.
+	APIUnavailable         time.Duration `yaml:"apiUnavailable"`
+	LongestAPIInterruption time.Duration `yaml:"longestAPIInterruption"`
+
+	// MinWorkloadReplicas is the fewest available replicas the test workload had during the outage.
+	MinWorkloadReplicas int32 `yaml:"minWorkloadReplicas"`
+
+	// WorkloadRescheduled is how long after the zone went down the test workload was fully available again.
+	// It is zero if that didn't happen during the outage.
+	WorkloadRescheduled time.Duration `yaml:"workloadRescheduled,omitempty"`
+
+	// NodesReady and OperatorsReady are how long after the zone was restored its nodes and the cluster operators were ready.
+	NodesReady     time.Duration `yaml:"nodesReady,omitempty"`
+	OperatorsReady time.Duration `yaml:"operatorsReady,omitempty"`
+}
+
+var _ = ginkgo.Describe("[Suite: az-failure] Availability zone outage", func() {
+	h := helper.New()
+
+	ginkgo.It("should be survived by the control plane and workloads", func() {
+		cfg := config.Instance.FaultInjection
+		if state.Instance.CloudProvider.CloudProviderID != "aws" {
+			ginkgo.Skip("AZ failures can only be simulated on AWS")
+		}
+
+		nodes, err := h.Kube().CoreV1().Nodes().List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list nodes")
+
+		zones := nodesByZone(nodes.Items)
+		if len(zones) < 2 {
+			ginkgo.Skip("the cluster is not multi-AZ")
+		}
+
+		zone, err := chooseZone(zones, cfg.Zone)
+		Expect(err).NotTo(HaveOccurred())
+
+		infra, err := h.Cfg().ConfigV1().Infrastructures().Get("cluster", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't get the cluster infrastructure name")
+
+		region := state.Instance.CloudProvider.Region
+		instances, err := aws.ClusterInstancesInZone(region, infra.Status.InfrastructureName, zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instances).NotTo(BeEmpty(), "no running instances found in %s", zone)
+
+		replicas := int32(2 * len(zones))
+		deployment, err := h.Kube().AppsV1().Deployments(h.CurrentProject()).Create(zoneSpreadDeployment(replicas))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the test workload")
+		Expect(waitForReplicas(h, deployment.Name, replicas, 10*time.Minute)).To(Succeed(), "the test workload never became available")
+
+		report := &AZFailureReport{
+			Zone:                zone,
+			Instances:           instances,
+			Nodes:               zones[zone],
+			MinWorkloadReplicas: replicas,
+		}
+
+		log.Printf("Simulating an outage of %s by stopping instances %v", zone, instances)
+		Expect(aws.StopInstances(region, instances)).To(Succeed())
+		down := time.Now()
+
+		restored := false
+		defer func() {
+			// never leave the zone down, even if the test fails
+			if !restored {
+				if err := aws.StartInstances(region, instances); err != nil {
+					logging.Warnf("Unable to restore %s: %v", zone, err)
+				}
+			}
+		}()
+
+		monitorOutage(h, deployment.Name, replicas, down, time.Duration(cfg.Duration)*time.Minute, report)
+		report.Outage = time.Since(down)
+
+		log.Printf("Restoring %s", zone)
+		Expect(aws.StartInstances(region, instances)).To(Succeed())
+		restored = true
+		up := time.Now()
+
+		recoveryTimeout := time.Duration(cfg.RecoveryTimeout) * time.Minute
+		nodesErr := wait.PollImmediate(pollInterval, recoveryTimeout, func() (bool, error) {
+			return nodesReady(h, zones[zone]), nil
+		})
+		if nodesErr == nil {
+			report.NodesReady = time.Since(up)
+		}
+
+		operatorsErr := wait.PollImmediate(pollInterval, recoveryTimeout-time.Since(up), func() (bool, error) {
+			ready, _ := healthchecks.CheckOperatorReadiness(h.Cfg().ConfigV1())
+			return ready, nil
+		})
+		if operatorsErr == nil {
+			report.OperatorsReady = time.Since(up)
+		}
+
+		data, err := yaml.Marshal(report)
+		Expect(err).NotTo(HaveOccurred(), "failure encoding AZ failure report")
+		h.WriteResults(map[string][]byte{AZFailureReportFile: data})
+
+		Expect(report.LongestAPIInterruption).To(BeNumerically("<=", maxAPIInterruption), "the API was unreachable for too long while %s was down", zone)
+		Expect(report.MinWorkloadReplicas).To(BeNumerically(">", 0), "the test workload was unavailable while %s was down", zone)
+		Expect(nodesErr).NotTo(HaveOccurred(), "nodes in %s weren't ready after it was restored", zone)
+		Expect(operatorsErr).NotTo(HaveOccurred(), "cluster operators didn't recover after %s was restored", zone)
+		Expect(waitForReplicas(h, deployment.Name, replicas, recoveryTimeout)).To(Succeed(), "the test workload didn't recover")
+	})
+})
+
+// monitorOutage polls the API and the test workload until the outage has lasted for duration.
+func monitorOutage(h *helper.H, name string, replicas int32, down time.Time, duration time.Duration, report *AZFailureReport) {
+	var interrupted time.Time
+	for time.Since(down) < duration {
+		time.Sleep(pollInterval)
+
+		deployment, err := h.Kube().AppsV1().Deployments(h.CurrentProject()).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if interrupted.IsZero() {
+				interrupted = time.Now()
+				log.Printf("API became unreachable: %v", err)
+			}
+			report.APIUnavailable += pollInterval
+			if d := time.Since(interrupted); d > report.LongestAPIInterruption {
+				report.LongestAPIInterruption = d
+			}
+			continue
+		}
+		if !interrupted.IsZero() {
+			log.Printf("API became reachable again after %v", time.Since(interrupted))
+			interrupted = time.Time{}
+		}
+
+		available := deployment.Status.AvailableReplicas
+		if available < report.MinWorkloadReplicas {
+			report.MinWorkloadReplicas = available
+		}
+		if available == replicas && report.MinWorkloadReplicas < replicas && report.WorkloadRescheduled == 0 {
+			report.WorkloadRescheduled = time.Since(down)
+			log.Printf("Test workload was rescheduled after %v", report.WorkloadRescheduled)
+		}
+	}
+}
+
+// nodesByZone groups the names of nodes by their availability zone.
+func nodesByZone(nodes []kubev1.Node) map[string][]string {
+	zones := map[string][]string{}
+	for _, node := range nodes {
+		if zone, ok := node.Labels[zoneLabel]; ok {
+			zones[zone] = append(zones[zone], node.Name)
+		}
+	}
+	return zones
+}
+
+// chooseZone returns the configured zone, or the last zone with nodes if none is configured.
+func chooseZone(zones map[string][]string, configured string) (string, error) {
+	if configured != "" {
+		if _, ok := zones[configured]; !ok {
+			return "", fmt.Errorf("no nodes found in zone %s", configured)
+		}
+		return configured, nil
+	}
+
+	var names []string
+	for zone := range zones {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	return names[len(names)-1], nil
+}
+
+// nodesReady returns true if all of the named nodes are ready.
+func nodesReady(h *helper.H, names []string) bool {
+	for _, name := range names {
+		node, err := h.Kube().CoreV1().Nodes().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+
+		ready := false
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == kubev1.NodeReady && condition.Status == kubev1.ConditionTrue {
+				ready = true
+			}
+		}
+		if !ready {
+			return false
+		}
+	}
+	return true
+}
+
+func waitForReplicas(h *helper.H, name string, replicas int32, timeout time.Duration) error {
+	return wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		deployment, err := h.Kube().AppsV1().Deployments(h.CurrentProject()).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		return deployment.Status.AvailableReplicas == replicas, nil
+	})
+}
+
+// zoneSpreadDeployment is a workload whose replicas prefer to run in different zones.
+func zoneSpreadDeployment(replicas int32) *appsv1.Deployment {
+	labels := map[string]string{"app": "az-failure"}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "az-failure",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: kubev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: kubev1.PodSpec{
+					Affinity: &kubev1.Affinity{
+						PodAntiAffinity: &kubev1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []kubev1.WeightedPodAffinityTerm{
+								{
+									Weight: 100,
+									PodAffinityTerm: kubev1.PodAffinityTerm{
+										LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+										TopologyKey:   zoneLabel,
+									},
+								},
+							},
+						},
+					},
+					Containers: []kubev1.Container{
+						{
+							Name:  "pause",
+							Image: "registry.access.redhat.com/ubi8/ubi-minimal",
+							Command: []string{
+								"/bin/sh", "-c", "sleep infinity",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,7 @@
+package faultinjection
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: az-failure]", "disruptive", "nodes", "slo")
+var _ = testlabels.Declare("[Suite: machine-health-check]", "disruptive", "nodes", "slo")
@@ -0,0 +1,247 @@
+package faultinjection
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	kubev1 "k8s.io/api/core/v1"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+const (
+	// MachineHealthCheckReportFile is the name of the report of replacement timings written by the machine health
+	// check suite.
+	MachineHealthCheckReportFile = "machine-health-check-report.yaml"
+
+	// machineAPINamespace is the namespace of the cluster's machines and machine health checks.
+	machineAPINamespace = "openshift-machine-api"
+
+	// machineRoleLabel is the machine label holding the role of its node.
+	machineRoleLabel = "machine.openshift.io/cluster-api-machine-role"
+
+	// hostCommandImage runs commands on nodes from privileged pods.
+	hostCommandImage = "registry.access.redhat.com/ubi8/ubi-minimal"
+)
+
+var (
+	machineResource            = schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machines"}
+	machineHealthCheckResource = schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machinehealthchecks"}
+)
+
+// MachineHealthCheckReport records the worker made unhealthy and how long its replacement took.
+type MachineHealthCheckReport struct {
+	MachineHealthCheck string `yaml:"machineHealthCheck"`
+	Machine            string `yaml:"machine"`
+	Node               string `yaml:"node"`
+
+	// ReplacementMachine and ReplacementNode replaced the unhealthy worker.
+	ReplacementMachine string `yaml:"replacementMachine,omitempty"`
+	ReplacementNode    string `yaml:"replacementNode,omitempty"`
+
+	// NodeNotReady, MachineDeleted, and Replaced are how long after the kubelet was stopped the node was reported not
+	// ready, the machine health check deleted the machine, and the replacement's node was ready.
+	NodeNotReady   time.Duration `yaml:"nodeNotReady,omitempty"`
+	MachineDeleted time.Duration `yaml:"machineDeleted,omitempty"`
+	Replaced       time.Duration `yaml:"replaced,omitempty"`
+}
+
+var _ = ginkgo.Describe("[Suite: machine-health-check] Machine health checks", func() {
+	h := helper.New()
+
+	ginkgo.It("should replace an unhealthy worker within the SLO", func() {
+		cfg := config.Instance.FaultInjection
+		machines := h.Dynamic().Resource(machineResource).Namespace(machineAPINamespace)
+
+		checks, err := h.Dynamic().Resource(machineHealthCheckResource).Namespace(machineAPINamespace).List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list machine health checks")
+
+		machineList, err := machines.List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list machines")
+
+		machine, check, err := chooseWorkerMachine(checks.Items, machineList.Items)
+		Expect(err).NotTo(HaveOccurred())
+		if machine == nil {
+			ginkgo.Skip("no worker machine is covered by a machine health check")
+		}
+
+		report := &MachineHealthCheckReport{
+			MachineHealthCheck: check.GetName(),
+			Machine:            machine.GetName(),
+			Node:               machineNode(*machine),
+		}
+
+		timeout := time.Duration(cfg.MachineReplacementTimeout) * time.Minute
+		h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
+
+		log.Printf("Stopping the kubelet of %s on machine %s, covered by machine health check %s", report.Node, report.Machine, report.MachineHealthCheck)
+		pod, err := h.Kube().CoreV1().Pods(h.CurrentProject()).Create(stopKubeletPod(report.Node, timeout))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the pod stopping the kubelet")
+		defer h.Kube().CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		stopped := time.Now()
+
+		err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+			node, err := h.Kube().CoreV1().Nodes().Get(report.Node, metav1.GetOptions{})
+			return err == nil && !nodeReady(*node), nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "%s was never reported not ready", report.Node)
+		report.NodeNotReady = time.Since(stopped)
+		log.Printf("%s was reported not ready after %v", report.Node, report.NodeNotReady)
+
+		existing := map[string]bool{}
+		for _, m := range machineList.Items {
+			existing[m.GetName()] = true
+		}
+
+		replaceErr := wait.PollImmediate(pollInterval, timeout-time.Since(stopped), func() (bool, error) {
+			if report.MachineDeleted == 0 {
+				m, err := machines.Get(report.Machine, metav1.GetOptions{})
+				if err != nil && !kerror.IsNotFound(err) || err == nil && m.GetDeletionTimestamp() == nil {
+					return false, nil
+				}
+				report.MachineDeleted = time.Since(stopped)
+				log.Printf("Machine %s was deleted after %v", report.Machine, report.MachineDeleted)
+			}
+
+			current, err := machines.List(metav1.ListOptions{})
+			if err != nil {
+				return false, nil
+			}
+			for _, m := range current.Items {
+				node := machineNode(m)
+				if existing[m.GetName()] || node == "" || !checkSelects(*check, m) {
+					continue
+				}
+
+				if n, err := h.Kube().CoreV1().Nodes().Get(node, metav1.GetOptions{}); err == nil && nodeReady(*n) {
+					report.ReplacementMachine, report.ReplacementNode = m.GetName(), node
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if replaceErr == nil {
+			report.Replaced = time.Since(stopped)
+			log.Printf("Machine %s was replaced by %s after %v", report.Machine, report.ReplacementMachine, report.Replaced)
+			metadata.Instance.SetTimeToMachineReplaced(report.Replaced.Seconds())
+		}
+
+		data, err := yaml.Marshal(report)
+		Expect(err).NotTo(HaveOccurred(), "failure encoding machine health check report")
+		h.WriteResults(map[string][]byte{MachineHealthCheckReportFile: data})
+
+		Expect(report.MachineDeleted).NotTo(BeZero(), "machine health check %s never deleted machine %s", report.MachineHealthCheck, report.Machine)
+		Expect(replaceErr).NotTo(HaveOccurred(), "machine %s wasn't replaced by a machine with a ready node", report.Machine)
+		Expect(report.Replaced).To(BeNumerically("<=", time.Duration(cfg.MachineReplacementSLO)*time.Minute), "replacing the unhealthy worker took longer than the SLO")
+	})
+})
+
+// chooseWorkerMachine returns the first worker machine with a node that is covered by a machine health check, and
+// the check. If there is none, the machine is nil.
+func chooseWorkerMachine(checks, machines []unstructured.Unstructured) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].GetName() < machines[j].GetName()
+	})
+
+	for i := range checks {
+		if _, err := checkSelector(checks[i]); err != nil {
+			return nil, nil, err
+		}
+
+		for j, machine := range machines {
+			if machine.GetLabels()[machineRoleLabel] != "worker" || machine.GetDeletionTimestamp() != nil || machineNode(machine) == "" {
+				continue
+			}
+			if checkSelects(checks[i], machine) {
+				return &machines[j], &checks[i], nil
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+// checkSelects returns true if a machine health check covers a machine. Checks with empty selectors are ignored.
+func checkSelects(check, machine unstructured.Unstructured) bool {
+	selector, err := checkSelector(check)
+	return err == nil && !selector.Empty() && selector.Matches(labels.Set(machine.GetLabels()))
+}
+
+// checkSelector returns the selector of the machines a machine health check covers.
+func checkSelector(check unstructured.Unstructured) (labels.Selector, error) {
+	raw, _, err := unstructured.NestedMap(check.Object, "spec", "selector")
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector in machine health check %s: %v", check.GetName(), err)
+	}
+
+	var selector metav1.LabelSelector
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &selector); err != nil {
+		return nil, fmt.Errorf("invalid selector in machine health check %s: %v", check.GetName(), err)
+	}
+	return metav1.LabelSelectorAsSelector(&selector)
+}
+
+// machineNode returns the name of a machine's node, if it has one.
+func machineNode(machine unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(machine.Object, "status", "nodeRef", "name")
+	return name
+}
+
+// nodeReady returns true if a node is ready.
+func nodeReady(node kubev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == kubev1.NodeReady {
+			return condition.Status == kubev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// stopKubeletPod is a privileged pod that stops the kubelet of a node, and starts it again after timeout if the node
+// is still around, so the node isn't left broken if it isn't replaced.
+func stopKubeletPod(nodeName string, timeout time.Duration) *kubev1.Pod {
+	privileged := true
+	script := fmt.Sprintf("systemctl stop kubelet && sleep %d; systemctl start kubelet", int(timeout.Seconds()))
+	return &kubev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "stop-kubelet-" + util.RandomStr(5),
+		},
+		Spec: kubev1.PodSpec{
+			NodeName:      nodeName,
+			RestartPolicy: kubev1.RestartPolicyNever,
+			Tolerations:   []kubev1.Toleration{{Operator: kubev1.TolerationOpExists}},
+			Containers: []kubev1.Container{
+				{
+					Name:    "stop-kubelet",
+					Image:   hostCommandImage,
+					Command: []string{"chroot", "/host", "/bin/sh", "-c", script},
+					SecurityContext: &kubev1.SecurityContext{
+						Privileged: &privileged,
+					},
+					VolumeMounts: []kubev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+				},
+			},
+			Volumes: []kubev1.Volume{
+				{
+					Name:         "host",
+					VolumeSource: kubev1.VolumeSource{HostPath: &kubev1.HostPathVolumeSource{Path: "/"}},
+				},
+			},
+		},
+	}
+}
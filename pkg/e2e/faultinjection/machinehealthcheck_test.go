@@ -0,0 +1,55 @@
+package faultinjection
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestChooseWorkerMachine(t *testing.T) {
+	machine := func(name, role, node string) unstructured.Unstructured {
+		m := unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":   name,
+				"labels": map[string]interface{}{machineRoleLabel: role, "machine.openshift.io/cluster-api-machineset": "worker-a"},
+			},
+		}}
+		if node != "" {
+			unstructured.SetNestedField(m.Object, node, "status", "nodeRef", "name")
+		}
+		return m
+	}
+	check := func(name string, matchLabels map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": name},
+			"spec":     map[string]interface{}{"selector": map[string]interface{}{"matchLabels": matchLabels}},
+		}}
+	}
+
+	machines := []unstructured.Unstructured{
+		machine("worker-c", "worker", "node-c"),
+		machine("master-a", "master", "node-master"),
+		machine("worker-b", "worker", ""),
+		machine("worker-d", "worker", "node-d"),
+	}
+
+	checks := []unstructured.Unstructured{
+		check("masters", map[string]interface{}{machineRoleLabel: "master"}),
+		check("srep-worker-healthcheck", map[string]interface{}{machineRoleLabel: "worker"}),
+	}
+	chosen, chosenCheck, err := chooseWorkerMachine(checks, machines)
+	if err != nil {
+		t.Fatalf("failed to choose a machine: %v", err)
+	}
+	if chosen == nil || chosen.GetName() != "worker-c" || chosenCheck.GetName() != "srep-worker-healthcheck" {
+		t.Errorf("expected worker-c, the first worker with a node, to be chosen with srep-worker-healthcheck, got %v and %v", chosen, chosenCheck)
+	}
+	if node := machineNode(*chosen); node != "node-c" {
+		t.Errorf("expected node-c, got %s", node)
+	}
+
+	// checks with empty selectors are ignored
+	if chosen, _, err = chooseWorkerMachine([]unstructured.Unstructured{check("empty", nil), checks[0]}, machines); err != nil || chosen != nil {
+		t.Errorf("expected no machine to be covered, got %v: %v", chosen, err)
+	}
+}
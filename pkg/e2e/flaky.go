@@ -0,0 +1,209 @@
+package e2e
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo"
+	ginkgoConfig "github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	osde2eReporters "github.com/openshift/osde2e/pkg/common/reporters"
+)
+
+const (
+	// retriesDirectory is the directory in a phase's report directory the reports of each retry of its failed specs
+	// are written to.
+	retriesDirectory = "retries"
+
+	// flakyMessagePrefix starts the passed message of specs that failed and then passed when they were run again.
+	flakyMessagePrefix = "Flaky: "
+
+	// topLevelText is the text of the container Ginkgo puts every spec in, which focus strings are matched against.
+	topLevelText = "[Top Level]"
+)
+
+// retryingSpecs is true while the failed specs of a phase are run again, on the cluster that was already set up.
+var retryingSpecs bool
+
+// junitReport is a JUnit report in a phase's report directory.
+type junitReport struct {
+	path  string
+	suite reporters.JUnitTestSuite
+}
+
+// retryFailedSpecs runs the specs that failed in a phase again, up to TEST_RETRY_COUNT times, and marks the ones that
+// pass as flaky in the phase's JUnit reports. It returns whether the phase's specs passed.
+func retryFailedSpecs(currentPhase, description, phaseDirectory string, passed bool) bool {
+	cfg := config.Instance
+	if passed || cfg.Tests.RetryCount == 0 || cfg.DryRun {
+		return passed
+	}
+
+	reports, err := readJUnitReports(phaseDirectory)
+	if err != nil {
+		logging.Warnf("Unable to read the JUnit reports of the %s phase to retry its failed specs: %v", currentPhase, err)
+		return passed
+	}
+
+	failed, setupFailed := failedSpecs(reports)
+	if setupFailed {
+		logging.Warnf("The suite of the %s phase failed to set up, so its specs aren't retried.", currentPhase)
+		return passed
+	}
+
+	for retry := 1; retry <= cfg.Tests.RetryCount && len(failed) > 0; retry++ {
+		if reason := phase.Aborted(); reason != nil {
+			logging.Warnf("Not retrying the failed specs of the %s phase as the run was aborted: %v", currentPhase, reason)
+			break
+		}
+
+		log.Printf("Running the %d failed specs of the %s phase again (retry %d of %d)...", len(failed), currentPhase, retry, cfg.Tests.RetryCount)
+		retried, err := runFocusedSpecs(currentPhase, description, failed, filepath.Join(phaseDirectory, retriesDirectory, strconv.Itoa(retry)))
+		if err != nil {
+			logging.Warnf("Unable to retry the failed specs of the %s phase: %v", currentPhase, err)
+			break
+		}
+
+		for _, name := range markFlaky(reports, retried, retry) {
+			log.Printf("%s passed on retry %d, so it's reported as flaky.", name, retry)
+		}
+		failed, _ = failedSpecs(reports)
+	}
+
+	for _, report := range reports {
+		data, err := xml.Marshal(&report.suite)
+		if err == nil {
+			err = ioutil.WriteFile(report.path, data, 0644)
+		}
+		if err != nil {
+			logging.Warnf("Unable to write JUnit report %s: %v", report.path, err)
+			return passed
+		}
+	}
+	return len(failed) == 0
+}
+
+// runFocusedSpecs runs the named specs, writing their reports to dir, and returns their JUnit report.
+func runFocusedSpecs(currentPhase, description string, names []string, dir string) (reporters.JUnitTestSuite, error) {
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return reporters.JUnitTestSuite{}, err
+	}
+
+	focus := ginkgoConfig.GinkgoConfig.FocusString
+	ginkgoConfig.GinkgoConfig.FocusString = focusSpecs(names)
+	retryingSpecs = true
+	defer func() {
+		ginkgoConfig.GinkgoConfig.FocusString = focus
+		retryingSpecs = false
+	}()
+
+	suffix := config.Instance.Suffix
+	reportPath := filepath.Join(dir, fmt.Sprintf("junit_%v.xml", suffix))
+	jsonReporter := osde2eReporters.NewJSONReporter(filepath.Join(dir, fmt.Sprintf("report_%v.json", suffix))).WithLabels(specLabels)
+
+	func() {
+		defer ginkgo.GinkgoRecover()
+		ginkgo.RunSpecsWithDefaultAndCustomReporters(ginkgo.GinkgoT(), description, []ginkgo.Reporter{reporters.NewJUnitReporter(reportPath), jsonReporter, osde2eReporters.NewProgressReporter(currentPhase)})
+	}()
+
+	var suite reporters.JUnitTestSuite
+	data, err := ioutil.ReadFile(reportPath)
+	if err == nil {
+		err = xml.Unmarshal(data, &suite)
+	}
+	return suite, err
+}
+
+// readJUnitReports reads the JUnit reports in a phase's report directory.
+func readJUnitReports(phaseDirectory string) ([]junitReport, error) {
+	files, err := ioutil.ReadDir(phaseDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []junitReport
+	for _, file := range files {
+		if file.IsDir() || !junitFileRegex.MatchString(file.Name()) {
+			continue
+		}
+
+		report := junitReport{path: filepath.Join(phaseDirectory, file.Name())}
+		data, err := ioutil.ReadFile(report.path)
+		if err != nil {
+			return nil, err
+		}
+		if err = xml.Unmarshal(data, &report.suite); err != nil {
+			return nil, fmt.Errorf("error unmarshalling %s: %v", file.Name(), err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// failedSpecs returns the names of the failed specs in reports, and whether the suite failed to set up or tear down.
+func failedSpecs(reports []junitReport) (failed []string, setupFailed bool) {
+	for _, report := range reports {
+		for _, testcase := range report.suite.TestCases {
+			if testcase.FailureMessage == nil {
+				continue
+			}
+
+			if testcase.Name == "BeforeSuite" || testcase.Name == "AfterSuite" {
+				setupFailed = true
+			} else {
+				failed = append(failed, testcase.Name)
+			}
+		}
+	}
+	return
+}
+
+// markFlaky marks the failed specs in reports that passed when retried as flaky, and returns their names.
+func markFlaky(reports []junitReport, retried reporters.JUnitTestSuite, retry int) (flaky []string) {
+	passed := map[string]bool{}
+	for _, testcase := range retried.TestCases {
+		passed[testcase.Name] = testcase.FailureMessage == nil && testcase.Skipped == nil
+	}
+
+	for i := range reports {
+		suite := &reports[i].suite
+		for j, testcase := range suite.TestCases {
+			if testcase.FailureMessage == nil || !passed[testcase.Name] {
+				continue
+			}
+
+			suite.TestCases[j].PassedMessage = &reporters.JUnitPassedMessage{
+				Message: fmt.Sprintf("%spassed on retry %d after failing: %s", flakyMessagePrefix, retry, testcase.FailureMessage.Message),
+			}
+			suite.TestCases[j].FailureMessage = nil
+			suite.Failures--
+			flaky = append(flaky, testcase.Name)
+		}
+	}
+	return flaky
+}
+
+// isFlaky returns true if a spec failed and then passed when it was run again.
+func isFlaky(testcase reporters.JUnitTestCase) bool {
+	return testcase.PassedMessage != nil && strings.HasPrefix(testcase.PassedMessage.Message, flakyMessagePrefix)
+}
+
+// focusSpecs returns a Ginkgo focus string matching only the named specs.
+func focusSpecs(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return fmt.Sprintf("^%s (%s)$", regexp.QuoteMeta(topLevelText), strings.Join(quoted, "|"))
+}
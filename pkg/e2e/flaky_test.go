@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/onsi/ginkgo/reporters"
+)
+
+func TestMarkFlaky(t *testing.T) {
+	failure := func(message string) *reporters.JUnitFailureMessage {
+		return &reporters.JUnitFailureMessage{Type: "Failure", Message: message}
+	}
+
+	reports := []junitReport{{suite: reporters.JUnitTestSuite{Tests: 4, Failures: 3, TestCases: []reporters.JUnitTestCase{
+		{Name: "Pods should be healthy"},
+		{Name: "Routes should be reachable", FailureMessage: failure("timed out")},
+		{Name: "Nodes should be ready", FailureMessage: failure("node not ready")},
+		{Name: "Alerts should not fire", FailureMessage: failure("alert firing")},
+	}}}}
+
+	failed, setupFailed := failedSpecs(reports)
+	if setupFailed || !reflect.DeepEqual(failed, []string{"Routes should be reachable", "Nodes should be ready", "Alerts should not fire"}) {
+		t.Fatalf("unexpected failed specs %v, setup failed: %t", failed, setupFailed)
+	}
+
+	retried := reporters.JUnitTestSuite{TestCases: []reporters.JUnitTestCase{
+		{Name: "Routes should be reachable"},
+		{Name: "Nodes should be ready", FailureMessage: failure("node not ready")},
+		{Name: "Alerts should not fire", Skipped: &reporters.JUnitSkipped{}},
+	}}
+	if flaky := markFlaky(reports, retried, 2); !reflect.DeepEqual(flaky, []string{"Routes should be reachable"}) {
+		t.Errorf("expected only the spec that passed when retried to be flaky, got %v", flaky)
+	}
+
+	suite := reports[0].suite
+	if suite.Failures != 2 {
+		t.Errorf("expected 2 failures to be left, got %d", suite.Failures)
+	}
+	for _, testcase := range suite.TestCases {
+		if flaky := testcase.Name == "Routes should be reachable"; isFlaky(testcase) != flaky {
+			t.Errorf("expected %s to be flaky: %t, got %+v", testcase.Name, flaky, testcase)
+		}
+	}
+	if message := suite.TestCases[1].PassedMessage.Message; message != "Flaky: passed on retry 2 after failing: timed out" {
+		t.Errorf("unexpected passed message %q", message)
+	}
+
+	reports[0].suite.TestCases = append(reports[0].suite.TestCases, reporters.JUnitTestCase{Name: "BeforeSuite", FailureMessage: failure("no cluster")})
+	if _, setupFailed = failedSpecs(reports); !setupFailed {
+		t.Errorf("expected a failed BeforeSuite to be a setup failure")
+	}
+}
+
+func TestFocusSpecs(t *testing.T) {
+	focus := regexp.MustCompile(focusSpecs([]string{"Routes should be reachable", "[Serial] Nodes should drain (1.2)"}))
+
+	tests := map[string]bool{
+		"[Top Level] Routes should be reachable":            true,
+		"[Top Level] [Serial] Nodes should drain (1.2)":     true,
+		"[Top Level] Ingress Routes should be reachable":    false,
+		"[Top Level] Routes should be reachable over HTTPS": false,
+		"[Top Level] [Serial] Nodes should drain 1.2":       false,
+	}
+	for text, expected := range tests {
+		if matched := focus.MatchString(text); matched != expected {
+			t.Errorf("expected %q to be matched: %t, got %t", text, expected, matched)
+		}
+	}
+}
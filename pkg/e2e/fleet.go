@@ -0,0 +1,252 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/cluster"
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/prometheus"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// FleetReportFile is where the report of a fleet audit is written in the report dir.
+const FleetReportFile = "fleet-report.yaml"
+
+// sloQueryTimeout is how long each SLO query may take.
+const sloQueryTimeout = 2 * time.Minute
+
+// FleetReport is the result of auditing existing clusters.
+type FleetReport struct {
+	Search   spi.ClusterSearch `yaml:"search"`
+	Audited  time.Time         `yaml:"audited"`
+	Passed   int               `yaml:"passed"`
+	Failed   int               `yaml:"failed"`
+	Clusters []FleetCluster    `yaml:"clusters"`
+}
+
+// FleetCluster is the result of auditing a cluster. A cluster passes if it is healthy and meets its SLOs. Drift and
+// checks that couldn't be run are reported, but don't fail the cluster.
+type FleetCluster struct {
+	ID      string `yaml:"id"`
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Passed  bool   `yaml:"passed"`
+
+	FailedHealthChecks []string       `yaml:"failedHealthChecks,omitempty"`
+	SLOViolations      []string       `yaml:"sloViolations,omitempty"`
+	Drift              []ClusterDrift `yaml:"drift,omitempty"`
+	Errors             []string       `yaml:"errors,omitempty"`
+}
+
+// sloQuery is a Prometheus query that returns results when a cluster misses an SLO.
+type sloQuery struct {
+	name  string
+	query *template.Template
+}
+
+// AuditFleet runs read-only checks against the existing clusters matching the search, up to limit clusters, and
+// writes a fleet report to the report dir. Clusters are only read from: they're checked for health, SLO violations,
+// and drift from their provider's intent, but nothing is created on them.
+func AuditFleet(search spi.ClusterSearch, limit int) (*FleetReport, error) {
+	cfg := config.Instance
+
+	queries, err := parseSLOQueries(cfg.Fleet.SLOQueries)
+	if err != nil {
+		return nil, err
+	}
+
+	var promAPI v1.API
+	if len(queries) > 0 {
+		client, err := prometheus.CreateClient()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create Prometheus client for SLO queries: %v", err)
+		}
+		promAPI = v1.NewAPI(client)
+	}
+
+	if provider, err = providers.ClusterProvider(); err != nil {
+		return nil, fmt.Errorf("could not setup cluster provider: %v", err)
+	}
+	sampler, ok := provider.(spi.ClusterSampleProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s can't search for clusters", cfg.Provider)
+	}
+
+	summaries, err := sampler.SearchClusters(search, limit)
+	if err != nil {
+		return nil, err
+	}
+	logging.Infof("Auditing %d clusters...", len(summaries))
+
+	skipped := map[string]bool{}
+	for _, name := range healthchecks.Skipped(cfg.Tests.HealthCheckSkip) {
+		skipped[name] = true
+	}
+
+	report := &FleetReport{Search: search, Audited: time.Now().UTC(), Clusters: make([]FleetCluster, len(summaries))}
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, cfg.Fleet.Parallel)
+	for i, summary := range summaries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, summary spi.ClusterSummary) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			report.Clusters[i] = auditCluster(summary, skipped, promAPI, queries)
+		}(i, summary)
+	}
+	wg.Wait()
+
+	for _, c := range report.Clusters {
+		if c.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	logging.Infof("%d of %d clusters passed the audit.", report.Passed, len(report.Clusters))
+	return report, report.write(cfg.ReportDir)
+}
+
+// auditCluster runs the read-only checks against a cluster.
+func auditCluster(summary spi.ClusterSummary, skipped map[string]bool, promAPI v1.API, queries []sloQuery) FleetCluster {
+	result := FleetCluster{ID: summary.ID, Name: summary.Name, Version: summary.Version}
+
+	if promAPI != nil {
+		for _, query := range queries {
+			violated, err := sloViolated(promAPI, query, summary.ID)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("SLO %s: %v", query.name, err))
+			} else if violated {
+				result.SLOViolations = append(result.SLOViolations, query.name)
+			}
+		}
+	}
+
+	kubeconfig, err := provider.ClusterKubeconfig(summary.ID)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("kubeconfig: %v", err))
+		return result.finish()
+	}
+
+	if result.FailedHealthChecks, err = cluster.CheckHealth(kubeconfig, skipped); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("health checks: %v", err))
+	}
+
+	if intentProvider, ok := provider.(spi.ClusterIntentProvider); ok {
+		if intent, err := intentProvider.ClusterIntent(summary.ID); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("intended configuration: %v", err))
+		} else if actual, err := observeCluster(kubeconfig); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("configuration: %v", err))
+		} else {
+			result.Drift = compareCluster(intent, actual)
+		}
+	}
+	return result.finish()
+}
+
+// finish decides whether the cluster passed and logs the result.
+func (c FleetCluster) finish() FleetCluster {
+	// a cluster that couldn't be health checked hasn't shown it's healthy
+	c.Passed = len(c.FailedHealthChecks) == 0 && len(c.SLOViolations) == 0 && len(c.Errors) == 0
+
+	var problems []string
+	for _, check := range c.FailedHealthChecks {
+		problems = append(problems, "unhealthy: "+check)
+	}
+	for _, slo := range c.SLOViolations {
+		problems = append(problems, "missed SLO "+slo)
+	}
+	for _, drift := range c.Drift {
+		problems = append(problems, "drifted: "+drift.String())
+	}
+	problems = append(problems, c.Errors...)
+
+	if len(problems) == 0 {
+		logging.Infof("Cluster %s (%s) passed the audit.", c.Name, c.ID)
+	} else {
+		logging.Errorf("Cluster %s (%s): %s", c.Name, c.ID, strings.Join(problems, "; "))
+	}
+	return c
+}
+
+// parseSLOQueries parses SLO queries given as name=query.
+func parseSLOQueries(queries []string) ([]sloQuery, error) {
+	var parsed []sloQuery
+	for _, q := range queries {
+		parts := strings.SplitN(q, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("SLO query '%s' should be in the format <name>=<query>", q)
+		}
+		tmpl, err := template.New(parts[0]).Parse(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SLO query %s: %v", parts[0], err)
+		}
+		parsed = append(parsed, sloQuery{name: parts[0], query: tmpl})
+	}
+	return parsed, nil
+}
+
+// sloViolated runs an SLO query for a cluster and returns true if it returned any results.
+func sloViolated(promAPI v1.API, query sloQuery, clusterID string) (bool, error) {
+	var buf bytes.Buffer
+	if err := query.query.Execute(&buf, struct{ ClusterID string }{clusterID}); err != nil {
+		return false, fmt.Errorf("error templating query: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sloQueryTimeout)
+	defer cancel()
+
+	value, _, err := promAPI.Query(ctx, buf.String(), time.Now())
+	if err != nil {
+		return false, err
+	}
+	return resultCount(value) > 0, nil
+}
+
+// resultCount is the number of samples or series in a query result.
+func resultCount(value model.Value) int {
+	switch v := value.(type) {
+	case model.Vector:
+		return len(v)
+	case model.Matrix:
+		return len(v)
+	case *model.Scalar, *model.String:
+		return 1
+	}
+	return 0
+}
+
+// write writes the report to the report dir.
+func (r *FleetReport) write(reportDir string) error {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("unable to marshal fleet report: %v", err)
+	}
+
+	if err = os.MkdirAll(reportDir, os.ModePerm); err != nil {
+		return fmt.Errorf("unable to create report dir: %v", err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(reportDir, FleetReportFile), data, os.FileMode(0644)); err != nil {
+		return fmt.Errorf("unable to write fleet report: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package e2e
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseSLOQueries(t *testing.T) {
+	queries, err := parseSLOQueries([]string{`api-availability=avg_over_time(up{cluster_id="{{.ClusterID}}"}[1h]) < 0.99`})
+	if err != nil {
+		t.Fatalf("failed to parse SLO queries: %v", err)
+	}
+	if len(queries) != 1 || queries[0].name != "api-availability" {
+		t.Fatalf("expected the api-availability query, got %v", queries)
+	}
+
+	var buf bytes.Buffer
+	if err = queries[0].query.Execute(&buf, struct{ ClusterID string }{"abc"}); err != nil {
+		t.Fatalf("failed to template query: %v", err)
+	}
+	if expected := `avg_over_time(up{cluster_id="abc"}[1h]) < 0.99`; buf.String() != expected {
+		t.Errorf("expected query %s, got %s", expected, buf.String())
+	}
+
+	for _, invalid := range []string{"no-query", "=up", "name=", "broken={{.ClusterID"} {
+		if _, err = parseSLOQueries([]string{invalid}); err == nil {
+			t.Errorf("expected SLO query %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestFleetClusterPassed(t *testing.T) {
+	tests := map[string]struct {
+		cluster FleetCluster
+		passed  bool
+	}{
+		"healthy":   {FleetCluster{}, true},
+		"drifted":   {FleetCluster{Drift: []ClusterDrift{{Field: "region", Intended: "us-east-1", Actual: "us-west-2"}}}, true},
+		"unhealthy": {FleetCluster{FailedHealthChecks: []string{"nodes: 1 not ready"}}, false},
+		"slo":       {FleetCluster{SLOViolations: []string{"api-availability"}}, false},
+		"unchecked": {FleetCluster{Errors: []string{"kubeconfig: not found"}}, false},
+	}
+	for name, test := range tests {
+		if passed := test.cluster.finish().Passed; passed != test.passed {
+			t.Errorf("%s: expected passed to be %t, got %t", name, test.passed, passed)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package e2e
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/reporters"
+
+	"github.com/openshift/osde2e/pkg/common/alert"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+)
+
+// runSuite is the suite failures that aren't attributed to a suite are counted under, such as a cluster that
+// couldn't be provisioned or upgraded.
+const runSuite = "run"
+
+// suiteRegex matches the suite at the start of a test context, ex. "[Suite: e2e]" in "[Suite: e2e] Pods".
+var suiteRegex = regexp.MustCompile(`^\[Suite: [^\]]+\]`)
+
+// pageGateRegressions records which blocking suites the run passed for the release it gates, paging once the release
+// has failed a suite too many times in a row.
+func pageGateRegressions(runErr error) error {
+	cfg := config.Instance
+	if cfg.DryRun || strings.HasPrefix(cfg.JobName, "rehearse-") {
+		logging.Infof("Job %s is a rehearsal or dry run, so gate regressions aren't tracked.", cfg.JobName)
+		return nil
+	}
+	if cfg.GateAlert.StateURL == "" {
+		return fmt.Errorf("no state URL is set to count consecutive failures in")
+	}
+
+	release := gatedRelease()
+	if release == "" {
+		return fmt.Errorf("no release was tested")
+	}
+
+	results, err := gateResults(cfg.ReportDir, cfg.Tests.InformingSuites, runErr)
+	if err != nil {
+		return err
+	}
+
+	source := cfg.JobName
+	if source == "" {
+		source = "osde2e"
+	}
+	pager := alert.NewPagerDuty(cfg.GateAlert.EventsURL, cfg.GateAlert.RoutingKey)
+	tracker := alert.New(alert.S3Store{URL: cfg.GateAlert.StateURL}, pager, cfg.GateAlert.Failures, source)
+
+	var errs []string
+	for _, result := range results {
+		result.Version, result.URL = release, jobURL()
+		if err = tracker.Record(result); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("couldn't track gate regressions: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// gateResults returns whether the run passed each blocking suite in the JUnit results of its phases, sorted by
+// suite. Tests outside of a suite are grouped by their JUnit suite. The run's own result is included, and only
+// fails if the run failed without a suite failing.
+func gateResults(reportDir string, informingSuites []string, runErr error) ([]alert.Result, error) {
+	failed := map[string]bool{}
+
+	phases, err := ioutil.ReadDir(reportDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading report dir: %v", err)
+	}
+	for _, phase := range phases {
+		if !phase.IsDir() {
+			continue
+		}
+		files, err := ioutil.ReadDir(filepath.Join(reportDir, phase.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading phase directory: %v", err)
+		}
+
+		for _, file := range files {
+			// alerts and log metrics are reported without failing the phase
+			if !junitFileRegex.MatchString(file.Name()) || file.Name() == alertsReportFile || file.Name() == "junit_logmetrics.xml" {
+				continue
+			}
+
+			data, err := ioutil.ReadFile(filepath.Join(reportDir, phase.Name(), file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			var testSuite reporters.JUnitTestSuite
+			if err = xml.Unmarshal(data, &testSuite); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %v", file.Name(), err)
+			}
+
+			for _, testcase := range testSuite.TestCases {
+				name := strings.TrimPrefix(testcase.Name, fmt.Sprintf("[%s] ", phase.Name()))
+				if testcase.Skipped != nil || suiteclass.Of(informingSuites, name) == suiteclass.Informing {
+					continue
+				}
+
+				suite := suiteRegex.FindString(name)
+				if suite == "" {
+					suite = testSuite.Name
+				}
+				failed[suite] = failed[suite] || testcase.FailureMessage != nil
+			}
+		}
+	}
+
+	var results []alert.Result
+	suiteFailed := false
+	for suite, suiteFailures := range failed {
+		results = append(results, alert.Result{Suite: suite, Passed: !suiteFailures})
+		suiteFailed = suiteFailed || suiteFailures
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Suite < results[j].Suite })
+	return append(results, alert.Result{Suite: runSuite, Passed: runErr == nil || suiteFailed}), nil
+}
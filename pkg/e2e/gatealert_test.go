@@ -0,0 +1,70 @@
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/alert"
+)
+
+func TestGateResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gate-results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"install/junit_abc.xml": `<testsuite name="OSD e2e suite">
+<testcase name="[install] [Suite: e2e] Pods should run"><failure>timed out</failure></testcase>
+<testcase name="[install] [Suite: e2e] Routes should be created"></testcase>
+<testcase name="[install] [Suite: operators] Certman should be installed"></testcase>
+<testcase name="[install] [Suite: scale] Nodes should scale"><skipped></skipped></testcase>
+<testcase name="[install] [Suite: informing] Baseline should match"><failure>drifted</failure></testcase>
+</testsuite>`,
+		"install/junit_addon.xml":      `<testsuite name="addon harness"><testcase name="addon is ready"></testcase></testsuite>`,
+		"install/junit_alerts.xml":     `<testsuite name="Alerts"><testcase name="[Alerts] KubePodCrashLooping (warning)"><failure>fired</failure></testcase></testsuite>`,
+		"install/junit_logmetrics.xml": `<testsuite name="Log Metrics"><testcase name="[Log Metrics] panics"><failure>1 match</failure></testcase></testsuite>`,
+		"upgrade/junit_abc.xml":        `<testsuite name="OSD e2e suite post-upgrade"><testcase name="[upgrade] [Suite: operators] Certman should be installed"><failure>missing</failure></testcase></testsuite>`,
+		"install/spec-durations.json":  `[]`,
+		"custom-prow-metadata.json":    `{}`,
+	}
+	for name, data := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := gateResults(dir, []string{"[Suite: informing]"}, fmt.Errorf("please inspect logs for more details"))
+	if err != nil {
+		t.Fatalf("failed to read gate results: %v", err)
+	}
+	expected := []alert.Result{
+		{Suite: "[Suite: e2e]", Passed: false},
+		{Suite: "[Suite: operators]", Passed: false},
+		{Suite: "addon harness", Passed: true},
+		{Suite: runSuite, Passed: true},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %v, got %v", expected, results)
+	}
+
+	// runs that fail without results fail on their own
+	empty, err := ioutil.TempDir("", "gate-results")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(empty)
+
+	results, err = gateResults(empty, nil, fmt.Errorf("error launching cluster"))
+	if expected := []alert.Result{{Suite: runSuite, Passed: false}}; err != nil || !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %v, got %v: %v", expected, results, err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package hibernation contains an opt-in suite that hibernates and resumes a cluster and checks the transitions against SLOs.
+package hibernation
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const pollInterval = 30 * time.Second
+
+var _ = ginkgo.Describe("[Suite: hibernation] Cluster hibernation", func() {
+	h := helper.New()
+
+	ginkgo.It("should hibernate and resume within the SLOs", func() {
+		cfg := config.Instance.Hibernation
+		clusterID := state.Instance.Cluster.ID
+		if clusterID == "" {
+			ginkgo.Skip("hibernation requires a cluster ID")
+		}
+
+		provider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		hibernationProvider, ok := provider.(spi.HibernationProvider)
+		if !ok {
+			ginkgo.Skip("the cluster provider does not support hibernation")
+		}
+
+		timeout := time.Duration(cfg.Timeout) * time.Minute
+
+		Expect(hibernationProvider.Hibernate(clusterID)).To(Succeed())
+		resumed := false
+		defer func() {
+			// never leave the cluster hibernating, the rest of the run needs it
+			if !resumed {
+				if err := hibernationProvider.Resume(clusterID); err != nil {
+					logging.Warnf("Unable to resume cluster '%s': %v", clusterID, err)
+				}
+			}
+		}()
+
+		hibernateStarted := time.Now()
+		Expect(waitForState(provider, clusterID, spi.ClusterStateHibernating, timeout)).To(Succeed(), "the cluster didn't hibernate")
+		timeToHibernate := time.Since(hibernateStarted)
+		log.Printf("Cluster hibernated after %v", timeToHibernate)
+
+		Expect(hibernationProvider.Resume(clusterID)).To(Succeed())
+		resumed = true
+
+		resumeStarted := time.Now()
+		Expect(waitForState(provider, clusterID, spi.ClusterStateReady, timeout)).To(Succeed(), "the cluster didn't resume")
+		timeToResume := time.Since(resumeStarted)
+		log.Printf("Cluster resumed after %v", timeToResume)
+
+		healthyStarted := time.Now()
+		healthErr := wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+			return clusterHealthy(h), nil
+		})
+		timeToHealthy := time.Since(healthyStarted)
+		if healthErr == nil {
+			log.Printf("Cluster was healthy %v after resuming", timeToHealthy)
+		}
+
+		metadata.Instance.SetHibernationTimes(timeToHibernate.Seconds(), timeToResume.Seconds(), timeToHealthy.Seconds())
+
+		Expect(healthErr).NotTo(HaveOccurred(), "the cluster wasn't healthy after resuming")
+		Expect(timeToHibernate).To(BeNumerically("<=", time.Duration(cfg.HibernateSLO)*time.Minute), "hibernating took longer than the SLO")
+		Expect(timeToResume).To(BeNumerically("<=", time.Duration(cfg.ResumeSLO)*time.Minute), "resuming took longer than the SLO")
+		Expect(timeToHealthy).To(BeNumerically("<=", time.Duration(cfg.HealthySLO)*time.Minute), "becoming healthy after resuming took longer than the SLO")
+	})
+})
+
+// waitForState polls the provider until the cluster is in the desired state.
+func waitForState(provider spi.Provider, clusterID string, desired spi.ClusterState, timeout time.Duration) error {
+	var last spi.ClusterState
+	err := phase.Poll(pollInterval, timeout, func() (bool, error) {
+		cluster, err := provider.GetCluster(clusterID)
+		if err != nil {
+			logging.Warnf("Error getting cluster '%s': %v", clusterID, err)
+			return false, nil
+		}
+
+		if cluster.State() != last {
+			last = cluster.State()
+			log.Printf("Cluster '%s' is %s", clusterID, last)
+		}
+		return last == desired, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cluster was %s rather than %s: %v", last, desired, err)
+	}
+	return nil
+}
+
+// clusterHealthy returns true if the nodes, operators, and pods of the cluster are healthy.
+func clusterHealthy(h *helper.H) bool {
+	checks := []func() (bool, error){
+		func() (bool, error) { return healthchecks.CheckNodeHealth(h.Kube().CoreV1()) },
+		func() (bool, error) { return healthchecks.CheckCVOReadiness(h.Cfg().ConfigV1()) },
+		func() (bool, error) { return healthchecks.CheckOperatorReadiness(h.Cfg().ConfigV1()) },
+		func() (bool, error) { return healthchecks.CheckPodHealth(h.Kube().CoreV1()) },
+	}
+
+	for _, check := range checks {
+		if healthy, err := check(); !healthy || err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package e2e
+
+import (
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/hooks"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// runHooks invokes the hooks for a point of the run. Hooks are integrations, so their failures are logged
+// without failing the run.
+func runHooks(point hooks.Point) {
+	invokeHooks(hookContext(point))
+}
+
+// runPostRunHooks invokes the post-run hooks with the outcome of the run.
+func runPostRunHooks(runErr error) {
+	ctx := hookContext(hooks.PostRun)
+	passed := runErr == nil
+	ctx.Passed = &passed
+	if runErr != nil {
+		ctx.Error = runErr.Error()
+	}
+	invokeHooks(ctx)
+}
+
+func invokeHooks(ctx hooks.Context) {
+	if config.Instance.DryRun {
+		return
+	}
+
+	if err := hooks.Run(ctx, config.Instance.Hooks.Webhooks); err != nil {
+		logging.Warnf("Error running hooks: %v", err)
+	}
+}
+
+func hookContext(point hooks.Point) hooks.Context {
+	cfg := config.Instance
+	state := state.Instance
+
+	return hooks.Context{
+		Point:          point,
+		JobName:        cfg.JobName,
+		JobID:          cfg.JobID,
+		Environment:    metadata.Instance.Environment,
+		ClusterID:      state.Cluster.ID,
+		ClusterName:    state.Cluster.Name,
+		ClusterVersion: state.Cluster.Version,
+		UpgradeVersion: state.Upgrade.ReleaseName,
+		ReportDir:      cfg.ReportDir,
+	}
+}
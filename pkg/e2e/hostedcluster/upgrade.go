@@ -0,0 +1,218 @@
+// Package hostedcluster contains suites for clusters with hosted control planes.
+package hostedcluster
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/upgrade"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+const (
+	// UpgradeReportFile is the name of the report of upgrade timings written by the hosted cluster upgrade suite.
+	UpgradeReportFile = "hcp-upgrade-report.yaml"
+
+	pollInterval = 30 * time.Second
+)
+
+// UpgradeReport records how the control plane and node pools of a hosted cluster were upgraded.
+type UpgradeReport struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+
+	// SkewRejected is whether upgrading a node pool past the control plane was rejected.
+	SkewRejected bool `yaml:"skewRejected"`
+
+	// ControlPlane is how long the control plane took to upgrade.
+	ControlPlane time.Duration `yaml:"controlPlane"`
+
+	// NodePools are how long each node pool took to upgrade.
+	NodePools map[string]time.Duration `yaml:"nodePools"`
+}
+
+var _ = ginkgo.Describe("[Suite: hcp-upgrade] Hosted cluster upgrade", func() {
+	h := helper.New()
+
+	ginkgo.It("should upgrade the control plane and node pools independently", func() {
+		cfg := config.Instance.Upgrade
+		clusterID := state.Instance.Cluster.ID
+		if clusterID == "" {
+			ginkgo.Skip("hosted cluster upgrades require a cluster ID")
+		}
+		if cfg.HostedVersion == "" {
+			ginkgo.Skip("no hosted cluster upgrade version, set HCP_UPGRADE_VERSION to run this suite")
+		}
+
+		provider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		hcpProvider, ok := provider.(spi.HostedClusterProvider)
+		if !ok {
+			ginkgo.Skip("the cluster provider does not support hosted clusters")
+		}
+
+		hosted, err := hcpProvider.HostedControlPlane(clusterID)
+		Expect(err).NotTo(HaveOccurred())
+		if !hosted {
+			ginkgo.Skip("the cluster does not have a hosted control plane")
+		}
+
+		target := strings.TrimPrefix(cfg.HostedVersion, util.VersionPrefix)
+		timeout := time.Duration(cfg.HostedTimeout) * time.Minute
+
+		cluster, err := provider.GetCluster(clusterID)
+		Expect(err).NotTo(HaveOccurred(), "couldn't get cluster")
+		from := strings.TrimPrefix(cluster.Version(), util.VersionPrefix)
+
+		nodePools, err := hcpProvider.NodePools(clusterID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePools).NotTo(BeEmpty(), "the cluster has no node pools")
+
+		report := &UpgradeReport{From: from, To: target, NodePools: map[string]time.Duration{}}
+		defer writeReport(h, report)
+
+		// node pools can't be newer than the control plane
+		newer, err := newerThan(target, from)
+		Expect(err).NotTo(HaveOccurred())
+		if newer {
+			err = hcpProvider.UpgradeNodePool(clusterID, nodePools[0].ID, target)
+			Expect(err).To(HaveOccurred(), "node pool %s was allowed to be upgraded past the control plane", nodePools[0].ID)
+			report.SkewRejected = true
+		}
+
+		Expect(upgrade.HandleVersionGates(provider, clusterID, target)).To(Succeed())
+		Expect(hcpProvider.UpgradeControlPlane(clusterID, target)).To(Succeed())
+		started := time.Now()
+		err = phase.Poll(pollInterval, timeout, func() (bool, error) {
+			return controlPlaneUpgraded(h, provider, clusterID, target), nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "the control plane wasn't upgraded to %s", target)
+		report.ControlPlane = time.Since(started)
+		log.Printf("Control plane upgraded to %s after %v", target, report.ControlPlane)
+
+		// the node pools are left behind until they're upgraded themselves, and the cluster must cope with the skew
+		Expect(nodePoolVersions(hcpProvider, clusterID)).To(Equal(versionsOf(nodePools)), "node pools were upgraded along with the control plane")
+		Expect(clusterHealthy(h)).To(BeTrue(), "the cluster isn't healthy while its node pools are behind the control plane")
+
+		for i, nodePool := range nodePools {
+			Expect(hcpProvider.UpgradeNodePool(clusterID, nodePool.ID, target)).To(Succeed())
+			started := time.Now()
+			err = phase.Poll(pollInterval, timeout, func() (bool, error) {
+				return nodePoolUpgraded(hcpProvider, clusterID, nodePool.ID, target), nil
+			})
+			Expect(err).NotTo(HaveOccurred(), "node pool %s wasn't upgraded to %s", nodePool.ID, target)
+			report.NodePools[nodePool.ID] = time.Since(started)
+			log.Printf("Node pool %s upgraded to %s after %v", nodePool.ID, target, report.NodePools[nodePool.ID])
+
+			versions := nodePoolVersions(hcpProvider, clusterID)
+			for _, remaining := range nodePools[i+1:] {
+				Expect(versions[remaining.ID]).To(Equal(remaining.Version), "node pool %s was upgraded along with %s", remaining.ID, nodePool.ID)
+			}
+		}
+
+		err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+			return clusterHealthy(h), nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "the cluster wasn't healthy after the upgrade")
+	})
+})
+
+// controlPlaneUpgraded returns true once both the provider and the cluster's ClusterVersion report the control plane at version.
+func controlPlaneUpgraded(h *helper.H, provider spi.Provider, clusterID, version string) bool {
+	cluster, err := provider.GetCluster(clusterID)
+	if err != nil {
+		logging.Errorf("Error getting cluster '%s': %v", clusterID, err)
+		return false
+	}
+	if strings.TrimPrefix(cluster.Version(), util.VersionPrefix) != version {
+		return false
+	}
+
+	done, msg, err := upgrade.IsUpgradeDone(h, &configv1.Update{Version: version})
+	if err != nil || !done {
+		log.Printf("Waiting for the control plane upgrade: %s", msg)
+		return false
+	}
+	return true
+}
+
+// nodePoolUpgraded returns true once a node pool is at version with all of its replicas.
+func nodePoolUpgraded(provider spi.HostedClusterProvider, clusterID, nodePoolID, version string) bool {
+	nodePools, err := provider.NodePools(clusterID)
+	if err != nil {
+		logging.Errorf("Error listing node pools: %v", err)
+		return false
+	}
+
+	for _, nodePool := range nodePools {
+		if nodePool.ID == nodePoolID {
+			return nodePool.Version == version && nodePool.CurrentReplicas == nodePool.Replicas
+		}
+	}
+	return false
+}
+
+func nodePoolVersions(provider spi.HostedClusterProvider, clusterID string) map[string]string {
+	nodePools, err := provider.NodePools(clusterID)
+	Expect(err).NotTo(HaveOccurred())
+	return versionsOf(nodePools)
+}
+
+func versionsOf(nodePools []spi.NodePool) map[string]string {
+	versions := map[string]string{}
+	for _, nodePool := range nodePools {
+		versions[nodePool.ID] = nodePool.Version
+	}
+	return versions
+}
+
+// newerThan returns whether version a is newer than version b.
+func newerThan(a, b string) (bool, error) {
+	aVersion, err := semver.NewVersion(a)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %s: %v", a, err)
+	}
+
+	bVersion, err := semver.NewVersion(b)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %s: %v", b, err)
+	}
+	return aVersion.GreaterThan(bVersion), nil
+}
+
+// clusterHealthy returns true if the nodes and operators of the cluster are healthy.
+func clusterHealthy(h *helper.H) bool {
+	if healthy, err := healthchecks.CheckNodeHealth(h.Kube().CoreV1()); !healthy || err != nil {
+		return false
+	}
+
+	healthy, err := healthchecks.CheckOperatorReadiness(h.Cfg().ConfigV1())
+	return healthy && err == nil
+}
+
+func writeReport(h *helper.H, report *UpgradeReport) {
+	data, err := yaml.Marshal(report)
+	if err != nil {
+		logging.Warnf("Unable to encode hosted cluster upgrade report: %v", err)
+		return
+	}
+	h.WriteResults(map[string][]byte{UpgradeReportFile: data})
+}
@@ -0,0 +1,49 @@
+package e2e
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+)
+
+// leakedObjectsFile is the name of the report of objects left behind by the run written to the report dir.
+const leakedObjectsFile = "leaked-objects.yaml"
+
+// writeLeakedObjects finds the objects the run's specs created that will be left on the cluster once the run's project
+// is deleted, reports them with the specs that created them, and records them in the metadata.
+func writeLeakedObjects(h *helper.H) error {
+	objects, err := h.RunObjects()
+	if err != nil {
+		return err
+	}
+
+	leaks := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		leaks = append(leaks, obj.String())
+	}
+	metadata.Instance.SetLeakedObjects(leaks)
+
+	if len(objects) == 0 {
+		log.Print("The run didn't leave any objects behind.")
+		return nil
+	}
+
+	logging.Warnf("The run left %d objects behind:", len(objects))
+	for _, leak := range leaks {
+		logging.Warnf("  %s", leak)
+	}
+
+	data, err := yaml.Marshal(objects)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(config.Instance.ReportDir, leakedObjectsFile), data, os.FileMode(0644))
+}
@@ -0,0 +1,58 @@
+package e2e
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/envlock"
+	"github.com/openshift/osde2e/pkg/common/logging"
+)
+
+// lockEnvironment takes the lock for an environment if runs against it must be serialized. The returned
+// function releases the lock. Losing the lock during the run aborts it.
+func lockEnvironment(env string) (func(), error) {
+	cfg := config.Instance.EnvironmentLock
+	if cfg.URL == "" || !environmentIsLocked(env, cfg.Environments) {
+		return func() {}, nil
+	}
+
+	if config.Instance.DryRun {
+		log.Printf("This is a dry run. Skipping locking environment %s.", env)
+		return func() {}, nil
+	}
+
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+
+	job := config.Instance.JobName
+	if config.Instance.JobID != -1 {
+		job = fmt.Sprintf("%s/%d", job, config.Instance.JobID)
+	}
+
+	lock := envlock.New(envlock.S3Store{URL: cfg.URL}, env, holder, job, time.Duration(cfg.TTL)*time.Minute)
+	if err := lock.Acquire(time.Duration(cfg.Timeout) * time.Minute); err != nil {
+		return nil, err
+	}
+
+	lock.Hold(func(err error) {
+		abortRun(fmt.Errorf("lost the lock on environment %s: %v", env, err))
+	})
+
+	return func() {
+		if err := lock.Release(); err != nil {
+			logging.Warnf("Unable to release the lock on environment %s: %v", env, err)
+		}
+	}, nil
+}
+
+func environmentIsLocked(env string, environments []string) bool {
+	for _, locked := range environments {
+		if locked == env {
+			return true
+		}
+	}
+	return false
+}
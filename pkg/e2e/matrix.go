@@ -0,0 +1,253 @@
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/matrix"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+)
+
+// matrixResultsFile is where the schedule and results of a matrix are written in its report directory.
+const matrixResultsFile = "matrix-results.yaml"
+
+// MatrixOptions are how a matrix is run.
+type MatrixOptions struct {
+	// Configs are the built in configs every entry is run with, before its own.
+	Configs []string
+
+	// CustomConfig and CustomConfigFormat are the custom config every entry is run with.
+	CustomConfig       string
+	CustomConfigFormat string
+
+	// ReportDir is where the results of the matrix are written. Each entry reports to a directory named after it.
+	ReportDir string
+
+	// Parallel is how many entries run at once. All of them run at once if it isn't positive.
+	Parallel int
+}
+
+// MatrixResults are the schedule of a matrix and how each of its entries ran.
+type MatrixResults struct {
+	// Quota is how many more clusters of each kind each environment could create before the matrix ran.
+	Quota map[string]map[string]int `yaml:"quota,omitempty"`
+
+	Schedule *matrix.Schedule `yaml:"schedule"`
+
+	// Entries are the results of the scheduled entries, in the matrix's order.
+	Entries []MatrixEntryResult `yaml:"entries,omitempty"`
+}
+
+// MatrixEntryResult is how an entry of a matrix ran.
+type MatrixEntryResult struct {
+	Entry       string `yaml:"entry"`
+	Environment string `yaml:"environment"`
+	ReportDir   string `yaml:"reportDir"`
+	Passed      bool   `yaml:"passed"`
+	Error       string `yaml:"error,omitempty"`
+}
+
+// Passed is whether every entry was scheduled and passed.
+func (r *MatrixResults) Passed() bool {
+	if len(r.Schedule.Unscheduled) > 0 {
+		return false
+	}
+	for _, entry := range r.Entries {
+		if !entry.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// QueryMatrixQuota queries how many more clusters of each kind the matrix uses each of its environments can create.
+// Environments whose provider can't report quota are left out, and are treated as unlimited when scheduling.
+// Environments whose quota can't be queried have none.
+func QueryMatrixQuota(m *matrix.Matrix) map[string]matrix.Quota {
+	quotas := map[string]matrix.Quota{}
+	for _, env := range m.Environments {
+		token := os.Getenv(env.Token())
+		if token == "" {
+			token = config.Instance.OCM.Token
+		}
+
+		provider, err := providers.ClusterProviderForEnvironment(env.Env, token)
+		if err != nil {
+			logging.Warnf("Unable to connect to environment %s, no clusters will be scheduled in it: %v", env.Name, err)
+			quotas[env.Name] = noQuota(m)
+			continue
+		}
+
+		quotaProvider, ok := provider.(spi.QuotaProvider)
+		if !ok {
+			logging.Warnf("Provider of environment %s can't report quota, scheduling as if it were unlimited.", env.Name)
+			continue
+		}
+
+		quotas[env.Name] = matrix.Quota{}
+		for _, key := range m.Keys() {
+			remaining, err := quotaProvider.RemainingQuota(key.CloudProvider, key.MultiAZ)
+			if err != nil {
+				logging.Warnf("Unable to get the %s quota (multiAZ=%t) of environment %s: %v", key.CloudProvider, key.MultiAZ, env.Name, err)
+			}
+			quotas[env.Name][key] = remaining
+		}
+	}
+	return quotas
+}
+
+// noQuota is quota that fits none of the matrix's entries.
+func noQuota(m *matrix.Matrix) matrix.Quota {
+	quota := matrix.Quota{}
+	for _, key := range m.Keys() {
+		quota[key] = 0
+	}
+	return quota
+}
+
+// PlanMatrix queries the quota of the matrix's environments and schedules its entries within it.
+func PlanMatrix(m *matrix.Matrix) *MatrixResults {
+	quotas := QueryMatrixQuota(m)
+	results := &MatrixResults{Schedule: m.Plan(quotas)}
+	for env, quota := range quotas {
+		if results.Quota == nil {
+			results.Quota = map[string]map[string]int{}
+		}
+		results.Quota[env] = map[string]int{}
+		for key, remaining := range quota {
+			results.Quota[env][quotaKeyName(key)] = remaining
+		}
+	}
+	return results
+}
+
+// quotaKeyName is how a kind of quota is shown in matrix results.
+func quotaKeyName(key matrix.QuotaKey) string {
+	if key.MultiAZ {
+		return key.CloudProvider + "/multi-az"
+	}
+	return key.CloudProvider + "/single-az"
+}
+
+// RunMatrix runs each scheduled entry of the matrix as its own osde2e test run and writes the results to the
+// matrix's report directory.
+func RunMatrix(m *matrix.Matrix, results *MatrixResults, opts MatrixOptions) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to find the osde2e executable to run the matrix: %v", err)
+	}
+	if err = os.MkdirAll(opts.ReportDir, os.FileMode(0755)); err != nil {
+		return fmt.Errorf("unable to create the matrix report directory: %v", err)
+	}
+
+	for _, name := range results.Schedule.Unscheduled {
+		log.Printf("Not running %s, no environment has quota left for its cluster.", name)
+	}
+
+	parallel := opts.Parallel
+	if parallel <= 0 || parallel > len(results.Schedule.Assignments) {
+		parallel = len(results.Schedule.Assignments)
+	}
+	slots := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	var outputMutex sync.Mutex
+	results.Entries = make([]MatrixEntryResult, len(results.Schedule.Assignments))
+	for i, assignment := range results.Schedule.Assignments {
+		entry, env := m.Entry(assignment.Entry), m.Environment(assignment.Environment)
+		result := &results.Entries[i]
+		*result = MatrixEntryResult{
+			Entry:       entry.Name,
+			Environment: env.Name,
+			ReportDir:   filepath.Join(opts.ReportDir, entry.Name),
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			prefix := fmt.Sprintf("[%s] ", entry.Name)
+			if logging.Format() == logging.JSONFormat {
+				prefix = ""
+			}
+			output := &nodeOutput{prefix: prefix, out: os.Stdout, mutex: &outputMutex}
+			defer output.flush()
+
+			log.Printf("Running %s in environment %s.", entry.Name, env.Name)
+			cmd := exec.Command(executable, matrixEntryArgs(entry, opts)...)
+			cmd.Env = append(os.Environ(), matrixEntryEnv(entry, env, result.ReportDir)...)
+			cmd.Stdout, cmd.Stderr = output, output
+			if err := cmd.Run(); err != nil {
+				logging.Errorf("%s failed: %v", entry.Name, err)
+				result.Error = err.Error()
+				return
+			}
+			result.Passed = true
+		}()
+	}
+	wg.Wait()
+
+	data, err := yaml.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("error marshaling matrix results: %v", err)
+	}
+	file := filepath.Join(opts.ReportDir, matrixResultsFile)
+	if err = ioutil.WriteFile(file, data, os.FileMode(0644)); err != nil {
+		return fmt.Errorf("error writing matrix results: %v", err)
+	}
+	log.Printf("Wrote matrix results to %s.", file)
+	return nil
+}
+
+// matrixEntryArgs are the arguments of the osde2e test run of an entry.
+func matrixEntryArgs(entry *matrix.Entry, opts MatrixOptions) []string {
+	args := []string{"-update=false", "test"}
+	if configs := append(append([]string{}, opts.Configs...), entry.Configs...); len(configs) > 0 {
+		args = append(args, "-configs", strings.Join(configs, ","))
+	}
+	if opts.CustomConfig != "" {
+		args = append(args, "-custom-config", opts.CustomConfig)
+	}
+	if opts.CustomConfigFormat != "" {
+		args = append(args, "-config-format", opts.CustomConfigFormat)
+	}
+	return args
+}
+
+// matrixEntryEnv are the environment variables that point the osde2e test run of an entry at its environment,
+// cluster, and report directory. They override the configs the entry is run with.
+func matrixEntryEnv(entry *matrix.Entry, env *matrix.Environment, reportDir string) []string {
+	vars := []string{
+		"OSD_ENV=" + env.Env,
+		"CLOUD_PROVIDER_ID=" + entry.Cloud(),
+		"MULTI_AZ=" + strconv.FormatBool(entry.MultiAZ),
+		"REPORT_DIR=" + reportDir,
+	}
+	if token := os.Getenv(env.Token()); token != "" {
+		vars = append(vars, "OCM_TOKEN="+token)
+	}
+	if entry.Version != "" {
+		vars = append(vars, "CLUSTER_VERSION="+entry.Version)
+	}
+	if entry.Region != "" {
+		vars = append(vars, "CLOUD_PROVIDER_REGION="+entry.Region)
+	}
+	for key, value := range entry.Env {
+		vars = append(vars, key+"="+value)
+	}
+	return vars
+}
@@ -0,0 +1,59 @@
+package e2e
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/matrix"
+)
+
+func TestMatrixEntryRun(t *testing.T) {
+	os.Setenv("STAGE_B_TOKEN", "token-b")
+	defer os.Unsetenv("STAGE_B_TOKEN")
+
+	entry := &matrix.Entry{
+		Name:    "4.6-us-west-2",
+		Version: "openshift-v4.6.1",
+		Region:  "us-west-2",
+		Configs: []string{"e2e-suite"},
+		Env:     map[string]string{"CLUSTER_EXPIRY_IN_MINUTES": "240"},
+	}
+	env := &matrix.Environment{Name: "stage-b", Env: "stage", TokenEnv: "STAGE_B_TOKEN"}
+
+	args := matrixEntryArgs(entry, MatrixOptions{Configs: []string{"stage"}, CustomConfig: "custom.yaml"})
+	expectedArgs := []string{"-update=false", "test", "-configs", "stage,e2e-suite", "-custom-config", "custom.yaml"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("expected arguments %v, got %v", expectedArgs, args)
+	}
+
+	vars := matrixEntryEnv(entry, env, "/tmp/report/4.6-us-west-2")
+	expectedVars := []string{
+		"OSD_ENV=stage",
+		"CLOUD_PROVIDER_ID=aws",
+		"MULTI_AZ=false",
+		"REPORT_DIR=/tmp/report/4.6-us-west-2",
+		"OCM_TOKEN=token-b",
+		"CLUSTER_VERSION=openshift-v4.6.1",
+		"CLOUD_PROVIDER_REGION=us-west-2",
+		"CLUSTER_EXPIRY_IN_MINUTES=240",
+	}
+	if !reflect.DeepEqual(vars, expectedVars) {
+		t.Errorf("expected environment %v, got %v", expectedVars, vars)
+	}
+}
+
+func TestMatrixResultsPassed(t *testing.T) {
+	results := &MatrixResults{
+		Schedule: &matrix.Schedule{Assignments: []matrix.Assignment{{Entry: "a", Environment: "stage"}}},
+		Entries:  []MatrixEntryResult{{Entry: "a", Environment: "stage", Passed: true}},
+	}
+	if !results.Passed() {
+		t.Errorf("expected the matrix to pass")
+	}
+
+	results.Schedule.Unscheduled = []string{"b"}
+	if results.Passed() {
+		t.Errorf("expected the matrix to fail with an unscheduled entry")
+	}
+}
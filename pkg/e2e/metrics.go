@@ -6,7 +6,6 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -16,6 +15,8 @@ import (
 	"github.com/onsi/ginkgo/reporters"
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/events"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/manifest"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	"github.com/openshift/osde2e/pkg/common/providers"
 	"github.com/openshift/osde2e/pkg/common/spi"
@@ -33,6 +34,7 @@ const (
 	metadataMetricName string = cicdPrefix + "metadata"
 	addonMetricName    string = cicdPrefix + "addon_metadata"
 	eventMetricName    string = cicdPrefix + "event"
+	failureMetricName  string = cicdPrefix + "failure_classification"
 )
 
 var junitFileRegex, logFileRegex *regexp.Regexp
@@ -44,9 +46,13 @@ type Metrics struct {
 	metadataGatherer *prometheus.GaugeVec
 	addonGatherer    *prometheus.GaugeVec
 	eventGatherer    *prometheus.CounterVec
+	failureGatherer  *prometheus.GaugeVec
 
 	// Provider for getting metrics data
 	provider spi.Provider
+
+	// scenario is the fingerprint of the cluster shape and suites, so results can be compared across jobs.
+	scenario string
 }
 
 // NewMetrics creates a new metrics object using the given config object.
@@ -57,45 +63,59 @@ func NewMetrics() *Metrics {
 		prometheus.GaugeOpts{
 			Name: jUnitMetricName,
 		},
-		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "phase", "suite", "testname", "result", "cluster_id", "job_id"},
+		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "phase", "suite", "testname", "result", "cluster_id", "job_id", "scenario"},
 	)
 	metadataGatherer := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: metadataMetricName,
 		},
-		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "metadata_name", "cluster_id", "job_id"},
+		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "metadata_name", "cluster_id", "job_id", "scenario"},
 	)
 	addonGatherer := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: addonMetricName,
 		},
-		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "metadata_name", "cluster_id", "job_id", "phase"},
+		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "metadata_name", "cluster_id", "job_id", "phase", "scenario"},
 	)
 	eventGatherer := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: eventMetricName,
 		},
-		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "event", "cluster_id", "job_id"},
+		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "event", "cluster_id", "job_id", "scenario"},
+	)
+	failureGatherer := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: failureMetricName,
+		},
+		[]string{"install_version", "upgrade_version", "cloud_provider", "environment", "phase", "category", "rule", "cluster_id", "job_id", "scenario"},
 	)
 	metricRegistry.MustRegister(jUnitGatherer)
 	metricRegistry.MustRegister(metadataGatherer)
 	metricRegistry.MustRegister(addonGatherer)
 	metricRegistry.MustRegister(eventGatherer)
+	metricRegistry.MustRegister(failureGatherer)
 
 	provider, err := providers.ClusterProvider()
 
 	if err != nil {
-		log.Printf("unable to get provider for metrics, failing: %v", err)
+		logging.Errorf("unable to get provider for metrics, failing: %v", err)
 		return nil
 	}
 
+	scenario, err := manifest.ScenarioFingerprint()
+	if err != nil {
+		logging.Warnf("unable to fingerprint scenario for metrics: %v", err)
+	}
+
 	return &Metrics{
 		metricRegistry:   metricRegistry,
 		jUnitGatherer:    jUnitGatherer,
 		metadataGatherer: metadataGatherer,
 		addonGatherer:    addonGatherer,
 		eventGatherer:    eventGatherer,
+		failureGatherer:  failureGatherer,
 		provider:         provider,
+		scenario:         scenario,
 	}
 }
 
@@ -140,6 +160,7 @@ func (m *Metrics) WritePrometheusFile(reportDir string) (string, error) {
 	}
 
 	m.processEvents(m.eventGatherer)
+	m.processFailureClassification(m.failureGatherer)
 
 	prometheusFileName := fmt.Sprintf(prometheusFileNamePattern, state.Instance.Cluster.ID, config.Instance.JobName)
 	output, err := m.registryToExpositionFormat()
@@ -160,8 +181,8 @@ func (m *Metrics) WritePrometheusFile(reportDir string) (string, error) {
 
 // processJUnitXMLFile will add results to the prometheusOutput that look like:
 //
-// cicd_jUnitResult {environment="prod", install_version="install-version", result="passed|failed|skipped", phase="currentphase", suite="suitename",
-//                   testname="testname", upgrade_version="upgrade-version"} testLength
+// cicd_jUnitResult {environment="prod", install_version="install-version", result="passed|failed|skipped|flaky", phase="currentphase", suite="suitename",
+//                   testname="testname", upgrade_version="upgrade-version", scenario="fingerprint"} testLength
 func (m *Metrics) processJUnitXMLFile(phase string, junitFile string) (err error) {
 	state := state.Instance
 
@@ -183,6 +204,8 @@ func (m *Metrics) processJUnitXMLFile(phase string, junitFile string) (err error
 			result = "failed"
 		} else if testcase.Skipped != nil {
 			result = "skipped"
+		} else if isFlaky(testcase) {
+			result = "flaky"
 		} else {
 			result = "passed"
 		}
@@ -196,7 +219,8 @@ func (m *Metrics) processJUnitXMLFile(phase string, junitFile string) (err error
 			testcase.Name,
 			result,
 			state.Cluster.ID,
-			strconv.Itoa(config.Instance.JobID)).Add(testcase.Time)
+			strconv.Itoa(config.Instance.JobID),
+			m.scenario).Add(testcase.Time)
 	}
 
 	return nil
@@ -208,7 +232,7 @@ func (m *Metrics) processJUnitXMLFile(phase string, junitFile string) (err error
 //
 // cicd_[addon_]metadata{environment="prod", install_version="install-version",
 //                       metadata_name="full.path.to.field.separated.by.periiod",
-//                       upgrade_version="upgrade-version"[, phase="install"]} userAssignedValue
+//                       upgrade_version="upgrade-version"[, phase="install"], scenario="fingerprint"} userAssignedValue
 //
 // Notes: Only numerical values or strings that look like numerical values will be captured. This is because
 //        Prometheus can only have numerical metric values and capturing strings through the use of labels is
@@ -260,7 +284,8 @@ func (m *Metrics) jsonToPrometheusOutput(gatherer *prometheus.GaugeVec, phase st
 						metadataName,
 						state.Cluster.ID,
 						strconv.Itoa(cfg.JobID),
-						phase).Add(floatValue)
+						phase,
+						m.scenario).Add(floatValue)
 				} else {
 					gatherer.WithLabelValues(state.Cluster.Version,
 						state.Upgrade.ReleaseName,
@@ -268,7 +293,8 @@ func (m *Metrics) jsonToPrometheusOutput(gatherer *prometheus.GaugeVec, phase st
 						m.provider.Environment(),
 						metadataName,
 						state.Cluster.ID,
-						strconv.Itoa(cfg.JobID)).Add(floatValue)
+						strconv.Itoa(cfg.JobID),
+						m.scenario).Add(floatValue)
 				}
 			}
 		}
@@ -290,10 +316,36 @@ func (m *Metrics) processEvents(gatherer *prometheus.CounterVec) {
 			m.provider.Environment(),
 			event,
 			state.Cluster.ID,
-			strconv.Itoa(config.Instance.JobID)).Inc()
+			strconv.Itoa(config.Instance.JobID),
+			m.scenario).Inc()
 	}
 }
 
+// processFailureClassification outputs the category of the run's install or upgrade failure, if it failed:
+//
+// cicd_failure_classification{environment="prod", install_version="install-version", phase="install",
+//                             category="cloud-capacity", rule="cloud-capacity", upgrade_version="upgrade-version"} 1
+func (m *Metrics) processFailureClassification(gatherer *prometheus.GaugeVec) {
+	state := state.Instance
+
+	classification := metadata.Instance.FailureClassification
+	if classification == nil {
+		return
+	}
+
+	gatherer.WithLabelValues(
+		state.Cluster.Version,
+		state.Upgrade.ReleaseName,
+		state.CloudProvider.CloudProviderID,
+		m.provider.Environment(),
+		classification.Phase,
+		classification.Category,
+		classification.Rule,
+		state.Cluster.ID,
+		strconv.Itoa(config.Instance.JobID),
+		m.scenario).Set(1)
+}
+
 // Generic Prometheus export file building functions
 
 // registryToExpositionFormat takes all of the gathered metrics and writes them out in the exposition format
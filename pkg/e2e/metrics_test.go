@@ -9,9 +9,11 @@ import (
 	"testing"
 
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/manifest"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	"github.com/openshift/osde2e/pkg/common/providers"
 	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/triage"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -46,11 +48,15 @@ func TestProcessJUnitXMLFile(t *testing.T) {
 			blah
 		</skipped>
 	</testcase>
+	<testcase name="test 5" time="5">
+		<passed>Flaky: passed on retry 1 after failing: timed out</passed>
+	</testcase>
 </testsuite>`,
-			expectedOutput: `cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",suite="test suite",testname="test 1",upgrade_version="upgrade-version"} 1
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",suite="test suite",testname="test 2",upgrade_version="upgrade-version"} 2
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="failed",suite="test suite",testname="test 3",upgrade_version="upgrade-version"} 3
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="skipped",suite="test suite",testname="test 4",upgrade_version="upgrade-version"} 4
+			expectedOutput: `cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",scenario="fingerprint",suite="test suite",testname="test 1",upgrade_version="upgrade-version"} 1
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",scenario="fingerprint",suite="test suite",testname="test 2",upgrade_version="upgrade-version"} 2
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="failed",scenario="fingerprint",suite="test suite",testname="test 3",upgrade_version="upgrade-version"} 3
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="skipped",scenario="fingerprint",suite="test suite",testname="test 4",upgrade_version="upgrade-version"} 4
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="flaky",scenario="fingerprint",suite="test suite",testname="test 5",upgrade_version="upgrade-version"} 5
 `,
 		},
 		{
@@ -66,9 +72,9 @@ newline" time="3">
 		</failure>
 	</testcase>
 </testsuite>`,
-			expectedOutput: `cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",suite="test \"suite\"",testname="test \\1",upgrade_version="upgrade-version"} 1
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",suite="test \"suite\"",testname="test 2",upgrade_version="upgrade-version"} 2
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="failed",suite="test \"suite\"",testname="test 3\nnewline",upgrade_version="upgrade-version"} 3
+			expectedOutput: `cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",scenario="fingerprint",suite="test \"suite\"",testname="test \\1",upgrade_version="upgrade-version"} 1
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",scenario="fingerprint",suite="test \"suite\"",testname="test 2",upgrade_version="upgrade-version"} 2
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="failed",scenario="fingerprint",suite="test \"suite\"",testname="test 3\nnewline",upgrade_version="upgrade-version"} 3
 `,
 		},
 	}
@@ -87,6 +93,7 @@ cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",ins
 		if m == nil {
 			t.Error("error creating new metrics provider")
 		}
+		m.scenario = "fingerprint"
 		tmpFile, err := ioutil.TempFile(tmpDir, "*")
 
 		if err != nil {
@@ -145,8 +152,8 @@ func TestProcessJSONFile(t *testing.T) {
 		}
 	}
 }`,
-			expectedOutput: `cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",upgrade_version="upgrade-version"} 6
-cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="another-nested field.another-level.test4",upgrade_version="upgrade-version"} 7
+			expectedOutput: `cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",scenario="fingerprint",upgrade_version="upgrade-version"} 6
+cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="another-nested field.another-level.test4",scenario="fingerprint",upgrade_version="upgrade-version"} 7
 `,
 			phase: "",
 		},
@@ -167,8 +174,8 @@ cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",instal
 		}
 	}
 }`,
-			expectedOutput: `cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",phase="install",upgrade_version="upgrade-version"} 6
-cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="another-nested field.another-level.test4",phase="install",upgrade_version="upgrade-version"} 7
+			expectedOutput: `cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",phase="install",scenario="fingerprint",upgrade_version="upgrade-version"} 6
+cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="another-nested field.another-level.test4",phase="install",scenario="fingerprint",upgrade_version="upgrade-version"} 7
 `,
 			phase: "install",
 		},
@@ -187,6 +194,7 @@ cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",
 		if m == nil {
 			t.Error("error creating new metrics provider")
 		}
+		m.scenario = "fingerprint"
 		tmpFile, err := ioutil.TempFile(tmpDir, "*")
 
 		if err != nil {
@@ -263,12 +271,12 @@ func TestWritePrometheusFile(t *testing.T) {
 }`
 	addonMetadataFileContents := metadataFileContents
 
-	jUnitExpectedOutput := `cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",suite="test suite 1",testname="test 1",upgrade_version="upgrade-version"} 1
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",suite="test suite 1",testname="test 2",upgrade_version="upgrade-version"} 2
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="failed",suite="test suite 1",testname="test 3",upgrade_version="upgrade-version"} 3
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="upgrade",result="passed",suite="test suite 2",testname="test 1",upgrade_version="upgrade-version"} 1
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="upgrade",result="passed",suite="test suite 2",testname="test 2",upgrade_version="upgrade-version"} 2
-cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="upgrade",result="failed",suite="test suite 2",testname="test 3",upgrade_version="upgrade-version"} 3
+	jUnitExpectedOutput := `cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",scenario="fingerprint",suite="test suite 1",testname="test 1",upgrade_version="upgrade-version"} 1
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="passed",scenario="fingerprint",suite="test suite 1",testname="test 2",upgrade_version="upgrade-version"} 2
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",result="failed",scenario="fingerprint",suite="test suite 1",testname="test 3",upgrade_version="upgrade-version"} 3
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="upgrade",result="passed",scenario="fingerprint",suite="test suite 2",testname="test 1",upgrade_version="upgrade-version"} 1
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="upgrade",result="passed",scenario="fingerprint",suite="test suite 2",testname="test 2",upgrade_version="upgrade-version"} 2
+cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="upgrade",result="failed",scenario="fingerprint",suite="test suite 2",testname="test 3",upgrade_version="upgrade-version"} 3
 `
 
 	tests := []struct {
@@ -292,8 +300,8 @@ cicd_jUnitResult{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",ins
 			},
 			metadataFileContents:      metadataFileContents,
 			addonMetadataFileContents: addonMetadataFileContents,
-			expectedOutput: jUnitExpectedOutput + `cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",upgrade_version="upgrade-version"} 6
-cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",phase="install",upgrade_version="upgrade-version"} 6
+			expectedOutput: jUnitExpectedOutput + `cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",scenario="fingerprint",upgrade_version="upgrade-version"} 6
+cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",phase="install",scenario="fingerprint",upgrade_version="upgrade-version"} 6
 `,
 		},
 		{
@@ -310,7 +318,7 @@ cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",
 			},
 			metadataFileContents:      metadataFileContents,
 			addonMetadataFileContents: "",
-			expectedOutput: jUnitExpectedOutput + `cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",upgrade_version="upgrade-version"} 6
+			expectedOutput: jUnitExpectedOutput + `cicd_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",metadata_name="test2",scenario="fingerprint",upgrade_version="upgrade-version"} 6
 `,
 		},
 		{
@@ -336,6 +344,7 @@ cicd_addon_metadata{cloud_provider="aws",cluster_id="1a2b3c",environment="prod",
 		if m == nil {
 			t.Error("error creating new metrics provider")
 		}
+		m.scenario = "fingerprint"
 		tmpDir, err := ioutil.TempDir("", "")
 
 		if err != nil {
@@ -442,3 +451,36 @@ func arraysHaveSameElements(array1 []string, array2 []string) error {
 
 	return nil
 }
+
+func TestProcessFailureClassification(t *testing.T) {
+	state.Instance.CloudProvider.CloudProviderID = "aws"
+	state.Instance.Cluster.ID = "1a2b3c"
+	state.Instance.Cluster.Version = "install-version"
+	state.Instance.Upgrade.ReleaseName = "upgrade-version"
+	config.Instance.Provider = providers.Mock
+	config.Instance.OCM.Env = "prod"
+	config.Instance.JobID = 123
+
+	defer func() { metadata.Instance.FailureClassification = nil }()
+	metadata.Instance.FailureClassification = &triage.Classification{Phase: "install", Category: triage.CloudCapacity, Rule: "cloud-capacity"}
+
+	m := NewMetrics()
+	if m == nil {
+		t.Fatal("error creating new metrics provider")
+	}
+	if scenario, err := manifest.ScenarioFingerprint(); err != nil || m.scenario != scenario {
+		t.Errorf("expected metrics to be tagged with scenario %s, got %s: %v", scenario, m.scenario, err)
+	}
+	m.scenario = "fingerprint"
+	m.processFailureClassification(m.failureGatherer)
+
+	output, err := m.registryToExpositionFormat()
+	if err != nil {
+		t.Fatalf("error convering registry to exposition format: %v", err)
+	}
+
+	expected := `cicd_failure_classification{category="cloud-capacity",cloud_provider="aws",cluster_id="1a2b3c",environment="prod",install_version="install-version",job_id="123",phase="install",rule="cloud-capacity",scenario="fingerprint",upgrade_version="upgrade-version"} 1`
+	if !strings.Contains(string(output), expected+"\n") {
+		t.Errorf("expected output to contain:\n%s\ngot:\n%s", expected, output)
+	}
+}
@@ -0,0 +1,6 @@
+package multicluster
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: multicluster]", "networking")
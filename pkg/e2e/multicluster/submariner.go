@@ -0,0 +1,343 @@
+// Package multicluster contains suites for multi-cluster offerings, which connect the cluster under test to a peer.
+package multicluster
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/addoncheck"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/runner"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/stats"
+	"github.com/openshift/osde2e/pkg/common/templates"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+const (
+	// ReportFile is the name of the report of the latency between the clusters.
+	ReportFile = "multicluster-report.yaml"
+
+	// submarinerNamespace is where Submariner runs.
+	submarinerNamespace = "submariner-operator"
+
+	echoApp   = "osde2e-echo"
+	echoImage = "openshift/hello-openshift"
+	echoPort  = 8080
+
+	pollInterval = 30 * time.Second
+
+	// latencyAttempts and latencyInterval bound how long the first request waits for the exported service to be reachable.
+	latencyAttempts = 30
+	latencyInterval = 10
+)
+
+var (
+	gatewayResource       = schema.GroupVersionResource{Group: "submariner.io", Version: "v1", Resource: "gateways"}
+	serviceExportResource = schema.GroupVersionResource{Group: "multicluster.x-k8s.io", Version: "v1alpha1", Resource: "serviceexports"}
+
+	// cmd to make requests to the exported service from the cluster under test
+	latencyCmdTpl *template.Template
+)
+
+func init() {
+	var err error
+
+	latencyCmdTpl, err = templates.LoadTemplate("/assets/multicluster/latency.template")
+
+	if err != nil {
+		panic(fmt.Sprintf("error while loading multi-cluster latency command: %v", err))
+	}
+}
+
+// LatencyReport describes the requests made from the cluster under test to a service exported by its peer.
+type LatencyReport struct {
+	PeerClusterID string `yaml:"peerClusterID"`
+	Requests      int    `yaml:"requests"`
+	Failures      int    `yaml:"failures"`
+
+	// Latencies are in milliseconds.
+	Mean float64 `yaml:"meanMs"`
+	P50  float64 `yaml:"p50Ms"`
+	P95  float64 `yaml:"p95Ms"`
+	Max  float64 `yaml:"maxMs"`
+}
+
+var _ = ginkgo.Describe("[Suite: multicluster] Submariner", func() {
+	h := helper.New()
+
+	multiclusterTimeoutInSeconds := 14400
+	ginkgo.It("should connect services across clusters", func() {
+		cfg := config.Instance.Multicluster
+		clusterID := state.Instance.Cluster.ID
+		if clusterID == "" {
+			ginkgo.Skip("multi-cluster tests require a cluster ID")
+		}
+
+		provider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		timeout := time.Duration(cfg.Timeout) * time.Minute
+		peerID := cfg.PeerClusterID
+		if peerID == "" {
+			mcProvider, ok := provider.(spi.MultiClusterProvider)
+			if !ok {
+				ginkgo.Skip("the cluster provider can't create peer clusters, set MULTICLUSTER_PEER_CLUSTER_ID to use an existing one")
+			}
+
+			peerID, err = mcProvider.LaunchPeerCluster(state.Instance.Cluster.Name+"-peer", cfg.PeerClusterSpec)
+			Expect(err).NotTo(HaveOccurred(), "couldn't create peer cluster")
+			log.Printf("Created peer cluster '%s'", peerID)
+			defer deletePeer(provider, peerID)
+		}
+
+		Expect(waitForCluster(provider, peerID, timeout)).To(Succeed(), "peer cluster '%s' wasn't ready", peerID)
+		peerKube, peerDynamic := peerClients(provider, peerID)
+
+		// the addon joins both clusters to the cluster set
+		for _, id := range []string{clusterID, peerID} {
+			_, err = provider.InstallAddons(id, []string{cfg.Addon})
+			Expect(err).NotTo(HaveOccurred(), "couldn't install %s on cluster '%s'", cfg.Addon, id)
+		}
+		readinessDir := config.Instance.Addons.ReadinessDir
+		Expect(addoncheck.Wait(h.Dynamic(), []string{cfg.Addon}, readinessDir, timeout)).To(Succeed(), "%s wasn't ready on the cluster under test", cfg.Addon)
+		Expect(addoncheck.Wait(peerDynamic, []string{cfg.Addon}, readinessDir, timeout)).To(Succeed(), "%s wasn't ready on the peer", cfg.Addon)
+		Expect(waitForGatewayConnection(h.Dynamic(), timeout)).To(Succeed(), "the cluster under test wasn't connected to its peer")
+		Expect(waitForGatewayConnection(peerDynamic, timeout)).To(Succeed(), "the peer wasn't connected to the cluster under test")
+
+		namespace, err := exportEchoService(peerKube, peerDynamic)
+		Expect(err).NotTo(HaveOccurred(), "couldn't export a service from the peer")
+		defer peerKube.CoreV1().Namespaces().Delete(namespace, &metav1.DeleteOptions{})
+
+		url := fmt.Sprintf("http://%s.%s.svc.clusterset.local:%d/", echoApp, namespace, echoPort)
+		latencies, failures := measureLatency(h, url, cfg.Requests, multiclusterTimeoutInSeconds)
+
+		report := summarizeLatency(latencies, failures)
+		report.PeerClusterID = peerID
+		data, err := yaml.Marshal(report)
+		Expect(err).NotTo(HaveOccurred())
+		h.WriteResults(map[string][]byte{ReportFile: data})
+		log.Printf("Inter-cluster latency: p50 %.1fms, p95 %.1fms, %d of %d requests failed", report.P50, report.P95, report.Failures, report.Requests)
+
+		Expect(latencies).NotTo(BeEmpty(), "the service exported by the peer was never reachable")
+		Expect(failures).To(BeZero(), "requests to the service exported by the peer failed")
+	})
+})
+
+// deletePeer deletes a peer cluster created by the suite.
+func deletePeer(provider spi.Provider, peerID string) {
+	log.Printf("Deleting peer cluster '%s'...", peerID)
+	if err := provider.DeleteCluster(peerID); err != nil {
+		logging.Warnf("Unable to delete peer cluster '%s': %v", peerID, err)
+	}
+}
+
+// waitForCluster waits for the provider to report a cluster ready.
+func waitForCluster(provider spi.Provider, clusterID string, timeout time.Duration) error {
+	return wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		cluster, err := provider.GetCluster(clusterID)
+		if err != nil {
+			logging.Warnf("Error getting cluster '%s': %v", clusterID, err)
+			return false, nil
+		}
+		if cluster.State() == spi.ClusterStateError {
+			return false, fmt.Errorf("the installation of cluster '%s' has errored", clusterID)
+		}
+		return cluster.State() == spi.ClusterStateReady, nil
+	})
+}
+
+// peerClients returns clients for the peer cluster.
+func peerClients(provider spi.Provider, peerID string) (kubernetes.Interface, dynamic.Interface) {
+	kubeconfig, err := provider.ClusterKubeconfig(peerID)
+	Expect(err).NotTo(HaveOccurred(), "couldn't get the kubeconfig of the peer")
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	Expect(err).NotTo(HaveOccurred(), "couldn't parse the kubeconfig of the peer")
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	Expect(err).NotTo(HaveOccurred(), "couldn't create a client for the peer")
+
+	dyn, err := dynamic.NewForConfig(restConfig)
+	Expect(err).NotTo(HaveOccurred(), "couldn't create a client for the peer")
+	return kube, dyn
+}
+
+// waitForGatewayConnection waits for the active Submariner gateway of a cluster to be connected to another cluster.
+func waitForGatewayConnection(client dynamic.Interface, timeout time.Duration) error {
+	return wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		gateways, err := client.Resource(gatewayResource).Namespace(submarinerNamespace).List(metav1.ListOptions{})
+		if err != nil {
+			log.Printf("Waiting for Submariner gateways: %v", err)
+			return false, nil
+		}
+
+		for _, gateway := range gateways.Items {
+			if haStatus, _, _ := unstructured.NestedString(gateway.Object, "status", "haStatus"); haStatus != "active" {
+				continue
+			}
+
+			connections, _, _ := unstructured.NestedSlice(gateway.Object, "status", "connections")
+			for _, connection := range connections {
+				if status, _, _ := unstructured.NestedString(connection.(map[string]interface{}), "status"); status == "connected" {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+}
+
+// exportEchoService runs an echo server on the peer in a new namespace and exports its service to the cluster set.
+func exportEchoService(kube kubernetes.Interface, dyn dynamic.Interface) (string, error) {
+	ns, err := kube.CoreV1().Namespaces().Create(&kubev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "osde2e-multicluster-" + util.RandomStr(5)},
+	})
+	if err != nil {
+		return "", err
+	}
+	namespace := ns.Name
+
+	replicas := int32(1)
+	labels := map[string]string{"app": echoApp}
+	if _, err = kube.AppsV1().Deployments(namespace).Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: echoApp},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: kubev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: kubev1.PodSpec{
+					Containers: []kubev1.Container{
+						{
+							Name:  echoApp,
+							Image: echoImage,
+							Ports: []kubev1.ContainerPort{{Name: "http", ContainerPort: echoPort}},
+						},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return namespace, err
+	}
+
+	if _, err = kube.CoreV1().Services(namespace).Create(&kubev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: echoApp, Labels: labels},
+		Spec: kubev1.ServiceSpec{
+			Selector: labels,
+			Ports: []kubev1.ServicePort{
+				{Name: "http", Port: echoPort, TargetPort: intstr.FromString("http")},
+			},
+		},
+	}); err != nil {
+		return namespace, err
+	}
+
+	_, err = dyn.Resource(serviceExportResource).Namespace(namespace).Create(&unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "multicluster.x-k8s.io/v1alpha1",
+		"kind":       "ServiceExport",
+		"metadata":   map[string]interface{}{"name": echoApp},
+	}}, metav1.CreateOptions{})
+	return namespace, err
+}
+
+// measureLatency makes requests to a URL from inside the cluster under test, once the URL is reachable.
+func measureLatency(h *helper.H, url string, requests, timeoutInSeconds int) ([]float64, int) {
+	h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
+	r := h.RunnerWithNoCommand()
+
+	cmd, err := h.ConvertTemplateToString(latencyCmdTpl, struct {
+		OutputDir string
+		URL       string
+		Requests  int
+		Attempts  int
+		Interval  int
+	}{
+		OutputDir: runner.DefaultRunner.OutputDir,
+		URL:       url,
+		Requests:  requests,
+		Attempts:  latencyAttempts,
+		Interval:  latencyInterval,
+	})
+	Expect(err).NotTo(HaveOccurred(), "failure creating templated command")
+
+	r.Name = "multicluster-latency"
+	r.Cmd = cmd
+
+	stopCh := make(chan struct{})
+	err = r.Run(timeoutInSeconds, stopCh)
+	Expect(err).NotTo(HaveOccurred(), "failure running command on pod")
+
+	results, err := r.RetrieveResults()
+	Expect(err).NotTo(HaveOccurred(), "failure retrieving results from pod")
+
+	return parseLatencies(results["latency.txt"])
+}
+
+// parseLatencies reads the latencies, in milliseconds, of successful requests from curl's output of
+// `<status code> <seconds>` lines and counts the requests that failed.
+func parseLatencies(data []byte) (latencies []float64, failures int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		seconds, err := strconv.ParseFloat(fields[1], 64)
+		if fields[0] != "200" || err != nil {
+			failures++
+			continue
+		}
+		latencies = append(latencies, seconds*1000)
+	}
+	return latencies, failures
+}
+
+// summarizeLatency describes the distribution of latencies.
+func summarizeLatency(latencies []float64, failures int) *LatencyReport {
+	report := &LatencyReport{Requests: len(latencies) + failures, Failures: failures}
+	if len(latencies) == 0 {
+		return report
+	}
+
+	sorted := append([]float64{}, latencies...)
+	sort.Float64s(sorted)
+
+	total := 0.0
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	report.Mean = total / float64(len(sorted))
+	report.P50 = stats.Percentile(sorted, 50)
+	report.P95 = stats.Percentile(sorted, 95)
+	report.Max = sorted[len(sorted)-1]
+	return report
+}
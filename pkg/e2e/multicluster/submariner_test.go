@@ -0,0 +1,34 @@
+package multicluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLatencies(t *testing.T) {
+	latencies, failures := parseLatencies([]byte("200 0.012\n200 0.004\n000 5.001\n503 0.002\n\n200 0.008\n"))
+
+	if expected := []float64{12, 4, 8}; !reflect.DeepEqual(latencies, expected) {
+		t.Errorf("expected latencies %v, got %v", expected, latencies)
+	}
+	if failures != 2 {
+		t.Errorf("expected 2 failures, got %d", failures)
+	}
+}
+
+func TestSummarizeLatency(t *testing.T) {
+	var latencies []float64
+	for i := 20; i >= 1; i-- {
+		latencies = append(latencies, float64(i))
+	}
+
+	report := summarizeLatency(latencies, 1)
+	expected := &LatencyReport{Requests: 21, Failures: 1, Mean: 10.5, P50: 10, P95: 19, Max: 20}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("expected %+v, got %+v", expected, report)
+	}
+
+	if empty := summarizeLatency(nil, 3); empty.Requests != 3 || empty.P50 != 0 {
+		t.Errorf("unexpected summary of failed requests %+v", empty)
+	}
+}
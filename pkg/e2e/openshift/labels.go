@@ -0,0 +1,10 @@
+package openshift
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: app-builds]", "builds")
+var _ = testlabels.Declare("[Suite: conformance]", "long-running")
+var _ = testlabels.Declare("[Suite: openshift][disruptive]", "disruptive")
+var _ = testlabels.Declare("[Suite: openshift][image-registry]", "images")
+var _ = testlabels.Declare("[Suite: openshift][image-ecosystem]", "images", "builds")
@@ -0,0 +1,10 @@
+package operators
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: operators] [OSD] Certman Operator", "security")
+var _ = testlabels.Declare("[Suite: operators] [OSD] RBAC Operator", "security")
+var _ = testlabels.Declare("[Suite: operators] [OSD] Dedicated Admins SubjectPermission", "security")
+var _ = testlabels.Declare("[Suite: informing] [OSD] Upgrade", "operators", "upgrade")
+var _ = testlabels.Declare("[Suite: informing] [OSD] validating webhook", "operators", "security")
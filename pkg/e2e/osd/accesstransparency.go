@@ -0,0 +1,76 @@
+package osd
+
+import (
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// accessRequestStates are the documented states of an access request.
+var accessRequestStates = []string{
+	spi.AccessRequestPending,
+	spi.AccessRequestApproved,
+	spi.AccessRequestDenied,
+	spi.AccessRequestExpired,
+}
+
+var _ = ginkgo.Describe("[Suite: service-definition] [OSD] Access transparency", func() {
+	// accessTransparency returns the provider's access transparency API, skipping the spec where it isn't available.
+	accessTransparency := func() spi.AccessTransparencyProvider {
+		if state.Instance.Cluster.ID == "" {
+			ginkgo.Skip("access transparency requires a cluster ID")
+		}
+
+		provider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		atProvider, ok := provider.(spi.AccessTransparencyProvider)
+		if !ok {
+			ginkgo.Skip("the cluster provider does not support access transparency")
+		}
+		return atProvider
+	}
+
+	ginkgo.It("should report whether access protection is enabled", func() {
+		_, err := accessTransparency().AccessProtection(state.Instance.Cluster.ID)
+		if err == spi.ErrAccessTransparencyUnsupported {
+			ginkgo.Skip(err.Error())
+		}
+		Expect(err).NotTo(HaveOccurred(), "failure getting access protection")
+	})
+
+	ginkgo.It("should only list access requests for the cluster", func() {
+		clusterID := state.Instance.Cluster.ID
+		requests, err := accessTransparency().AccessRequests(clusterID)
+		if err == spi.ErrAccessTransparencyUnsupported {
+			ginkgo.Skip(err.Error())
+		}
+		Expect(err).NotTo(HaveOccurred(), "failure listing access requests")
+
+		for _, request := range requests {
+			Expect(request.ClusterID).To(Equal(clusterID), "access request %s is for another cluster", request.ID)
+			Expect(accessRequestStates).To(ContainElement(request.State), "access request %s has an undocumented state", request.ID)
+		}
+	})
+
+	ginkgo.It("should record who made an access request, why, and when it must be decided by", func() {
+		requests, err := accessTransparency().AccessRequests(state.Instance.Cluster.ID)
+		if err == spi.ErrAccessTransparencyUnsupported {
+			ginkgo.Skip(err.Error())
+		}
+		Expect(err).NotTo(HaveOccurred(), "failure listing access requests")
+
+		if len(requests) == 0 {
+			ginkgo.Skip("no access requests have been made for the cluster")
+		}
+
+		for _, request := range requests {
+			Expect(request.RequestedBy).NotTo(BeEmpty(), "access request %s has no requester", request.ID)
+			Expect(request.Justification).NotTo(BeEmpty(), "access request %s has no justification", request.ID)
+			Expect(request.DeadlineAt.After(request.CreatedAt)).To(BeTrue(), "access request %s must be decided on after it was made", request.ID)
+		}
+	})
+})
@@ -0,0 +1,296 @@
+package osd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	routev1 "github.com/openshift/api/route/v1"
+	kubev1 "k8s.io/api/core/v1"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+// customDomainResource is the custom domains operator's resource for serving apps on a customer's domain.
+var customDomainResource = schema.GroupVersionResource{Group: "managed.openshift.io", Version: "v1alpha1", Resource: "customdomains"}
+
+// customDomainReady is the state of a custom domain whose ingress controller is serving it.
+const customDomainReady = "Ready"
+
+// This suite creates an ingress controller and its load balancer, so it is opt-in.
+var _ = ginkgo.Describe("[Suite: custom-domain] [OSD] Custom domains", func() {
+	h := helper.New()
+
+	ginkgo.It("should serve routes on a custom domain with the customer's certificate and renew it", func() {
+		cfg := config.Instance.CustomDomain
+
+		_, err := h.Dynamic().Resource(customDomainResource).List(metav1.ListOptions{Limit: 1})
+		if kerror.IsNotFound(err) {
+			ginkgo.Skip("the custom domains operator isn't installed")
+		}
+		Expect(err).NotTo(HaveOccurred(), "failure listing custom domains")
+
+		name := "osde2e-" + util.RandomStr(5)
+		domain := cfg.Domain
+		if domain == "" {
+			domain = fmt.Sprintf("apps.%s.example.com", name)
+		}
+		namespace := h.CurrentProject()
+
+		ca, err := newCertificateAuthority()
+		Expect(err).NotTo(HaveOccurred(), "failure creating a certificate authority")
+		cert, err := ca.issue("*." + domain)
+		Expect(err).NotTo(HaveOccurred(), "failure issuing a certificate for %s", domain)
+
+		_, err = h.Kube().CoreV1().Secrets(namespace).Create(cert.secret(name))
+		Expect(err).NotTo(HaveOccurred(), "failure creating the certificate secret")
+
+		_, err = h.Dynamic().Resource(customDomainResource).Create(customDomain(name, domain, namespace), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred(), "failure creating the custom domain")
+		defer func() {
+			if err := h.Dynamic().Resource(customDomainResource).Delete(name, &metav1.DeleteOptions{}); err != nil {
+				logging.Warnf("Unable to delete custom domain '%s': %v", name, err)
+			}
+		}()
+
+		_, err = h.Kube().AppsV1().Deployments(namespace).Create(exampleAppDeployment(name))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the sample app")
+		_, err = h.Kube().CoreV1().Services(namespace).Create(exampleAppService(name))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the sample app service")
+
+		host := fmt.Sprintf("%s.%s", name, domain)
+		_, err = h.Route().RouteV1().Routes(namespace).Create(customDomainRoute(name, host))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the sample app route")
+
+		timeout := time.Duration(cfg.Timeout) * time.Minute
+		var endpoint string
+		err = wait.PollImmediate(15*time.Second, timeout, func() (bool, error) {
+			var state string
+			if state, endpoint, err = customDomainStatus(h, name); err != nil {
+				logging.Warnf("Unable to get the status of custom domain '%s': %v", name, err)
+				return false, nil
+			}
+			return state == customDomainReady && endpoint != "", nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "custom domain %s never became ready", domain)
+
+		// the route is served once the ingress controller's load balancer is reachable
+		err = wait.PollImmediate(15*time.Second, timeout, func() (bool, error) {
+			served, err := requestCustomDomain(endpoint, host, ca.pool)
+			if err != nil {
+				logging.Warnf("Unable to reach %s through %s yet: %v", host, endpoint, err)
+				return false, nil
+			}
+			return served.SerialNumber.Cmp(cert.serial) == 0, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "%s wasn't served with the customer's certificate through %s", host, endpoint)
+
+		// certificates are renewed by replacing the secret, as customers are told to
+		renewed, err := ca.issue("*." + domain)
+		Expect(err).NotTo(HaveOccurred(), "failure issuing a renewed certificate for %s", domain)
+		err = h.Kube().CoreV1().Secrets(namespace).Delete(name, &metav1.DeleteOptions{})
+		Expect(err).NotTo(HaveOccurred(), "failure deleting the expiring certificate secret")
+		_, err = h.Kube().CoreV1().Secrets(namespace).Create(renewed.secret(name))
+		Expect(err).NotTo(HaveOccurred(), "failure creating the renewed certificate secret")
+
+		err = wait.PollImmediate(15*time.Second, timeout, func() (bool, error) {
+			served, err := requestCustomDomain(endpoint, host, ca.pool)
+			if err != nil {
+				logging.Warnf("Unable to reach %s through %s after renewing its certificate: %v", host, endpoint, err)
+				return false, nil
+			}
+			return served.SerialNumber.Cmp(renewed.serial) == 0, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "%s wasn't served with the renewed certificate", host)
+	})
+})
+
+// customDomain serves apps on a domain with the certificate in a secret.
+func customDomain(name, domain, namespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "managed.openshift.io/v1alpha1",
+		"kind":       "CustomDomain",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"domain": domain,
+			"certificate": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}}
+}
+
+// customDomainStatus returns the state of a custom domain and the endpoint customers point its DNS records at.
+func customDomainStatus(h *helper.H, name string) (state, endpoint string, err error) {
+	obj, err := h.Dynamic().Resource(customDomainResource).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	state, _, _ = unstructured.NestedString(obj.Object, "status", "state")
+	endpoint, _, _ = unstructured.NestedString(obj.Object, "status", "endpoint")
+	return state, endpoint, nil
+}
+
+// customDomainRoute exposes the sample app on a host of the custom domain with the ingress controller's certificate.
+func customDomainRoute(name, host string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: routev1.RouteSpec{
+			Host: host,
+			To:   routev1.RouteTargetReference{Kind: "Service", Name: name},
+			Port: &routev1.RoutePort{TargetPort: intstr.FromString("web")},
+			TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+		},
+	}
+}
+
+// requestCustomDomain requests a host of the custom domain through its endpoint, so that its DNS doesn't need to be
+// set up, and returns the certificate it was served with. The certificate must be trusted by the pool.
+func requestCustomDomain(endpoint, host string, pool *x509.CertPool) (*x509.Certificate, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	client := &http.Client{
+		Timeout: time.Minute,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{ServerName: host, RootCAs: pool},
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, net.JoinHostPort(endpoint, "443"))
+			},
+		},
+	}
+
+	resp, err := client.Get("https://" + host)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp.TLS.PeerCertificates[0], nil
+}
+
+// certificateAuthority issues certificates in place of the customer's certificate authority.
+type certificateAuthority struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+	pool *x509.CertPool
+}
+
+// customerCertificate is a certificate issued for a custom domain.
+type customerCertificate struct {
+	serial  *big.Int
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newCertificateAuthority creates a self-signed certificate authority.
+func newCertificateAuthority() (*certificateAuthority, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "osde2e custom domain CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &certificateAuthority{cert: cert, key: key, pool: pool}, nil
+}
+
+// issue issues a serving certificate for a host, which may be a wildcard.
+func (ca *certificateAuthority) issue(host string) (*customerCertificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customerCertificate{
+		serial:  serial,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	}, nil
+}
+
+// secret is the TLS secret a customer creates for the certificate.
+func (c *customerCertificate) secret(name string) *kubev1.Secret {
+	return &kubev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Type:       kubev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			kubev1.TLSCertKey:       c.certPEM,
+			kubev1.TLSPrivateKeyKey: c.keyPEM,
+		},
+	}
+}
+
+// randomSerial returns a random certificate serial number.
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
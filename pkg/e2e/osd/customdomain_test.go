@@ -0,0 +1,43 @@
+package osd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+)
+
+func TestCustomerCertificate(t *testing.T) {
+	ca, err := newCertificateAuthority()
+	if err != nil {
+		t.Fatalf("failed to create certificate authority: %v", err)
+	}
+
+	issued, err := ca.issue("*.apps.example.com")
+	if err != nil {
+		t.Fatalf("failed to issue certificate: %v", err)
+	}
+	renewed, err := ca.issue("*.apps.example.com")
+	if err != nil {
+		t.Fatalf("failed to issue renewed certificate: %v", err)
+	}
+	if issued.serial.Cmp(renewed.serial) == 0 {
+		t.Errorf("expected the renewed certificate to have a new serial number")
+	}
+
+	secret := issued.secret("custom-domain")
+	pair, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+	if err != nil {
+		t.Fatalf("expected the secret to hold a valid key pair: %v", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = cert.Verify(x509.VerifyOptions{DNSName: "app.apps.example.com", Roots: ca.pool}); err != nil {
+		t.Errorf("expected hosts of the domain to be trusted: %v", err)
+	}
+	if _, err = cert.Verify(x509.VerifyOptions{DNSName: "app.example.com", Roots: ca.pool}); err == nil {
+		t.Errorf("expected hosts outside of the domain not to be trusted")
+	}
+}
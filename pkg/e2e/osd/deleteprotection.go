@@ -0,0 +1,77 @@
+package osd
+
+import (
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// This suite tries to delete the cluster under test, so it is opt-in. If delete protection doesn't work, the cluster
+// is deleted and the rest of the run fails. Once the cluster is unprotected, it is deleted if the run would delete it
+// anyway, so nothing else can be tested on it afterwards.
+var _ = ginkgo.Describe("[Suite: delete-protection] [OSD] Delete protection", func() {
+	ginkgo.It("should refuse to delete protected clusters until they are unprotected", func() {
+		clusterID := state.Instance.Cluster.ID
+		if clusterID == "" {
+			ginkgo.Skip("delete protection requires a cluster ID")
+		}
+
+		provider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		dpProvider, ok := provider.(spi.DeleteProtectionProvider)
+		if !ok {
+			ginkgo.Skip("the cluster provider does not support delete protection")
+		}
+
+		Expect(dpProvider.SetDeleteProtection(clusterID, true)).To(Succeed())
+		protected := true
+		defer func() {
+			// never leave the cluster protected, it couldn't be deleted at the end of the run
+			if protected {
+				if err := dpProvider.SetDeleteProtection(clusterID, false); err != nil {
+					logging.Warnf("Unable to remove delete protection of cluster '%s': %v", clusterID, err)
+				}
+			}
+		}()
+
+		enabled, err := dpProvider.DeleteProtected(clusterID)
+		Expect(err).NotTo(HaveOccurred(), "failure getting delete protection")
+		Expect(enabled).To(BeTrue(), "delete protection wasn't enabled")
+
+		Expect(provider.DeleteCluster(clusterID)).NotTo(Succeed(), "a protected cluster was deleted")
+
+		cluster, err := provider.GetCluster(clusterID)
+		Expect(err).NotTo(HaveOccurred(), "failure getting cluster after refused deletion")
+		Expect(cluster.State()).NotTo(Equal(spi.ClusterStateUninstalling), "a protected cluster is uninstalling")
+
+		Expect(dpProvider.SetDeleteProtection(clusterID, false)).To(Succeed())
+		protected = false
+
+		enabled, err = dpProvider.DeleteProtected(clusterID)
+		Expect(err).NotTo(HaveOccurred(), "failure getting delete protection")
+		Expect(enabled).To(BeFalse(), "delete protection wasn't removed")
+
+		// the cluster is only deleted when the run is about to delete it, so the upgrade phase still has a cluster
+		upgrading := state.Instance.Phase == phase.InstallPhase && state.Instance.Upgrade.ReleaseName != ""
+		if !config.Instance.Cluster.DestroyAfterTest || upgrading {
+			logging.Infof("Not deleting unprotected cluster '%s' as the run keeps using it.", clusterID)
+			return
+		}
+
+		Expect(provider.DeleteCluster(clusterID)).To(Succeed(), "an unprotected cluster couldn't be deleted")
+
+		cluster, err = provider.GetCluster(clusterID)
+		Expect(err).NotTo(HaveOccurred(), "failure getting cluster after deletion")
+		Expect(cluster.State()).To(Equal(spi.ClusterStateUninstalling), "an unprotected cluster isn't uninstalling")
+
+		// the run waits for this deletion instead of deleting the cluster again
+		state.Instance.Cluster.State = spi.ClusterStateUninstalling
+	})
+})
@@ -0,0 +1,212 @@
+package osd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+// challengingClient is the OAuth client that exchanges credentials for a token without a browser, like oc login.
+const challengingClient = "openshift-challenging-client"
+
+// This suite changes how users log in to the cluster under test, so it is opt-in.
+var _ = ginkgo.Describe("[Suite: identity-federation] [OSD] External OIDC identity provider", func() {
+	h := helper.New()
+
+	ginkgo.It("should log in users and map their group claims to RBAC", func() {
+		cfg := config.Instance.IdentityFederation
+		clusterID := state.Instance.Cluster.ID
+		if clusterID == "" {
+			ginkgo.Skip("identity federation requires a cluster ID")
+		}
+		if cfg.Issuer == "" {
+			ginkgo.Skip("no OIDC issuer is configured")
+		}
+
+		provider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		idpProvider, ok := provider.(spi.IdentityProviderProvider)
+		if !ok {
+			ginkgo.Skip("the cluster provider does not support identity providers")
+		}
+
+		name := "osde2e-oidc-" + util.RandomStr(5)
+		idpID, err := idpProvider.AddOpenIDIdentityProvider(clusterID, spi.OpenIDIdentityProvider{
+			Name:         name,
+			Issuer:       cfg.Issuer,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			GroupsClaim:  cfg.GroupsClaim,
+			ExtraScopes:  cfg.ExtraScopes,
+		})
+		Expect(err).NotTo(HaveOccurred(), "failure adding the identity provider")
+		defer func() {
+			if err := idpProvider.DeleteIdentityProvider(clusterID, idpID); err != nil {
+				logging.Warnf("Unable to delete identity provider '%s' of cluster '%s': %v", idpID, clusterID, err)
+			}
+		}()
+
+		authorizeURL, err := authorizationEndpoint(h)
+		Expect(err).NotTo(HaveOccurred(), "failure discovering the OAuth server")
+
+		// the OAuth server is redeployed with the identity provider, which takes a while
+		var token string
+		err = wait.PollImmediate(30*time.Second, time.Duration(cfg.Timeout)*time.Minute, func() (bool, error) {
+			if token, err = requestToken(oauthClient(), authorizeURL, name, cfg.Username, cfg.Password); err != nil {
+				logging.Warnf("Unable to log in as %s through identity provider %s yet: %v", cfg.Username, name, err)
+				return false, nil
+			}
+			return true, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "the OAuth server didn't accept logins through the identity provider")
+
+		user := h.AsToken(token)
+		me, err := user.User().UserV1().Users().Get("~", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "failure getting the logged in user")
+		defer func() {
+			// users and identities outlive the identity provider, so they're removed to keep reruns clean
+			for _, identity := range me.Identities {
+				if err := h.User().UserV1().Identities().Delete(identity, &metav1.DeleteOptions{}); err != nil {
+					logging.Warnf("Unable to delete identity '%s': %v", identity, err)
+				}
+			}
+			if err := h.User().UserV1().Users().Delete(me.Name, &metav1.DeleteOptions{}); err != nil {
+				logging.Warnf("Unable to delete user '%s': %v", me.Name, err)
+			}
+		}()
+		Expect(me.Identities).To(ContainElement(HavePrefix(name+":")), "the user wasn't mapped from the identity provider")
+
+		if cfg.Group == "" {
+			return
+		}
+
+		group, err := h.User().UserV1().Groups().Get(cfg.Group, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "group %s wasn't synced from the %s claim", cfg.Group, cfg.GroupsClaim)
+		Expect(group.Users).To(ContainElement(me.Name), "user %s wasn't added to group %s", me.Name, cfg.Group)
+
+		project := h.CurrentProject()
+		_, err = user.Kube().CoreV1().Pods(project).List(metav1.ListOptions{})
+		Expect(kerror.IsForbidden(err)).To(BeTrue(), "expected the user to be forbidden before the group is bound, got %v", err)
+
+		_, err = h.Kube().RbacV1().RoleBindings(project).Create(&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: cfg.Group}},
+		})
+		Expect(err).NotTo(HaveOccurred(), "failure binding the group to a role")
+
+		// RBAC changes take a moment to be picked up by every API server
+		err = wait.PollImmediate(5*time.Second, time.Minute, func() (bool, error) {
+			_, err := user.Kube().CoreV1().Pods(project).List(metav1.ListOptions{})
+			return err == nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "the group's role binding didn't give the user access")
+
+		_, err = user.Kube().CoreV1().Pods("openshift-config").List(metav1.ListOptions{})
+		Expect(kerror.IsForbidden(err)).To(BeTrue(), "expected the user to be forbidden outside of the bound project, got %v", err)
+	})
+})
+
+// authorizationEndpoint discovers the authorization endpoint of the cluster's OAuth server.
+func authorizationEndpoint(h *helper.H) (string, error) {
+	data, err := h.Kube().CoreV1().RESTClient().Get().AbsPath("/.well-known/oauth-authorization-server").DoRaw()
+	if err != nil {
+		return "", err
+	}
+
+	var metadata struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+	}
+	if err = json.Unmarshal(data, &metadata); err != nil {
+		return "", fmt.Errorf("error parsing OAuth server metadata: %v", err)
+	}
+	if metadata.AuthorizationEndpoint == "" {
+		return "", fmt.Errorf("the OAuth server metadata has no authorization endpoint")
+	}
+	return metadata.AuthorizationEndpoint, nil
+}
+
+// oauthClient returns a client that doesn't follow redirects, since the token is in the redirect's location.
+func oauthClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			// the OAuth route is served with the cluster's ingress certificate, which test clusters don't have signed
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// requestToken exchanges a user's credentials for an OpenShift token through an identity provider, the way oc login
+// does. The identity provider checks the credentials with the external issuer.
+func requestToken(client *http.Client, authorizeURL, idp, username, password string) (string, error) {
+	u, err := url.Parse(authorizeURL)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = url.Values{
+		"client_id":     {challengingClient},
+		"response_type": {"token"},
+		"idp":           {idp},
+	}.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(username, password)
+	// the OAuth server only answers challenges to requests that can't have come from a browser
+	req.Header.Set("X-CSRF-Token", "1")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		return "", fmt.Errorf("expected a redirect with a token, got %s", resp.Status)
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return "", err
+	}
+
+	fragment, err := url.ParseQuery(location.Fragment)
+	if err != nil {
+		return "", fmt.Errorf("error parsing token redirect: %v", err)
+	}
+	if errCode := fragment.Get("error"); errCode != "" {
+		return "", fmt.Errorf("%s: %s", errCode, fragment.Get("error_description"))
+	}
+
+	token := fragment.Get("access_token")
+	if token == "" {
+		return "", fmt.Errorf("the token redirect has no access token")
+	}
+	return token, nil
+}
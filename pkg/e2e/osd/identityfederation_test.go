@@ -0,0 +1,47 @@
+package osd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("client_id") != challengingClient || query.Get("idp") != "external" || r.Header.Get("X-CSRF-Token") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		username, password, ok := r.BasicAuth()
+		switch {
+		case !ok:
+			w.WriteHeader(http.StatusUnauthorized)
+		case username == "alice" && password == "secret":
+			w.Header().Set("Location", "https://oauth.example.com/oauth/token/implicit#access_token=sha256~abc&token_type=Bearer")
+			w.WriteHeader(http.StatusFound)
+		default:
+			w.Header().Set("Location", "https://oauth.example.com/oauth/token/implicit#error=access_denied&error_description=bad+credentials")
+			w.WriteHeader(http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	client := server.Client()
+	client.CheckRedirect = oauthClient().CheckRedirect
+
+	token, err := requestToken(client, server.URL+"/oauth/authorize", "external", "alice", "secret")
+	if err != nil || token != "sha256~abc" {
+		t.Errorf("expected token sha256~abc, got %q: %v", token, err)
+	}
+
+	if _, err = requestToken(client, server.URL+"/oauth/authorize", "external", "alice", "wrong"); err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("expected access to be denied, got %v", err)
+	}
+
+	if _, err = requestToken(client, server.URL+"/oauth/authorize", "other", "alice", "secret"); err == nil {
+		t.Errorf("expected an unknown identity provider to fail")
+	}
+}
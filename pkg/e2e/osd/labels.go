@@ -0,0 +1,15 @@
+package osd
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: service-definition] [OSD] Access transparency", "security")
+var _ = testlabels.Declare("[Suite: service-definition] [OSD] DaemonSets", "workloads")
+var _ = testlabels.Declare("[Suite: service-definition] [OSD] Privileged Containers", "security")
+var _ = testlabels.Declare("[Suite: delete-protection]", "security")
+var _ = testlabels.Declare("[Suite: identity-federation]", "security")
+var _ = testlabels.Declare("[Suite: informing] [OSD] NodeLabels", "nodes")
+var _ = testlabels.Declare("[Suite: informing] [OSD] Node resource reservations", "nodes")
+var _ = testlabels.Declare("[Suite: informing] [OSD] Node OS content", "nodes", "security")
+var _ = testlabels.Declare("[Suite: e2e] [OSD] Node clocks", "nodes")
+var _ = testlabels.Declare("[Suite: informing] [OSD] User workload monitoring", "monitoring")
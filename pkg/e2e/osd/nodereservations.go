@@ -0,0 +1,231 @@
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/markbates/pkger"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+)
+
+// defaultNodeReservations are the maintained reservations for each node role.
+const defaultNodeReservations = "/assets/osd/node-reservations.yaml"
+
+// instanceTypeLabels hold the instance type of a node, newest first.
+var instanceTypeLabels = []string{"node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type"}
+
+// Reservations are the resources the kubelet reserves for the system and for Kubernetes daemons.
+type Reservations struct {
+	SystemReserved map[string]string `yaml:"systemReserved" json:"systemReserved"`
+	KubeReserved   map[string]string `yaml:"kubeReserved" json:"kubeReserved"`
+}
+
+// RoleReservations are the expected reservations for nodes with a role.
+type RoleReservations struct {
+	Default       Reservations            `yaml:"default"`
+	InstanceTypes map[string]Reservations `yaml:"instanceTypes"`
+}
+
+// kubeletConfig is the part of the kubelet's configz response that is checked.
+type kubeletConfig struct {
+	Reservations
+	EvictionHard map[string]string `json:"evictionHard"`
+}
+
+var _ = ginkgo.Describe("[Suite: informing] [OSD] Node resource reservations", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should match the managed configuration for each node", func() {
+		expectations, err := loadNodeReservations(config.Instance.Tests.NodeReservations)
+		Expect(err).NotTo(HaveOccurred(), "failure loading node reservations")
+
+		nodes, err := h.Kube().CoreV1().Nodes().List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list nodes")
+		Expect(nodes.Items).NotTo(BeEmpty())
+
+		var problems []string
+		for _, node := range nodes.Items {
+			role := nodeRole(node)
+			roleExpectations, ok := expectations[role]
+			if !ok {
+				continue
+			}
+
+			kubelet, err := getKubeletConfig(h, node.Name)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", node.Name, err))
+				continue
+			}
+
+			expected := roleExpectations.forInstanceType(nodeInstanceType(node))
+			for _, problem := range checkNodeReservations(node, kubelet, expected) {
+				problems = append(problems, fmt.Sprintf("%s (%s): %s", node.Name, role, problem))
+			}
+		}
+		Expect(problems).To(BeEmpty(), "node reservations don't match the managed configuration")
+	})
+})
+
+// forInstanceType returns the reservations expected for an instance type.
+func (r RoleReservations) forInstanceType(instanceType string) Reservations {
+	if reservations, ok := r.InstanceTypes[instanceType]; ok {
+		return reservations
+	}
+	return r.Default
+}
+
+func loadNodeReservations(file string) (map[string]RoleReservations, error) {
+	var data []byte
+	var err error
+	if file != "" {
+		if data, err = ioutil.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("error reading node reservations: %v", err)
+		}
+	} else {
+		reader, err := pkger.Open(defaultNodeReservations)
+		if err != nil {
+			return nil, fmt.Errorf("error opening node reservations: %v", err)
+		}
+		defer reader.Close()
+
+		if data, err = ioutil.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("error reading node reservations: %v", err)
+		}
+	}
+
+	expectations := map[string]RoleReservations{}
+	if err = yaml.Unmarshal(data, &expectations); err != nil {
+		return nil, fmt.Errorf("error parsing node reservations: %v", err)
+	}
+	return expectations, nil
+}
+
+// getKubeletConfig reads the running configuration of a node's kubelet through the API server.
+func getKubeletConfig(h *helper.H, nodeName string) (*kubeletConfig, error) {
+	data, err := h.Kube().CoreV1().RESTClient().Get().AbsPath("/api/v1/nodes", nodeName, "proxy", "configz").DoRaw()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get kubelet config: %v", err)
+	}
+
+	configz := struct {
+		KubeletConfig kubeletConfig `json:"kubeletconfig"`
+	}{}
+	if err = json.Unmarshal(data, &configz); err != nil {
+		return nil, fmt.Errorf("couldn't parse kubelet config: %v", err)
+	}
+	return &configz.KubeletConfig, nil
+}
+
+// checkNodeReservations compares a node's kubelet reservations to expectations and checks that its allocatable
+// resources are its capacity less what is reserved.
+func checkNodeReservations(node kubev1.Node, kubelet *kubeletConfig, expected Reservations) []string {
+	var problems []string
+	problems = append(problems, compareReserved("systemReserved", expected.SystemReserved, kubelet.SystemReserved)...)
+	problems = append(problems, compareReserved("kubeReserved", expected.KubeReserved, kubelet.KubeReserved)...)
+
+	// the kubelet also holds back the hard eviction threshold for memory
+	evictionThresholds := map[string]string{}
+	if threshold, ok := kubelet.EvictionHard["memory.available"]; ok {
+		evictionThresholds[string(kubev1.ResourceMemory)] = threshold
+	}
+
+	for _, name := range []kubev1.ResourceName{kubev1.ResourceCPU, kubev1.ResourceMemory} {
+		capacity, ok := node.Status.Capacity[name]
+		if !ok {
+			continue
+		}
+
+		want := capacity.DeepCopy()
+		checkable := true
+		for _, reserved := range []map[string]string{kubelet.SystemReserved, kubelet.KubeReserved, evictionThresholds} {
+			if value, ok := reserved[string(name)]; ok {
+				quantity, err := resource.ParseQuantity(value)
+				if err != nil {
+					// percentage thresholds depend on the kubelet's view of the node
+					checkable = false
+					break
+				}
+				want.Sub(quantity)
+			}
+		}
+
+		allocatable := node.Status.Allocatable[name]
+		if checkable && allocatable.Cmp(want) != 0 {
+			problems = append(problems, fmt.Sprintf("allocatable %s is %s, expected %s from a capacity of %s", name, allocatable.String(), want.String(), capacity.String()))
+		}
+	}
+	return problems
+}
+
+// compareReserved reports resources whose reservation differs from what is expected.
+func compareReserved(kind string, expected, actual map[string]string) []string {
+	var problems []string
+
+	names := map[string]bool{}
+	for name := range expected {
+		names[name] = true
+	}
+	for name := range actual {
+		names[name] = true
+	}
+
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		want, wantOK := expected[name]
+		got, gotOK := actual[name]
+		switch {
+		case !gotOK:
+			problems = append(problems, fmt.Sprintf("%s %s is not set, expected %s", kind, name, want))
+		case !wantOK:
+			problems = append(problems, fmt.Sprintf("%s %s is %s, expected it not to be set", kind, name, got))
+		case !equalQuantities(want, got):
+			problems = append(problems, fmt.Sprintf("%s %s is %s, expected %s", kind, name, got, want))
+		}
+	}
+	return problems
+}
+
+func equalQuantities(a, b string) bool {
+	qa, errA := resource.ParseQuantity(a)
+	qb, errB := resource.ParseQuantity(b)
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return qa.Cmp(qb) == 0
+}
+
+// nodeRole returns the most specific role of a node. OSD infra nodes are also labeled as workers.
+func nodeRole(node kubev1.Node) string {
+	for _, role := range []string{"master", "infra", "worker"} {
+		if _, ok := node.Labels["node-role.kubernetes.io/"+role]; ok {
+			return role
+		}
+	}
+	return ""
+}
+
+func nodeInstanceType(node kubev1.Node) string {
+	for _, label := range instanceTypeLabels {
+		if instanceType, ok := node.Labels[label]; ok {
+			return instanceType
+		}
+	}
+	return ""
+}
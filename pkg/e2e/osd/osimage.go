@@ -0,0 +1,270 @@
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+const (
+	// osImageURLConfigMap holds the machine-os-content of the cluster's release.
+	osImageURLConfigMap = "machine-config-osimageurl"
+	osImageURLNamespace = "openshift-machine-config-operator"
+	osImageURLKey       = "osImageURL"
+
+	// osContentReportFile is the name of the report of each node's OS content.
+	osContentReportFile = "node-os-content.yaml"
+
+	// hostCommandImage runs commands on nodes, chrooted into the node's filesystem.
+	hostCommandImage = "registry.access.redhat.com/ubi8/ubi-minimal"
+)
+
+// rpmOstreeStatus is the part of `rpm-ostree status --json` that is checked.
+type rpmOstreeStatus struct {
+	Deployments []rpmOstreeDeployment `json:"deployments" yaml:"deployments"`
+}
+
+// rpmOstreeDeployment is an OS deployment on a node.
+type rpmOstreeDeployment struct {
+	Booted                  bool     `json:"booted" yaml:"booted"`
+	Pinned                  bool     `json:"pinned" yaml:"pinned"`
+	Checksum                string   `json:"checksum" yaml:"checksum"`
+	Version                 string   `json:"version" yaml:"version"`
+	CustomOrigin            []string `json:"custom-origin" yaml:"customOrigin,omitempty"`
+	ContainerImageReference string   `json:"container-image-reference" yaml:"containerImageReference,omitempty"`
+	RequestedPackages       []string `json:"requested-packages" yaml:"requestedPackages,omitempty"`
+	RequestedLocalPackages  []string `json:"requested-local-packages" yaml:"requestedLocalPackages,omitempty"`
+	BaseLocalReplacements   []string `json:"requested-base-local-replacements" yaml:"baseLocalReplacements,omitempty"`
+}
+
+// nodeOSContent is the OS content of a node.
+type nodeOSContent struct {
+	Role   string          `yaml:"role"`
+	Status rpmOstreeStatus `yaml:"status"`
+}
+
+var _ = ginkgo.Describe("[Suite: informing] [OSD] Node OS content", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should be the machine-os-content of the cluster version on every node", func() {
+		cm, err := h.Kube().CoreV1().ConfigMaps(osImageURLNamespace).Get(osImageURLConfigMap, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't get the expected machine-os-content")
+		expected := cm.Data[osImageURLKey]
+		Expect(expected).NotTo(BeEmpty(), "no machine-os-content in %s", osImageURLConfigMap)
+
+		nodes, err := h.Kube().CoreV1().Nodes().List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list nodes")
+		Expect(nodes.Items).NotTo(BeEmpty())
+
+		var problems []string
+		content := map[string]nodeOSContent{}
+		for _, node := range nodes.Items {
+			status, err := getRPMOstreeStatus(h, node.Name)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", node.Name, err))
+				continue
+			}
+			content[node.Name] = nodeOSContent{Role: nodeRole(node), Status: *status}
+		}
+
+		if data, err := yaml.Marshal(content); err == nil {
+			h.WriteResults(map[string][]byte{osContentReportFile: data})
+		}
+
+		problems = append(problems, checkOSContent(expected, content)...)
+		Expect(problems).To(BeEmpty(), "nodes have drifted from the machine-os-content of the cluster version")
+	})
+})
+
+// getRPMOstreeStatus reads the OS deployments of a node from a privileged pod scheduled onto it.
+func getRPMOstreeStatus(h *helper.H, nodeName string) (*rpmOstreeStatus, error) {
+	data, err := runOnHost(h, nodeName, "rpm-ostree", "status", "--json")
+	if err != nil {
+		return nil, err
+	}
+
+	var status rpmOstreeStatus
+	if err = json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("couldn't parse rpm-ostree status: %v", err)
+	}
+	return &status, nil
+}
+
+// runOnHost runs a command on a node from a privileged pod scheduled onto it, and returns its output.
+func runOnHost(h *helper.H, nodeName string, command ...string) ([]byte, error) {
+	pod, err := h.Kube().CoreV1().Pods(h.CurrentProject()).Create(hostCommandPod(nodeName, command))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create %s pod: %v", command[0], err)
+	}
+	defer h.Kube().CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+
+	if phase := h.WaitForPodPhase(pod, kubev1.PodSucceeded, 30, 5*time.Second); phase != kubev1.PodSucceeded {
+		return nil, fmt.Errorf("%s pod is %s", command[0], phase)
+	}
+
+	data, err := h.Kube().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &kubev1.PodLogOptions{}).DoRaw()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get %s output: %v", command[0], err)
+	}
+	return data, nil
+}
+
+func hostCommandPod(nodeName string, command []string) *kubev1.Pod {
+	privileged := true
+	return &kubev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s", command[0], util.RandomStr(5)),
+		},
+		Spec: kubev1.PodSpec{
+			NodeName:      nodeName,
+			RestartPolicy: kubev1.RestartPolicyNever,
+			Tolerations:   []kubev1.Toleration{{Operator: kubev1.TolerationOpExists}},
+			Containers: []kubev1.Container{
+				{
+					Name:    command[0],
+					Image:   hostCommandImage,
+					Command: append([]string{"chroot", "/host"}, command...),
+					SecurityContext: &kubev1.SecurityContext{
+						Privileged: &privileged,
+					},
+					VolumeMounts: []kubev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+				},
+			},
+			Volumes: []kubev1.Volume{
+				{
+					Name:         "host",
+					VolumeSource: kubev1.VolumeSource{HostPath: &kubev1.HostPathVolumeSource{Path: "/"}},
+				},
+			},
+		},
+	}
+}
+
+// checkOSContent compares the booted deployment of each node to the expected machine-os-content, and the
+// packages layered onto it to those of the other nodes with the same role.
+func checkOSContent(expected string, content map[string]nodeOSContent) []string {
+	var problems []string
+
+	var names []string
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	layered := map[string]string{}
+	for _, name := range names {
+		node := content[name]
+		booted, staged := node.Status.booted()
+		if booted == nil {
+			problems = append(problems, fmt.Sprintf("%s: no booted deployment", name))
+			continue
+		}
+
+		if image := booted.image(); imageDigest(image) != imageDigest(expected) {
+			problems = append(problems, fmt.Sprintf("%s: booted %s, expected %s", name, image, expected))
+		}
+		if staged {
+			problems = append(problems, fmt.Sprintf("%s: a deployment is staged for the next boot", name))
+		}
+		for _, deployment := range node.Status.Deployments {
+			if deployment.Pinned {
+				problems = append(problems, fmt.Sprintf("%s: deployment %s is pinned", name, deployment.Checksum))
+			}
+		}
+
+		layered[name] = booted.layeredPackages()
+	}
+
+	problems = append(problems, checkLayeredPackages(names, content, layered)...)
+	return problems
+}
+
+// checkLayeredPackages reports nodes whose layered packages differ from those of most nodes with the same role.
+func checkLayeredPackages(names []string, content map[string]nodeOSContent, layered map[string]string) []string {
+	counts := map[string]map[string]int{}
+	for _, name := range names {
+		if packages, ok := layered[name]; ok {
+			role := content[name].Role
+			if counts[role] == nil {
+				counts[role] = map[string]int{}
+			}
+			counts[role][packages]++
+		}
+	}
+
+	common := map[string]string{}
+	for role, roleCounts := range counts {
+		best := -1
+		for packages, count := range roleCounts {
+			if count > best || (count == best && packages < common[role]) {
+				common[role], best = packages, count
+			}
+		}
+	}
+
+	var problems []string
+	for _, name := range names {
+		packages, ok := layered[name]
+		role := content[name].Role
+		if ok && packages != common[role] {
+			problems = append(problems, fmt.Sprintf("%s: layered packages [%s] differ from other %s nodes [%s]", name, packages, role, common[role]))
+		}
+	}
+	return problems
+}
+
+// booted returns the booted deployment and whether a different deployment will be booted next.
+func (s rpmOstreeStatus) booted() (booted *rpmOstreeDeployment, staged bool) {
+	for i := range s.Deployments {
+		if s.Deployments[i].Booted {
+			// the first deployment is the one booted next
+			return &s.Deployments[i], i != 0
+		}
+	}
+	return nil, false
+}
+
+// image returns the container image a deployment was pulled from.
+func (d rpmOstreeDeployment) image() string {
+	if len(d.CustomOrigin) > 0 {
+		return strings.TrimPrefix(d.CustomOrigin[0], "pivot://")
+	}
+	if ref := d.ContainerImageReference; ref != "" {
+		// references are prefixed with their ostree transport, ex. ostree-unverified-registry:quay.io/...
+		if i := strings.Index(ref, "docker://"); i >= 0 {
+			return ref[i+len("docker://"):]
+		}
+		return strings.TrimPrefix(ref, "ostree-unverified-registry:")
+	}
+	return d.Checksum
+}
+
+// layeredPackages returns the packages layered onto or replaced in a deployment, which include MachineConfig extensions.
+func (d rpmOstreeDeployment) layeredPackages() string {
+	var packages []string
+	packages = append(packages, d.RequestedPackages...)
+	packages = append(packages, d.RequestedLocalPackages...)
+	packages = append(packages, d.BaseLocalReplacements...)
+	sort.Strings(packages)
+	return strings.Join(packages, ",")
+}
+
+// imageDigest returns the digest of an image pulled by digest, so the same image matches through a mirror.
+func imageDigest(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	return image
+}
@@ -0,0 +1,57 @@
+package osd
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	machineOSContent = "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:1111"
+	oldOSContent     = "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0000"
+)
+
+func deployment(image string, booted bool, packages ...string) rpmOstreeDeployment {
+	return rpmOstreeDeployment{
+		Booted:            booted,
+		Checksum:          image,
+		CustomOrigin:      []string{"pivot://" + image, "Managed by machine-config-operator"},
+		RequestedPackages: packages,
+	}
+}
+
+func TestCheckOSContent(t *testing.T) {
+	pinned := deployment(oldOSContent, false)
+	pinned.Pinned = true
+
+	content := map[string]nodeOSContent{
+		"master-0": {Role: "master", Status: rpmOstreeStatus{Deployments: []rpmOstreeDeployment{deployment(machineOSContent, true)}}},
+		"worker-0": {Role: "worker", Status: rpmOstreeStatus{Deployments: []rpmOstreeDeployment{deployment(machineOSContent, true, "usbguard")}}},
+		"worker-1": {Role: "worker", Status: rpmOstreeStatus{Deployments: []rpmOstreeDeployment{deployment(machineOSContent, true, "usbguard")}}},
+		// mirrored and pinned
+		"worker-2": {Role: "worker", Status: rpmOstreeStatus{Deployments: []rpmOstreeDeployment{
+			{Booted: true, ContainerImageReference: "ostree-unverified-registry:mirror.example.com/ocp@sha256:1111", RequestedPackages: []string{"usbguard"}},
+			pinned,
+		}}},
+		// partially updated: the new content is staged but the old one is booted
+		"worker-3": {Role: "worker", Status: rpmOstreeStatus{Deployments: []rpmOstreeDeployment{
+			deployment(machineOSContent, false, "usbguard"),
+			deployment(oldOSContent, true, "usbguard"),
+		}}},
+		// missing an extension
+		"worker-4": {Role: "worker", Status: rpmOstreeStatus{Deployments: []rpmOstreeDeployment{deployment(machineOSContent, true)}}},
+		"worker-5": {Role: "worker", Status: rpmOstreeStatus{}},
+	}
+
+	expected := []string{
+		"worker-2: deployment " + oldOSContent + " is pinned",
+		"worker-3: booted " + oldOSContent + ", expected " + machineOSContent,
+		"worker-3: a deployment is staged for the next boot",
+		"worker-5: no booted deployment",
+		"worker-4: layered packages [] differ from other worker nodes [usbguard]",
+	}
+
+	problems := checkOSContent(machineOSContent, content)
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("expected problems:\n%v\ngot:\n%v", expected, problems)
+	}
+}
@@ -0,0 +1,73 @@
+package osd
+
+import (
+	"strings"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/providers/ocmprovider"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	workerRoleLabel = "node-role.kubernetes.io/worker"
+	infraRoleLabel  = "node-role.kubernetes.io/infra"
+)
+
+var _ = ginkgo.Describe("[Suite: e2e] [OSD] Security groups", func() {
+	h := helper.New()
+
+	ginkgo.It("should be attached to every compute node", func() {
+		groups := config.Instance.Cluster.SecurityGroups
+		if len(groups) == 0 {
+			ginkgo.Skip("no additional security groups were requested with CLUSTER_SECURITY_GROUPS")
+		}
+		if state.Instance.CloudProvider.CloudProviderID != "aws" {
+			ginkgo.Skip("security groups can only be checked on AWS")
+		}
+		if !ocmprovider.Options.CCS {
+			ginkgo.Skip("security groups are only attached to CCS clusters")
+		}
+
+		nodes, err := h.Kube().CoreV1().Nodes().List(metav1.ListOptions{LabelSelector: workerRoleLabel})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list compute nodes")
+
+		// infra nodes are workers too, but additional security groups are only attached to compute nodes
+		computeInstances := map[string]string{}
+		for _, node := range nodes.Items {
+			if _, infra := node.Labels[infraRoleLabel]; infra {
+				continue
+			}
+			// the provider ID is aws:///<zone>/<instance ID>
+			providerID := node.Spec.ProviderID
+			computeInstances[providerID[strings.LastIndex(providerID, "/")+1:]] = node.Name
+		}
+		Expect(computeInstances).NotTo(BeEmpty(), "no compute nodes found")
+
+		infra, err := h.Cfg().ConfigV1().Infrastructures().Get("cluster", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't get the cluster infrastructure name")
+
+		// the nodes are in the CCS account, which osde2e's own credentials can't see
+		instances, err := aws.ClusterInstances(ocmprovider.Options.CCSAWSAccessKeyID, ocmprovider.Options.CCSAWSSecretAccessKey,
+			state.Instance.CloudProvider.Region, infra.Status.InfrastructureName)
+		Expect(err).NotTo(HaveOccurred())
+
+		found := 0
+		for _, instance := range instances {
+			node, ok := computeInstances[instance.ID]
+			if !ok {
+				continue
+			}
+			found++
+			for _, group := range groups {
+				Expect(instance.SecurityGroups).To(ContainElement(group), "security group %s isn't attached to node %s (%s)", group, node, instance.ID)
+			}
+		}
+		Expect(found).To(Equal(len(computeInstances)), "not every compute node has a running instance")
+	})
+})
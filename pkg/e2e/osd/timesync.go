@@ -0,0 +1,120 @@
+package osd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+)
+
+const (
+	// clockReportFile is the name of the report of each node's time synchronization.
+	clockReportFile = "node-clocks.yaml"
+
+	// unsynchronisedLeap is the leap status chrony reports until it has synchronized with a source.
+	unsynchronisedLeap = "Not synchronised"
+)
+
+// chronyTracking is the part of `chronyc -c tracking` that is checked.
+type chronyTracking struct {
+	// ReferenceID identifies the source chrony is synchronized with. It's 00000000 if there is none.
+	ReferenceID string `yaml:"referenceID"`
+	Source      string `yaml:"source"`
+	Stratum     int    `yaml:"stratum"`
+
+	// SystemTimeOffset is how far, in seconds, the system clock is from NTP time.
+	SystemTimeOffset float64 `yaml:"systemTimeOffset"`
+
+	LeapStatus string `yaml:"leapStatus"`
+}
+
+var _ = ginkgo.Describe("[Suite: e2e] [OSD] Node clocks", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should be synchronized by chrony on every node", func() {
+		nodes, err := h.Kube().CoreV1().Nodes().List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list nodes")
+		Expect(nodes.Items).NotTo(BeEmpty())
+
+		var problems []string
+		tracking := map[string]chronyTracking{}
+		for _, node := range nodes.Items {
+			data, err := runOnHost(h, node.Name, "chronyc", "-n", "-c", "tracking")
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", node.Name, err))
+				continue
+			}
+
+			t, err := parseChronyTracking(string(data))
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", node.Name, err))
+				continue
+			}
+			tracking[node.Name] = *t
+		}
+
+		if data, err := yaml.Marshal(tracking); err == nil {
+			h.WriteResults(map[string][]byte{clockReportFile: data})
+		}
+
+		problems = append(problems, checkChronyTracking(tracking, config.Instance.Tests.MaxClockSkew)...)
+		Expect(problems).To(BeEmpty(), "node clocks aren't synchronized")
+	})
+})
+
+// parseChronyTracking parses the CSV output of `chronyc -c tracking`.
+func parseChronyTracking(output string) (*chronyTracking, error) {
+	fields, err := csv.NewReader(strings.NewReader(strings.TrimSpace(output))).Read()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse chrony tracking: %v", err)
+	}
+	if len(fields) < 14 {
+		return nil, fmt.Errorf("expected 14 fields in chrony tracking, got %d: %s", len(fields), output)
+	}
+
+	t := &chronyTracking{
+		ReferenceID: fields[0],
+		Source:      fields[1],
+		LeapStatus:  fields[13],
+	}
+	if t.Stratum, err = strconv.Atoi(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid stratum %s: %v", fields[2], err)
+	}
+	if t.SystemTimeOffset, err = strconv.ParseFloat(fields[4], 64); err != nil {
+		return nil, fmt.Errorf("invalid system time offset %s: %v", fields[4], err)
+	}
+	return t, nil
+}
+
+// checkChronyTracking reports nodes that aren't synchronized with a time source, or whose clock is further than
+// maxSkew seconds from NTP time.
+func checkChronyTracking(tracking map[string]chronyTracking, maxSkew float64) []string {
+	var names []string
+	for name := range tracking {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		t := tracking[name]
+		switch {
+		case t.LeapStatus == unsynchronisedLeap || t.ReferenceID == "00000000":
+			problems = append(problems, fmt.Sprintf("%s: chrony isn't synchronized with a time source", name))
+		case math.Abs(t.SystemTimeOffset) > maxSkew:
+			problems = append(problems, fmt.Sprintf("%s: clock is %.3fs from NTP time, more than %gs", name, t.SystemTimeOffset, maxSkew))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,46 @@
+package osd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChronyTracking(t *testing.T) {
+	output := "A9FEA97B,169.254.169.123,4,1591012345.123456789,-0.000012345,0.000001234,0.000023456,-12.345,0.001,0.012,0.000345678,0.000123456,64.2,Normal\n"
+
+	tracking, err := parseChronyTracking(output)
+	if err != nil {
+		t.Fatalf("failed to parse chrony tracking: %v", err)
+	}
+
+	expected := &chronyTracking{
+		ReferenceID:      "A9FEA97B",
+		Source:           "169.254.169.123",
+		Stratum:          4,
+		SystemTimeOffset: -0.000012345,
+		LeapStatus:       "Normal",
+	}
+	if !reflect.DeepEqual(tracking, expected) {
+		t.Errorf("expected %+v, got %+v", expected, tracking)
+	}
+
+	if _, err = parseChronyTracking("506 Cannot talk to daemon"); err == nil {
+		t.Errorf("expected an error when chronyd isn't running")
+	}
+}
+
+func TestCheckChronyTracking(t *testing.T) {
+	tracking := map[string]chronyTracking{
+		"master-0": {ReferenceID: "A9FEA97B", Stratum: 4, SystemTimeOffset: 0.0002, LeapStatus: "Normal"},
+		"worker-0": {ReferenceID: "A9FEA97B", Stratum: 4, SystemTimeOffset: -3.5, LeapStatus: "Normal"},
+		"worker-1": {ReferenceID: "00000000", Stratum: 0, LeapStatus: unsynchronisedLeap},
+	}
+
+	expected := []string{
+		"worker-0: clock is -3.500s from NTP time, more than 2s",
+		"worker-1: chrony isn't synchronized with a time source",
+	}
+	if problems := checkChronyTracking(tracking, 2); !reflect.DeepEqual(problems, expected) {
+		t.Errorf("expected %q, got %q", expected, problems)
+	}
+}
@@ -0,0 +1,295 @@
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/runner"
+	"github.com/openshift/osde2e/pkg/common/templates"
+)
+
+const (
+	// monitoringConfigName is the ConfigMap configuring the cluster monitoring stack.
+	monitoringConfigName      = "cluster-monitoring-config"
+	monitoringConfigNamespace = "openshift-monitoring"
+	monitoringConfigKey       = "config.yaml"
+
+	// userWorkloadNamespace is where the user workload monitoring stack runs.
+	userWorkloadNamespace  = "openshift-user-workload-monitoring"
+	userWorkloadPrometheus = "prometheus-user-workload"
+
+	// uwmApp is the name of the sample app, its ServiceMonitor, and its PrometheusRule.
+	uwmApp      = "prometheus-example-app"
+	uwmAppImage = "quay.io/brancz/prometheus-example-app:v0.2.0"
+	uwmAlert    = "OSDE2EUserWorkloadAlert"
+
+	// uwmQueryAttempts and uwmQueryInterval bound how long the queries wait for metrics and alerts to arrive.
+	uwmQueryAttempts = 20
+	uwmQueryInterval = 30
+)
+
+var (
+	serviceMonitorResource = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "servicemonitors"}
+	prometheusRuleResource = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "prometheusrules"}
+
+	// cmd to query the user workload metrics and alerts through Thanos
+	uwmQueryCmdTpl *template.Template
+)
+
+func init() {
+	var err error
+
+	uwmQueryCmdTpl, err = templates.LoadTemplate("/assets/osd/uwm-query.template")
+
+	if err != nil {
+		panic(fmt.Sprintf("error while loading user workload monitoring query command: %v", err))
+	}
+}
+
+// uwmQuery is a query run against Thanos, whose response is written to Name.json.
+type uwmQuery struct {
+	Name  string
+	Query string
+}
+
+// promResponse is the part of a Prometheus query response that is checked.
+type promResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+var _ = ginkgo.Describe("[Suite: informing] [OSD] User workload monitoring", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	uwmTimeoutInSeconds := 1800
+	ginkgo.It("should collect metrics and fire alerts for user workloads", func() {
+		restore, err := enableUserWorkloadMonitoring(h)
+		Expect(err).NotTo(HaveOccurred(), "couldn't enable user workload monitoring")
+		defer restore()
+
+		err = wait.PollImmediate(15*time.Second, 10*time.Minute, func() (bool, error) {
+			prometheus, err := h.Kube().AppsV1().StatefulSets(userWorkloadNamespace).Get(userWorkloadPrometheus, metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+			return prometheus.Status.ReadyReplicas > 0 && prometheus.Status.ReadyReplicas == prometheus.Status.Replicas, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "the user workload Prometheus never became ready")
+
+		namespace := h.CurrentProject()
+		_, err = h.Kube().AppsV1().Deployments(namespace).Create(exampleAppDeployment(uwmApp))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the sample app")
+
+		_, err = h.Kube().CoreV1().Services(namespace).Create(exampleAppService(uwmApp))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the sample app service")
+
+		_, err = h.Dynamic().Resource(serviceMonitorResource).Namespace(namespace).Create(uwmServiceMonitor(), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the ServiceMonitor")
+
+		_, err = h.Dynamic().Resource(prometheusRuleResource).Namespace(namespace).Create(uwmPrometheusRule(), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the PrometheusRule")
+
+		results := queryUserWorkloads(h, uwmTimeoutInSeconds, []uwmQuery{
+			{Name: "uwm-metrics", Query: fmt.Sprintf(`version{namespace="%s",job="%s"}`, namespace, uwmApp)},
+			{Name: "uwm-alerts", Query: fmt.Sprintf(`ALERTS{namespace="%s",alertname="%s",alertstate="firing"}`, namespace, uwmAlert)},
+		})
+
+		Expect(results["uwm-metrics"].Data.Result).NotTo(BeEmpty(), "metrics of the sample app were never collected")
+		Expect(results["uwm-alerts"].Data.Result).NotTo(BeEmpty(), "the sample app's alert never fired")
+	})
+})
+
+// enableUserWorkloadMonitoring turns on user workload monitoring in the cluster monitoring config. The returned
+// function puts back the original config.
+func enableUserWorkloadMonitoring(h *helper.H) (func(), error) {
+	configMaps := h.Kube().CoreV1().ConfigMaps(monitoringConfigNamespace)
+
+	original, err := configMaps.Get(monitoringConfigName, metav1.GetOptions{})
+	exists := err == nil
+	if !exists {
+		original = &kubev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: monitoringConfigName, Namespace: monitoringConfigNamespace},
+		}
+	}
+
+	monitoringConfig := map[string]interface{}{}
+	if err = yaml.Unmarshal([]byte(original.Data[monitoringConfigKey]), &monitoringConfig); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %v", monitoringConfigName, err)
+	}
+
+	if enabled, _ := monitoringConfig["enableUserWorkload"].(bool); enabled {
+		return func() {}, nil
+	}
+
+	monitoringConfig["enableUserWorkload"] = true
+	data, err := yaml.Marshal(monitoringConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := original.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string]string{}
+	}
+	updated.Data[monitoringConfigKey] = string(data)
+
+	if exists {
+		_, err = configMaps.Update(updated)
+	} else {
+		_, err = configMaps.Create(updated)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		var err error
+		if exists {
+			var current *kubev1.ConfigMap
+			if current, err = configMaps.Get(monitoringConfigName, metav1.GetOptions{}); err == nil {
+				current.Data = original.Data
+				_, err = configMaps.Update(current)
+			}
+		} else {
+			err = configMaps.Delete(monitoringConfigName, &metav1.DeleteOptions{})
+		}
+		Expect(err).NotTo(HaveOccurred(), "couldn't restore %s", monitoringConfigName)
+	}, nil
+}
+
+// queryUserWorkloads runs the queries from inside the cluster, retrying each until it has results or the
+// attempts run out. The responses are written to the report dir and returned by name.
+func queryUserWorkloads(h *helper.H, timeoutInSeconds int, queries []uwmQuery) map[string]promResponse {
+	h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
+	r := h.RunnerWithNoCommand()
+
+	cmd, err := h.ConvertTemplateToString(uwmQueryCmdTpl, struct {
+		OutputDir string
+		Queries   []uwmQuery
+		Attempts  int
+		Interval  int
+	}{
+		OutputDir: runner.DefaultRunner.OutputDir,
+		Queries:   queries,
+		Attempts:  uwmQueryAttempts,
+		Interval:  uwmQueryInterval,
+	})
+	Expect(err).NotTo(HaveOccurred(), "failure creating templated command")
+
+	r.Name = "uwm-query"
+	r.Cmd = cmd
+
+	stopCh := make(chan struct{})
+	err = r.Run(timeoutInSeconds, stopCh)
+	Expect(err).NotTo(HaveOccurred(), "failure running command on pod")
+
+	results, err := r.RetrieveResults()
+	Expect(err).NotTo(HaveOccurred(), "failure retrieving results from pod")
+
+	h.WriteResults(results)
+
+	responses := map[string]promResponse{}
+	for _, query := range queries {
+		response := promResponse{}
+		err = json.Unmarshal(results[query.Name+".json"], &response)
+		Expect(err).NotTo(HaveOccurred(), "failure parsing JSON results of %s", query.Name)
+		Expect(response.Status).To(Equal("success"), "query %s failed", query.Name)
+		responses[query.Name] = response
+	}
+	return responses
+}
+
+// exampleAppDeployment runs the sample app, which serves its metrics and a greeting on its web port.
+func exampleAppDeployment(name string) *appsv1.Deployment {
+	replicas := int32(1)
+	labels := map[string]string{"app": name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: kubev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: kubev1.PodSpec{
+					Containers: []kubev1.Container{
+						{
+							Name:  name,
+							Image: uwmAppImage,
+							Ports: []kubev1.ContainerPort{{Name: "web", ContainerPort: 8080}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func exampleAppService(name string) *kubev1.Service {
+	labels := map[string]string{"app": name}
+	return &kubev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: kubev1.ServiceSpec{
+			Selector: labels,
+			Ports: []kubev1.ServicePort{
+				{Name: "web", Port: 8080, TargetPort: intstr.FromString("web")},
+			},
+		},
+	}
+}
+
+func uwmServiceMonitor() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "ServiceMonitor",
+		"metadata":   map[string]interface{}{"name": uwmApp},
+		"spec": map[string]interface{}{
+			"endpoints": []interface{}{
+				map[string]interface{}{"port": "web", "interval": "30s"},
+			},
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"app": uwmApp},
+			},
+		},
+	}}
+}
+
+// uwmPrometheusRule is an alert that fires as soon as the sample app's metrics are collected.
+func uwmPrometheusRule() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "PrometheusRule",
+		"metadata":   map[string]interface{}{"name": uwmApp},
+		"spec": map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{
+					"name": uwmApp,
+					"rules": []interface{}{
+						map[string]interface{}{
+							"alert": uwmAlert,
+							"expr":  fmt.Sprintf(`version{job="%s"} > 0`, uwmApp),
+						},
+					},
+				},
+			},
+		},
+	}}
+}
@@ -0,0 +1,635 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	ginkgoConfig "github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	"github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/load"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/progress"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	osde2eReporters "github.com/openshift/osde2e/pkg/common/reporters"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// nodesDirectory is the directory in a phase's report directory the reports of each node are written to.
+	nodesDirectory = "nodes"
+
+	// nodeCommand is the osde2e command that runs a node of a parallel phase.
+	nodeCommand = "test-node"
+
+	// nodeField is the field nodes tag what they log with.
+	nodeField = "node"
+
+	// Files in the directory handed off to the nodes.
+	handoffFile       = "handoff.yaml"
+	handoffConfigFile = "config.yaml"
+	handoffStateFile  = "state.yaml"
+
+	// progressDrainTime is how long events still in flight from nodes are waited for once every node has exited.
+	progressDrainTime = 200 * time.Millisecond
+)
+
+// nodeHandoff is what the nodes of a parallel phase need from the osde2e process running the phase.
+type nodeHandoff struct {
+	Phase            string `yaml:"phase"`
+	Description      string `yaml:"description"`
+	Nodes            int    `yaml:"nodes"`
+	SyncHost         string `yaml:"syncHost"`
+	Seed             int64  `yaml:"seed"`
+	ProgressEndpoint string `yaml:"progressEndpoint"`
+}
+
+// runSpecsInParallel sets the suite up, then runs the specs of a phase across the configured number of nodes and
+// merges their JUnit and JSON reports into the phase's. It returns whether every node passed.
+func runSpecsInParallel(phase, description, phaseDirectory string) bool {
+	cfg := config.Instance
+	nodes := cfg.Tests.Parallelism
+
+	junitPath := filepath.Join(phaseDirectory, fmt.Sprintf("junit_%v.xml", cfg.Suffix))
+	jsonPath := filepath.Join(phaseDirectory, fmt.Sprintf("report_%v.json", cfg.Suffix))
+
+	// the nodes only run specs, so the suite is set up once for all of them
+	if err := setupSuite(); err != nil {
+		logging.Errorf("Suite setup failed: %v", err)
+		if writeErr := writeSetupFailure(description, junitPath, jsonPath, err); writeErr != nil {
+			logging.Warnf("Unable to report the suite setup failure: %v", writeErr)
+		}
+		return false
+	}
+
+	specs := listSpecs(description)
+	numRun, numSerial := 0, 0
+	for _, spec := range specs {
+		if spec.runs {
+			numRun++
+		}
+		if spec.serial() {
+			numSerial++
+		}
+	}
+
+	server := newSyncServer(specs)
+	syncHost, err := server.start()
+	if err != nil {
+		logging.Errorf("Unable to start the sync server for parallel nodes: %v", err)
+		return false
+	}
+	defer server.stop()
+
+	relay, err := startProgressRelay(progress.Event{Phase: phase, Total: numRun})
+	if err != nil {
+		logging.Errorf("Unable to relay progress from parallel nodes: %v", err)
+		return false
+	}
+
+	handoffDir, err := writeHandoff(nodeHandoff{
+		Phase:            phase,
+		Description:      description,
+		Nodes:            nodes,
+		SyncHost:         syncHost,
+		Seed:             ginkgoConfig.GinkgoConfig.RandomSeed,
+		ProgressEndpoint: "udp://" + relay.addr(),
+	})
+	if err != nil {
+		relay.stop()
+		logging.Errorf("Unable to hand off the run to parallel nodes: %v", err)
+		return false
+	}
+	defer os.RemoveAll(handoffDir)
+
+	nodesDir := filepath.Join(phaseDirectory, nodesDirectory)
+	if err = os.MkdirAll(nodesDir, os.FileMode(0755)); err != nil {
+		relay.stop()
+		logging.Errorf("Unable to create the node report directory %s: %v", nodesDir, err)
+		return false
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		relay.stop()
+		logging.Errorf("Unable to find the osde2e executable to start parallel nodes: %v", err)
+		return false
+	}
+
+	log.Printf("Running %d specs across %d nodes, %d of them serially.", numRun, nodes, numSerial)
+	started := relay.counts()
+	started.Type = progress.PhaseStarted
+	progress.Emit(started)
+
+	var wg sync.WaitGroup
+	var outputMutex sync.Mutex
+	failed := make([]bool, nodes+1)
+	for node := 1; node <= nodes; node++ {
+		// JSON lines can't be prefixed, the nodes tag what they log with their number instead
+		prefix := fmt.Sprintf("[node %d] ", node)
+		if logging.Format() == logging.JSONFormat {
+			prefix = ""
+		}
+		output := &nodeOutput{prefix: prefix, out: os.Stdout, mutex: &outputMutex}
+		cmd := exec.Command(executable, "-update=false", nodeCommand, "-handoff", handoffDir, "-node", strconv.Itoa(node))
+		cmd.Stdout, cmd.Stderr = output, output
+
+		if err = cmd.Start(); err != nil {
+			logging.Errorf("Unable to start node %d: %v", node, err)
+			failed[node] = true
+			server.done(node)
+			continue
+		}
+
+		wg.Add(1)
+		go func(node int) {
+			defer wg.Done()
+			if err := cmd.Wait(); err != nil {
+				logging.Errorf("Node %d failed: %v", node, err)
+				failed[node] = true
+			}
+			output.flush()
+			server.done(node)
+		}(node)
+	}
+	wg.Wait()
+
+	ended := relay.stop()
+	passed := true
+	var junitFiles, jsonFiles []string
+	for node := 1; node <= nodes; node++ {
+		passed = passed && !failed[node]
+		junitFiles = append(junitFiles, filepath.Join(nodesDir, fmt.Sprintf("junit_%v_node%d.xml", cfg.Suffix, node)))
+		jsonFiles = append(jsonFiles, filepath.Join(nodesDir, fmt.Sprintf("report_%v_node%d.json", cfg.Suffix, node)))
+	}
+
+	if err = mergeJUnitReports(junitFiles, junitPath); err != nil {
+		logging.Warnf("Unable to merge the JUnit reports of the nodes: %v", err)
+		passed = false
+	}
+	if err = mergeJSONReports(jsonFiles, jsonPath); err != nil {
+		logging.Warnf("Unable to merge the JSON reports of the nodes: %v", err)
+	}
+
+	ended.Type = progress.PhaseEnded
+	ended.State = progress.Passed
+	if !passed {
+		ended.State = progress.Failed
+	}
+	progress.Emit(ended)
+
+	return passed
+}
+
+// RunNode runs a node of a parallel phase, using what the osde2e process running the phase handed off in
+// handoffDir. It returns whether the specs run by the node passed.
+func RunNode(handoffDir string, node int) bool {
+	testing.Init()
+
+	handoff, err := readHandoff(handoffDir)
+	if err != nil {
+		logging.Errorf("Unable to read the handoff from %s: %v", handoffDir, err)
+		return false
+	}
+
+	cfg := config.Instance
+	if err = logging.Configure(cfg.LogLevel, cfg.LogFormat); err != nil {
+		logging.Errorf("Unable to configure logging: %v", err)
+		return false
+	}
+	logging.SetField(nodeField, node)
+	parallelNode = true
+	gomega.RegisterFailHandler(ginkgo.Fail)
+
+	ginkgoConfig.DefaultReporterConfig.NoisySkippings = !cfg.Tests.SuppressSkipNotifications
+	ginkgoConfig.GinkgoConfig.SkipString = cfg.Tests.GinkgoSkip
+	ginkgoConfig.GinkgoConfig.FocusString = cfg.Tests.GinkgoFocus
+	ginkgoConfig.GinkgoConfig.RandomSeed = handoff.Seed
+	ginkgoConfig.GinkgoConfig.ParallelNode = node
+	ginkgoConfig.GinkgoConfig.ParallelTotal = handoff.Nodes
+	ginkgoConfig.GinkgoConfig.SyncHost = fmt.Sprintf("%s/node/%d", handoff.SyncHost, node)
+
+	if err = selectImpactedSuites(); err != nil {
+		logging.Errorf("Could not select the suites impacted by changed components: %v", err)
+		return false
+	}
+
+	if err = selectLabeledSuites(); err != nil {
+		logging.Errorf("Could not select the suites by their labels: %v", err)
+		return false
+	}
+
+	if err = progress.Start(handoff.ProgressEndpoint); err != nil {
+		logging.Warnf("Unable to send progress events: %v", err)
+	}
+	defer progress.Stop()
+
+	// the provider is used to collect logs after each spec
+	if len(cfg.Kubeconfig.Path) == 0 {
+		if provider, err = providers.ClusterProvider(); err != nil {
+			logging.Errorf("Could not setup cluster provider: %v", err)
+			return false
+		}
+	}
+
+	state.Instance.Phase = handoff.Phase
+	startClassBudgets()
+
+	nodesDir := filepath.Join(cfg.ReportDir, handoff.Phase, nodesDirectory)
+	junitReporter := reporters.NewJUnitReporter(filepath.Join(nodesDir, fmt.Sprintf("junit_%v_node%d.xml", cfg.Suffix, node)))
+	jsonReporter := osde2eReporters.NewJSONReporter(filepath.Join(nodesDir, fmt.Sprintf("report_%v_node%d.json", cfg.Suffix, node))).WithLabels(specLabels)
+
+	passed := false
+	func() {
+		defer ginkgo.GinkgoRecover()
+		passed = ginkgo.RunSpecsWithDefaultAndCustomReporters(ginkgo.GinkgoT(), handoff.Description, []ginkgo.Reporter{junitReporter, jsonReporter, osde2eReporters.NewProgressReporter(handoff.Phase)})
+	}()
+	return passed
+}
+
+// writeHandoff writes what the nodes need, including the config and state, to a new directory only readable by the
+// current user, as it contains secrets.
+func writeHandoff(handoff nodeHandoff) (string, error) {
+	dir, err := ioutil.TempDir("", "osde2e-nodes")
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(handoff)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(dir, handoffFile), data, 0600)
+	}
+
+	if err == nil {
+		data, err = marshalConfig()
+	}
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(dir, handoffConfigFile), data, 0600)
+	}
+
+	if err == nil {
+		data, err = yaml.Marshal(state.Instance)
+	}
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(dir, handoffStateFile), data, 0600)
+	}
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// marshalConfig marshals the config, including the config of other components such as cluster providers.
+func marshalConfig() ([]byte, error) {
+	data, err := yaml.Marshal(config.Instance)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := yaml.MapSlice{}
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg, err = load.MarshalExtensions(config.Instance, cfg); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(cfg)
+}
+
+// readHandoff reads what was handed off to a node, loading the config and state of the osde2e process running the
+// phase.
+func readHandoff(dir string) (*nodeHandoff, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, handoffFile))
+	if err != nil {
+		return nil, err
+	}
+
+	handoff := &nodeHandoff{}
+	if err = yaml.Unmarshal(data, handoff); err != nil {
+		return nil, err
+	}
+
+	// the handed off config already has the environment applied
+	if err = load.File(config.Instance, filepath.Join(dir, handoffConfigFile)); err != nil {
+		return nil, fmt.Errorf("error loading config: %v", err)
+	}
+
+	if err = load.File(state.Instance, filepath.Join(dir, handoffStateFile)); err != nil {
+		return nil, fmt.Errorf("error loading state: %v", err)
+	}
+	return handoff, nil
+}
+
+// specLister records the specs of the suite in the order Ginkgo iterates over them.
+type specLister struct {
+	specs []listedSpec
+}
+
+// listSpecs lists the specs of the suite with a dry run. Ginkgo orders specs the same way for the same seed, so the
+// indices of the listed specs are the indices the nodes ask for.
+func listSpecs(description string) []listedSpec {
+	lister := &specLister{}
+
+	dryRun := ginkgoConfig.GinkgoConfig.DryRun
+	ginkgoConfig.GinkgoConfig.DryRun = true
+	defer func() {
+		ginkgoConfig.GinkgoConfig.DryRun = dryRun
+	}()
+
+	func() {
+		defer ginkgo.GinkgoRecover()
+		ginkgo.RunSpecsWithCustomReporters(ginkgo.GinkgoT(), description, []ginkgo.Reporter{lister})
+	}()
+	return lister.specs
+}
+
+// SpecSuiteWillBegin does nothing.
+func (l *specLister) SpecSuiteWillBegin(config ginkgoConfig.GinkgoConfigType, summary *types.SuiteSummary) {
+}
+
+// BeforeSuiteDidRun does nothing.
+func (l *specLister) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {}
+
+// SpecWillRun does nothing.
+func (l *specLister) SpecWillRun(specSummary *types.SpecSummary) {}
+
+// SpecDidComplete records a spec and whether it will run.
+func (l *specLister) SpecDidComplete(specSummary *types.SpecSummary) {
+	spec := listedSpec{
+		name: strings.Join(specSummary.ComponentTexts, " "),
+		runs: specSummary.State != types.SpecStateSkipped && specSummary.State != types.SpecStatePending,
+	}
+	if texts := specSummary.ComponentTexts; len(texts) > 1 {
+		spec.suite = texts[1]
+		spec.context = strings.Join(texts[1:len(texts)-1], " ")
+	}
+	l.specs = append(l.specs, spec)
+}
+
+// AfterSuiteDidRun does nothing.
+func (l *specLister) AfterSuiteDidRun(setupSummary *types.SetupSummary) {}
+
+// SpecSuiteDidEnd does nothing.
+func (l *specLister) SpecSuiteDidEnd(summary *types.SuiteSummary) {}
+
+// progressRelay re-emits the spec events sent by nodes, counting the specs completed across all of them.
+type progressRelay struct {
+	conn net.PacketConn
+	done chan struct{}
+
+	mutex sync.Mutex
+	event progress.Event
+}
+
+// startProgressRelay listens for events from nodes on a local UDP port, starting from the counts in event.
+func startProgressRelay(event progress.Event) (*progressRelay, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &progressRelay{conn: conn, done: make(chan struct{}), event: event}
+	go r.relay()
+	return r, nil
+}
+
+// addr is the address nodes send events to.
+func (r *progressRelay) addr() string {
+	return r.conn.LocalAddr().String()
+}
+
+func (r *progressRelay) relay() {
+	defer close(r.done)
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		var event progress.Event
+		if err = json.Unmarshal(buf[:n], &event); err == nil {
+			r.observe(event)
+		}
+	}
+}
+
+// observe re-emits an event from a node. The phase events of nodes are dropped, as the phase is reported as a whole.
+func (r *progressRelay) observe(event progress.Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch event.Type {
+	case progress.SpecStarted:
+		progress.Emit(progress.Event{Type: progress.SpecStarted, Phase: r.event.Phase, Spec: event.Spec})
+	case progress.SpecCompleted:
+		switch event.State {
+		case progress.Passed:
+			r.event.Passed++
+		case progress.Skipped:
+			r.event.Skipped++
+		default:
+			r.event.Failed++
+		}
+
+		r.event.Completed++
+		if r.event.Total > 0 {
+			r.event.Percent = 100 * float64(r.event.Completed) / float64(r.event.Total)
+		}
+
+		completed := r.event
+		completed.Type = progress.SpecCompleted
+		completed.State = event.State
+		completed.Spec = event.Spec
+		progress.Emit(completed)
+	}
+}
+
+// counts returns the counts of the specs completed so far.
+func (r *progressRelay) counts() progress.Event {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.event
+}
+
+// stop waits briefly for events still in flight, then stops relaying and returns the final counts.
+func (r *progressRelay) stop() progress.Event {
+	r.conn.SetReadDeadline(time.Now().Add(progressDrainTime))
+	<-r.done
+	r.conn.Close()
+	return r.counts()
+}
+
+// nodeOutput writes the output of a node a line at a time, prefixing each line with the node it came from.
+type nodeOutput struct {
+	prefix string
+	out    io.Writer
+
+	// mutex is shared by the nodes writing to out, so their lines aren't interleaved.
+	mutex *sync.Mutex
+	line  []byte
+}
+
+func (o *nodeOutput) Write(p []byte) (int, error) {
+	o.line = append(o.line, p...)
+	for {
+		i := bytes.IndexByte(o.line, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		o.mutex.Lock()
+		fmt.Fprintf(o.out, "%s%s", o.prefix, o.line[:i+1])
+		o.mutex.Unlock()
+		o.line = o.line[i+1:]
+	}
+}
+
+// flush writes what is left of the last line.
+func (o *nodeOutput) flush() {
+	if len(o.line) > 0 {
+		o.Write([]byte{'\n'})
+	}
+}
+
+// mergeJUnitReports merges the JUnit reports of the nodes into one report. Nodes that exited before writing a report
+// are skipped.
+func mergeJUnitReports(files []string, merged string) error {
+	var suite reporters.JUnitTestSuite
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var nodeSuite reporters.JUnitTestSuite
+		if err = xml.Unmarshal(data, &nodeSuite); err != nil {
+			return fmt.Errorf("error unmarshalling %s: %v", file, err)
+		}
+
+		suite.Name = nodeSuite.Name
+		suite.Tests += nodeSuite.Tests
+		suite.Failures += nodeSuite.Failures
+		suite.Errors += nodeSuite.Errors
+		suite.TestCases = append(suite.TestCases, nodeSuite.TestCases...)
+
+		// the nodes ran at the same time, so the phase took as long as the slowest node
+		if nodeSuite.Time > suite.Time {
+			suite.Time = nodeSuite.Time
+		}
+	}
+
+	data, err := xml.Marshal(&suite)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(merged, data, 0644)
+}
+
+// mergeJSONReports merges the JSON reports of the nodes into one report, ordering specs by when they started.
+func mergeJSONReports(files []string, merged string) error {
+	report := osde2eReporters.Report{SuiteSucceeded: true, SpecReports: []osde2eReporters.SpecReport{}}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		var nodeReport osde2eReporters.Report
+		if err = json.Unmarshal(data, &nodeReport); err != nil {
+			return fmt.Errorf("error unmarshalling %s: %v", file, err)
+		}
+
+		report.SuiteDescription = nodeReport.SuiteDescription
+		report.SuiteSucceeded = report.SuiteSucceeded && nodeReport.SuiteSucceeded
+		if report.StartTime.IsZero() || nodeReport.StartTime.Before(report.StartTime) {
+			report.StartTime = nodeReport.StartTime
+		}
+		if nodeReport.EndTime.After(report.EndTime) {
+			report.EndTime = nodeReport.EndTime
+		}
+		report.SpecReports = append(report.SpecReports, nodeReport.SpecReports...)
+	}
+
+	report.RunTime = report.EndTime.Sub(report.StartTime).Seconds()
+	sort.SliceStable(report.SpecReports, func(i, j int) bool {
+		return report.SpecReports[i].StartTime.Before(report.SpecReports[j].StartTime)
+	})
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(merged, data, 0644)
+}
+
+// writeSetupFailure writes JUnit and JSON reports with a failed BeforeSuite, like Ginkgo does when the suite can't be
+// set up.
+func writeSetupFailure(description, junitPath, jsonPath string, setupErr error) error {
+	suite := reporters.JUnitTestSuite{
+		Name:     description,
+		Tests:    1,
+		Failures: 1,
+		TestCases: []reporters.JUnitTestCase{{
+			Name:           "BeforeSuite",
+			ClassName:      description,
+			FailureMessage: &reporters.JUnitFailureMessage{Type: "Failure", Message: setupErr.Error()},
+		}},
+	}
+
+	data, err := xml.Marshal(&suite)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(junitPath, data, 0644); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report := osde2eReporters.Report{
+		SuiteDescription: description,
+		StartTime:        now,
+		EndTime:          now,
+		SpecReports: []osde2eReporters.SpecReport{{
+			ContainerHierarchyTexts: []string{},
+			LeafNodeType:            "BeforeSuite",
+			State:                   "failed",
+			StartTime:               now,
+			EndTime:                 now,
+			Failure:                 &osde2eReporters.Failure{Message: setupErr.Error()},
+		}},
+	}
+
+	if data, err = json.MarshalIndent(report, "", "  "); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(jsonPath, data, 0644)
+}
@@ -0,0 +1,250 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	osde2eReporters "github.com/openshift/osde2e/pkg/common/reporters"
+)
+
+func TestSyncServer(t *testing.T) {
+	server := newSyncServer([]listedSpec{
+		{name: "Pods should be healthy", runs: true},
+		{name: "[Serial] Nodes should drain", runs: true},
+		{name: "[Serial] Nodes should reboot"},
+		{name: "Routes should be reachable", runs: true},
+	})
+	host, err := server.start()
+	if err != nil {
+		t.Fatalf("failed to start sync server: %v", err)
+	}
+	defer server.stop()
+
+	next := func(node int) int {
+		resp, err := http.Get(fmt.Sprintf("%s/node/%d/counter", host, node))
+		if err != nil {
+			t.Errorf("failed to get counter: %v", err)
+			return -1
+		}
+		defer resp.Body.Close()
+
+		var c counter
+		if err = json.NewDecoder(resp.Body).Decode(&c); err != nil {
+			t.Errorf("failed to decode counter: %v", err)
+		}
+		return c.Index
+	}
+
+	// the serial spec is handed out last, and specs that won't run aren't serialized
+	if got := []int{next(1), next(2), next(2)}; !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Fatalf("expected specs 0, 2, and 3 to be handed out first, got %v", got)
+	}
+
+	waiting := make(chan int)
+	go func() {
+		waiting <- next(2)
+	}()
+
+	select {
+	case index := <-waiting:
+		t.Fatalf("expected the serial spec to be held while node 1 is running a spec, got %d", index)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// once node 1 is done, one of the nodes gets the serial spec and the other is told there are no more specs
+	got := []int{next(1), <-waiting}
+	if !reflect.DeepEqual(got, []int{1, 4}) && !reflect.DeepEqual(got, []int{4, 1}) {
+		t.Errorf("expected the serial spec and the end of the specs, got %v", got)
+	}
+
+	resp, err := http.Get(host + "/node/2/has-counter")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("expected the server to have a counter, got %v %v", resp, err)
+	}
+
+	beforeSuite := func() types.RemoteBeforeSuiteData {
+		resp, err := http.Get(host + "/node/2/BeforeSuiteState")
+		if err != nil {
+			t.Fatalf("failed to get BeforeSuite state: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var data types.RemoteBeforeSuiteData
+		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			t.Fatalf("failed to decode BeforeSuite state: %v", err)
+		}
+		return data
+	}
+
+	if state := beforeSuite().State; state != types.RemoteBeforeSuiteStatePending {
+		t.Errorf("expected the BeforeSuite to be pending, got %v", state)
+	}
+
+	posted := types.RemoteBeforeSuiteData{Data: []byte("ready"), State: types.RemoteBeforeSuiteStatePassed}
+	if _, err = http.Post(host+"/node/1/BeforeSuiteState", "application/json", bytes.NewReader(posted.ToJSON())); err != nil {
+		t.Fatalf("failed to post BeforeSuite state: %v", err)
+	}
+
+	if data := beforeSuite(); !reflect.DeepEqual(data, posted) {
+		t.Errorf("expected the BeforeSuite state posted by node 1, got %+v", data)
+	}
+}
+
+func TestMergeReports(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	writeFile := func(name string, data []byte, err error) string {
+		path := filepath.Join(tmpDir, name)
+		if err == nil {
+			err = ioutil.WriteFile(path, data, 0644)
+		}
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	junitFiles := []string{
+		writeFile(xmlReport(reporters.JUnitTestSuite{Name: "OSD e2e suite", Tests: 2, Failures: 1, Time: 30, TestCases: []reporters.JUnitTestCase{
+			{Name: "Pods should be healthy"},
+			{Name: "Routes should be reachable", FailureMessage: &reporters.JUnitFailureMessage{Message: "timed out"}},
+		}})),
+		writeFile(xmlReport(reporters.JUnitTestSuite{Name: "OSD e2e suite", Tests: 1, Time: 45, TestCases: []reporters.JUnitTestCase{
+			{Name: "Nodes should be ready"},
+		}})),
+		filepath.Join(tmpDir, "junit_node3.xml"),
+	}
+
+	merged := filepath.Join(tmpDir, "junit.xml")
+	if err = mergeJUnitReports(junitFiles, merged); err != nil {
+		t.Fatalf("failed to merge JUnit reports: %v", err)
+	}
+
+	var suite reporters.JUnitTestSuite
+	data, err := ioutil.ReadFile(merged)
+	if err == nil {
+		err = xml.Unmarshal(data, &suite)
+	}
+	if err != nil {
+		t.Fatalf("failed to read merged JUnit report: %v", err)
+	}
+
+	if suite.Name != "OSD e2e suite" || suite.Tests != 3 || suite.Failures != 1 || suite.Time != 45 || len(suite.TestCases) != 3 {
+		t.Errorf("unexpected merged JUnit report: %+v", suite)
+	}
+
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	jsonFiles := []string{
+		writeFile(jsonReport("report_node1.json", osde2eReporters.Report{SuiteDescription: "OSD e2e suite", SuiteSucceeded: false, StartTime: at(0), EndTime: at(30), SpecReports: []osde2eReporters.SpecReport{
+			{LeafNodeText: "a", StartTime: at(0)},
+			{LeafNodeText: "c", StartTime: at(20)},
+		}})),
+		writeFile(jsonReport("report_node2.json", osde2eReporters.Report{SuiteDescription: "OSD e2e suite", SuiteSucceeded: true, StartTime: at(1), EndTime: at(45), SpecReports: []osde2eReporters.SpecReport{
+			{LeafNodeText: "b", StartTime: at(10)},
+		}})),
+	}
+
+	merged = filepath.Join(tmpDir, "report.json")
+	if err = mergeJSONReports(jsonFiles, merged); err != nil {
+		t.Fatalf("failed to merge JSON reports: %v", err)
+	}
+
+	var report osde2eReporters.Report
+	data, err = ioutil.ReadFile(merged)
+	if err == nil {
+		err = json.Unmarshal(data, &report)
+	}
+	if err != nil {
+		t.Fatalf("failed to read merged JSON report: %v", err)
+	}
+
+	var order []string
+	for _, spec := range report.SpecReports {
+		order = append(order, spec.LeafNodeText)
+	}
+	if report.SuiteSucceeded || report.RunTime != 45 || !reflect.DeepEqual(order, []string{"a", "b", "c"}) {
+		t.Errorf("unexpected merged JSON report: %+v", report)
+	}
+}
+
+func TestNodeOutput(t *testing.T) {
+	var out bytes.Buffer
+	output := &nodeOutput{prefix: "[node 2] ", out: &out, mutex: &sync.Mutex{}}
+
+	fmt.Fprint(output, "Running Suite\nRan 2 ")
+	fmt.Fprint(output, "of 5 specs\npassed")
+	output.flush()
+
+	expected := "[node 2] Running Suite\n[node 2] Ran 2 of 5 specs\n[node 2] passed\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func xmlReport(suite reporters.JUnitTestSuite) (string, []byte, error) {
+	data, err := xml.Marshal(&suite)
+	return fmt.Sprintf("junit_%d.xml", suite.Tests), data, err
+}
+
+func jsonReport(name string, report osde2eReporters.Report) (string, []byte, error) {
+	data, err := json.Marshal(report)
+	return name, data, err
+}
+
+func TestHandoff(t *testing.T) {
+	defer func(resolvers []string, jobName string) {
+		config.Instance.Tests.DNSResolvers, config.Instance.JobName = resolvers, jobName
+	}(config.Instance.Tests.DNSResolvers, config.Instance.JobName)
+
+	os.Setenv("DNS_RESOLVERS", "1.1.1.1,8.8.8.8")
+	defer os.Unsetenv("DNS_RESOLVERS")
+	os.Setenv("JOB_NAME", "from-env")
+	defer os.Unsetenv("JOB_NAME")
+
+	// the config handed off was loaded with the environment, then changed by the phase
+	config.Instance.Tests.DNSResolvers = []string{"1.1.1.1", "8.8.8.8"}
+	config.Instance.JobName = "from-phase"
+
+	dir, err := writeHandoff(nodeHandoff{Phase: "install", Nodes: 2})
+	if err != nil {
+		t.Fatalf("failed to write handoff: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	config.Instance.Tests.DNSResolvers, config.Instance.JobName = nil, ""
+	handoff, err := readHandoff(dir)
+	if err != nil {
+		t.Fatalf("failed to read handoff: %v", err)
+	}
+
+	if handoff.Phase != "install" || handoff.Nodes != 2 {
+		t.Errorf("unexpected handoff: %+v", handoff)
+	}
+	if expected := []string{"1.1.1.1", "8.8.8.8"}; !reflect.DeepEqual(config.Instance.Tests.DNSResolvers, expected) {
+		t.Errorf("expected the handed off resolvers %v, got %v", expected, config.Instance.Tests.DNSResolvers)
+	}
+	if config.Instance.JobName != "from-phase" {
+		t.Errorf("expected the handed off config to be loaded as is, got job name %s", config.Instance.JobName)
+	}
+}
@@ -0,0 +1,210 @@
+package e2e
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/impact"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/plan"
+	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+// identityFederationSuite is the suite that adds an identity provider to the cluster under test.
+const identityFederationSuite = "[Suite: identity-federation]"
+
+// appliedPlan is the plan the run is applying, if any.
+var appliedPlan *plan.Plan
+
+// BuildPlan chooses the cluster and versions a run with the current config would use, and returns the operations
+// it would make against the provider without making them.
+func BuildPlan() (*plan.Plan, error) {
+	return buildPlan(true)
+}
+
+// buildPlan builds the plan of a run. If requirePayload is false, the creation of clusters by providers that can't
+// describe their cluster requests is planned without the request.
+func buildPlan(requirePayload bool) (*plan.Plan, error) {
+	cfg := config.Instance
+	state := state.Instance
+
+	var err error
+	if provider, err = providers.ClusterProvider(); err != nil {
+		return nil, fmt.Errorf("could not setup cluster provider: %v", err)
+	}
+
+	if err = ChooseVersions(); err != nil {
+		return nil, fmt.Errorf("failed to configure versions: %v", err)
+	}
+	if reason := skipReason(); reason != "" {
+		return nil, fmt.Errorf("the run wouldn't test anything: %s", reason)
+	}
+
+	p := &plan.Plan{
+		Created:     time.Now().UTC(),
+		Provider:    cfg.Provider,
+		Environment: provider.Environment(),
+	}
+
+	if state.Upgrade.ReleaseName != "" || state.Upgrade.Image != "" {
+		p.Upgrade = &plan.Upgrade{ReleaseName: state.Upgrade.ReleaseName, Image: state.Upgrade.Image}
+	}
+
+	if state.Cluster.ID == "" {
+		planner, ok := provider.(spi.PlanProvider)
+		if !ok && requirePayload {
+			return nil, fmt.Errorf("provider %s can't plan cluster creation", cfg.Provider)
+		}
+
+		if state.Cluster.Name == "" {
+			if state.Cluster.Name, err = clusterName(provider); err != nil {
+				return nil, fmt.Errorf("could not name cluster: %v", err)
+			}
+		}
+
+		var payload string
+		if ok {
+			if payload, err = planner.ClusterPayload(state.Cluster.Name); err != nil {
+				return nil, fmt.Errorf("could not describe cluster: %v", err)
+			}
+		}
+
+		p.Operations = append(p.Operations, plan.Operation{
+			Kind:        plan.CreateCluster,
+			Description: fmt.Sprintf("Create cluster %s running %s in %s %s.", state.Cluster.Name, state.Cluster.Version, state.CloudProvider.CloudProviderID, state.CloudProvider.Region),
+			Payload:     payload,
+		})
+	} else {
+		cluster, err := provider.GetCluster(state.Cluster.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve cluster information: %v", err)
+		}
+		state.Cluster.Name, state.Cluster.Version = cluster.Name(), cluster.Version()
+		state.CloudProvider.CloudProviderID, state.CloudProvider.Region = cluster.CloudProvider(), cluster.Region()
+	}
+
+	p.Cluster = plan.Cluster{
+		ID:            state.Cluster.ID,
+		Name:          state.Cluster.Name,
+		Version:       state.Cluster.Version,
+		CloudProvider: state.CloudProvider.CloudProviderID,
+		Region:        state.CloudProvider.Region,
+	}
+
+	if len(cfg.Addons.IDs) > 0 {
+		p.Operations = append(p.Operations, plan.Operation{
+			Kind:        plan.InstallAddons,
+			Description: fmt.Sprintf("Install %d addon(s) and wait for the cluster to be ready.", len(cfg.Addons.IDs)),
+			Addons:      cfg.Addons.IDs,
+		})
+	}
+
+	if cfg.Cluster.AutoscalerMaxNodes > 0 {
+		p.Operations = append(p.Operations, plan.Operation{
+			Kind:        plan.ConfigureAutoscaler,
+			Description: fmt.Sprintf("Let the cluster autoscaler scale to %d nodes.", cfg.Cluster.AutoscalerMaxNodes),
+			Autoscaler: &plan.Autoscaler{
+				MaxNodesTotal:        cfg.Cluster.AutoscalerMaxNodes,
+				ScaleDownUtilization: cfg.Cluster.AutoscalerScaleDownUtilization,
+			},
+		})
+	}
+
+	if idp := cfg.IdentityFederation; idp.Issuer != "" && impact.Selects(cfg.Tests.TestsToRun, identityFederationSuite) {
+		p.Operations = append(p.Operations, plan.Operation{
+			Kind:        plan.AddIdentityProvider,
+			Description: fmt.Sprintf("Add an identity provider for %s during the %s suite, then remove it.", idp.Issuer, identityFederationSuite),
+			IdentityProvider: &plan.IdentityProvider{
+				Issuer:      idp.Issuer,
+				ClientID:    idp.ClientID,
+				GroupsClaim: idp.GroupsClaim,
+				ExtraScopes: idp.ExtraScopes,
+			},
+		})
+	}
+
+	return p, nil
+}
+
+// applyPlan configures the run to make exactly the operations of a plan, instead of choosing its own cluster,
+// versions, and operations.
+func applyPlan(file string) error {
+	cfg := config.Instance
+	state := state.Instance
+
+	p, err := plan.Read(file)
+	if err != nil {
+		return err
+	}
+	if err = p.Check(cfg.Provider, provider.Environment()); err != nil {
+		return err
+	}
+
+	state.Cluster.ID, state.Cluster.Name, state.Cluster.Version = p.Cluster.ID, p.Cluster.Name, p.Cluster.Version
+	state.CloudProvider.CloudProviderID, state.CloudProvider.Region = p.Cluster.CloudProvider, p.Cluster.Region
+
+	state.Upgrade.ReleaseName, state.Upgrade.Image = "", ""
+	if p.Upgrade != nil {
+		state.Upgrade.ReleaseName, state.Upgrade.Image = p.Upgrade.ReleaseName, p.Upgrade.Image
+	}
+
+	// only the operations in the plan are made
+	cfg.Cluster.Adopt = false
+	cfg.Addons.IDs = nil
+	cfg.Cluster.AutoscalerMaxNodes, cfg.Cluster.AutoscalerScaleDownUtilization = 0, ""
+	cfg.IdentityFederation.Issuer = ""
+
+	for _, op := range p.Operations {
+		switch op.Kind {
+		case plan.CreateCluster:
+			if p.Cluster.ID != "" {
+				return fmt.Errorf("plan both uses cluster %s and creates a cluster", p.Cluster.ID)
+			}
+		case plan.InstallAddons:
+			cfg.Addons.IDs = op.Addons
+		case plan.ConfigureAutoscaler:
+			if op.Autoscaler != nil {
+				cfg.Cluster.AutoscalerMaxNodes, cfg.Cluster.AutoscalerScaleDownUtilization = op.Autoscaler.MaxNodesTotal, op.Autoscaler.ScaleDownUtilization
+			}
+		case plan.AddIdentityProvider:
+			if idp := op.IdentityProvider; idp != nil {
+				// the client secret isn't in the plan, so it still comes from the config
+				cfg.IdentityFederation.Issuer, cfg.IdentityFederation.ClientID = idp.Issuer, idp.ClientID
+				cfg.IdentityFederation.GroupsClaim, cfg.IdentityFederation.ExtraScopes = idp.GroupsClaim, idp.ExtraScopes
+			}
+			if !impact.Selects(cfg.Tests.TestsToRun, identityFederationSuite) {
+				cfg.Tests.TestsToRun = append(cfg.Tests.TestsToRun, identityFederationSuite)
+			}
+		default:
+			return fmt.Errorf("plan has an unknown operation %s", op.Kind)
+		}
+	}
+
+	if p.Cluster.ID == "" && p.Operation(plan.CreateCluster) == nil {
+		return fmt.Errorf("plan neither uses nor creates a cluster")
+	}
+
+	metadata.Instance.SetClusterVersion(state.Cluster.Version)
+	metadata.Instance.SetUpgradeVersion(state.Upgrade.ReleaseName)
+
+	appliedPlan = p
+	log.Printf("Applying plan %s made at %s with %d operation(s).", file, p.Created.Format(time.RFC3339), len(p.Operations))
+	return nil
+}
+
+// launchCluster creates the cluster under test, from the payload of the plan being applied if there is one.
+func launchCluster(provider spi.Provider) (string, error) {
+	if appliedPlan == nil {
+		return provider.LaunchCluster()
+	}
+
+	planner, ok := provider.(spi.PlanProvider)
+	if !ok {
+		return "", fmt.Errorf("provider %s can't apply plans", config.Instance.Provider)
+	}
+	return planner.LaunchClusterFromPayload(appliedPlan.Operation(plan.CreateCluster).Payload)
+}
@@ -0,0 +1,83 @@
+package e2e
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/plan"
+	"github.com/openshift/osde2e/pkg/common/providers/mock"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+func TestApplyPlan(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if provider, err = mock.New("stage"); err != nil {
+		t.Fatalf("failed to create mock provider: %v", err)
+	}
+	defer func() { provider, appliedPlan = nil, nil }()
+
+	defer func(cfg config.Config, st state.State) {
+		*config.Instance, *state.Instance = cfg, st
+	}(*config.Instance, *state.Instance)
+	config.Instance.Provider = "mock"
+	config.Instance.Cluster.Adopt = true
+	config.Instance.Addons.IDs = []string{"not-planned"}
+	config.Instance.IdentityFederation.Issuer = "https://not-planned.example.com"
+	config.Instance.Tests.TestsToRun = []string{"[Suite: e2e]"}
+	state.Instance.Cluster.Version = "openshift-v4.6.0"
+
+	p := &plan.Plan{
+		Provider:    "mock",
+		Environment: "stage",
+		Cluster:     plan.Cluster{Name: "osde2e-abcde", Version: "openshift-v4.5.1", CloudProvider: "aws", Region: "us-east-1"},
+		Upgrade:     &plan.Upgrade{ReleaseName: "openshift-v4.5.2"},
+		Operations: []plan.Operation{
+			{Kind: plan.CreateCluster, Payload: "{}"},
+			{Kind: plan.InstallAddons, Addons: []string{"prow-operator"}},
+			{Kind: plan.AddIdentityProvider, IdentityProvider: &plan.IdentityProvider{Issuer: "https://issuer.example.com", ClientID: "osde2e"}},
+		},
+	}
+	file := filepath.Join(tmpDir, "plan.yaml")
+	if err = p.Write(file); err != nil {
+		t.Fatalf("failed to write plan: %v", err)
+	}
+
+	if err = applyPlan(file); err != nil {
+		t.Fatalf("failed to apply plan: %v", err)
+	}
+
+	cfg, st := config.Instance, state.Instance
+	if st.Cluster.Name != "osde2e-abcde" || st.Cluster.Version != "openshift-v4.5.1" || st.Upgrade.ReleaseName != "openshift-v4.5.2" {
+		t.Errorf("expected the cluster and upgrade of the plan, got %+v and %+v", st.Cluster, st.Upgrade)
+	}
+	if cfg.Cluster.Adopt {
+		t.Errorf("expected a plan to never adopt a cluster")
+	}
+	if !reflect.DeepEqual(cfg.Addons.IDs, []string{"prow-operator"}) {
+		t.Errorf("expected only the planned addons, got %v", cfg.Addons.IDs)
+	}
+	if cfg.IdentityFederation.Issuer != "https://issuer.example.com" {
+		t.Errorf("expected the planned identity provider, got %s", cfg.IdentityFederation.Issuer)
+	}
+	if !reflect.DeepEqual(cfg.Tests.TestsToRun, []string{"[Suite: e2e]", identityFederationSuite}) {
+		t.Errorf("expected the identity federation suite to be selected, got %v", cfg.Tests.TestsToRun)
+	}
+
+	// plans only apply where they were made
+	p.Environment = "prod"
+	if err = p.Write(file); err != nil {
+		t.Fatalf("failed to write plan: %v", err)
+	}
+	if err = applyPlan(file); err == nil {
+		t.Errorf("expected a prod plan not to apply to stage")
+	}
+}
@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"strconv"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/metrics/push"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+)
+
+// pushPhaseMetrics pushes the run-level metrics of a phase that ended to the Pushgateway, if one is configured.
+// Failing to push doesn't fail the phase.
+func pushPhaseMetrics(phaseName string, results []suiteclass.Result, passed bool) {
+	cfg := config.Instance
+	if cfg.Pushgateway.URL == "" || cfg.DryRun {
+		return
+	}
+
+	run := phaseRun(phaseName, results, passed)
+	job := cfg.Pushgateway.Job
+	if job == "" {
+		job = cfg.JobName
+	}
+	if err := push.New(cfg.Pushgateway.URL, job, cfg.Pushgateway.Token).Push(run); err != nil {
+		logging.Warnf("Unable to push the metrics of the %s phase: %v", phaseName, err)
+		return
+	}
+	logging.Infof("Pushed the metrics of the %s phase to %s", phaseName, cfg.Pushgateway.URL)
+}
+
+// phaseRun collects the run-level metrics of a phase from its test results and the run's metadata.
+func phaseRun(phaseName string, results []suiteclass.Result, passed bool) push.Run {
+	state := state.Instance
+	md := metadata.Instance
+
+	run := push.Run{
+		Phase: phaseName,
+		JobID: strconv.Itoa(config.Instance.JobID),
+		Labels: map[string]string{
+			"install_version": state.Cluster.Version,
+			"upgrade_version": state.Upgrade.ReleaseName,
+			"cloud_provider":  state.CloudProvider.CloudProviderID,
+			"environment":     environment(),
+			"cluster_id":      state.Cluster.ID,
+		},
+		Success: passed,
+	}
+	for _, result := range results {
+		run.Failed += result.Failures
+		run.Skipped += result.Skipped
+		run.Passed += result.Tests - result.Failures - result.Skipped
+	}
+
+	if phaseName == phase.UpgradePhase {
+		run.UpgradeSeconds = md.TimeToUpgradedCluster
+		run.HealthCheckWaitSeconds = md.TimeToUpgradedClusterReady
+	} else {
+		run.ProvisionSeconds = md.TimeToOCMReportingInstalled
+		run.HealthCheckWaitSeconds = md.TimeToClusterReady
+	}
+	return run
+}
+
+// environment returns the environment of the cluster provider, if there is one.
+func environment() string {
+	if provider == nil {
+		return ""
+	}
+	return provider.Environment()
+}
@@ -0,0 +1,32 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+)
+
+func TestPhaseRun(t *testing.T) {
+	defer func(upgrade, upgradeReady float64) {
+		metadata.Instance.TimeToUpgradedCluster, metadata.Instance.TimeToUpgradedClusterReady = upgrade, upgradeReady
+	}(metadata.Instance.TimeToUpgradedCluster, metadata.Instance.TimeToUpgradedClusterReady)
+	metadata.Instance.TimeToUpgradedCluster, metadata.Instance.TimeToUpgradedClusterReady = 2400, 300
+
+	results := []suiteclass.Result{
+		{Class: suiteclass.Blocking, Tests: 10, Failures: 1, Skipped: 2},
+		{Class: suiteclass.Informing, Tests: 4, Failures: 2},
+	}
+	run := phaseRun(phase.UpgradePhase, results, false)
+
+	if run.Passed != 9 || run.Failed != 3 || run.Skipped != 2 {
+		t.Errorf("expected 9 passed, 3 failed, and 2 skipped tests, got %d, %d, and %d", run.Passed, run.Failed, run.Skipped)
+	}
+	if run.UpgradeSeconds != 2400 || run.HealthCheckWaitSeconds != 300 || run.ProvisionSeconds != 0 {
+		t.Errorf("expected the upgrade timings, got %+v", run)
+	}
+	if run.Phase != phase.UpgradePhase || run.Success {
+		t.Errorf("expected a failed upgrade phase, got %+v", run)
+	}
+}
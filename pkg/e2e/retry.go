@@ -0,0 +1,168 @@
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/phase"
+	"github.com/openshift/osde2e/pkg/common/state"
+	"github.com/openshift/osde2e/pkg/common/triage"
+)
+
+// attemptsDir is the directory in the report dir the results of retried attempts are moved to.
+const attemptsDir = "attempts"
+
+var (
+	// phaseFailure is the install or upgrade failure of the current attempt, and failedPhase is the phase it happened in.
+	phaseFailure error
+	failedPhase  string
+
+	// phaseClassification is the category of phaseFailure.
+	phaseClassification triage.Classification
+
+	// destroyedCluster is true once the cluster of the current attempt has been deleted.
+	destroyedCluster bool
+)
+
+// recordPhaseFailure keeps the first install or upgrade failure of the current attempt and records its category.
+func recordPhaseFailure(phase string, err error) {
+	if phaseFailure == nil {
+		failedPhase, phaseFailure = phase, err
+		phaseClassification = classifyFailure(phase, err)
+	}
+}
+
+// classifyFailure records and returns the category of a failure, using the provisioning logs of the cluster when there is one.
+func classifyFailure(phase string, err error) triage.Classification {
+	var logs map[string][]byte
+	if clusterID := state.Instance.Cluster.ID; provider != nil && clusterID != "" {
+		var logsErr error
+		if logs, logsErr = provider.Logs(clusterID); logsErr != nil {
+			logging.Warnf("Unable to retrieve logs of cluster '%s' to classify the failure: %v", clusterID, logsErr)
+		}
+	}
+
+	classification := triage.ClassifyError(err, logs)
+	classification.Phase = phase
+	log.Printf("The %s failure is classified as %s.", phase, classification.Category)
+	metadata.Instance.SetFailureClassification(&classification)
+	return classification
+}
+
+// shouldRetry returns true if the attempt failed because of the infrastructure and can be retried on a new cluster.
+func shouldRetry(attempt int) bool {
+	cfg := config.Instance
+	if phaseFailure == nil || cfg.Tests.PhaseRetries == 0 {
+		return false
+	}
+
+	switch {
+	case !triage.Infrastructure(phaseClassification.Category):
+		log.Printf("The %s failure isn't classified as an infrastructure failure, so the run isn't retried.", failedPhase)
+	case attempt > cfg.Tests.PhaseRetries:
+		log.Printf("The run has been retried %d times, so it isn't retried again.", cfg.Tests.PhaseRetries)
+	case !launchedCluster:
+		log.Printf("The cluster wasn't created by this run, so the run can't be retried on a new cluster.")
+	case appliedPlan != nil:
+		log.Printf("The run is applying a plan, which creates a single cluster, so it isn't retried.")
+	case phase.Aborted() != nil:
+		log.Printf("The run was aborted, so it isn't retried.")
+	case cfg.DryRun:
+		log.Printf("This is a dry run, so it isn't retried.")
+	default:
+		return true
+	}
+	return false
+}
+
+// prepareRetry tears down the cluster of a failed attempt, moves its results aside, and resets
+// the run so the next attempt creates a new cluster.
+func prepareRetry(attempt int) error {
+	cfg := config.Instance
+	state := state.Instance
+
+	classification := phaseClassification.Category
+	logging.Warnf("Attempt %d failed during %s with a %s failure, retrying on a new cluster: %v", attempt, failedPhase, classification, phaseFailure)
+
+	stopBudget()
+	phase.BeginCleanup()
+
+	if !destroyedCluster && state.Cluster.ID != "" {
+		log.Printf("Destroying cluster '%s' of attempt %d...", state.Cluster.ID, attempt)
+		if err := deleteCluster(state.Cluster.ID); err != nil {
+			return fmt.Errorf("error deleting cluster: %v", err)
+		}
+	}
+
+	resultsDir := filepath.Join(attemptsDir, strconv.Itoa(attempt))
+	if cfg.ReportDir != "" {
+		if err := moveAttemptResults(cfg.ReportDir, resultsDir); err != nil {
+			return fmt.Errorf("error moving results of attempt %d: %v", attempt, err)
+		}
+	}
+
+	metadata.Instance.AddAttempt(metadata.Attempt{
+		Number:         attempt,
+		ClusterID:      state.Cluster.ID,
+		Phase:          failedPhase,
+		Classification: classification,
+		Failure:        phaseFailure.Error(),
+		ResultsDir:     resultsDir,
+	})
+	metadata.Instance.SetClusterID("")
+	metadata.Instance.SetClusterName("")
+	metadata.Instance.SetClusterAccess("", "", "", "")
+	metadata.Instance.SetPassRate(phase.InstallPhase, -1)
+	metadata.Instance.SetPassRate(phase.UpgradePhase, -1)
+	metadata.Instance.SetFlakeRate(phase.InstallPhase, -1)
+	metadata.Instance.SetFlakeRate(phase.UpgradePhase, -1)
+	metadata.Instance.ResetFlakyTests()
+	metadata.Instance.ResetSuiteClassResults()
+	metadata.Instance.SetClusterDrift(nil)
+	clusterDrift = ClusterDriftReport{}
+	metadata.Instance.SetFailureClassification(nil)
+
+	state.Cluster.ID = ""
+	state.Cluster.Name = ""
+	state.Cluster.State = ""
+	state.Kubeconfig.Contents = nil
+
+	launchedCluster = false
+	destroyedCluster = false
+	phaseFailure, failedPhase = nil, ""
+	phaseClassification = triage.Classification{}
+
+	phase.EndCleanup()
+	resumeBudget()
+	return nil
+}
+
+// moveAttemptResults moves everything in the report dir, other than earlier attempts, into resultsDir within it.
+func moveAttemptResults(reportDir, resultsDir string) error {
+	files, err := ioutil.ReadDir(reportDir)
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(reportDir, resultsDir)
+	if err = os.MkdirAll(dest, os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.Name() == attemptsDir {
+			continue
+		}
+		if err = os.Rename(filepath.Join(reportDir, file.Name()), filepath.Join(dest, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/triage"
+)
+
+func TestClassifyPhaseFailure(t *testing.T) {
+	tests := map[string]bool{
+		"could not launch cluster: couldn't create cluster: InsufficientInstanceCapacity: no m5.xlarge": true,
+		"could not retrieve cluster information from OCM: couldn't retrieve cluster 'abc': status 503":  true,
+		"failed waiting for cluster ready: the installation of cluster 'abc' has errored":               false,
+		"failed to upgrade cluster: timed out after 90 min waiting for upgrade":                         false,
+		"failed waiting for cluster ready: PollClusterHealth has returned an error 5 times in a row":    false,
+	}
+
+	for failure, expected := range tests {
+		if infrastructure := triage.Infrastructure(classifyFailure("install", errors.New(failure)).Category); infrastructure != expected {
+			t.Errorf("expected %q being an infrastructure failure to be %t", failure, expected)
+		}
+	}
+}
+
+func TestClassifyTypedPhaseFailure(t *testing.T) {
+	unavailable := fmt.Errorf("failed while installing addons: %w", &triage.OCMError{Status: 503, Err: errors.New("api error: unavailable")})
+	if classification := classifyFailure("install", unavailable); classification.Category != triage.OCMBackend {
+		t.Errorf("expected OCM being unavailable to be classified as %s, got %q", triage.OCMBackend, classification.Category)
+	}
+
+	rejected := fmt.Errorf("failed while installing addons: %w", &triage.OCMError{Status: 400, Err: errors.New("api error: bad request")})
+	if classification := classifyFailure("install", rejected); triage.Infrastructure(classification.Category) {
+		t.Errorf("expected rejected OCM requests not to be classified as infrastructure failures, got %q", classification.Category)
+	}
+}
+
+func TestMoveAttemptResults(t *testing.T) {
+	reportDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(reportDir)
+
+	for _, file := range []string{"metadata.json", "install/junit_abc.xml", "attempts/1/metadata.json"} {
+		path := filepath.Join(reportDir, file)
+		if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err = ioutil.WriteFile(path, []byte("{}"), os.FileMode(0644)); err != nil {
+			t.Fatalf("failed to write %s: %v", file, err)
+		}
+	}
+
+	if err = moveAttemptResults(reportDir, filepath.Join(attemptsDir, "2")); err != nil {
+		t.Fatalf("failed to move results: %v", err)
+	}
+
+	for _, file := range []string{"attempts/1/metadata.json", "attempts/2/metadata.json", "attempts/2/install/junit_abc.xml"} {
+		if _, err = os.Stat(filepath.Join(reportDir, file)); err != nil {
+			t.Errorf("expected %s to exist: %v", file, err)
+		}
+	}
+
+	files, err := ioutil.ReadDir(reportDir)
+	if err != nil {
+		t.Fatalf("failed to read report dir: %v", err)
+	}
+	if len(files) != 1 || files[0].Name() != attemptsDir {
+		t.Errorf("expected only the attempts to be left in the report dir, got %v", files)
+	}
+}
@@ -0,0 +1,234 @@
+package scale
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/stats"
+)
+
+const (
+	// imagePullReportFile is the name of the report of the image pulls.
+	imagePullReportFile = "image-pull-report.yaml"
+
+	imagePullLabel = "osde2e-image-pull"
+)
+
+var (
+	// pulledMessage is the message of kubelet's Pulled events. Newer kubelets include how long the pull took.
+	pulledMessage = regexp.MustCompile(`^Successfully pulled image "([^"]+)"(?: in ([0-9.]+m?s))?`)
+
+	// quotedImage finds the image in kubelet's Pulling and Failed events.
+	quotedImage = regexp.MustCompile(`image "([^"]+)"`)
+
+	// throttledPull matches pull failures caused by registry rate limits and quotas.
+	throttledPull = regexp.MustCompile(`toomanyrequests|(?i)too many requests|rate limit|status 429|quota exceeded`)
+)
+
+// ImagePullReport describes the pulls of an image on every node.
+type ImagePullReport struct {
+	Pulled    int `yaml:"pulled"`
+	Failed    int `yaml:"failed"`
+	Throttled int `yaml:"throttled"`
+
+	// Latencies are in seconds.
+	P50 float64 `yaml:"p50Seconds"`
+	P95 float64 `yaml:"p95Seconds"`
+	Max float64 `yaml:"maxSeconds"`
+
+	Errors []string `yaml:"errors,omitempty"`
+
+	latencies []float64
+}
+
+var _ = ginkgo.Describe("[Suite: scale-image-pull] Image pulls", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should pull large images on every node at once", func() {
+		cfg := config.Instance.Scale
+		Expect(cfg.ImagePullImages).NotTo(BeEmpty(), "no images to pull")
+
+		// every image is pulled on every node at the same time
+		var daemonSets []*appsv1.DaemonSet
+		for i, image := range cfg.ImagePullImages {
+			ds, err := h.Kube().AppsV1().DaemonSets(h.CurrentProject()).Create(imagePullDaemonSet(fmt.Sprintf("%s-%d", imagePullLabel, i), image))
+			Expect(err).NotTo(HaveOccurred(), "couldn't create a daemonset pulling %s", image)
+			daemonSets = append(daemonSets, ds)
+		}
+
+		timeout := time.Duration(cfg.ImagePullTimeout) * time.Minute
+		pullErr := wait.PollImmediate(10*time.Second, timeout, func() (bool, error) {
+			return imagesPulled(h, daemonSets), nil
+		})
+
+		events, err := h.Kube().CoreV1().Events(h.CurrentProject()).List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list image pull events")
+
+		reports := summarizePulls(events.Items)
+		data, err := yaml.Marshal(reports)
+		Expect(err).NotTo(HaveOccurred())
+		h.WriteResults(map[string][]byte{imagePullReportFile: data})
+
+		for image, report := range reports {
+			log.Printf("Pulled %s %d times: p50 %.1fs, p95 %.1fs, max %.1fs, %d failures, %d throttled", image, report.Pulled, report.P50, report.P95, report.Max, report.Failed, report.Throttled)
+			Expect(report.Throttled).To(BeZero(), "pulls of %s were throttled by the registry: %v", image, report.Errors)
+			Expect(report.Failed).To(BeZero(), "pulls of %s failed: %v", image, report.Errors)
+		}
+		Expect(pullErr).NotTo(HaveOccurred(), "not every image was pulled within %v", timeout)
+		for _, image := range cfg.ImagePullImages {
+			Expect(reports).To(HaveKey(image), "%s was never pulled", image)
+		}
+	})
+})
+
+// imagePullDaemonSet returns a daemonset that pulls an image on every node, including masters and infra nodes.
+func imagePullDaemonSet(name, image string) *appsv1.DaemonSet {
+	labels := map[string]string{imagePullLabel: name}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: kubev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: kubev1.PodSpec{
+					Tolerations: []kubev1.Toleration{{Operator: kubev1.TolerationOpExists}},
+					Containers: []kubev1.Container{
+						{
+							Name:            "pull",
+							Image:           image,
+							ImagePullPolicy: kubev1.PullAlways,
+							// only the pull is measured, so the image doesn't need to run
+							Command: []string{"/bin/sh", "-c", "sleep 3600"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// imagesPulled returns true once every pod of the daemonsets has either pulled its image or given up on it.
+func imagesPulled(h *helper.H, daemonSets []*appsv1.DaemonSet) bool {
+	for _, ds := range daemonSets {
+		current, err := h.Kube().AppsV1().DaemonSets(ds.Namespace).Get(ds.Name, metav1.GetOptions{})
+		if err != nil || current.Status.DesiredNumberScheduled == 0 {
+			return false
+		}
+
+		pods, err := h.Kube().CoreV1().Pods(ds.Namespace).List(metav1.ListOptions{
+			LabelSelector: metav1.FormatLabelSelector(ds.Spec.Selector),
+		})
+		if err != nil || len(pods.Items) < int(current.Status.DesiredNumberScheduled) {
+			return false
+		}
+
+		for _, pod := range pods.Items {
+			if len(pod.Status.ContainerStatuses) == 0 {
+				return false
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				waiting := status.State.Waiting
+				backedOff := waiting != nil && waiting.Reason == "ImagePullBackOff"
+				if status.ImageID == "" && !backedOff {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// summarizePulls reports the latency and failures of pulls of each image from kubelet's events.
+func summarizePulls(events []kubev1.Event) map[string]*ImagePullReport {
+	reports := map[string]*ImagePullReport{}
+	report := func(image string) *ImagePullReport {
+		if reports[image] == nil {
+			reports[image] = &ImagePullReport{}
+		}
+		return reports[image]
+	}
+
+	// pulls without their duration in the Pulled event are timed from the Pulling event of the same pod
+	pulling := map[string]time.Time{}
+	for _, event := range events {
+		if event.Reason == "Pulling" {
+			if match := quotedImage.FindStringSubmatch(event.Message); match != nil {
+				pulling[string(event.InvolvedObject.UID)+match[1]] = event.FirstTimestamp.Time
+			}
+		}
+	}
+
+	errors := map[string]map[string]bool{}
+	for _, event := range events {
+		switch event.Reason {
+		case "Pulled":
+			match := pulledMessage.FindStringSubmatch(event.Message)
+			if match == nil {
+				// the image was already present
+				continue
+			}
+
+			r := report(match[1])
+			r.Pulled++
+			if latency, err := time.ParseDuration(match[2]); err == nil {
+				r.latencies = append(r.latencies, latency.Seconds())
+			} else if started, ok := pulling[string(event.InvolvedObject.UID)+match[1]]; ok {
+				r.latencies = append(r.latencies, event.FirstTimestamp.Sub(started).Seconds())
+			}
+		case "Failed":
+			match := quotedImage.FindStringSubmatch(event.Message)
+			if match == nil || !strings.Contains(event.Message, "pull") {
+				continue
+			}
+
+			// repeated failures are aggregated into one event
+			count := int(event.Count)
+			if count < 1 {
+				count = 1
+			}
+
+			r := report(match[1])
+			if throttledPull.MatchString(event.Message) {
+				r.Throttled += count
+			} else {
+				r.Failed += count
+			}
+
+			if errors[match[1]] == nil {
+				errors[match[1]] = map[string]bool{}
+			}
+			errors[match[1]][event.Message] = true
+		}
+	}
+
+	for image, r := range reports {
+		for message := range errors[image] {
+			r.Errors = append(r.Errors, message)
+		}
+		sort.Strings(r.Errors)
+
+		if len(r.latencies) == 0 {
+			continue
+		}
+		sort.Float64s(r.latencies)
+		r.P50 = stats.Percentile(r.latencies, 50)
+		r.P95 = stats.Percentile(r.latencies, 95)
+		r.Max = r.latencies[len(r.latencies)-1]
+	}
+	return reports
+}
@@ -0,0 +1,57 @@
+package scale
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func pullEvent(pod, reason, message string, at time.Time, count int32) kubev1.Event {
+	return kubev1.Event{
+		InvolvedObject: kubev1.ObjectReference{UID: types.UID(pod)},
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: metav1.NewTime(at),
+		Count:          count,
+	}
+}
+
+func TestSummarizePulls(t *testing.T) {
+	start := time.Now()
+	events := []kubev1.Event{
+		pullEvent("a", "Pulling", `Pulling image "quay.io/big"`, start, 1),
+		pullEvent("a", "Pulled", `Successfully pulled image "quay.io/big" in 12.5s`, start, 1),
+		pullEvent("b", "Pulling", `Pulling image "quay.io/big"`, start, 1),
+		pullEvent("b", "Pulled", `Successfully pulled image "quay.io/big"`, start.Add(40*time.Second), 1),
+		pullEvent("c", "Pulled", `Container image "quay.io/big" already present on machine`, start, 1),
+		pullEvent("d", "Failed", `Failed to pull image "quay.io/other": rpc error: toomanyrequests: too many requests`, start, 3),
+		pullEvent("e", "Failed", `Failed to pull image "quay.io/other": unauthorized: access denied`, start, 1),
+		pullEvent("e", "Failed", "Error: ErrImagePull", start, 1),
+	}
+
+	expected := map[string]*ImagePullReport{
+		"quay.io/big": {
+			Pulled:    2,
+			P50:       12.5,
+			P95:       40,
+			Max:       40,
+			latencies: []float64{12.5, 40},
+		},
+		"quay.io/other": {
+			Failed:    1,
+			Throttled: 3,
+			Errors: []string{
+				`Failed to pull image "quay.io/other": rpc error: toomanyrequests: too many requests`,
+				`Failed to pull image "quay.io/other": unauthorized: access denied`,
+			},
+		},
+	}
+
+	if reports := summarizePulls(events); !reflect.DeepEqual(reports, expected) {
+		t.Errorf("expected %+v, got %+v", expected, reports)
+	}
+}
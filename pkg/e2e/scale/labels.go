@@ -0,0 +1,8 @@
+package scale
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: scale-", "performance")
+var _ = testlabels.Declare("[Suite: scale-network]", "networking")
+var _ = testlabels.Declare("[Suite: scale-image-pull]", "images")
@@ -0,0 +1,392 @@
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/markbates/pkger"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+const (
+	// defaultNetworkBaselines are the maintained network baselines for each instance type.
+	defaultNetworkBaselines = "/assets/scale/network-baselines.yaml"
+
+	// networkReportFile is the name of the report of the network measurements.
+	networkReportFile = "network-performance.yaml"
+
+	networkLabel = "osde2e-network-perf"
+
+	iperfPort     = 5201
+	netserverPort = 12865
+
+	// netperfDataPort is the port netperf sends test data to. Otherwise it picks a random port, which couldn't be
+	// reached through a service.
+	netperfDataPort = 35000
+
+	// netperfSeparator separates the output of iperf3 from the output of netperf in the client's logs.
+	netperfSeparator = "--- netperf ---"
+
+	workerRoleLabel = "node-role.kubernetes.io/worker"
+	infraRoleLabel  = "node-role.kubernetes.io/infra"
+	masterRoleLabel = "node-role.kubernetes.io/master"
+)
+
+// Network paths that are measured.
+const (
+	podToPod     = "podToPod"
+	podToService = "podToService"
+	crossAZ      = "crossAZ"
+)
+
+var (
+	// instanceTypeLabels hold the instance type of a node, newest first.
+	instanceTypeLabels = []string{"node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type"}
+
+	// zoneLabels hold the availability zone of a node, newest first.
+	zoneLabels = []string{"topology.kubernetes.io/zone", "failure-domain.beta.kubernetes.io/zone"}
+)
+
+// NetworkBaseline is the expected performance of a network path. Zero values aren't checked.
+type NetworkBaseline struct {
+	ThroughputGbps         float64 `yaml:"throughputGbps"`
+	LatencyP99Microseconds float64 `yaml:"latencyP99Microseconds"`
+}
+
+// NetworkBaselines are the expected performance of each network path, by instance type.
+type NetworkBaselines struct {
+	Default       map[string]NetworkBaseline            `yaml:"default"`
+	InstanceTypes map[string]map[string]NetworkBaseline `yaml:"instanceTypes"`
+}
+
+// NetworkMeasurement is the measured performance of a network path.
+type NetworkMeasurement struct {
+	ClientNode string `yaml:"clientNode"`
+	ServerNode string `yaml:"serverNode"`
+
+	ThroughputGbps          float64 `yaml:"throughputGbps"`
+	LatencyP50Microseconds  float64 `yaml:"latencyP50Microseconds"`
+	LatencyP99Microseconds  float64 `yaml:"latencyP99Microseconds"`
+	LatencyMeanMicroseconds float64 `yaml:"latencyMeanMicroseconds"`
+
+	Baseline NetworkBaseline `yaml:"baseline"`
+	Skipped  string          `yaml:"skipped,omitempty"`
+	Error    string          `yaml:"error,omitempty"`
+}
+
+// NetworkReport is the result of the network performance suite.
+type NetworkReport struct {
+	InstanceType string                         `yaml:"instanceType"`
+	Tolerance    int                            `yaml:"tolerancePercent"`
+	Paths        map[string]*NetworkMeasurement `yaml:"paths"`
+}
+
+var _ = ginkgo.Describe("[Suite: scale-network] Network performance", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should meet the throughput and latency baselines of the instance type", func() {
+		cfg := config.Instance.Scale
+		baselines, err := loadNetworkBaselines(cfg.NetworkBaselines)
+		Expect(err).NotTo(HaveOccurred(), "failure loading network baselines")
+
+		nodes, err := h.Kube().CoreV1().Nodes().List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't list nodes")
+
+		workers := networkWorkers(nodes.Items)
+		Expect(len(workers)).To(BeNumerically(">=", 2), "at least two worker nodes are needed to measure the network")
+
+		server, sameZone, otherZone := chooseNetworkNodes(workers)
+		instanceType := nodeLabel(*server, instanceTypeLabels)
+
+		pod, err := h.Kube().CoreV1().Pods(h.CurrentProject()).Create(networkServerPod(server.Name, cfg.NetworkImage))
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the network server")
+		svc, err := h.Kube().CoreV1().Services(h.CurrentProject()).Create(networkServerService())
+		Expect(err).NotTo(HaveOccurred(), "couldn't create the network server's service")
+
+		Expect(h.WaitForPodPhase(pod, kubev1.PodRunning, 60, 5*time.Second)).To(Equal(kubev1.PodRunning), "network server didn't start")
+		pod, err = h.Kube().CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "couldn't get the network server")
+
+		report := NetworkReport{
+			InstanceType: instanceType,
+			Tolerance:    cfg.NetworkTolerance,
+			Paths:        map[string]*NetworkMeasurement{},
+		}
+
+		targets := []struct {
+			path   string
+			client *kubev1.Node
+			target string
+		}{
+			{podToPod, sameZone, pod.Status.PodIP},
+			{podToService, sameZone, svc.Spec.ClusterIP},
+			{crossAZ, otherZone, pod.Status.PodIP},
+		}
+
+		var problems []string
+		for _, t := range targets {
+			m := &NetworkMeasurement{ServerNode: server.Name, Baseline: baselines.forInstanceType(instanceType, t.path)}
+			report.Paths[t.path] = m
+
+			if t.client == nil {
+				m.Skipped = "no worker node in another availability zone"
+				continue
+			}
+			m.ClientNode = t.client.Name
+
+			if err = measureNetwork(h, t.client.Name, t.target, cfg.NetworkImage, cfg.NetworkDuration, m); err != nil {
+				m.Error = err.Error()
+				problems = append(problems, fmt.Sprintf("%s: %v", t.path, err))
+				continue
+			}
+
+			log.Printf("Network %s from %s to %s: %.2f Gbps, p50 %.0fus, p99 %.0fus", t.path, m.ClientNode, m.ServerNode, m.ThroughputGbps, m.LatencyP50Microseconds, m.LatencyP99Microseconds)
+			for _, problem := range checkNetworkBaseline(m, cfg.NetworkTolerance) {
+				problems = append(problems, fmt.Sprintf("%s: %s", t.path, problem))
+			}
+		}
+
+		if data, err := yaml.Marshal(report); err == nil {
+			h.WriteResults(map[string][]byte{networkReportFile: data})
+		}
+		Expect(problems).To(BeEmpty(), "network performance doesn't meet the baselines for %s", instanceType)
+	})
+})
+
+// forInstanceType returns the baseline of a network path for an instance type.
+func (b NetworkBaselines) forInstanceType(instanceType, path string) NetworkBaseline {
+	if baseline, ok := b.InstanceTypes[instanceType][path]; ok {
+		return baseline
+	}
+	return b.Default[path]
+}
+
+func loadNetworkBaselines(file string) (*NetworkBaselines, error) {
+	var data []byte
+	var err error
+	if file != "" {
+		if data, err = ioutil.ReadFile(file); err != nil {
+			return nil, fmt.Errorf("error reading network baselines: %v", err)
+		}
+	} else {
+		reader, err := pkger.Open(defaultNetworkBaselines)
+		if err != nil {
+			return nil, fmt.Errorf("error opening network baselines: %v", err)
+		}
+		defer reader.Close()
+
+		if data, err = ioutil.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("error reading network baselines: %v", err)
+		}
+	}
+
+	baselines := &NetworkBaselines{}
+	if err = yaml.UnmarshalStrict(data, baselines); err != nil {
+		return nil, fmt.Errorf("error parsing network baselines: %v", err)
+	}
+	return baselines, nil
+}
+
+// networkWorkers returns the schedulable worker nodes that aren't also infra or master nodes.
+func networkWorkers(nodes []kubev1.Node) []kubev1.Node {
+	var workers []kubev1.Node
+	for _, node := range nodes {
+		_, worker := node.Labels[workerRoleLabel]
+		_, infra := node.Labels[infraRoleLabel]
+		_, master := node.Labels[masterRoleLabel]
+		if worker && !infra && !master && !node.Spec.Unschedulable {
+			workers = append(workers, node)
+		}
+	}
+	return workers
+}
+
+// chooseNetworkNodes picks the node running the server, a different node for clients, preferably in the same zone,
+// and a node in another zone for cross-AZ clients. There's no cross-AZ client if every worker is in one zone.
+func chooseNetworkNodes(workers []kubev1.Node) (server, sameZone, otherZone *kubev1.Node) {
+	server = &workers[0]
+	zone := nodeLabel(*server, zoneLabels)
+
+	for i := range workers[1:] {
+		node := &workers[i+1]
+		if nodeLabel(*node, zoneLabels) == zone {
+			if sameZone == nil {
+				sameZone = node
+			}
+		} else if otherZone == nil {
+			otherZone = node
+		}
+	}
+
+	if sameZone == nil {
+		sameZone = otherZone
+	}
+	return server, sameZone, otherZone
+}
+
+// nodeLabel returns the value of the first of the labels set on a node.
+func nodeLabel(node kubev1.Node, labels []string) string {
+	for _, label := range labels {
+		if value, ok := node.Labels[label]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// measureNetwork runs a client on a node that measures the throughput and latency to the target.
+func measureNetwork(h *helper.H, nodeName, target, image string, duration int, m *NetworkMeasurement) error {
+	pod, err := h.Kube().CoreV1().Pods(h.CurrentProject()).Create(networkClientPod(nodeName, target, image, duration))
+	if err != nil {
+		return fmt.Errorf("couldn't create network client: %v", err)
+	}
+	defer h.Kube().CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+
+	// both tools run for the duration, on top of pulling the image
+	attempts := (2*duration)/5 + 60
+	if phase := h.WaitForPodPhase(pod, kubev1.PodSucceeded, attempts, 5*time.Second); phase != kubev1.PodSucceeded {
+		return fmt.Errorf("network client is %s", phase)
+	}
+
+	data, err := h.Kube().CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &kubev1.PodLogOptions{}).DoRaw()
+	if err != nil {
+		return fmt.Errorf("couldn't get network client output: %v", err)
+	}
+	return parseNetworkOutput(string(data), m)
+}
+
+// parseNetworkOutput reads the throughput reported by iperf3 and the latencies reported by netperf.
+func parseNetworkOutput(output string, m *NetworkMeasurement) error {
+	parts := strings.SplitN(output, netperfSeparator, 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("network client output is incomplete: %s", strings.TrimSpace(output))
+	}
+
+	iperf := struct {
+		Error string `json:"error"`
+		End   struct {
+			SumReceived struct {
+				BitsPerSecond float64 `json:"bits_per_second"`
+			} `json:"sum_received"`
+		} `json:"end"`
+	}{}
+	if err := json.Unmarshal([]byte(parts[0]), &iperf); err != nil {
+		return fmt.Errorf("couldn't parse iperf3 output: %v", err)
+	}
+	if iperf.Error != "" {
+		return fmt.Errorf("iperf3 failed: %s", iperf.Error)
+	}
+	m.ThroughputGbps = iperf.End.SumReceived.BitsPerSecond / 1e9
+
+	// netperf prints a banner and header before the requested values, separated by commas
+	lines := strings.Split(strings.TrimSpace(parts[1]), "\n")
+	values := strings.Split(strings.TrimSpace(lines[len(lines)-1]), ",")
+	if len(values) != 3 {
+		return fmt.Errorf("couldn't parse netperf output: %s", strings.TrimSpace(parts[1]))
+	}
+
+	latencies := []*float64{&m.LatencyP50Microseconds, &m.LatencyP99Microseconds, &m.LatencyMeanMicroseconds}
+	for i, value := range values {
+		latency, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("couldn't parse netperf latency %q: %v", value, err)
+		}
+		*latencies[i] = latency
+	}
+	return nil
+}
+
+// checkNetworkBaseline compares a measurement to its baseline, allowing it to be worse by tolerance percent.
+func checkNetworkBaseline(m *NetworkMeasurement, tolerance int) []string {
+	var problems []string
+	slack := float64(tolerance) / 100
+
+	if min := m.Baseline.ThroughputGbps * (1 - slack); m.Baseline.ThroughputGbps > 0 && m.ThroughputGbps < min {
+		problems = append(problems, fmt.Sprintf("throughput %.2f Gbps is below %.2f Gbps (baseline %.2f Gbps)", m.ThroughputGbps, min, m.Baseline.ThroughputGbps))
+	}
+
+	if max := m.Baseline.LatencyP99Microseconds * (1 + slack); m.Baseline.LatencyP99Microseconds > 0 && m.LatencyP99Microseconds > max {
+		problems = append(problems, fmt.Sprintf("p99 latency %.0fus is above %.0fus (baseline %.0fus)", m.LatencyP99Microseconds, max, m.Baseline.LatencyP99Microseconds))
+	}
+	return problems
+}
+
+// networkServerPod returns a pod running the iperf3 and netperf servers on a node.
+func networkServerPod(nodeName, image string) *kubev1.Pod {
+	return &kubev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-server", networkLabel),
+			Labels: map[string]string{networkLabel: "server"},
+		},
+		Spec: kubev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []kubev1.Container{
+				{
+					Name:    "server",
+					Image:   image,
+					Command: []string{"/bin/sh", "-c", fmt.Sprintf("netserver -p %d && iperf3 -s -p %d", netserverPort, iperfPort)},
+					Ports: []kubev1.ContainerPort{
+						{Name: "iperf3", ContainerPort: iperfPort},
+						{Name: "netserver", ContainerPort: netserverPort},
+						{Name: "netperf-data", ContainerPort: netperfDataPort},
+					},
+				},
+			},
+		},
+	}
+}
+
+// networkServerService returns a service in front of the network server.
+func networkServerService() *kubev1.Service {
+	return &kubev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: networkLabel},
+		Spec: kubev1.ServiceSpec{
+			Selector: map[string]string{networkLabel: "server"},
+			Ports: []kubev1.ServicePort{
+				{Name: "iperf3", Port: iperfPort, TargetPort: intstr.FromInt(iperfPort)},
+				{Name: "netserver", Port: netserverPort, TargetPort: intstr.FromInt(netserverPort)},
+				{Name: "netperf-data", Port: netperfDataPort, TargetPort: intstr.FromInt(netperfDataPort)},
+			},
+		},
+	}
+}
+
+// networkClientPod returns a pod on a node that measures throughput with iperf3 and then latency with netperf.
+func networkClientPod(nodeName, target, image string, duration int) *kubev1.Pod {
+	script := fmt.Sprintf("iperf3 -c %[1]s -p %[2]d -t %[3]d -J; echo '%[4]s'; "+
+		"netperf -H %[1]s -p %[5]d -l %[3]d -t TCP_RR -- -P ,%[6]d -o P50_LATENCY,P99_LATENCY,MEAN_LATENCY",
+		target, iperfPort, duration, netperfSeparator, netserverPort, netperfDataPort)
+
+	return &kubev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("%s-client-%s", networkLabel, util.RandomStr(5)),
+			Labels: map[string]string{networkLabel: "client"},
+		},
+		Spec: kubev1.PodSpec{
+			NodeName:      nodeName,
+			RestartPolicy: kubev1.RestartPolicyNever,
+			Containers: []kubev1.Container{
+				{
+					Name:    "client",
+					Image:   image,
+					Command: []string{"/bin/sh", "-c", script},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package scale
+
+import (
+	"strings"
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseNetworkOutput(t *testing.T) {
+	output := `{"start": {}, "end": {"sum_received": {"bits_per_second": 4500000000.0}}}
+--- netperf ---
+MIGRATED TCP REQUEST/RESPONSE TEST from 0.0.0.0 () port 0 AF_INET to 10.128.2.5 () port 35000 AF_INET : first burst 0
+50th Percentile Latency Microseconds,99th Percentile Latency Microseconds,Mean Latency Microseconds
+85,210,92.41
+`
+	m := &NetworkMeasurement{}
+	if err := parseNetworkOutput(output, m); err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if m.ThroughputGbps != 4.5 || m.LatencyP50Microseconds != 85 || m.LatencyP99Microseconds != 210 || m.LatencyMeanMicroseconds != 92.41 {
+		t.Errorf("output wasn't parsed correctly: %+v", m)
+	}
+
+	for _, bad := range []string{
+		`{"error": "unable to connect to server: Connection refused"}` + "\n--- netperf ---\n1,2,3\n",
+		`{"end": {}}`,
+		`{"end": {}}` + "\n--- netperf ---\nestablish control: are you sure there is a netserver listening\n",
+	} {
+		if err := parseNetworkOutput(bad, &NetworkMeasurement{}); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestCheckNetworkBaseline(t *testing.T) {
+	baseline := NetworkBaseline{ThroughputGbps: 4, LatencyP99Microseconds: 500}
+	tests := []struct {
+		description string
+		measurement NetworkMeasurement
+		problems    []string
+	}{
+		{"meets baseline", NetworkMeasurement{ThroughputGbps: 4.2, LatencyP99Microseconds: 450, Baseline: baseline}, nil},
+		{"within tolerance", NetworkMeasurement{ThroughputGbps: 3.5, LatencyP99Microseconds: 590, Baseline: baseline}, nil},
+		{"slow", NetworkMeasurement{ThroughputGbps: 3, LatencyP99Microseconds: 700, Baseline: baseline}, []string{"throughput 3.00 Gbps", "p99 latency 700us"}},
+		{"no baseline", NetworkMeasurement{ThroughputGbps: 0.1, LatencyP99Microseconds: 9000}, nil},
+	}
+
+	for _, test := range tests {
+		problems := checkNetworkBaseline(&test.measurement, 20)
+		if len(problems) != len(test.problems) {
+			t.Errorf("%s: expected %d problems, got %v", test.description, len(test.problems), problems)
+			continue
+		}
+		for i, problem := range test.problems {
+			if !strings.HasPrefix(problems[i], problem) {
+				t.Errorf("%s: expected problem starting with %q, got %q", test.description, problem, problems[i])
+			}
+		}
+	}
+}
+
+func networkNode(name, zone string, labels ...string) kubev1.Node {
+	node := kubev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{zoneLabels[0]: zone}}}
+	for _, label := range labels {
+		node.Labels[label] = ""
+	}
+	return node
+}
+
+func TestChooseNetworkNodes(t *testing.T) {
+	nodes := []kubev1.Node{
+		networkNode("master", "a", masterRoleLabel, workerRoleLabel),
+		networkNode("infra", "a", infraRoleLabel, workerRoleLabel),
+		networkNode("worker-a1", "a", workerRoleLabel),
+		networkNode("worker-b1", "b", workerRoleLabel),
+		networkNode("worker-a2", "a", workerRoleLabel),
+	}
+
+	server, sameZone, otherZone := chooseNetworkNodes(networkWorkers(nodes))
+	if server.Name != "worker-a1" || sameZone.Name != "worker-a2" || otherZone.Name != "worker-b1" {
+		t.Errorf("expected worker-a1, worker-a2, and worker-b1, got %s, %s, and %v", server.Name, sameZone.Name, otherZone)
+	}
+
+	// clients are in another zone if no other worker shares the server's zone
+	server, sameZone, otherZone = chooseNetworkNodes(networkWorkers(nodes[2:4]))
+	if sameZone == nil || sameZone.Name != "worker-b1" || otherZone.Name != "worker-b1" {
+		t.Errorf("expected clients on worker-b1, got %v and %v", sameZone, otherZone)
+	}
+
+	// there's no cross-AZ client in a single zone cluster
+	if _, _, otherZone = chooseNetworkNodes([]kubev1.Node{nodes[2], nodes[4]}); otherZone != nil {
+		t.Errorf("expected no cross-AZ client, got %s", otherZone.Name)
+	}
+}
+
+func TestLoadNetworkBaselines(t *testing.T) {
+	baselines, err := loadNetworkBaselines("")
+	if err != nil {
+		t.Fatalf("failed to load the maintained baselines: %v", err)
+	}
+
+	for _, path := range []string{podToPod, podToService, crossAZ} {
+		if baselines.forInstanceType("unknown", path).ThroughputGbps == 0 {
+			t.Errorf("expected a default throughput baseline for %s", path)
+		}
+	}
+	if baselines.forInstanceType("m5.xlarge", podToPod) == baselines.Default[podToPod] {
+		t.Errorf("expected m5.xlarge to have its own baseline")
+	}
+}
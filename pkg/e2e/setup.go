@@ -6,20 +6,28 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/openshift/osde2e/pkg/common/addoncheck"
 	"github.com/openshift/osde2e/pkg/common/cluster"
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/events"
+	"github.com/openshift/osde2e/pkg/common/hooks"
+	"github.com/openshift/osde2e/pkg/common/impact"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/naming"
+	"github.com/openshift/osde2e/pkg/common/phase"
 	"github.com/openshift/osde2e/pkg/common/providers"
+	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
-	"github.com/openshift/osde2e/pkg/common/util"
 )
 
 // Check if the test should run
@@ -27,37 +35,100 @@ var _ = ginkgo.BeforeEach(func() {
 	testText := ginkgo.CurrentGinkgoTestDescription().TestText
 	testContext := strings.TrimSpace(strings.TrimSuffix(ginkgo.CurrentGinkgoTestDescription().FullTestText, testText))
 
-	shouldRun := false
-	for _, testToRun := range config.Instance.Tests.TestsToRun {
-		if strings.HasPrefix(testContext, testToRun) {
-			shouldRun = true
-			break
+	if !selectsContext(testContext) {
+		if labelFocus != nil {
+			ginkgo.Skip(fmt.Sprintf("test %s will not be run as the labels of its context (%s) don't match %s", ginkgo.CurrentGinkgoTestDescription().FullTestText, testContext, labelFocus))
 		}
+		ginkgo.Skip(fmt.Sprintf("test %s will not be run as its context (%s) is not specified as part of the tests to run", ginkgo.CurrentGinkgoTestDescription().FullTestText, testContext))
 	}
 
-	if !shouldRun {
-		ginkgo.Skip(fmt.Sprintf("test %s will not be run as its context (%s) is not specified as part of the tests to run", ginkgo.CurrentGinkgoTestDescription().FullTestText, testContext))
+	if reason := phase.Aborted(); reason != nil {
+		ginkgo.Skip(fmt.Sprintf("test %s will not be run as the run was aborted: %v", ginkgo.CurrentGinkgoTestDescription().FullTestText, reason))
 	}
+
+	if impactedSuites != nil && !impact.Selects(impactedSuites, testContext) {
+		ginkgo.Skip(fmt.Sprintf("test %s will not be run as its context (%s) isn't impacted by the changed components", ginkgo.CurrentGinkgoTestDescription().FullTestText, testContext))
+	}
+
+	logging.SetField(logging.SuiteField, testContext)
+	checkClassBudget()
 })
 
+var _ = ginkgo.AfterEach(func() {
+	logging.SetField(logging.SuiteField, nil)
+})
+
+// launchedCluster is true if the cluster under test was created by this run.
+var launchedCluster bool
+
+// adoptedCluster is true if the cluster under test was found by searching for an existing cluster.
+var adoptedCluster bool
+
+// impactedSuites are the suites impacted by the changed components. If nil, every suite runs.
+var impactedSuites []string
+
+// selectImpactedSuites maps the changed components to the suites they impact.
+func selectImpactedSuites() error {
+	changed := config.Instance.Tests.ChangedComponents
+	if len(changed) == 0 {
+		return nil
+	}
+
+	mapping, err := impact.LoadMapping(config.Instance.Tests.ImpactMapping)
+	if err != nil {
+		return err
+	}
+
+	suites, known := mapping.Suites(changed)
+	if !known {
+		log.Printf("The impact of changes to %s is unknown, running every suite.", strings.Join(changed, ", "))
+		return nil
+	}
+
+	impactedSuites = suites
+	log.Printf("Changes to %s impact %d suites: %s", strings.Join(changed, ", "), len(suites), strings.Join(suites, ", "))
+	return nil
+}
+
+// parallelNode is true in the processes running the specs of a parallel phase, whose suite was set up by the parent.
+var parallelNode bool
+
 // Setup cluster before testing begins.
 var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 	defer ginkgo.GinkgoRecover()
+
+	if !parallelNode && !retryingSpecs {
+		Expect(setupSuite()).To(Succeed())
+	}
+	return []byte{}
+}, func(data []byte) {
+	// only needs to run once
+})
+
+// setupSuite sets up the cluster and its addons, then runs the post-install hooks.
+func setupSuite() error {
 	cfg := config.Instance
 	state := state.Instance
 
 	err := setupCluster()
-	events.HandleErrorWithEvents(err, events.InstallSuccessful, events.InstallFailed).ShouldNot(HaveOccurred(), "failed to setup cluster for testing")
 	if err != nil {
-		return []byte{}
+		recordPhaseFailure(phase.InstallPhase, err)
+		events.RecordEvent(events.InstallFailed)
+		return fmt.Errorf("failed to setup cluster for testing: %w", err)
 	}
+	events.RecordEvent(events.InstallSuccessful)
 
 	if len(cfg.Addons.IDs) > 0 {
-		err = installAddons()
-		events.HandleErrorWithEvents(err, events.InstallAddonsSuccessful, events.InstallAddonsFailed).ShouldNot(HaveOccurred(), "failed while installing addons")
-		if err != nil {
-			return []byte{}
+		if err = installAddons(); err != nil {
+			events.RecordEvent(events.InstallAddonsFailed)
+			return fmt.Errorf("failed while installing addons: %w", err)
 		}
+		events.RecordEvent(events.InstallAddonsSuccessful)
+	}
+
+	// the post-install hooks have already run by the time the suite is set up again for the upgrade phase
+	if state.Phase == phase.InstallPhase {
+		runHooks(hooks.PostInstall)
 	}
 
 	if len(state.Kubeconfig.Contents) == 0 {
@@ -68,10 +139,12 @@ var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 		log.Printf("No kubeconfig contents found, but there should be some by now.")
 	}
 
-	return []byte{}
-}, func(data []byte) {
-	// only needs to run once
-})
+	// the suite is set up again for the upgrade phase, by which point the cluster is no longer as it started
+	if state.Phase == phase.InstallPhase {
+		checkClusterDrift(driftAtStart)
+	}
+	return nil
+}
 
 // Collect logs after each test
 var _ = ginkgo.JustAfterEach(getLogs)
@@ -83,7 +156,7 @@ func getLogs() {
 	if provider == nil {
 		log.Println("OSD was not configured. Skipping log collection...")
 	} else if state.Cluster.ID == "" {
-		log.Println("CLUSTER_ID is not set, likely due to a setup failure. Skipping log collection...")
+		logging.Warnf("CLUSTER_ID is not set, likely due to a setup failure. Skipping log collection...")
 	} else {
 		logs, err := provider.Logs(state.Cluster.ID)
 		Expect(err).NotTo(HaveOccurred(), "failed to collect cluster logs")
@@ -104,24 +177,46 @@ func setupCluster() (err error) {
 	provider, err := providers.ClusterProvider()
 
 	if err != nil {
-		return fmt.Errorf("error getting cluster provisioning client: %v", err)
+		return fmt.Errorf("error getting cluster provisioning client: %w", err)
+	}
+
+	if state.Cluster.ID == "" && cfg.Cluster.Adopt {
+		if state.Cluster.ID, err = adoptCluster(provider); err != nil {
+			return fmt.Errorf("could not adopt cluster: %w", err)
+		}
+		adoptedCluster = state.Cluster.ID != ""
 	}
 
 	// create a new cluster if no ID is specified
 	if state.Cluster.ID == "" {
+		if err = checkCloudLocation(provider); err != nil {
+			return fmt.Errorf("could not launch cluster: %w", err)
+		}
+
 		if state.Cluster.Name == "" {
-			state.Cluster.Name = clusterName()
+			if state.Cluster.Name, err = clusterName(provider); err != nil {
+				return fmt.Errorf("could not name cluster: %w", err)
+			}
 		}
 
-		if state.Cluster.ID, err = provider.LaunchCluster(); err != nil {
-			return fmt.Errorf("could not launch cluster: %v", err)
+		if err = reserveCluster(); err != nil {
+			return fmt.Errorf("could not launch cluster: %w", err)
 		}
+
+		runHooks(hooks.PreProvision)
+
+		if state.Cluster.ID, err = launchCluster(provider); err != nil {
+			return fmt.Errorf("could not launch cluster: %w", err)
+		}
+		launchedCluster = true
 	} else {
-		log.Printf("CLUSTER_ID of '%s' was provided, skipping cluster creation and using it instead", state.Cluster.ID)
+		if !adoptedCluster {
+			log.Printf("CLUSTER_ID of '%s' was provided, skipping cluster creation and using it instead", state.Cluster.ID)
+		}
 
 		cluster, err := provider.GetCluster(state.Cluster.ID)
 		if err != nil {
-			return fmt.Errorf("could not retrieve cluster information from OCM: %v", err)
+			return fmt.Errorf("could not retrieve cluster information from OCM: %w", err)
 		}
 
 		state.Cluster.Name = cluster.Name()
@@ -143,16 +238,177 @@ func setupCluster() (err error) {
 	metadata.Instance.SetClusterID(state.Cluster.ID)
 
 	if err = cluster.WaitForClusterReady(provider, state.Cluster.ID); err != nil {
-		return fmt.Errorf("failed waiting for cluster ready: %v", err)
+		return fmt.Errorf("failed waiting for cluster ready: %w", err)
+	}
+
+	recordClusterAccess(provider, state.Cluster.ID)
+
+	if err = cluster.WaitForDNSPropagation(provider, state.Cluster.ID); err != nil {
+		return fmt.Errorf("failed waiting for DNS propagation: %w", err)
+	}
+
+	if err = cluster.WaitForNoCriticalAlerts(provider, state.Cluster.ID); err != nil {
+		return fmt.Errorf("failed waiting for critical alerts to clear: %w", err)
 	}
 
 	if state.Kubeconfig.Contents, err = provider.ClusterKubeconfig(state.Cluster.ID); err != nil {
-		return fmt.Errorf("could not get kubeconfig for cluster: %v", err)
+		return fmt.Errorf("could not get kubeconfig for cluster: %w", err)
+	}
+
+	if err = configureAutoscaler(provider, state.Cluster.ID); err != nil {
+		return fmt.Errorf("could not configure cluster autoscaler: %w", err)
 	}
 
 	return nil
 }
 
+// checkCloudLocation checks that the chosen region is a region of the chosen cloud provider, if the provider can list
+// them. Failing to list them doesn't stop the cluster from being launched.
+func checkCloudLocation(provider spi.Provider) error {
+	metadata, ok := provider.(spi.CloudMetadataProvider)
+	if !ok {
+		return nil
+	}
+
+	location := state.Instance.CloudProvider
+	regions, err := metadata.Regions(location.CloudProviderID)
+	if err != nil {
+		logging.Warnf("Unable to check region %s of cloud provider %s: %v", location.Region, location.CloudProviderID, err)
+		return nil
+	}
+
+	ids := make([]string, 0, len(regions))
+	for _, region := range regions {
+		if region.ID == location.Region {
+			return nil
+		}
+		ids = append(ids, region.ID)
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("cloud provider %s has no regions", location.CloudProviderID)
+	}
+	return fmt.Errorf("%s isn't a region of cloud provider %s, expected one of %s", location.Region, location.CloudProviderID, strings.Join(ids, ", "))
+}
+
+// adoptCluster searches for an existing cluster matching the chosen version, cloud provider, and region that isn't
+// claimed by another run, claims it, and returns its ID, or an empty string if there isn't one.
+func adoptCluster(provider spi.Provider) (string, error) {
+	cfg := config.Instance
+	state := state.Instance
+
+	searcher, ok := provider.(spi.ClusterSearchProvider)
+	if !ok {
+		log.Printf("Provider %s can't search for clusters, so a new cluster will be created.", cfg.Provider)
+		return "", nil
+	}
+
+	properties := map[string]string{}
+	for _, property := range cfg.Cluster.AdoptProperties {
+		parts := strings.SplitN(property, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return "", fmt.Errorf("property '%s' should be in the format <property>=<value>", property)
+		}
+		properties[parts[0]] = parts[1]
+	}
+
+	search := spi.ClusterSearch{
+		Version:       state.Cluster.Version,
+		CloudProvider: state.CloudProvider.CloudProviderID,
+		Region:        state.CloudProvider.Region,
+		Properties:    properties,
+		Query:         cfg.Cluster.AdoptSearch,
+	}
+
+	// another run can claim the cluster found between the search and the claim, so the search is repeated
+	for attempt := 1; attempt <= adoptAttempts; attempt++ {
+		clusterID, err := searcher.FindCluster(search)
+		if err != nil {
+			return "", err
+		}
+
+		if clusterID == "" {
+			log.Printf("No cluster could be adopted, so a new cluster will be created.")
+			return "", nil
+		}
+
+		claimed, err := searcher.ClaimCluster(clusterID, adoptionClaim())
+		if err != nil {
+			return "", fmt.Errorf("couldn't claim cluster '%s': %w", clusterID, err)
+		}
+
+		if claimed {
+			log.Printf("Adopting cluster '%s' instead of creating a cluster.", clusterID)
+			return clusterID, nil
+		}
+		log.Printf("Cluster '%s' was claimed by another run, searching again.", clusterID)
+	}
+
+	log.Printf("Every cluster found was claimed by another run, so a new cluster will be created.")
+	return "", nil
+}
+
+// adoptAttempts is the number of clusters a run tries to claim before creating one.
+const adoptAttempts = 5
+
+// adoptionClaim identifies the run in the claim on the cluster it adopts.
+func adoptionClaim() string {
+	cfg := config.Instance
+	return fmt.Sprintf("%s-%d-%s", cfg.JobName, cfg.JobID, cfg.Suffix)
+}
+
+// releaseAdoptedCluster removes the run's claim on the cluster it adopted, so that other runs can adopt it.
+func releaseAdoptedCluster(provider spi.Provider) {
+	searcher, ok := provider.(spi.ClusterSearchProvider)
+	if !ok {
+		return
+	}
+
+	if err := searcher.ReleaseCluster(state.Instance.Cluster.ID, adoptionClaim()); err != nil {
+		logging.Warnf("Unable to release adopted cluster '%s': %v", state.Instance.Cluster.ID, err)
+	}
+}
+
+// recordClusterAccess adds the region and URLs of a ready cluster to the metadata so they're in every report. The
+// cluster is usable without them, so failing to look them up doesn't fail setup.
+func recordClusterAccess(provider spi.Provider, clusterID string) {
+	cluster, err := provider.GetCluster(clusterID)
+	if err != nil {
+		logging.Warnf("Unable to record how to access cluster '%s': %v", clusterID, err)
+		return
+	}
+
+	log.Printf("Cluster console: %s", cluster.ConsoleURL())
+	log.Printf("Cluster API: %s", cluster.APIURL())
+	log.Printf("Cluster page: %s", cluster.PageURL())
+
+	metadata.Instance.SetClusterAccess(cluster.Region(), cluster.ConsoleURL(), cluster.APIURL(), cluster.PageURL())
+}
+
+// configureAutoscaler applies the configured cluster autoscaler settings to new and existing clusters.
+func configureAutoscaler(provider spi.Provider, clusterID string) error {
+	cfg := config.Instance
+	if cfg.Cluster.AutoscalerMaxNodes == 0 {
+		return nil
+	}
+
+	autoscalerProvider, ok := provider.(spi.AutoscalerProvider)
+	if !ok {
+		return fmt.Errorf("provider %s can't configure the cluster autoscaler", cfg.Provider)
+	}
+
+	if threshold := cfg.Cluster.AutoscalerScaleDownUtilization; threshold != "" {
+		if value, err := strconv.ParseFloat(threshold, 64); err != nil || value < 0 || value > 1 {
+			return fmt.Errorf("scale down utilization must be between 0 and 1, got %s", threshold)
+		}
+	}
+
+	return autoscalerProvider.ConfigureAutoscaler(clusterID, spi.AutoscalerSettings{
+		MaxNodesTotal:                 cfg.Cluster.AutoscalerMaxNodes,
+		ScaleDownUtilizationThreshold: cfg.Cluster.AutoscalerScaleDownUtilization,
+	})
+}
+
 // installAddons installs addons onto the cluster
 func installAddons() (err error) {
 	clusterID := state.Instance.Cluster.ID
@@ -162,11 +418,27 @@ func installAddons() (err error) {
 	}
 	if num > 0 {
 		if err = cluster.WaitForClusterReady(provider, clusterID); err != nil {
-			return fmt.Errorf("failed waiting for cluster ready: %v", err)
+			return fmt.Errorf("failed waiting for cluster ready: %w", err)
 		}
 	}
 
-	return nil
+	return waitForAddons()
+}
+
+// waitForAddons waits for the objects the installed addons are declared to create to be ready.
+func waitForAddons() error {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(state.Instance.Kubeconfig.Contents)
+	if err != nil {
+		return fmt.Errorf("error generating restconfig: %v", err)
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error creating dynamic client: %v", err)
+	}
+
+	cfg := config.Instance.Addons
+	return addoncheck.Wait(client, cfg.IDs, cfg.ReadinessDir, time.Duration(cfg.ReadinessTimeout)*time.Minute)
 }
 
 // useKubeconfig reads the path provided for a TEST_KUBECONFIG and uses it for testing.
@@ -190,11 +462,17 @@ func useKubeconfig() (err error) {
 	return nil
 }
 
-// cluster name format must be short enough to support all versions
-func clusterName() string {
-	vers := strings.TrimPrefix(state.Instance.Cluster.Version, util.VersionPrefix)
-	safeVersion := strings.Replace(vers, ".", "-", -1)
-	return "ci-cluster-" + safeVersion + "-" + config.Instance.Suffix
+// clusterName generates a name for the cluster from the name template, checking that it isn't taken when the
+// provider can search for clusters.
+func clusterName(provider spi.Provider) (string, error) {
+	cfg := config.Instance
+	values := naming.NewValues(cfg.JobName, cfg.JobID, cfg.Cluster.Purpose, state.Instance.Cluster.Version, cfg.Suffix)
+
+	var taken naming.TakenFunc
+	if searcher, ok := provider.(spi.ClusterSearchProvider); ok {
+		taken = searcher.NameTaken
+	}
+	return naming.Generate(cfg.Cluster.NameTemplate, values, taken)
 }
 
 func writeLogs(m map[string][]byte) {
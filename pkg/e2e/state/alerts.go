@@ -3,24 +3,50 @@ package state
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/common/log"
+	"gopkg.in/yaml.v2"
 
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
 	"github.com/openshift/osde2e/pkg/common/config"
 	"github.com/openshift/osde2e/pkg/common/helper"
 	"github.com/openshift/osde2e/pkg/common/providers"
 	"github.com/openshift/osde2e/pkg/common/runner"
+	osde2eState "github.com/openshift/osde2e/pkg/common/state"
 	"github.com/openshift/osde2e/pkg/common/templates"
 )
 
+// defaultAlertExpectations are the maintained alert expectations for each OCP version.
+const defaultAlertExpectations = "/assets/state/alert-expectations.yaml"
+
 var (
 	// cmd to run get alerts from alertmanager
 	alertsCmdTpl *template.Template
 )
 
+// AlertExpectations are the alerts that are expected to fire during runs of an OCP minor version.
+type AlertExpectations struct {
+	// Alerts may fire in every phase.
+	Alerts []string `yaml:"alerts"`
+
+	// Phases maps a phase to the alerts that may also fire in it.
+	Phases map[string][]string `yaml:"phases"`
+}
+
+// Allows returns true if an alert is expected to fire in a phase.
+func (e AlertExpectations) Allows(alert, phase string) bool {
+	for _, expected := range append(e.Alerts, e.Phases[phase]...) {
+		if expected == alert {
+			return true
+		}
+	}
+	return false
+}
+
 // A mapping of alerts to ignore by cluster provider and environment.
 var ignoreAlerts = map[string]map[string][]string{
 	"ocm": {
@@ -44,65 +70,70 @@ var _ = ginkgo.Describe("[Suite: e2e] Cluster state", func() {
 
 	alertsTimeoutInSeconds := 900
 	ginkgo.It("should have no alerts", func() {
-		// setup runner
-		h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
-		r := h.RunnerWithNoCommand()
-
-		alertsCommand, err := h.ConvertTemplateToString(alertsCmdTpl, struct {
-			OutputDir string
-		}{
-			OutputDir: runner.DefaultRunner.OutputDir,
-		})
-		Expect(err).NotTo(HaveOccurred(), "failure creating templated command")
-
-		r.Name = "alerts"
-		r.Cmd = alertsCommand
-
-		// run tests
-		stopCh := make(chan struct{})
-		err = r.Run(alertsTimeoutInSeconds, stopCh)
-		Expect(err).NotTo(HaveOccurred(), "failure running command on pod")
-
-		// get results
-		results, err := r.RetrieveResults()
-		Expect(err).NotTo(HaveOccurred(), "failure retrieving results from pod")
-
-		// write results
-		h.WriteResults(results)
-
-		queryJSON := query{}
-		err = json.Unmarshal(results["alerts.json"], &queryJSON)
-		Expect(err).NotTo(HaveOccurred(), "failure parsing JSON results from alert manager")
+		queryJSON := queryAlerts(h, alertsTimeoutInSeconds)
 
 		clusterProvider, err := providers.ClusterProvider()
 		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
 
-		foundCritical := findCriticalAlerts(queryJSON.Data.Results, config.Instance.Provider, clusterProvider.Environment())
-		Expect(foundCritical).To(BeFalse(), "found a critical alert")
+		cv, err := healthchecks.GetClusterVersionObject(h.Cfg().ConfigV1())
+		Expect(err).NotTo(HaveOccurred(), "failure getting cluster version")
 
+		expectations, err := loadAlertExpectations(config.Instance.Tests.AlertExpectations)
+		Expect(err).NotTo(HaveOccurred(), "failure loading alert expectations")
+
+		expected, ok := expectations[minorVersion(cv.Status.Desired.Version)]
+		if !ok {
+			log.Infof("There are no alert expectations for OCP %s, only critical alerts will fail", cv.Status.Desired.Version)
+			foundCritical := findCriticalAlerts(queryJSON.Data.Results, config.Instance.Provider, clusterProvider.Environment())
+			Expect(foundCritical).To(BeFalse(), "found a critical alert")
+			return
+		}
+
+		unexpected := findUnexpectedAlerts(queryJSON.Data.Results, expected, osde2eState.Instance.Phase, config.Instance.Provider, clusterProvider.Environment())
+		Expect(unexpected).To(BeEmpty(), "found unexpected alerts: %s", strings.Join(unexpected, ", "))
 	}, float64(alertsTimeoutInSeconds+30))
 })
 
+// queryAlerts runs the alerts query from inside the cluster, writes the results to the report dir, and returns them.
+func queryAlerts(h *helper.H, timeoutInSeconds int) query {
+	// setup runner
+	h.SetServiceAccount("system:serviceaccount:%s:cluster-admin")
+	r := h.RunnerWithNoCommand()
+
+	alertsCommand, err := h.ConvertTemplateToString(alertsCmdTpl, struct {
+		OutputDir string
+	}{
+		OutputDir: runner.DefaultRunner.OutputDir,
+	})
+	Expect(err).NotTo(HaveOccurred(), "failure creating templated command")
+
+	r.Name = "alerts"
+	r.Cmd = alertsCommand
+
+	// run tests
+	stopCh := make(chan struct{})
+	err = r.Run(timeoutInSeconds, stopCh)
+	Expect(err).NotTo(HaveOccurred(), "failure running command on pod")
+
+	// get results
+	results, err := r.RetrieveResults()
+	Expect(err).NotTo(HaveOccurred(), "failure retrieving results from pod")
+
+	// write results
+	h.WriteResults(results)
+
+	queryJSON := query{}
+	err = json.Unmarshal(results["alerts.json"], &queryJSON)
+	Expect(err).NotTo(HaveOccurred(), "failure parsing JSON results from alert manager")
+	return queryJSON
+}
+
 func findCriticalAlerts(results []result, provider, environment string) bool {
 	foundCritical := false
 	for _, result := range results {
 		ignoredCritical := false
 		if result.Metric.Severity == "critical" {
-			// If there alerts to ignore for this provider, let's look through them.
-			if ignoreForEnv, ok := ignoreAlerts[provider]; ok {
-				// If we can find alerts to ignore for this environment, let's look through those, too.
-				if ignoreAlertList, ok := ignoreForEnv[environment]; ok {
-					for _, alertToIgnore := range ignoreAlertList {
-						// If we find an alert in our ignore alert list, set this flag. This will indicate that the presence
-						// of this alert will not fail this test.
-						if alertToIgnore == result.Metric.AlertName {
-							ignoredCritical = true
-							break
-						}
-					}
-				}
-			}
-
+			ignoredCritical = ignoredAlert(result.Metric.AlertName, provider, environment)
 			if !ignoredCritical {
 				foundCritical = true
 			}
@@ -118,6 +149,48 @@ func findCriticalAlerts(results []result, provider, environment string) bool {
 	return foundCritical
 }
 
+// findUnexpectedAlerts returns the warning and critical alerts that aren't expected to fire in a phase.
+func findUnexpectedAlerts(results []result, expected AlertExpectations, phase, provider, environment string) []string {
+	var unexpected []string
+	for _, result := range results {
+		alert, severity := result.Metric.AlertName, result.Metric.Severity
+		switch {
+		case severity != "warning" && severity != "critical":
+			log.Infof("Active alert: %s, Severity: %s", alert, severity)
+		case expected.Allows(alert, phase) || ignoredAlert(alert, provider, environment):
+			log.Infof("Active alert: %s, Severity: %s (expected in the %s phase, ignoring)", alert, severity, phase)
+		default:
+			log.Infof("Active alert: %s, Severity: %s (unexpected)", alert, severity)
+			unexpected = append(unexpected, alert)
+		}
+	}
+	return unexpected
+}
+
+// ignoredAlert returns true if an alert is known to be consistently critical for a provider and environment.
+func ignoredAlert(alert, provider, environment string) bool {
+	for _, ignored := range ignoreAlerts[provider][environment] {
+		if ignored == alert {
+			return true
+		}
+	}
+	return false
+}
+
+// loadAlertExpectations reads alert expectations keyed by OCP minor version from a file or the maintained expectations.
+func loadAlertExpectations(file string) (map[string]AlertExpectations, error) {
+	data, err := readExpectations(file, defaultAlertExpectations)
+	if err != nil {
+		return nil, fmt.Errorf("error reading alert expectations: %v", err)
+	}
+
+	expectations := map[string]AlertExpectations{}
+	if err = yaml.Unmarshal(data, &expectations); err != nil {
+		return nil, fmt.Errorf("error parsing alert expectations: %v", err)
+	}
+	return expectations, nil
+}
+
 type query struct {
 	Data data `json:"data"`
 }
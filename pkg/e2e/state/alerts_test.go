@@ -1,6 +1,9 @@
 package state
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestFindCriticalAlerts(t *testing.T) {
 	tests := []struct {
@@ -148,3 +151,61 @@ func TestFindCriticalAlerts(t *testing.T) {
 		}
 	}
 }
+
+func TestFindUnexpectedAlerts(t *testing.T) {
+	expected := AlertExpectations{
+		Alerts: []string{"AlertmanagerReceiversNotConfigured"},
+		Phases: map[string][]string{"upgrade": {"ClusterNotUpgradeable"}},
+	}
+
+	results := []result{
+		{Metric: metric{AlertName: "AlertmanagerReceiversNotConfigured", Severity: "warning"}},
+		{Metric: metric{AlertName: "ClusterNotUpgradeable", Severity: "warning"}},
+		{Metric: metric{AlertName: "UpdateAvailable", Severity: "info"}},
+		{Metric: metric{AlertName: "MetricsClientSendFailingSRE", Severity: "critical"}},
+		{Metric: metric{AlertName: "KubePodCrashLooping", Severity: "critical"}},
+	}
+
+	tests := []struct {
+		Name        string
+		Phase       string
+		Environment string
+		Expected    []string
+	}{
+		{
+			Name:        "install phase",
+			Phase:       "install",
+			Environment: "prod",
+			Expected:    []string{"ClusterNotUpgradeable", "MetricsClientSendFailingSRE", "KubePodCrashLooping"},
+		},
+		{
+			Name:        "upgrade phase",
+			Phase:       "upgrade",
+			Environment: "prod",
+			Expected:    []string{"MetricsClientSendFailingSRE", "KubePodCrashLooping"},
+		},
+		{
+			Name:        "ignored in environment",
+			Phase:       "upgrade",
+			Environment: "int",
+			Expected:    []string{"KubePodCrashLooping"},
+		},
+	}
+
+	for _, test := range tests {
+		if unexpected := findUnexpectedAlerts(results, expected, test.Phase, "ocm", test.Environment); !reflect.DeepEqual(unexpected, test.Expected) {
+			t.Errorf("Test %s: expected unexpected alerts %v, got %v", test.Name, test.Expected, unexpected)
+		}
+	}
+}
+
+func TestMaintainedAlertExpectations(t *testing.T) {
+	expectations, err := loadAlertExpectations("")
+	if err != nil {
+		t.Fatalf("failed to load maintained alert expectations: %v", err)
+	}
+
+	if !expectations["4.6"].Allows("ClusterNotUpgradeable", "upgrade") {
+		t.Errorf("expected ClusterNotUpgradeable to be allowed during 4.6 upgrades")
+	}
+}
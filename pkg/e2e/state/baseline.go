@@ -0,0 +1,257 @@
+package state
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+)
+
+const (
+	// BaselineSnapshotFile is the name of the snapshot of the cluster written to the report dir.
+	BaselineSnapshotFile = "baseline-snapshot.yaml"
+
+	// BaselineAnomaliesFile is the name of the anomalies found comparing the cluster to the fleet baseline.
+	BaselineAnomaliesFile = "baseline-anomalies.yaml"
+
+	// defaultBaselineTolerance is how far resource usage may stray from the baseline when it doesn't set a tolerance.
+	defaultBaselineTolerance = 0.5
+)
+
+// Snapshot captures the characteristics of a cluster that are compared against the fleet. A snapshot of a
+// healthy cluster can be used as a fleet baseline.
+type Snapshot struct {
+	// Version is the version of the cluster.
+	Version string `yaml:"version,omitempty"`
+
+	// Operators maps each ClusterOperator to the version it reports.
+	Operators map[string]string `yaml:"operators"`
+
+	// Alerts are the firing alerts.
+	Alerts []string `yaml:"alerts"`
+
+	// Namespaces holds the resources requested in each platform namespace.
+	Namespaces map[string]Usage `yaml:"namespaces"`
+
+	// Tolerance is the fraction resource usage may differ from a baseline before it is an anomaly.
+	Tolerance float64 `yaml:"tolerance,omitempty"`
+}
+
+// Usage is the resources requested by the pods in a namespace.
+type Usage struct {
+	Pods int `yaml:"pods"`
+
+	// CPU is in millicores.
+	CPU int64 `yaml:"cpu"`
+
+	// Memory is in bytes.
+	Memory int64 `yaml:"memory"`
+}
+
+// Anomaly is a way a cluster differs from the fleet baseline.
+type Anomaly struct {
+	Kind    string `yaml:"kind"`
+	Name    string `yaml:"name"`
+	Message string `yaml:"message"`
+}
+
+var _ = ginkgo.Describe("[Suite: informing] Cluster baseline", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	baselineTimeoutInSeconds := 900
+	ginkgo.It("should look like the fleet baseline", func() {
+		snapshot := takeSnapshot(h, baselineTimeoutInSeconds)
+
+		data, err := yaml.Marshal(snapshot)
+		Expect(err).NotTo(HaveOccurred(), "failure encoding cluster snapshot")
+		results := map[string][]byte{BaselineSnapshotFile: data}
+
+		if config.Instance.Tests.FleetBaseline == "" {
+			h.WriteResults(results)
+			ginkgo.Skip("no fleet baseline is configured, set FLEET_BASELINE to compare the cluster against one")
+		}
+
+		baseline, err := loadBaseline(config.Instance.Tests.FleetBaseline)
+		Expect(err).NotTo(HaveOccurred(), "failure loading fleet baseline")
+
+		anomalies := compareToBaseline(baseline, snapshot)
+		if data, err = yaml.Marshal(anomalies); err == nil {
+			results[BaselineAnomaliesFile] = data
+		}
+		h.WriteResults(results)
+
+		Expect(anomalies).To(BeEmpty(), "cluster differs from the fleet baseline:\n%s", describeAnomalies(anomalies))
+	})
+})
+
+// takeSnapshot captures the cluster's operators, firing alerts, and platform resource usage.
+func takeSnapshot(h *helper.H, timeoutInSeconds int) *Snapshot {
+	snapshot := &Snapshot{
+		Operators:  map[string]string{},
+		Namespaces: map[string]Usage{},
+	}
+
+	cv, err := healthchecks.GetClusterVersionObject(h.Cfg().ConfigV1())
+	Expect(err).NotTo(HaveOccurred(), "failure getting cluster version")
+	snapshot.Version = cv.Status.Desired.Version
+
+	operators, err := h.Cfg().ConfigV1().ClusterOperators().List(metav1.ListOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failure listing ClusterOperators")
+	for _, co := range operators.Items {
+		snapshot.Operators[co.Name] = ""
+		for _, version := range co.Status.Versions {
+			if version.Name == "operator" {
+				snapshot.Operators[co.Name] = version.Version
+			}
+		}
+	}
+
+	pods, err := h.Kube().CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failure listing pods")
+	snapshot.Namespaces = namespaceUsage(pods.Items)
+
+	for _, result := range queryAlerts(h, timeoutInSeconds).Data.Results {
+		snapshot.Alerts = append(snapshot.Alerts, result.Metric.AlertName)
+	}
+	sort.Strings(snapshot.Alerts)
+	return snapshot
+}
+
+// namespaceUsage totals the resources requested by running pods in platform namespaces.
+func namespaceUsage(pods []kubev1.Pod) map[string]Usage {
+	usage := map[string]Usage{}
+	for _, pod := range pods {
+		if !isPlatformNamespace(pod.Namespace) || pod.Status.Phase != kubev1.PodRunning {
+			continue
+		}
+
+		ns := usage[pod.Namespace]
+		ns.Pods++
+		for _, container := range pod.Spec.Containers {
+			ns.CPU += container.Resources.Requests.Cpu().MilliValue()
+			ns.Memory += container.Resources.Requests.Memory().Value()
+		}
+		usage[pod.Namespace] = ns
+	}
+	return usage
+}
+
+func isPlatformNamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-")
+}
+
+// loadBaseline reads a fleet baseline snapshot.
+func loadBaseline(file string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fleet baseline: %v", err)
+	}
+
+	baseline := &Snapshot{}
+	if err = yaml.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("error parsing fleet baseline %s: %v", file, err)
+	}
+	return baseline, nil
+}
+
+// compareToBaseline finds the ways a cluster snapshot differs from the fleet baseline.
+func compareToBaseline(baseline, snapshot *Snapshot) []Anomaly {
+	anomalies := []Anomaly{}
+
+	for _, name := range sortedKeys(baseline.Operators) {
+		if _, ok := snapshot.Operators[name]; !ok {
+			anomalies = append(anomalies, Anomaly{"operator", name, "ClusterOperator is missing"})
+		}
+	}
+
+	for _, name := range sortedKeys(snapshot.Operators) {
+		if _, ok := baseline.Operators[name]; !ok {
+			anomalies = append(anomalies, Anomaly{"operator", name, "ClusterOperator is not in the fleet baseline"})
+		}
+
+		// every operator should report the version the cluster is running
+		if version := snapshot.Operators[name]; version != "" && snapshot.Version != "" && version != snapshot.Version {
+			anomalies = append(anomalies, Anomaly{"operator", name, fmt.Sprintf("reports version %s but the cluster is at %s", version, snapshot.Version)})
+		}
+	}
+
+	expectedAlerts := map[string]bool{}
+	for _, alert := range baseline.Alerts {
+		expectedAlerts[alert] = true
+	}
+	for _, alert := range snapshot.Alerts {
+		if !expectedAlerts[alert] {
+			anomalies = append(anomalies, Anomaly{"alert", alert, "alert is firing but doesn't normally fire in the fleet"})
+		}
+	}
+
+	tolerance := baseline.Tolerance
+	if tolerance <= 0 {
+		tolerance = defaultBaselineTolerance
+	}
+
+	namespaces := make([]string, 0, len(baseline.Namespaces))
+	for namespace := range baseline.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		expected := baseline.Namespaces[namespace]
+		actual, ok := snapshot.Namespaces[namespace]
+		if !ok {
+			anomalies = append(anomalies, Anomaly{"namespace", namespace, "no running pods found"})
+			continue
+		}
+
+		for _, resource := range []struct {
+			name             string
+			expected, actual float64
+		}{
+			{"pods", float64(expected.Pods), float64(actual.Pods)},
+			{"cpu requests", float64(expected.CPU), float64(actual.CPU)},
+			{"memory requests", float64(expected.Memory), float64(actual.Memory)},
+		} {
+			if outsideTolerance(resource.expected, resource.actual, tolerance) {
+				anomalies = append(anomalies, Anomaly{"namespace", namespace, fmt.Sprintf("%s of %.0f differ from the fleet baseline of %.0f", resource.name, resource.actual, resource.expected)})
+			}
+		}
+	}
+	return anomalies
+}
+
+func outsideTolerance(expected, actual, tolerance float64) bool {
+	if expected == 0 {
+		return actual != 0
+	}
+	return math.Abs(actual-expected)/expected > tolerance
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func describeAnomalies(anomalies []Anomaly) string {
+	lines := make([]string, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		lines = append(lines, fmt.Sprintf("%s %s: %s", anomaly.Kind, anomaly.Name, anomaly.Message))
+	}
+	return strings.Join(lines, "\n")
+}
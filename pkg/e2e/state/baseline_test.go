@@ -0,0 +1,91 @@
+package state
+
+import (
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCompareToBaseline(t *testing.T) {
+	baseline := &Snapshot{
+		Operators: map[string]string{"dns": "", "ingress": "", "monitoring": ""},
+		Alerts:    []string{"KnownNoisyAlert"},
+		Namespaces: map[string]Usage{
+			"openshift-dns":        {Pods: 6, CPU: 600, Memory: 600},
+			"openshift-monitoring": {Pods: 20, CPU: 2000, Memory: 2000},
+			"openshift-ingress":    {Pods: 2, CPU: 200, Memory: 200},
+		},
+	}
+
+	snapshot := &Snapshot{
+		Version:   "4.5.1",
+		Operators: map[string]string{"dns": "4.5.1", "ingress": "4.5.0", "extra": "4.5.1"},
+		Alerts:    []string{"KnownNoisyAlert", "NewAlert"},
+		Namespaces: map[string]Usage{
+			"openshift-dns":        {Pods: 6, CPU: 650, Memory: 550},
+			"openshift-monitoring": {Pods: 20, CPU: 2000, Memory: 5000},
+		},
+	}
+
+	expected := []Anomaly{
+		{"operator", "monitoring", "ClusterOperator is missing"},
+		{"operator", "extra", "ClusterOperator is not in the fleet baseline"},
+		{"operator", "ingress", "reports version 4.5.0 but the cluster is at 4.5.1"},
+		{"alert", "NewAlert", "alert is firing but doesn't normally fire in the fleet"},
+		{"namespace", "openshift-ingress", "no running pods found"},
+		{"namespace", "openshift-monitoring", "memory requests of 5000 differ from the fleet baseline of 2000"},
+	}
+
+	anomalies := compareToBaseline(baseline, snapshot)
+	if len(anomalies) != len(expected) {
+		t.Fatalf("expected %d anomalies, got %d:\n%s", len(expected), len(anomalies), describeAnomalies(anomalies))
+	}
+
+	for i := range expected {
+		if anomalies[i] != expected[i] {
+			t.Errorf("expected anomaly %v, got %v", expected[i], anomalies[i])
+		}
+	}
+
+	if anomalies := compareToBaseline(snapshot, snapshot); len(anomalies) != 1 {
+		t.Errorf("a cluster should only differ from its own snapshot by operator versions, got:\n%s", describeAnomalies(anomalies))
+	}
+}
+
+func TestNamespaceUsage(t *testing.T) {
+	pod := func(namespace string, phase kubev1.PodPhase, cpu, memory string) kubev1.Pod {
+		return kubev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: kubev1.PodSpec{
+				Containers: []kubev1.Container{
+					{
+						Resources: kubev1.ResourceRequirements{
+							Requests: kubev1.ResourceList{
+								kubev1.ResourceCPU:    resource.MustParse(cpu),
+								kubev1.ResourceMemory: resource.MustParse(memory),
+							},
+						},
+					},
+				},
+			},
+			Status: kubev1.PodStatus{Phase: phase},
+		}
+	}
+
+	usage := namespaceUsage([]kubev1.Pod{
+		pod("openshift-dns", kubev1.PodRunning, "100m", "64Mi"),
+		pod("openshift-dns", kubev1.PodRunning, "50m", "64Mi"),
+		pod("openshift-dns", kubev1.PodSucceeded, "1", "1Gi"),
+		pod("osde2e-abcde", kubev1.PodRunning, "1", "1Gi"),
+	})
+
+	if len(usage) != 1 {
+		t.Fatalf("expected only platform namespaces, got %v", usage)
+	}
+
+	if dns := usage["openshift-dns"]; dns.Pods != 2 || dns.CPU != 150 || dns.Memory != 128*1024*1024 {
+		t.Errorf("unexpected usage for openshift-dns: %+v", dns)
+	}
+}
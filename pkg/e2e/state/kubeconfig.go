@@ -0,0 +1,218 @@
+package state
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/markbates/pkger"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/osde2e/pkg/common/cluster/healthchecks"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+)
+
+const (
+	// KubeConfigReportFile is the name of the report of the cluster's Kubernetes configuration written to the report dir.
+	KubeConfigReportFile = "kube-config-report.yaml"
+
+	// defaultKubeExpectations are the maintained expectations for each OCP version.
+	defaultKubeExpectations = "/assets/state/kube-expectations.yaml"
+
+	// kubeAPIServerNamespace and kubeAPIServerConfig locate the config the kube-apiserver operator renders.
+	kubeAPIServerNamespace = "openshift-kube-apiserver"
+	kubeAPIServerConfig    = "config"
+)
+
+// KubeExpectations is the expected Kubernetes configuration of an OCP minor version.
+type KubeExpectations struct {
+	// KubeVersion is the expected major.minor Kubernetes version.
+	KubeVersion string `yaml:"kubeVersion"`
+
+	// FeatureGates are the expected values of feature gates set on the kube-apiserver.
+	FeatureGates map[string]bool `yaml:"featureGates"`
+
+	// AdmissionPlugins must be enabled.
+	AdmissionPlugins []string `yaml:"admissionPlugins"`
+
+	// DisabledAdmissionPlugins must not be enabled.
+	DisabledAdmissionPlugins []string `yaml:"disabledAdmissionPlugins"`
+}
+
+// KubeConfigReport is the Kubernetes configuration of a cluster and how it differs from expectations.
+type KubeConfigReport struct {
+	OCPVersion       string          `yaml:"ocpVersion"`
+	KubeVersion      string          `yaml:"kubeVersion"`
+	FeatureGates     map[string]bool `yaml:"featureGates"`
+	AdmissionPlugins []string        `yaml:"admissionPlugins"`
+	Differences      []Anomaly       `yaml:"differences"`
+}
+
+// kubeAPIServerArgs is the part of the rendered kube-apiserver config that is reported.
+type kubeAPIServerArgs struct {
+	APIServerArguments map[string][]string `yaml:"apiServerArguments"`
+}
+
+var _ = ginkgo.Describe("[Suite: informing] Kubernetes configuration", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should match the expectations for the OCP version", func() {
+		report := collectKubeConfig(h)
+
+		expectations, err := loadKubeExpectations(config.Instance.Tests.KubeExpectations)
+		Expect(err).NotTo(HaveOccurred(), "failure loading Kubernetes expectations")
+
+		expected, ok := expectations[minorVersion(report.OCPVersion)]
+		if ok {
+			report.Differences = compareKubeConfig(expected, report)
+		}
+
+		data, err := yaml.Marshal(report)
+		Expect(err).NotTo(HaveOccurred(), "failure encoding Kubernetes configuration report")
+		h.WriteResults(map[string][]byte{KubeConfigReportFile: data})
+
+		if !ok {
+			ginkgo.Skip(fmt.Sprintf("there are no Kubernetes expectations for OCP %s", report.OCPVersion))
+		}
+		Expect(report.Differences).To(BeEmpty(), "Kubernetes configuration differs from expectations:\n%s", describeAnomalies(report.Differences))
+	})
+})
+
+// collectKubeConfig reads the cluster's Kubernetes version, feature gates, and admission plugins.
+func collectKubeConfig(h *helper.H) *KubeConfigReport {
+	report := &KubeConfigReport{}
+
+	cv, err := healthchecks.GetClusterVersionObject(h.Cfg().ConfigV1())
+	Expect(err).NotTo(HaveOccurred(), "failure getting cluster version")
+	report.OCPVersion = cv.Status.Desired.Version
+
+	version, err := h.Kube().Discovery().ServerVersion()
+	Expect(err).NotTo(HaveOccurred(), "failure getting Kubernetes version")
+	report.KubeVersion = version.GitVersion
+
+	cm, err := h.Kube().CoreV1().ConfigMaps(kubeAPIServerNamespace).Get(kubeAPIServerConfig, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failure getting kube-apiserver config")
+
+	report.FeatureGates, report.AdmissionPlugins, err = parseKubeAPIServerConfig(cm.Data["config.yaml"])
+	Expect(err).NotTo(HaveOccurred(), "failure parsing kube-apiserver config")
+	return report
+}
+
+// parseKubeAPIServerConfig returns the feature gates and admission plugins set in a rendered kube-apiserver config.
+func parseKubeAPIServerConfig(data string) (map[string]bool, []string, error) {
+	args := kubeAPIServerArgs{}
+	if err := yaml.Unmarshal([]byte(data), &args); err != nil {
+		return nil, nil, err
+	}
+
+	gates := map[string]bool{}
+	for _, arg := range args.APIServerArguments["feature-gates"] {
+		for _, gate := range strings.Split(arg, ",") {
+			parts := strings.SplitN(gate, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+
+			enabled, err := strconv.ParseBool(parts[1])
+			if err != nil {
+				return nil, nil, fmt.Errorf("feature gate %s has invalid value %q", parts[0], parts[1])
+			}
+			gates[parts[0]] = enabled
+		}
+	}
+
+	var plugins []string
+	for _, arg := range args.APIServerArguments["enable-admission-plugins"] {
+		plugins = append(plugins, strings.Split(arg, ",")...)
+	}
+	sort.Strings(plugins)
+	return gates, plugins, nil
+}
+
+// loadKubeExpectations reads expectations keyed by OCP minor version from a file or the maintained expectations.
+func loadKubeExpectations(file string) (map[string]KubeExpectations, error) {
+	data, err := readExpectations(file, defaultKubeExpectations)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Kubernetes expectations: %v", err)
+	}
+
+	expectations := map[string]KubeExpectations{}
+	if err = yaml.Unmarshal(data, &expectations); err != nil {
+		return nil, fmt.Errorf("error parsing Kubernetes expectations: %v", err)
+	}
+	return expectations, nil
+}
+
+// readExpectations reads a file of expectations, or the maintained asset if no file is given.
+func readExpectations(file, asset string) ([]byte, error) {
+	if file != "" {
+		return ioutil.ReadFile(file)
+	}
+
+	reader, err := pkger.Open(asset)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// compareKubeConfig finds the ways the cluster's Kubernetes configuration differs from expectations.
+func compareKubeConfig(expected KubeExpectations, report *KubeConfigReport) []Anomaly {
+	differences := []Anomaly{}
+
+	if expected.KubeVersion != "" && minorVersion(report.KubeVersion) != expected.KubeVersion {
+		differences = append(differences, Anomaly{"version", "kubernetes", fmt.Sprintf("version %s is not %s", report.KubeVersion, expected.KubeVersion)})
+	}
+
+	gates := make([]string, 0, len(expected.FeatureGates))
+	for gate := range expected.FeatureGates {
+		gates = append(gates, gate)
+	}
+	sort.Strings(gates)
+
+	for _, gate := range gates {
+		if enabled, ok := report.FeatureGates[gate]; !ok {
+			differences = append(differences, Anomaly{"feature-gate", gate, "feature gate is not set"})
+		} else if enabled != expected.FeatureGates[gate] {
+			differences = append(differences, Anomaly{"feature-gate", gate, fmt.Sprintf("feature gate is %t, expected %t", enabled, expected.FeatureGates[gate])})
+		}
+	}
+
+	enabled := map[string]bool{}
+	for _, plugin := range report.AdmissionPlugins {
+		enabled[plugin] = true
+	}
+
+	for _, plugin := range expected.AdmissionPlugins {
+		if !enabled[plugin] {
+			differences = append(differences, Anomaly{"admission-plugin", plugin, "admission plugin is not enabled"})
+		}
+	}
+
+	for _, plugin := range expected.DisabledAdmissionPlugins {
+		if enabled[plugin] {
+			differences = append(differences, Anomaly{"admission-plugin", plugin, "admission plugin should not be enabled"})
+		}
+	}
+	return differences
+}
+
+var minorVersionRE = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+// minorVersion returns the major.minor part of a version, such as 1.18 for v1.18.3+6c42de8.
+func minorVersion(version string) string {
+	match := minorVersionRE.FindStringSubmatch(version)
+	if match == nil {
+		return ""
+	}
+	return match[1] + "." + match[2]
+}
@@ -0,0 +1,68 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKubeAPIServerConfig(t *testing.T) {
+	data := `{"apiVersion":"kubecontrolplane.config.openshift.io/v1","apiServerArguments":{"feature-gates":["RotateKubeletServerCertificate=true","SupportPodPidsLimit=true,LegacyNodeRoleBehavior=false"],"enable-admission-plugins":["NodeRestriction","ResourceQuota"]}}`
+
+	gates, plugins, err := parseKubeAPIServerConfig(data)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+
+	expectedGates := map[string]bool{"RotateKubeletServerCertificate": true, "SupportPodPidsLimit": true, "LegacyNodeRoleBehavior": false}
+	if !reflect.DeepEqual(gates, expectedGates) {
+		t.Errorf("expected feature gates %v, got %v", expectedGates, gates)
+	}
+
+	if !reflect.DeepEqual(plugins, []string{"NodeRestriction", "ResourceQuota"}) {
+		t.Errorf("unexpected admission plugins %v", plugins)
+	}
+}
+
+func TestCompareKubeConfig(t *testing.T) {
+	expected := KubeExpectations{
+		KubeVersion:              "1.18",
+		FeatureGates:             map[string]bool{"SupportPodPidsLimit": true, "RotateKubeletServerCertificate": true, "LegacyNodeRoleBehavior": false},
+		AdmissionPlugins:         []string{"NodeRestriction", "ResourceQuota"},
+		DisabledAdmissionPlugins: []string{"AlwaysAdmit"},
+	}
+
+	report := &KubeConfigReport{
+		OCPVersion:       "4.5.2",
+		KubeVersion:      "v1.19.0+d59ce34",
+		FeatureGates:     map[string]bool{"SupportPodPidsLimit": true, "LegacyNodeRoleBehavior": true},
+		AdmissionPlugins: []string{"AlwaysAdmit", "NodeRestriction"},
+	}
+
+	want := []Anomaly{
+		{"version", "kubernetes", "version v1.19.0+d59ce34 is not 1.18"},
+		{"feature-gate", "LegacyNodeRoleBehavior", "feature gate is true, expected false"},
+		{"feature-gate", "RotateKubeletServerCertificate", "feature gate is not set"},
+		{"admission-plugin", "ResourceQuota", "admission plugin is not enabled"},
+		{"admission-plugin", "AlwaysAdmit", "admission plugin should not be enabled"},
+	}
+
+	if differences := compareKubeConfig(expected, report); !reflect.DeepEqual(differences, want) {
+		t.Errorf("unexpected differences:\n%s", describeAnomalies(differences))
+	}
+}
+
+func TestDefaultKubeExpectations(t *testing.T) {
+	expectations, err := loadKubeExpectations("")
+	if err != nil {
+		t.Fatalf("failed to load the maintained expectations: %v", err)
+	}
+
+	for version, expected := range expectations {
+		if minorVersion(version) != version || minorVersion(expected.KubeVersion) != expected.KubeVersion {
+			t.Errorf("expectations for %s must be keyed by and expect major.minor versions", version)
+		}
+		if len(expected.AdmissionPlugins) == 0 {
+			t.Errorf("expectations for %s should list admission plugins", version)
+		}
+	}
+}
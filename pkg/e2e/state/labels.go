@@ -0,0 +1,8 @@
+package state
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: e2e] Cluster state", "monitoring")
+var _ = testlabels.Declare("[Suite: informing] Cluster baseline", "monitoring")
+var _ = testlabels.Declare("[Suite: informing] Kubernetes configuration", "security")
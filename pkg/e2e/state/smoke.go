@@ -0,0 +1,28 @@
+package state
+
+import (
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/providers"
+)
+
+// smokeAlertsTimeoutInSeconds bounds the alerts query of the smoke suite, which includes starting its pod.
+const smokeAlertsTimeoutInSeconds = 120
+
+var _ = ginkgo.Describe("[Suite: smoke] Cluster state", func() {
+	defer ginkgo.GinkgoRecover()
+	h := helper.New()
+
+	ginkgo.It("should have no critical alerts", func() {
+		queryJSON := queryAlerts(h, smokeAlertsTimeoutInSeconds)
+
+		clusterProvider, err := providers.ClusterProvider()
+		Expect(err).NotTo(HaveOccurred(), "failure to get cluster provider")
+
+		foundCritical := findCriticalAlerts(queryJSON.Data.Results, config.Instance.Provider, clusterProvider.Environment())
+		Expect(foundCritical).To(BeFalse(), "found a critical alert")
+	})
+})
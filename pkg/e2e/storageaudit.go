@@ -0,0 +1,182 @@
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	osconfig "github.com/openshift/client-go/config/clientset/versioned"
+	"gopkg.in/yaml.v2"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openshift/osde2e/pkg/common/aws"
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
+	"github.com/openshift/osde2e/pkg/common/metadata"
+	"github.com/openshift/osde2e/pkg/common/state"
+)
+
+const (
+	// storageAuditFile is the name of the storage audit written to the report dir.
+	storageAuditFile = "storage-audit.yaml"
+
+	// ebsCSIDriver is the name of the AWS EBS CSI driver.
+	ebsCSIDriver = "ebs.csi.aws.com"
+
+	// volumeAuditDeprovisionTimeout is how many minutes to wait for a cluster to be deleted before auditing its EBS
+	// volumes, if no CLUSTER_DOWN_TIMEOUT is set.
+	volumeAuditDeprovisionTimeout = 60
+)
+
+// StorageAudit lists the storage left behind by a run.
+type StorageAudit struct {
+	// LeakedPVs are persistent volumes whose claims were deleted but that were never reclaimed.
+	LeakedPVs []string `yaml:"leakedPVs,omitempty"`
+
+	// BoundPVs are persistent volumes still bound to claims when the cluster was deleted. They aren't leaks, but their
+	// EBS volumes must be gone once the cluster is.
+	BoundPVs []string `yaml:"boundPVs,omitempty"`
+
+	// LeakedVolumes are EBS volumes of the cluster that still exist after it was deleted.
+	LeakedVolumes []string `yaml:"leakedVolumes,omitempty"`
+
+	// VolumesChecked is false if the cluster's EBS volumes weren't checked.
+	VolumesChecked bool `yaml:"volumesChecked"`
+
+	infraName string
+	volumeIDs []string
+}
+
+// Leaks returns every leaked PV and EBS volume.
+func (a *StorageAudit) Leaks() []string {
+	var leaks []string
+	for _, pv := range a.LeakedPVs {
+		leaks = append(leaks, "pv/"+pv)
+	}
+	return append(leaks, a.LeakedVolumes...)
+}
+
+// auditPersistentVolumes finds PVs that weren't reclaimed or are still bound and remembers the EBS volumes backing
+// every PV, so they can be checked once the cluster is deleted. It must run while the cluster still exists.
+func auditPersistentVolumes() (*StorageAudit, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(state.Instance.Kubeconfig.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("error generating restconfig: %v", err)
+	}
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pvs, err := kube.CoreV1().PersistentVolumes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing persistent volumes: %v", err)
+	}
+
+	audit := &StorageAudit{}
+	if config.Instance.Cluster.AuditStorage {
+		audit.LeakedPVs = pvsInPhase(pvs.Items, kubev1.VolumeReleased, kubev1.VolumeFailed)
+		audit.BoundPVs = pvsInPhase(pvs.Items, kubev1.VolumeBound)
+		if len(audit.BoundPVs) > 0 {
+			logging.Infof("Persistent volumes still bound before deletion: %s", strings.Join(audit.BoundPVs, ", "))
+		}
+	}
+	for _, pv := range pvs.Items {
+		if id := ebsVolumeID(pv); id != "" {
+			audit.volumeIDs = append(audit.volumeIDs, id)
+		}
+	}
+
+	if state.Instance.CloudProvider.CloudProviderID == "aws" {
+		cfgClient, err := osconfig.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		infra, err := cfgClient.ConfigV1().Infrastructures().Get("cluster", metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting the cluster infrastructure name: %v", err)
+		}
+		audit.infraName = infra.Status.InfrastructureName
+	}
+	return audit, nil
+}
+
+// auditVolumes finds EBS volumes of the cluster that survived its deletion.
+func (a *StorageAudit) auditVolumes() error {
+	if a.infraName == "" {
+		return nil
+	}
+
+	volumes, err := aws.ClusterVolumes(state.Instance.CloudProvider.Region, a.infraName, a.volumeIDs)
+	if err != nil {
+		return err
+	}
+
+	a.VolumesChecked = true
+	for _, volume := range volumes {
+		if volume.State == "deleting" || volume.State == "deleted" {
+			continue
+		}
+
+		leak := volume.ID
+		if volume.PVName != "" {
+			leak += " (" + volume.PVName + ")"
+		}
+		a.LeakedVolumes = append(a.LeakedVolumes, leak)
+	}
+	sort.Strings(a.LeakedVolumes)
+	return nil
+}
+
+// write writes the audit to the report dir and records the leaks in the metadata.
+func (a *StorageAudit) write() {
+	metadata.Instance.SetLeakedStorage(a.Leaks())
+
+	data, err := yaml.Marshal(a)
+	if err != nil {
+		logging.Warnf("Unable to marshal storage audit: %v", err)
+		return
+	}
+
+	path := filepath.Join(config.Instance.ReportDir, storageAuditFile)
+	if err = ioutil.WriteFile(path, data, os.FileMode(0644)); err != nil {
+		logging.Warnf("Unable to write storage audit: %v", err)
+	}
+}
+
+// pvsInPhase returns the sorted names of the PVs in any of the phases. PVs that are released or failed had their claims
+// deleted without the volume being deleted or reused.
+func pvsInPhase(pvs []kubev1.PersistentVolume, phases ...kubev1.PersistentVolumePhase) []string {
+	var names []string
+	for _, pv := range pvs {
+		for _, phase := range phases {
+			if pv.Status.Phase == phase {
+				names = append(names, pv.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ebsVolumeID returns the ID of the EBS volume backing a PV, if there is one.
+func ebsVolumeID(pv kubev1.PersistentVolume) string {
+	switch {
+	case pv.Spec.AWSElasticBlockStore != nil:
+		// in-tree volume IDs may be prefixed with the zone, for example aws://us-east-1a/vol-abc
+		id := pv.Spec.AWSElasticBlockStore.VolumeID
+		return id[strings.LastIndex(id, "/")+1:]
+	case pv.Spec.CSI != nil && pv.Spec.CSI.Driver == ebsCSIDriver:
+		return pv.Spec.CSI.VolumeHandle
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package e2e
+
+import (
+	"reflect"
+	"testing"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPVsInPhase(t *testing.T) {
+	pv := func(name string, phase kubev1.PersistentVolumePhase) kubev1.PersistentVolume {
+		return kubev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     kubev1.PersistentVolumeStatus{Phase: phase},
+		}
+	}
+
+	pvs := []kubev1.PersistentVolume{
+		pv("bound", kubev1.VolumeBound),
+		pv("released", kubev1.VolumeReleased),
+		pv("available", kubev1.VolumeAvailable),
+		pv("failed", kubev1.VolumeFailed),
+	}
+
+	if leaked, expected := pvsInPhase(pvs, kubev1.VolumeReleased, kubev1.VolumeFailed), []string{"failed", "released"}; !reflect.DeepEqual(leaked, expected) {
+		t.Errorf("expected leaked PVs %v, got %v", expected, leaked)
+	}
+	if bound, expected := pvsInPhase(pvs, kubev1.VolumeBound), []string{"bound"}; !reflect.DeepEqual(bound, expected) {
+		t.Errorf("expected bound PVs %v, got %v", expected, bound)
+	}
+}
+
+func TestEBSVolumeID(t *testing.T) {
+	tests := map[string]kubev1.PersistentVolumeSource{
+		"vol-intree": {AWSElasticBlockStore: &kubev1.AWSElasticBlockStoreVolumeSource{VolumeID: "aws://us-east-1a/vol-intree"}},
+		"vol-bare":   {AWSElasticBlockStore: &kubev1.AWSElasticBlockStoreVolumeSource{VolumeID: "vol-bare"}},
+		"vol-csi":    {CSI: &kubev1.CSIPersistentVolumeSource{Driver: ebsCSIDriver, VolumeHandle: "vol-csi"}},
+		"":           {CSI: &kubev1.CSIPersistentVolumeSource{Driver: "efs.csi.aws.com", VolumeHandle: "fs-abc"}},
+	}
+
+	for expected, source := range tests {
+		pv := kubev1.PersistentVolume{Spec: kubev1.PersistentVolumeSpec{PersistentVolumeSource: source}}
+		if id := ebsVolumeID(pv); id != expected {
+			t.Errorf("expected volume ID %q, got %q", expected, id)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package e2e
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+)
+
+// classBudgets are the time budgets of the suite classes in the current phase.
+var classBudgets *suiteclass.Budgets
+
+// specStarted is when the running spec started, to charge its time to its class.
+var specStarted time.Time
+
+// Charge the time each spec took to its class
+var _ = ginkgo.AfterEach(func() {
+	if classBudgets != nil && !specStarted.IsZero() {
+		classBudgets.Spend(specClass(), time.Since(specStarted))
+	}
+	specStarted = time.Time{}
+})
+
+// startClassBudgets gives each suite class a fresh time budget for a phase.
+func startClassBudgets() {
+	cfg := config.Instance.Tests
+	classBudgets = suiteclass.NewBudgets(map[string]time.Duration{
+		suiteclass.Blocking:  time.Duration(cfg.BlockingTimeout) * time.Minute,
+		suiteclass.Informing: time.Duration(cfg.InformingTimeout) * time.Minute,
+	})
+}
+
+// checkClassBudget skips the running spec if its class has used up its time in the phase.
+func checkClassBudget() {
+	specStarted = time.Now()
+	if classBudgets == nil {
+		return
+	}
+
+	if class := specClass(); classBudgets.Exceeded(class) {
+		ginkgo.Skip(fmt.Sprintf("test %s will not be run as the %s suites used up their timeout of %v", ginkgo.CurrentGinkgoTestDescription().FullTestText, class, classBudgets.Timeout(class)))
+	}
+}
+
+// specClass returns the class of the suite the running spec belongs to.
+func specClass() string {
+	desc := ginkgo.CurrentGinkgoTestDescription()
+	testContext := strings.TrimSpace(strings.TrimSuffix(desc.FullTestText, desc.TestText))
+	return suiteclass.Of(config.Instance.Tests.InformingSuites, testContext)
+}
+
+// specLabels labels specs in the JSON report with the class of their suite.
+func specLabels(texts []string) []string {
+	return []string{suiteclass.Of(config.Instance.Tests.InformingSuites, strings.Join(texts, " "))}
+}
@@ -0,0 +1,194 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+// serialMarker marks specs that must not run at the same time as any other spec.
+const serialMarker = "[Serial]"
+
+// listedSpec is a spec of the suite, in the order Ginkgo iterates over them.
+type listedSpec struct {
+	name string
+
+	// suite is the text of the spec's top level container.
+	suite string
+
+	// context is the text of the spec's containers, which suites are selected by.
+	context string
+
+	// runs is false if the spec is filtered out by focus and skip, or is pending.
+	runs bool
+}
+
+// serial is true if the spec must run while no other spec is running.
+func (s listedSpec) serial() bool {
+	return s.runs && strings.Contains(s.name, serialMarker)
+}
+
+// fullText is the spec's name, as it's reported.
+func (s listedSpec) fullText() string {
+	return strings.TrimPrefix(s.name, topLevelText+" ")
+}
+
+// counter is what Ginkgo's parallel iterator expects from the sync server's counter.
+type counter struct {
+	Index int `json:"index"`
+}
+
+// syncServer is the server the nodes of a parallel phase synchronize through. Like the server started by ginkgo -p,
+// it hands out the specs to run and relays the result of the BeforeSuite. Unlike it, serial specs are held back until
+// every other spec has been handed out and no other node is running a spec. Each node uses its own path,
+// /node/<n>, so the server knows which nodes are busy.
+type syncServer struct {
+	mutex sync.Mutex
+	cond  *sync.Cond
+
+	// queue is the order the specs are handed out in, as indices into the suite's specs.
+	queue   []int
+	serial  map[int]bool
+	total   int
+	busy    map[int]bool
+	stopped bool
+
+	beforeSuite []byte
+
+	listener net.Listener
+	server   *http.Server
+}
+
+// newSyncServer creates a server handing out specs, with the serial specs last.
+func newSyncServer(specs []listedSpec) *syncServer {
+	s := &syncServer{
+		serial:      map[int]bool{},
+		total:       len(specs),
+		busy:        map[int]bool{},
+		beforeSuite: types.RemoteBeforeSuiteData{State: types.RemoteBeforeSuiteStatePending}.ToJSON(),
+	}
+	s.cond = sync.NewCond(&s.mutex)
+
+	var serial []int
+	for i, spec := range specs {
+		if spec.serial() {
+			s.serial[i] = true
+			serial = append(serial, i)
+		} else {
+			s.queue = append(s.queue, i)
+		}
+	}
+	s.queue = append(s.queue, serial...)
+	return s
+}
+
+// start serves on a local port, returning the URL nodes use as the base of their sync host.
+func (s *syncServer) start() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	s.listener = listener
+	s.server = &http.Server{Handler: s}
+	go s.server.Serve(listener)
+	return "http://" + listener.Addr().String(), nil
+}
+
+// stop releases nodes still waiting for a spec and stops serving.
+func (s *syncServer) stop() {
+	s.mutex.Lock()
+	s.stopped = true
+	s.cond.Broadcast()
+	s.mutex.Unlock()
+
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+// next returns the index of the next spec for a node to run, which has finished the spec it was last given. Once
+// there are no more specs, the number of specs is returned.
+func (s *syncServer) next(node int) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.busy, node)
+	s.cond.Broadcast()
+
+	for {
+		if len(s.queue) == 0 || s.stopped {
+			return s.total
+		}
+
+		index := s.queue[0]
+		if !s.serial[index] || len(s.busy) == 0 {
+			s.queue = s.queue[1:]
+			s.busy[node] = true
+			return index
+		}
+		s.cond.Wait()
+	}
+}
+
+// done records that a node exited, so that serial specs don't wait for it.
+func (s *syncServer) done(node int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.busy, node)
+	s.cond.Broadcast()
+}
+
+// ServeHTTP handles the requests Ginkgo's parallel nodes make to /node/<n>/<endpoint>.
+func (s *syncServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "node" {
+		http.NotFound(w, r)
+		return
+	}
+
+	node, err := strconv.Atoi(parts[1])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch parts[2] {
+	case "has-counter":
+		w.WriteHeader(http.StatusOK)
+	case "counter":
+		json.NewEncoder(w).Encode(counter{Index: s.next(node)})
+	case "BeforeSuiteState":
+		s.handleBeforeSuite(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleBeforeSuite stores the result of the BeforeSuite posted by the first node and returns it to the others.
+func (s *syncServer) handleBeforeSuite(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.mutex.Lock()
+		s.beforeSuite = data
+		s.mutex.Unlock()
+		return
+	}
+
+	s.mutex.Lock()
+	data := s.beforeSuite
+	s.mutex.Unlock()
+	w.Write(data)
+}
@@ -0,0 +1,93 @@
+package e2e
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/suiteclass"
+	"github.com/openshift/osde2e/pkg/common/testlabels"
+)
+
+// labelFocus selects the suites to run by their labels. If nil, the suites are selected by TESTS_TO_RUN.
+var labelFocus *testlabels.Expression
+
+// SuiteLabels are the labels of a suite, and whether it's selected to run.
+type SuiteLabels struct {
+	Suite    string
+	Labels   []string
+	Selected bool
+}
+
+// selectLabeledSuites parses the expression selecting the suites to run by their labels, if there is one.
+func selectLabeledSuites() (err error) {
+	labelFocus = nil
+	if focus := config.Instance.Tests.FocusLabels; focus != "" {
+		if labelFocus, err = testlabels.Parse(focus); err != nil {
+			return err
+		}
+		log.Printf("Running the suites with labels matching %s.", labelFocus)
+	}
+	return nil
+}
+
+// selectsContext returns true if a test context is selected to run, by its labels or by TESTS_TO_RUN.
+func selectsContext(testContext string) bool {
+	if labelFocus != nil {
+		return labelFocus.Matches(contextLabels(testContext))
+	}
+
+	for _, testToRun := range config.Instance.Tests.TestsToRun {
+		if strings.HasPrefix(testContext, testToRun) {
+			return true
+		}
+	}
+	return false
+}
+
+// contextLabels returns the sorted labels of a test context, including the class of its suite.
+func contextLabels(testContext string) []string {
+	labels := testlabels.Of(testContext)
+	class := suiteclass.Of(config.Instance.Tests.InformingSuites, testContext)
+	for _, label := range labels {
+		if label == class {
+			return labels
+		}
+	}
+
+	labels = append(labels, class)
+	sort.Strings(labels)
+	return labels
+}
+
+// ListSuites lists the suites with their labels, and whether FOCUS_LABELS or TESTS_TO_RUN select them.
+func ListSuites() ([]SuiteLabels, error) {
+	testing.Init()
+
+	if err := selectLabeledSuites(); err != nil {
+		return nil, fmt.Errorf("could not select the suites by their labels: %v", err)
+	}
+
+	var suites []SuiteLabels
+	listed := map[string]bool{}
+	for _, spec := range listSpecs("OSD e2e suite") {
+		if spec.suite == "" || listed[spec.suite] {
+			continue
+		}
+		listed[spec.suite] = true
+
+		suites = append(suites, SuiteLabels{
+			Suite:    spec.suite,
+			Labels:   contextLabels(spec.suite),
+			Selected: selectsContext(spec.suite),
+		})
+	}
+
+	sort.Slice(suites, func(i, j int) bool {
+		return suites[i].Suite < suites[j].Suite
+	})
+	return suites, nil
+}
@@ -0,0 +1,53 @@
+package e2e
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/testlabels"
+)
+
+func TestSelectsContext(t *testing.T) {
+	testlabels.Declare("[Suite: e2e] Storage", "storage")
+	config.Instance.Tests.InformingSuites = []string{"[Suite: informing]"}
+	config.Instance.Tests.TestsToRun = []string{"[Suite: e2e]"}
+	defer func() {
+		config.Instance.Tests.FocusLabels = ""
+		config.Instance.Tests.TestsToRun = nil
+		labelFocus = nil
+	}()
+
+	if labels := contextLabels("[Suite: e2e] Storage"); !reflect.DeepEqual(labels, []string{"blocking", "e2e", "storage"}) {
+		t.Errorf("unexpected labels %v", labels)
+	}
+	if labels := contextLabels("[Suite: informing] Cluster baseline"); !reflect.DeepEqual(labels, []string{"informing"}) {
+		t.Errorf("expected the class of informing suites to be labeled once, got %v", labels)
+	}
+
+	if !selectsContext("[Suite: e2e] Storage") || selectsContext("[Suite: informing] Cluster baseline") {
+		t.Errorf("expected TESTS_TO_RUN to select the suites without FOCUS_LABELS")
+	}
+
+	config.Instance.Tests.FocusLabels = "informing || e2e && !storage"
+	if err := selectLabeledSuites(); err != nil {
+		t.Fatalf("failed to parse the label focus: %v", err)
+	}
+
+	tests := map[string]bool{
+		"[Suite: e2e] Storage":                false,
+		"[Suite: e2e] Pods":                   true,
+		"[Suite: informing] Cluster baseline": true,
+		"[Suite: operators] Certman":          false,
+	}
+	for testContext, expected := range tests {
+		if selected := selectsContext(testContext); selected != expected {
+			t.Errorf("expected %s to be selected: %t, got %t", testContext, expected, selected)
+		}
+	}
+
+	config.Instance.Tests.FocusLabels = "informing &&"
+	if err := selectLabeledSuites(); err == nil {
+		t.Errorf("expected an invalid label focus to fail")
+	}
+}
@@ -0,0 +1,98 @@
+package verify
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/helper"
+)
+
+const (
+	// autoscalerName is the name of the ClusterAutoscaler the cluster uses.
+	autoscalerName = "default"
+
+	// autoscalerNamespace is where the cluster autoscaler is deployed.
+	autoscalerNamespace = "openshift-machine-api"
+)
+
+var clusterAutoscalerResource = schema.GroupVersionResource{
+	Group:    "autoscaling.openshift.io",
+	Version:  "v1",
+	Resource: "clusterautoscalers",
+}
+
+var _ = ginkgo.Describe("[Suite: e2e] Cluster autoscaler", func() {
+	h := helper.New()
+
+	ginkgo.It("should reflect the configured autoscaler settings", func() {
+		cfg := config.Instance.Cluster
+		if cfg.AutoscalerMaxNodes == 0 {
+			ginkgo.Skip("the cluster autoscaler is not configured, set CLUSTER_AUTOSCALER_MAX_NODES to configure it")
+		}
+
+		var (
+			interval = 30 * time.Second
+			timeout  = 10 * time.Minute
+			mismatch error
+		)
+
+		// settings applied through the provider take some time to reach the cluster
+		err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+			autoscaler, err := h.Dynamic().Resource(clusterAutoscalerResource).Get(autoscalerName, metav1.GetOptions{})
+			if err != nil {
+				mismatch = fmt.Errorf("couldn't get ClusterAutoscaler '%s': %v", autoscalerName, err)
+			} else {
+				mismatch = checkAutoscaler(autoscaler, cfg.AutoscalerMaxNodes, cfg.AutoscalerScaleDownUtilization)
+			}
+
+			if mismatch != nil {
+				log.Printf("Waiting for the cluster autoscaler to be configured: %v", mismatch)
+			}
+			return mismatch == nil, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "cluster autoscaler was not configured: %v", mismatch)
+
+		deployment, err := h.Kube().AppsV1().Deployments(autoscalerNamespace).Get("cluster-autoscaler-"+autoscalerName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred(), "cluster autoscaler is not deployed")
+		Expect(deployment.Status.AvailableReplicas).To(BeNumerically(">", 0), "cluster autoscaler is not available")
+	})
+})
+
+// checkAutoscaler compares a ClusterAutoscaler to the configured settings.
+func checkAutoscaler(autoscaler *unstructured.Unstructured, maxNodes int, scaleDownUtilization string) error {
+	actualMaxNodes, _, err := unstructured.NestedInt64(autoscaler.Object, "spec", "resourceLimits", "maxNodesTotal")
+	if err != nil {
+		return err
+	}
+	if actualMaxNodes != int64(maxNodes) {
+		return fmt.Errorf("maxNodesTotal is %d, expected %d", actualMaxNodes, maxNodes)
+	}
+
+	if scaleDownUtilization == "" {
+		return nil
+	}
+
+	actualUtilization, _, err := unstructured.NestedString(autoscaler.Object, "spec", "scaleDown", "utilizationThreshold")
+	if err != nil {
+		return err
+	}
+
+	// the threshold may be formatted differently, such as 0.5 and 0.50
+	actual, actualErr := strconv.ParseFloat(actualUtilization, 64)
+	expected, expectedErr := strconv.ParseFloat(scaleDownUtilization, 64)
+	if actualErr != nil || expectedErr != nil || actual != expected {
+		return fmt.Errorf("scaleDown.utilizationThreshold is %q, expected %q", actualUtilization, scaleDownUtilization)
+	}
+	return nil
+}
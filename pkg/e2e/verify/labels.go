@@ -0,0 +1,12 @@
+package verify
+
+import "github.com/openshift/osde2e/pkg/common/testlabels"
+
+// Labels of the suites in this package, used to select them with FOCUS_LABELS.
+var _ = testlabels.Declare("[Suite: e2e] Cluster autoscaler", "nodes")
+var _ = testlabels.Declare("[Suite: e2e] ImageStreams", "images")
+var _ = testlabels.Declare("[Suite: e2e] Pods", "workloads")
+var _ = testlabels.Declare("[Suite: e2e] [OSD] Prometheus Exporters", "monitoring")
+var _ = testlabels.Declare("[Suite: e2e] Routes", "networking")
+var _ = testlabels.Declare("[Suite: e2e] Storage", "storage")
+var _ = testlabels.Declare("[Suite: e2e] Validation Webhook", "security")
@@ -0,0 +1,69 @@
+package verify
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/osde2e/pkg/common/helper"
+	"github.com/openshift/osde2e/pkg/common/util"
+)
+
+// smokeScheduleTimeoutInSeconds is how long the smoke suite waits for its pod to be scheduled, so the whole suite
+// finishes within a few minutes.
+const smokeScheduleTimeoutInSeconds = 50
+
+// The smoke suite is the fastest set of critical checks, used to sanity-check an existing cluster with osde2e smoke.
+var _ = ginkgo.Describe("[Suite: smoke] Cluster", func() {
+	h := helper.New()
+
+	ginkgo.It("should serve the API", func() {
+		version, err := h.Kube().Discovery().ServerVersion()
+		Expect(err).NotTo(HaveOccurred(), "failed getting the API server version")
+		Expect(version.GitVersion).NotTo(BeEmpty(), "the API server didn't report its version")
+	})
+
+	ginkgo.It("should serve the console", func() {
+		for _, route := range consoleRoutes(h) {
+			testRouteIngresses(route, http.StatusOK)
+		}
+	})
+
+	ginkgo.It("should route traffic through the ingress", func() {
+		testRouteIngresses(oauthRoute(h), http.StatusForbidden)
+	})
+
+	ginkgo.It("should schedule a pod", func() {
+		pod, err := h.Kube().CoreV1().Pods(h.CurrentProject()).Create(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "smoke-" + util.RandomStr(5)},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:  "smoke",
+					Image: "registry.access.redhat.com/ubi8/ubi-minimal",
+				}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred(), "failed creating a pod")
+
+		// only scheduling is checked, since pulling the image can take longer than the check allows
+		err = wait.PollImmediate(2*time.Second, smokeScheduleTimeoutInSeconds*time.Second, func() (bool, error) {
+			current, err := h.Kube().CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			for _, condition := range current.Status.Conditions {
+				if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		Expect(err).NotTo(HaveOccurred(), "pod %s/%s wasn't scheduled", pod.Namespace, pod.Name)
+	})
+})
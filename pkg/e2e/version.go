@@ -9,6 +9,7 @@ import (
 
 	"github.com/Masterminds/semver"
 	"github.com/openshift/osde2e/pkg/common/config"
+	"github.com/openshift/osde2e/pkg/common/logging"
 	"github.com/openshift/osde2e/pkg/common/metadata"
 	"github.com/openshift/osde2e/pkg/common/spi"
 	"github.com/openshift/osde2e/pkg/common/state"
@@ -25,7 +26,7 @@ func filterOnCincinnati(installVersion *semver.Version, upgradeVersion *semver.V
 	versionInCincinnati, err := upgrade.DoesEdgeExistInCincinnati(installVersion, upgradeVersion)
 
 	if err != nil {
-		log.Printf("error while trying to filter on version in Cincinnati: %v", err)
+		logging.Warnf("error while trying to filter on version in Cincinnati: %v", err)
 		return false
 	}
 
@@ -131,14 +132,14 @@ func setupVersion() (*semver.Version, error) {
 			defaultIndex := findDefaultVersionIndex(availableVersions)
 
 			if defaultIndex < 0 {
-				log.Printf("unable to find default version in avaialable version list")
+				logging.Warnf("unable to find default version in avaialable version list")
 				state.Cluster.PreviousVersionFromDefaultFound = false
 			} else {
 
 				targetIndex := defaultIndex - cfg.Cluster.PreviousReleaseFromDefault
 
 				if targetIndex < 0 {
-					log.Printf("not enough enabled versions to go back %d releases", cfg.Cluster.PreviousReleaseFromDefault)
+					logging.Warnf("not enough enabled versions to go back %d releases", cfg.Cluster.PreviousReleaseFromDefault)
 					state.Cluster.PreviousVersionFromDefaultFound = false
 				} else {
 					selectedVersion = availableVersions[targetIndex].Version()
@@ -158,7 +159,7 @@ func setupVersion() (*semver.Version, error) {
 			if state.Cluster.EnoughVersionsForOldestOrMiddleTest && state.Cluster.PreviousVersionFromDefaultFound {
 				state.Cluster.Version = util.SemverToOpenshiftVersion(selectedVersion)
 			} else {
-				log.Printf("Unable to get the %s.", versionType)
+				logging.Errorf("Unable to get the %s.", versionType)
 			}
 		} else {
 			return nil, fmt.Errorf("error finding default cluster version: %v", err)
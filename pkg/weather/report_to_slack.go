@@ -3,6 +3,8 @@ package weather
 import (
 	"bytes"
 	"fmt"
+	"log"
+	"reflect"
 	"text/template"
 
 	"github.com/openshift/osde2e/pkg/common/config"
@@ -29,6 +31,22 @@ func init() {
 	if err != nil {
 		panic(fmt.Sprintf("error loading slack job template: %v", err))
 	}
+
+	config.OnChange(logWeatherChanges)
+}
+
+// logWeatherChanges notes changes to where reports are sent and what they alert on, since a reloaded config only
+// takes effect with the next report.
+func logWeatherChanges(previous, current config.Config) {
+	if previous.Weather.SlackWebhook != current.Weather.SlackWebhook {
+		log.Printf("Slack webhook changed, the next weather report will be sent to the new webhook.")
+	}
+	if previous.Weather.StartOfTimeWindowInHours != current.Weather.StartOfTimeWindowInHours ||
+		previous.Weather.NumberOfSamplesNecessary != current.Weather.NumberOfSamplesNecessary ||
+		!reflect.DeepEqual(previous.Weather.JobWhitelist, current.Weather.JobWhitelist) {
+		log.Printf("Weather report thresholds changed: looking back %d hours for %d failures of jobs matching %v.",
+			current.Weather.StartOfTimeWindowInHours, current.Weather.NumberOfSamplesNecessary, current.Weather.JobWhitelist)
+	}
 }
 
 // SendReportToSlack will send the weather report to slack
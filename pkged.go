@@ -9,4 +9,4 @@ import (
 	"github.com/markbates/pkger/pkging/mem"
 )
 
-var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7d69739bbabfff5b3993a7b76d10182764e6ffc0d8069b185c0b2481eedcf90d5b0d46608ec1eb9dfbdeff035e933869dae3f46c66a68d11929084f4d177d7ffdec4d9b76971f3f0bf37e3b88ce6de177f9ade4ef3302ba2f85b793b2d82900fabc79d7876f370731b4dd3f0761286dfd6b7e3e96d31f36fdf2af7e9a69fe6d359f9d52da39b87375ff1e9c670d3f0e6e1e670df99fa87dbdfca282e7efb16b3f0b770151765f15b39fdad08cbdfe6f96f79320e675f6e3edd58ee6c1c962f5b9927e35b1667f3d57fdc346836de6af117f7e6d30d9c4e5fd672f3e946774b3fba79f8ef9b2f37fff3e9c62c5d16de3c94b379b8bb81a15b4cb39b879ba2bafb2d08f3300bc2cc5f3ffc76f2cad49d259e5b86c56dddf09b4f37ea548959585435e7ae9fb8e3f0cb785abd623b78f583372af89f4f379d30af7379f36f71d5526f5d86c5cda71b7f9ae6b3b0286ebf31b70c4f13c69b38afefb3d28db37076cbe2a2dc2584abfad76c9d97d3c38f5b775be3f6c68ff3a86efbee3e387d1814eef126f49fde06bc2802e945c26d9c95e12c73d96d182cdd59503ccfc6589c97b17f4c8952f7e4ee507ce666c1bc8cd99947c5dc2b59787c9006e2f1a62a7772e7374e6e4e3b50442e7872c78bcd27f722e04fee9fbdb26427e3b41239e9e9dd6d9ec4ab9b4f3761e64f83381b9ffcbc758b0c9cde7b6e11361b4f52e2cc9dad4f53a2f0b4b6db49353d4feef330ad6e67b3e9ac6ad6b7b4faee27336d3cf5e6dfbeb96c7a1b85b3f0e9b397d3f8f587c74f90ba79f176d63c196f3bfedd3cb745194cabda22b788767f6efd992f54e37f7863b5145c363e4df2f3f9e9edb7b42ca6b3f234290bcb72e6fae169dab4a807ea34299f32767affbcc82cfcc642bf6471f924b988b3310bbfb1781c3d796bb12e7c97b1db7015fa61b638f7689ed5f3e3905e8645c9a675efa6f57fb7f17437fbb7c96985bcdb3fb75e3c3efe2c8bfdefddcc4fe334dcfdb94de7ac8c73b71e943ae1f7f9b40c837c1667a5ebd5133a0bcbedffb75159e6273febfff6a37748dcb7789756614c3e9bd6f852ddcf67d593fa6b4e8b7a006e3edde4dbb6577f6e2be8dfddef46b5fe350e57f9e1c76db1ce4ab71a9fd93c2bb7ddd9fdbaf56b04dfdf1dc6cf2da7690d2a2f9eec06ee457ab1ae1ab99b304539f3a7f5972aca599c8deb47ebccdffd3956bffb7e379f6e76ed9a67b13f0d4e7eddcecb6fa0f9f4febebe2ddc6f55be459805d3d9ed78cadc6cfc653a1bdfae6ef7681cb97ee4f2dcfb72e553b60602277e2777fda75a3defcdb747a8b732cf678b708fec6fe48b92e0dbdb395e82fa1b99bfd3e36a02065951fd4bc3a270c7af55f7648a8fe7f512fa6ebe7c365dadbf9391bf8daa9dff8d5c7190b9af3c2ed6c50ed2ce3dad56da6d11faf35978ebc5413c9bbf3a5a75d672e666c5b7e92c7d2bd37e8e5615be275f56d5f73f9f6eacb0280fd44e36676c9b74a073b649fa34a81af9f0bf37efa21b7537cef674d84f52a9ea549f063f5cf0763cfd924e83ba3c0e67455c137fe00b106efeeffffeefd3cdb76d3fdf24ad1f6edda208cb3a5b458a577f83b074635627655b9a7897e7d34d116fc29b870627353fdda4157e3cf0a071d7b86f8006a853fe53e3cbc30dcff1dc674efc0c1a16683cf0fc83287d69dcdf81a628099fb9c6035781455cfc27a8066c3b7615b255847eb8b879683605eefed34d3f9bde3c081ccf37b9e6a71b83c55972f3705f7fa1f0e60134efefee3edda038b879001cc77dba518f3fedfffc277703eee681fb740383aa4eeed38d79d27c9925c593dba99f1475edad324eab9698a17ff300c47ba971cf89f7dca71ba3a8524451027777dc3df77f9f6ef47359efa47dd67d8fabacedf767b5fff39f79362fc2e0e6e1bfb94fdc27ee7feaef5951bc574ee80927f4563fdee691bc79cc82dffa9ddfd2b848ebea5e63843edd54fd0a9ef3445bacd8723e4728f997a0cb8e38aa3a918cdf3b9ff650f37f9f6e02b774f70392bbb3302b8f751d0bd42f7a0778dd7ee998ff31cbe92c7c1bc68ed9f648d6048dfb3d923578ee0c84099f79d102c243437868dc7fe100d76870d2dd4b0cfbe6b2e2bb2076770031b007314100f7f73f0162db96bf0262a07916c500e00e7803245e6c823bae7906c54420def3fcfd21ebaecf6740ecf59c97c7b0dbc32ef404ca8ed3e5f07c8b57a73874c492237a6c67df0e22769fef29469c42c236f775f5ffd1d57fb2560f3870d3aa2ea401dae9b65a727dd7affeebd63fdb6aebcd6b7cc8ffcad53efeac6bacde306ab5e4a2a5deb79c919cb47a068f966fbfe5fc55d7a7b57a7ecb5bcaeb965ab4bc96bc68a9dd166dc99b80196b2f5d2dbcd4dfbff77a5dafeb75bdaed7f5ba5ed7eb7afd5dafd1fec778f0e736e47a5dafeb75bdfe65d7e8c0d9cbad031c778fecfee890281f13bba7c0bdbd978ff5ed8408f231b17b942c8c0e89f231b17b64ea478744f998d83d0a26468744f998d83dca04468744f998d83dca35e0e1977c4c545e199ceb75bdaed7f5aaafcefe47b75554f051834634fe53db74bdaed7f5ba5eff844b96bbd0825b52ed7b3a9e9a0ead736eb55a75fe13d5937caca37b546b8d0e89eda7c55b87e757daf57a5dafebf5f7bdfedfffbbb98835901b04d3ec7b168ddb3c3f6cd1d8f8cc83da1ca8f9d0b8fbd2e0eeeff9fb7b5efc2993c6fb833510ffcb4d1a05a921718d83dd4e936b34efb97bfe8c31d05d43027c6db9b4b553dc77f98c35d01b59af268d5793c67fb051d30e4e2e6fd9b8ad78fbe7f36c9e65e1ec4b19a679ed4ff77d847b51640f78e01e7cc7f0f1bd48f77dc347ee5e6c5ecef071dbf21fb3de6e5658be05254992ee41833b6bf7f85785ba67b3ec7503c8fdf3ab01e45f1e2b5e59d2479b488757b87e67798f39c934b9d5d7111a8dbfc6b2e009dacc53a588b645d121a068a7cad2c594f999917b7ca3d957b528508de9407056edb4ccbd74d4ec77f38533ce4b6ac388aa0ae758d3c77e5b9e3b04b0612c4754850b2f061cb50dce5fe61b5fc593e1783aeef7e4c84f95c25371e1da46398c5bab76dc1a3bbc54faea8a052a5b7899deec77ba557d9123c03c4871971225f15436a7d8600e2fcd694f6ff67be5dd80c1dc237811d850fa369a8eabb63a7cb9a029d55d02f2a0331debadeabd9079b65c383664753bdaadb12fc8ccd954ed6e6def79bc0e5236a1489938bc04bc6cb47b87c1fc8ce60e8f658737160111b96f36772857b5275095dc4bf1da3fa96f60be361edbf7d7ff54563a24a8f2dc856b51f38892511b48d598ecf304a9540404308b546d829b7dfbb7ffb8b1b74d1f39369ceeeaf91ad87019d8b0ebdada765cdac7fabc5429a9351d072ade046db0d8e61d9d6bf732b08de9c0d6982fe022e8e98fc77ae4bc6eab351d874231473dbc71957d3ea3e3f122e71036a7cfdfaf1a0b8f80a89e434ab17052361f08c674d06e2dfcaa8e365853db005e0f6e06820c7c7e5cfa29de0464c5f96b71e32adc691b788f5f018f60c313f03ce8e9cdd3711b987259a72b7214a8e3edb74c2410f464107461ee674fc7f964fed6df694076795b6f8df7cb76bf52e7ab63b9ffbeafcc9dd7dba2e2d2ef4111aa6c5d7df793712efbaa18790435fbbd6763b87cf62d529a530e447e67fa783a47be5f5eaefacb39b6c621552a5c6288c3581e529b327f9cdb16d006565792616bfab8c71da460cb54241362035b0ab4fa9dc6f8c537cf8c693bd6a38180377e2cc52e692c2811f96a5dfbfcf8b19de45f474c1a59dc48b2dba3a9c7eb63ca63aedf9317b4a78f0764397653291e90bafd924360e2f18db29eebf5f37bc9cd8c8597d57342d28462eeda1536189eae70738f60ce21300ad4eebc5e2736973d8e72e6f0d1a2dfd6d46a3ed1b6fce8f1fd3152a56418cbbed63db4296e67c1d4252bd66f77c74fdad67e92af6ac7f8793b7c0146410f6f0669950e239788cc67d51843e0f3784336f7d3515bace7c5578b8b9ff52d7ec4dcb60e154bda5abeefabc19adaadf1c0ee566b7ded1136aff08f768a3155efc73ebf62d573ddaaeea5391de5a927f4c7ae29e75e2cdfe966636ed8dc5d5f858bbe2a4dfa3d6d131271726e1d0dd68db1d68ea69e6070f5b89949b3c2054bc09cdfc35c3547bfd9dc588b9db19369cc21c55dbf671494e065bfd35d0ed772e2f1226b3319232efa86126c61b44268b99b3fbd60e1a765e1f14a32c858e491e52efd0c46b4a68fd5d83b82c6aafe1ee6390f22bffd621d8e072648fd542a0784e69e8aa4b373729c2f9cb51cf93db908cd5649cfed9bfb6f5f8d951ab0a02dffeef1fdc57be6e0fedb7f33fdf1b7b69cf9295ef6db60d5579549a0329e5aaf7db3e9a347cac4b5fbe381dd1af7b333df863f994fc96a41053d3e5d475a3baafa96517b34f65529f1d7add2dbb6bddcee15e0e9fe553ddf80df7d5e9afbaac2b91d2e0bd7621e5478048aa56d8a47bac0dcced76fa69fb7d36a5ceaf78f9fae057dfc7c8cbec6adf8ccfc599ceeb5962a65fefa3e09b97d5d501a1025a9c6656043e60b703348570bbaeed7f3c1abf3b7123391da3690bfc276ab74d62fe7c34fbfdb7e31170b8ff7e3cbc8a0f2d9741107e1ec3b62a863b6b72451e2dd5bbeb5c203277c119b773c772f88cd1f9444897ce34e6cf207f64c38914449c22f73ae15f78c5453003c5f7168af39d7de0b073fdc7d975f73ae3d9ff52a89ba4aa2fec1dce511512e2f8c3ad47d9b4efde46d64ab73fc2150bbfbd21024be71dfe41a3f056ae259f1faaf0335e91031a02949fc3ddfb83b2773da22d55190b4eff2aba07636eb15d4aea0f66f00b52df07c34b2dd26732ff4a7d9b778fc36c89de4db439dd8007707c1bad07c1be304e14b9317449e1304f0a382f52dc8dd9f13acf35ce327406edbf21f8b28f05ec97a9d151ce8b143a75f41b957b27e9c64fdd9047b55c2be7b7a95afff6dc0e2742d9f08d76d39c7295efb3c5b7893e93898741f1d7e057c01323fd39bed981b1fee27b5b07c426d8d7309cd1d5ee1a809a2c086534fd0f2a097943ba1fad852f465d05d593a324c4ce02356591b2a1a3119b630ce1f9125ab186b96de833adac8dc882f562633b035c126446217e1e0112ad3259c181646799b4ce46204148c120db95c3e772c43f5f8eedaeb465f896a0888942a4af525493413226a63853e5ae968899966635cfda32ac49a437bc1c4c39a837820982059eb1d258640ebe3545c5a4cf91d316642a63dd24e6b053175dcae64428c05ac50d34ff1ccc041ac9348c1095ae90062c4288396b2420cb62d467b4187224fc979da915d8c35821211ba80f611862a661af4bb5a955f27180e31af105f6196c7688439fae88364e37721d4bb142110251063e2754b4b9fb0088160aab36469200d634c3b88acd411d37a54154d9c686d94e021541402330af514ce11c38f23068995681d2fc96d8c22e22bc6efb40b127dc26c8b949c89a16d6618eb9c06298a4c3f01aadf0d98d793670e676c0c044a948ad0e568ee58ca42e7fb4b9cc996bb51448b45aac10caca3e82b56b4d242d4b578d8c3199c84091b200ead4ce6aca16544ae1088144318b040b158c420d32c92b28d9e2abfc324c038c91507e00431a3707b0c7a5d5a5aa9a85a58eb0c8912637e555a8016a14215bd1718388d348b0bb09e2ac4ed328c370c5145591844534d9bc59e5a008b45c4657436242b57e7348c586404002a248b88871ac001f0112ad4b112cc3c24eab8a32c310808b6e4afa682d7b84b333de196be4063af2be63889125d514a73821398d095c3af4cc419a52b0413b39b238ca3425792b5359191a94623aaf88d00685db78327d892d70e29138fb1d24c5611ee6a3ac5b8f03851d15199e80ad51d1eb826eb6f4c4ba13abf5a391b6de929fa3a5060a21358a0898275a088c36e696201022b2d1f7546bba3441ae813dc73269aa2ab8a03531061d458d36e3431ba62d3625aa463fa681191401523624711b4e5b545ca86a5b0dfadb41ce8d577530141486c7abddcc2c0d958091c86442ba8aa0c47b64cad8e6cf8cc98c309d575462dc4e724500d05319979ea3d57ad671d6818261ac596b2c20a1ec274b444a9b85d7f246f23ac1098305defe66d8cb13962d365d0d310468843135909156aa38456e3d119f189a8038c4922426c291d84036aa8a325aad6b32debce44599aa9d227698e5c8e9a88e1c608600c13ecea29542b3cb098569a1974cd6eae12161456222996a520a842815806d49188698f1a9013170e0f662648365461264ca18eb2fcd162c1efd8328698448e651953428cde1005064cb4ae450ad15328f104cdd53368612226beaa2856ca8666afb5413ce07022f586563d264b4c4ac14f15db40d4c428ef3808534381d0ef465827a3156678a183e0779861a2e39c471c127d05ce749427985f2e09f34517c0394c4557671aa4184f82546b123b98c034721d464d9c144b6b62c438852e4957aeae424c6c3a81fcaae190d2f4555c866adef17af2083338f3993133593ed06dd9b0101e624e9ce948c37a9703240538e0b8cd88b1af664f9e5b286a1306556807a647a225c10121aa8250921bd0d6d79665b40da2608b51aab37c53d5071567a9f722030236c0dda8ed1265e66cb4c8b3e5479c048a9f888ad7a5a667472b4c56aec7e33eb420f4b0b6a05d3cb594d1caca98a1f7e406eec850ef16ebb09747bad25fa2896cea041764a2c5b02b8eac74d5d639f0bb65691427a285bbca3254303653c5861b46ad5e5e7840b361620c75d55853053e0638efc3c4303c75b57026cad4c146132510433e1230c95597699da0072dcc72de4a574b0ff737e6441e624b51d0446e380854fb11c41d7970dcef28728192214b260850c5eb061646621b2718d7cfabfd8edcd70abcade216af87b13c0d7a70e96fa68bc1a6bb36d68de560d29aebd694332c7fa9d70ae29d624bad15d98f5ba12e6e6c9587dcf870dfd9eed15e0f6f02b5ae7be61231a9de17d846b5878f5d01c7d4d6e6d486cc5f8388aa20f7c64fde019c14afbd1473d4d64b87c0d22562cbe5d99cb6f249606b6b4a446e406a013d0b7ba3ef95a9db308ce58ec78ba94bfc47bfa7319ae2aa9e09b56ba5b8d4cef086dada66389e966fb7a551f51154e3b7572a3bfc2adfb589f919cc69ca268e0dd960ab146bf6f763bf513a88e1c464301f25d0c4498e11c0aec9ba4bc8b059d122db6f69546bcfc288e31c52567bab8db2c8d5bbce06816a6f9d2ebd6e4e89a2e938c18f08183d73624cf449b2410955093366418fda2ebf5a50150c2d50611f4510e526c25102015530c308a35cdd611fc6a884448d8668a32921a3a5a72a36b45b2bccafa688d77ad684420c688ff6f2b6816135f70c6c2918e3686a014dc19682484fb609c6aece81bed7150944b851d12e28c58a81b089153aa464b574958a76d1d8c8967b94942a064a49bb1271b9bced4c1413126d6674141a26a2e524c12326461fb1dc861614aaf6983c9ee9d888aabd83769402625a40a699103b1c22e2a30b9ca5df33a83e69ad9c24304d85cefd2e20ba92af473c7824aad6afd7295975100896230667662a463a47178ea5156657721c21803b6c77474cc14304214e108730aa69453381aede835d54d176acc222cd22dd1c3a20780cd5ee866438f6488410c086c9490a4e81eb258a505157a3d4b0fd8d6662212a2c92bb8612584304b0a9241b44c0a383d9efb4cb6228c0154a73136263662686ae67f2dcd918ed806b54b4e510a790c3dda011305ad29e42f5497763a5f994e0bca2f522cf8e9654a124c0185929433a89560e80990e18b1b220826a945b083eea2070e8061353c99b0e880a9dd74a9331037769d799b400e6c47c88344367f988e0c0743950ba1bd9d0710e090e1284b52edd281dcf1a6d1cbe1cfa4a7f69a68cea13d8a42a071c24966682233d5d11c4e1255614e275038a19cd492a4ead54b349a6c4102b02ae9f53956e8c0463cda0041488e5b30a0b893a5a21921723a6a928cd276e6a0c2c44878162a8a3ac9a9fce12235d24292498191368610e7370a22bda1c279ae5d9721ff3c5d25072c54c998d37518392820bbb6086b368a823ed112bba48185a1a38c2fa84210ce814036a0d916163449716a72c74a2d9283320e6c401c6d1d052b552b730d65997c300120bc31eb23418b21c5a091e7a8ab32215769362e37050d6717f69d8b905597f4551a4f8a9863183a6d9d5161841e2624adc9e61611235919d8890337ef7bad0401b36a41db980352da341cf4a00b18cc40454814c861e815f715a4e51222966a2b96e1aed6891eed24c234854a8eed78b69073144799b768c042ad395d7cd2d9d872e22e523621453aec203f8483bb2895880fdee2ad66df9d14191eb3238abd61bb6a30ec6588578baa65d5aad97214a459360d827b636819cc321ab0530af61c298ab4f141b4f8c4797e55db7970f7486004ae8a3838a15152844b60c08c64384961b5f150dcc714b8ae9d26050a9c65107f42be213112af9cc64d430156d8d2da53094c0a63dfa15aa3a479280430894a6254768c36c6c69262106f27a46642ab982187d841cb7f17acc0a6d1d54bc1f44e2efb4a70cdd0d06980b1aa10a31b60ceaa99022e603942a33c33626613a0298140d43d1577082277a168d489ad3102b3362330449a43a444c105190db0b50c8f2c821a50a31ed8e583ec46a64e38e3631012c8d0ec4a10d9b4e5a1696aaf45c05db9e0d2d9c968f18745770625037c9558794ae870d716847919ee02649e014b1fe1266300ad35581d3920b13d0b4583ed189d4409b3e172209c16435d1b9fe8662bc1ca530f37bd8845c4e10a60556020726d8f470b2b648b132ab41b719f2128777264666285a815834d03b788e71851fb98353c5c0209f3b442c3c80bb24650476e90263fc88b18146769ec034821608a61666c8b4b48ea7ea9c85a302f10a224c4b3c123d52222e7c062d7f03232f110ddcd10a13e433929426ec6211771418aa464112c9c628df205e5c9a0ada8c92958d85a84726cad46018e9dd52dfed972bc8e00c9100ea0ab570820b080c6c251a8118eff6d315f4363b2328d5285c82e7411bcca8fdb6cc0001b48424c030c91f715ad6328351b232ab31dfd7eb77eb7d447538ac9a4c99c144b3f4ae58f1186494880a4cf289d7d51a8488aa9fc2d2b0710413f111d9f914b2bc245960986af415e148f513718633c3c5dd644d119d050caaa38936d4bb798e79a0e9c0e8853dade3d972cf4ab069026566da34f2086c3a5c24eb4023d052883e510c27f1450ca66b5fcd63a2ea6b8ba3534f516cbf674444a1c449574ac8d86c8859e42588b3269a8a55a3473790ba892256386174258226da44e7f080e22809302ba8101918893c5657544f964b68e7aebb6153920430240a42764ebcae362513a50d91d4c5191eea5cde75304e46809556860d1de400f3b9eb62ad17f464824934c096ac12a6959051eae129871565192879e9f70c1376358af8d5b0a262694731a13de61d4c550febeb8a47d101ed58a0c221dab332360c130d390c4f11e92f61ba4a60a2f0184404333c1f25528c537f6355bc1a56b0df534cdd968716179823ce284d96c761e2f058cd95106b0eb40c0cc96a687594022bb0a00a8c7524ba98a32e4e964b93e510f30567317fe5f29a02d3dcf2bab489367d10a8985869f915a6c50a7771c3e5c49955f1648ad6258c2698875d8c4a0bab50b01024a602e756c288a9389c030c2e503462257802f9085a2c78c42ce885dddc32bb62d7eac8d4c04a13da1455388d12ac22a66033d1625d5d756aba042bf6d0362a1e794518d542861573427120c80d07478f413a5a0f714ef5445c3b1ba5ed62888744893d8b7531881e896acc6186a390e51dcb52145dc573bf43230ca88d71b54f271b5f895c535d6ecc8dd1465d508d0f266a01889d439d68a5d7332cd8a5dc885fcd748615c264b7da47118e0c9d8c36381187a64271d5bf11afa151caa8cbd12902c12ce0001ef672cbc39ae8646ca30388a15df17e358e2406c6bf8f121163d65d3b36e330307a431c58d53ac01d4d31016e9209a67a4fae71d853bb1bb7174c4897db508c40c8ba2b9cc92ede28aa33d11a5eb7b1267694e81306100e88df157f773672e275c5a1c34543a48ed630a1898e7317274163c42819314670b55f76233720b8c203db65da8c9062e5a6a3a5ab04139d9bf288a38f01c7adac24621ea206b1b4c750096678a20dc2740968455731450cbadad05457bf932c1f7a98da6e9756742b42567f8541d08519365da105288e26a1127449423b5e020407c1c46318e3b444b8abac1dbe847eaaaf879692404ba18e9d3fea44e9391b836290af70972e2da2f4ac04b821d35caa4446a81a76a896433dc975dc0d12afe20b12d1d401ede18e9640a69428310ca80603aae638484482ed00eba8a2dbe564042a3ad9a878d6354ecba599425bb721c6c45f1216253855084c2303a6d1802a5033b066071d25c12a74300f866122764d0bc6ba20475409dc301d2d832e8c3d9c5b580d96157de72a8c7a8c129c8261480cc553f3042a1576954317e433aa308a6d7944b244acf645c48218ab2b4426f22c4cfb1b2b61b687686971d8d411c0b403114e5798a4e5a38761e177641702ca61861bb06bcc51a650a2c211c581a2f3cacccca80599d64049040d452b4912b81077975612700ec6aa99482ec68ae024c142c7794915d9f512203a9bbe6831a5b426d0c59836ad896104d5be65e7106f58bf9621a3624350397485484542fe48082cbc1e36dc84ae1d84c44035084c9558ef281a2225ad652c991c618b998888133f359ab4bbc2ae305e5964e58e52ecf81d39d139b6aee84f93693da3471398ae16184323e021f17ac1041149a05d4cfc542bdcae4420a21c9e685394621bdb41ec75c50e9a1889830d952434c28ad2b0b8088729eec30c636ccb06467a43c714a3893cc18948110f38428cdfa1257f856ad4aec627601a362dc3c069248cf8bcade3bc3fb273e282295fad4f9dc09edbd5a8ced1214694788a5160a645d8964567a399263154afa330b461d02122d441771d762bbc836d3cd14c974d97c4d2222f859092448420e89936a5ba920f110b36262721c8646276738278c6195da96726b98d2bba44898849b08a260674539d73aaf58fa76b9448ae9bd09935d15c8fa1259c280866b243bb343350d974556079dd3c421c264851e684618a37f8d1ca72123243f0951c9b157ee02031d4d19a760d1bdae325ee68d3118376a082c84d348a195e8418e321010c5b8a803bcad0c0868a2754d73b8a4b08e37017d8660663c84705c6c1d060b8b0320399ea98c7bca886aa812c2677dc34aaf909c218a20a869e925b2809963e7036b407139d4b36d8ea8b3eebaea9929b18e175854766aa2966960ff50ee608c9b18ec4ae99d12104f9578b886ec063d5b4238601fd8a5164fa58dfd01e8d31070668a2112bc5b64144a2a3e98a6479e2555c7f66d8306badb042dba1d25d999962577426e2c11202b4811385ba8076b02a16047757d8322c28b4d60e08128bd13ec92afc289b1441d3075a8f2a7862aa2bb1a20f20c18e998248475ae9b068a9034ca8100d42a6d5f585292ca09d4f30a233444a0e72a0471216eb6aa46016b547c05957bcbcce752b4e45c45db1f0140d9baac15bbddc083840cc448a428b2dc8c41862ce98f9bd6068aad07650c06df9266302c9a8deffa0a22fbd0ec5186bbf3b3ce090027b96a554f4216f31bfa113d8777bd57e922ca95a163e084a572d1944b4e20b93106382532536bb8d8d83f0d44792ea7165e259c9b2e2437585129869d8b3e1576c2972c88ca6d7c1304cc402816012aa5acfc09840a67d7526f2504f31f6d5921045737147360d052bde46261e59591845aeaf621b2711c5095d20521606e9af50a26164b75624098681aa557cb58d41de4749b0f1192dac893cd0d5e50a71fe2ae0c49eab8a86cee51877a36580151b25620c37ca822a74a9f350b152d1f0301d55f4aea91a65a8e6b1cb211129ca622f93c4987e4589f12e7adbdb701772509e853b95ed5bb605fb4c3fe1a20c1a16000f7ca376dc038da624f1c2fd8fba2837002f49cdbf858b72d5a2fb13c3a87d97cf5817bc91f56a4375b5a1fa079b45ecf1e4f2c653bb9aeb631283e9327ba783f2cbec07a8139bdff34d7e1fc47dcf37b9010441e02ee79b5c37fc435c93ffaa10f77c76bd6a3975cc70359efaeba3c499b57c349eeaafe5af88834c4fd118f3380ad488f9b16c525b5ef8d968eb619ae2a527681c5415aef6901d4f27fdb56c0504948ead89ed717e170a15c314b07e5b7cf4786df3cde61e1f63d9abbd796a6f3e20d9e678727aff68b6a638659193aef65eb8c4254aeca958fa6626e347a5acea0a1ed5880b7af26618df2ffc9eb608d6e22648f5b9c327734f909997195397506e904a6bbabebf755329fe6a977783448c3c52d7958742c9bcb4f6547e0c8572ed1031a3666b6ea652ec0b406ac7facebb6e5766943f7a7cbfd957cac225e2cc36a3a3825b90d79ee0cf7d814e06a9910f52694989b8715596066d71e1a7fee22b9f2f9c09a8fa34acbdb06c2e6f8ff3ba7f2e59e5412fd97938d3dc215ab11dd79c505bdbb8449afb9be93814ca9cc6adf9c1b82d33a46f765d66e7212e6ebed9e02ee471e1f358fa86c48597a2314d25e0a5b04a9fd35e5def5dc8b3b4df165587b0aa3f19aebdbd8c6a3c26fdb53e86a992d75ede6b79ef397efa4d5f9633b7dec7d5773e8cad5dde512226bb36d5efbe28917d5b30d74f3e4fa6de3bb7a533f90f4133ee7ee5be042e1833e3eeba2f5df7a57fc2be7466751e37a6c72d3056a0c485e6f40c584e7f3f02a3fe7bbd816c41ee08a202902a907a0ea094ac36551d562acda929a7b56b796f0f5edc633b2bef5ce28c07098d2ab0f6d200d5a0586f14f9010cfbbdadabebe3f87b60c9bd0196179644ec86b598a7a93b5bff1050be2873004b5efc5560d904d2593f889f04cbaae157b0bc82e53f042c5facd053c0d498af4aeba02d6b5e4a177e0aa23aa64f3c7dc46a94fb6b79bda7ea035ed9f4d58079295ed77129daf2c2db52e03bca1f76abf43d18faa932a73c1a0f927ce16435d558f6dbf92975fb7bbf1deda877f402ac1f2f0e7685efb2ef615a9de567ceb9e62dd07810a40781fb0224a9293544e9ee67a24202ee4f15b98a227f8032f1eebe01048913ce397409dcfddd1d773c4d76d7e5730e5daf67bd8a5caf22d77f300e6fd1e4f202d7badeedff3f1613f27c91c361d8e2dddb14db7b61ee5d2121c50b9e852dbeeeb8fac728b6bf2acc3d9d61afd26bfbc7576aedaf8e12aface623a9e6f398ebb7c1a2df93735fc569c52fb6338305ed56496ddc688ff3096dd7a190c603760cbfe5833a6cd5f89bcdc5b510b667b0a087977d95cd8314cf03555a87a37c43edd1f851181fc2537d8befe7b5c7cc219c1a60fdb67617ae5b73b34a27bb305d6b59fa8657f37edcfaaf7eafb118a425705254875fa229cb76a1b8b6e1aa7adac24df12468cb1b87570a6a82896b02cee3a51d6fbcbadf86e332d694281c7d1ebaaba72f68ca0a6aeb0b9f37224f45a5c32765a04a8bad2012ccfdb5287a64f934a458ef101232da8784ecf76aaf9b85278cc603d2189fad6fb90d2346556eecefc2e6f55525a16db07178bd0cd4fbb2f62e8ae5a59f32de252b46799cf4dba0f9cefa277efb69ff5c22f294889c2768e220c50d8780a5a7a2f1697a3bc51357bd1ff7d3d5a21a47579536415b8ebcccc89d74c56cbefa36227304987b7c43f25469e29065dcef34feebd9772f5d1ee67e2cffd7602d6e7c7efce80bc674404a16928079713dfefb670b97c06f7e664475a8b33618f6dbfdb89d1a538f4849bfe32cf5f631efe3783ba706f6f8b19dc1dc2368ec1071e356e4bd59f549119d545ab8eb561964ce784092277dacd8817e5b1c1df21de7f1639b1978c4190a02baa475ee1f0fe3c10ce0f006f3057d1ca44a111034f678675caf895a488faaf2cd7e1b5a18191d880dab3fce59a832ee34ed42ec46f9fdfdb87ca2f67c2fbb217ce6c53a34b3f0d0b8ffc201aed1e0a43be1a7d80dfe6f61e12102f19ee7ef0f59773d3eb30dbf9ef3ca6c5c998d7f3219513e21182ec76c54f5deba2c9c95c57b43cf3fcbbc0738feee3b0cc67b81edfb0c06bf0d21732106a36ef88730187f4d607b3aa75e672f768fafecc55f1d175eacdf935038025e7bed5609d7add25fb74ab3dd8a4736e65c555abb76be95074fa6e3518a233fc5ebfe2e8e73c0470b8780bcdfe64a9f8f16c1ba22e44be6c5495ca56de3c902e0a72be6a5c682aa68ac090aa0b6267e458a0eb1869050469e8ad741af8e9bccfa1617fb2a9ed39a98ef170e59313f83754cdaea19e615cee1a3c4e3fdf89be98ffbe9d1cae3495cdc5402818a6ac2fb89b507010b2f651581997ba95f33241ea9fa8a81bf06b3e136deef9958cf3273d6621d2ff99be9e7035e59baa6c4eba6b40a085e8766fff538b4db3e6c0669bef1f846dcbfd83947cbe92c615337f88e21f131db9f4a68360e70dcb8129a5742f34a68fe5d3794239e5c9ed83cd47d5bbdfa4d58dbb6edc701ad594bb0ef1f1ad217916bf2e09e6bfeb06f440d68e2df03d0f8c69dc44b079ddebecbe710edf5ac5748bb42dabf01d26ad8f96058bb1dcfc3a2f4a6d3ef44d03e66fb53e9b6bf870bd8956ebb82dc15e4de0f722720f4cbe0eef6db6c9a9561167c0ec29c4dd76998955fd66ecadec6c1574bed61f1fe5efa8562c5bbcb8915eb86ff0bc58aafcdc457058da759aec2c6bf1fc6bcbef25f0fc9edd8f2d25f4bbc6eb626fdb6bc3deb6c136dfabda327535fd5184da535355babc12499eb75784c742c4b3416a8dd75bf2d475e3a1a3b294e3d4163fd4e773e6c3796b592de9459d883ccdbd539109c959362ceb1ba8f7518cfce740c55bcf45449f4089e07ad57cfd14cfdb40e473aa7add333340de6a97812a8d2216ca7476a39a6ee108d79a7e720aacad2ef4cc754c0cc1760ecf1d2ecf0ac07736af79b7d95ae3d5ee4e82ebca99f566d6313c7d69bfd8e5eb587a3b5d180c2516b774ea00a58a02a8963c3e8781ea55cbc787fbb558fdd3096b380e04da06a0b8f2f4ecf70e45c5287573de9ebf9f339df71cee6d2edb54a3fc5896b9f3fabb2fade833498f8b1987b6be9b44dcd60f2a4cc9adac622b0b53aaceab3331dd7d45640ed8cb179766e62d54e4106c3b8b5d23b72f9b4dc76dc3c22ad436b3ad6add6d222c9e93b999739cfcfb0dcf7ad0731fc6676250b2bc637c8b4afd6b3733d77060acdbe0ae74fce79acfead658dc6b2e80978dd578d22b00d8edafdf1dea8a6afc205b5f5b1974a5c5f6573875f01aaa2b163ca8997e9c7b351cde5385023e6c532e7bd7c47ee6506478992f45565e2f078e3afab3ed1bcfab6f55c6fcbbc636b3b9b6c7d1ca8f7639ab2396dcb1b6a6bbc4b0cd657a3852f8c9e8dab3e7689987aeb657d56ecaeae45608feaf6f4db01ef9015a8be3155c58db6960b9788acaf2ab1275036d8add78088138f0725ad0d76e0948e5ef461574e639e2a094fcf5aad9f1fdf4344fef45bd576ef2fcf209d9c9c853af2048d1bc6ad8dde692ddbe38bcbed5f03c9229c2d623ffc11dae8499103bfd8e07e21617441179cbae157c2e84a18fd7b08a3270bf8ed834af65409daa3fce83d14c974dc57579193e2e2b00bbe4a6d9cdbe54f289ab6fe04d5fd584e2859b140455b7457e9c253578b80af7dc37708cdd27e8f2d0253169c2d9a2f76d457d4efae160e816d87ac222f35985f534d50f455d4ec77c50555718dc4fdd674b7b338e39048c08f8f01dffb3d03f8bdda0b69d357193768cbc04bebd3ab99978dceb58bf3d67214d8705151632e31224f5d8907d3d3d1ae9fa431a676c4515b9b3b6439de9e200be27d5b695bd6d08e3addbde3409d1dcd4ed1bc1ed7b5cc85b65c7d17dde39564942a85530782ef57dfac8e97b0fb3665bfeecba8a2e8967aa7553ddf5463ec0870b13d90e647bfdff48377af5918c4c5e7d42dca70f6630cfe9b250fce09dcafb41d922ee89cc0fd84ed10bfdf76846a276bdc5d77b2eb4ef617dfc9de5cc5ff28367f4d09ccfd35289d2d5bf678647dcf81348802d5985aaa1253b2da9cb0df91df6b35fb3d8d51956d8e6cbeb6a8dd615510d5a78f1df3ef413d7688313bcffe772fc5fe6ffb78ca32bedaae53d18416397cc9bc74c70ef68c2525a7e28d93a3cdb3d3f772e3edd1e5d3b147947a433f6d934b4044f9eadd45c35f8b9923f4e72eb95fec44074d6a69acdf6e4dfaaab4eeab39f085d1b1ccf3f16dd763b6a958eb8ae57dce32fa295ed56286de733142ddfe65b0130578cf59d1b65c5202167e9654df61a97741fe93ec26af6f7cf1e3d9cd270bf6fd2ce7ebc50e66be82f0ebb66afe82e1dbea865fd9ceeb66fdefdaac3f86f57cb14bbedc71ceed80f2dae3ebd06e27e5ceef3027bbee2bac93b33136c9be7ce4a7010b94e7cf5e67ab9e08a65fd9fd0295d5678b39a93271f95fc76615cc5d843fc3659d2fb8476e69674df26b90fb8201ceea865f91fb8adcff12e43ebf8cff915cd6c653159e8efe9842f5b992d14fa5a2e2367c7e1505293aa49fc1f30afb99afae72875736c3b8557326818a4b5f5d45818af67bd9b37e6cebf35e707dafb7e964efdbf5f96338ace77d3e55b852de580f52b618f0c1c2e383826269b3f540c79b011fc4037bbb57efea685634c155097b71256ce4c56f2862cd5a749dbab588194ea9f98c563a51c21edb106c768adaa29e233bb1b827e0ad62d56c654fc662ed8feb0805a61c39a9c4577d7076128793774cfa3d3c3f510570ae8af6e5aa79bf085e7c6bfd64ccf13c782245d88aef9f72e4dc1343025471d19de9d898e8c270f42b69ad1f6590cf943af0c7dc2fb457e3f90bf2c7dcd55eed4a65fddba8ac8f658f8f5bfd2bdad9b36c68cf281c9bb267dabea360383b9205a782c781b965950f42c6ed71dc052506e709fdf7b0e54f48935fc5fecec2208db3ef3884ed33fd594e610dc037efc5bf87b7c4d529ecea2f71dd1b7e646fd8a3cb477b4becde739bae8bdfd98f49f8ce1739c4e306ef88bef22e1cfc7e406ebe79dfbc60406ef071e157fea240f8f61c7c233ef73ec395eafcbb21cb2b2bfe9d323d952efc58e60fa718ac2bfe9b2efc148d75b3210e26ad71601bac262a7bfa229828714598eae37ce6123119c67297da72e109ac36b66ea7cf2c06d43a7ade8e2804b997a2d2b3d9c627cbefe875eabc17b090d8d6f35c4e77d2864dd0d3a240c559b897bfed4d08158ded23415e5276e711363f2fa303a22f28c5aeee13b3c9fafe54e3ff42d671b474d8d6319ca0b9f1cc42e1602971e8fba9ac0ccf83c94bf9da894ccc20d8809622754718caa344b1e00b4b86aa2f34f27a78cfa8942e11d90bb9dc894cd1c2cc4268f50d634341cf8df0ff407da8ab5808045f51f2dcc1a01ecbad713fa26b8fe75e5a6b6cf36c2831d6d486036a339312e7957cade31cdf8dfdc096235fd0cfbc776795c2637118cb4bc73636012fadf78e3027ffca439d5d401150741b685f2d00ffe47ed551494b5f55362f649ec7fa67d44e9afd5e350686e009da33d9df139debf3b976de8962d3e2cfb5f1cdf9dca30baf874b8a401db5f595f7ede77619dac6ca6b83658d83b6c1555836b02be656abd6d5b9b12e3d01cf03a50ef6d4ecb725deb1fb0b4f65f1803f5dc7276db15f58f1fcdcfb7b72ad7bf105c8bc0c128f5f018fe08ea72ab9679d9191f3abc82560f8fc5d410c267599d1c7580c3ddd9ff285ff6e52f490f740834adf7748b91c097a414fddbae11f61c47ba53faff4e75f8afe3cacd9774a3ad53a7d13548487b2076ba370082b5f25228f1b2c3f20bbbcf14b82ebc414754b309c48351d624ca86d6c2c222527805cd6c41e81b69f328e2269eed4a07b4e31795e21e9578469ba5586ea28c83fca0cf3e9a0cfa6d3f27311fab3f0fd7cfe8b324746fffe1782ec250fdeaa1a7e65f3af30fb2f80d917abf7dd8aa5899fe2d7f9f3b3347e7ecc7742cf0f63197956b27450500478ba4219c53aa305e20212e21c7eb5b8c7a0c796353f26c8916f63f66be0f00791f02c08be43c77e3910bca0bb18f8381dfb1504af20f89702c1f3f8f712eb94a58bf7768a8d66bfe7acde415ad6b29b13c5fb514ed3998e71a70f3c85f6698f9a9e95ac1c7e657abc51e274f9a761de7bed8bcee43fa0de3dffeb504fbaa0e74dddf02bea5d51ef5f817a1f654f5473c90372145bfe0847fdcc41f13402c146ef38df75953955879c51cf1c223598b8554775f052f4a1b0bafbfbc34e32670b1dc94af00b2598d2053d64b62dbf42ec1562ffd110fbda027e871a7df4d3aaf03f55054e336de16570e712f25752833f8d53f8d3aaf063df9b46bbb11a4c5a8f67d59b67c6e89d2a711322689848546c0eb66d6eabaa3da7aa3cb82e9c35837867fd409611306c0b68ddd7df81553f95ca17f1ecf652ea1d6b64f25844294e5fcb77421f1cd485151bf4baaab864a1351dd762232158f8e94bf5f4ebfdc216c4da708480f2ceb17bae0e3de3feb157a39fb835b1e791915eedf32ba60632bf53b51ab5eaf75ca0875377a9fd1c37ff905a19f8427fe10bda64201cfabf3fccf4b8a653a9080860cfd4dce539f31bff54f3d2069b4095d60e099ef7f5499bf1aefe51ad4a3e635aa06eb531d699be1fd5cc1f6ae37d40f0f7299a5fe43eaa9a7f252b7c41279bbae1573aed4aa7fd2be8b45fa16e3e05cd9e536eed68b81f25daa2edc9c286e1f1f004a0b9314a71449560edda9059bc38a989a19ec67c5e027e6a1cf35669761daaef94c03b00f7306ead209f3cfe0a70fd216fc697258ef2c65fa865912e78a027f8b8c8fb5790bd82ec5f0c643f5ce2f894d3bb90d4f1bb714f5f933a3ee388cf491e2f0eb2655894df3987739be5cf3ccb8917fe1ede897f4967f0ab6fe2d537f12f88f75b54f948afc4fa0db79e1be4d3e01d04e369c63dd8098d5f18f0a2713932b16af7bf3adec57ecf7a853adc3fbed2867f1fac78b292df470cba44e41db2caa902a72e11b38fb088d9b6ed7d10f3025f80d8fc8500235e900f157f8c886a34efb8e6c1afa4c9dff39c742f35ae08734598bf10c2fc20bc20554a5e17dec90bda062c5495d257574f7d405e9e3076d494a862e612b1b113ec1d02dc7969907bd9b8e90b3072d2151b10a5f0d5230ff9821f3dab791a2f3f0cfe8af7e15ff11400c55f4a615d30b683f8a324d6530014789e9324e10a805700fc8b01607131045401f07a301f9013248aff7a88b8abf735ddfa493b22ce3bb197f08804bc0c8e1c1b4e87716b11d8c67a201853c7d6d880dfb679c01fca9cd4636ca57cd9ce464165e5568d22475e4faeed45ea43306c83dbb5ad3e4bd0d9daf878fd588a5dd258f8fc381eb45bf180e8b13dda4b2cb5ecd9999582abb23af4a69f61365ccbc9f3509e9418536fdd4a1e5518052acafb9355ecc7adc5d7b83ffe3a698c777d58b8444ca83d9ebb3d587a9de2990d8451508297fd0eba3bd5cd53553a4a39773a7c6b3b6e0729eb895dc63450c1d16d5dddbace2355e1dcce747c1ccbc3b7dad571b479a8dbb60b67400958063dd675ed7eb3df2ba58fd9f9deaf907a25a4e6af64002ee8585e37fcbaff5df7bf7fd4fef7310aa779ad79afb6a327c72bbf3c28f0cdb0964fb7c1a751963bd3b1dfd316dba8dfa7e66815344a09c507e5d4dae874f75bded24f25cee38d85d7998e71d718edd377914f1a7a6bafa052d694c7dc3e8af1b0d3faa372147f9a7d8bc7dfd13ced33fdb0ee49fc0cf85a7d2f3d08dc1720494da9214a773fa87be2efc4867807fe54dd9328f207c1ac7877df0082c40967505312b8fbbb3bee8085fb2e9f81cd37b25eb54f57edd33f11eef740724175d3aeca5b3708a6d9e7621e97ef21025fe43e40db778e1ee33f03a14634f101085f040e484d5190ee3f73e20fd1815b48bba0d567e3a34e1e034d9e1781700845b4eff119407b23eb071082c74de915caef98e14afbfd85c1e0e5ca3d127c817a3c4871fbdb401ed08037d9c926da8187049c07f5d15a4a52f1b93ee02ea1603ab46ef91e89ea21d751a0fa368e343e73c002dc43437c68dc7db913c5a670c7713fcc4ff2774dae7177418db5f8612718de89bcd0b8db673df4f81c3ff97ad62b8e5c71e4551c59be9499f2ab4540e0c84f25de25906d2d006b866b7b9fb06418cb515087e994d79404bb101178332038f28551a9ff717f957d0babbfd359ea667ef86e4ae59532077ae53bb1ba2e4aaf5cd080baf151a1baaef4ca15673e16675e59913f4bb5180b2fa50b3f0591971acc36fd3f6a5bbc6f67305b7f9ecdb37760cc939c079b985fc9095d908201574ee88a2c7f4f6479b20e8f78427b9a88323c1fc65bede105b99b907f3f25f22cef0127f8ef988e343e73bc059a0f7cf3816b7c119a9220341be24fb03aa2d0e02ee928cb7f9875eebd2881e6c157e1d0e573bcceeb59af4871458ad790e2d95afc59da03ad29e6b2edc96ead8c0003b83664c3585ef82a5e3b365cf8991168cbad0a4b03a51510c66d0f81dd1f0c0a124a68eea58c738934afe897339299a5df06714058417b557d17c3afb19fbf03b90eb90ed299eff8f65f543a7341735ff1c35cfbafd2992b627d28621dd6e00f4b67324768bd269d595d90168a6bb62ecec6efa688ce96384866bee3dadef8ccdd5b003c34f8078effd2bce741f3bef97318d36c5c907f6a7c94677b536872227fb43ddaf7f80cc6bc91f58a31578c790d63ceaec7f3b451bf2dd7969d3e808b9ab73a58413ea183f29d6c26f7d2e092744bfcae6892f18bf091dfb152bca84ce6922730fea091e2552673c594bf0ca6bc8800e9f1c636b2de3632e0d8252277413a844dc79fd3b09cc5fe7b34cf2f721f781ce93b04c87b8df3de2197119a9774e9913e8a02f9ab1ae75dd1e29f82162f56e3096aa8526611ccf9299bf89be9e389bfc521c2cc8080ccb347a561b56ae3603fc519b5c7cd7e5beb580ab670179b680d0cc80134b0d0526ff72bce68ea92608ad5684d6d63eaf1ab6418b756db483a92f02cbdd447d3131f1865e2f078e3af01e7f125f3620eeca2e654dc160b3bd3713f35222f0eb8be1ab0e010c64c1f07aa34a3a4e2c6e0c2e5f1bc0e4f26c80b2f3358bf67708e0d81bf96737f331d57fde977d9bceaa3972a455f31989f51e6c7b2e267dac28fff683ff0828eb761d3288f1bc3b8154324a9fd1aa5a39cf21172eb906ad1c25347cdfe361e79e109c1d3f436b76ac7473f16875f015f80cc8fc1921231a99dd79f7d97fdb7ebab6cd357c545508f9d9284e672ec0878eda7781ed461da142eb0f5b126c8cc4b61eea57ef67afbfacbf7f53b5af8c2a8a4aab81990d5c2e34be0b79e8e8397de8f7d014fdcb63cf50483dbbe93655e2aad2962a94514cee1a3ce3e966ef5bdc8de3789199c4b5645bf1744ae5db7794c5369ddefc12935e5414034e6a722ab28eb7e57199966fd1d83aaedaeca325781533fc51b57950ada998ef551f5eed522004fd30756f772bb6736fe5ced73d3f97b48ec97d90ffcfb776484fc67fede02e283203c34f82f122734efef847bf033b436c75d5046d8f82819212ff13c7764ca0f3d3eb37bbe91f5ba7b5e77cfd777cfe78bf189b0700bc55b21210b7b72ee674cf312907b19e6a8ad37fb9d51436fb726fdce78ecaa12f033fd52c614593c8e4ab6fe3c0b59e816e1e76fd3d9e77c360d3e07e13777cede0335efabe2bdf0237c16b88a7a07c28370ff85e3c0dd3de09b8d9f5251342e48bd7f18fc88a2c473e0e865b8edef3905c56b19afd073859ed7a0e77d8bf355389a533be2508a0b4a940d454a5a5184a8a72d6817329a2ac0eb55545deb527034cdc2635367d3f447b1e81de5df0f44fc9dc5dd3ff08d07aef1e50e348124dc8bfc4f019170c123c13e0e880400ee1b07938d438fcf41d1eb59af607405a3d7c0e81dabf38f2251f77248c4d69fe7f978e606e1e772fa79b36ff97b8497df2b7c7438feae2e55a87831517ce0eebe88520526122ffd0c0689f797d4a57ed891595243b8e30e4618871e9fc3a0d7b35e31e88a41af63d0db4bf344ad6acb999f2a09dd465b5878e94ac4fb340c178469cc5371e4f378f30126ac8727ef36db385be2003577df2577048b931e406dcd2a36ee853b20483fc57709f7977400befb20a869484d49385aa81e7a7c066adec87a859a2bd4bc0a35e7d6e3bb4d5a5f98a67a82ccbccc98ba8472b6e95f0a672a1aec3db115672f82cb7e07512e6ab471c1d052e0a310e56ab47145948f4594e3227cdd6a6317a8e952f0309b679fdde2731006b1ef9661f0d90dd2f83dce776f15dc43c89df84ba2d3554409df902e072155bbff4df1efaf00f20f0190b716e579cae4e010839489c34bc0cb46cd7e5bdb84f5d1995cf3f09c1c9f6bb6dea42a4e5c6244818a930151ea038906c42882aa9cdd8f2f044f85efb2f073ea1665385b84b332f65df66e86e97b850f2667dfa174c4cf5cd302dc03b87be0852fc25df38e07cdc60f9fe656c1d4dd2555e6e247513af777778d66e348beec7b7c06a7dec87a05aa2b50bd0654df5b9a3fe919d833260e59b10101512d5bce28f333983bbc525c3042c1b6f1d934088bcf6e167ccea741f183a0f47ae1f782d245ed782e483b7d18285ded78aea0f42b40e9f5a5f94741a98ed4bb1910654edb60e9f170737150cac3d90f876a7ab3e4018ebe132de19270042ea84e173f2a58c2158eae70f42be0e89575f907b1c89699ff31e19b8a24ce3f47a1cbcae8b31f857ef21e85fa6b850e07297ec727e8a2b2e80b1243c247b9045d65d157f4f960f4796549be26462a73bfbb17ff687d4a942250a38eabe2892be8cd7e0fae03f287cf9f3e34ae74c7ef226d8ef98e67f7ff3a28b9bf202103ae9127af50f2378592e32a7c43af25c088f217438872393d58fefc94a9f17b2af8536c8dc1d5d6f88a29ff7a4c79cff2fca3c6c6fd4b81d1de1ef102de583f56d57b03c75dd42beb82b63c1f1738eeea957505a70f03a71f5ba4af9a24bf03a6a0ea091af6d560ed10c886716b794113e57d37fea8d7d60fd4f3a700d6fd15b0ae807505acf7add08ba2d525e3601e9c3efe28e7f72315fd297875496dfd15afae78f5f7c5ab1fe3022f0058eb4b0256117e4ee32060e1e7c57678de034eaf143a0011f885e2ed0b8aa2aa765fc5db5730fa3b82d12b4bf255f113f0796cb804269e8a0f71d80e07842774e1276cee0b30f2d4e5a535675563a72c088bf20741e75ca13f05742ee87c7a059d2be8fc8d41e7dc927c1374be7a2a64be002f063adbd918feec74fca79eeefee53b67b817d5dd6f419887591066fefae1b79357a6ee2cf1dc322c6eeb86bf7abcfbe999ee4f0e947f5ec1ffec97d87fdf78f36f71d5526f5d86d51af7a7693e0b8be2f61b73cbf03461bc89f3fa3e2bdd380b67b72c2eca5d42b8aa7fcdd679393dfcb875b7356e6ffc388feab6efee83d38741e11e6f42ffe96dc08b22905e24dcc65919ce3297dd86c1d29d05c5f36c8cc57919fbc79428754fee0ec5676e16cccb989d7954ccbd9285c70769201e6faa7227777ee3e4e6b40345e4822777bcd87c722f02fee4fed92b4b76324eabff4f6e156cb9adc2d07ff13ae791ccbc99d37e4c1704634c83814a22275ef4df7b84b1839b899d7d1731d2d58d0c425cf371fcbef644bcd85b7368b457a1b5de54a690e84fb57f96a83fff5f21d64b186ba4d77536f193dbb3f2a31ed80508c0d3ea06def7aad34c38a7ae932e88ac0287ad2edc0adeb7609011b7a9f162a685ef7204521b385b2fb12f8350a0deb9fecb1bf92848676a48c554bbdd4018806ac86b22904ad758c05ca81a8ac1b9dafffb2fa03ba715394b2b18ad374e77ce9a7ef5561c5149e784be69a5fdf5ab50f2b93f169c34920b7975213f840da5fb277860e59d0671b6e66e12ce76e9fc816f74d32086e4c846998b92815f29906e23584ff29c1bda6b9a9ea2278a95991f73f516709e71c158632284ac2fec27e048decd80b9004df97e4e8360e92f7ea96ab68cbec5c510387a925c1f489ea6e5144ba8ace0b3b7d44f5218b2a83c444ae11e70bebc1e9ad23048a042de2924b03edfab70f4aa0cf7f465ff9a4353e695bc557cb75d2c91a83b7daefd6fd945d931efaa7d1b4098e0a437ff0530e2266635eea5eae5dbf135560c6e3cbd1f3f76d879e0d3f32a6f56a82d7282ab9e957d83d75fda6e9bf128ea1be49d157303b61ef9376844699ea55bb5b849f908edf22284dbb8437c133d7ff93758b6f5f24918472c92f655944f9a40ad126871b6ad85f4b45a994a203d7601862dd2dca39cf0159ee77c3ffea9ebffef3f000000ffff010000ffff0e4aa8e004b60100`)))
+var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7d7d93a23af6ff5b99e2df75da04c416abf60fa15bc45667440564ebd6142434d086871550f1d6bcf75f059fb09fa667ef7cefecd62f747543c2c94972721272fa7392fcc985f1639271dd3f393fcc83c2bd4149d44c522fce82f0316f2619f6788fbebe0bd75c976b0649e4359f3cefb16cfa49335ba3e67be91a9c16a5c93affeae401d77d378b063771228feb72e7f05d82cec14f7910669f1e43e27df2766196679ff2e453e6e59f8af453baf2bdf50dd7e0e6cedaf7f297a54c577e938471b1fbe644b8dd7aafc4370ed7e0f42479c9856b7063274701d7fd1777c3fdd1e066b9433cae9baf0bef18d03d274b62aecb65f4d527eca55e8cbd1895dd4fb52c2367bd729ddccb9a55c1b906a726fd907819e59c3a68e5f8de8d9fd02c0ec2ab5ebcc3e08f0677e7a515955b3c8609d7e0dc32f732aec1a1244ad75e96351f89937bf5087f1fa65538ce9d30f6d64d1266f931c2db554feb32cd93f343d33970ac629b284c036f7d09e3fa4b9c39978087ae8398174528bd88688671eead6387343dbc75d6387b4e464898e621bac40491530b9d93af9d181779485e7995156e4ebccb8b088b97004d570ba1562d50af401638f02ac48bedabb008f95af8599639a9c96927825a0d69a899aec21dd7e0bc1825388cfdda63d3c962580fbb4ee6b55b573161ecaccb7a4ce0d5b9359fa87ad6c2a917d1d7eb75b2a6c57a8c68bbd734cd4fdce2f1d1214933f0d61ed7784f0bdf7b796982c849b377f9d0bf878aff90a699e538a1dc02270b8eb7265a2381caff9c23ed0a0ef1eb51282deac1c728cf92755e8f8abd3c5f3bc8abc7255925a87a549a10520f3f4fb2f61e89877212e657d15918fbc47b24a11f5ce59a95197208697a3b0f79f1e6b557451ceeeaf1b997e524a96a47bb6a9834c3e4a8fd87e8888ebc875bd30d4f314d37ccb3d3f351f3a330f28eb76654903c4c9d4a2855c4bf8b24f770ba0ee3dc71ab3e147bf465ece5cd20cfd3da63153e49ef1c792af1312ef77679ba4eaaf185d2146b2ac8aa3593ac1200d7e0d243d9e9ad4987fe63f828d5eac9f776e9f9a1999571ee50f9ac8b383f54e7f8d4447e520b9de5e7e44914a2d7de1c05f7223e2b69218f0a93e56b94542d95e5eb30f6ab57658c8eb70bfb63fb710dee58ae220e51826b4fcd227f84edeb70a70a66ce23a5db78314ed64d3f214eecdf246bbfb96b1e870e1438287078f031aa3421251480f803ea2a11ed3d1fa53b8d50ef1117eb8d771ad9dfa10b56f8f17d8a9783fa3bc43fa83155401c674d1c679197658eff16bb2b15f78b3cfb085dba4e76e50f08f96640bffcef50853876de789d95d971487bed2ded69cdcc43c5da6bba210ed7c59bd2aa48f3b513678fc93a7a8fe8a4a394e147e862caef8f0637f7b2fc3cdb890b420e51e779ce216a9c605ac8ee9fdc87e68d63278c4ff3b0ff7096aa26e304ff74c2a69fdc4409aed21bde3a0babc91fbc8102f7fdfbf70647c7ac1f4dadbb4d4a4027e1f48ebddc094995263ecc86e9d811ee3dae0b1a5c44078c6e4b12aac76fd548d2e578c0b73f43f0198a73d0ee02d805ad1bb1ddba950489976cfa51c8be612a968384e8f855c9999690cee1d8dc9ecdedd9dc9ecdedd9dc9ecdedd9dc9ecdedd9dc9ecdedd9dcfeddb9fd71f8a206cacaffe8dcb7c97d6f70d8c99d93285267edc5f985cb85b4cae21da6dda693655e9ebd6f3a1c69fe7303028a2264060433209801c10c0866403003821910cc8060060433209801f17b0c88e384fe979b11cd9bbbd9b7599eacbdf70d8a0bd9c9a668c356e76c56f0e0b959013e03e1332fcea1d06d09dd56e70640d06a01e956f80c5a5d006ab6c5a343b29371f127d7cbc388de279987b82e9478b10d6f41bbc1cdaab0d8915a1d0881f4bdc1c96475284b0b486d1a4cd02ae3bab0dde0946b2ec7ac2f4c44287678bef39dfe8f7dc375db6d01741a9c1a62ae0b01000d4e8b13ae2b009e6f83dbca74f5b8ae20c04ea7c18d3fcc7b42c278c5756183d3b1b7a9ecb0594d788b4b76d6b76fa9834145627dfb56c445e661aefb2fd0000df0c7f7bf686f9dd4e799d9755197f3fb836d55b7992e76cfc5d239f4d0a3a1736cbe6b4ba76ebd1ca89ff5e503b471e9eaacf7ffb0f7d7faea791ce07af45a0ca17d77dfebc934d0d3e89f7bfaa7a7a8bd772fff4cffc68f727a3872a4394c7b3d39eba99dde722aaf7a8309bfd89e687ee6aaf80d7b03d473b772d953b39edb93373df5be67f7e43d2693d28d761b3742a77cd9c52e76b18b5dec6217bbd8f5bf7a4d4f0ffee8f4c42e76b18b5decfa1baee9d9b2972fc3f1fdc5dc9f9e23e54be4fd39f21c3e59e5d3f33f11e44be4fde53f0bd373a47c89bc3f47f6a6e748f912797f8eec4dcf91f225f2fe1cd99b9e23e54be4fd39b2a79f1e7af225b27f7a6017bbd8c5aed7aebbd3c37d2fa3c3473568043e1b34d8a0c1060d3668bc3e68b09f0fffc8f2bd3ed70f5335f9f9cb673fca19ac528f73c15eaf57839ee4531c1dadcff3cce9395239455e81626ceecae6ae6ceecae6aeffd373d77ffe93fb25de400ec649fca3b505079a9f5a5b207479a10bc49b5b499224be752bb2b5056c6d015b5bc0d616b0b5056c6d015b5bc0d616b0b5056c6d015b5bf03bd7169ce6febf7e89c181f181ffe77511c7defa26f7a2b4dabbe6c7a6c68b2427cb83bf15de5b8170363ef82e6c755bed9bdbb6c48b524b80f61b8b0f98f5c1ac0f667d30eb83591fccfa60d607b33e98f5c1ac0f667dfc9dd6c71b46c265b9e392ef03ed6edb3180349b81ddd7e962ea7f0d65c115866b5795025b11c5a5093325ea6f1dc326289ea42edf6a6bea30c0ea241909cb9d12e5a91b4ddbda7dba59fa696e5b7a60ab7db09c270f9a22174b13922fa17ceb95e217fafc68810724c864b9afde6f97d63070d51d7155b277e7893f9e26bea64e36ae2567b6a5a72e2feebf84bd9d12f6fca539018e65135d35025bdda56e64cc6d73b271237d7fcc63e19890b88201683e9a22874b73b276793b9aab2477ac695bbbebd17c816dc2adabf6815d95b3e76b83c9d636c76d9acf314c506ca74bde9097fc64834d111cf398dbd69077cc09999a932757300a3c80d2391d2d7bac078e291214d7f829c077a37e6ecf137f69ea2b976fe55835f678303ee45ffdcaa96bf6634ae3095931336160f386f4384d6fbd12f88eb9f447ab2040914edcb896a7d2f3116f3c616b98e201b9737911d85600bef8c9f93dbda3c130c511c96c1313fb2ef1eda89f217e51cb9f96bf9fb9aa242c6ab4ba35795a9abb00ab64e33ebd956e1220b51f3ae62ec52a21689f5cbf577abe3d186ed05de29be5509edf6f43eb582fdb1457b4bd3c21cbb5014eb1eafb2382c9724556b62902c7d245fafec24b266ebcacc9b6e78f66675d930d38795caca4b16e48f262d5ba2ec7c00edc8171d2c999cbefc8d4187eb9e6dfcb35550c5c73d1d6eefbd6024a96be183ece167a7f4ef4a1d1278fc6bd34d217e2a3be22637d7a2d671cf5334cd30ef2db11d1370e6f143a95a5052525ce6f473399b8912e3dd6d30dec8d3b30727b012b5d7b26bf5c530ff10bb50f9cbb837ecc0503a08101749594cfcaef9fcb3fd00912a6b97ba43de62f38969e688a68d9e67068ab468155023ceb5aa7463399a62b705f0eb0ea5fd7e7bd3cd5be804a28d8a6963b26d5553dc583558e55696d9bcfdb634896a6fed58d76e29750062836c81b32bab4c9408688f77314197b6cee00dad6e4a8927c69e253fb4eb1354916273a50ef9bd7755df252ee9afd82f60bb39442c76c6d10ef679a226d298f9135244830323c186fd0c0d83b0a2c6d6b02dd81be1fc593c4faffa48f5e6865dee577d0358d49a5238371fb87bab392201ec810dfebe973f9d7be15c0b62660641e697b75f900df3525e8c6fa7469e9c997b0f7a22ddee0f9bc0dcff5c0919461135ec96234fb4059542347035da47d8feadacfeb29fd6ed9a90d6080ee9eb5c3393d95197d36a4c7197c51878f8e97b5fa045818e79837c29179a11d598731aa2e3b34186e9cc878c2aab17aa1a3bc04513479457f00fd0e9538224ff6a2ffb4e4abb65ab87c4edca7e7b4f4570eb0a9a7b6293e9dbe7946354688f239fed99874fab5ad60eb58741e42362e9990252f15f660dcd6eec6fcb8fac65fd31fe61a5467645095c77f5dafabb652fb25eecb1b57254fde3cf1e791c1db96f6509b273d28d124c04aaff3b52f1b0b103c2e56c6dc58ec168bb2f78f17ed5e8a7ba7973c6933092241db2c23528c78a3c0a11c2ead49a2f8e9c31c0c1f17f7d2fd5763bb7a50a5274dc5042bf2d6e5f5bda6c04c53d3cd3284d537eeab55d78b5eeeeee1bf112f15a8fa3680d82bc51453fd84d9d69a899739d94cacd23fce50aa44c693a3767c6d65d3efcaaa9abf85f2d4e5a76dadbf5dcd56926241f9abae680f980f52575df8da4cde2c4b39b6ada98f5469754dd7cbdd527e5e8e3d56fb005be382b60f56fb74ce562e043dc003636f5b13f76b19f44611adcb42fa3a1b06b6aa6fdc105663002ab5d49a1d78d81688b5c1d6b78521414a2f4733da3787b9638a41a5a3a5bc724b79efaa067dbfabc2bc4894c8dea0504e359514da20db8dc2167c9c67bead767c971ffb289e886e347eed7bba1985bdd5831a6c90a057727b98539d11bf624bdf624bbf77aca1f438eb45c3500e516404ce3ef311bf23b6d5f3c7f3dead46eb122d1e167d633eeb4b33dd9818f3be3e57fcf469694d7dcc4ba5c3ef364b735a78663f777b87f8e7631afdbe287e4a65ff44f3c1eac2a7f2b42312bb667ffb8371c21fcd608422291f99366d47e91dfe011ac89937ebe5f66b76408c13c7dc11eda29fff76796d53c98ece6d2d28690adc7c35d3bdcb8b557ffb3ac3b7a3780296960e91f0a20d83a5a0a7385a54b2d20693cc368dad76777f969d12c1b5ad925253e0565386afc85f7aa2dfd2a5490adb1a8e5d1eefb57a7f9a4d13dace366f006d206fecc1d81f995bdf89a4706452992da41f963f22d45ed89b7b198f2248b0da5f2d2d3d38e8ad210dcbd503cdc355a51895bdab3e41657aadd3bd7f7cb41ea352bf3df31a80fc99dd50d0fcb4835e64945e53f473bd35053ebd1c87fef3bc5fe84cb4dbd8a5f64b5de59a6bcfc161ec653f709abb90fd05bfb90e647e73cc6f8ef9cd31bf39e637c7fce698df1cf39b637e73cc6f8ef9cdfd37f8cdd50c81ff330fba7316b42345ce9ace056f4a2722efdb1ecf894f1688c0b73ee23e77b2413a000020c0b6c0dce798fb1c739f63ee73cc7d8eb9cf31f739e63ec7dce798fb1c739ffbaf709f7bdb42b8b8d069a53cc7e6305f5ac30adaa430ad638a7b6da027f64c7e7207144e364a6da06f34f5de5ff23b88049da050de53187549a157ea3216896429e87b4d25a0a23da4df6035a0b4673ea89453379453acb41e96c284a710fc09de47821c2cf9455b1b4ce03282018a289ca3e5ae201314f5812b687597bc7dbddc233f5dd9969cb902c9eda3fbd12fe16b4a5bbb82e9a55289262585e89510f8483042d7ec974737b9e22a1c8aa9bb4d724dc514da159616a12e36f5bc0bc7ec3c8c66548607573cea0ee00a43804a31a75097634e326c4d806d6985a7c0f597c1b84af36be02a448a2cf7d69fb3d4433fc0aaae497f0aaf825d91eff2d24d4b6a7724d0860cae62701583ab185cc5e02a065731b88ac1550cae62701583ab7e2b5c753dbbfff558d515ff26a1dba37daec6fccfcefe0360d56b094e2608e43fb4df03ec8ab02b801b9e872d49040203ac1860c5002b065831c08a01560cb062801503ac1860c500abdf0c58fdd84cb842ac644d8574b16c3a5af41d4d3d23253ee6097014baf05a8236efd34566395d346ecfe4c2e575824a19b8a55c62b3e553644a1b548b8e0945861c8bbe6f3db896916195c8e653e2e3c110dad3f490b65a2c2a3fb93c5da0a6932f616f37f6d3cce5fbab69d4cf967411a835d4b129d1cd23fc492ff927f77f60445db0bccfa9e7ad3f6045bd9ae26446f16de98366d405cbb9051d9e9951cc8c62661433a39819c5cc28664631338a9951cc8c6266d47f9319f5eab4ffca8e5a38eac24774c39150ded8a11c9cedaa8bf7998ff8097123ead9d629b4fe95171bb5af0a37329ee8261d67cfc078ec633ed8207ee1bb9101aa4d4284b16fab523114a6be2bd80445bb0029db078d6e7852caa0560e887863afa97a6a4794ce28b022dfcd16fa0c5574fde2e899b8b24d3ba09e72a8acca5ef1a879274237aaeed5462c233f2d6c4baf3604a936035261b0a49bc24446c5bbb2e594d66ef4d42bc64a6737f193fa067a679ac95e2bc6fbfb62acb4b6a3fd3d4f371c42aab49a0272bf784ae8a629c5783e2ecf7c7e990d1846a983f2f7edbc23cdc9b2fb908f5eab2b822edfbe01a0dd69ddb65acc498f39e931273de6a4c79cf498931e73d2634e7acc498f39e93127bddfeba4779cd7ff7aefbc0363fa194ec3d8ff00927445794690840efc0884543735dabc24b41884c42024062131088941480c4262101283901884c420240621fd5e08e93593e00a339a2c2d79af0de4c033ab8ddf29069423552aaa5d17caed614788034673380cc81a57be76de4cde3b2ad98e94ca07afc26b9060d08dfeabdd26e8c6f1b61510144d528a1dd134eeac4531a2393609b0adb1bfb486441bc8a56ddaa947d3a952743c208696a3c0aad1c2837176d82cbf2ac39eeeeaa0a9f60645c05f5665a19b8f4f162e1c4237acf8cb9a3a4996a618dbcfea732a97634d0ed8954ae82e162b8dee6a112d7c141b052a658223a3a4f5abca396b3d2ccd5db5ebc3173fc93505bb8baa9e8bb6a62e4adb00fef482531d0e0798a1872b39d676aff852db3d6364f62156a5fd92ef67afec607192777590c968f6663a7ab8445ef39bcc9796015ce1e00b8942b841aa512e2d7d83280e460f7e32fb4f8e4a0a7b0603a4ae9ee7bb3fb6cf31df5e6cc2833fe5975026e3c8c0dafd054fbcce0bbd514f52549bec0be39fa8e35b69e8812641400f04f106ab1c4512a43b9ad0b29ef4efcbb19e43981f746d9ef8f67c48ac993ca3070fd9d6387eb36e7dbc41515ef9810eb72ff92c05ba830a3dbc40dfb8663f75098895b07698d65917e0a16e3358da264e91a097deec595b1c0f073a1e5c72d5864e75d8d6821e2a143bf4f089f8793b5139e89b259fd3bc2e79d0835f4efeaf27beafe8ecf130af39ae0e3c82fbd7ea4af373e349e29836b08cfc657d0cf09a1c691d0bc407a91debd8e40f751c99c6932b4c448ae7bac69b32a3b22d0ff4b59d5ac29e7f90c7a56d3f92663493032c8c73db1cee47e664efcce00a4584bff419fc8414b8b515f884f8556e57878c7d5897e6a77c87dbe472c84ead0d5d532a1cdaefe82e31e5c7dbfedd740af0a9eebba6011cd58068fb4a5c090357354aacc2c08dfaf1b9be03b974797a604440b035fef97edfcfbf2e808eb5fe151f5f3fee76635bc337f5e890cf706fcd64770e27f7d64ca607c3458e89218a16fed4dc1114eb93a529d243e64aedfed296aff1744c317285eac095b8e2751c37e86180883732ba73cf75bb485bdb14e9372bc20aac0ee940919451398dccba9c5e8c49357d9ee6d57789ca5fa5e31dcc4fe53dd2ae5c7eb2a66db88c8dd4557582fc1ff5c7fe162930c426c9ecc1040fb76feadbb02a973101f4d015d71a3feb43c7f67e3edebf9bf745c60802df04d25cefd3efd98997fcf5ccebf9d87e69bb7c7968bbb32c9ef7c3f7fad2dfa01b279e065271b93475a2dd1f0fbb128cd29ec932d5017c7fd6017f7e2e937625637a201bedb3b602799bd639eafc4c1fbab44b3cb9f4a553bee6d4372223b3ade1e659fd2e3a3c18864b739c533eae49f688271b371efff532f487ca74317e56ef8f965f27b64a9e96964e6c45966d15a66e3cf6178211560776f569d9608a84eab0ced7f8a62e3d142982a91be15a998cad2dd0039816fe623594a7a53cad0e58b2267bc794e83ced2ddddc237507dd28cbed482a31df2f6df597e8e4fcc8d7d76b7cffb22ef6275b7760144e29abae301496d670f5411dbccc952c3b7055420f014add085507aa39aab47184f107c7f55a7abee51ba7f4257a65cc200595ab4b0f38aa8d19f49049c7d4e90168855dca826d0e139797d6c3edaff299aafe9774f489fb01ae51a7fc29ff29d815852edfba69dd8a5082cfd6c530ff29e63fc5fca798ff14f39f62fe53cc7f8af94f31ff29e63fc5fca7fe76ffa92b3be0d77b51d5d937a9b74b8cca9bdc8b52fafcbeddf182faec53752b7cc4a5eacafa10c5569bb95431972ae652c55caa984b1573a9622e55cca58ab95431972ae652f57b5daadeb70f2ece551424d1d495ef982d7fa8047bdbbaf7c733f9d62b45190f74e25a324002941ee6996fab1d0ad43c616b98690adc6b0adc688ab4b2ade5c68d8dcc557a3905a93d0502873a40dd2d7c2dce6f47c498cd0750d2c25e340ce59002c30e05900793cc368dad36c86f472b421da94a0ad03c5ae0812edcb7a769e40a9aef50a7aeb0b77aa08be367bd5d454f860459064182be7fb440faa594572ee5a94e208ab6fec81afb23b3e38f789de0502ab0b9cb3405e4aed96f8d2800652efcc9ac97e3b2170fad3cc1037d6bf1938dad1a92a618b75825b96d48c015f4c01d40e486e8795dfef135ec859e901573c10074c3375d25e5a305362e75ae32c5276f26026f300d9548dfb8d1e2e1d7004c4986dfb7ef28c14fc2492dd815e00d68b7c416104187c1490c4e6270128393189cc4e0240627313889c1490c4e6270d26f8593e8a4fed7a34849869b7182bdcf6b2ff3d61b270f9338fbc092fc37d29cac8edb0eff0e90043e03f1336ccd61abcbf35d51ba69756e615b9484cfa0d505e00d30e94fae978711bd4f320f715d28f1621bde8276839b5561b123b53a1002e97b8393c9ea50921690da3498a055c67561bbc129d75c40ab05a45be1c244846287e73bdf2924b2e1baedb6003a0d4e0d31d785008006a7c509d71500cfb7c16df59f068feb0a02ec741adcf8c3bc27248c575c1736381d7b9bca4e9b5d44b7b8e4667dfb963a185414d6b76f455c641ee6baff020dd0007f7cff8be6585dbb9e5966176d3abc3cd85e759bea62175d2ca1430f3e1a42c7e6bbb684b806a726fd907819f7ffd8fbb72e476daf5b1cfe2acff8dde69f0481a92a32c6be286cc4c1052e0bb404bae3e0360661d3657cfcf4ef10b6cbd5a7ea4e9e4ef2dbeff64dd28585908434d15a9a6bae73e9cfd6fae924ea0a053774f82174f8d63abeba7adc8389678e2933264b0ef44bc13c91d9a0b88e2772d540791388d3bf7b378c72e62f6f25bf9e2ad379d648578fbf712d2813757efa3b9431167bd1c73a1d3ed14754521b2bae1d94d9c25cf459991b38e48da8b90d9be1c29ff7ae2727d87116b4bc11958c9fcba5bba56f07d1731b8e4f43f385c742b677310ba516233d3fb738be2dff14cbf2749ed978c1d94ed62f63ec8e3319e3c250c8e35e5751d65366cd749e6ab0e070e92bd452833297b1760c2bb26f3266c4b53b94f45cf73e3e6fc31bb1ec63ffec4ffa2f6636ae0a7baf3f0d7b5d49d9dfde6d360bcd2e891f653b3aceb0e4826f328dac24c7dab551390bfb71395cdb3690f125eb4c0dcac2c68bcca6f32932c1b5f1860fcd8e33b4cd97f53c8be1580cfb7b3f1b8fa0cc6d5ca531d15d19f7a1c9b8c352e42a956db83c47729f251fff6dcce44af2b95d1b898c1932a650148da8fa720b5349fb39516e337bba19ca6cd23206aa923122d7f77d8a999031147dac62471be8b34c7336bd7387fccbf7f0782a2fdd89b943efdc11ddf9b62279dcfdf58c4197699e2e3532895abf5ee7b1b9e20c49defbfa290e24f7bde4aabc1f3ba98c291b3e7ef16e278bc7e68bf7ed74c650c63b2dbdb26fbb4d046f30ca9c691f13e10ebf327746abf97879890dbbd6752edfafa74ffbdd7e7e6ddceb92aa70f8279fd9c70a2d03a5cf1501729dc071b230ef3f4c7f16e75dc2cf66d7fcfa71337b79e3657e770bf195f297edc3401bfc180fe58ddbd2407737699f9bb4cf4ddae726ed7393f6b949fbdca47d6ed23e37699f9bb4cf4ddae75f96f6f9866970f54a80559a516d44b1523e533435dcc57495a9fe9cabbd67622bb3deb943b4f94c19a1cb54bd95d1ccbd352d157398becd0f685f3038a40c0e3d39c56e8f99aaef9298ac9ec3e2fe6929bd0dfe26b58d63e128cb71e88e675a77e85578c2c70d9584924688fcf0d8f524946f9263a69b694ca445bb2b9cc0f810d6f752e5445a658906876cf8d891c36327eb09878f8b692c152f8c431ab727ebbb5acda70d947923db79eaafcc5e9130d44a924aae96dbe280944ced44b6a817f21ab7b1928408e5cd5e648d24aad0b9a761c4634f7f8e2521e6f4ef0fb1b274875e4f68c98fabedd381dc7f3ac6c47852f12e0d0dd50f8d7ebc66a13b3f9359eaa7da408563a2c2226dbe44c69326c939529d028c0fa1fe31578d8ddb5bafc581c78ff3a7d89a7b074f7a17a4f5bc98887d363b9cc832de507f2e622233175a69ec191f4243128b26521de943ac6cd265b0cd16eedc5b24f36429155ed6d2ab70cc647690a124214d3721d31569c9266c6f7c98b675a6ea62b8eceeb9f45e0c91f1f3acd8f665b55d14b397f5fbbeef6bb1577bb577329f0c56150dee070f03a4dfbfe7f6d6fe50b4dff4bb7b5579d0f4bb2ffcde6f38379fbbbdef34a4aac6031a5cbdcac6e041d11ff46fbbbd1f3ef77abf3ef9f34ab4efb8bd757570afdfa917b737ba7b30b4cfdddeef567ef67b6b5ff8bd4f2dfec71cdfffff6acfbfd78ff72dfd6cb310c5ffb8a3ff6916eba6afee5be6fcfff71fd9afe273cbfee6bdffabdefb2ba2fcfc13bed7ba7f6f5679fd3eb2f525fe57a076ffdb4033d4c1c39d32f853a06618ea833ab8ff1c320ce54f80daeb933fc79dfb1f0235fd5d507bb7f233a8a93750bb81da0dd43e07b513f0fcddc8f67bbdc966f96af961317f1fe4de94bb409d3e40f717a81b6877ef639ca6fd76a76abaaa681afa02e3de9e3a7c0e728621b76dca678405e56e80fe0c61e1f5d99fd5827e6cebf670d9ba699aaa0c3e47b9772bff2665e1347cff18ca7d63827d867dd70975fef5c65ff82fe62f7c7b2dbfe2c67f92d86ca181432f2727d3e457d6f8555e732965c3dec86d9e8ea7656a7c2565bc4d54ac4819d92226ab4cf3dac2a94f0675b49a47d8df15d63ef26910022363b0c590608f85022280764c23d306f022df213e3d9aca545def431140544148a86e5128c604af76a40a22a0ed9055e67a8a30d0daa3a9d26e9228b033d53a6456f9ccec40a3acb369e3ef58ed8584f218301f47cd7407c28b01bc1880db04bc843b4595819750156921aa0ffe082f08f25c68f45d24f0472a44488437e6a3c73d019ea4961112000d300ff3065e0228163e2b31d474ef230254704122bca7820c23c19d62c469865b958fcc14c063b4d6498ab84b81d8203c925b9e2cef3320135031cbb18832c14b50f83847f531b708f12d4e292a6b02c032ab8bfc4a9414152b5fd4bb807a00c04794ededa9f01c6eeb21d4de90d630211833b2e4c46fc8860a184f056151ed8db2ba8d81962cc7c1476ea1daaf441cb14e0981c4e112c0573cc26919e635b273ab109963be244a700c28ea68a39354e16d12e1adafba3b589a517ac47a244a3b1001f8b47c06ec7511e569a4120796a49ad5e2892a741f8ae440a2a04cb542e74048210a1c895210e145ac1147bfc11f495d00d42d4e10d45404ebd41124b3781735ba1d81379a30bc0075df4588af679863df2902684a2f520af01bcc524b001c05e5186f03e6d9612c1699bd469128592af8cb84ed535ff1808a322810c16c59b28c0e5082c898609e4435888cea3e8cf00e50c120329f430c07b0f8d2af955daef14566e92dd465ed63dc8515d4a4e6fb44dd875409ba542baad06a2940b9f6717d882a93867639e5381f14c8b3d21154109987847575264417d6fb122ccfe700eb4cd1b14fbbdac7dc4f549486c23d8611e6bebadf27476f9761ff506052fb8cac6985c147589f585d081a4151d38d7dc1ad696d3cf9153849e561dfc6096950097470e0565905967e1709aff4818f23a633620365715992d83c44ac1b44587c8c9aeec9578903366294ea7799d3468092635493c98c796b6ee3c93436793432835c041b5271df173ca26acb0a3bc0549822b31f14b99e7de401a93d0e11de03860969a63bdae8a7f5c7da2105cc482d7cdf6a8700104ec56a57381e054a155a997886794c6b5efb988fa66aadfb0880d53a81088f28143cb0a73b2ad7736cfa49857761835dd6b434557848050ca60880d490fa0db129ebec48785db8246968b53613c53aaa0d1c4598129b682c0a884f75e00e0f88a26f1315bd84a83e722c42d2109f2edb71248a8f10051360651245c18ab1c099d02220b567456cad6798b34cf3527f4922607a9ddb18478d9884cee391aa4881da7026513f263b609d9637380e280f81b6a384020f3021b95582cfa67b10b0f551f1912c81f9d0aa54a17a8ec98b4fdb1ad4dd8e895c4f11d990464f7de1110e50158d77c7e2a2224d9926828750af7751152ca021296bf6a96f136031af88ba1f24ac0b731bba99dd8e32c79c82202fb9085e42d13ef9b119441426a0e82f3ef5c0b714c41a0485a21ca7423c878eb98968396482d8242ec28c953b06056336a6b46e0312fb87280a8601c31009ce7dd11e230a1382939def940141e209ac729832fc921cbd328bcd31d405ce6b1d67160fb3b8dc03dba7990a2e8908c9c0db720b56119eeea3a5087cc71cc0c824beb53ecc9cb6f4b1bba39519fa0cd6acf216c4d2a751b31ffa0afa18451e875a8fc0c2bb1906081b1c93a3e091d3ae33e4c5a40e26be1d1c3826e3025a97d44190d9fb6d52e15502c11dad0910b5d480b5762abc51e1900844ab46cd7e97817b0c2b730211c6b432070945406b8fc0c87cba7eef384d115ed2c86414719c594504541f420dd0ff2ebf77ecc1904eed33ede830599857c7f2d13a0487c1ae4f3b1cad9420ca77bea4605d6460edaecd9ae9f88d44fef91b7dfe7b74fa465fe441270bf325657a2d9f57489a58b53a51b9624fa65116f90195bd6cebfc9367488ade41a67ce6b1df258c7429d31f53556cf8632b23550f52dafe8905db6c49c4cc997eef9ebe0d93c545da371fe78e277803b29e4aca93f6d4b1654faf92b2acddfb6d19c83ecaf4d227cad7f051ee51da739ba494744f7d4c62229e7a5952ebcebd8cfd118fa8803a14a49dd62484ba058a200d85b5230242b91739bdcb40aebd08a8a224ac93dfd6982ecbd4b7922345f2dbbada6556cb19f67ca8614c51e0845550f9557da435b799085e0a87c7a9badf721b4d2224b18f5342db90425913c43108a0405bfb8c7d00b423cc2e27f4e8e199e05d66e398c48f7b50f72baa7a4e547102883bdc69870110b9170a20c20050ae22e4618830658e193380d457909b593a2314068079481bc0018510309f70b6dfa59800159e98c6a6c3596703c21db70c962aed30a9704898f7128c309fd57a94d4c51858e052d1c624229a6c4fa8c28b0f41e9abc4e123bc26c0d74478218144a14c1fa728d9e54ec0fdea719fd4451862bec92dc47cdc1ea62a1a33db73a1d62360fb1145c56e2ac84bd8e8a5aff06d1279ebd03292442bc819dbd3a9c030a184404d150ab4df2b8635497d875854eeed04a6b4f62266b52441c578665b47b68445c64a4a1104a1626068509ad55893bbab6913c4f9d10b412bd7116bd30017d1842208717da40c8d13101fb9251644237bdab42181e025ac03df5f9a9be4180c0b6520f7961368880256312804efb883b95f59c7a869570c5a925b5e99c5e58e63ce0a001a3582faacdc27882c7d2458b42c4a62976d44c9d84745c28fc042dcde25a85cfbaad785420460712ba91e11287a3ba15ee08b76caa008530575e9d10c7c680983a2a6e059fc884759343d266a37c9b1bb0b1bc1fd8adc715b4109d5bbb086d26ff68c2ab0038c5966151c046f59a3afa2c68bd9122f08600dfadfb9cd8f410de0059ca13515ed8bc442664ff794b5eba9f06cdab455da044f11e5930207f67429e767b203eaebac210c44509108145048e5636f03b51765b1e982bade05b8c5612362389603ced6cacc422fb02c273ef5c6807d9d09ba0ba004bf1214105f01e2d184063150be8b14bcf59917d3654040d19f00ca49647b9d1f01f8c252001116017168e49199684954c324c3c99e55e604d8fa9828c4f4c1dd05711b11e1ee392d71de78008284a1e56d8112960267a91344c0ca3b1ad73a51828f9945027a14133e32d704e47af64816d58845411d228e893049c6c83334dd8ad6060e6b2f4d9bf2bc17b1766153126613fbb25ec2b85810da0ef928a8095eed33ab8d7c95a49475632a387045e20119f391195251406eed177e6c8e135aa6a9202f72bd415c8e00c026b03a708bcbf532a18d1e32202e8bbd8a288942a34704aa074c88d4af700c55304e456ba54efbe40b8a68cdc7095defb9c6098d4dc4002694ee8eb9ad07a0283b0e7c170882e538fa883f53b5d6096e5f42c183107b0788f03ac045cc1dfe4c6c5f6175a1508aba30324b7a1431445ec8584033272843dc622af89828ca317344348b7d246d3f42f58fdcc193f40808946230b3094014f0cc269c8a1cd106bf047150cd9a2902b61e04d8df930a2a7f594e59d3f219e017160b4a5869274caf29c334750a3a136d99b0ce26c0ada968276097318cbc2a44a40b46046631b94b9a6e1dd9d84931c4594c2268ba31206b4faa80a7756b27ac4b3308f4495c967e0d77ac262b2adc1d599272d6ecd7d074caac467791682b9f19037a7495193528a9f795afb8470eb09b3664993b1012a56514f81a7091901ac20cea43c4d6fb90014c6241b33a51932a5806d85b53513ef923d80048fc6813687000a8dd244c5f67082cd608462cbe0580314040a7715b93a624112a5611081a46de28b37d2582724d554c99f0ea8c95632e0ff50589f22329b35a0f60e4ad43d4beb0ba0b89053a8c3099d9c19ad5460cb43d5255df85981ea7f53e06ad745885578100ea5b9d7ffe5eee89202f9415c4c73c821ad6040510d51e2300e7efe99e64c73315dd0ed629834d31442f3c7edf674011dd115600a9db31345def3398d6fb508ef9a5dedceabf2376a2801d0afc426a2ff22d5dda186c5aeb98d46d9559de8031ddce1bd2053194a4d6c7346e5744b41d5b16416897cf144a3baff51758062958f58153fe5208624f2b6fe25b6d0b2af27c143833c71b65b1e944358421c22fa1946067e42e514ad3471e2311667e8583a4ce7540ab436eb70b66fb8748e1ab0ce33877829261ce92668f6742bc4c4094594d95a8f26cb003871f094f6bac4b9c082c83d1caab7c059e3894750162cdb53200aaab60efb95fef76246ed3f42856ac2ec88c614ae3966596b762151e126a58b08489afb45602504f91e8a225043e6a11a86d9a82e7148ec980954f109936135e4704e719ac14c07857e0b6cb9d202496c7a9ba9fc85d2c1fe190c47335016e67e01fa48de2233e8a90c421ee444b3199d51e4d04ac287377a4d9d7a4c62aa0928180cdb43616d0e4c748da6a80217770e8c7e624528a70aa045d28dac5ac4e54b05b3c032f21510084ed27d108af019335c764e1533d0585a750ef76a16809a86b2512f93e553d4c9a36ca2c7e478fae4c0fc4a2a67b26cd7a0f160c52457f89a44d863d8b095e834a2ca05d0436d1224a5888c926aa050b71a22428500aecb1a8868aa82589443106513833ab8d424bb7a291c903c07724e654e234adc1a60243587b0bdfde8ffa7d09e078120722b31ff64c706f26008715874233070994e3a2991e26d072bfd60fc9110f53203061789145c202548e991d6cc812ca9968475184b12fd3918c780988c700b0035c1f735ca6a1bd3b86c760482d24c70798bd462c6e89cfbc2e738288585c99aafb175f0066c24ce577944219f86c7a845a9f849883ecdf54f5e8b4113c55f88aa2e2a550104c9c36cac0d393a538fa8800890bc6ec1e47ea00e0e3b4d60184754862a1000a9c0914915c0730f27088e08e55c07dc7ec7138b3ad63ea1415b39423078a66c2dac3d24ce188eda4f206993538b0b8943e2a44a160b9a57f4c8e669d59fa2451ca09b5a707227d04d0a6501783a9e06c2a0403f9bdb4cab46040724b8f53e1bd30b6dea7cd7497e2a2f295954a153e2e14651fd5a5c8280f58e48d67b87881ca7b9a353bc4e5be4a60bdb0bc4968ef3fb2653bc980c7a9c5e5be95d2c8dd83a4f02c214cb547c4a1ac66b8b058cd47598db484923a1300d074142c7c48d48ee48d7f9844b82611e649dc8e7d869de4187040ed1e2cbe8b1876a21aa533e1a51c97c1cc0ee299dd4dfcbaf5c12aea0c73805a0f7dc41d18793511b8a3751010bb78e2760b45ad33880bf0693b4c2ab39e220c131a4420da0334dd2e6c48ecc70480e53b26ca1a1acc485306a4299f38265e005e5c8c700d364940459359ad5b614416be66961c17e9ac99ee0a8b2c326823b08b1da0649762c133c1193468326301ceecb626586257374951fbc2b1e0109b53b6ac75f95da4a25880bda7ac325f668d7b8c6a116794779102a14f11f011a1d0ec8135dd3803b2ce47664a105740c08058c1862e316736997228b0afe29770c92322bc01ad4b1260af63759112b076515d2809801dd6460a80b5a42eb63eb41dc7669ad5484f8eae1e09dc45154901f85d54054121c80b8d5b0247e1ca7d1aa5eb23a3dd24d54a9b6aed9831b2ce1c08d29a1f124af5c20e1869f0c21f618fb28ef73e96a559422442caf42a6f823b6eed21d5e6fb88edd36903493e326b5f1107b9ff0c85e7040eaf49b3df0290a05009cb9ca2a2ccd0b8052c6fbc756a198c50ae40e5ad680331c4c522b3f411ad823a81c066352f01e341a494306bc0254b0088cd00a83ff08103adcc0a6a9d5315298c051f49643e13bb1ccaf12984076114481faa3655dba10fad3b8d5b96a2952ad7a7cf88935a1ef7153e01ca5986833508af84d8d493a317862cb0b31116f42848c274e223eb30b324de9121545e988ad58e455e99358470e9bc438513c69cfbb89d50511c43c5a044982cb45a4655a10496e184d23f2df725b86421039b5601491b5f49e4fa87d581d6469ad6fc25aabc341374472a4cc9d24cb8c59701edee521b4599d59654014631de30011c8e308e962d9b8940cb710ba1c40f28eac09e1eb815c4249eef60e4ada682c432dd512afd9602b6330098302420c21a8cf02480c0868afbfe08a78c09052c14874bb2206ab9062826818075b40c6868cf5550757b66073412e6286dcade9e6042508e8164b88d685dec72941cb9436a5fa98f10b97a2eac03c76d08140e128fc2c6c3e1b29df82350186bc1a7ba152ef984a0f639627a5aa86087712900f167903e7bf08fdce10b50d013ad3c163510074c673e5dedd9b2ad3369f52f83982c1ff780f97086ad7db8c47156272a55d18e207a2415e629e223b0f535036b0f511011edf190a0a28e0477d9529e4474779c9230479ec33154a1bdd7e5fe803048c206953ef5ba44943b1f01e35af934135e5fdfac216b12b71550fe4259a7100539ac160bdf2e3188723845c941daf2be62494b45074b5f67d883d00ed4c8698342412cac8d7216892dab820928c14bee1493d02671420be56437051561d3fefb47b0bfcb461c00bc8f898a148a89134558ee0fd548e4039f113775e4f7a4de71bb5be7a8e852bb1384f263a2907a06c0a0c18bd01a1c130aab9c1a76a6747516d53b6987fa9833b2f4208bc93344d89c89e02e1b0199d5fa9aa2a29ad99e13003022bce7a432277e0390db1d63d84b61648601069c1d4d96b17d04b44c731b62a84b0e35df52d6ad03e6ee69ed018d1ff7ac2e2685ed49bb3a06d4bab42e8eb9e0eba8329f7c7bb7a74abe2f14dd496d3df09516c02a7705e098d6fa821cf19663bef35582a3460f32e053b9df0deda09bd9ed2255a84e31de5e7c9200fc99d6c10fedb7b3a3f293c8a12fb3f391ed7bdc824ba10bb1e087d4d7eefe50d01fcae037fd6e706fa80f1abaa9afddd4d76eea6b37f5b59bfada4d7deda6be76535fbba9afddd4d76eea6bffaafada6563fff359cce79afb2f74b1da2daf2192ef876a7c51fc6273dc29ea8f88a7a87f28ea1faafe9b8114457f30d4fb9b78ca4d3ce5269e72134fb989a7dcc4536ee22937f1949b78ca4d3ce5269ef2ef8aa77cdb38783544fee31ea43a2b117e43e7a042d92b7e2ecc90c7e6365f4ee732410d6d6097699e4264629a1819c3f9aa720f665430d425b1a70fe7edfd4c9347a1857087fa3853bda3d43f192fcc4cde1f35b8e33132e2705ebdfd7b1c3eaea01165d2ec8554949c1d74964a4a882db542eaf91877b2ae626c974ae198c7c9e2619b3bdeb638e8c7a2f137895a6f32cd14d93258a58c2b4f8d71e08787dfd3c6583cc76f7547ea76a675226b88d4fbf844b3256c8c854c50345cf895ebbcb967da8e33d5bd7371b74e99fe1287e595baae99874ccb37b9c6aba726689f9ab7da32fa366ff2edb3da6e930abdd52b6987f3b6ef5fcaf63284ecae7f9ee06dc2bcb51c0ff9bba485f7cab9c7d57ca6752d5f3c6e5ec3d69681f141f64b70912f833653f5e38718ddcf5458e72a181fa82e130549655c9435445edf70a7aff77ea68ac61dea76c2c4ba883d3195216dacd8f67a3597fe1fccfe77cea6f37c09527176310bcd9e5ece55e3309bbe7dcfe47a3ddccd3d3bd8164c57dcd1ca78ef39e3fe3dbfb93746c6e47c2dd36053c8f69ef559ce7d3bf561d9dda72c993fd5bc94e3973505e571a07cdef6b39e4f9fd849eabce40dde70957ef5be535b74f9ae5f9ff33a4fe24b1b7ef211e0ef2f9be56f65d7881f34d5bf2c7e31d535a4283f62abbf39217c5014dd78b8d9ea375bfd66abdf6cf59bad7eb3d56fb6facd56bfd9ea375bfd66abff77d8ea5f6ef7afb6faf3105b911250062676ed52c9d8ee9761b55f15365abbf6becc9adc701bd8b8d560fc6c97226153f97bc76352ba7620b3741c793c35dc25287ca10cfa720e690b7b2f9efbe4b4a77f7f88958727ed7c7dbe7ac835a26736fd65d8785beed4f3d9c16c32558620cb7020b19e45ab79aeca0c25488635b7bc5acb4c2807ae8acd64f178e04cb977ed609bd9c661b278acfcca3d4c0ea6316ce67b77a8ab3cf6ea94058aebaca5edba2986e898c6ad982c1e774f151759b49ee736aeb92d36fcb89afbc381ac53fe1f9deaf6b6794344be40878491b69061d6a3c78daf4237399855a6eeb779b59abb2a6f64db64287a618b2a61bb2e61fb96ab833bd7462db7c99a47ebf987e96a93dbf898ab32d3c77a9e34b84ad5e220ed539951c33d7a2250a747bf3a95e50d6e33fb1b65555ff5abf9613292655ba5b7f543f36d9b65fbd6496c1e79b49a27aab1ce6cbccb557aefdaa8cc9ba2cd1668916944c954e5ce1d599b80f5fd32864bb27a1a9a0a1fbea9af5acdfdd81cb88e1c6f51bbc3a04a54ffde75cc92dba4cd9afc756ce4ff27765f97c2e3527962789db2623359986bceb87279c6d7fb951c791534fef1d42f3ed43705430b1ebbf3d997f51d5256ac8ad318d4723ea66c7f749deb3c7962deb670c8368b5673ffd4bf45a679358f5d999d653793baae32f47df858256a504d0ee62e61a496f6fe699e403f4f3e4cdbe3c52f25eb4f34e93b91f3cedc65ea2949f4e4327fe3429cdab59a278db1e6a7f6edf286ca3e2c1216bc70cd937e8d5ace5b5e154da026dab79e9d695ce40d5f675adecd46ab791283921dd7f3422ddbc2a65dae996522b3cd38e6818748ca64ecfa31ae76dbd73556edb6a90d251fc935dcaf37b986577ea44b5f124d63d2fb8adcd1fe98db78e3dac13a8983e373e8bd5947675f8930cb5c0b84f49fbccee74a9fcaf29c91abdf88f53e15c187ee2fe43c975ffd20916c9b5966d5e0e1499def7b3c18e90e67bacca2a3485f91f4e91115363cf6cac286faa9e6dbbc4165317c5cf8d5e32e58283b3f5476fee2711f44ab5d305aed82d00c289a2e4e58f3f88bf4d514aa50d2e1e32662a024bd9c0196feba8774e4fe32bd4835c9f119b9724c4e6b29ea31ecf05ced9574a47b64b4db16f6fc976787d43da6d5c1ba90d98362cf230e329e8786c24703896f07d997c27165d9d573eff7a3f2fa4ad6c547fd788f2ef212179fe537ef7ff5c90dbe59c7d58ff7793daffe39c8ed426298f8a4ecb57fafbfbbce379ff7f53ade3eefd5bfa6bc1d3b9c2d799b37c6269321ab5f8c236c8a461c3255efa4fcc4f91b513f57bbad1c03f93ef26af020c79e36b0943e4cd9c77424af4d7f39fb91fbeb5fdcfbeadb0bb699d474b60168bd373e44d73aa7aab1c95563cda337edaaf6a56b9707ce1243ea457f717fe8f6cf7dbd174e89e49f8746d93fdb9efe22db9857fa57fc8caff3669ab0420078be6cf775be5c7e77e5b769f99d767dadaec5f3b77f7b7852ad5fbef5ce665aa7a531597dea67ddcbb57e910391bee14fc6af5fd70e1c786856598f05a2495970d6137fd3f6539be43a5e669aa7f73e58ad680b7b3e7f121e3af9e61f57725c65e2fcb738f1fe786289e70a95bae5aab1f9742c4fbfc9ec71491c7ca53d5fdefb76febcb661ba9263d59f279cd6d4676d103271bfd8702d78c515b085946f59e6c77771252a6cd85de7f3fc17e2c02189c99bf5779dcbaf3ee7b7cf7b7d17d35fde9e815cde7161cb2c71f21dfa86dbe828639ec817b26febcdf559fa67e33c187fb7eff2db51e9d3d4c6471efb12c3dfe2cdf9fa693cce59e33e5dcb32339f7ac5c574a49ffce7c7d777b47aaecfdf8f2b66ac9e45f092c6e68eb3e9db7bad22f63e9d73d77e5c75f4fb67c2f1cd1ca99f3ff5fff7dfb467db9acbec72bc5216ae66ae9238104f9f9cfbb89f8f7529ebe16c7f947fbffded8b79ec90facdb7f5b2b7599cf7c9fdf9c2751eeee51eecb4ae8eca225b422765855c892b87eb1acdb51f7bdf54eddadc314f7dfcfbe6c7e9cc68da7fcf2759bf67937b70d94e58178ecc29d0e385963742296cd83cc97dcae86d9dd7ef56b634cb7c19f4f5cbb3bf67e7f1179067892a9d870236693c9df346ac79eccb3d2eca6cb2f1b4e93c69e83c6fa0e4c3dd3c571ffa8c8369ecca6bc77326c852eec732262466c9fdff69bf50ed5136fa74de0093b91abce3e53cedd9deb75f60f11219e7f7d28f5512c97dcdbefd74cdecb685b4ad3ec5dcafcc4f74e9fff9dc8c5c70659e3269677d7d4e5ef658e7ec957d26ca4fce1ea333167d797e25ce6bfa750f2df720b9069ddc47cf22fded79e37cdcd749507ecec629f1fa7968bcee8ffb7b1def64fb9dea4779737a7e7ffd8a13973c0c12c7e4feb89f7791064aeec0792f601e78f406f7abdd96db721f27d6f97130fef25cedcdd9de09a3cc939c15ae0ae782535fc724d288b7fb008935edaca1dfc6e11a1f0a5b34093b9d175e705daec32434577903cd73e8c9b19bca5c1fafdf6661caf3f0f33cd15fdfb5dc2bcb7adc6129efb9fe6d73b9773e8eaf67873f840f99e675499f0f8444d2eebdde77edcb651e4afc78fd3e0d8d45a6125d8ed3937af607ccffcf4f3eaf5c8b34af7fad56d90f1e587ea5fce5c412dddfbd7360a9fcaa0c7a3178f4873af86370ffdb000dee0c43d51efe76a1e42fd5e02f8fbe5672f7707ffff05d35786d80344d43ef66767eb7f26fea24a3fb7f520cfeb359f0f9b9ee753e5d0bdc2492ff8b2592cfafe9f7afacceab83717c22a3b4992d9459b8fa0a4165f5f16a54fa1f7be2c6e9037125ae687233b5fe82b472d974458db1e1a1d9f48e24e70a6cef133cda2fc81de34f4947afbf830dc7535225657e3652c67f3fa9e334aceb4dd3a42f873f05945fdcf30a96aafeff0058de21e3e1ef004b55bf81e50d2c7f0a587eb142df02a62772db381443d3cb9ab3d7535ab78bd518ecb2cdfbbce9272665a1e2a36b17226b7a6b5ee69297b9f1e50ef0ccb62496bc7e01c3ebeeb5dd261244a7abce1db66f77f81fdd6179664cd22fc07afcd3c14e1e687f0fd3fa221708fb21010bb5cfc36dfc36d091ae180f83bb9b80c54dc0e226607113b0b80958dc042c6e021637018b9b80c54dc0e22660f1af0a5848b098fd0df2157dbd723eed562ff5af32165d2c96b3f56f87b411ef1b1adfb8e7d5793240da8fc4c6bc353ed4bb3b03dd62636eb131b7d8985b6ccc2d36e6161b738b8db9c5c6dc62636eb131b7d8987f3736e65d2be17a18e31e4c3c734c9168fde18accfaa7659af7e23aa6a4e5f78733591308d7f644e11482b3c1bc508d43aac2c1b57549573a3e9db2edd5ae2dc307c88a87efd4e304288d89785a9834b5e99cdbd02431ac8bc755e5da9ea488c91369911fcc2a61839eead687082c3d196a22b2853c0577e7b9668aa4a7e3faf394e9c7c2c69b44a5f3c2113b1efb6bd7eec48c7dd2261966a34aea71d618350f8b9e42966ba46fd7534cf4dca6f3ccc60bce769be1c21fbb0793a6523a83152b49d9726d71746da3716d6bded3ead4bd703119d1a1792c240d9729f38c41996b3273e1749e2ceb791acbfe27c7a785e9cb83aaac09f4be9ebe4d8ffa445292fbb10e44b6246d664b6a9f81b2663a2f1cafcd87e6964be9105b17455f2e59e607934e91f9810a775ec893fbe1603e0dcd8e337c9494c99e8a3e341b79ba9f1f4c2db561e3da42393db7d8e64bf91ec84a8e6f1a93e3db71efc7cb465b491f2cecb2cce4b371309ad23d8e151d032e9e69dd7d00cbf00978665407f8dafee9c6b58c2eed6959d3b97c4fb3060ed9c15413b6473d8d51b66f512832b48ab3e085b3e966d810c11b8c32677a273351e6b651836d4c33f5f4b73b3495b7ef81a89e941499f7992f878ff29d293253e40c1ef509456da279db5c852a53f5ba2f173deefcc7d5dc75cc2dc7644bfbcc9aa2e291cc86f938976321c79cabe5ae8889932ca56ccae3fefcecf5e5bdd151ad474c5479631cb9cc6ed990be9c3f7adcc9b2fd73b5c064d54fa9f7d0d7db5ce7289ce6763f26594437337b2f439dc425cbe597e3f6cd3628afbfdb7b495bdf249a984e2211a42c38645a2012d5d870478684d1d3d85deb8fe43a4a59f0fadc6fbd9fe07cdf0ff455f5478f97ec5ff2f9c729acbed787e38ff7c142a7facd2e080787ffc5b8a17f64dca67febb8297f71dc94ffc5b80d7efc99d3bf3c6e93c71f1e37f417c6ed4fbcfbd37cfb890482d37f7f7dd92c97b3972ba9e2fbb4822f6eb938fbeef4fb777c7dcaaf8afe2b522334f84333fed094df9061dc1903ddb8ffe769a5af8f7e5389a63cdcdf2bdf634a29aaf2a0ebef32a5deadfc9bb4d27ef0fe71a6d48527f28943f43aa32e3fdf5852ffc52ca9f756f375579eaba0b843b4751db3cde50e9989f570198862f8d8f11806c3795bf1e1e362a6ade74f420a8575eb4cc5758e24f97e3e9764fc5e70ce0944e1c04eee748b063685dd0b92c980f6f9589b8f33d6d53270e4c3e261d3e7fd9eb62251cbad3b44c21d4a72ffe32694d77be4e42d3f98c607d86fdcc5e32fae33d83e351d4a1abaedd1bb11cbe1bcdd26077329eb97a27369035531348f898ad73c44551a2225530db9c35fbacefec1b58dcab583036758e1e163c717666f7d64b65172c7df9e835fb6b91a94994dbb44adbbc2366490f6861fd0263fe87ac676e34cf5e7892603e188709d40e44bde262a94897a16587340eefeb799369d3fb1c1fcabf5edfab697dc56e497679d32b953c7351fa263a2fa5d613f747d8ef485b9cb1ba1ca00062ead9e21bafbc1faab7cf869ff52a6ab323054060d3e35304818da65369dbfbd3e6ca04aed87b9dbecb7721c53db381643b3cc96412b03876255be1b5d5a7032b0cec86c4306e92fdcd1e097cfde7b97aaa4cd17e62f4f07fd98abf371ae05ab27262da542648b7efc2fbf6d53463ec8e0247788f6ee104ddca1bb1836c12a6346ed8e929d3f7cad673b9e9fe6d4533c1f0f9732808fce13a61f53c9810e659fb09e34c6363d3c76c532993fb1fa933e4a1a9e3bd4a7afe5aef3783c14014c950053e41bdee861fc3a1e2240891a885cf3e74583d705a3f34c4d247f797e0a0aa2f2fe3b774822a0329b6f10b9f356cca425f4e6da4ffa4a77dfff1e776fbfbf3f48f343ea1f03fdb7c1fd9d3e40da837aa3f9dd687e379adf8de677a3f9dd687e379adf8de677a3f9dd687e379adfbf4bf3ebd2ee6fa1f9c97a7f4fc5eca5fb75b66f677997768bd5f27c82f7aea5f1ad9b5e7d7fda7b51925fb53eeeb4fb5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa5bbaaa7f395dd5fb56c2f544d13d986666c3a197a8b3cb529e20498e178f4b29a7d6f3b50afb41cacd49f9baba883d297b7c4969b4e50b53242c58b99631a24353ca3f6ef385a95e146c9e16a6992deb79a69195e4d82552deab177090f2b466294fe5a484741a13bde7d039459937ba7c86e4d9c9ba2a299927e53533c9efb0b1e2da525a158eaecdcfd2c792336796d77e9cb86f6fd4754ecfb30d540c4d71e6359ea41397fe3c53f5f52c3cf1e25cdb9032c08734266da2e2b56be3358f3d253f0cc6ee6e555dff36bb24ae2fe3d2660bb3efc72c3477bd0c64b893275db5eb9c64ebf283b94ea5d4e341f6819ec781f46d4fe2a994f896a78aeb5c7d1d6b59675ff6cc635c67c37e3cd055b6f62c89375f2d82e140717b7ed9eb38dcb9437ef9b7e4b474ae756a7bc6f0266185c8852712155ad9ee5cd1b785753ecd3df114cff70465d6e8db027b42f20e79cc05c4662d4f54f35dcf9debdb20f949de59a2afbf3e7c9c17b1b9cc1b5cbff2e986cafc557ab89675c28e6b5ec96de8a50ecf3c9aceb5e4e931583c36d799267a89dc3e6d9a94198f83208d0329f156a5675ecf53683e15cc13d436eaa831142a25109d7aec56d34d10ba3d7fe732de93c5e3c7cbbfe5f5fc2c3f28af5ffe3d5cb8ca53952c7a3ed3e57d1f57f37173fef767fd1e5ffbfd7ffef3b36dfcf59507f07ddbfe4de18b4dafde7f87cfa3fdaaea11d2fe18687f0c1e7e5390321828c6bdf62ff079ce4fbed6a123fd41551fbe4fe751ef90f63e9de79dbabfc9e651efff1536cff938f813bfc7750e5d4e8b6f6c9eff6636cf57d7eff5ab9b6870c8868f1d393c76f9e1b10b878f8b690cf20b7548e3f62ceabb9a4fa5c0b314b71c4e5792cb52a8e53661a875874a97abe5b63848de4c27b245bd90d7b88d952444286ff6226b822db7e9dcd330e252a09462c9cca654ebce5f85409128ee46ca22b761d3278438b8eb333a6e1286fadf40c54aa29675a6e68b0f613e779b6b02496f585e793d8d810a9bf63c974f124932b4cd1a21f91cf2abdef37f3226fb0a283fa09789e3cf9fd8c33c9562e5cb9edf6178da7ad333f90ffa2ad302e54398b74f2adea5a1a1faa1b12f98fcd2baf3e745cf757a154f25b6387c8895ed05e19f9af698a98385fbf8b3843cfbe5f77bbdc9667fd6f9fa8d7b2e388d94c17724eaf45ecf73f087aafea11bbf0d1eeed19d6efc399c4686aadfa1fbcf701a21c5f8364ea3bb9f0ad4cafdcf07ead3d8dd90fa86d47f11a9bfb1363f3193de8643f5dbcdbcd18534355cfbba6d3eabd8f78433ce70950ecde7297a9c4ba57c19f6525c4c92c5e0ba155e9ec25ffa7a62697ec9f024bc4c980cf198f62adec385ff6a929dd5c9a5f923cd0109a4ed5b93cb75c8d6b565368260f5b5b671b98d5f4ee7291bf475bb8e27325b6603a032fb82de932c871773878b2496aad7e0c88fc54541bcbfcf2ecabeff7dc892f922c7e489e15d2ab7c24b2ef285d9e68757734b869129d9c194844069566df8f0daf67318900caf9a278c74691cf4e623b5f74866b491e65611078a0c377325c55e1208d9f46462d9a4cd55a96c0df5f4dd7bf56d3134173c1c9c4c83e1c934e809aef09a01e4ce1dbafba7cad2dc7efbff59ffa599d39cfa7d0ed709334d6657817e4e643628a73022384ce5ffed4b6608a929480e05a3d2ac9abb3840b923b50cc93453c93465e418c9ec09f1f44d39996508b5321345a6ead3cc919940741972d0e497be495364a8cca318e4c75c66e435dfdc1373e6ad32d578916d7d0acd5eeb5066a04954f053c645a2892ab3e9d9d4ebc3f6c25e28b61155bf0991633054e6b48163a6c121518114cc5092e9f9fa25648ae2aa57a25f9ecd462c4d4f51ff409b64f8059226fe2cd4b7722392aba59c9f9b943dc890ac4aba1c0a478c64962c9959a9cf40a291835c1f456f022af3fcb5dce759acc5f609bdb6457cb7bede5436eb343ea9ef730bd767b5fc6d26cc75c18a365bf6a6f5b5ccb90d53b6d792581c4f7370fa89f9f98d3936f8d61c1bbf99635f5d0fc7d57cbc7c6dcf0fb6b97ddb66e93250cf2e837eedbeba4b6476afcadaf851bd90f38637501632db093db5499aca5c66bc8efdf7e6e8c74fe6e8f7d6a8ec4f732df3f394d737edfc252dbe633c5f0a5d3663a71dd0290a464583fbc1c3000dd05fdf93bda1e47ebe25d37503dddf2b0fca275b32457f50fe84e97c79f0e795dc1b3fb425bbbb6cc990941afe7c4bf66ee567e3f9e18b3dd96908ffb13dd9e7dbb01fda04fc5f401478af1fef5308b2cd4214ffe38efea759ac9b7e8ff92d9ec0fff71f79285a7c4e19b86d2affeaa6f202273f9ffc73aef9f7f56c56bc0f697d891b9eddf0ec8667373cfb397876429dbf17d47e177226fe803bed4db90bcaa1c1f743976f1eb4af7bd006ffc651c727f3ea33c0bbcea3f3af3737da7fb11bed5b4bf8152c7aa2812bf368c7bccd9c7a2e4366fb3c7fe77caeae73ca8b29c567784c2a3eec0f99cba49187e93dd1e0280f9ff306af5dab183ecddbcd1b6fc6ab78c325e4386278972353e47dc827c8fcbe52d8651e448f63e92dc81b50bef57ba67a1f25f981aae24e5ef3a3e438194d7f9270c32763d5cc8ac5a6f901bc7b5bf015f034fd06787f11f0b47fe3c4e0edabff7c877703bcff6b01efeddafc1cf1de64365ec2266be421ab29332d9759439a82edfab382c286328bfda5ebbc116d380cc6599f293bd825ec44359119c087cdd9a7afa0327782298fbd377e5d7ae78e5c244521a4f041f1addf1b63d18b30e03edbe39d3b9aee2651f27720dcba4985f801807b53ee15df14e3866f7f11df14e3866f377cfb39f8f666697e0e6ffb96cb64a95fd9d07d7583f6d84af5bd26654590c4e691da7088de405caf9af7d8ca23a1038fc9b77edf264d2b754dfa04affd3d2357f9794706bbd54b2d5669b17e1fb0aec5feb49bed473977eff8d9d09d32b87b501ed42b2c189a313094c19ff0b3fdaf600b0dde75b3bd57f7d90e1ddcbc6c372fdbcdcb76f6b25df1e4e7bbd85eebfe5d3efa5d589305fe0aa0ddc98d98f6f0c7c0f84d57ee54f4a0dc3dfce380f6fae437a8a30eee0df5870e0e90fe2ea2bd5bf90dd26e907683b46f415a0f3b7f33acfd3edfccd65db65ad5ef03dcb5d8ffa3fbb6f78f47dfabfb0c72ea0de46e207703b9af81dc1b10fac7e0eef70f2f52736359fc5acc5ab13a34b365f7030eb76fde7581c58787efb8df7e140effcb43c7fe06f75b3f74ff181c7e6f267e8696d799f7b6c8cd1df75fec8efbd32bff157cfe93c466fb96da9bc4e62e3f18aa1f3e56eed06c32cd9da7ff3ff6aeac3d559ddf7f95f3acebb79504a1d23bb5157159bbeb00c279ce0501aa2883afe0f8e9cf93c8a8426d976df7decd05ad400612c82ffff9bf9feea5766c9ecce1cc1f8ee60a3b6d50df7667f3d513c9e4324aeb2a1dc7141f77d8fc176724515dd9456cc7911e1e57cfcdea86c43d1e60d3ffbe83a236bbacba555d9951878fbf759c25e5c19ff445ecd52b702433497d116ae3fe94f8b0118fe10651f03edb0dd7708515ce0ea0d5177b439467c433b7ddc36e0b331cc338ce008014e2abf6a46253fcf6531ab95f6c6d8c077fa2b1b263b07d3b363f8fa2fa2fb4b1c44ba2b62326e10a37c7f78cc4e318671f20a6ce8c46e230b798c4a359048e29b688a23a7e069265e0b8ff669dccddb3ddf04c052bbc3b6b0483c82b3aca5ca0c8bbfc58a36c07d80f6e1ff587db81f87e6b81f03bcd5cef0e92f9dae8ed7a88dd48f4f153725f6a361648697958a1adefa7fbae6bce0c9b5ba09d907d26de9ce5eaecb4716f6d8e3bb338c348fe1ef62dec31d9e7880f956d80836cb611e6eb1de60d29c2ce22caf5fa66a8ccb37d3ac853737d65c6d6eecbfdd7c1a330945bbdd7bed3f96b9864c538b41dc57ce625b11fb947a4f7a45da3a3d90d0ebb32645573b1d18024f6d7381b0d72059c1567a5c22dc03e94eaa031c751048cd8dd61b0212e30d8431f9df6b1405e8fd1941676e39961d9377683411e8e5a20aca22c3c501d7716c48d658c2316d4702401ec12b38fb35a48e2746db02f47f34ab21ab968b7c1df4118b5b536c7073721a9691e5c6986fe4413b97d07471d20d97b5a36ce96d48dd6aba970330401f1acc71108b4a33994927ad83548609f2727f7d37e140e66df95210a38e2c0f1fbc3727f06bb5868e3ce0b623bccb35ddf934c36d797db178164602dd7b661bd8736ca5549f8c52af32308a3daf509a36a95a18411258cbe8e30ca2de062aac89ca55449e2d4f6720945829d9bb653d5958364172ca436ceedf2198aa6f9944375c36ecc3565eb60af7a82eea2b646e2766d62ea294168c795daceda1c3458f580e6eb88fa9a4a8fdbb5aaf49baab22579c20c8ce26d92cf8e8f9cfd08124bc4810def2ceac4520460d8a916576a278e8a382f1ed36d36008e2c8014e020efe5dc736127cfa939eeaf3135869d1f91b8e5926c1e2fd1388999df94d1c69d958a8d6088d329b0e367d59a8dcea879c8e917f591506769268fd18aec32bb06638d1bceb3dd78c286332f6e2b50156ea68d254cb56df018a37713e25c6f86f78229ba4d94c36a8fe75865fb6b63f691f777ad982f455ff2d232ede0c6d583d05abe8fc12fad19ef65c49cf8b3f73216ef64d56c2e25c2e2c32fdbc984ebef6464e2e84e4677b24fdec94a57f1bf8acddf694a7f61ec40a81ed8b2df29eb7b0ea401897030248959b7fb0cfb3d35da751e4735d044274d0689c3cee08d4f045312802c2d1f83baad92c4a3e7d8ffc76bb1ff87316659c6c2e7ca8a263a5315860e722376b0dddb684aa67d1ce5c1eb4f71a256c3cbf6cb4c8805e9d0c701e68859567aaf3ed11530d5b045a818548d1de7a9ac44bcee23d101af0d3b8ed4accf2451d849e202e06814499de3f9c58919d3280199f791dcc3617bf6c7ac61f4fc1b331205a06356b48913d882b5e1cd7102c5cdd323587c90dd844f7b83fb0433b1b2057b39cb595c2ddeaa21cbfe04b61332d7dfacc9d4d1cd9a6ed65fb9597f0eeb79b24b9eee38e776c0c60ec12dae9fa9777e87c9ecba05ac93baefed23816dbb4f524d9bade37be776ec035b9c134c17ec7ea6e838c6cc9fa86e6ba6c3af63b302475f5b1fe1b2ce578c915ba8821f81dce0fac84da68e223745ee2f41eef3cbf85fc965ed91d882dacb9f295473584e380021c00a46036ea7a61bc53a3b8fe798f3710c718bc36ce394f53bdcb629caa141d2a88fe2bdec681c87f6d009d757fc4c99bd2f1af3e77058c763ce2a5c35d8db755d67dd85e61a4133d064617f48ea2befbbd0b4bbe3033715b5c163713455c25e5d093b45768922764044d7ae4e44cc24a6619e564a8eec3398fb48511b48622a1647ac8c45f1be36a87bb9b9d8191392547ad098aaae00f118d448e290e96326b5e555461540c2c047f5f077bf364fdef55366cee595995d1791f83eb7569a4cce90608495b60ffea4377b629f5fbe92d67a2f837ca656c21f33c28fa0b2e027f0c7dfe22e4aa9ac9f4c657d2e7b9c6ef505dad9b36c68bb17a863cd69da0582612f250bb282c7eea0b1c90919b1fd1894031c4404b152b48d96b2e539d2e4abd8dfa565bab6f78643585ce823de12ff70a7b02a807cedcf9dc2a8bf04f597a0fe12e7fd256274f96c6f89a89f8abb0bfeeb9c70f8a50078be4a0c8700dcbd6d4171110e7e3ad9f98740c897d29da58d17da031e66ef9b29cff81b3c42c9f49b4b0b50aaf31f437596aff80b657aa2964b6767ec1ab6a6686b9c03e16950e5bab33a097c42644feda7b5396bd9585ef534592cb11ce1d96ee4d29835dd238b0111479f8bb5e96081dc5188c6cede50366fe87548d92b58481cda3996d3659e616fb63b5353943d2b96bfc52684ad0e89318563d65f53768773659c97d101ce605b41d476c66c929c6735fef9b69b4cc6d2e1d0c6f36cb4eae5cad4534b8964ece93d225399f9f96b7999584f917bfd614b787c91fb8d97796bd8cf8e213edada14b5e59851c1e9122366237ba432c5a1ec0c47a3edab2cf75aa3c9f5da1b3db6862360fe359abffcce97c547948f63841d6298e85d1e1f8d38484f571b3b034d510bcad5d36f3c7a7f5d9ce3847dfa9d2b9339742873d889451df7f6261476b1234ce60893361f813602ada731e8fc3504fd6f1e57678dd897d0105bfb139967723496da78ce4b6d3c073d16b19d23d95f4ee79ab95ee244b1afe71c30e2a3f47b6e6b6bd496436d04d6a6c215f5177fdba135ee6d51136c488e192c135538a63bc6cc6d07afab93ba78ed23565e992d92d08b979a0254c7d21a898edd85d9759c799623d9f687fb6f3788231bb6f2425e5f41700b90223f202cebcce0507ca8703bd515f07cdc97698319a9f3f2391643f9fd69b1362e264593b2090d2a309f4f825ec188f70f09d07247ddd2c68b0950e1fb1d5228fdf9efa73f93357ba1a45324d7b1c9e6ca6cc560dd0b54c5090b89c87483855d252a6b9f125c1953d403c190916aaa4a6fa68d7bfba122cc33cac690107b4a7f8c1335692361a512d03fa7983caf90343061ea1e94a14f2373f1596698f9495ffa7e781358c6d20a2f06d7933a29a35ffb197c7eb9db5f69e3c5300b6a146629cc7e3acc9eacde0be1368a08ff36b46669fc455a2e43cf3fdb8d111ace37eac80c4cd9df8e3c4d7e72b460c4988a252ffa7f0d99df66dbd960281cb28da931969daf81c37722e1591064849f0182e51e63a58dffcd82325310fc6920781eff4eb1aeb5d1b33929dbeaf602d292c86e328af7544ef3e04fe40709a09626696d6d8086f3ad0ab703047ba1ec6ebe0df332560617815ea67c827a35f823504f2877bd296dbc18f56a90a21e45bd2f40bdcccabdaa3d11e192bb4a2ab67c0f477de4a0988d40b07f7a50df7495c9aa43cea86792480d03b94ea23a2077f4a9b01afd3f51a895626b51a594ac04cccf40d8721799d2c68b11960154884985989f29c42c5ac017a8d15f3eac0aff5615b8e675b0537de412f2775283e7e3147e58159e8e9def35abdbeeacfefbac7af3cc1c5da8121ff447fdde60c4b5c64cbf39660eaadaa69d2f9f7317396b067161fba0d11881de78083a8fc57dc8a2e10a617e4ed2234e0c388032377265b7a89cd43c554d6236e85cbfe4104307bbd910b1118b537cbdbc635cf2b02f779e5f46a075e1dce5db3eebfe11d12259b726e7383252e198533a27f73c0d18a95a7b44f59bd23c674d11926f7cf0476a6560b0d2da603bb32e9b8c7fadb90e8eab98d4375d2130710ab4bc9a3b3523c87c774656f3d2047bec0ea72ae6f15873cf2c47edbf1055f219d302f1a08d199e197baa66fe541beff8ff858ae693d209a526fc1056b8dccba6b4f162424da0ac3065853f93153e59b71732c21f51376741b3ad86073b1ae6bd44db54853dc7607b3d04fb1980662623579e6a2d73a78ffbce107233c204639f612800c3eda565f1b53109ec9cd9e41a097063ffd73e9c5f29b765f9b4e7641017016cae4602b235e167802cfb1920fb3788bd4f41f627806c6eed7e86c431cfe95d49eaf866dcd363eeb380233e2779bc3ac8865610be9187f350e423de89fffc5c4e902d754e2c6b9bfa2652df44ea9b58e89b784095cff44a243d54906e2e7cf30282315b30063bb6fa23025e544ba9c4b2b60b8944b6fa851057f4751d215ffa35c5b7296df88fa00d4f57f265c4a0ae705055b60badd5f77585f33ec322e6f06c9741cc09be008eff7c80e1610d32424da8a60b58a8f2770cff65e1e121777d8001dc57125114617e02c2bc135e46a2302f16de35d65a133896d8c2daca1cef7826c358aa2911394f57b86ad3ce07b843aeb940de8437d8fe5475b74e5769058698f29027fce859cdd364f369f0175c867f411e00b9afa0b07816424610d86f04c0f2d00e656d17022047492c4a625d99c44a17e81510500400b5fb8bae924122fbef87884dbb54b79e798e298332f612481100f2fa2feab8ef3fdbf5b539eeedba6ccf57c71da70b0fcfdc85499d4c3be4397076a7482ae88458fffd6c37a6a8ddc0ea1b9e24c118f798e8d96608821087b17cb61b48b2055b57aa6b034eec6eb36e7795277b1c8d591d77bc68fe629b0756179d401b3418c3939de75d637e1cca53537a3edad5e7bfc53eb6a95948b3ad6dd8f5f55fb634f96b569d446358631b236d3c59e9ed7e881eb2b935b14b782fd01479233d8ceea27923fd6ba290bc8fd846607898b744ca9ab1cbf04d11a46eebe2c1757e24b618fdc18f9fe377e65d456da4360fe4d9a270063897a8d9761e756cd3d30e85cfd9f97222ead2cd2f5732deff20c3ff88fdef135240438632009401b82e03905ba3575338ad88e61d13e8b9f4caa789024bc35ae6b7c17c9465ac546a77d624924bce1c0d43a330d7e478bb7bdaf51e1ee32d6f63b80283606f8db067d163ef25be1e453ea93e45506a8aad9d0665268e62fcfc50ff53398ae17baff6e40dcd535c28464b26c14a813dc64afe063037801b32d57b8eb987dcad7057e358c0425e3baf6cc26be00f41e178f55ff4edfd03542bb76f2850027cf63fa6b5b03cd3f28cddfdff64ba74f5e51ce9a11560958fb52cd4ad64833dfeefaf9206fe2fc6b6fffd8556af367e52b40b2dfc5918bebb585a41507975f4d0ca5e98eced0539f742ddf6ac65c5b18330ba606dc9afe56e11fac98f8a7e68915cad18f6027f17c9b999bd69067a7a6219f95313721c104e2e546c2fb4969eee542c73a32fcde0b898e3d88bd036d22b5357cf9c25d597ba67ae42db39732b58a1d0b1d21baec9a527b85ee6cca8664eb20308a63ac89d418ecf9d730066ce8fba0c9dcc3c6d392633427c5659ccededaffffcb23cc3376d6f92f959d1030f64cf911e587c3577c5f6f4e52e7b656a655babccb0343e73beb05c7c7bb9f497f8b15e5dfcde335fdac447abd757ddf12b536b69fdfa4fd9575876337d05aebe084adbc17f0f037fb34c25084d1fb736d58369f4af622c0d16cf7fd2235e0aba33c95e3216abece9ab1b06fe32cc5ef2ac305cea8695bde60764a2b29716bee364cf8fab2cad57c73242c70e739703db9b38d6ab634fa6b95e835d60e88e53b1b6966179eb73b7569ebdcd5ec7dbb2e393d1e1a56afb15db8fbefec3651723efe15f05d9f1950ab209f1457e475fbe8bb78ac3bf8abb72427ba193492117febbf243cb5c2c6d2fd41159439e856f7a56589986e122f3939cc7b3975c8c9f38ba165adb70b1f409bee032ab259e48f236fd804cc0af887039fcabbcda8e159d47b34a7e4daced22f95109765ea8e3f959ae3ca226487e558c899f394be64f0f7dd736cedd8926eee43a5621fce757f4c104e1d2f0c99b0ac2a5ed4dc8ad9d6744ffd2e6a3f7f7eb3fbfa2e75a79b6e19b995f9555f80af8fc798d9c06fa2b2eb7b63cd35f5626bea37b935b7f39a96c2b11741853dd98ea90b9acd4c277768065b8374a93a6f1eab9b45c8c50658557cbb515237b49b9e9dc7c2d2f710aea2585df1831fe004d2fa8985ee05a41a04f8a9acb7de29355185c526eb1f4b7bb370ac2ca14effc25a56cd3d30b6e07bb2082b47377f14aab0496b15a5a15649bf67255385ba468b8d4bde0d55fba6585e26f14377849390fb7f77f94ef7a93ef8a29fa2bda7d444d5674d3f4bd9b6065879748634e4ac73c46f58d1ca0f006b0c43298bb07ec2dcb0081e758a176c3705f6cf291749d3602780839c0be219181775c95bb2b77bf286dbc502453fdd224a0f1b774c484a5df4e5a800a61fe8e4298c2959b4a5e4c31cd687cf8dd1b21d001681629099a261ab1f202c74496c4d61c0b9c0dc05cc3d22379ba4d70099e6c823c8e706fe048f5860143c0dc57b9fbeadded1dc7f1ec1dc37c83e958d275da0877c741b67af7268ef04cf5aedc76acb4f1421ce1288e501cf9008e6c8263fc50e1766d2afd17c315a0aef4b1e3f9ef48f279389f3b73ac783349bc6c6cea6f46b19ae47d5791a706fb123efdb9e368f284fb9b57dd76564beb7242e56c951865eede7016e56e4015532b10de73c26db57607784e781fc80001723cb83b02190018a11864007f55f511f309eaa3bb2ff514a518f36fc198b3cb310738c06013a0094d65cbe8a396fe6c1f34f04daf9f3a52b60fbf655118984a35ca7456f714d003d829130393650357559cc01c779cf1c0f853ff9f7818f8bfbf7475cfb81c890aeac45054adfd0cc6a9dca5b2b4f14230aa7e69f05e0a46ff12302a58911f659f7a6be4e2d43b608adcde55d1661584be7b63faae6e7b97e34d51ad187138a60471ceeb836bb006b502b0a10a61aa10a60a61aa10a60a61aa10a60a61aa10a60a61aa10a60ae1af510817b3081f6666b018668d06608e20c06e706667733539ae69395668dd600b1fcb086dff72a6a6b466c2d8542f616ce03dc3dfb3c22d23dc0902036a94afa17c0de56b285f43f91acad750be86f23594afa17c0de56bbe99af29a5f73fcadbf41d24ca0c0e6e8e3df8b0b7a0ae08ab2b2a6ccce5ee66b9f22ee167b22563fe05fc101bda72dbb7d2c60b55c180dabe51dbb7f7dbbee5d6618a2b5abbc38d3c7995989f5ccf2ed682d6c5528fa3b2094ec037a2ff546f183804fc3de4ef99ea2dcb0b2ccb57b96f30924dba4e1be16a9c00f8eadb40c15699378cedcb1a2f060af8a5f17f2852fc4b90e2682d7e940619ed3499f1ce98acad71821775dc5f1b5e0fcb5c4914820e0887a6e23038488b11474c1880b9a6680be43a09fd72da4f6b6334816d2a4ea0b5717b57c3af89b1b800b99252316671f067d8f597876c2c6dbc10b2386a734b6d6edf6f739bacc177dbf57b2a5b2fb2ebdf5ed147686a2c6e568bc952372fa7890aeac43853ad95e04ca2030238322c53bb0535be06ef389ea54a20aa04a24a20aa04a24a20aa04a24a20aa04a24a20aa04a24aa06f56021590fa1f15bd4c67461300433477aad2bfa69fced446d6d2d38fd554e56cccf93aef6563d8da3d84b7550e08b52acff1948da16c0c6563281b43d918cac6503686b231948da16c0c6563be9b8d394fea7f988d59a8aebc436eebdad66bb66979a11dee6e5e2dd35abe8f9d79a36eccd670fc256c0dc4a107d8da2dacdef12c2f408eb23594ada16c0d656b285b43d91acad650b686b23594ada16ccd37b3356f90fc1f656f9cb9a6708c3eee735d4573b48351ec02c1ab0622c08cccd2b5bdc9e50cceb91a315b7377115b03305bc3805b8ee1210b059e6a6ba8b6866a6ba8b6866a6ba8b6866a6ba8b6866a6ba8b6866a6bbe5b5b7396d03fcfcc48cd0649376f80fe9a780b27a9d9739e7d8b283cf402b966e4d9579f686e6b811e85d540e15cc476425de1bc4ff036b6bdf022e626cc333480ff199108f87207e3b2c60bbdf5c097e6578f5ef431bf977e2bd45bef9fe1ad97acc1146b10ec850453447965cefc89ae70cc15b1c1f12737ae152e6d23b800234e4ac758c10942395870370012b010ee59e61608022f5439e1eeeb5d7b93ae338db04cedee8e791b2c58962f078bd2c60bc1824c1e450b8a16ef448b93d598410d51f0868acc18ae3333f6feefeea0b15215e03cdb8d196acb7b5394775d057868fc12f686f50da6640c57f6b4f184979a9d87614b1eca8ff260b403bd3e0346dde168f3d494b03fb0af2ba62f8bd39d36eef9086ee7cf767d8beb2351608faee3fc5fa1247253a460b16e6ba642796fec008360e8209b014f0f898fb1633df813c9ed4d916d3292683a66b3315561cf31d8a789290a4b4dc13ec8fdb50ee595d4ee3806db5823afe748ed1ea38efbc0d83516c6de9fe0f1488fce0a8f11b9ad406af51cc3d31cc36eb40cafb336ec3f1d87bcd63022b73b8e06e5eab35db7fb234194f03571bad0e074a4e3e783d335125f78e941c5fd058835f3d79bccb6693313e4b6421cdb21497f64838da670734c0d1ebf97f8dd49a2b397446e6d92b96bcdadc166a2b2f2ce70e595d96cec4db1c598e3a749876d38c8ed2f906b78c5cf276d2e1bf7748d73ba6922b7ef2adb35822130eaf979406e6d62b0f24c6f367cc4f698439f8e875c61a78d1c77a8b418154e1f10e418557156f87d2911352c393816d73690dae654c7e27ed79868aeb093da7d5f1b34baa6d2710c977330f52d3db65e0603f21e4dfcecbae8787aabef1baebcd74521d01efcc9d30bee7bbb3641fe7a77f878bdddd39bdc609182bf0a2fda3e8f8bc7fb67f58dc818f006d68680bb67d9fb2abc151896afddb135f0f5b476d275da0814206420bc80d66698f2c818671bff7ff6ceb62b6d248ae39f289c99cc4c9ede692b41ab74a59260dee5a90699241c12503967bffb9ec812ac25c344d3da5deecb5d981beae1fe98fbbf4f5be38d3f9f142663c0648cf693317e76c6c64d743c1e9ccec38c5f04333c0f320779932bedfcf335bdfa74727ffef9eeceb74d1c66575d1561a67e984cb35849629f9789122671387ba944082173e8707d65d765f295c462ccc2b4671064224a29a42b215d09e94a485742ba12d29590ae847425a42b215d09e9ca0f4e571ebaf2efcf5c1e2ec3c4c9ad9acc83745cfab6930483517ee90e73cf1d2e3aec387bfef508f9b228e3857c90d370a8d6534c99e0065b8c582aed519d610369c884e806a21b886e20ba81e806a21b886e20ba81e806a21b886e3e3aba69b8ebbf39aac1c16034af533c1c651dd65365d50f197f5216318ffd2256bee78b6a8155a444f1777fc94b89d846ce846ce69828045585579858c4e821847503ab1afd80c2abcd837726183355848d8379630d517aa0ecaad934648d216bdc65d658ce391b33c94b6f92a071ea149edb5f7be37e5a15f38c07172bef6cc4bdb48f8341559073d295b89267f1eea32ef2b42d8b24cecb8348d56f9061a9b4da47a5630d9bc460eaef0751fde817bc20181bf4f03e2a0d51828428121a0718018cba84918477be974467dd91883fd5839ccb5c596f3f79218521f1e19a4148cc20aa205295d1316621bdc7cccaa34dd5fcfd0caa1ffd021326253a925930c50cf1f24ca1f1660621601030e80d0c12bbe60e40d1e4340bd3feccbb7906d02a481f99b3fd7fce68e5f28b6a376f12aacefa1774c1d5afbc8c21c5d4d977a2468da87c6e73dd2137c8b4f0f3fa4d460da263627e40dc553f7a67849a9a4924d66f6a8818e2f59b42e3cda8d10135809af6a8d9e78fd22ad04fbb3483aa37221be6beeba1c9b7b02bce541196045c766fdb1205ebc7d16fab0b892234de48140c4401a2b427cace099b1b6ec34a9d39e96cead86299297ea14471340dfd328e143f4aa79904304407b708d1d9c14b09bbc1a45a7c498d1ec2885264ea1fb06077fbe49d0d8699a1aa326ab04a4d214144b61b01a233000800a43540444eb9ff66526ff01e57ddb0260eb2ebaae3761d4f86c8739156bfeeee5ebf985c699eedcc7c779844b633bb74fbb3c0e5cb4b775844d5b9c9f9b4233c15a1cf63659afa77b1325f72fef2aa25c493e8e0164f8cc854e5a916a216453d44986a52063d47d073043d47d073043d47d073043d47d073043d47d073043d471fde7324baef4b6bb23f56e60d86f7b7ee23bf747979eb46fc72728a03fbb1cb91ef9b0f9dfa55afd12a5e94d3d06f1be1341faea39c033a2e539076839185754b253da26bba8a356afc7e11a67ef4ce88a1eb54a387755c0de90766b9088d37ca300c745cd071dbebb8875cf3bd40c2c973e54c35442cab4a87fb45870d909b0f9fc5e543be98b5a4d19e533586909cd842b145cc6a7b38a31a2606882d20b680d802620b882d20b680d802620b882d20b680d8f247882d7b2efbef0d6c188f06d12a4ccbee55962c8fe242f1b34899e751d136ae693c2cabb2fc5f26e68a6b5d84c641650195a57b95a5d935df0da395673beb4bb7bff43ee187401dad3b5759e6f1a2fa31f3b3306e49a48693358ee851e0088bbb1f85c69b7144014780a337e2a8c12fdfc9a2c9290f370bbb92201df22e39349bce7f18d457c820a8e1d0963ec43c8ed601f1654868bc913e0496efc0f29df6cb779afc783f7842b59c8767db6add8b73cfed17919d7cf66de7de2757d55e9ba7c81d770699349fb5b8e0bc7ef7162b9832991c12b1906a11dad3358d9906830c1264902083041924c82041060932489041820c1264902083f4d119a4d777fc5d9c124c9c75741625919df0f03eafb69217a13afef26a60d4ec79cb796ab26bd743dee4625cfdf7d7e9697aeb3eaebdebf9660bfa3affb27f23fa8fb3174215577b3cabacd3ddf960b8f027a7f56ecb6fffee1ab856137eab96bb5930d52e4c3ba9e6c38c7d17f38038e8ebf404773823a628fd3ba98869f7be3a56328e428231c402b0d078a304830d90604082692fc1ecbc5030be818c124fed4c59291ff27ac055d3cc3d213a640c1cdb484d2c648ad0388cd484919a5d8ed49471cff7ced43cef0a46dbb17b72438985586a676a0b287614c3c7c503ab04a61be1c4004e00a7f6706ae7a48d9337253035b20372e18476f474eb8ef8d7e9c9438751d6f69f2131be588a5912768e0b5806000b80f527014bc2433ba5d5e32fa095cccd500a5732868e8b57e2b21e8169b860c105eb575cb0645cb453603d7509ac2256d26914f158596dfe3c32706a385483081f87bc2d96a284c69b6184014600a3f6306a70c946f90987aa33f4ddd12cb09d3a9be6a4ce53a8f25530f356e18c2f43324a02fba1eb8ac365112b398fe2a26c099d7d878e0d3ae21d0b42e3001d804ed7d0d9e79242e8fc15d8231e925167d0d97c1be3b77e1d5f7f0da5fe0450260c65c250260c65c250260c65c250260c65c250260c65c2ffc132e1bfff010000ffff03006395c55a2d9b0300`)))
@@ -0,0 +1,40 @@
+#!/bin/bash
+#
+# Bumps the vendored OCM SDK to the given version and checks the OCM provider is still compatible with it.
+#
+
+set -euo pipefail
+
+SDK="github.com/openshift-online/ocm-sdk-go"
+PROVIDER="./pkg/common/providers/ocmprovider"
+
+if [ $# -ne 1 ]; then
+	echo "usage: $0 VERSION" >&2
+	exit 1
+fi
+VERSION="$1"
+
+cd "$(dirname "$0")/.."
+
+OLD_VERSION="$(go list -m -f '{{.Version}}' "$SDK")"
+echo "Bumping $SDK from $OLD_VERSION to $VERSION..."
+
+go get "$SDK@$VERSION"
+go mod tidy
+go mod vendor
+
+# Removed or renamed models fail to build.
+echo "Building against $SDK $VERSION..."
+go build -mod=vendor ./...
+go vet -mod=vendor "$PROVIDER/..."
+
+# Models that are written or read differently fail the compatibility tests.
+echo "Running the OCM SDK compatibility tests..."
+if ! go test -mod=vendor -count=1 -run 'SDKCompat' "$PROVIDER"; then
+	echo "The OCM provider isn't compatible with $SDK $VERSION." >&2
+	echo "Update the provider, or the recorded API documents in $PROVIDER/testdata/sdk-compat if the API changed." >&2
+	exit 1
+fi
+
+go test -mod=vendor -count=1 ./pkg/common/providers/...
+echo "$SDK bumped from $OLD_VERSION to $VERSION."